@@ -0,0 +1,245 @@
+// Package auditchain walks the per-org hash chain of audit_logs and signs
+// checkpoints of its head with ed25519, so a later rewrite of the whole chain
+// is caught as well as an edit to one row.
+package auditchain
+
+import (
+	"bufio"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mainPackage/model"
+	"mainPackage/store"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// page is how many rows one query of a walk reads.
+const page = 1000
+
+// DefaultFile is where checkpoints go when AUDIT_CHECKPOINT_FILE is unset.
+const DefaultFile = "logs/audit_checkpoints.jsonl"
+
+// ErrNoKey is returned by LoadKey when AUDIT_CHECKPOINT_KEY is unset.
+var ErrNoKey = errors.New("AUDIT_CHECKPOINT_KEY is not set")
+
+// Verify walks the org's chain from a checkpoint, or from the start when from
+// is the zero value, and stops at the first row that does not follow from the
+// one before it. Rows written before the chain existed are skipped; once the
+// chain has started, at the recorded cut-over or at the checkpoint, a row
+// without a hash is a break.
+func Verify(ctx context.Context, repo store.AuditRepository, orgId string, from model.AuditCheckpoint) (model.AuditChainReport, error) {
+	report := model.AuditChainReport{OrgID: orgId, Valid: true, LastID: from.LastID, LastHash: from.Hash}
+	prev := from.Hash
+	start := 0
+	if prev == "" {
+		var err error
+		if start, err = repo.ChainStart(ctx); err != nil {
+			return report, err
+		}
+	}
+	for {
+		list, err := repo.ListChain(ctx, orgId, report.LastID, page)
+		if err != nil {
+			return report, err
+		}
+		for i := range list {
+			e := &list[i]
+			var broken *model.AuditChainBreak
+			switch {
+			case e.Hash == "" && prev == "" && e.ID <= start:
+				report.Unchained++
+				report.LastID = e.ID
+				continue
+			case e.Hash == "":
+				broken = &model.AuditChainBreak{ID: e.ID, Reason: "row has no hash"}
+			case e.PrevHash != prev:
+				broken = &model.AuditChainBreak{ID: e.ID, Reason: "previous row was changed, removed or reordered", Expected: prev, Found: e.PrevHash}
+			case e.ChainHash() != e.Hash:
+				broken = &model.AuditChainBreak{ID: e.ID, Reason: "row content was changed", Expected: e.ChainHash(), Found: e.Hash}
+			}
+			if broken != nil {
+				report.Valid = false
+				report.BrokenAt = broken
+				return report, nil
+			}
+			prev = e.Hash
+			report.Checked++
+			report.LastID = e.ID
+			report.LastHash = e.Hash
+		}
+		if len(list) < page {
+			return report, nil
+		}
+	}
+}
+
+// File returns the checkpoint file path from AUDIT_CHECKPOINT_FILE.
+func File() string {
+	if path := strings.TrimSpace(os.Getenv("AUDIT_CHECKPOINT_FILE")); path != "" {
+		return path
+	}
+	return DefaultFile
+}
+
+// LoadKey reads the signing key from AUDIT_CHECKPOINT_KEY, the base64 of a
+// 32-byte ed25519 seed as printed by NewKey.
+func LoadKey() (ed25519.PrivateKey, error) {
+	raw := strings.TrimSpace(os.Getenv("AUDIT_CHECKPOINT_KEY"))
+	if raw == "" {
+		return nil, ErrNoKey
+	}
+	seed, err := base64.StdEncoding.DecodeString(raw)
+	if err != nil || len(seed) != ed25519.SeedSize {
+		return nil, fmt.Errorf("AUDIT_CHECKPOINT_KEY must be the base64 of a %d-byte seed", ed25519.SeedSize)
+	}
+	return ed25519.NewKeyFromSeed(seed), nil
+}
+
+// NewKey returns a fresh seed for AUDIT_CHECKPOINT_KEY and the base64 public
+// key that checks its signatures.
+func NewKey() (string, string, error) {
+	pub, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return "", "", err
+	}
+	return base64.StdEncoding.EncodeToString(key.Seed()), base64.StdEncoding.EncodeToString(pub), nil
+}
+
+func signedBytes(cp model.AuditCheckpoint) []byte {
+	cp.Signature = ""
+	raw, _ := json.Marshal(cp)
+	return raw
+}
+
+// Sign returns cp with its signature set.
+func Sign(key ed25519.PrivateKey, cp model.AuditCheckpoint) model.AuditCheckpoint {
+	cp.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(key, signedBytes(cp)))
+	return cp
+}
+
+// CheckSignature reports whether cp was signed by the key of pub.
+func CheckSignature(pub ed25519.PublicKey, cp model.AuditCheckpoint) bool {
+	sig, err := base64.StdEncoding.DecodeString(cp.Signature)
+	return err == nil && ed25519.Verify(pub, signedBytes(cp), sig)
+}
+
+// Checkpoint verifies every org's chain from its entry in last and returns a
+// signed checkpoint for each org whose chain is intact and has grown; last is
+// moved on to them. Orgs whose chain is broken are returned in broken and get
+// no checkpoint.
+func Checkpoint(ctx context.Context, repo store.AuditRepository, key ed25519.PrivateKey, last map[string]model.AuditCheckpoint) ([]model.AuditCheckpoint, []model.AuditChainReport, error) {
+	orgs, err := repo.ChainOrgs(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	var signed []model.AuditCheckpoint
+	var broken []model.AuditChainReport
+	now := time.Now().UTC()
+	for _, orgId := range orgs {
+		report, err := Verify(ctx, repo, orgId, last[orgId])
+		if err != nil {
+			return signed, broken, err
+		}
+		if !report.Valid {
+			broken = append(broken, report)
+			continue
+		}
+		if report.LastHash == "" || report.LastID == last[orgId].LastID {
+			continue
+		}
+		cp := Sign(key, model.AuditCheckpoint{OrgID: orgId, LastID: report.LastID, Hash: report.LastHash, CreatedAt: now})
+		signed = append(signed, cp)
+		last[orgId] = cp
+	}
+	return signed, broken, nil
+}
+
+// Append adds checkpoints to the file at path, one JSON object per line.
+func Append(path string, cps []model.AuditCheckpoint) error {
+	if len(cps) == 0 {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(f)
+	for _, cp := range cps {
+		if err := enc.Encode(cp); err != nil {
+			f.Close()
+			return err
+		}
+	}
+	return f.Close()
+}
+
+// ReadFile returns the checkpoints in the file at path, oldest first. A
+// missing file holds none.
+func ReadFile(path string) ([]model.AuditCheckpoint, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var cps []model.AuditCheckpoint
+	sc := bufio.NewScanner(f)
+	for line := 1; sc.Scan(); line++ {
+		if strings.TrimSpace(sc.Text()) == "" {
+			continue
+		}
+		var cp model.AuditCheckpoint
+		if err := json.Unmarshal(sc.Bytes(), &cp); err != nil {
+			return nil, fmt.Errorf("%s line %d: %w", path, line, err)
+		}
+		cps = append(cps, cp)
+	}
+	return cps, sc.Err()
+}
+
+// Latest returns the newest checkpoint per org among those signed by pub.
+func Latest(pub ed25519.PublicKey, cps []model.AuditCheckpoint) map[string]model.AuditCheckpoint {
+	last := make(map[string]model.AuditCheckpoint)
+	for _, cp := range cps {
+		if CheckSignature(pub, cp) && cp.LastID > last[cp.OrgID].LastID {
+			last[cp.OrgID] = cp
+		}
+	}
+	return last
+}
+
+// CheckAgainst confirms that the row each checkpoint names still carries the
+// hash it was signed with. It returns a description of each checkpoint that
+// fails, including those with a bad signature.
+func CheckAgainst(ctx context.Context, repo store.AuditRepository, pub ed25519.PublicKey, cps []model.AuditCheckpoint) ([]string, error) {
+	var problems []string
+	for _, cp := range cps {
+		if !CheckSignature(pub, cp) {
+			problems = append(problems, fmt.Sprintf("org %q id %d: bad signature", cp.OrgID, cp.LastID))
+			continue
+		}
+		list, err := repo.ListChain(ctx, cp.OrgID, cp.LastID-1, 1)
+		if err != nil {
+			return problems, err
+		}
+		switch {
+		case len(list) == 0 || list[0].ID != cp.LastID:
+			problems = append(problems, fmt.Sprintf("org %q id %d: row is missing", cp.OrgID, cp.LastID))
+		case list[0].Hash != cp.Hash:
+			problems = append(problems, fmt.Sprintf("org %q id %d: hash %s does not match checkpoint %s", cp.OrgID, cp.LastID, list[0].Hash, cp.Hash))
+		}
+	}
+	return problems, nil
+}
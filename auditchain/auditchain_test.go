@@ -0,0 +1,193 @@
+package auditchain
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"mainPackage/model"
+	"mainPackage/store"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// tampered serves the chain of repo with fn applied to every row it lists.
+type tampered struct {
+	store.AuditRepository
+	fn func(e *model.AuditLog)
+}
+
+func (t tampered) ListChain(ctx context.Context, orgId string, afterID, limit int) ([]model.AuditLog, error) {
+	list, err := t.AuditRepository.ListChain(ctx, orgId, afterID, limit)
+	for i := range list {
+		t.fn(&list[i])
+	}
+	return list, err
+}
+
+func auditRepo(t *testing.T, orgs ...string) store.AuditRepository {
+	t.Helper()
+	m := store.NewMemory()
+	var entries []model.AuditLog
+	for _, org := range orgs {
+		for _, action := range []string{"create", "update", "delete"} {
+			entries = append(entries, model.AuditLog{OrgID: org, Username: "u", Action: action, CreatedAt: time.Now()})
+		}
+	}
+	if err := m.Audit.Insert(context.Background(), entries); err != nil {
+		t.Fatal(err)
+	}
+	return m.Audit
+}
+
+func TestVerify(t *testing.T) {
+	ctx := context.Background()
+	repo := auditRepo(t, "o1")
+
+	report, err := Verify(ctx, repo, "o1", model.AuditCheckpoint{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.Valid || report.Checked != 3 {
+		t.Fatalf("intact chain = %+v, want 3 valid rows", report)
+	}
+
+	list, err := repo.ListChain(ctx, "o1", 0, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edited := tampered{repo, func(e *model.AuditLog) {
+		if e.ID == list[1].ID {
+			e.Action = "read"
+		}
+	}}
+	report, err = Verify(ctx, edited, "o1", model.AuditCheckpoint{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Valid || report.BrokenAt == nil || report.BrokenAt.ID != list[1].ID {
+		t.Errorf("edited row = %+v, want a break at %d", report, list[1].ID)
+	}
+
+	// Rewriting a row together with its hash still breaks the next link.
+	rewritten := tampered{repo, func(e *model.AuditLog) {
+		if e.ID == list[1].ID {
+			e.Action = "read"
+			e.Hash = e.ChainHash()
+		}
+	}}
+	report, err = Verify(ctx, rewritten, "o1", model.AuditCheckpoint{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Valid || report.BrokenAt == nil || report.BrokenAt.ID != list[2].ID {
+		t.Errorf("rewritten row = %+v, want a break at %d", report, list[2].ID)
+	}
+}
+
+func TestVerifyLegacyRows(t *testing.T) {
+	ctx := context.Background()
+	m := store.NewMemory()
+	m.AddLegacyAudit(model.AuditLog{OrgID: "o1", Action: "old"}, model.AuditLog{OrgID: "o1", Action: "old"})
+	if err := m.Audit.Insert(ctx, []model.AuditLog{{OrgID: "o1", Action: "new", CreatedAt: time.Now()}}); err != nil {
+		t.Fatal(err)
+	}
+	report, err := Verify(ctx, m.Audit, "o1", model.AuditCheckpoint{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.Valid || report.Unchained != 2 || report.Checked != 1 {
+		t.Errorf("legacy rows then a chained one = %+v, want 2 unchained and 1 checked", report)
+	}
+}
+
+func TestVerifyBlankedChain(t *testing.T) {
+	ctx := context.Background()
+	repo := auditRepo(t, "o1")
+	list, err := repo.ListChain(ctx, "o1", 0, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blanked := tampered{repo, func(e *model.AuditLog) {
+		e.PrevHash, e.Hash = "", ""
+	}}
+
+	report, err := Verify(ctx, blanked, "o1", model.AuditCheckpoint{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Valid || report.BrokenAt == nil || report.BrokenAt.ID != list[0].ID {
+		t.Errorf("blanked chain = %+v, want a break at the first row %d", report, list[0].ID)
+	}
+
+	// From a checkpoint the chain has started whatever the cut-over says.
+	from := model.AuditCheckpoint{OrgID: "o1", LastID: list[0].ID, Hash: list[0].Hash}
+	report, err = Verify(ctx, blanked, "o1", from)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Valid || report.BrokenAt == nil || report.BrokenAt.ID != list[1].ID {
+		t.Errorf("blanked chain after a checkpoint = %+v, want a break at %d", report, list[1].ID)
+	}
+}
+
+func TestCheckpointRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	repo := auditRepo(t, "o1", "o2")
+	pub, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	last := map[string]model.AuditCheckpoint{}
+	signed, broken, err := Checkpoint(ctx, repo, key, last)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(signed) != 2 || len(broken) != 0 {
+		t.Fatalf("Checkpoint = %d signed, %d broken, want 2 and 0", len(signed), len(broken))
+	}
+	if again, _, _ := Checkpoint(ctx, repo, key, last); len(again) != 0 {
+		t.Errorf("Checkpoint of unchanged chains signed %d more", len(again))
+	}
+
+	path := filepath.Join(t.TempDir(), "logs", "checkpoints.jsonl")
+	if err := Append(path, signed); err != nil {
+		t.Fatal(err)
+	}
+	cps, err := ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cps) != 2 {
+		t.Fatalf("ReadFile = %d checkpoints, want 2", len(cps))
+	}
+	for _, cp := range cps {
+		if !CheckSignature(pub, cp) {
+			t.Errorf("checkpoint %+v does not verify after a round trip", cp)
+		}
+	}
+	if latest := Latest(pub, cps); latest["o1"].LastID != signed[0].LastID {
+		t.Errorf("Latest = %+v", latest)
+	}
+	if problems, err := CheckAgainst(ctx, repo, pub, cps); err != nil || len(problems) != 0 {
+		t.Errorf("CheckAgainst = %v, %v, want no problems", problems, err)
+	}
+
+	forged := cps[0]
+	forged.Hash = "00"
+	problems, err := CheckAgainst(ctx, repo, pub, []model.AuditCheckpoint{forged, Sign(key, forged)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(problems) != 2 {
+		t.Errorf("CheckAgainst forged = %v, want a bad signature and a hash mismatch", problems)
+	}
+}
+
+func TestReadFileMissing(t *testing.T) {
+	cps, err := ReadFile(filepath.Join(t.TempDir(), "none.jsonl"))
+	if err != nil || cps != nil {
+		t.Errorf("ReadFile of a missing file = %v, %v, want nothing", cps, err)
+	}
+}
@@ -0,0 +1,73 @@
+// Package broker carries notification events between API instances, so
+// every instance can update the sockets it holds no matter which instance
+// the change happened on.
+package broker
+
+import (
+	"context"
+	"sync"
+)
+
+// Message kinds.
+const (
+	// KindNotification announces a committed notification by id.
+	KindNotification = "notification"
+	// KindUnread asks for a user's unread count to be pushed again.
+	KindUnread = "unread"
+)
+
+// Message is one event passed between instances.
+type Message struct {
+	Kind           string `json:"kind"`
+	NotificationID int64  `json:"notificationId,omitempty"`
+	OrgID          string `json:"orgId,omitempty"`
+	Username       string `json:"username,omitempty"`
+}
+
+// Broker publishes messages and hands every published message, this
+// instance's own included, to its subscribers.
+type Broker interface {
+	Publish(ctx context.Context, m Message) error
+	// Subscribe calls deliver for each message published from now on until
+	// ctx is done. deliver runs on the broker's goroutine and should not
+	// block.
+	Subscribe(ctx context.Context, deliver func(m Message)) error
+}
+
+// Local is a Broker for a single instance: Publish delivers straight to the
+// subscribers of the same process.
+type Local struct {
+	mu   sync.RWMutex
+	subs map[int]func(m Message)
+	next int
+}
+
+// NewLocal returns an in-process Broker.
+func NewLocal() *Local {
+	return &Local{subs: make(map[int]func(m Message))}
+}
+
+func (b *Local) Publish(ctx context.Context, m Message) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for _, deliver := range b.subs {
+		deliver(m)
+	}
+	return nil
+}
+
+// Subscribe registers deliver and blocks until ctx is done.
+func (b *Local) Subscribe(ctx context.Context, deliver func(m Message)) error {
+	b.mu.Lock()
+	key := b.next
+	b.next++
+	b.subs[key] = deliver
+	b.mu.Unlock()
+
+	<-ctx.Done()
+
+	b.mu.Lock()
+	delete(b.subs, key)
+	b.mu.Unlock()
+	return ctx.Err()
+}
@@ -0,0 +1,82 @@
+package broker
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+// Channel is the Postgres channel messages are sent on, as JSON payloads.
+const Channel = "cms_notifications"
+
+// Postgres is a Broker over LISTEN/NOTIFY. NOTIFY is transactional, so a
+// message published inside a transaction is only seen once it commits.
+type Postgres struct {
+	pool   *pgxpool.Pool
+	logger *zap.Logger
+	// retry is the pause before re-listening after the connection drops.
+	retry time.Duration
+}
+
+// NewPostgres returns a Broker that notifies through pool.
+func NewPostgres(pool *pgxpool.Pool, logger *zap.Logger) *Postgres {
+	return &Postgres{pool: pool, logger: logger, retry: 2 * time.Second}
+}
+
+func (b *Postgres) Publish(ctx context.Context, m Message) error {
+	payload, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	_, err = b.pool.Exec(ctx, `SELECT pg_notify($1, $2)`, Channel, string(payload))
+	return err
+}
+
+// Subscribe holds one pooled connection in LISTEN and re-listens after it is
+// lost, until ctx is done. Messages sent while the connection was down are
+// missed.
+func (b *Postgres) Subscribe(ctx context.Context, deliver func(m Message)) error {
+	for {
+		err := b.listen(ctx, deliver)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		b.logger.Warn("Notification listener lost, retrying", zap.Error(err), zap.Duration("in", b.retry))
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(b.retry):
+		}
+	}
+}
+
+func (b *Postgres) listen(ctx context.Context, deliver func(m Message)) error {
+	pooled, err := b.pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	// The connection is taken out of the pool and closed afterwards rather
+	// than returned, so no other caller inherits the subscription.
+	conn := pooled.Hijack()
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, `LISTEN `+Channel); err != nil {
+		return err
+	}
+	b.logger.Info("Listening for notifications", zap.String("channel", Channel))
+	for {
+		n, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		var m Message
+		if err := json.Unmarshal([]byte(n.Payload), &m); err != nil {
+			b.logger.Warn("Ignoring malformed notification payload", zap.String("payload", n.Payload))
+			continue
+		}
+		deliver(m)
+	}
+}
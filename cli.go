@@ -0,0 +1,225 @@
+package main
+
+import (
+	"context"
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"mainPackage/auditchain"
+	"mainPackage/credential"
+	"mainPackage/migrations"
+	"mainPackage/model"
+	"mainPackage/store"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// runCommand dispatches the administrative subcommands of the binary,
+// e.g. `cmsapi migrate up`. The HTTP server only starts when no
+// subcommand is given.
+func runCommand(ctx context.Context, pool *pgxpool.Pool, args []string) error {
+	switch args[0] {
+	case "migrate":
+		return runMigrate(ctx, pool, args[1:])
+	case "credentials":
+		return runCredentials(ctx, pool, args[1:])
+	case "audit":
+		return runAudit(ctx, pool, args[1:])
+	default:
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+}
+
+// runOfflineCommand runs the subcommands that need no database, so they work
+// where none is reachable. It reports whether args named one.
+func runOfflineCommand(args []string) (bool, error) {
+	if len(args) >= 2 && args[0] == "audit" && args[1] == "keygen" {
+		return true, auditKeygen()
+	}
+	return false, nil
+}
+
+// runMigrate handles `migrate up`, `migrate down [steps]` and `migrate status`.
+// down reverts one migration unless a step count (or "all") is given.
+func runMigrate(ctx context.Context, pool *pgxpool.Pool, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: migrate up|down [steps|all]|status")
+	}
+	switch args[0] {
+	case "up":
+		n, err := migrations.Up(ctx, pool)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("applied %d migration(s)\n", n)
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			if args[1] == "all" {
+				steps = int(^uint(0) >> 1)
+			} else {
+				v, err := strconv.Atoi(args[1])
+				if err != nil || v < 1 {
+					return fmt.Errorf("invalid step count %q", args[1])
+				}
+				steps = v
+			}
+		}
+		n, err := migrations.Down(ctx, pool, steps)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("reverted %d migration(s)\n", n)
+	case "status":
+		list, err := migrations.GetStatus(ctx, pool)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+		for _, s := range list {
+			state, at := "pending", ""
+			if s.Applied {
+				state, at = "applied", s.AppliedAt.Format("2006-01-02 15:04:05 MST")
+			}
+			fmt.Fprintf(w, "%04d\t%s\t%s\t%s\n", s.Version, s.Name, state, at)
+		}
+		return w.Flush()
+	default:
+		return fmt.Errorf("unknown migrate action %q", args[0])
+	}
+	return nil
+}
+
+// runCredentials handles `credentials report`, which lists the accounts whose
+// password is still stored with the legacy AES scheme. Those are upgraded on
+// their next successful login.
+func runCredentials(ctx context.Context, pool *pgxpool.Pool, args []string) error {
+	if len(args) == 0 || args[0] != "report" {
+		return fmt.Errorf("usage: credentials report")
+	}
+	list, err := store.NewPostgres(pool).Users.ListCredentials(ctx)
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ORG\tUSERNAME\tACTIVE")
+	legacy := 0
+	for _, u := range list {
+		if !credential.IsLegacy(u.Password) {
+			continue
+		}
+		legacy++
+		fmt.Fprintf(w, "%s\t%s\t%t\n", u.OrgID, u.Username, u.Active)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	fmt.Printf("%d of %d account(s) still use the legacy password scheme\n", legacy, len(list))
+	return nil
+}
+
+// runAudit handles `audit verify [orgId]`, `audit checkpoint` and
+// `audit keygen`; keygen runs before the database is opened, see
+// runOfflineCommand. verify walks every org's chain, or the given org's, and
+// when AUDIT_CHECKPOINT_KEY is set also checks the rows named in the
+// checkpoint file; it fails if anything does not match. checkpoint writes a
+// checkpoint now instead of waiting for the server's hourly one.
+func runAudit(ctx context.Context, pool *pgxpool.Pool, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: audit verify [orgId]|checkpoint|keygen")
+	}
+	repo := store.NewPostgres(pool).Audit
+	switch args[0] {
+	case "verify":
+		orgs := args[1:]
+		if len(orgs) == 0 {
+			var err error
+			if orgs, err = repo.ChainOrgs(ctx); err != nil {
+				return err
+			}
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ORG\tCHECKED\tUNCHAINED\tLAST ID\tSTATUS")
+		broken := 0
+		for _, orgId := range orgs {
+			report, err := auditchain.Verify(ctx, repo, orgId, model.AuditCheckpoint{})
+			if err != nil {
+				return err
+			}
+			state := "intact"
+			if !report.Valid {
+				broken++
+				state = fmt.Sprintf("broken at id %d: %s", report.BrokenAt.ID, report.BrokenAt.Reason)
+			}
+			fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%s\n", orgId, report.Checked, report.Unchained, report.LastID, state)
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+
+		key, err := auditchain.LoadKey()
+		if err != nil && !errors.Is(err, auditchain.ErrNoKey) {
+			return err
+		}
+		if key != nil {
+			path := auditchain.File()
+			cps, err := auditchain.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			problems, err := auditchain.CheckAgainst(ctx, repo, key.Public().(ed25519.PublicKey), cps)
+			if err != nil {
+				return err
+			}
+			for _, p := range problems {
+				fmt.Println("checkpoint:", p)
+			}
+			fmt.Printf("%d checkpoint(s) in %s, %d failed\n", len(cps), path, len(problems))
+			broken += len(problems)
+		}
+		if broken > 0 {
+			return fmt.Errorf("audit log failed verification")
+		}
+	case "checkpoint":
+		key, err := auditchain.LoadKey()
+		if err != nil {
+			return err
+		}
+		path := auditchain.File()
+		cps, err := auditchain.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		signed, broken, err := auditchain.Checkpoint(ctx, repo, key, auditchain.Latest(key.Public().(ed25519.PublicKey), cps))
+		if err != nil {
+			return err
+		}
+		for _, report := range broken {
+			fmt.Printf("org %s broken at id %d: %s\n", report.OrgID, report.BrokenAt.ID, report.BrokenAt.Reason)
+		}
+		if err := auditchain.Append(path, signed); err != nil {
+			return err
+		}
+		fmt.Printf("wrote %d checkpoint(s) to %s\n", len(signed), path)
+		if len(broken) > 0 {
+			return fmt.Errorf("%d audit chain(s) broken", len(broken))
+		}
+	default:
+		return fmt.Errorf("unknown audit action %q", args[0])
+	}
+	return nil
+}
+
+// auditKeygen prints a fresh checkpoint signing key and its public key.
+func auditKeygen() error {
+	seed, pub, err := auditchain.NewKey()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("AUDIT_CHECKPOINT_KEY=%s\npublic key: %s\n", seed, pub)
+	return nil
+}
@@ -5,31 +5,88 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
-	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"gopkg.in/natefinch/lumberjack.v2"
 )
 
-func ConnectDB() (*pgx.Conn, context.Context, context.CancelFunc) {
-	logger := GetLog()
+// DBConfig holds the connection pool settings read from the environment.
+type DBConfig struct {
+	ConnString        string
+	MaxConns          int32
+	MinConns          int32
+	MaxConnIdleTime   time.Duration
+	MaxConnLifetime   time.Duration
+	HealthCheckPeriod time.Duration
+	QueryTimeout      time.Duration
+}
+
+// GetDBConfig builds the pool settings from DB_* environment variables,
+// falling back to defaults for anything that is unset or invalid.
+func GetDBConfig() DBConfig {
 	var username string = os.Getenv("DB_USER")
 	var password string = os.Getenv("DB_PASS")
 	var host string = os.Getenv("DB_HOST")
 	var database string = os.Getenv("DB_NAME")
-	connStr := fmt.Sprintf("postgres://%s:%s@%s/%s", username, password, host, database)
-	logger.Debug("Connection String : " + connStr)
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	return DBConfig{
+		ConnString:        fmt.Sprintf("postgres://%s:%s@%s/%s", username, password, host, database),
+		MaxConns:          int32(getEnvInt("DB_MAX_CONNS", 20)),
+		MinConns:          int32(getEnvInt("DB_MIN_CONNS", 2)),
+		MaxConnIdleTime:   getEnvDuration("DB_MAX_CONN_IDLE_TIME", 5*time.Minute),
+		MaxConnLifetime:   getEnvDuration("DB_MAX_CONN_LIFETIME", time.Hour),
+		HealthCheckPeriod: getEnvDuration("DB_HEALTH_CHECK_PERIOD", time.Minute),
+		QueryTimeout:      getEnvDuration("DB_QUERY_TIMEOUT", 5*time.Second),
+	}
+}
+
+// NewPool opens the application-wide connection pool and verifies it with a ping.
+func NewPool(ctx context.Context, cfg DBConfig) (*pgxpool.Pool, error) {
+	logger := GetLog()
+	poolCfg, err := pgxpool.ParseConfig(cfg.ConnString)
+	if err != nil {
+		return nil, fmt.Errorf("parse database config: %w", err)
+	}
+	poolCfg.MaxConns = cfg.MaxConns
+	poolCfg.MinConns = cfg.MinConns
+	poolCfg.MaxConnIdleTime = cfg.MaxConnIdleTime
+	poolCfg.MaxConnLifetime = cfg.MaxConnLifetime
+	poolCfg.HealthCheckPeriod = cfg.HealthCheckPeriod
 
-	conn, err := pgx.Connect(ctx, connStr)
+	pool, err := pgxpool.NewWithConfig(ctx, poolCfg)
 	if err != nil {
-		cancel()
-		logger.DPanic("Unable to connect to database : " + err.Error())
+		return nil, fmt.Errorf("create database pool: %w", err)
+	}
+	if err := pool.Ping(ctx); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("ping database: %w", err)
+	}
+	logger.Info("Database pool ready",
+		zap.Int32("maxConns", cfg.MaxConns),
+		zap.Int32("minConns", cfg.MinConns),
+		zap.Duration("maxConnIdleTime", cfg.MaxConnIdleTime),
+		zap.Duration("maxConnLifetime", cfg.MaxConnLifetime),
+	)
+	return pool, nil
+}
+
+func getEnvInt(key string, def int) int {
+	v, err := strconv.Atoi(strings.TrimSpace(os.Getenv(key)))
+	if err != nil || v <= 0 {
+		return def
 	}
+	return v
+}
 
-	return conn, ctx, cancel
+func getEnvDuration(key string, def time.Duration) time.Duration {
+	v, err := time.ParseDuration(strings.TrimSpace(os.Getenv(key)))
+	if err != nil || v <= 0 {
+		return def
+	}
+	return v
 }
 
 func GetLog() *zap.Logger {
@@ -0,0 +1,121 @@
+// Package credential hashes user passwords with argon2id and checks them,
+// including the reversible AES-GCM values written by earlier releases.
+package credential
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// Parameters for new hashes. Stored hashes carry their own, so raising these
+// only affects hashes made afterwards; Verify asks for a re-hash of older
+// ones.
+const (
+	memory     = 64 * 1024
+	iterations = 3
+	threads    = 2
+	saltLen    = 16
+	keyLen     = 32
+)
+
+const prefix = "$argon2id$"
+
+// ErrMalformed is returned for a stored value that is neither an argon2id
+// hash nor a legacy ciphertext that decrypts.
+var ErrMalformed = errors.New("stored password is malformed")
+
+// Hash returns the PHC string for password, e.g.
+// $argon2id$v=19$m=65536,t=3,p=2$<salt>$<key>, with a fresh random salt.
+func Hash(password string) (string, error) {
+	salt := make([]byte, saltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	key := argon2.IDKey([]byte(password), salt, iterations, memory, threads, keyLen)
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s", prefix, argon2.Version, memory, iterations, threads,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+// IsLegacy reports whether stored predates argon2id hashing.
+func IsLegacy(stored string) bool {
+	return !strings.HasPrefix(stored, prefix)
+}
+
+// Verify checks password against stored. rehash is set when the password
+// matched but stored should be replaced by a fresh Hash: it is a legacy
+// ciphertext or was hashed with other parameters.
+func Verify(stored, password string) (ok, rehash bool, err error) {
+	if IsLegacy(stored) {
+		plain, err := decryptLegacy(stored)
+		if err != nil {
+			return false, false, fmt.Errorf("%w: %v", ErrMalformed, err)
+		}
+		ok = subtle.ConstantTimeCompare([]byte(plain), []byte(password)) == 1
+		return ok, ok, nil
+	}
+
+	var version int
+	var m uint32
+	var t uint32
+	var p uint8
+	parts := strings.Split(stored, "$")
+	if len(parts) != 6 {
+		return false, false, ErrMalformed
+	}
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return false, false, ErrMalformed
+	}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &m, &t, &p); err != nil {
+		return false, false, ErrMalformed
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return false, false, ErrMalformed
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return false, false, ErrMalformed
+	}
+	got := argon2.IDKey([]byte(password), salt, t, m, p, uint32(len(key)))
+	ok = subtle.ConstantTimeCompare(got, key) == 1
+	rehash = ok && (version != argon2.Version || m != memory || t != iterations || p != threads || len(key) != keyLen)
+	return ok, rehash, nil
+}
+
+// decryptLegacy opens a value written by the old AES-GCM helper, keyed on
+// the SHA-256 of SECRET_KEY with the nonce in front of the ciphertext.
+func decryptLegacy(ciphertextBase64 string) (string, error) {
+	key := sha256.Sum256([]byte(os.Getenv("SECRET_KEY")))
+	ciphertext, err := base64.StdEncoding.DecodeString(ciphertextBase64)
+	if err != nil {
+		return "", err
+	}
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return "", err
+	}
+	aesGCM, err := cipher.NewGCM(block)
+	if err != nil {
+		return "", err
+	}
+	nonceSize := aesGCM.NonceSize()
+	if len(ciphertext) < nonceSize {
+		return "", fmt.Errorf("ciphertext too short")
+	}
+	nonce, ciphertext := ciphertext[:nonceSize], ciphertext[nonceSize:]
+	plaintext, err := aesGCM.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
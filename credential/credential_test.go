@@ -0,0 +1,100 @@
+package credential
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"testing"
+
+	"golang.org/x/crypto/argon2"
+)
+
+func TestHashVerify(t *testing.T) {
+	stored, err := Hash("s3cret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if IsLegacy(stored) {
+		t.Fatalf("new hash %q reported as legacy", stored)
+	}
+	if again, _ := Hash("s3cret"); again == stored {
+		t.Error("two hashes of one password share a salt")
+	}
+
+	ok, rehash, err := Verify(stored, "s3cret")
+	if err != nil || !ok || rehash {
+		t.Errorf("Verify(right) = %v, %v, %v, want true, false, nil", ok, rehash, err)
+	}
+	ok, rehash, err = Verify(stored, "S3cret")
+	if err != nil || ok || rehash {
+		t.Errorf("Verify(wrong) = %v, %v, %v, want false, false, nil", ok, rehash, err)
+	}
+}
+
+func TestVerifyOlderParameters(t *testing.T) {
+	salt := []byte("0123456789abcdef")
+	key := argon2.IDKey([]byte("s3cret"), salt, 1, memory, threads, keyLen)
+	stored := fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s", prefix, argon2.Version, memory, 1, threads,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key))
+
+	ok, rehash, err := Verify(stored, "s3cret")
+	if err != nil || !ok || !rehash {
+		t.Errorf("Verify = %v, %v, %v, want a match that asks for a re-hash", ok, rehash, err)
+	}
+	if ok, rehash, _ := Verify(stored, "other"); ok || rehash {
+		t.Errorf("Verify(wrong) = %v, %v, want no match and no re-hash", ok, rehash)
+	}
+}
+
+// encryptLegacy writes password the way the old AES-GCM helper did.
+func encryptLegacy(t *testing.T, password string) string {
+	t.Helper()
+	key := sha256.Sum256([]byte("legacy-key"))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	return base64.StdEncoding.EncodeToString(gcm.Seal(nonce, nonce, []byte(password), nil))
+}
+
+func TestVerifyLegacy(t *testing.T) {
+	t.Setenv("SECRET_KEY", "legacy-key")
+	stored := encryptLegacy(t, "s3cret")
+	if !IsLegacy(stored) {
+		t.Fatal("legacy ciphertext not reported as legacy")
+	}
+	ok, rehash, err := Verify(stored, "s3cret")
+	if err != nil || !ok || !rehash {
+		t.Errorf("Verify(right) = %v, %v, %v, want a match that asks for a re-hash", ok, rehash, err)
+	}
+	ok, rehash, err = Verify(stored, "other")
+	if err != nil || ok || rehash {
+		t.Errorf("Verify(wrong) = %v, %v, %v, want false, false, nil", ok, rehash, err)
+	}
+
+	t.Setenv("SECRET_KEY", "another-key")
+	if _, _, err := Verify(stored, "s3cret"); !errors.Is(err, ErrMalformed) {
+		t.Errorf("Verify under another key: %v, want ErrMalformed", err)
+	}
+}
+
+func TestVerifyMalformed(t *testing.T) {
+	for _, stored := range []string{
+		"$argon2id$v=19$m=65536,t=3,p=2$onlysalt",
+		"$argon2id$v=x$m=65536,t=3,p=2$c2FsdA$a2V5",
+		"$argon2id$v=19$m=65536,t=3,p=2$!!!$a2V5",
+		"not base64 at all",
+	} {
+		if _, _, err := Verify(stored, "x"); !errors.Is(err, ErrMalformed) {
+			t.Errorf("Verify(%q): %v, want ErrMalformed", stored, err)
+		}
+	}
+}
@@ -80,7 +80,7 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "default": 10,
+                        "default": 1000,
                         "description": "length",
                         "name": "length",
                         "in": "query"
@@ -96,6 +96,253 @@ const docTemplate = `{
                 }
             }
         },
+        "/api/v1/audit_log/export": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Streams every row of the caller's org audit log matching the search filters, newest first, as CSV or NDJSON. limit is ignored. If the export fails part way it ends with a line starting \"# export failed\" (CSV) or an object with an error field (NDJSON).",
+                "produces": [
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "Audit Log"
+                ],
+                "summary": "Export Audit Log",
+                "operationId": "Export Audit Log",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "csv",
+                        "description": "csv or ndjson",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "createdAt from, RFC 3339, inclusive",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "createdAt to, RFC 3339, exclusive",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "username",
+                        "name": "username",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "mainFunc",
+                        "name": "mainFunc",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "subFunc, the route pattern",
+                        "name": "subFunc",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "HTTP method",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "response status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "id of the record acted on",
+                        "name": "entityId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "text to find in message, newData, oldData or resData",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "start after this search cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK - Request successful",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/audit_log/search": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Lists the caller's org audit log newest first, one page at a time. Pass the nextCursor of a page as cursor to get the next one.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Audit Log"
+                ],
+                "summary": "Search Audit Log",
+                "operationId": "Search Audit Log",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "createdAt from, RFC 3339, inclusive",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "createdAt to, RFC 3339, exclusive",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "username",
+                        "name": "username",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "mainFunc",
+                        "name": "mainFunc",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "subFunc, the route pattern",
+                        "name": "subFunc",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "HTTP method",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "response status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "id of the record acted on",
+                        "name": "entityId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "text to find in message, newData, oldData or resData",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "nextCursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK - Request successful",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.AuditLogPage"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/audit_log/verify": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Walks the caller's org audit chain and reports the first row whose hash or link to the row before it does not match.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Audit Log"
+                ],
+                "summary": "Verify Audit Log",
+                "operationId": "Verify Audit Log",
+                "responses": {
+                    "200": {
+                        "description": "OK - Request successful",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.AuditChainReport"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
         "/api/v1/audit_log/{username}": {
             "get": {
                 "security": [
@@ -121,6 +368,20 @@ const docTemplate = `{
                         "name": "username",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "start",
+                        "name": "start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1000,
+                        "description": "length",
+                        "name": "length",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -260,6 +521,45 @@ const docTemplate = `{
                 }
             }
         },
+        "/api/v1/auth/logout": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Revokes the session of the refresh token in the body, or of the access token when the body is empty.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Logout",
+                "operationId": "Logout",
+                "parameters": [
+                    {
+                        "description": "Body",
+                        "name": "Case",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/model.LogoutInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK - Request successful",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
         "/api/v1/auth/refresh": {
             "post": {
                 "security": [
@@ -267,6 +567,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
+                "description": "Exchanges a refresh token for a new access token and a new refresh token. Each refresh token can be used once; presenting one again revokes its whole session.",
                 "consumes": [
                     "application/json"
                 ],
@@ -404,6 +705,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
+                "description": "Creates a case in the organization's initial status, the target of its case status transition without fromStatusId, or \"new\" when it has none; 409 when that transition is inactive or when wfId names a workflow that was never published. With wfId the case opens on the start node of the workflow's published version; nodeId may be left out and is rejected when it names another node. The lifecycle dates and users are left for status changes to set.",
                 "consumes": [
                     "application/json"
                 ],
@@ -430,7 +732,19 @@ const docTemplate = `{
                     "200": {
                         "description": "OK - Request successful",
                         "schema": {
-                            "$ref": "#/definitions/model.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.CaseCreated"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
@@ -552,13 +866,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/case_history": {
-            "get": {
+        "/api/v1/case/{id}/advance": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
+                "description": "Moves the case along one outgoing edge of its current workflow node. The label is only needed when several edges leave the node. A node that carries a form is left only after the form was submitted on it; a concurrent move of the same case returns 409.",
                 "consumes": [
                     "application/json"
                 ],
@@ -568,36 +883,49 @@ const docTemplate = `{
                 "tags": [
                     "Cases"
                 ],
-                "summary": "Get Case History",
-                "operationId": "Get Case History",
+                "summary": "Advance Case",
+                "operationId": "Advance Case",
                 "parameters": [
                     {
                         "type": "integer",
-                        "default": 0,
-                        "description": "start",
-                        "name": "start",
-                        "in": "query"
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "length",
-                        "name": "length",
-                        "in": "query"
+                        "description": "Edge to follow",
+                        "name": "Body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/model.CaseAdvance"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK - Request successful",
                         "schema": {
-                            "$ref": "#/definitions/model.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.CaseAdvanced"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/api/v1/case_history/add": {
-            "post": {
+        "/api/v1/case/{id}/forms/{formId}/submissions": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -612,31 +940,61 @@ const docTemplate = `{
                 "tags": [
                     "Cases"
                 ],
-                "summary": "Create Case History",
-                "operationId": "Create Case History",
+                "summary": "List Case Form Submissions",
+                "operationId": "List Case Form Submissions",
                 "parameters": [
                     {
-                        "description": "Create Data",
-                        "name": "Body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.CaseHistoryInsert"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK - Request successful",
-                        "schema": {
-                            "$ref": "#/definitions/model.Response"
-                        }
+                        "type": "integer",
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "formId",
+                        "name": "formId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "nodeId",
+                        "name": "nodeId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "latest revision only",
+                        "name": "latest",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK - Request successful",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/model.FormSubmission"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
                     }
                 }
-            }
-        },
-        "/api/v1/case_history/{caseId}": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -651,29 +1009,78 @@ const docTemplate = `{
                 "tags": [
                     "Cases"
                 ],
-                "summary": "Get Case History By Case Id",
-                "operationId": "Get Case History By Case Id",
+                "summary": "Submit Case Form",
+                "operationId": "Submit Case Form",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
                     {
                         "type": "string",
-                        "description": "caseId",
-                        "name": "caseId",
+                        "description": "formId",
+                        "name": "formId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Submission, nodeId defaults to the current stage",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.FormSubmissionInsert"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK - Request successful",
                         "schema": {
-                            "$ref": "#/definitions/model.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.FormSubmission"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Validation failed",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/model.FormFieldError"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/api/v1/case_history/{id}": {
-            "delete": {
+        "/api/v1/case/{id}/stages": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -688,8 +1095,8 @@ const docTemplate = `{
                 "tags": [
                     "Cases"
                 ],
-                "summary": "Delete Case History",
-                "operationId": "Delete Case History",
+                "summary": "Get Case Stage History",
+                "operationId": "Get Case Stage History",
                 "parameters": [
                     {
                         "type": "integer",
@@ -703,17 +1110,35 @@ const docTemplate = `{
                     "200": {
                         "description": "OK - Request successful",
                         "schema": {
-                            "$ref": "#/definitions/model.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/model.CaseStageHistory"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
-            },
-            "patch": {
+            }
+        },
+        "/api/v1/case/{id}/status": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
+                "description": "Moves the case to statusId if the organization allows the change from its current status, and stamps the lifecycle date and user the transition names. caseDuration is recomputed, and the change is written to the case history and notified.",
                 "consumes": [
                     "application/json"
                 ],
@@ -723,8 +1148,8 @@ const docTemplate = `{
                 "tags": [
                     "Cases"
                 ],
-                "summary": "Update Case History",
-                "operationId": "Update Case History",
+                "summary": "Change Case Status",
+                "operationId": "Change Case Status",
                 "parameters": [
                     {
                         "type": "integer",
@@ -734,12 +1159,12 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Update data",
+                        "description": "Status to move to",
                         "name": "Body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.CaseHistoryUpdate"
+                            "$ref": "#/definitions/model.CaseStatusChange"
                         }
                     }
                 ],
@@ -747,13 +1172,25 @@ const docTemplate = `{
                     "200": {
                         "description": "OK - Request successful",
                         "schema": {
-                            "$ref": "#/definitions/model.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.CaseStatusChanged"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/api/v1/case_status": {
+        "/api/v1/case/{id}/submissions": {
             "get": {
                 "security": [
                     {
@@ -769,8 +1206,78 @@ const docTemplate = `{
                 "tags": [
                     "Cases"
                 ],
-                "summary": "Get Case Status",
-                "operationId": "Get Case Status",
+                "summary": "List Case Submissions",
+                "operationId": "List Case Submissions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "formId",
+                        "name": "formId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "nodeId",
+                        "name": "nodeId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "latest revision only",
+                        "name": "latest",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK - Request successful",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/model.FormSubmission"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/case_history": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cases"
+                ],
+                "summary": "Get Case History",
+                "operationId": "Get Case History",
                 "parameters": [
                     {
                         "type": "integer",
@@ -797,7 +1304,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/case_status/add": {
+        "/api/v1/case_history/add": {
             "post": {
                 "security": [
                     {
@@ -813,8 +1320,8 @@ const docTemplate = `{
                 "tags": [
                     "Cases"
                 ],
-                "summary": "Create Case Status",
-                "operationId": "Create Case Status",
+                "summary": "Create Case History",
+                "operationId": "Create Case History",
                 "parameters": [
                     {
                         "description": "Create Data",
@@ -822,7 +1329,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.CaseStatusInsert"
+                            "$ref": "#/definitions/model.CaseHistoryInsert"
                         }
                     }
                 ],
@@ -836,7 +1343,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/case_status/{id}": {
+        "/api/v1/case_history/{caseId}": {
             "get": {
                 "security": [
                     {
@@ -852,13 +1359,13 @@ const docTemplate = `{
                 "tags": [
                     "Cases"
                 ],
-                "summary": "Get Case Status by id",
-                "operationId": "Get Case Status by id",
+                "summary": "Get Case History By Case Id",
+                "operationId": "Get Case History By Case Id",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "id",
-                        "name": "id",
+                        "description": "caseId",
+                        "name": "caseId",
                         "in": "path",
                         "required": true
                     }
@@ -871,7 +1378,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/api/v1/case_history/{id}": {
             "delete": {
                 "security": [
                     {
@@ -887,8 +1396,8 @@ const docTemplate = `{
                 "tags": [
                     "Cases"
                 ],
-                "summary": "Delete Case Status",
-                "operationId": "Delete Case Status",
+                "summary": "Delete Case History",
+                "operationId": "Delete Case History",
                 "parameters": [
                     {
                         "type": "integer",
@@ -922,8 +1431,8 @@ const docTemplate = `{
                 "tags": [
                     "Cases"
                 ],
-                "summary": "Update Case Status",
-                "operationId": "Update Case Status",
+                "summary": "Update Case History",
+                "operationId": "Update Case History",
                 "parameters": [
                     {
                         "type": "integer",
@@ -938,7 +1447,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.CaseStatusUpdate"
+                            "$ref": "#/definitions/model.CaseHistoryUpdate"
                         }
                     }
                 ],
@@ -952,7 +1461,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/casesubtypes": {
+        "/api/v1/case_status": {
             "get": {
                 "security": [
                     {
@@ -968,8 +1477,8 @@ const docTemplate = `{
                 "tags": [
                     "Cases"
                 ],
-                "summary": "List CasesSubType",
-                "operationId": "ListCaseSubTypes",
+                "summary": "Get Case Status",
+                "operationId": "Get Case Status",
                 "parameters": [
                     {
                         "type": "integer",
@@ -996,7 +1505,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/casesubtypes/add": {
+        "/api/v1/case_status/add": {
             "post": {
                 "security": [
                     {
@@ -1012,8 +1521,8 @@ const docTemplate = `{
                 "tags": [
                     "Cases"
                 ],
-                "summary": "Create CaseSubType",
-                "operationId": "Create CaseSubType",
+                "summary": "Create Case Status",
+                "operationId": "Create Case Status",
                 "parameters": [
                     {
                         "description": "Create Data",
@@ -1021,7 +1530,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.CaseSubTypeInsert"
+                            "$ref": "#/definitions/model.CaseStatusInsert"
                         }
                     }
                 ],
@@ -1035,7 +1544,42 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/casesubtypes/{id}": {
+        "/api/v1/case_status/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cases"
+                ],
+                "summary": "Get Case Status by id",
+                "operationId": "Get Case Status by id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK - Request successful",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            },
             "delete": {
                 "security": [
                     {
@@ -1051,8 +1595,8 @@ const docTemplate = `{
                 "tags": [
                     "Cases"
                 ],
-                "summary": "Delete CaseSubType",
-                "operationId": "Delete CaseSubType",
+                "summary": "Delete Case Status",
+                "operationId": "Delete Case Status",
                 "parameters": [
                     {
                         "type": "integer",
@@ -1086,8 +1630,8 @@ const docTemplate = `{
                 "tags": [
                     "Cases"
                 ],
-                "summary": "Update CaseSubType",
-                "operationId": "Update CaseSubType",
+                "summary": "Update Case Status",
+                "operationId": "Update Case Status",
                 "parameters": [
                     {
                         "type": "integer",
@@ -1102,7 +1646,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.CaseSubTypeUpdate"
+                            "$ref": "#/definitions/model.CaseStatusUpdate"
                         }
                     }
                 ],
@@ -1116,13 +1660,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/casetypes": {
+        "/api/v1/case_status_transitions": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
+                "description": "Lists the status changes the organization allows on its cases.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1132,41 +1677,41 @@ const docTemplate = `{
                 "tags": [
                     "Cases"
                 ],
-                "summary": "List Cases",
-                "operationId": "ListCaseTypes",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "default": 0,
-                        "description": "start",
-                        "name": "start",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "length",
-                        "name": "length",
-                        "in": "query"
-                    }
-                ],
+                "summary": "List Case Status Transitions",
+                "operationId": "List Case Status Transitions",
                 "responses": {
                     "200": {
                         "description": "OK - Request successful",
                         "schema": {
-                            "$ref": "#/definitions/model.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/model.CaseStatusTransition"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/api/v1/casetypes/add": {
+        "/api/v1/case_status_transitions/add": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
+                "description": "Allows cases to move from one case_status to another. stamp names the lifecycle date and user the move sets: commanded, received, arrived or closed; reopened clears closedDate and userclose. Leaving fromStatusId empty makes toStatusId the status new cases start in; an organization has one such transition and it carries no stamp.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1176,16 +1721,16 @@ const docTemplate = `{
                 "tags": [
                     "Cases"
                 ],
-                "summary": "Create CaseType",
-                "operationId": "Create CaseType",
+                "summary": "Create Case Status Transition",
+                "operationId": "Create Case Status Transition",
                 "parameters": [
                     {
-                        "description": "Create Data",
+                        "description": "Transition to create",
                         "name": "Body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.CaseTypeInsert"
+                            "$ref": "#/definitions/model.CaseStatusTransitionInput"
                         }
                     }
                 ],
@@ -1199,8 +1744,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/casetypes/{id}": {
-            "delete": {
+        "/api/v1/case_status_transitions/{id}": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -1215,12 +1760,12 @@ const docTemplate = `{
                 "tags": [
                     "Cases"
                 ],
-                "summary": "Delete CaseType",
-                "operationId": "Delete CaseType",
+                "summary": "Get Case Status Transition",
+                "operationId": "Get Case Status Transition",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "id",
+                        "type": "string",
+                        "description": "transition id",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1230,12 +1775,24 @@ const docTemplate = `{
                     "200": {
                         "description": "OK - Request successful",
                         "schema": {
-                            "$ref": "#/definitions/model.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.CaseStatusTransition"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             },
-            "patch": {
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -1250,24 +1807,15 @@ const docTemplate = `{
                 "tags": [
                     "Cases"
                 ],
-                "summary": "Update CaseType",
-                "operationId": "Update CaseType",
+                "summary": "Delete Case Status Transition",
+                "operationId": "Delete Case Status Transition",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "id",
+                        "type": "string",
+                        "description": "transition id",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Update data",
-                        "name": "Body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.CaseTypeUpdate"
-                        }
                     }
                 ],
                 "responses": {
@@ -1278,10 +1826,8 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/casetypes_with_subtype": {
-            "get": {
+            },
+            "patch": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -1296,8 +1842,26 @@ const docTemplate = `{
                 "tags": [
                     "Cases"
                 ],
-                "summary": "List Cases Type with Sub type",
-                "operationId": "List Cases Type with Sub type",
+                "summary": "Update Case Status Transition",
+                "operationId": "Update Case Status Transition",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "transition id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Transition to update",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CaseStatusTransitionInput"
+                        }
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK - Request successful",
@@ -1308,7 +1872,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/commands": {
+        "/api/v1/casesubtypes": {
             "get": {
                 "security": [
                     {
@@ -1322,10 +1886,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Organization"
+                    "Cases"
                 ],
-                "summary": "Get Commands",
-                "operationId": "Get Commands",
+                "summary": "List CasesSubType",
+                "operationId": "ListCaseSubTypes",
                 "parameters": [
                     {
                         "type": "integer",
@@ -1352,7 +1916,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/commands/add": {
+        "/api/v1/casesubtypes/add": {
             "post": {
                 "security": [
                     {
@@ -1366,10 +1930,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Organization"
+                    "Cases"
                 ],
-                "summary": "Create Commands",
-                "operationId": "Create Commands",
+                "summary": "Create CaseSubType",
+                "operationId": "Create CaseSubType",
                 "parameters": [
                     {
                         "description": "Create Data",
@@ -1377,7 +1941,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.CommandInsert"
+                            "$ref": "#/definitions/model.CaseSubTypeInsert"
                         }
                     }
                 ],
@@ -1391,42 +1955,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/commands/{id}": {
-            "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Organization"
-                ],
-                "summary": "Get Commands by id",
-                "operationId": "Get Commands by id",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK - Request successful",
-                        "schema": {
-                            "$ref": "#/definitions/model.Response"
-                        }
-                    }
-                }
-            },
+        "/api/v1/casesubtypes/{id}": {
             "delete": {
                 "security": [
                     {
@@ -1440,10 +1969,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Organization"
+                    "Cases"
                 ],
-                "summary": "Delete Commands",
-                "operationId": "Delete Commands",
+                "summary": "Delete CaseSubType",
+                "operationId": "Delete CaseSubType",
                 "parameters": [
                     {
                         "type": "integer",
@@ -1475,10 +2004,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Organization"
+                    "Cases"
                 ],
-                "summary": "Update Commands",
-                "operationId": "Update Commands",
+                "summary": "Update CaseSubType",
+                "operationId": "Update CaseSubType",
                 "parameters": [
                     {
                         "type": "integer",
@@ -1493,7 +2022,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.CommandUpdate"
+                            "$ref": "#/definitions/model.CaseSubTypeUpdate"
                         }
                     }
                 ],
@@ -1507,7 +2036,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/customer": {
+        "/api/v1/casetypes": {
             "get": {
                 "security": [
                     {
@@ -1521,10 +2050,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Customer"
+                    "Cases"
                 ],
-                "summary": "Get Customer",
-                "operationId": "Get Customer",
+                "summary": "List Cases",
+                "operationId": "ListCaseTypes",
                 "parameters": [
                     {
                         "type": "integer",
@@ -1551,7 +2080,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/customer/add": {
+        "/api/v1/casetypes/add": {
             "post": {
                 "security": [
                     {
@@ -1565,18 +2094,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Customer"
+                    "Cases"
                 ],
-                "summary": "Create Customer",
-                "operationId": "Create Customer",
+                "summary": "Create CaseType",
+                "operationId": "Create CaseType",
                 "parameters": [
                     {
-                        "description": "Customer to be created",
-                        "name": "Case",
+                        "description": "Create Data",
+                        "name": "Body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.CustomerInsert"
+                            "$ref": "#/definitions/model.CaseTypeInsert"
                         }
                     }
                 ],
@@ -1590,8 +2119,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/customer/{id}": {
-            "get": {
+        "/api/v1/casetypes/{id}": {
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -1604,10 +2133,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Customer"
+                    "Cases"
                 ],
-                "summary": "Get Customer by Id",
-                "operationId": "Get Customer by Id",
+                "summary": "Delete CaseType",
+                "operationId": "Delete CaseType",
                 "parameters": [
                     {
                         "type": "integer",
@@ -1626,7 +2155,7 @@ const docTemplate = `{
                     }
                 }
             },
-            "delete": {
+            "patch": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -1639,10 +2168,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Customer"
+                    "Cases"
                 ],
-                "summary": "Delete Customer",
-                "operationId": "Delete Customer",
+                "summary": "Update CaseType",
+                "operationId": "Update CaseType",
                 "parameters": [
                     {
                         "type": "integer",
@@ -1650,6 +2179,15 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Update data",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CaseTypeUpdate"
+                        }
                     }
                 ],
                 "responses": {
@@ -1660,8 +2198,10 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
+            }
+        },
+        "/api/v1/casetypes_with_subtype": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -1674,28 +2214,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Customer"
-                ],
-                "summary": "Update Customer",
-                "operationId": "Update Customer",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Data Update",
-                        "name": "Body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.CustomerUpdate"
-                        }
-                    }
+                    "Cases"
                 ],
+                "summary": "List Cases Type with Sub type",
+                "operationId": "List Cases Type with Sub type",
                 "responses": {
                     "200": {
                         "description": "OK - Request successful",
@@ -1706,7 +2228,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/customer_contacts": {
+        "/api/v1/commands": {
             "get": {
                 "security": [
                     {
@@ -1720,10 +2242,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Customer"
+                    "Organization"
                 ],
-                "summary": "Get Customer Contact",
-                "operationId": "Get Customer Contact",
+                "summary": "Get Commands",
+                "operationId": "Get Commands",
                 "parameters": [
                     {
                         "type": "integer",
@@ -1750,7 +2272,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/customer_contacts/add": {
+        "/api/v1/commands/add": {
             "post": {
                 "security": [
                     {
@@ -1764,18 +2286,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Customer"
+                    "Organization"
                 ],
-                "summary": "Create Customer Contact",
-                "operationId": "Create Customer Contact",
+                "summary": "Create Commands",
+                "operationId": "Create Commands",
                 "parameters": [
                     {
-                        "description": "Data to be created",
+                        "description": "Create Data",
                         "name": "Body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.CustomerContactInsert"
+                            "$ref": "#/definitions/model.CommandInsert"
                         }
                     }
                 ],
@@ -1789,7 +2311,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/customer_contacts/{id}": {
+        "/api/v1/commands/{id}": {
             "get": {
                 "security": [
                     {
@@ -1803,13 +2325,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Customer"
+                    "Organization"
                 ],
-                "summary": "Get Customer Contact by Id",
-                "operationId": "Get Customer Contact by Id",
+                "summary": "Get Commands by id",
+                "operationId": "Get Commands by id",
                 "parameters": [
                     {
-                        "type": "integer",
+                        "type": "string",
                         "description": "id",
                         "name": "id",
                         "in": "path",
@@ -1838,10 +2360,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Customer"
+                    "Organization"
                 ],
-                "summary": "Delete Customer Contact",
-                "operationId": "Delete Customer Contact",
+                "summary": "Delete Commands",
+                "operationId": "Delete Commands",
                 "parameters": [
                     {
                         "type": "integer",
@@ -1873,10 +2395,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Customer"
+                    "Organization"
                 ],
-                "summary": "Update Customer Contact",
-                "operationId": "Update Customer Contact",
+                "summary": "Update Commands",
+                "operationId": "Update Commands",
                 "parameters": [
                     {
                         "type": "integer",
@@ -1886,12 +2408,12 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Data Update",
+                        "description": "Update data",
                         "name": "Body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.CustomerContactUpdate"
+                            "$ref": "#/definitions/model.CommandUpdate"
                         }
                     }
                 ],
@@ -1905,7 +2427,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/customer_with_socials": {
+        "/api/v1/customer": {
             "get": {
                 "security": [
                     {
@@ -1921,8 +2443,8 @@ const docTemplate = `{
                 "tags": [
                     "Customer"
                 ],
-                "summary": "Get Customer with Social",
-                "operationId": "Get Customer with Social",
+                "summary": "Get Customer",
+                "operationId": "Get Customer",
                 "parameters": [
                     {
                         "type": "integer",
@@ -1949,7 +2471,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/customer_with_socials/add": {
+        "/api/v1/customer/add": {
             "post": {
                 "security": [
                     {
@@ -1965,16 +2487,16 @@ const docTemplate = `{
                 "tags": [
                     "Customer"
                 ],
-                "summary": "Create Customer with Social",
-                "operationId": "Create Customer with Social",
+                "summary": "Create Customer",
+                "operationId": "Create Customer",
                 "parameters": [
                     {
-                        "description": "Data to be created",
-                        "name": "Body",
+                        "description": "Customer to be created",
+                        "name": "Case",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.CustomerSocialInsert"
+                            "$ref": "#/definitions/model.CustomerInsert"
                         }
                     }
                 ],
@@ -1988,7 +2510,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/customer_with_socials/{id}": {
+        "/api/v1/customer/{id}": {
             "get": {
                 "security": [
                     {
@@ -2004,8 +2526,8 @@ const docTemplate = `{
                 "tags": [
                     "Customer"
                 ],
-                "summary": "Get Customer with Social by Id",
-                "operationId": "Get Customer with Social by Id",
+                "summary": "Get Customer by Id",
+                "operationId": "Get Customer by Id",
                 "parameters": [
                     {
                         "type": "integer",
@@ -2039,8 +2561,8 @@ const docTemplate = `{
                 "tags": [
                     "Customer"
                 ],
-                "summary": "Delete Customer with Social",
-                "operationId": "Delete Customer with Social",
+                "summary": "Delete Customer",
+                "operationId": "Delete Customer",
                 "parameters": [
                     {
                         "type": "integer",
@@ -2074,8 +2596,8 @@ const docTemplate = `{
                 "tags": [
                     "Customer"
                 ],
-                "summary": "Update Customer with Social",
-                "operationId": "Update Customer with Social",
+                "summary": "Update Customer",
+                "operationId": "Update Customer",
                 "parameters": [
                     {
                         "type": "integer",
@@ -2090,38 +2612,10 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.CustomerSocialUpdate"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK - Request successful",
-                        "schema": {
-                            "$ref": "#/definitions/model.Response"
+                            "$ref": "#/definitions/model.CustomerUpdate"
                         }
                     }
-                }
-            }
-        },
-        "/api/v1/department_command_stations": {
-            "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
                 ],
-                "tags": [
-                    "Organization"
-                ],
-                "summary": "Get Stations Command Department",
-                "operationId": "Get Stations Command Department",
                 "responses": {
                     "200": {
                         "description": "OK - Request successful",
@@ -2132,7 +2626,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/departments": {
+        "/api/v1/customer_contacts": {
             "get": {
                 "security": [
                     {
@@ -2146,10 +2640,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Organization"
+                    "Customer"
                 ],
-                "summary": "Get Department",
-                "operationId": "Get Department",
+                "summary": "Get Customer Contact",
+                "operationId": "Get Customer Contact",
                 "parameters": [
                     {
                         "type": "integer",
@@ -2176,7 +2670,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/departments/add": {
+        "/api/v1/customer_contacts/add": {
             "post": {
                 "security": [
                     {
@@ -2190,18 +2684,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Organization"
+                    "Customer"
                 ],
-                "summary": "Create Department",
-                "operationId": "Create Department",
+                "summary": "Create Customer Contact",
+                "operationId": "Create Customer Contact",
                 "parameters": [
                     {
-                        "description": "Create Data",
+                        "description": "Data to be created",
                         "name": "Body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.DepartmentInsert"
+                            "$ref": "#/definitions/model.CustomerContactInsert"
                         }
                     }
                 ],
@@ -2215,7 +2709,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/departments/{id}": {
+        "/api/v1/customer_contacts/{id}": {
             "get": {
                 "security": [
                     {
@@ -2229,13 +2723,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Organization"
+                    "Customer"
                 ],
-                "summary": "Get Department by ID",
-                "operationId": "Get Department by ID",
+                "summary": "Get Customer Contact by Id",
+                "operationId": "Get Customer Contact by Id",
                 "parameters": [
                     {
-                        "type": "string",
+                        "type": "integer",
                         "description": "id",
                         "name": "id",
                         "in": "path",
@@ -2264,10 +2758,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Organization"
+                    "Customer"
                 ],
-                "summary": "Delete Department",
-                "operationId": "Delete Department",
+                "summary": "Delete Customer Contact",
+                "operationId": "Delete Customer Contact",
                 "parameters": [
                     {
                         "type": "integer",
@@ -2299,10 +2793,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Organization"
+                    "Customer"
                 ],
-                "summary": "Update Department",
-                "operationId": "Update Department",
+                "summary": "Update Customer Contact",
+                "operationId": "Update Customer Contact",
                 "parameters": [
                     {
                         "type": "integer",
@@ -2312,12 +2806,12 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Update data",
+                        "description": "Data Update",
                         "name": "Body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.DepartmentUpdate"
+                            "$ref": "#/definitions/model.CustomerContactUpdate"
                         }
                     }
                 ],
@@ -2331,7 +2825,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/devices": {
+        "/api/v1/customer_with_socials": {
             "get": {
                 "security": [
                     {
@@ -2345,10 +2839,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Device Iot"
+                    "Customer"
                 ],
-                "summary": "Get Device IoT",
-                "operationId": "Get Device IoT",
+                "summary": "Get Customer with Social",
+                "operationId": "Get Customer with Social",
                 "parameters": [
                     {
                         "type": "integer",
@@ -2375,44 +2869,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/devices/{id}": {
-            "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Device Iot"
-                ],
-                "summary": "Get Device IoT By ID",
-                "operationId": "Get Device IoT By ID",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK - Request successful",
-                        "schema": {
-                            "$ref": "#/definitions/model.Response"
-                        }
-                    }
-                }
-            }
-        },
-        "/api/v1/dispatch/event": {
+        "/api/v1/customer_with_socials/add": {
             "post": {
                 "security": [
                     {
@@ -2426,18 +2883,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Dispatch"
+                    "Customer"
                 ],
-                "summary": "Dispatch unit follow SOP",
-                "operationId": "updateUnit",
+                "summary": "Create Customer with Social",
+                "operationId": "Create Customer with Social",
                 "parameters": [
                     {
-                        "description": "Update unit event",
+                        "description": "Data to be created",
                         "name": "Body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.UpdateStageRequest"
+                            "$ref": "#/definitions/model.CustomerSocialInsert"
                         }
                     }
                 ],
@@ -2451,7 +2908,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/dispatch/{caseId}/SOP": {
+        "/api/v1/customer_with_socials/{id}": {
             "get": {
                 "security": [
                     {
@@ -2465,15 +2922,15 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Dispatch"
+                    "Customer"
                 ],
-                "summary": "Get SOP",
-                "operationId": "Case By CaseId",
+                "summary": "Get Customer with Social by Id",
+                "operationId": "Get Customer with Social by Id",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "caseId",
-                        "name": "caseId",
+                        "type": "integer",
+                        "description": "id",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -2486,10 +2943,8 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/dispatch/{caseId}/units": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -2502,15 +2957,15 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Dispatch"
+                    "Customer"
                 ],
-                "summary": "Get Unit",
-                "operationId": "CaseByCaseId",
+                "summary": "Delete Customer with Social",
+                "operationId": "Delete Customer with Social",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "caseId",
-                        "name": "caseId",
+                        "type": "integer",
+                        "description": "id",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -2523,10 +2978,8 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/forms": {
-            "get": {
+            },
+            "patch": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -2539,24 +2992,26 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Form and Workflow"
+                    "Customer"
                 ],
-                "summary": "Get Form",
-                "operationId": "Get Form",
+                "summary": "Update Customer with Social",
+                "operationId": "Update Customer with Social",
                 "parameters": [
                     {
-                        "type": "string",
+                        "type": "integer",
                         "description": "id",
                         "name": "id",
-                        "in": "query",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "version",
-                        "name": "version",
-                        "in": "query",
-                        "required": true
+                        "description": "Data Update",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CustomerSocialUpdate"
+                        }
                     }
                 ],
                 "responses": {
@@ -2567,8 +3022,10 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/api/v1/department_command_stations": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -2581,21 +3038,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Form and Workflow"
-                ],
-                "summary": "Create Form",
-                "operationId": "Create Form",
-                "parameters": [
-                    {
-                        "description": "Created Data",
-                        "name": "Case",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.FormInsert"
-                        }
-                    }
+                    "Organization"
                 ],
+                "summary": "Get Stations Command Department",
+                "operationId": "Get Stations Command Department",
                 "responses": {
                     "200": {
                         "description": "OK - Request successful",
@@ -2606,8 +3052,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/forms/active": {
-            "patch": {
+        "/api/v1/departments": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -2620,19 +3066,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Form and Workflow"
+                    "Organization"
                 ],
-                "summary": "Update Form Status",
-                "operationId": "Update Form Status",
+                "summary": "Get Department",
+                "operationId": "Get Department",
                 "parameters": [
                     {
-                        "description": "Update Data",
-                        "name": "Case",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.FormActive"
-                        }
+                        "type": "integer",
+                        "default": 0,
+                        "description": "start",
+                        "name": "start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "length",
+                        "name": "length",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -2645,7 +3096,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/forms/casesubtype": {
+        "/api/v1/departments/add": {
             "post": {
                 "security": [
                     {
@@ -2659,18 +3110,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Form and Workflow"
+                    "Organization"
                 ],
-                "summary": "Get Form by Casesubtype",
-                "operationId": "Get Form by Casesubtype",
+                "summary": "Create Department",
+                "operationId": "Create Department",
                 "parameters": [
                     {
-                        "description": "Data",
-                        "name": "Case",
+                        "description": "Create Data",
+                        "name": "Body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.FormByCasesubtype"
+                            "$ref": "#/definitions/model.DepartmentInsert"
                         }
                     }
                 ],
@@ -2684,7 +3135,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/forms/getAllForms": {
+        "/api/v1/departments/{id}": {
             "get": {
                 "security": [
                     {
@@ -2698,22 +3149,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Form and Workflow"
+                    "Organization"
+                ],
+                "summary": "Get Department by ID",
+                "operationId": "Get Department by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get All Form",
-                "operationId": "Get All Form",
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "OK - Request successful",
                         "schema": {
-                            "$ref": "#/definitions/model.ResponseDataFormList"
+                            "$ref": "#/definitions/model.Response"
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/forms/lock": {
-            "patch": {
+            },
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -2726,19 +3184,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Form and Workflow"
+                    "Organization"
                 ],
-                "summary": "Update Form Lock",
-                "operationId": "Update Form Lock",
+                "summary": "Delete Department",
+                "operationId": "Delete Department",
                 "parameters": [
                     {
-                        "description": "Update Data",
-                        "name": "Case",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.FormLock"
-                        }
+                        "type": "integer",
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -2749,9 +3205,7 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/forms/publish": {
+            },
             "patch": {
                 "security": [
                     {
@@ -2765,18 +3219,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Form and Workflow"
+                    "Organization"
                 ],
-                "summary": "Update Form Publish",
-                "operationId": "Update Form Publish",
+                "summary": "Update Department",
+                "operationId": "Update Department",
                 "parameters": [
                     {
-                        "description": "Update Data",
-                        "name": "Case",
+                        "type": "integer",
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update data",
+                        "name": "Body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.FormPublish"
+                            "$ref": "#/definitions/model.DepartmentUpdate"
                         }
                     }
                 ],
@@ -2790,8 +3251,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/forms/{id}": {
-            "delete": {
+        "/api/v1/devices": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -2804,17 +3265,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Form and Workflow"
+                    "Device Iot"
                 ],
-                "summary": "Delete Form",
-                "operationId": "Delete Form",
+                "summary": "Get Device IoT",
+                "operationId": "Get Device IoT",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "default": 0,
+                        "description": "start",
+                        "name": "start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "length",
+                        "name": "length",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -2827,8 +3295,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/forms/{uuid}": {
-            "patch": {
+        "/api/v1/devices/{id}": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -2841,26 +3309,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Form and Workflow"
+                    "Device Iot"
                 ],
-                "summary": "Update Form",
-                "operationId": "Update Form",
+                "summary": "Get Device IoT By ID",
+                "operationId": "Get Device IoT By ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "uuid",
-                        "name": "uuid",
+                        "description": "id",
+                        "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Update Data",
-                        "name": "Case",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.FormUpdate"
-                        }
                     }
                 ],
                 "responses": {
@@ -2873,7 +3332,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/mdm/companies": {
+        "/api/v1/dispatch/{caseId}/SOP": {
             "get": {
                 "security": [
                     {
@@ -2887,24 +3346,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Mobile device management (Units)"
+                    "Dispatch"
                 ],
-                "summary": "Get Mmd Companies",
-                "operationId": "Get Mmd Companies",
+                "summary": "Get SOP",
+                "operationId": "Case By CaseId",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "default": 0,
-                        "description": "start",
-                        "name": "start",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "length",
-                        "name": "length",
-                        "in": "query"
+                        "type": "string",
+                        "description": "caseId",
+                        "name": "caseId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -2917,13 +3369,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/mdm/companies/add": {
+        "/api/v1/dispatch/{caseId}/assign": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
+                "description": "Dispatches units to the case. Every unit must be active and free; the first assignment stamps the case's commandedDate.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2931,18 +3384,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Mobile device management (Units)"
+                    "Dispatch"
                 ],
-                "summary": "Create Mmd Companies Types",
-                "operationId": "Create Mmd Companies Types",
+                "summary": "Assign Units",
+                "operationId": "Assign Units",
                 "parameters": [
                     {
-                        "description": "Create Data",
+                        "type": "string",
+                        "description": "caseId",
+                        "name": "caseId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Units to assign",
                         "name": "Body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.MmdCompaniesInsert"
+                            "$ref": "#/definitions/model.DispatchUnits"
                         }
                     }
                 ],
@@ -2950,19 +3410,35 @@ const docTemplate = `{
                     "200": {
                         "description": "OK - Request successful",
                         "schema": {
-                            "$ref": "#/definitions/model.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/model.CaseDispatch"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/api/v1/mdm/companies/{id}": {
+        "/api/v1/dispatch/{caseId}/assignments": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
+                "description": "Lists every unit dispatched to the case, released ones included.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2970,15 +3446,15 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Mobile device management (Units)"
+                    "Dispatch"
                 ],
-                "summary": "Get Mmd Companies by Id",
-                "operationId": "Get Mmd Companies by Id",
+                "summary": "List Case Dispatches",
+                "operationId": "List Case Dispatches",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "id",
-                        "name": "id",
+                        "type": "string",
+                        "description": "caseId",
+                        "name": "caseId",
                         "in": "path",
                         "required": true
                     }
@@ -2987,17 +3463,35 @@ const docTemplate = `{
                     "200": {
                         "description": "OK - Request successful",
                         "schema": {
-                            "$ref": "#/definitions/model.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/model.CaseDispatch"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/v1/dispatch/{caseId}/unassign": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
+                "description": "Releases the open dispatches of units on the case and makes the units available again.",
                 "consumes": [
                     "application/json"
                 ],
@@ -3005,29 +3499,55 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Mobile device management (Units)"
+                    "Dispatch"
                 ],
-                "summary": "Delete Mmd Companies",
-                "operationId": "Delete Mmd Companies",
+                "summary": "Unassign Units",
+                "operationId": "Unassign Units",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "id",
-                        "name": "id",
+                        "type": "string",
+                        "description": "caseId",
+                        "name": "caseId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Units to unassign",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.DispatchUnits"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK - Request successful",
                         "schema": {
-                            "$ref": "#/definitions/model.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/model.CaseDispatch"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
-            },
-            "patch": {
+            }
+        },
+        "/api/v1/dispatch/{caseId}/units": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -3040,26 +3560,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Mobile device management (Units)"
+                    "Dispatch"
                 ],
-                "summary": "Update Mmd Companies",
-                "operationId": "Update Mmd Companies",
+                "summary": "Get Unit",
+                "operationId": "CaseByCaseId",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "id",
-                        "name": "id",
+                        "type": "string",
+                        "description": "caseId",
+                        "name": "caseId",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Update data",
-                        "name": "Body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.MmdCompaniesUpdate"
-                        }
                     }
                 ],
                 "responses": {
@@ -3072,13 +3583,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/mdm/properties": {
-            "get": {
+        "/api/v1/dispatch/{caseId}/units/{unitId}/status": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
+                "description": "Moves a unit's open dispatch on the case to acknowledged, en-route, on-scene, completed or released. Only the unit's user, or a user with case.update, may report. Acknowledged and on-scene stamp the case's receivedDate and arrivedDate the first time any unit reaches them; a step reported past a skipped one stamps the skipped step's date too.",
                 "consumes": [
                     "application/json"
                 ],
@@ -3086,38 +3598,59 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Mobile device management (Units)"
+                    "Dispatch"
                 ],
-                "summary": "Get Mmd Properties",
-                "operationId": "Get Mmd Properties",
+                "summary": "Report Unit Status",
+                "operationId": "Report Unit Status",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "default": 0,
-                        "description": "start",
-                        "name": "start",
-                        "in": "query"
+                        "type": "string",
+                        "description": "caseId",
+                        "name": "caseId",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "length",
-                        "name": "length",
-                        "in": "query"
+                        "type": "string",
+                        "description": "unitId",
+                        "name": "unitId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Next step",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.DispatchStatusReport"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK - Request successful",
                         "schema": {
-                            "$ref": "#/definitions/model.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.CaseDispatch"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/api/v1/mdm/properties/add": {
-            "post": {
+        "/api/v1/forms": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -3130,19 +3663,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Mobile device management (Units)"
+                    "Form and Workflow"
                 ],
-                "summary": "Create Mmd Properties",
-                "operationId": "Create Mmd Properties",
+                "summary": "Get Form",
+                "operationId": "Get Form",
                 "parameters": [
                     {
-                        "description": "Create Data",
-                        "name": "Body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.MmdPropertyInsert"
-                        }
+                        "type": "string",
+                        "description": "id",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "version",
+                        "name": "version",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -3153,10 +3691,8 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/mdm/properties/{id}": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -3169,17 +3705,19 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Mobile device management (Units)"
+                    "Form and Workflow"
                 ],
-                "summary": "Get Mmd Properties by Id",
-                "operationId": "Get Mmd Properties by Id",
+                "summary": "Create Form",
+                "operationId": "Create Form",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Created Data",
+                        "name": "Case",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.FormInsert"
+                        }
                     }
                 ],
                 "responses": {
@@ -3190,8 +3728,10 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/v1/forms/active": {
+            "patch": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -3204,17 +3744,19 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Mobile device management (Units)"
+                    "Form and Workflow"
                 ],
-                "summary": "Delete Mmd Properties",
-                "operationId": "Delete Mmd Properties",
+                "summary": "Update Form Status",
+                "operationId": "Update Form Status",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Update Data",
+                        "name": "Case",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.FormActive"
+                        }
                     }
                 ],
                 "responses": {
@@ -3225,8 +3767,10 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
+            }
+        },
+        "/api/v1/forms/casesubtype": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -3239,25 +3783,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Mobile device management (Units)"
+                    "Form and Workflow"
                 ],
-                "summary": "Update Mmd Properties",
-                "operationId": "Update Mmd Properties",
+                "summary": "Get Form by Casesubtype",
+                "operationId": "Get Form by Casesubtype",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Update data",
-                        "name": "Body",
+                        "description": "Data",
+                        "name": "Case",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.MmdPropertyUpdate"
+                            "$ref": "#/definitions/model.FormByCasesubtype"
                         }
                     }
                 ],
@@ -3271,7 +3808,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/mdm/sources": {
+        "/api/v1/forms/getAllForms": {
             "get": {
                 "security": [
                     {
@@ -3285,38 +3822,22 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Mobile device management (Units)"
-                ],
-                "summary": "Get Mmd Unit Sources",
-                "operationId": "Get Mmd Unit Sources",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "default": 0,
-                        "description": "start",
-                        "name": "start",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "length",
-                        "name": "length",
-                        "in": "query"
-                    }
+                    "Form and Workflow"
                 ],
+                "summary": "Get All Form",
+                "operationId": "Get All Form",
                 "responses": {
                     "200": {
-                        "description": "OK - Request successful",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Response"
+                            "$ref": "#/definitions/model.ResponseDataFormList"
                         }
                     }
                 }
             }
         },
-        "/api/v1/mdm/sources/add": {
-            "post": {
+        "/api/v1/forms/lock": {
+            "patch": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -3329,18 +3850,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Mobile device management (Units)"
+                    "Form and Workflow"
                 ],
-                "summary": "Create Mmd Unit Sources",
-                "operationId": "Create Mmd Unit Sources",
+                "summary": "Update Form Lock",
+                "operationId": "Update Form Lock",
                 "parameters": [
                     {
-                        "description": "Create Data",
-                        "name": "Body",
+                        "description": "Update Data",
+                        "name": "Case",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.MmdUnitSourceInsert"
+                            "$ref": "#/definitions/model.FormLock"
                         }
                     }
                 ],
@@ -3354,13 +3875,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/mdm/sources/{id}": {
-            "get": {
+        "/api/v1/forms/publish": {
+            "patch": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
+                "description": "Publishing copies the draft into the next numbered version, which becomes the one cases fill in. Unpublishing only clears the flag.",
                 "consumes": [
                     "application/json"
                 ],
@@ -3368,17 +3890,19 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Mobile device management (Units)"
+                    "Form and Workflow"
                 ],
-                "summary": "Get Mmd Unit Sources by Id",
-                "operationId": "Get Mmd Unit Sources by Id",
+                "summary": "Update Form Publish",
+                "operationId": "Update Form Publish",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Update Data",
+                        "name": "Case",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.FormPublish"
+                        }
                     }
                 ],
                 "responses": {
@@ -3389,7 +3913,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/api/v1/forms/{id}": {
             "delete": {
                 "security": [
                     {
@@ -3403,10 +3929,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Mobile device management (Units)"
+                    "Form and Workflow"
                 ],
-                "summary": "Delete Mmd Unit Sources",
-                "operationId": "Delete Mmd Unit Sources",
+                "summary": "Delete Form",
+                "operationId": "Delete Form",
                 "parameters": [
                     {
                         "type": "integer",
@@ -3424,13 +3950,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/api/v1/forms/{uuid}": {
             "patch": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
+                "description": "Replaces the draft. Published versions are not changed and publish in the body is ignored; use Update Form Publish. A locked form is refused with 409.",
                 "consumes": [
                     "application/json"
                 ],
@@ -3438,25 +3967,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Mobile device management (Units)"
+                    "Form and Workflow"
                 ],
-                "summary": "Update Mmd Unit Sources",
-                "operationId": "Update Mmd Unit Sources",
+                "summary": "Update Form",
+                "operationId": "Update Form",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "id",
-                        "name": "id",
+                        "type": "string",
+                        "description": "uuid",
+                        "name": "uuid",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Update data",
-                        "name": "Body",
+                        "description": "Update Data",
+                        "name": "Case",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.MmdUnitSourceUpdate"
+                            "$ref": "#/definitions/model.FormUpdate"
                         }
                     }
                 ],
@@ -3470,13 +3999,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/mdm/status": {
+        "/api/v1/forms/{uuid}/diff": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
+                "description": "Compares two versions of a form field by field. from defaults to the current version and to defaults to the draft.",
                 "consumes": [
                     "application/json"
                 ],
@@ -3484,23 +4014,28 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Mobile device management (Units)"
+                    "Form and Workflow"
                 ],
-                "summary": "Get Mmd Unit Status",
-                "operationId": "Get Mmd Unit Status",
+                "summary": "Diff Form Versions",
+                "operationId": "Diff Form Versions",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "default": 0,
-                        "description": "start",
-                        "name": "start",
+                        "type": "string",
+                        "description": "uuid",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "version, the current one when empty",
+                        "name": "from",
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "length",
-                        "name": "length",
+                        "type": "string",
+                        "description": "version, the draft when empty",
+                        "name": "to",
                         "in": "query"
                     }
                 ],
@@ -3508,14 +4043,26 @@ const docTemplate = `{
                     "200": {
                         "description": "OK - Request successful",
                         "schema": {
-                            "$ref": "#/definitions/model.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.FormDiff"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/api/v1/mdm/status/add": {
-            "post": {
+        "/api/v1/forms/{uuid}/versions": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -3528,32 +4075,45 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Mobile device management (Units)"
+                    "Form and Workflow"
                 ],
-                "summary": "Create Mmd Unit Status",
-                "operationId": "Create Mmd Unit Status",
+                "summary": "Get Form Versions",
+                "operationId": "Get Form Versions",
                 "parameters": [
                     {
-                        "description": "Create Data",
-                        "name": "Body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.MmdUnitStatusInsert"
-                        }
+                        "type": "string",
+                        "description": "uuid",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK - Request successful",
                         "schema": {
-                            "$ref": "#/definitions/model.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/model.FormVersion"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/api/v1/mdm/status/{id}": {
+        "/api/v1/mdm/companies": {
             "get": {
                 "security": [
                     {
@@ -3569,24 +4129,107 @@ const docTemplate = `{
                 "tags": [
                     "Mobile device management (Units)"
                 ],
-                "summary": "Get Mmd Unit Status by Id",
-                "operationId": "Get Mmd Unit Status by Id",
+                "summary": "Get Mmd Companies",
+                "operationId": "Get Mmd Companies",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK - Request successful",
-                        "schema": {
-                            "$ref": "#/definitions/model.Response"
-                        }
-                    }
+                        "default": 0,
+                        "description": "start",
+                        "name": "start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "length",
+                        "name": "length",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK - Request successful",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/mdm/companies/add": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Mobile device management (Units)"
+                ],
+                "summary": "Create Mmd Companies Types",
+                "operationId": "Create Mmd Companies Types",
+                "parameters": [
+                    {
+                        "description": "Create Data",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.MmdCompaniesInsert"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK - Request successful",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/mdm/companies/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Mobile device management (Units)"
+                ],
+                "summary": "Get Mmd Companies by Id",
+                "operationId": "Get Mmd Companies by Id",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK - Request successful",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
                 }
             },
             "delete": {
@@ -3604,8 +4247,8 @@ const docTemplate = `{
                 "tags": [
                     "Mobile device management (Units)"
                 ],
-                "summary": "Delete Mmd Unit Status",
-                "operationId": "Delete Mmd Unit Status",
+                "summary": "Delete Mmd Companies",
+                "operationId": "Delete Mmd Companies",
                 "parameters": [
                     {
                         "type": "integer",
@@ -3639,8 +4282,8 @@ const docTemplate = `{
                 "tags": [
                     "Mobile device management (Units)"
                 ],
-                "summary": "Update Mmd Unit Status",
-                "operationId": "Update Mmd Unit Status",
+                "summary": "Update Mmd Companies",
+                "operationId": "Update Mmd Companies",
                 "parameters": [
                     {
                         "type": "integer",
@@ -3655,7 +4298,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.MmdUnitStatusUpdate"
+                            "$ref": "#/definitions/model.MmdCompaniesUpdate"
                         }
                     }
                 ],
@@ -3669,7 +4312,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/mdm/types": {
+        "/api/v1/mdm/properties": {
             "get": {
                 "security": [
                     {
@@ -3685,8 +4328,8 @@ const docTemplate = `{
                 "tags": [
                     "Mobile device management (Units)"
                 ],
-                "summary": "Get Mmd Unit Types",
-                "operationId": "Get Mmd Unit Types",
+                "summary": "Get Mmd Properties",
+                "operationId": "Get Mmd Properties",
                 "parameters": [
                     {
                         "type": "integer",
@@ -3713,7 +4356,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/mdm/types/add": {
+        "/api/v1/mdm/properties/add": {
             "post": {
                 "security": [
                     {
@@ -3729,8 +4372,8 @@ const docTemplate = `{
                 "tags": [
                     "Mobile device management (Units)"
                 ],
-                "summary": "Create Mmd Unit Types",
-                "operationId": "Create Mmd Unit Types",
+                "summary": "Create Mmd Properties",
+                "operationId": "Create Mmd Properties",
                 "parameters": [
                     {
                         "description": "Create Data",
@@ -3738,7 +4381,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.MmdUnitTypeInsert"
+                            "$ref": "#/definitions/model.MmdPropertyInsert"
                         }
                     }
                 ],
@@ -3752,7 +4395,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/mdm/types/{id}": {
+        "/api/v1/mdm/properties/{id}": {
             "get": {
                 "security": [
                     {
@@ -3768,8 +4411,8 @@ const docTemplate = `{
                 "tags": [
                     "Mobile device management (Units)"
                 ],
-                "summary": "Get Mmd Unit Types by Id",
-                "operationId": "Get Mmd Unit Types by Id",
+                "summary": "Get Mmd Properties by Id",
+                "operationId": "Get Mmd Properties by Id",
                 "parameters": [
                     {
                         "type": "integer",
@@ -3803,8 +4446,8 @@ const docTemplate = `{
                 "tags": [
                     "Mobile device management (Units)"
                 ],
-                "summary": "Delete Mmd Types Sources",
-                "operationId": "Delete Mmd Types Sources",
+                "summary": "Delete Mmd Properties",
+                "operationId": "Delete Mmd Properties",
                 "parameters": [
                     {
                         "type": "integer",
@@ -3838,8 +4481,8 @@ const docTemplate = `{
                 "tags": [
                     "Mobile device management (Units)"
                 ],
-                "summary": "Update Mmd Types Sources",
-                "operationId": "Update Mmd Types Sources",
+                "summary": "Update Mmd Properties",
+                "operationId": "Update Mmd Properties",
                 "parameters": [
                     {
                         "type": "integer",
@@ -3854,7 +4497,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.MmdUnitTypeUpdate"
+                            "$ref": "#/definitions/model.MmdPropertyUpdate"
                         }
                     }
                 ],
@@ -3868,7 +4511,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/mdm/units": {
+        "/api/v1/mdm/sources": {
             "get": {
                 "security": [
                     {
@@ -3884,8 +4527,8 @@ const docTemplate = `{
                 "tags": [
                     "Mobile device management (Units)"
                 ],
-                "summary": "Get Mmd Unit",
-                "operationId": "Get Mmd Unit",
+                "summary": "Get Mmd Unit Sources",
+                "operationId": "Get Mmd Unit Sources",
                 "parameters": [
                     {
                         "type": "integer",
@@ -3912,7 +4555,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/mdm/units/add": {
+        "/api/v1/mdm/sources/add": {
             "post": {
                 "security": [
                     {
@@ -3928,8 +4571,8 @@ const docTemplate = `{
                 "tags": [
                     "Mobile device management (Units)"
                 ],
-                "summary": "Create Mmd Unit",
-                "operationId": "Create Mmd Unit",
+                "summary": "Create Mmd Unit Sources",
+                "operationId": "Create Mmd Unit Sources",
                 "parameters": [
                     {
                         "description": "Create Data",
@@ -3937,7 +4580,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.MmdUnitInsert"
+                            "$ref": "#/definitions/model.MmdUnitSourceInsert"
                         }
                     }
                 ],
@@ -3951,7 +4594,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/mdm/units/properties/{unitId}": {
+        "/api/v1/mdm/sources/{id}": {
             "get": {
                 "security": [
                     {
@@ -3967,27 +4610,13 @@ const docTemplate = `{
                 "tags": [
                     "Mobile device management (Units)"
                 ],
-                "summary": "Get Mmd Unit With Property",
-                "operationId": "Get Mmd Unit With Property",
+                "summary": "Get Mmd Unit Sources by Id",
+                "operationId": "Get Mmd Unit Sources by Id",
                 "parameters": [
                     {
                         "type": "integer",
-                        "default": 0,
-                        "description": "start",
-                        "name": "start",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "length",
-                        "name": "length",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "unitId",
-                        "name": "unitId",
+                        "description": "id",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -4000,10 +4629,8 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/mdm/units/{id}": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -4018,8 +4645,8 @@ const docTemplate = `{
                 "tags": [
                     "Mobile device management (Units)"
                 ],
-                "summary": "Get Mmd Unit by Id",
-                "operationId": "Get Mmd Unit by Id",
+                "summary": "Delete Mmd Unit Sources",
+                "operationId": "Delete Mmd Unit Sources",
                 "parameters": [
                     {
                         "type": "integer",
@@ -4038,7 +4665,7 @@ const docTemplate = `{
                     }
                 }
             },
-            "delete": {
+            "patch": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -4053,8 +4680,8 @@ const docTemplate = `{
                 "tags": [
                     "Mobile device management (Units)"
                 ],
-                "summary": "Delete Mmd Unit",
-                "operationId": "Delete Mmd Unit",
+                "summary": "Update Mmd Unit Sources",
+                "operationId": "Update Mmd Unit Sources",
                 "parameters": [
                     {
                         "type": "integer",
@@ -4062,6 +4689,15 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Update data",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.MmdUnitSourceUpdate"
+                        }
                     }
                 ],
                 "responses": {
@@ -4072,8 +4708,10 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
+            }
+        },
+        "/api/v1/mdm/status": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -4088,24 +4726,22 @@ const docTemplate = `{
                 "tags": [
                     "Mobile device management (Units)"
                 ],
-                "summary": "Update Mmd Unit",
-                "operationId": "Update Mmd Unit",
+                "summary": "Get Mmd Unit Status",
+                "operationId": "Get Mmd Unit Status",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "default": 0,
+                        "description": "start",
+                        "name": "start",
+                        "in": "query"
                     },
                     {
-                        "description": "Update data",
-                        "name": "Body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.MmdUnitUpdate"
-                        }
+                        "type": "integer",
+                        "default": 10,
+                        "description": "length",
+                        "name": "length",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -4118,14 +4754,13 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/notifications": {
+        "/api/v1/mdm/status/add": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Creates a batch of notifications, saves them to the database in a single transaction, and broadcasts each one to relevant online users. The input should be a JSON array of notification objects. Note: Do not include 'id' or 'createdAt' fields in the request body - these will be generated automatically.",
                 "consumes": [
                     "application/json"
                 ],
@@ -4133,90 +4768,38 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Notifications"
+                    "Mobile device management (Units)"
                 ],
-                "summary": "Create one or more new notifications",
+                "summary": "Create Mmd Unit Status",
+                "operationId": "Create Mmd Unit Status",
                 "parameters": [
                     {
-                        "description": "A JSON array of notification objects. The ` + "`" + `data` + "`" + ` field should be an array of key-value objects, e.g., ` + "`" + `\\",
-                        "name": "notifications",
+                        "description": "Create Data",
+                        "name": "Body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.NotificationCreateRequest"
-                            }
+                            "$ref": "#/definitions/model.MmdUnitStatusInsert"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Notifications created successfully",
+                    "200": {
+                        "description": "OK - Request successful",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.Notification"
-                            }
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid request body",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "500": {
-                        "description": "Internal server error (e.g., database transaction failure)",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/model.Response"
                         }
                     }
                 }
             }
         },
-        "/api/v1/notifications/register": {
+        "/api/v1/mdm/status/{id}": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Establishes a WebSocket connection. The client must send a JSON message with ` + "`" + `orgId` + "`" + ` and ` + "`" + `username` + "`" + ` to register the session.",
-                "tags": [
-                    "Notifications"
-                ],
-                "summary": "WebSocket endpoint for real-time notifications",
-                "responses": {
-                    "101": {
-                        "description": "Switching Protocols"
-                    },
-                    "400": {
-                        "description": "Bad Request (invalid registration message)"
-                    },
-                    "404": {
-                        "description": "Not Found (User not found)"
-                    },
-                    "500": {
-                        "description": "Internal Server Error"
-                    }
-                }
-            }
-        },
-        "/api/v1/notifications/{id}": {
-            "put": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Updates the content of a specific notification by its ID.",
                 "consumes": [
                     "application/json"
                 ],
@@ -4224,59 +4807,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Notifications"
+                    "Mobile device management (Units)"
                 ],
-                "summary": "Update an existing notification",
+                "summary": "Get Mmd Unit Status by Id",
+                "operationId": "Get Mmd Unit Status by Id",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Notification ID",
+                        "description": "id",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Notification object with fields to update.",
-                        "name": "notification",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.Notification"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Notification updated successfully",
-                        "schema": {
-                            "$ref": "#/definitions/model.Notification"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid request body or ID",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "404": {
-                        "description": "Notification not found",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "500": {
-                        "description": "Database error",
+                        "description": "OK - Request successful",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/model.Response"
                         }
                     }
                 }
@@ -4287,18 +4835,21 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Deletes a notification by its ID.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Notifications"
+                    "Mobile device management (Units)"
                 ],
-                "summary": "Delete a notification",
+                "summary": "Delete Mmd Unit Status",
+                "operationId": "Delete Mmd Unit Status",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Notification ID",
+                        "description": "id",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -4306,98 +4857,59 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Message confirming deletion",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "404": {
-                        "description": "Notification not found",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "500": {
-                        "description": "Database error",
+                        "description": "OK - Request successful",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/model.Response"
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/notifications/{orgId}/{username}": {
-            "get": {
+            },
+            "patch": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieves all notifications intended for a user by their username and organization ID.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Notifications"
+                    "Mobile device management (Units)"
                 ],
-                "summary": "Get all notifications for a specific user",
+                "summary": "Update Mmd Unit Status",
+                "operationId": "Update Mmd Unit Status",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Organization ID of the user",
-                        "name": "orgId",
+                        "type": "integer",
+                        "description": "id",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Username to fetch notifications for",
-                        "name": "username",
-                        "in": "path",
-                        "required": true
+                        "description": "Update data",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.MmdUnitStatusUpdate"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.Notification"
-                            }
-                        }
-                    },
-                    "404": {
-                        "description": "User not found",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "500": {
-                        "description": "Database error",
+                        "description": "OK - Request successful",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/model.Response"
                         }
                     }
                 }
             }
         },
-        "/api/v1/permission": {
+        "/api/v1/mdm/types": {
             "get": {
                 "security": [
                     {
@@ -4411,10 +4923,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Permission"
+                    "Mobile device management (Units)"
                 ],
-                "summary": "Get Permission",
-                "operationId": "Get Permission",
+                "summary": "Get Mmd Unit Types",
+                "operationId": "Get Mmd Unit Types",
                 "parameters": [
                     {
                         "type": "integer",
@@ -4441,7 +4953,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/permission/add": {
+        "/api/v1/mdm/types/add": {
             "post": {
                 "security": [
                     {
@@ -4455,10 +4967,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Permission"
+                    "Mobile device management (Units)"
                 ],
-                "summary": "Create Permission",
-                "operationId": "Create Permission",
+                "summary": "Create Mmd Unit Types",
+                "operationId": "Create Mmd Unit Types",
                 "parameters": [
                     {
                         "description": "Create Data",
@@ -4466,7 +4978,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.PermissionInsert"
+                            "$ref": "#/definitions/model.MmdUnitTypeInsert"
                         }
                     }
                 ],
@@ -4480,7 +4992,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/permission/{permId}": {
+        "/api/v1/mdm/types/{id}": {
             "get": {
                 "security": [
                     {
@@ -4494,15 +5006,15 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Permission"
+                    "Mobile device management (Units)"
                 ],
-                "summary": "Get Permission by id",
-                "operationId": "Get Permission by id",
+                "summary": "Get Mmd Unit Types by Id",
+                "operationId": "Get Mmd Unit Types by Id",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "permId",
-                        "name": "permId",
+                        "type": "integer",
+                        "description": "id",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -4529,15 +5041,15 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Permission"
+                    "Mobile device management (Units)"
                 ],
-                "summary": "Delete Permission",
-                "operationId": "Delete Permission",
+                "summary": "Delete Mmd Types Sources",
+                "operationId": "Delete Mmd Types Sources",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "permId",
-                        "name": "permId",
+                        "type": "integer",
+                        "description": "id",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -4564,15 +5076,15 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Permission"
+                    "Mobile device management (Units)"
                 ],
-                "summary": "Update Permission",
-                "operationId": "Update Permission",
+                "summary": "Update Mmd Types Sources",
+                "operationId": "Update Mmd Types Sources",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "permId",
-                        "name": "permId",
+                        "type": "integer",
+                        "description": "id",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
@@ -4582,7 +5094,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.PermissionUpdate"
+                            "$ref": "#/definitions/model.MmdUnitTypeUpdate"
                         }
                     }
                 ],
@@ -4596,7 +5108,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/role": {
+        "/api/v1/mdm/units": {
             "get": {
                 "security": [
                     {
@@ -4610,10 +5122,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Role"
+                    "Mobile device management (Units)"
                 ],
-                "summary": "Get Role",
-                "operationId": "Get Role",
+                "summary": "Get Mmd Unit",
+                "operationId": "Get Mmd Unit",
                 "parameters": [
                     {
                         "type": "integer",
@@ -4640,7 +5152,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/role/add": {
+        "/api/v1/mdm/units/add": {
             "post": {
                 "security": [
                     {
@@ -4654,10 +5166,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Role"
+                    "Mobile device management (Units)"
                 ],
-                "summary": "Create Role",
-                "operationId": "Create Role",
+                "summary": "Create Mmd Unit",
+                "operationId": "Create Mmd Unit",
                 "parameters": [
                     {
                         "description": "Create Data",
@@ -4665,7 +5177,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.RoleInsert"
+                            "$ref": "#/definitions/model.MmdUnitInsert"
                         }
                     }
                 ],
@@ -4679,7 +5191,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/role/{id}": {
+        "/api/v1/mdm/units/properties/{unitId}": {
             "get": {
                 "security": [
                     {
@@ -4693,15 +5205,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Role"
+                    "Mobile device management (Units)"
                 ],
-                "summary": "Get Role by ID",
-                "operationId": "Get Role by ID",
+                "summary": "Get Mmd Unit With Property",
+                "operationId": "Get Mmd Unit With Property",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "start",
+                        "name": "start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "length",
+                        "name": "length",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "id",
-                        "name": "id",
+                        "description": "unitId",
+                        "name": "unitId",
                         "in": "path",
                         "required": true
                     }
@@ -4714,8 +5240,10 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/v1/mdm/units/{id}": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -4728,13 +5256,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Role"
+                    "Mobile device management (Units)"
                 ],
-                "summary": "Delete Role",
-                "operationId": "Delete Role",
+                "summary": "Get Mmd Unit by Id",
+                "operationId": "Get Mmd Unit by Id",
                 "parameters": [
                     {
-                        "type": "string",
+                        "type": "integer",
                         "description": "id",
                         "name": "id",
                         "in": "path",
@@ -4750,7 +5278,7 @@ const docTemplate = `{
                     }
                 }
             },
-            "patch": {
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -4763,26 +5291,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Role"
+                    "Mobile device management (Units)"
                 ],
-                "summary": "Update Role",
-                "operationId": "Update Role",
+                "summary": "Delete Mmd Unit",
+                "operationId": "Delete Mmd Unit",
                 "parameters": [
                     {
-                        "type": "string",
+                        "type": "integer",
                         "description": "id",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Update data",
-                        "name": "Body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.RoleUpdate"
-                        }
                     }
                 ],
                 "responses": {
@@ -4793,10 +5312,8 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/role_permission": {
-            "get": {
+            },
+            "patch": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -4809,24 +5326,26 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Role"
+                    "Mobile device management (Units)"
                 ],
-                "summary": "Get RolePermission",
-                "operationId": "Get RolePermission",
+                "summary": "Update Mmd Unit",
+                "operationId": "Update Mmd Unit",
                 "parameters": [
                     {
                         "type": "integer",
-                        "default": 0,
-                        "description": "start",
-                        "name": "start",
-                        "in": "query"
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "length",
-                        "name": "length",
-                        "in": "query"
+                        "description": "Update data",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.MmdUnitUpdate"
+                        }
                     }
                 ],
                 "responses": {
@@ -4839,13 +5358,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/role_permission/add": {
-            "post": {
+        "/api/v1/notification_escalation": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
+                "description": "Lists the organization's escalation policies by event type.",
                 "consumes": [
                     "application/json"
                 ],
@@ -4853,38 +5373,43 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Role"
-                ],
-                "summary": "Create RolePermission",
-                "operationId": "Create RolePermission",
-                "parameters": [
-                    {
-                        "description": "Create Data",
-                        "name": "Body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.RolePermissionInsert"
-                        }
-                    }
+                    "Notifications"
                 ],
+                "summary": "List Notification Escalation Policies",
+                "operationId": "List Notification Escalation Policies",
                 "responses": {
                     "200": {
                         "description": "OK - Request successful",
                         "schema": {
-                            "$ref": "#/definitions/model.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/model.EscalationPolicy"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/api/v1/role_permission/multi": {
-            "patch": {
+        "/api/v1/notification_escalation/add": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
+                "description": "Sets how unacknowledged notifications of one event type escalate. Each step widens the recipients to commId, deptId or orgId once afterMinutes have passed since the notification was sent; steps must widen and come later in turn.",
                 "consumes": [
                     "application/json"
                 ],
@@ -4892,18 +5417,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Role"
+                    "Notifications"
                 ],
-                "summary": "Update Multi RolePermission",
-                "operationId": "Update Multi RolePermission",
+                "summary": "Create Notification Escalation Policy",
+                "operationId": "Create Notification Escalation Policy",
                 "parameters": [
                     {
-                        "description": "Update data",
+                        "description": "Policy to create",
                         "name": "Body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.MultiRolePermissionUpdate"
+                            "$ref": "#/definitions/model.EscalationPolicyInput"
                         }
                     }
                 ],
@@ -4917,7 +5442,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/role_permission/roleId/{roleId}": {
+        "/api/v1/notification_escalation/{id}": {
             "get": {
                 "security": [
                     {
@@ -4931,15 +5456,15 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Role"
+                    "Notifications"
                 ],
-                "summary": "Get RolePermission by roleID",
-                "operationId": "Get RolePermission by roleID",
+                "summary": "Get Notification Escalation Policy",
+                "operationId": "Get Notification Escalation Policy",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "roleId",
-                        "name": "roleId",
+                        "description": "policy id",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -4948,14 +5473,24 @@ const docTemplate = `{
                     "200": {
                         "description": "OK - Request successful",
                         "schema": {
-                            "$ref": "#/definitions/model.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.EscalationPolicy"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/role_permission/{id}": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -4968,14 +5503,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Role"
+                    "Notifications"
                 ],
-                "summary": "Get RolePermission by ID",
-                "operationId": "Get RolePermission by ID",
+                "summary": "Delete Notification Escalation Policy",
+                "operationId": "Delete Notification Escalation Policy",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "id",
+                        "type": "string",
+                        "description": "policy id",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -4990,7 +5525,7 @@ const docTemplate = `{
                     }
                 }
             },
-            "delete": {
+            "patch": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -5003,17 +5538,26 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Role"
+                    "Notifications"
                 ],
-                "summary": "Delete RolePermission",
-                "operationId": "Delete RolePermission",
+                "summary": "Update Notification Escalation Policy",
+                "operationId": "Update Notification Escalation Policy",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "id",
+                        "type": "string",
+                        "description": "policy id",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Policy to update",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.EscalationPolicyInput"
+                        }
                     }
                 ],
                 "responses": {
@@ -5026,13 +5570,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/role_permission/{roleId}": {
-            "patch": {
+        "/api/v1/notifications": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
+                "description": "Creates a batch of notifications, saves them to the database in a single transaction, and broadcasts each one to relevant online users. The input should be a JSON array of notification objects. Note: Do not include 'id' or 'createdAt' fields in the request body - these will be generated automatically.",
                 "consumes": [
                     "application/json"
                 ],
@@ -5040,89 +5585,162 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Role"
+                    "Notifications"
                 ],
-                "summary": "Update RolePermission",
-                "operationId": "Update RolePermission",
+                "summary": "Create one or more new notifications",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "roleId",
-                        "name": "roleId",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Update data",
-                        "name": "Body",
+                        "description": "A JSON array of notification objects. The ` + "`" + `data` + "`" + ` field should be an array of key-value objects, e.g., ` + "`" + `\\",
+                        "name": "notifications",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.RolePermissionUpdate"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.NotificationCreateRequest"
+                            }
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK - Request successful",
+                    "201": {
+                        "description": "Notifications created successfully",
                         "schema": {
-                            "$ref": "#/definitions/model.Response"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Notification"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "orgId of another organization",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error (e.g., database transaction failure)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/api/v1/skill": {
+        "/api/v1/notifications/me": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Lists the notifications addressed to the caller, newest first, one page at a time. Pass the nextCursor of a page as cursor to get the next one; nextCursor is empty on the last page.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Notifications"
                 ],
-                "summary": "Get Skill",
-                "operationId": "Get Skill",
+                "summary": "Get the caller's notifications",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "default": 0,
-                        "description": "start",
-                        "name": "start",
+                        "type": "string",
+                        "description": "Only this event type",
+                        "name": "eventType",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created at or after (RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created before (RFC 3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "unread, read, acknowledged or dismissed",
+                        "name": "state",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "nextCursor of the previous page",
+                        "name": "cursor",
                         "in": "query"
                     },
                     {
                         "type": "integer",
-                        "default": 10,
-                        "description": "length",
-                        "name": "length",
+                        "default": 20,
+                        "description": "Page size, at most 100",
+                        "name": "limit",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK - Request successful",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Response"
+                            "$ref": "#/definitions/model.NotificationPage"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter or cursor",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Database error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/api/v1/skill/add": {
+        "/api/v1/notifications/read": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
+                "description": "Marks the given notifications, or with all=true every notification addressed to the caller, as read by the caller. Ids the caller cannot see are ignored.",
                 "consumes": [
                     "application/json"
                 ],
@@ -5130,108 +5748,130 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Notifications"
                 ],
-                "summary": "Create Skill",
-                "operationId": "Create Skill",
+                "summary": "Mark notifications read",
                 "parameters": [
                     {
-                        "description": "Create Data",
-                        "name": "Body",
+                        "description": "ids to mark, or all",
+                        "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.SkillInsert"
+                            "$ref": "#/definitions/model.NotificationReadInput"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK - Request successful",
+                        "description": "Number of notifications newly marked and the unread count",
                         "schema": {
-                            "$ref": "#/definitions/model.Response"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "integer"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Neither ids nor all given",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Database error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/api/v1/skill/{id}": {
+        "/api/v1/notifications/register": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+                "description": "Establishes a WebSocket connection bound to the caller's access token. The token is taken from the subprotocol pair ` + "`" + `bearer, \u003ctoken\u003e` + "`" + ` or from a first frame ` + "`" + `{\"type\":\"auth\",\"token\":\"...\"}` + "`" + ` sent within 10 seconds. The socket is closed with code 4001 when the token expires, unless the client sends a newer token for the same user as another ` + "`" + `auth` + "`" + ` frame.",
                 "tags": [
-                    "User"
-                ],
-                "summary": "Get Skill by ID",
-                "operationId": "Get Skill by ID",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "Notifications"
                 ],
+                "summary": "WebSocket endpoint for real-time notifications",
                 "responses": {
-                    "200": {
-                        "description": "OK - Request successful",
-                        "schema": {
-                            "$ref": "#/definitions/model.Response"
-                        }
+                    "101": {
+                        "description": "Switching Protocols"
+                    },
+                    "401": {
+                        "description": "Unauthorized (invalid access token)"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/v1/notifications/unread_count": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Counts the notifications addressed to the caller that are neither read nor dismissed.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "User"
-                ],
-                "summary": "Delete Skill",
-                "operationId": "Delete Skill",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "Notifications"
                 ],
+                "summary": "Get the caller's unread notification count",
                 "responses": {
                     "200": {
-                        "description": "OK - Request successful",
+                        "description": "Unread count",
                         "schema": {
-                            "$ref": "#/definitions/model.Response"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "integer"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Database error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
-            },
-            "patch": {
+            }
+        },
+        "/api/v1/notifications/{id}": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
+                "description": "Updates the content of a specific notification by its ID.",
                 "consumes": [
                     "application/json"
                 ],
@@ -5239,143 +5879,188 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Notifications"
                 ],
-                "summary": "Update Skill",
-                "operationId": "Update Skill",
+                "summary": "Update an existing notification",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "id",
+                        "description": "Notification ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Update data",
-                        "name": "Body",
+                        "description": "Notification object with fields to update.",
+                        "name": "notification",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.SkillUpdate"
+                            "$ref": "#/definitions/model.Notification"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK - Request successful",
+                        "description": "Notification updated successfully",
                         "schema": {
-                            "$ref": "#/definitions/model.Response"
+                            "$ref": "#/definitions/model.Notification"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Notification not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Database error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/stations": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Deletes a notification by its ID.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Organization"
+                    "Notifications"
                 ],
-                "summary": "Get Stations",
-                "operationId": "Get Stations",
+                "summary": "Delete a notification",
                 "parameters": [
                     {
                         "type": "integer",
-                        "default": 0,
-                        "description": "start",
-                        "name": "start",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "length",
-                        "name": "length",
-                        "in": "query"
+                        "description": "Notification ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK - Request successful",
+                        "description": "Message confirming deletion",
                         "schema": {
-                            "$ref": "#/definitions/model.Response"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Notification not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Database error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/api/v1/stations/add": {
+        "/api/v1/notifications/{id}/acknowledge": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Records that the caller acknowledged one notification addressed to them. Acknowledging also marks it read.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Organization"
+                    "Notifications"
                 ],
-                "summary": "Create Stations",
-                "operationId": "Create Stations",
+                "summary": "Acknowledge a notification",
                 "parameters": [
                     {
-                        "description": "Create Data",
-                        "name": "Body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.StationInsert"
-                        }
-                    }
+                        "type": "integer",
+                        "description": "Notification ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK - Request successful",
+                        "description": "Notification with the caller's state",
                         "schema": {
-                            "$ref": "#/definitions/model.Response"
+                            "$ref": "#/definitions/model.Notification"
+                        }
+                    },
+                    "404": {
+                        "description": "Notification not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Database error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/api/v1/stations/{id}": {
-            "get": {
+        "/api/v1/notifications/{id}/dismiss": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Hides one notification addressed to the caller from their unread count.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Organization"
+                    "Notifications"
                 ],
-                "summary": "Get Stations by id",
-                "operationId": "Get Stations  by id",
+                "summary": "Dismiss a notification",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "id",
+                        "type": "integer",
+                        "description": "Notification ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -5383,34 +6068,51 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK - Request successful",
+                        "description": "Notification with the caller's state",
                         "schema": {
-                            "$ref": "#/definitions/model.Response"
+                            "$ref": "#/definitions/model.Notification"
+                        }
+                    },
+                    "404": {
+                        "description": "Notification not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Database error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/v1/notifications/{id}/read": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Marks one notification addressed to the caller as read.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Organization"
+                    "Notifications"
                 ],
-                "summary": "Delete Stations",
-                "operationId": "Delete Stations",
+                "summary": "Mark a notification read",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "id",
+                        "description": "Notification ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -5418,59 +6120,105 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK - Request successful",
+                        "description": "Notification with the caller's state",
                         "schema": {
-                            "$ref": "#/definitions/model.Response"
+                            "$ref": "#/definitions/model.Notification"
+                        }
+                    },
+                    "404": {
+                        "description": "Notification not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Database error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
-            },
-            "patch": {
+            }
+        },
+        "/api/v1/notifications/{orgId}/{username}": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Retrieves all notifications intended for a user by their username and organization ID. The organization must be the caller's, and reading another user's notifications needs the user.view permission. Deprecated: use GET /api/v1/notifications/me, which pages and filters the caller's own inbox.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Organization"
+                    "Notifications"
                 ],
-                "summary": "Update Stations",
-                "operationId": "Update Stations",
+                "summary": "Get all notifications for a specific user",
+                "deprecated": true,
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "id",
-                        "name": "id",
+                        "type": "string",
+                        "description": "Organization ID of the user",
+                        "name": "orgId",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Update data",
-                        "name": "Body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.StationUpdate"
-                        }
+                        "type": "string",
+                        "description": "Username to fetch notifications for",
+                        "name": "username",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK - Request successful",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Response"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Notification"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Another organization or user",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Database error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/api/v1/user_groups/all": {
+        "/api/v1/permission": {
             "get": {
                 "security": [
                     {
@@ -5484,10 +6232,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "UserGroup"
+                    "Permission"
                 ],
-                "summary": "Get User Groups",
-                "operationId": "GetUserGroups",
+                "summary": "Get Permission",
+                "operationId": "Get Permission",
                 "parameters": [
                     {
                         "type": "integer",
@@ -5514,8 +6262,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/users": {
-            "get": {
+        "/api/v1/permission/add": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -5528,24 +6276,19 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Permission"
                 ],
-                "summary": "Get User",
-                "operationId": "Get User",
+                "summary": "Create Permission",
+                "operationId": "Create Permission",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "default": 0,
-                        "description": "start",
-                        "name": "start",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "length",
-                        "name": "length",
-                        "in": "query"
+                        "description": "Create Data",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PermissionInsert"
+                        }
                     }
                 ],
                 "responses": {
@@ -5558,13 +6301,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/users/add": {
-            "post": {
+        "/api/v1/permission/catalogue": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
+                "description": "Lists the permissions the API enforces.",
                 "consumes": [
                     "application/json"
                 ],
@@ -5572,32 +6316,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
-                ],
-                "summary": "Create User",
-                "operationId": "Create User",
-                "parameters": [
-                    {
-                        "description": "User to be created",
-                        "name": "Case",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.UserInput"
-                        }
-                    }
+                    "Role"
                 ],
+                "summary": "Get Permission Catalogue",
+                "operationId": "Get Permission Catalogue",
                 "responses": {
                     "200": {
                         "description": "OK - Request successful",
                         "schema": {
-                            "$ref": "#/definitions/model.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/model.PermissionEntry"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/api/v1/users/username/{username}": {
+        "/api/v1/permission/{permId}": {
             "get": {
                 "security": [
                     {
@@ -5611,15 +6359,15 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Permission"
                 ],
-                "summary": "Get User by username",
-                "operationId": "Get User by username",
+                "summary": "Get Permission by id",
+                "operationId": "Get Permission by id",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "username",
-                        "name": "username",
+                        "description": "permId",
+                        "name": "permId",
                         "in": "path",
                         "required": true
                     }
@@ -5633,7 +6381,7 @@ const docTemplate = `{
                     }
                 }
             },
-            "patch": {
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -5646,26 +6394,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Permission"
                 ],
-                "summary": "Update User By Username",
-                "operationId": "Update User By Username",
+                "summary": "Delete Permission",
+                "operationId": "Delete Permission",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "username",
-                        "name": "username",
+                        "description": "permId",
+                        "name": "permId",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Data Update",
-                        "name": "Body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.UserUpdate"
-                        }
                     }
                 ],
                 "responses": {
@@ -5676,10 +6415,8 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/users/{id}": {
-            "get": {
+            },
+            "patch": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -5692,95 +6429,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
-                ],
-                "summary": "Get User by Id",
-                "operationId": "Get User by Id",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK - Request successful",
-                        "schema": {
-                            "$ref": "#/definitions/model.Response"
-                        }
-                    }
-                }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "User"
-                ],
-                "summary": "Delete User",
-                "operationId": "Delete User",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK - Request successful",
-                        "schema": {
-                            "$ref": "#/definitions/model.Response"
-                        }
-                    }
-                }
-            },
-            "patch": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "User"
+                    "Permission"
                 ],
-                "summary": "Update User",
-                "operationId": "Update User",
+                "summary": "Update Permission",
+                "operationId": "Update Permission",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "id",
-                        "name": "id",
+                        "type": "string",
+                        "description": "permId",
+                        "name": "permId",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Data Update",
+                        "description": "Update data",
                         "name": "Body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.UserUpdate"
+                            "$ref": "#/definitions/model.PermissionUpdate"
                         }
                     }
                 ],
@@ -5794,7 +6461,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/users_with_contacts": {
+        "/api/v1/role": {
             "get": {
                 "security": [
                     {
@@ -5808,10 +6475,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Role"
                 ],
-                "summary": "Get User with contacts",
-                "operationId": "Get User with contacts",
+                "summary": "Get Role",
+                "operationId": "Get Role",
                 "parameters": [
                     {
                         "type": "integer",
@@ -5838,7 +6505,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/users_with_contacts/add": {
+        "/api/v1/role/add": {
             "post": {
                 "security": [
                     {
@@ -5852,10 +6519,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Role"
                 ],
-                "summary": "Create User with contacts",
-                "operationId": "Create User with contacts",
+                "summary": "Create Role",
+                "operationId": "Create Role",
                 "parameters": [
                     {
                         "description": "Create Data",
@@ -5863,7 +6530,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.UserContactInsert"
+                            "$ref": "#/definitions/model.RoleInsert"
                         }
                     }
                 ],
@@ -5877,7 +6544,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/users_with_contacts/{id}": {
+        "/api/v1/role/{id}": {
             "get": {
                 "security": [
                     {
@@ -5891,13 +6558,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Role"
                 ],
-                "summary": "Get User with contacts by id",
-                "operationId": "Get User with contacts by id",
+                "summary": "Get Role by ID",
+                "operationId": "Get Role by ID",
                 "parameters": [
                     {
-                        "type": "integer",
+                        "type": "string",
                         "description": "id",
                         "name": "id",
                         "in": "path",
@@ -5926,13 +6593,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Role"
                 ],
-                "summary": "Delete User with contacts",
-                "operationId": "Delete User with contacts",
+                "summary": "Delete Role",
+                "operationId": "Delete Role",
                 "parameters": [
                     {
-                        "type": "integer",
+                        "type": "string",
                         "description": "id",
                         "name": "id",
                         "in": "path",
@@ -5961,13 +6628,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Role"
                 ],
-                "summary": "Update User with contacts",
-                "operationId": "Update User with contacts",
+                "summary": "Update Role",
+                "operationId": "Update Role",
                 "parameters": [
                     {
-                        "type": "integer",
+                        "type": "string",
                         "description": "id",
                         "name": "id",
                         "in": "path",
@@ -5979,7 +6646,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.UserContactInsertUpdate"
+                            "$ref": "#/definitions/model.RoleUpdate"
                         }
                     }
                 ],
@@ -5993,7 +6660,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/users_with_skills": {
+        "/api/v1/role_permission": {
             "get": {
                 "security": [
                     {
@@ -6007,10 +6674,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Role"
                 ],
-                "summary": "Get User with skills",
-                "operationId": "Get User with skills",
+                "summary": "Get RolePermission",
+                "operationId": "Get RolePermission",
                 "parameters": [
                     {
                         "type": "integer",
@@ -6037,7 +6704,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/users_with_skills/add": {
+        "/api/v1/role_permission/add": {
             "post": {
                 "security": [
                     {
@@ -6051,10 +6718,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Role"
                 ],
-                "summary": "Create User with skill",
-                "operationId": "Create User with skill",
+                "summary": "Create RolePermission",
+                "operationId": "Create RolePermission",
                 "parameters": [
                     {
                         "description": "Create Data",
@@ -6062,7 +6729,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.UserSkillInsert"
+                            "$ref": "#/definitions/model.RolePermissionInsert"
                         }
                     }
                 ],
@@ -6076,7 +6743,46 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/users_with_skills/skillId/{skillId}": {
+        "/api/v1/role_permission/multi": {
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Role"
+                ],
+                "summary": "Update Multi RolePermission",
+                "operationId": "Update Multi RolePermission",
+                "parameters": [
+                    {
+                        "description": "Update data",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.MultiRolePermissionUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK - Request successful",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/role_permission/roleId/{roleId}": {
             "get": {
                 "security": [
                     {
@@ -6090,15 +6796,15 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Role"
                 ],
-                "summary": "Get User with skills by skill id",
-                "operationId": "Get User with skills by skillId",
+                "summary": "Get RolePermission by roleID",
+                "operationId": "Get RolePermission by roleID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "skillId",
-                        "name": "skillId",
+                        "description": "roleId",
+                        "name": "roleId",
                         "in": "path",
                         "required": true
                     }
@@ -6113,7 +6819,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/users_with_skills/{id}": {
+        "/api/v1/role_permission/{id}": {
             "get": {
                 "security": [
                     {
@@ -6127,10 +6833,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Role"
                 ],
-                "summary": "Get User with skills by id",
-                "operationId": "Get User with skills by id",
+                "summary": "Get RolePermission by ID",
+                "operationId": "Get RolePermission by ID",
                 "parameters": [
                     {
                         "type": "integer",
@@ -6162,10 +6868,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Role"
                 ],
-                "summary": "Delete User with skill",
-                "operationId": "Delete User with skill",
+                "summary": "Delete RolePermission",
+                "operationId": "Delete RolePermission",
                 "parameters": [
                     {
                         "type": "integer",
@@ -6183,7 +6889,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/api/v1/role_permission/{roleId}": {
             "patch": {
                 "security": [
                     {
@@ -6197,15 +6905,15 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Role"
                 ],
-                "summary": "Update User with skill",
-                "operationId": "Update User with skill",
+                "summary": "Update RolePermission",
+                "operationId": "Update RolePermission",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "id",
-                        "name": "id",
+                        "type": "string",
+                        "description": "roleId",
+                        "name": "roleId",
                         "in": "path",
                         "required": true
                     },
@@ -6215,7 +6923,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.UserSkillUpdate"
+                            "$ref": "#/definitions/model.RolePermissionUpdate"
                         }
                     }
                 ],
@@ -6229,7 +6937,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/users_with_socials": {
+        "/api/v1/skill": {
             "get": {
                 "security": [
                     {
@@ -6245,8 +6953,8 @@ const docTemplate = `{
                 "tags": [
                     "User"
                 ],
-                "summary": "Get User with socials",
-                "operationId": "Get User with socials",
+                "summary": "Get Skill",
+                "operationId": "Get Skill",
                 "parameters": [
                     {
                         "type": "integer",
@@ -6273,7 +6981,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/users_with_socials/add": {
+        "/api/v1/skill/add": {
             "post": {
                 "security": [
                     {
@@ -6289,8 +6997,8 @@ const docTemplate = `{
                 "tags": [
                     "User"
                 ],
-                "summary": "Create User with socials",
-                "operationId": "Create User with socials",
+                "summary": "Create Skill",
+                "operationId": "Create Skill",
                 "parameters": [
                     {
                         "description": "Create Data",
@@ -6298,7 +7006,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.UserSocialInsert"
+                            "$ref": "#/definitions/model.SkillInsert"
                         }
                     }
                 ],
@@ -6312,7 +7020,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/users_with_socials/{id}": {
+        "/api/v1/skill/{id}": {
             "get": {
                 "security": [
                     {
@@ -6328,11 +7036,11 @@ const docTemplate = `{
                 "tags": [
                     "User"
                 ],
-                "summary": "Get User with Socials by id",
-                "operationId": "Get User with Socials by id",
+                "summary": "Get Skill by ID",
+                "operationId": "Get Skill by ID",
                 "parameters": [
                     {
-                        "type": "integer",
+                        "type": "string",
                         "description": "id",
                         "name": "id",
                         "in": "path",
@@ -6363,8 +7071,8 @@ const docTemplate = `{
                 "tags": [
                     "User"
                 ],
-                "summary": "Delete User with socials",
-                "operationId": "Delete User with socials",
+                "summary": "Delete Skill",
+                "operationId": "Delete Skill",
                 "parameters": [
                     {
                         "type": "integer",
@@ -6398,8 +7106,8 @@ const docTemplate = `{
                 "tags": [
                     "User"
                 ],
-                "summary": "Update User with socials",
-                "operationId": "Update User with socials",
+                "summary": "Update Skill",
+                "operationId": "Update Skill",
                 "parameters": [
                     {
                         "type": "integer",
@@ -6414,7 +7122,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.UserSocialUpdate"
+                            "$ref": "#/definitions/model.SkillUpdate"
                         }
                     }
                 ],
@@ -6428,7 +7136,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/workflows": {
+        "/api/v1/stations": {
             "get": {
                 "security": [
                     {
@@ -6442,10 +7150,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Form and Workflow"
+                    "Organization"
                 ],
-                "summary": "Get Workflow List",
-                "operationId": "Get Workflow List",
+                "summary": "Get Stations",
+                "operationId": "Get Stations",
                 "parameters": [
                     {
                         "type": "integer",
@@ -6470,7 +7178,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/api/v1/stations/add": {
             "post": {
                 "security": [
                     {
@@ -6484,10 +7194,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Form and Workflow"
+                    "Organization"
                 ],
-                "summary": "Create Workflow",
-                "operationId": "Create Workflow",
+                "summary": "Create Stations",
+                "operationId": "Create Stations",
                 "parameters": [
                     {
                         "description": "Create Data",
@@ -6495,7 +7205,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.WorkFlowInsert"
+                            "$ref": "#/definitions/model.StationInsert"
                         }
                     }
                 ],
@@ -6509,7 +7219,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/workflows/{id}": {
+        "/api/v1/stations/{id}": {
             "get": {
                 "security": [
                     {
@@ -6523,10 +7233,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Form and Workflow"
+                    "Organization"
                 ],
-                "summary": "Get Workflow",
-                "operationId": "Get Workflow",
+                "summary": "Get Stations by id",
+                "operationId": "Get Stations  by id",
                 "parameters": [
                     {
                         "type": "string",
@@ -6544,10 +7254,89 @@ const docTemplate = `{
                         }
                     }
                 }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "Delete Stations",
+                "operationId": "Delete Stations",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK - Request successful",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "Update Stations",
+                "operationId": "Update Stations",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update data",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.StationUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK - Request successful",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
             }
         },
-        "/api/v1/workflows/{uuid}": {
-            "delete": {
+        "/api/v1/system/db_stats": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -6560,15 +7349,110 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Form and Workflow"
+                    "System"
                 ],
-                "summary": "Delete Workflow",
-                "operationId": "Delete Workflow",
+                "summary": "Get DB Pool Stats",
+                "operationId": "Get DB Pool Stats",
+                "responses": {
+                    "200": {
+                        "description": "OK - Request successful",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/system/outbox": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Lists the organization's notification deliveries, newest first. Use status=dead for the dead-letter view.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "System"
+                ],
+                "summary": "Get Notification Outbox",
+                "operationId": "Get Notification Outbox",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "uuid",
-                        "name": "uuid",
+                        "description": "pending, delivered or dead",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "start",
+                        "name": "start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "length",
+                        "name": "length",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK - Request successful",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/model.OutboxEntry"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/system/outbox/{id}/retry": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Moves a dead-lettered delivery back to pending so the relay tries it again.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "System"
+                ],
+                "summary": "Retry Notification Outbox Entry",
+                "operationId": "Retry Notification Outbox Entry",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "outbox entry id",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -6581,54 +7465,1811 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Form and Workflow"
-                ],
-                "summary": "Update Workflow",
-                "operationId": "Update Workflow",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "uuid",
-                        "name": "uuid",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Create Data",
-                        "name": "Body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.WorkFlowInsert"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK - Request successful",
-                        "schema": {
-                            "$ref": "#/definitions/model.Response"
-                        }
-                    }
+            }
+        },
+        "/api/v1/system/ws_stats": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Reports the notification sockets open on this instance, their send queue depth and the messages sent, dropped or failed since start.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "System"
+                ],
+                "summary": "Get WebSocket Hub Stats",
+                "operationId": "Get WebSocket Hub Stats",
+                "responses": {
+                    "200": {
+                        "description": "OK - Request successful",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.WsHubStats"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user_groups/all": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "UserGroup"
+                ],
+                "summary": "Get User Groups",
+                "operationId": "GetUserGroups",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "start",
+                        "name": "start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "length",
+                        "name": "length",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK - Request successful",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get User",
+                "operationId": "Get User",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "start",
+                        "name": "start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "length",
+                        "name": "length",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK - Request successful",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/add": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Create User",
+                "operationId": "Create User",
+                "parameters": [
+                    {
+                        "description": "User to be created",
+                        "name": "Case",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UserInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK - Request successful",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/username/{username}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get User by username",
+                "operationId": "Get User by username",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "username",
+                        "name": "username",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK - Request successful",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Update User By Username",
+                "operationId": "Update User By Username",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "username",
+                        "name": "username",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Data Update",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UserUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK - Request successful",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get User by Id",
+                "operationId": "Get User by Id",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK - Request successful",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Delete User",
+                "operationId": "Delete User",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK - Request successful",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Update User",
+                "operationId": "Update User",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Data Update",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UserUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK - Request successful",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{id}/sessions/revoke": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Revokes every refresh token of a user, signing them out everywhere once their access tokens expire.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Revoke User Sessions",
+                "operationId": "Revoke User Sessions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK - Request successful",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users_with_contacts": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get User with contacts",
+                "operationId": "Get User with contacts",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "start",
+                        "name": "start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "length",
+                        "name": "length",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK - Request successful",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users_with_contacts/add": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Create User with contacts",
+                "operationId": "Create User with contacts",
+                "parameters": [
+                    {
+                        "description": "Create Data",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UserContactInsert"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK - Request successful",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users_with_contacts/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get User with contacts by id",
+                "operationId": "Get User with contacts by id",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK - Request successful",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Delete User with contacts",
+                "operationId": "Delete User with contacts",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK - Request successful",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Update User with contacts",
+                "operationId": "Update User with contacts",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update data",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UserContactInsertUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK - Request successful",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users_with_skills": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get User with skills",
+                "operationId": "Get User with skills",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "start",
+                        "name": "start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "length",
+                        "name": "length",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK - Request successful",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users_with_skills/add": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Create User with skill",
+                "operationId": "Create User with skill",
+                "parameters": [
+                    {
+                        "description": "Create Data",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UserSkillInsert"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK - Request successful",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users_with_skills/skillId/{skillId}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get User with skills by skill id",
+                "operationId": "Get User with skills by skillId",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "skillId",
+                        "name": "skillId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK - Request successful",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users_with_skills/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get User with skills by id",
+                "operationId": "Get User with skills by id",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK - Request successful",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Delete User with skill",
+                "operationId": "Delete User with skill",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK - Request successful",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Update User with skill",
+                "operationId": "Update User with skill",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update data",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UserSkillUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK - Request successful",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users_with_socials": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get User with socials",
+                "operationId": "Get User with socials",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "start",
+                        "name": "start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "length",
+                        "name": "length",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK - Request successful",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users_with_socials/add": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Create User with socials",
+                "operationId": "Create User with socials",
+                "parameters": [
+                    {
+                        "description": "Create Data",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UserSocialInsert"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK - Request successful",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users_with_socials/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get User with Socials by id",
+                "operationId": "Get User with Socials by id",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK - Request successful",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Delete User with socials",
+                "operationId": "Delete User with socials",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK - Request successful",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Update User with socials",
+                "operationId": "Update User with socials",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update data",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UserSocialUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK - Request successful",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/workflows": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Form and Workflow"
+                ],
+                "summary": "Get Workflow List",
+                "operationId": "Get Workflow List",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "start",
+                        "name": "start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "length",
+                        "name": "length",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK - Request successful",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Form and Workflow"
+                ],
+                "summary": "Create Workflow",
+                "operationId": "Create Workflow",
+                "parameters": [
+                    {
+                        "description": "Create Data",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.WorkFlowInsert"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK - Request successful",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/workflows/validate": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Checks a workflow graph without saving it: edges must join existing nodes, there must be one start node, every node must be reachable and lead to an end node, and referenced forms must exist.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Form and Workflow"
+                ],
+                "summary": "Validate Workflow",
+                "operationId": "Validate Workflow",
+                "parameters": [
+                    {
+                        "description": "Workflow to check",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.WorkFlowInsert"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK - Request successful",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.WorkflowValidation"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/workflows/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Form and Workflow"
+                ],
+                "summary": "Get Workflow",
+                "operationId": "Get Workflow",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "published version, the draft when empty",
+                        "name": "versions",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK - Request successful",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/workflows/{id}/versions": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Form and Workflow"
+                ],
+                "summary": "Get Workflow Versions",
+                "operationId": "Get Workflow Versions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK - Request successful",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/model.WorkflowVersion"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/workflows/{uuid}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Form and Workflow"
+                ],
+                "summary": "Delete Workflow",
+                "operationId": "Delete Workflow",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "uuid",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK - Request successful",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Form and Workflow"
+                ],
+                "summary": "Update Workflow",
+                "operationId": "Update Workflow",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "uuid",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Create Data",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.WorkFlowInsert"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK - Request successful",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/workflows/{uuid}/publish": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Validates the draft and freezes it into the next numbered version. New cases start on it; running cases keep the version they started on. A draft with validation errors is refused with the report in data.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Form and Workflow"
+                ],
+                "summary": "Publish Workflow",
+                "operationId": "Publish Workflow",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "uuid",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK - Request successful",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/workflows/{uuid}/rollback/{versions}": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Replaces the draft with a copy of a published version. Published versions are not changed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Form and Workflow"
+                ],
+                "summary": "Rollback Workflow Draft",
+                "operationId": "Rollback Workflow Draft",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "uuid",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "published version",
+                        "name": "versions",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK - Request successful",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "model.AuditChainBreak": {
+            "type": "object",
+            "properties": {
+                "expected": {
+                    "type": "string"
+                },
+                "found": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.AuditChainReport": {
+            "type": "object",
+            "properties": {
+                "brokenAt": {
+                    "description": "BrokenAt is the first row that does not follow from the one before.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.AuditChainBreak"
+                        }
+                    ]
+                },
+                "checked": {
+                    "description": "Checked counts the chained rows verified; Unchained counts the rows\nfrom before the chain that were skipped.",
+                    "type": "integer"
+                },
+                "lastHash": {
+                    "type": "string"
+                },
+                "lastId": {
+                    "type": "integer"
+                },
+                "orgId": {
+                    "type": "string"
+                },
+                "unchained": {
+                    "type": "integer"
+                },
+                "valid": {
+                    "description": "Valid is false when BrokenAt is set.",
+                    "type": "boolean"
+                }
+            }
+        },
+        "model.AuditLog": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "duration": {
+                    "type": "number"
+                },
+                "entityId": {
+                    "description": "EntityID is the id in the route of the call, if it has one.",
+                    "type": "string"
+                },
+                "hash": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "mainFunc": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "nameFunc": {
+                    "type": "string"
+                },
+                "newData": {
+                    "type": "string"
+                },
+                "oldData": {
+                    "type": "string"
+                },
+                "orgId": {
+                    "type": "string"
+                },
+                "prevHash": {
+                    "description": "PrevHash is the Hash of the org's previous row; both are empty on rows\nwritten before the chain existed.",
+                    "type": "string"
+                },
+                "resData": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "subFunc": {
+                    "type": "string"
+                },
+                "txId": {
+                    "type": "string"
+                },
+                "uniqueId": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.AuditLogPage": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.AuditLog"
+                    }
+                },
+                "nextCursor": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Case": {
+            "type": "object",
+            "properties": {
+                "arrivedDate": {
+                    "type": "string"
+                },
+                "caseDetail": {
+                    "type": "string"
+                },
+                "caseDuration": {
+                    "type": "integer"
+                },
+                "caseId": {
+                    "type": "string"
+                },
+                "caseLat": {
+                    "type": "string"
+                },
+                "caseLon": {
+                    "type": "string"
+                },
+                "caseSTypeId": {
+                    "type": "string"
+                },
+                "caseTypeId": {
+                    "type": "string"
+                },
+                "caseVersion": {
+                    "type": "string"
+                },
+                "caselocAddr": {
+                    "type": "string"
+                },
+                "caselocAddrDecs": {
+                    "type": "string"
+                },
+                "closedDate": {
+                    "type": "string"
+                },
+                "commandedDate": {
+                    "type": "string"
+                },
+                "countryId": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdBy": {
+                    "type": "string"
+                },
+                "createdDate": {
+                    "type": "string"
+                },
+                "currentStage": {},
+                "deviceId": {
+                    "type": "string"
+                },
+                "distId": {
+                    "type": "string"
+                },
+                "extReceive": {
+                    "type": "string"
+                },
+                "formSubmissions": {
+                    "description": "FormSubmissions holds the latest revision of each form submitted for\nthe case; only CaseById fills it.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.FormSubmission"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "orgId": {
+                    "type": "string"
+                },
+                "phoneNo": {
+                    "type": "string"
+                },
+                "phoneNoHide": {
+                    "type": "boolean"
+                },
+                "priority": {
+                    "type": "integer"
+                },
+                "provId": {
+                    "type": "string"
+                },
+                "receivedDate": {
+                    "type": "string"
+                },
+                "referCaseId": {
+                    "type": "string"
+                },
+                "resDetail": {
+                    "type": "string"
+                },
+                "resId": {
+                    "type": "string"
+                },
+                "scheduleDate": {
+                    "type": "string"
+                },
+                "scheduleFlag": {
+                    "type": "boolean"
+                },
+                "sop": {},
+                "source": {
+                    "type": "string"
+                },
+                "startedDate": {
+                    "type": "string"
+                },
+                "statusId": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "updatedBy": {
+                    "type": "string"
+                },
+                "userarrive": {
+                    "type": "string"
+                },
+                "userclose": {
+                    "type": "string"
+                },
+                "usercommand": {
+                    "type": "string"
+                },
+                "usercreate": {
+                    "type": "string"
+                },
+                "userreceive": {
+                    "type": "string"
+                },
+                "versions": {
+                    "type": "string"
+                },
+                "wfId": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.CaseAdvance": {
+            "type": "object",
+            "properties": {
+                "label": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.CaseAdvanced": {
+            "type": "object",
+            "properties": {
+                "caseId": {
+                    "type": "string"
+                },
+                "currentStage": {
+                    "$ref": "#/definitions/model.CurrentStage"
+                },
+                "fromNodeId": {
+                    "type": "string"
+                },
+                "label": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.CaseCreated": {
+            "type": "object",
+            "properties": {
+                "caseId": {
+                    "type": "string"
+                },
+                "currentStage": {
+                    "$ref": "#/definitions/model.CurrentStage"
+                },
+                "id": {
+                    "type": "string"
                 }
             }
-        }
-    },
-    "definitions": {
+        },
+        "model.CaseDispatch": {
+            "type": "object",
+            "properties": {
+                "acknowledgedAt": {
+                    "type": "string"
+                },
+                "assignedAt": {
+                    "type": "string"
+                },
+                "assignedBy": {
+                    "type": "string"
+                },
+                "caseId": {
+                    "type": "string"
+                },
+                "completedAt": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdBy": {
+                    "type": "string"
+                },
+                "enRouteAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "onSceneAt": {
+                    "type": "string"
+                },
+                "orgId": {
+                    "type": "string"
+                },
+                "releasedAt": {
+                    "type": "string"
+                },
+                "releasedBy": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "unitId": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "updatedBy": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
         "model.CaseHistoryInsert": {
             "type": "object",
             "properties": {
@@ -6670,15 +9311,9 @@ const docTemplate = `{
                 "caseVersion"
             ],
             "properties": {
-                "arrivedDate": {
-                    "type": "string"
-                },
                 "caseDetail": {
                     "type": "string"
                 },
-                "caseDuration": {
-                    "type": "integer"
-                },
                 "caseId": {
                     "type": "string"
                 },
@@ -6703,12 +9338,6 @@ const docTemplate = `{
                 "caselocAddrDecs": {
                     "type": "string"
                 },
-                "closedDate": {
-                    "type": "string"
-                },
-                "commandedDate": {
-                    "type": "string"
-                },
                 "countryId": {
                     "type": "string"
                 },
@@ -6725,6 +9354,7 @@ const docTemplate = `{
                     "type": "string"
                 },
                 "nodeId": {
+                    "description": "optional; must be WfID's start node",
                     "type": "string"
                 },
                 "phoneNo": {
@@ -6739,9 +9369,6 @@ const docTemplate = `{
                 "provId": {
                     "type": "string"
                 },
-                "receivedDate": {
-                    "type": "string"
-                },
                 "referCaseId": {
                     "type": "string"
                 },
@@ -6763,22 +9390,42 @@ const docTemplate = `{
                 "startedDate": {
                     "type": "string"
                 },
-                "statusId": {
+                "usercreate": {
                     "type": "string"
                 },
-                "userarrive": {
+                "versions": {
                     "type": "string"
                 },
-                "userclose": {
+                "wfId": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.CaseStageHistory": {
+            "type": "object",
+            "properties": {
+                "caseId": {
                     "type": "string"
                 },
-                "usercommand": {
+                "createdAt": {
                     "type": "string"
                 },
-                "usercreate": {
+                "createdBy": {
                     "type": "string"
                 },
-                "userreceive": {
+                "fromNodeId": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "orgId": {
+                    "type": "string"
+                },
+                "toNodeId": {
                     "type": "string"
                 },
                 "versions": {
@@ -6789,6 +9436,37 @@ const docTemplate = `{
                 }
             }
         },
+        "model.CaseStatusChange": {
+            "type": "object",
+            "required": [
+                "statusId"
+            ],
+            "properties": {
+                "note": {
+                    "type": "string"
+                },
+                "statusId": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.CaseStatusChanged": {
+            "type": "object",
+            "properties": {
+                "case": {
+                    "$ref": "#/definitions/model.Case"
+                },
+                "fromStatusId": {
+                    "type": "string"
+                },
+                "stamp": {
+                    "type": "string"
+                },
+                "toStatusId": {
+                    "type": "string"
+                }
+            }
+        },
         "model.CaseStatusInsert": {
             "type": "object",
             "properties": {
@@ -6806,6 +9484,61 @@ const docTemplate = `{
                 }
             }
         },
+        "model.CaseStatusTransition": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdBy": {
+                    "type": "string"
+                },
+                "fromStatusId": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "orgId": {
+                    "type": "string"
+                },
+                "stamp": {
+                    "type": "string"
+                },
+                "toStatusId": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "updatedBy": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.CaseStatusTransitionInput": {
+            "type": "object",
+            "required": [
+                "toStatusId"
+            ],
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "fromStatusId": {
+                    "type": "string"
+                },
+                "stamp": {
+                    "type": "string"
+                },
+                "toStatusId": {
+                    "type": "string"
+                }
+            }
+        },
         "model.CaseStatusUpdate": {
             "type": "object",
             "properties": {
@@ -6933,15 +9666,9 @@ const docTemplate = `{
         "model.CaseUpdate": {
             "type": "object",
             "properties": {
-                "arrivedDate": {
-                    "type": "string"
-                },
                 "caseDetail": {
                     "type": "string"
                 },
-                "caseDuration": {
-                    "type": "integer"
-                },
                 "caseLat": {
                     "type": "string"
                 },
@@ -6963,12 +9690,6 @@ const docTemplate = `{
                 "caselocAddrDecs": {
                     "type": "string"
                 },
-                "closedDate": {
-                    "type": "string"
-                },
-                "commandedDate": {
-                    "type": "string"
-                },
                 "countryId": {
                     "type": "string"
                 },
@@ -6996,49 +9717,28 @@ const docTemplate = `{
                 "provId": {
                     "type": "string"
                 },
-                "receivedDate": {
-                    "type": "string"
-                },
                 "referCaseId": {
                     "type": "string"
                 },
-                "resDetail": {
-                    "type": "string"
-                },
-                "resId": {
-                    "type": "string"
-                },
-                "scheduleDate": {
-                    "type": "string"
-                },
-                "scheduleFlag": {
-                    "type": "boolean"
-                },
-                "source": {
-                    "type": "string"
-                },
-                "startedDate": {
-                    "type": "string"
-                },
-                "statusId": {
+                "resDetail": {
                     "type": "string"
                 },
-                "userarrive": {
+                "resId": {
                     "type": "string"
                 },
-                "userclose": {
+                "scheduleDate": {
                     "type": "string"
                 },
-                "usercommand": {
-                    "type": "string"
+                "scheduleFlag": {
+                    "type": "boolean"
                 },
-                "usercreate": {
+                "source": {
                     "type": "string"
                 },
-                "userreceive": {
+                "startedDate": {
                     "type": "string"
                 },
-                "wfId": {
+                "usercreate": {
                     "type": "string"
                 }
             }
@@ -7085,6 +9785,41 @@ const docTemplate = `{
                 }
             }
         },
+        "model.CurrentStage": {
+            "type": "object",
+            "properties": {
+                "caseId": {
+                    "type": "string"
+                },
+                "data": {
+                    "description": "jsonb"
+                },
+                "formId": {
+                    "type": "string"
+                },
+                "group": {
+                    "type": "string"
+                },
+                "nodeId": {
+                    "type": "string"
+                },
+                "pic": {
+                    "type": "string"
+                },
+                "section": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "versions": {
+                    "type": "string"
+                },
+                "wfId": {
+                    "type": "string"
+                }
+            }
+        },
         "model.CustomerContactInsert": {
             "type": "object",
             "properties": {
@@ -7308,26 +10043,281 @@ const docTemplate = `{
                 }
             }
         },
+        "model.DispatchStatusReport": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "note": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.DispatchUnits": {
+            "type": "object",
+            "required": [
+                "unitIds"
+            ],
+            "properties": {
+                "note": {
+                    "type": "string"
+                },
+                "unitIds": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.EscalationPolicy": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdBy": {
+                    "type": "string"
+                },
+                "eventType": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "orgId": {
+                    "type": "string"
+                },
+                "steps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.EscalationStep"
+                    }
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "updatedBy": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.EscalationPolicyInput": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "eventType": {
+                    "type": "string"
+                },
+                "steps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.EscalationStep"
+                    }
+                }
+            }
+        },
+        "model.EscalationStep": {
+            "type": "object",
+            "properties": {
+                "afterMinutes": {
+                    "type": "integer"
+                },
+                "recipientType": {
+                    "description": "commId, deptId or orgId",
+                    "type": "string"
+                }
+            }
+        },
         "model.FormActive": {
             "type": "object",
             "properties": {
                 "active": {
                     "type": "boolean"
                 },
-                "formId": {
+                "formId": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.FormByCasesubtype": {
+            "type": "object",
+            "properties": {
+                "caseSubType": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.FormDiff": {
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.FormFieldChange"
+                    }
+                },
+                "formId": {
+                    "type": "string"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.FormFieldChange": {
+            "type": "object",
+            "properties": {
+                "after": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "before": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "change": {
+                    "type": "string"
+                },
+                "field": {
+                    "type": "string"
+                },
+                "keys": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.FormFieldError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "field": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.FormInsert": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "formColSpan": {
+                    "type": "integer"
+                },
+                "formFieldJson": {
+                    "type": "array",
+                    "items": {
+                        "type": "object",
+                        "additionalProperties": true
+                    }
+                },
+                "formName": {
+                    "type": "string"
+                },
+                "locks": {
+                    "type": "boolean"
+                },
+                "publish": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "model.FormLock": {
+            "type": "object",
+            "properties": {
+                "formId": {
+                    "type": "string"
+                },
+                "locks": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "model.FormPublish": {
+            "type": "object",
+            "properties": {
+                "formId": {
+                    "type": "string"
+                },
+                "publish": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "model.FormSubmission": {
+            "type": "object",
+            "properties": {
+                "caseId": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdBy": {
+                    "type": "string"
+                },
+                "data": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "formId": {
+                    "type": "string"
+                },
+                "formVersions": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "nodeId": {
+                    "type": "string"
+                },
+                "orgId": {
                     "type": "string"
+                },
+                "revision": {
+                    "type": "integer"
                 }
             }
         },
-        "model.FormByCasesubtype": {
+        "model.FormSubmissionInsert": {
             "type": "object",
+            "required": [
+                "data"
+            ],
             "properties": {
-                "caseSubType": {
+                "data": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "nodeId": {
                     "type": "string"
                 }
             }
         },
-        "model.FormInsert": {
+        "model.FormUpdate": {
             "type": "object",
             "properties": {
                 "active": {
@@ -7354,52 +10344,32 @@ const docTemplate = `{
                 }
             }
         },
-        "model.FormLock": {
+        "model.FormVersion": {
             "type": "object",
             "properties": {
-                "formId": {
-                    "type": "string"
+                "formColSpan": {
+                    "type": "integer"
                 },
-                "locks": {
-                    "type": "boolean"
-                }
-            }
-        },
-        "model.FormPublish": {
-            "type": "object",
-            "properties": {
                 "formId": {
                     "type": "string"
                 },
-                "publish": {
-                    "type": "boolean"
-                }
-            }
-        },
-        "model.FormUpdate": {
-            "type": "object",
-            "properties": {
-                "active": {
-                    "type": "boolean"
+                "formName": {
+                    "type": "string"
                 },
-                "formColSpan": {
-                    "type": "integer"
+                "id": {
+                    "type": "string"
                 },
-                "formFieldJson": {
-                    "type": "array",
-                    "items": {
-                        "type": "object",
-                        "additionalProperties": true
-                    }
+                "orgId": {
+                    "type": "string"
                 },
-                "formName": {
+                "publishedAt": {
                     "type": "string"
                 },
-                "locks": {
-                    "type": "boolean"
+                "publishedBy": {
+                    "type": "string"
                 },
-                "publish": {
-                    "type": "boolean"
+                "versions": {
+                    "type": "string"
                 }
             }
         },
@@ -7462,6 +10432,14 @@ const docTemplate = `{
                 }
             }
         },
+        "model.LogoutInput": {
+            "type": "object",
+            "properties": {
+                "refreshToken": {
+                    "type": "string"
+                }
+            }
+        },
         "model.MmdCompaniesInsert": {
             "type": "object",
             "properties": {
@@ -7863,6 +10841,13 @@ const docTemplate = `{
                         "$ref": "#/definitions/model.Data"
                     }
                 },
+                "escalations": {
+                    "description": "Escalations lists the escalation steps taken, oldest first.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.NotificationEscalation"
+                    }
+                },
                 "eventType": {
                     "type": "string"
                 },
@@ -7899,6 +10884,14 @@ const docTemplate = `{
                 "senderType": {
                     "description": "\"SYSTEM\" or \"USER\"",
                     "type": "string"
+                },
+                "state": {
+                    "description": "State is what the listing user has done with the notification.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.NotificationState"
+                        }
+                    ]
                 }
             }
         },
@@ -7947,6 +10940,120 @@ const docTemplate = `{
                 }
             }
         },
+        "model.NotificationEscalation": {
+            "type": "object",
+            "properties": {
+                "added": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Recipient"
+                    }
+                },
+                "escalatedAt": {
+                    "type": "string"
+                },
+                "level": {
+                    "type": "integer"
+                },
+                "recipientType": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.NotificationPage": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Notification"
+                    }
+                },
+                "nextCursor": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.NotificationReadInput": {
+            "type": "object",
+            "properties": {
+                "all": {
+                    "type": "boolean"
+                },
+                "ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "model.NotificationState": {
+            "type": "object",
+            "properties": {
+                "acknowledgedAt": {
+                    "type": "string"
+                },
+                "deliveredAt": {
+                    "type": "string"
+                },
+                "dismissedAt": {
+                    "type": "string"
+                },
+                "readAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.OutboxEntry": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "channel": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deliveredAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "lastError": {
+                    "type": "string"
+                },
+                "nextAttemptAt": {
+                    "type": "string"
+                },
+                "notificationId": {
+                    "type": "integer"
+                },
+                "orgId": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.PermissionEntry": {
+            "type": "object",
+            "properties": {
+                "groupName": {
+                    "type": "string"
+                },
+                "permId": {
+                    "type": "string"
+                },
+                "permName": {
+                    "type": "string"
+                }
+            }
+        },
         "model.PermissionInsert": {
             "type": "object",
             "properties": {
@@ -8165,26 +11272,6 @@ const docTemplate = `{
                 }
             }
         },
-        "model.UpdateStageRequest": {
-            "type": "object",
-            "properties": {
-                "caseId": {
-                    "type": "string"
-                },
-                "nodeId": {
-                    "type": "string"
-                },
-                "status": {
-                    "type": "string"
-                },
-                "unitId": {
-                    "type": "string"
-                },
-                "unitUser": {
-                    "type": "string"
-                }
-            }
-        },
         "model.UserAdminInput": {
             "type": "object",
             "properties": {
@@ -8631,6 +11718,104 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "model.WorkflowIssue": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "edgeId": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "nodeId": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.WorkflowValidation": {
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.WorkflowIssue"
+                    }
+                },
+                "valid": {
+                    "type": "boolean"
+                },
+                "warnings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.WorkflowIssue"
+                    }
+                }
+            }
+        },
+        "model.WorkflowVersion": {
+            "type": "object",
+            "properties": {
+                "desc": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "orgId": {
+                    "type": "string"
+                },
+                "publishedAt": {
+                    "type": "string"
+                },
+                "publishedBy": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "versions": {
+                    "type": "string"
+                },
+                "wfId": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.WsHubStats": {
+            "type": "object",
+            "properties": {
+                "dropped": {
+                    "type": "integer"
+                },
+                "maxQueueDepth": {
+                    "type": "integer"
+                },
+                "queueCapacity": {
+                    "type": "integer"
+                },
+                "queueDepth": {
+                    "type": "integer"
+                },
+                "sent": {
+                    "type": "integer"
+                },
+                "sessions": {
+                    "type": "integer"
+                },
+                "slowConsumerClosed": {
+                    "type": "integer"
+                },
+                "users": {
+                    "type": "integer"
+                },
+                "writeFailed": {
+                    "type": "integer"
+                }
+            }
         }
     },
     "securityDefinitions": {
@@ -0,0 +1,60 @@
+package formdata
+
+import (
+	"mainPackage/model"
+	"reflect"
+	"sort"
+)
+
+// Diff compares two versions of a form field by field, matching fields by
+// id. Fields are reported in the order of the newer version, followed by the
+// removed ones.
+func Diff(from, to []map[string]interface{}) []model.FormFieldChange {
+	before := map[string]map[string]interface{}{}
+	for _, f := range flatten(from) {
+		if id := fieldID(f); id != "" {
+			before[id] = f
+		}
+	}
+	changes := []model.FormFieldChange{}
+	seen := map[string]bool{}
+	for _, f := range flatten(to) {
+		id := fieldID(f)
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		old, ok := before[id]
+		if !ok {
+			changes = append(changes, model.FormFieldChange{Field: id, Change: "added", After: f})
+			continue
+		}
+		if keys := changedKeys(old, f); len(keys) > 0 {
+			changes = append(changes, model.FormFieldChange{Field: id, Change: "changed", Keys: keys, Before: old, After: f})
+		}
+	}
+	for _, f := range flatten(from) {
+		id := fieldID(f)
+		if id != "" && !seen[id] {
+			seen[id] = true
+			changes = append(changes, model.FormFieldChange{Field: id, Change: "removed", Before: f})
+		}
+	}
+	return changes
+}
+
+func changedKeys(a, b map[string]interface{}) []string {
+	var keys []string
+	for k, v := range a {
+		if w, ok := b[k]; !ok || !reflect.DeepEqual(v, w) {
+			keys = append(keys, k)
+		}
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,50 @@
+package formdata
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	from := fields(t, `[
+		{"id": "name", "type": "textInput", "label": "Name"},
+		{"id": "age", "type": "numberInput", "max": 120},
+		{"id": "old", "type": "textInput"},
+		{"id": "group", "type": "InputGroup", "value": [{"id": "phone", "type": "phoneInput"}]}
+	]`)
+	to := fields(t, `[
+		{"id": "email", "type": "emailInput"},
+		{"id": "name", "type": "textInput", "label": "Name"},
+		{"id": "age", "type": "numberInput", "max": 150, "required": true},
+		{"id": "group", "type": "InputGroup", "value": [{"id": "phone", "type": "phoneInput", "required": true}]}
+	]`)
+
+	changes := Diff(from, to)
+	var got []string
+	for _, c := range changes {
+		got = append(got, c.Field+":"+c.Change)
+	}
+	want := []string{"email:added", "age:changed", "phone:changed", "old:removed"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("Diff = %v, want %v", got, want)
+	}
+	if keys := changes[1].Keys; !slices.Equal(keys, []string{"max", "required"}) {
+		t.Errorf("age changed keys = %v, want [max required]", keys)
+	}
+	if changes[0].Before != nil || changes[0].After["id"] != "email" {
+		t.Errorf("added field = %+v", changes[0])
+	}
+	if changes[3].After != nil || changes[3].Before["id"] != "old" {
+		t.Errorf("removed field = %+v", changes[3])
+	}
+}
+
+func TestDiffUnchanged(t *testing.T) {
+	form := fields(t, testForm)
+	if changes := Diff(form, fields(t, testForm)); len(changes) != 0 {
+		t.Errorf("Diff of identical forms = %+v, want none", changes)
+	}
+	if changes := Diff(nil, nil); changes == nil {
+		t.Error("Diff of empty forms = nil, want an empty list")
+	}
+}
@@ -0,0 +1,243 @@
+// Package formdata checks values submitted for a form against the field
+// definitions stored in form_elements.eleData.
+package formdata
+
+import (
+	"fmt"
+	"mainPackage/model"
+	"math"
+	"strings"
+	"time"
+)
+
+// Validate checks data against the form's fields and returns one error per
+// offending field. Keys in data that match no field are reported too, so a
+// typo never silently drops a value.
+func Validate(fields []map[string]interface{}, data map[string]interface{}) []model.FormFieldError {
+	var errs []model.FormFieldError
+	known := map[string]bool{}
+	for _, f := range flatten(fields) {
+		id := fieldID(f)
+		if id == "" {
+			continue
+		}
+		known[id] = true
+		value, present := data[id]
+		if !present || empty(value) {
+			if truthy(f["required"]) {
+				errs = append(errs, fieldError(id, "required", "%s is required", label(f)))
+			}
+			continue
+		}
+		if err := checkValue(id, f, value); err != nil {
+			errs = append(errs, *err)
+		}
+	}
+	for key := range data {
+		if !known[key] {
+			errs = append(errs, fieldError(key, "unknown", "%s is not a field of this form", key))
+		}
+	}
+	return errs
+}
+
+// kind groups the designer's many input types by the value they hold.
+func kind(fieldType string) string {
+	t := strings.ToLower(fieldType)
+	switch {
+	case strings.Contains(t, "multi"), strings.Contains(t, "checkbox"):
+		return "multi"
+	case strings.Contains(t, "select"), strings.Contains(t, "radio"), strings.Contains(t, "dropdown"), strings.Contains(t, "option"):
+		return "option"
+	case strings.Contains(t, "number"):
+		return "number"
+	case strings.Contains(t, "date"), strings.Contains(t, "time"):
+		return "date"
+	case strings.Contains(t, "email"):
+		return "email"
+	case strings.Contains(t, "text"), strings.Contains(t, "password"), strings.Contains(t, "phone"):
+		return "text"
+	}
+	return ""
+}
+
+func checkValue(id string, f map[string]interface{}, value interface{}) *model.FormFieldError {
+	name := label(f)
+	typ, _ := f["type"].(string)
+	switch kind(typ) {
+	case "text", "email":
+		s, ok := value.(string)
+		if !ok {
+			e := fieldError(id, "type", "%s must be text", name)
+			return &e
+		}
+		if kind(typ) == "email" && !strings.Contains(s, "@") {
+			e := fieldError(id, "type", "%s must be an email address", name)
+			return &e
+		}
+		return checkRange(id, f, float64(len([]rune(s))), "%s must be at least %v characters", "%s must be at most %v characters")
+	case "number":
+		n, ok := value.(float64)
+		if !ok || math.IsNaN(n) {
+			e := fieldError(id, "type", "%s must be a number", name)
+			return &e
+		}
+		return checkRange(id, f, n, "%s must be at least %v", "%s must be at most %v")
+	case "date":
+		s, ok := value.(string)
+		if !ok || !isDate(s) {
+			e := fieldError(id, "type", "%s must be a date", name)
+			return &e
+		}
+	case "option":
+		s, ok := value.(string)
+		if !ok {
+			e := fieldError(id, "type", "%s must be one of the options", name)
+			return &e
+		}
+		if opts := options(f); opts != nil && !opts[s] {
+			e := fieldError(id, "option", "%s is not an option of %s", s, name)
+			return &e
+		}
+	case "multi":
+		list, ok := value.([]interface{})
+		if !ok {
+			e := fieldError(id, "type", "%s must be a list", name)
+			return &e
+		}
+		opts := options(f)
+		for _, item := range list {
+			s, ok := item.(string)
+			if !ok || (opts != nil && !opts[s]) {
+				e := fieldError(id, "option", "%v is not an option of %s", item, name)
+				return &e
+			}
+		}
+		return checkRange(id, f, float64(len(list)), "%s needs at least %v choices", "%s allows at most %v choices")
+	}
+	return nil
+}
+
+// checkRange applies the field's min and max to n: the value of a number,
+// the length of text or the number of choices.
+func checkRange(id string, f map[string]interface{}, n float64, below, above string) *model.FormFieldError {
+	if min, ok := number(f["min"]); ok && n < min {
+		e := fieldError(id, "min", below, label(f), min)
+		return &e
+	}
+	if max, ok := number(f["max"]); ok && n > max {
+		e := fieldError(id, "max", above, label(f), max)
+		return &e
+	}
+	return nil
+}
+
+// flatten returns the input fields, descending into groups whose value
+// holds child field definitions.
+func flatten(fields []map[string]interface{}) []map[string]interface{} {
+	var out []map[string]interface{}
+	for _, f := range fields {
+		children, ok := f["value"].([]interface{})
+		if ok && len(children) > 0 && isField(children[0]) {
+			var nested []map[string]interface{}
+			for _, c := range children {
+				if m, ok := c.(map[string]interface{}); ok {
+					nested = append(nested, m)
+				}
+			}
+			out = append(out, flatten(nested)...)
+			continue
+		}
+		out = append(out, f)
+	}
+	return out
+}
+
+func isField(v interface{}) bool {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	_, hasType := m["type"]
+	return hasType && fieldID(m) != ""
+}
+
+func fieldID(f map[string]interface{}) string {
+	for _, key := range []string{"id", "name"} {
+		if s, ok := f[key].(string); ok && s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+func label(f map[string]interface{}) string {
+	if s, ok := f["label"].(string); ok && s != "" {
+		return s
+	}
+	return fieldID(f)
+}
+
+// options returns the allowed values, or nil when the field lists none.
+// Options may be plain strings or objects with a value.
+func options(f map[string]interface{}) map[string]bool {
+	list, ok := f["options"].([]interface{})
+	if !ok || len(list) == 0 {
+		return nil
+	}
+	set := map[string]bool{}
+	for _, o := range list {
+		switch v := o.(type) {
+		case string:
+			set[v] = true
+		case map[string]interface{}:
+			if s, ok := v["value"].(string); ok {
+				set[s] = true
+			}
+		}
+	}
+	return set
+}
+
+func number(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case string:
+		var f float64
+		if _, err := fmt.Sscan(n, &f); err == nil {
+			return f, true
+		}
+	}
+	return 0, false
+}
+
+func truthy(v interface{}) bool {
+	b, _ := v.(bool)
+	return b
+}
+
+func empty(v interface{}) bool {
+	switch x := v.(type) {
+	case nil:
+		return true
+	case string:
+		return strings.TrimSpace(x) == ""
+	case []interface{}:
+		return len(x) == 0
+	}
+	return false
+}
+
+func isDate(s string) bool {
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04", "2006-01-02 15:04:05", "2006-01-02"} {
+		if _, err := time.Parse(layout, s); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+func fieldError(field, code, format string, args ...interface{}) model.FormFieldError {
+	return model.FormFieldError{Field: field, Code: code, Message: fmt.Sprintf(format, args...)}
+}
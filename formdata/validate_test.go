@@ -0,0 +1,97 @@
+package formdata
+
+import (
+	"encoding/json"
+	"mainPackage/model"
+	"testing"
+)
+
+// fields decodes a form definition the way it comes out of eleData.
+func fields(t *testing.T, src string) []map[string]interface{} {
+	t.Helper()
+	var list []map[string]interface{}
+	if err := json.Unmarshal([]byte(src), &list); err != nil {
+		t.Fatal(err)
+	}
+	return list
+}
+
+func data(t *testing.T, src string) map[string]interface{} {
+	t.Helper()
+	var m map[string]interface{}
+	if err := json.Unmarshal([]byte(src), &m); err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func byField(errs []model.FormFieldError) map[string]string {
+	m := map[string]string{}
+	for _, e := range errs {
+		m[e.Field] = e.Code
+	}
+	return m
+}
+
+const testForm = `[
+	{"id": "name", "type": "textInput", "label": "Name", "required": true, "min": 2, "max": 10},
+	{"id": "email", "type": "emailInput"},
+	{"id": "age", "type": "numberInput", "min": "0", "max": 120},
+	{"id": "seen", "type": "dateInput"},
+	{"id": "kind", "type": "select", "options": ["fire", {"value": "flood"}]},
+	{"id": "tags", "type": "multiCheckbox", "options": ["a", "b", "c"], "max": 2},
+	{"id": "group", "type": "InputGroup", "value": [
+		{"id": "phone", "type": "phoneInput", "required": true}
+	]}
+]`
+
+func TestValidateAccepts(t *testing.T) {
+	errs := Validate(fields(t, testForm), data(t, `{
+		"name": "Alice", "email": "a@b.c", "age": 30, "seen": "2024-01-02",
+		"kind": "flood", "tags": ["a", "c"], "phone": "0812345678"
+	}`))
+	if len(errs) != 0 {
+		t.Errorf("Validate = %+v, want no errors", errs)
+	}
+}
+
+func TestValidateOptionalFieldsMayBeEmpty(t *testing.T) {
+	errs := Validate(fields(t, testForm), data(t, `{"name": "Alice", "email": "", "tags": [], "phone": "1"}`))
+	if len(errs) != 0 {
+		t.Errorf("Validate = %+v, want no errors", errs)
+	}
+}
+
+func TestValidateRejects(t *testing.T) {
+	tests := []struct {
+		name, data, field, code string
+	}{
+		{"required missing", `{"phone": "1"}`, "name", "required"},
+		{"required blank", `{"name": "  ", "phone": "1"}`, "name", "required"},
+		{"nested required", `{"name": "Alice"}`, "phone", "required"},
+		{"text too short", `{"name": "A", "phone": "1"}`, "name", "min"},
+		{"text too long", `{"name": "Alexandrina!", "phone": "1"}`, "name", "max"},
+		{"text not a string", `{"name": 5, "phone": "1"}`, "name", "type"},
+		{"bad email", `{"name": "Alice", "email": "alice", "phone": "1"}`, "email", "type"},
+		{"number as text", `{"name": "Alice", "age": "30", "phone": "1"}`, "age", "type"},
+		{"number below min", `{"name": "Alice", "age": -1, "phone": "1"}`, "age", "min"},
+		{"number above max", `{"name": "Alice", "age": 121, "phone": "1"}`, "age", "max"},
+		{"bad date", `{"name": "Alice", "seen": "yesterday", "phone": "1"}`, "seen", "type"},
+		{"unknown option", `{"name": "Alice", "kind": "quake", "phone": "1"}`, "kind", "option"},
+		{"unknown choice", `{"name": "Alice", "tags": ["z"], "phone": "1"}`, "tags", "option"},
+		{"too many choices", `{"name": "Alice", "tags": ["a", "b", "c"], "phone": "1"}`, "tags", "max"},
+		{"choices not a list", `{"name": "Alice", "tags": "a", "phone": "1"}`, "tags", "type"},
+		{"unknown key", `{"name": "Alice", "phone": "1", "nmae": "x"}`, "nmae", "unknown"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := byField(Validate(fields(t, testForm), data(t, tt.data)))
+			if errs[tt.field] != tt.code {
+				t.Errorf("Validate errors = %v, want %s on %s", errs, tt.code, tt.field)
+			}
+			if len(errs) != 1 {
+				t.Errorf("Validate errors = %v, want only %s", errs, tt.field)
+			}
+		})
+	}
+}
@@ -0,0 +1,441 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"mainPackage/auditchain"
+	"mainPackage/model"
+	"maps"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	// auditQueue is how many entries may wait for the writer; past it
+	// entries are dropped rather than holding up the request.
+	auditQueue = 4096
+	// auditBatch is how many entries of one org an insert writes.
+	auditBatch = 100
+	// auditPending caps the entries the writer holds while inserts fail;
+	// past it the writer stops draining the queue until the database is back.
+	auditPending = 50000
+	// auditRetryMin and auditRetryMax bound the wait between attempts to
+	// write entries that failed.
+	auditRetryMin = time.Second
+	auditRetryMax = time.Minute
+	// auditFlush bounds how long an entry waits for its batch to fill.
+	auditFlush = time.Second
+	// auditMaxData caps each of newData, oldData and resData.
+	auditMaxData = 64 * 1024
+
+	auditOldKey = "auditOldData"
+
+	auditRedacted = "[REDACTED]"
+)
+
+// auditSecretKeys are the parts of a JSON key whose value is never written
+// to the audit log.
+var auditSecretKeys = []string{"password", "token", "secret", "otp", "authorization", "credential"}
+
+var (
+	auditEntries = make(chan model.AuditLog, auditQueue)
+	auditDropped atomic.Int64
+)
+
+// recordAudit queues e for the writer without blocking.
+func recordAudit(e model.AuditLog) {
+	select {
+	case auditEntries <- e:
+	default:
+		// every drop is logged with enough to reconstruct who did what
+		log.Printf("ERROR: Audit queue full, entry dropped (%d so far): org=%q user=%q action=%q func=%q entity=%q at=%s",
+			auditDropped.Add(1), e.OrgID, e.Username, e.Action, e.NameFunc, e.EntityID, e.CreatedAt.Format(time.RFC3339Nano))
+	}
+}
+
+// StartAuditWriter writes queued audit entries in batches until ctx is done,
+// then writes what is left. Entries that fail to write are kept and retried
+// with backoff.
+func StartAuditWriter(ctx context.Context) {
+	log.Println("Starting audit log writer...")
+	ticker := time.NewTicker(auditFlush)
+	defer ticker.Stop()
+	var pending []model.AuditLog
+	var retryAt time.Time
+	backoff := auditRetryMin
+	flush := func() {
+		if len(pending) == 0 || time.Now().Before(retryAt) {
+			return
+		}
+		pending = writeAudit(pending)
+		if len(pending) == 0 {
+			retryAt, backoff = time.Time{}, auditRetryMin
+			return
+		}
+		log.Printf("ERROR: %d audit entries not written, retrying in %s", len(pending), backoff)
+		retryAt = time.Now().Add(backoff)
+		backoff = min(backoff*2, auditRetryMax)
+	}
+	for {
+		queue := auditEntries
+		if len(pending) >= auditPending {
+			queue = nil
+		}
+		select {
+		case e := <-queue:
+			pending = append(pending, e)
+			if len(pending)%auditBatch == 0 {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-ctx.Done():
+		drain:
+			for len(pending) < auditPending {
+				select {
+				case e := <-auditEntries:
+					pending = append(pending, e)
+				default:
+					break drain
+				}
+			}
+			retryAt = time.Time{}
+			flush()
+			if len(pending) > 0 {
+				log.Printf("ERROR: %d audit entries lost at shutdown", len(pending))
+			}
+			return
+		}
+	}
+}
+
+// writeAudit inserts entries one org at a time, auditBatch per transaction,
+// and returns those not written. An org whose insert fails keeps the rest of
+// its entries back so its chain stays in order; other orgs still commit.
+func writeAudit(entries []model.AuditLog) []model.AuditLog {
+	byOrg := make(map[string][]model.AuditLog)
+	var orgs []string
+	for _, e := range entries {
+		if _, ok := byOrg[e.OrgID]; !ok {
+			orgs = append(orgs, e.OrgID)
+		}
+		byOrg[e.OrgID] = append(byOrg[e.OrgID], e)
+	}
+	var left []model.AuditLog
+	for _, orgId := range orgs {
+		rest := byOrg[orgId]
+		for len(rest) > 0 {
+			n := min(len(rest), auditBatch)
+			repo, qctx, cancel := getStore(context.Background())
+			err := repo.Audit.Insert(qctx, rest[:n])
+			cancel()
+			if err != nil {
+				log.Printf("ERROR: Failed to write %d audit entries of org %q: %v", n, orgId, err)
+				break
+			}
+			rest = rest[n:]
+		}
+		left = append(left, rest...)
+	}
+	return left
+}
+
+// redactAudit re-encodes a JSON document with the values of secret keys
+// replaced, and caps it at auditMaxData. Anything that is not JSON is
+// replaced by a note of its size, since it cannot be searched for secrets.
+func redactAudit(raw []byte) string {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 {
+		return ""
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return fmt.Sprintf("[non-JSON body, %d bytes]", len(raw))
+	}
+	out, err := json.Marshal(redactValue(v))
+	if err != nil {
+		return fmt.Sprintf("[unencodable body, %d bytes]", len(raw))
+	}
+	if len(out) > auditMaxData {
+		out = out[:auditMaxData]
+	}
+	return string(out)
+}
+
+func redactValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, child := range t {
+			if isSecretKey(k) {
+				t[k] = auditRedacted
+			} else {
+				t[k] = redactValue(child)
+			}
+		}
+	case []interface{}:
+		for i := range t {
+			t[i] = redactValue(t[i])
+		}
+	}
+	return v
+}
+
+func isSecretKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, s := range auditSecretKeys {
+		if strings.Contains(key, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// auditPrior records v, the state of the row a handler is about to change,
+// as the oldData of the request's audit entry.
+func auditPrior(c *gin.Context, v interface{}) {
+	if raw, err := json.Marshal(v); err == nil {
+		c.Set(auditOldKey, raw)
+	}
+}
+
+// auditService records a change made outside a request, such as by a
+// background job. newData and oldData are encoded and redacted like request
+// data.
+func auditService(orgId, mainFunc, nameFunc, action string, newData, oldData interface{}, err error) {
+	e := model.AuditLog{
+		OrgID:     orgId,
+		Username:  "system",
+		TxID:      uuid.NewString(),
+		UniqueId:  uuid.NewString(),
+		MainFunc:  mainFunc,
+		SubFunc:   "service",
+		NameFunc:  nameFunc,
+		Action:    action,
+		Status:    http.StatusOK,
+		CreatedAt: time.Now(),
+	}
+	if newData != nil {
+		raw, _ := json.Marshal(newData)
+		e.NewData = redactAudit(raw)
+	}
+	if oldData != nil {
+		raw, _ := json.Marshal(oldData)
+		e.OldData = redactAudit(raw)
+	}
+	if err != nil {
+		e.Status = http.StatusInternalServerError
+		e.Message = err.Error()
+	}
+	recordAudit(e)
+}
+
+// auditResponseWriter keeps the first auditMaxData bytes of the response.
+type auditResponseWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *auditResponseWriter) Write(b []byte) (int, error) {
+	w.keep(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *auditResponseWriter) WriteString(s string) (int, error) {
+	w.keep([]byte(s))
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (w *auditResponseWriter) keep(b []byte) {
+	if room := auditMaxData - w.body.Len(); room > 0 {
+		w.body.Write(b[:min(len(b), room)])
+	}
+}
+
+// auditFuncs maps a route onto the mainFunc, subFunc and nameFunc columns:
+// the first path segment after the version, the route pattern and the
+// handler's function name.
+func auditFuncs(c *gin.Context) (string, string, string) {
+	path := c.FullPath()
+	rest := strings.TrimPrefix(path, "/api/v1/")
+	mainFunc, _, _ := strings.Cut(rest, "/")
+	name := c.HandlerName()
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		name = name[i+1:]
+	}
+	return mainFunc, path, name
+}
+
+// auditEntity is the record a route acts on: its id parameter, or else its
+// last parameter, as in /users/username/:username.
+func auditEntity(c *gin.Context) string {
+	if id := c.Param("id"); id != "" {
+		return id
+	}
+	if len(c.Params) > 0 {
+		return c.Params[len(c.Params)-1].Value
+	}
+	return ""
+}
+
+// auditMessage pulls the error text out of a failed response.
+func auditMessage(status int, body []byte) string {
+	if status < http.StatusBadRequest {
+		return ""
+	}
+	var res struct {
+		Desc  string `json:"desc"`
+		Error string `json:"error"`
+	}
+	if json.Unmarshal(body, &res) != nil {
+		return http.StatusText(status)
+	}
+	if res.Desc != "" {
+		return res.Desc
+	}
+	if res.Error != "" {
+		return res.Error
+	}
+	return http.StatusText(status)
+}
+
+// AuditTrail records every POST, PUT, PATCH and DELETE that reaches a route
+// in audit_logs, with its duration in milliseconds. The request id comes from
+// X-Request-Id when the client sends one and is echoed back; handlers add the
+// prior row state with auditPrior. It must run before ProtectedHandler so
+// it sees the whole chain.
+func AuditTrail() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		default:
+			c.Next()
+			return
+		}
+
+		start := time.Now()
+		txId := c.GetHeader("X-Request-Id")
+		if txId == "" {
+			txId = uuid.NewString()
+		}
+		c.Header("X-Request-Id", txId)
+
+		var newData string
+		if c.Request.Body != nil {
+			if strings.HasPrefix(c.ContentType(), "multipart/") {
+				newData = "[multipart body omitted]"
+			} else {
+				body, err := io.ReadAll(c.Request.Body)
+				_ = c.Request.Body.Close()
+				c.Request.Body = io.NopCloser(bytes.NewReader(body))
+				if err == nil {
+					newData = redactAudit(body)
+				}
+			}
+		}
+
+		w := &auditResponseWriter{ResponseWriter: c.Writer}
+		c.Writer = w
+		c.Next()
+
+		if c.FullPath() == "" {
+			return
+		}
+		mainFunc, subFunc, nameFunc := auditFuncs(c)
+		status := c.Writer.Status()
+		e := model.AuditLog{
+			OrgID:     c.GetString("orgId"),
+			Username:  c.GetString("username"),
+			TxID:      txId,
+			UniqueId:  uuid.NewString(),
+			MainFunc:  mainFunc,
+			SubFunc:   subFunc,
+			NameFunc:  nameFunc,
+			Action:    c.Request.Method,
+			Status:    status,
+			Duration:  float64(time.Since(start).Microseconds()) / 1000,
+			NewData:   newData,
+			ResData:   redactAudit(w.body.Bytes()),
+			Message:   auditMessage(status, w.body.Bytes()),
+			CreatedAt: start,
+			EntityID:  auditEntity(c),
+		}
+		if e.Username == "" {
+			// unauthenticated calls such as login name the user in the body
+			var body struct {
+				Username string `json:"username"`
+			}
+			_ = json.Unmarshal([]byte(newData), &body)
+			e.Username = body.Username
+		}
+		if old, ok := c.Get(auditOldKey); ok {
+			e.OldData = redactAudit(old.([]byte))
+		}
+		recordAudit(e)
+	}
+}
+
+// auditCheckpointEvery is how often the head of every org's audit chain is
+// signed and appended to the checkpoint file.
+const auditCheckpointEvery = time.Hour
+
+// StartAuditCheckpoints signs a checkpoint of each org's audit chain every
+// auditCheckpointEvery, after verifying the rows added since the last one.
+// It does nothing when AUDIT_CHECKPOINT_KEY is unset.
+func StartAuditCheckpoints() {
+	key, err := auditchain.LoadKey()
+	if err != nil {
+		log.Printf("WARNING: Audit checkpoints disabled: %v", err)
+		return
+	}
+	path := auditchain.File()
+	cps, err := auditchain.ReadFile(path)
+	if err != nil {
+		log.Printf("WARNING: Failed to read audit checkpoints, verifying from the start: %v", err)
+	}
+	last := auditchain.Latest(key.Public().(ed25519.PublicKey), cps)
+
+	log.Println("Starting background worker for audit checkpoints...")
+	ticker := time.NewTicker(auditCheckpointEvery)
+	go func() {
+		for {
+			<-ticker.C
+			last = writeAuditCheckpoints(key, path, last)
+		}
+	}()
+}
+
+// writeAuditCheckpoints appends a checkpoint for every org whose chain grew
+// and returns the checkpoints to continue from. last is kept when the file
+// cannot be written, so the next run signs the same rows again.
+func writeAuditCheckpoints(key ed25519.PrivateKey, path string, last map[string]model.AuditCheckpoint) map[string]model.AuditCheckpoint {
+	ctx, cancel := context.WithTimeout(context.Background(), auditVerifyTimeout)
+	defer cancel()
+	next := maps.Clone(last)
+	signed, broken, err := auditchain.Checkpoint(ctx, appStore.Audit, key, next)
+	for _, report := range broken {
+		log.Printf("ERROR: Audit chain of org %q broken at id %d: %s", report.OrgID, report.BrokenAt.ID, report.BrokenAt.Reason)
+	}
+	if err != nil {
+		log.Printf("ERROR: Audit checkpoint failed: %v", err)
+	}
+	if err := auditchain.Append(path, signed); err != nil {
+		log.Printf("ERROR: Failed to write %d audit checkpoints to %s: %v", len(signed), path, err)
+		return last
+	}
+	if len(signed) > 0 {
+		log.Printf("Scheduler: Wrote %d audit checkpoints.", len(signed))
+	}
+	return next
+}
@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"mainPackage/model"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRedactAudit(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"empty", "  ", ""},
+		{"plain", `{"name":"a","n":1.50}`, `{"n":1.50,"name":"a"}`},
+		{"secret keys", `{"username":"a","Password":"p","refreshToken":"t","apiSecret":"s"}`,
+			`{"Password":"[REDACTED]","apiSecret":"[REDACTED]","refreshToken":"[REDACTED]","username":"a"}`},
+		{"nested", `{"user":{"otp":"1"},"list":[{"credential":{"x":1}},2]}`,
+			`{"list":[{"credential":"[REDACTED]"},2],"user":{"otp":"[REDACTED]"}}`},
+		{"not JSON", "password=p", "[non-JSON body, 10 bytes]"},
+	}
+	for _, tt := range tests {
+		if got := redactAudit([]byte(tt.in)); got != tt.want {
+			t.Errorf("%s: redactAudit(%s) = %s, want %s", tt.name, tt.in, got, tt.want)
+		}
+	}
+
+	big, _ := json.Marshal(map[string]string{"v": strings.Repeat("x", auditMaxData)})
+	if got := redactAudit(big); len(got) != auditMaxData {
+		t.Errorf("redactAudit of %d bytes kept %d, want %d", len(big), len(got), auditMaxData)
+	}
+}
+
+// drainAudit empties the audit queue and returns what was in it.
+func drainAudit() []model.AuditLog {
+	var entries []model.AuditLog
+	for {
+		select {
+		case e := <-auditEntries:
+			entries = append(entries, e)
+		default:
+			return entries
+		}
+	}
+}
+
+func TestAuditTrail(t *testing.T) {
+	newTestStore(t)
+	drainAudit()
+	r := gin.New()
+	r.Use(AuditTrail())
+	v1 := r.Group("/api/v1", ProtectedHandler)
+	v1.GET("/users/:id", func(c *gin.Context) { c.Status(http.StatusOK) })
+	v1.PUT("/users/:id", func(c *gin.Context) {
+		auditPrior(c, map[string]string{"name": "old", "password": "hash"})
+		c.JSON(http.StatusConflict, model.Response{Status: "-1", Msg: "Failure", Desc: "taken", Data: map[string]string{"token": "t"}})
+	})
+	tok := accessToken(t)
+
+	do(t, r, http.MethodGet, "/api/v1/users/u1", tok, nil, nil)
+	if entries := drainAudit(); len(entries) != 0 {
+		t.Errorf("GET recorded %d entries, want none", len(entries))
+	}
+
+	body := strings.NewReader(`{"name":"new","password":"p"}`)
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/users/u1", body)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+tok)
+	req.Header.Set("X-Request-Id", "req-1")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if got := w.Header().Get("X-Request-Id"); got != "req-1" {
+		t.Errorf("X-Request-Id echoed as %q, want req-1", got)
+	}
+
+	entries := drainAudit()
+	if len(entries) != 1 {
+		t.Fatalf("PUT recorded %d entries, want 1", len(entries))
+	}
+	e := entries[0]
+	if e.OrgID != testOrg || e.Username != "alice" || e.TxID != "req-1" || e.Action != http.MethodPut {
+		t.Errorf("entry = org %q user %q tx %q action %q", e.OrgID, e.Username, e.TxID, e.Action)
+	}
+	if e.MainFunc != "users" || e.SubFunc != "/api/v1/users/:id" || e.EntityID != "u1" {
+		t.Errorf("entry = main %q sub %q entity %q", e.MainFunc, e.SubFunc, e.EntityID)
+	}
+	if e.Status != http.StatusConflict || e.Message != "taken" {
+		t.Errorf("entry = status %d message %q, want 409 taken", e.Status, e.Message)
+	}
+	for name, data := range map[string]string{"newData": e.NewData, "oldData": e.OldData, "resData": e.ResData} {
+		if data == "" || strings.Contains(data, `"p"`) || strings.Contains(data, `"hash"`) || strings.Contains(data, `"t"`) {
+			t.Errorf("%s = %s, want it recorded with secrets redacted", name, data)
+		}
+	}
+}
+
+func TestAuditTrailUnauthenticated(t *testing.T) {
+	newTestStore(t)
+	drainAudit()
+	r := gin.New()
+	r.Use(AuditTrail())
+	r.POST("/api/v1/auth/login", func(c *gin.Context) { c.Status(http.StatusUnauthorized) })
+
+	do(t, r, http.MethodPost, "/api/v1/auth/login", "", map[string]string{"username": "bob", "password": "p"}, nil)
+	entries := drainAudit()
+	if len(entries) != 1 || entries[0].Username != "bob" {
+		t.Fatalf("login recorded %+v, want one entry for bob", entries)
+	}
+	if entries[0].Message != http.StatusText(http.StatusUnauthorized) {
+		t.Errorf("message = %q, want the status text", entries[0].Message)
+	}
+}
+
+func TestWriteAudit(t *testing.T) {
+	m := newTestStore(t)
+	var entries []model.AuditLog
+	for range auditBatch + 1 {
+		entries = append(entries, model.AuditLog{OrgID: testOrg, Action: "POST"})
+	}
+	entries = append(entries, model.AuditLog{OrgID: "o2", Action: "POST"})
+	if left := writeAudit(entries); len(left) != 0 {
+		t.Fatalf("writeAudit left %d entries", len(left))
+	}
+	for org, want := range map[string]int{testOrg: auditBatch + 1, "o2": 1} {
+		list, err := m.Audit.ListChain(context.Background(), org, 0, 2*auditBatch)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(list) != want {
+			t.Errorf("org %s has %d rows, want %d", org, len(list), want)
+		}
+	}
+}
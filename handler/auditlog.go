@@ -1,16 +1,34 @@
 package handler
 
 import (
+	"context"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mainPackage/auditchain"
 	"mainPackage/config"
 	"mainPackage/model"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/jackc/pgx/v5"
 	"go.uber.org/zap"
 )
 
+const (
+	// auditDefaultLimit and auditMaxLimit bound a page of audit rows.
+	auditDefaultLimit = 50
+	auditMaxLimit     = 1000
+	// auditExportPage is how many rows an export reads per query.
+	auditExportPage = 1000
+	// auditVerifyTimeout bounds a walk of one org's audit chain.
+	auditVerifyTimeout = 2 * time.Minute
+)
+
 // @summary Get Audit Log
 // @tags Audit Log
 // @security ApiKeyAuth
@@ -18,34 +36,45 @@ import (
 // @accept json
 // @produce json
 // @Param start query int false "start" default(0)
-// @Param length query int false "length" default(10)
+// @Param length query int false "length" default(1000)
 // @response 200 {object} model.Response "OK - Request successful"
 // @Router /api/v1/audit_log [get]
 func GetAuditlog(c *gin.Context) {
-	logger := config.GetLog()
+	listAuditlog(c, model.AuditLogFilter{})
+}
 
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+// @summary Get Audit Log By Username
+// @tags Audit Log
+// @security ApiKeyAuth
+// @id Get Audit Log By Username
+// @accept json
+// @produce json
+// @Param username path string true "username"
+// @Param start query int false "start" default(0)
+// @Param length query int false "length" default(1000)
+// @response 200 {object} model.Response "OK - Request successful"
+// @Router /api/v1/audit_log/{username} [get]
+func GetAuditlogByUsername(c *gin.Context) {
+	listAuditlog(c, model.AuditLogFilter{Username: c.Param("username")})
+}
+
+// listAuditlog serves the offset listings of the caller's org, newest first.
+func listAuditlog(c *gin.Context, f model.AuditLogFilter) {
+	logger := config.GetLog()
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
-	startStr := c.DefaultQuery("start", "0")
-	start, err := strconv.Atoi(startStr)
-	if err != nil {
+
+	start, err := strconv.Atoi(c.DefaultQuery("start", "0"))
+	if err != nil || start < 0 {
 		start = 0
 	}
-	lengthStr := c.DefaultQuery("length", "1000")
-	length, err := strconv.Atoi(lengthStr)
-	if err != nil {
+	length, err := strconv.Atoi(c.DefaultQuery("length", "1000"))
+	if err != nil || length < 1 {
 		length = 1000
 	}
-	query := `SELECT id, "orgId", username, "txId", "uniqueId", "mainFunc", "subFunc", "nameFunc", action, status, duration, "newData", "oldData", "resData", message, "createdAt"
-	FROM public.audit_logs LIMIT $1 OFFSET $2`
+	f.Offset, f.Limit = start, min(length, auditMaxLimit)
 
-	var rows pgx.Rows
-	logger.Debug(`Query`, zap.String("query", query))
-	rows, err = conn.Query(ctx, query, length, start)
+	list, err := repo.Audit.Search(ctx, tokenString(c, "orgId"), f)
 	if err != nil {
 		logger.Warn("Query failed", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, model.Response{
@@ -55,88 +84,120 @@ func GetAuditlog(c *gin.Context) {
 		})
 		return
 	}
-	defer rows.Close()
-	var errorMsg string
-	var AuditLog model.AuditLog
-	var AuditLogList []model.AuditLog
-	found := false
-	for rows.Next() {
-
-		err := rows.Scan(
-			&AuditLog.ID,
-			&AuditLog.OrgID,
-			&AuditLog.Username,
-			&AuditLog.TxID,
-			&AuditLog.UniqueId,
-			&AuditLog.MainFunc,
-			&AuditLog.SubFunc,
-			&AuditLog.NameFunc,
-			&AuditLog.Action,
-			&AuditLog.Status,
-			&AuditLog.Duration,
-			&AuditLog.NewData,
-			&AuditLog.OldData,
-			&AuditLog.ResData,
-			&AuditLog.Message,
-			&AuditLog.CreatedAt,
-		)
+	if len(list) == 0 {
+		c.JSON(http.StatusInternalServerError, model.Response{
+			Status: "-1",
+			Msg:    "Failed",
+		})
+		return
+	}
+	c.JSON(http.StatusOK, model.Response{
+		Status: "0",
+		Msg:    "Success",
+		Data:   list,
+	})
+}
+
+func encodeAuditCursor(e model.AuditLog) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(e.ID)))
+}
+
+func decodeAuditCursor(cursor string) (int, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, errors.New("invalid cursor")
+	}
+	id, err := strconv.Atoi(string(raw))
+	if err != nil || id < 1 {
+		return 0, errors.New("invalid cursor")
+	}
+	return id, nil
+}
+
+// auditFilter reads the query parameters shared by SearchAuditlog and
+// ExportAuditlog.
+func auditFilter(c *gin.Context) (model.AuditLogFilter, error) {
+	f := model.AuditLogFilter{
+		Username: c.Query("username"),
+		MainFunc: c.Query("mainFunc"),
+		SubFunc:  c.Query("subFunc"),
+		Action:   strings.ToUpper(c.Query("action")),
+		EntityID: c.Query("entityId"),
+		Query:    strings.TrimSpace(c.Query("q")),
+		Limit:    auditDefaultLimit,
+	}
+	for name, dst := range map[string]**time.Time{"from": &f.From, "to": &f.To} {
+		v := c.Query(name)
+		if v == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, v)
 		if err != nil {
-			logger.Warn("Scan failed", zap.Error(err))
-			response := model.Response{
-				Status: "-1",
-				Msg:    "Failed",
-				Desc:   errorMsg,
-			}
-			c.JSON(http.StatusInternalServerError, response)
-			return
+			return f, errors.New(name + " must be an RFC 3339 time")
 		}
-		AuditLogList = append(AuditLogList, AuditLog)
-		found = true
+		*dst = &t
 	}
-	if !found {
-		response := model.Response{
-			Status: "-1",
-			Msg:    "Failed",
-			Desc:   errorMsg,
+	if v := c.Query("status"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return f, errors.New("status must be a number")
 		}
-		c.JSON(http.StatusInternalServerError, response)
-	} else {
-		response := model.Response{
-			Status: "0",
-			Msg:    "Success",
-			Data:   AuditLogList,
-			Desc:   "",
+		f.Status = &n
+	}
+	if v := c.Query("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return f, errors.New("limit must be a positive number")
+		}
+		f.Limit = min(n, auditMaxLimit)
+	}
+	if v := c.Query("cursor"); v != "" {
+		id, err := decodeAuditCursor(v)
+		if err != nil {
+			return f, err
 		}
-		c.JSON(http.StatusOK, response)
+		f.BeforeID = id
 	}
+	return f, nil
 }
 
-// @summary Get Audit Log By Username
+// @summary Search Audit Log
+// @description Lists the caller's org audit log newest first, one page at a time. Pass the nextCursor of a page as cursor to get the next one.
 // @tags Audit Log
 // @security ApiKeyAuth
-// @id Get Audit Log By Username
+// @id Search Audit Log
 // @accept json
 // @produce json
-// @Param username path string true "username"
-// @response 200 {object} model.Response "OK - Request successful"
-// @Router /api/v1/audit_log/{username} [get]
-func GetAuditlogByUsername(c *gin.Context) {
+// @Param from query string false "createdAt from, RFC 3339, inclusive"
+// @Param to query string false "createdAt to, RFC 3339, exclusive"
+// @Param username query string false "username"
+// @Param mainFunc query string false "mainFunc"
+// @Param subFunc query string false "subFunc, the route pattern"
+// @Param action query string false "HTTP method"
+// @Param status query int false "response status"
+// @Param entityId query string false "id of the record acted on"
+// @Param q query string false "text to find in message, newData, oldData or resData"
+// @Param limit query int false "page size" default(50)
+// @Param cursor query string false "nextCursor of the previous page"
+// @response 200 {object} model.Response{data=model.AuditLogPage} "OK - Request successful"
+// @Router /api/v1/audit_log/search [get]
+func SearchAuditlog(c *gin.Context) {
 	logger := config.GetLog()
-
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
+	f, err := auditFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.Response{
+			Status: "-1",
+			Msg:    "Failure",
+			Desc:   err.Error(),
+		})
 		return
 	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
-	orgId := GetVariableFromToken(c, "orgId")
-	username := c.Param("username")
-
-	query := `SELECT id, "orgId", username, "txId", "uniqueId", "mainFunc", "subFunc", "nameFunc", action, status, duration, "newData", "oldData", "resData", message, "createdAt"
-	FROM public.audit_logs WHERE "orgId"=$1 AND username=$2`
-	var rows pgx.Rows
-	logger.Debug(`Query`, zap.String("query", query))
-	rows, err := conn.Query(ctx, query, orgId, username)
+
+	limit := f.Limit
+	f.Limit = limit + 1
+	list, err := repo.Audit.Search(ctx, tokenString(c, "orgId"), f)
 	if err != nil {
 		logger.Warn("Query failed", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, model.Response{
@@ -146,58 +207,157 @@ func GetAuditlogByUsername(c *gin.Context) {
 		})
 		return
 	}
-	defer rows.Close()
-	var errorMsg string
-	var AuditLog model.AuditLog
-	var AuditLogList []model.AuditLog
-	found := false
-	for rows.Next() {
-
-		err := rows.Scan(
-			&AuditLog.ID,
-			&AuditLog.OrgID,
-			&AuditLog.Username,
-			&AuditLog.TxID,
-			&AuditLog.UniqueId,
-			&AuditLog.MainFunc,
-			&AuditLog.SubFunc,
-			&AuditLog.NameFunc,
-			&AuditLog.Action,
-			&AuditLog.Status,
-			&AuditLog.Duration,
-			&AuditLog.NewData,
-			&AuditLog.OldData,
-			&AuditLog.ResData,
-			&AuditLog.Message,
-			&AuditLog.CreatedAt,
-		)
+	page := model.AuditLogPage{Items: list}
+	if len(list) > limit {
+		page.Items = list[:limit]
+		page.NextCursor = encodeAuditCursor(list[limit-1])
+	}
+	if page.Items == nil {
+		page.Items = []model.AuditLog{}
+	}
+	c.JSON(http.StatusOK, model.Response{
+		Status: "0",
+		Msg:    "Success",
+		Data:   page,
+	})
+}
+
+var auditCSVHeader = []string{"id", "createdAt", "orgId", "username", "txId", "uniqueId", "mainFunc", "subFunc",
+	"nameFunc", "entityId", "action", "status", "duration", "message", "newData", "oldData", "resData",
+	"prevHash", "hash"}
+
+func auditCSVRecord(e model.AuditLog) []string {
+	return []string{strconv.Itoa(e.ID), e.CreatedAt.UTC().Format(time.RFC3339Nano), e.OrgID, e.Username, e.TxID,
+		e.UniqueId, e.MainFunc, e.SubFunc, e.NameFunc, e.EntityID, e.Action, strconv.Itoa(e.Status),
+		strconv.FormatFloat(e.Duration, 'f', -1, 64), e.Message, e.NewData, e.OldData, e.ResData,
+		e.PrevHash, e.Hash}
+}
+
+// @summary Export Audit Log
+// @description Streams every row of the caller's org audit log matching the search filters, newest first, as CSV or NDJSON. limit is ignored. If the export fails part way it ends with a line starting "# export failed" (CSV) or an object with an error field (NDJSON).
+// @tags Audit Log
+// @security ApiKeyAuth
+// @id Export Audit Log
+// @produce text/csv
+// @produce application/x-ndjson
+// @Param format query string false "csv or ndjson" default(csv)
+// @Param from query string false "createdAt from, RFC 3339, inclusive"
+// @Param to query string false "createdAt to, RFC 3339, exclusive"
+// @Param username query string false "username"
+// @Param mainFunc query string false "mainFunc"
+// @Param subFunc query string false "subFunc, the route pattern"
+// @Param action query string false "HTTP method"
+// @Param status query int false "response status"
+// @Param entityId query string false "id of the record acted on"
+// @Param q query string false "text to find in message, newData, oldData or resData"
+// @Param cursor query string false "start after this search cursor"
+// @response 200 {string} string "OK - Request successful"
+// @Router /api/v1/audit_log/export [get]
+func ExportAuditlog(c *gin.Context) {
+	logger := config.GetLog()
+	f, err := auditFilter(c)
+	format := c.DefaultQuery("format", "csv")
+	if err == nil && format != "csv" && format != "ndjson" {
+		err = errors.New("format must be csv or ndjson")
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.Response{
+			Status: "-1",
+			Msg:    "Failure",
+			Desc:   err.Error(),
+		})
+		return
+	}
+	orgId := tokenString(c, "orgId")
+	f.Limit = auditExportPage
+
+	name := "audit_log_" + time.Now().UTC().Format("20060102T150405Z")
+	if format == "csv" {
+		c.Header("Content-Type", "text/csv; charset=utf-8")
+		name += ".csv"
+	} else {
+		c.Header("Content-Type", "application/x-ndjson")
+		name += ".ndjson"
+	}
+	c.Header("Content-Disposition", `attachment; filename="`+name+`"`)
+	c.Status(http.StatusOK)
+
+	csvw := csv.NewWriter(c.Writer)
+	enc := json.NewEncoder(c.Writer)
+	if format == "csv" {
+		_ = csvw.Write(auditCSVHeader)
+	}
+	rows := 0
+	for {
+		repo, ctx, cancel := getStore(c.Request.Context())
+		list, err := repo.Audit.Search(ctx, orgId, f)
+		cancel()
 		if err != nil {
-			logger.Warn("Scan failed", zap.Error(err))
-			response := model.Response{
-				Status: "-1",
-				Msg:    "Failed",
-				Desc:   errorMsg,
+			logger.Warn("Audit export failed", zap.Int("rows", rows), zap.Error(err))
+			if format == "csv" {
+				csvw.Flush()
+				fmt.Fprintf(c.Writer, "# export failed after %d rows: %v\n", rows, err)
+			} else {
+				_ = enc.Encode(gin.H{"error": "export failed", "detail": err.Error(), "rows": rows})
 			}
-			c.JSON(http.StatusInternalServerError, response)
 			return
 		}
-		AuditLogList = append(AuditLogList, AuditLog)
-		found = true
-	}
-	if !found {
-		response := model.Response{
-			Status: "-1",
-			Msg:    "Failed",
-			Desc:   errorMsg,
+		for _, e := range list {
+			if format == "csv" {
+				err = csvw.Write(auditCSVRecord(e))
+			} else {
+				err = enc.Encode(e)
+			}
+			if err != nil {
+				// the client went away
+				return
+			}
 		}
-		c.JSON(http.StatusInternalServerError, response)
-	} else {
-		response := model.Response{
-			Status: "0",
-			Msg:    "Success",
-			Data:   AuditLogList,
-			Desc:   "",
+		rows += len(list)
+		csvw.Flush()
+		c.Writer.Flush()
+		if len(list) < f.Limit {
+			return
 		}
-		c.JSON(http.StatusOK, response)
+		f.BeforeID = list[len(list)-1].ID
+	}
+}
+
+// @summary Verify Audit Log
+// @description Walks the caller's org audit chain and reports the first row whose hash or link to the row before it does not match.
+// @tags Audit Log
+// @security ApiKeyAuth
+// @id Verify Audit Log
+// @accept json
+// @produce json
+// @response 200 {object} model.Response{data=model.AuditChainReport} "OK - Request successful"
+// @Router /api/v1/audit_log/verify [get]
+func VerifyAuditlog(c *gin.Context) {
+	logger := config.GetLog()
+	// the walk reads the whole chain, so it gets longer than one query
+	ctx, cancel := context.WithTimeout(c.Request.Context(), auditVerifyTimeout)
+	defer cancel()
+
+	report, err := auditchain.Verify(ctx, appStore.Audit, tokenString(c, "orgId"), model.AuditCheckpoint{})
+	if err != nil {
+		logger.Warn("Verify failed", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, model.Response{
+			Status: "-1",
+			Msg:    "Failure",
+			Desc:   err.Error(),
+		})
+		return
+	}
+	desc := "Audit chain intact"
+	if !report.Valid {
+		desc = fmt.Sprintf("Audit chain broken at id %d: %s", report.BrokenAt.ID, report.BrokenAt.Reason)
+		logger.Error("Audit chain broken", zap.String("orgId", report.OrgID), zap.Int("id", report.BrokenAt.ID),
+			zap.String("reason", report.BrokenAt.Reason))
 	}
+	c.JSON(http.StatusOK, model.Response{
+		Status: "0",
+		Msg:    "Success",
+		Data:   report,
+		Desc:   desc,
+	})
 }
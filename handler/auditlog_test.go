@@ -0,0 +1,177 @@
+package handler
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"mainPackage/model"
+	"mainPackage/store"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func auditRouter() *gin.Engine {
+	r := gin.New()
+	v1 := r.Group("/api/v1", ProtectedHandler)
+	v1.GET("/audit_log/search", SearchAuditlog)
+	v1.GET("/audit_log/export", ExportAuditlog)
+	return r
+}
+
+// seedAudit writes n audit rows of testOrg, alternating between alice's
+// POSTs and bob's DELETEs, and one row of another org.
+func seedAudit(t *testing.T, m *store.Memory, n int) {
+	t.Helper()
+	entries := []model.AuditLog{{OrgID: "o2", Username: "alice", Action: http.MethodPost, CreatedAt: time.Now()}}
+	for i := range n {
+		e := model.AuditLog{OrgID: testOrg, Username: "alice", Action: http.MethodPost, Status: http.StatusOK,
+			NewData: `{"name":"row ` + strconv.Itoa(i) + `"}`, CreatedAt: time.Now()}
+		if i%2 == 1 {
+			e.Username, e.Action, e.Status = "bob", http.MethodDelete, http.StatusNotFound
+		}
+		entries = append(entries, e)
+	}
+	if err := m.Audit.Insert(context.Background(), entries); err != nil {
+		t.Fatal(err)
+	}
+}
+
+type auditPageResponse struct {
+	Status string             `json:"status"`
+	Desc   string             `json:"desc"`
+	Data   model.AuditLogPage `json:"data"`
+}
+
+func TestSearchAuditlog(t *testing.T) {
+	m := newTestStore(t)
+	seedAudit(t, m, 5)
+	r := auditRouter()
+	tok := accessToken(t)
+
+	var ids []int
+	cursor := ""
+	for pages := 1; ; pages++ {
+		var res auditPageResponse
+		path := "/api/v1/audit_log/search?limit=2&cursor=" + cursor
+		if code := do(t, r, http.MethodGet, path, tok, nil, &res); code != http.StatusOK {
+			t.Fatalf("page %d: %d %+v", pages, code, res)
+		}
+		for _, e := range res.Data.Items {
+			if e.OrgID != testOrg {
+				t.Errorf("search returned a row of org %q", e.OrgID)
+			}
+			ids = append(ids, e.ID)
+		}
+		if cursor = res.Data.NextCursor; cursor == "" || pages > 5 {
+			break
+		}
+	}
+	if len(ids) != 5 {
+		t.Fatalf("paged %d rows, want 5", len(ids))
+	}
+	for i := 1; i < len(ids); i++ {
+		if ids[i] >= ids[i-1] {
+			t.Fatalf("paged ids %v, want newest first without repeats", ids)
+		}
+	}
+
+	tests := []struct {
+		query string
+		want  int
+	}{
+		{"username=bob", 2},
+		{"action=delete", 2},
+		{"status=200", 3},
+		{"q=ROW%203", 1},
+		{"username=alice&action=DELETE", 0},
+	}
+	for _, tt := range tests {
+		var res auditPageResponse
+		if code := do(t, r, http.MethodGet, "/api/v1/audit_log/search?"+tt.query, tok, nil, &res); code != http.StatusOK {
+			t.Fatalf("%s: %d", tt.query, code)
+		}
+		if len(res.Data.Items) != tt.want {
+			t.Errorf("%s: %d rows, want %d", tt.query, len(res.Data.Items), tt.want)
+		}
+	}
+
+	for _, q := range []string{"cursor=junk", "limit=-1", "status=ok", "from=today"} {
+		if code := do(t, r, http.MethodGet, "/api/v1/audit_log/search?"+q, tok, nil, nil); code != http.StatusBadRequest {
+			t.Errorf("%s: %d, want 400", q, code)
+		}
+	}
+}
+
+func exportAudit(t *testing.T, query string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/audit_log/export?"+query, nil)
+	req.Header.Set("Authorization", "Bearer "+accessToken(t))
+	w := httptest.NewRecorder()
+	auditRouter().ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("export %s: %d %s", query, w.Code, w.Body.String())
+	}
+	return w
+}
+
+func TestExportAuditlog(t *testing.T) {
+	m := newTestStore(t)
+	// More than one export page, so the export has to follow its own cursor.
+	rows := auditExportPage + 3
+	seedAudit(t, m, rows)
+
+	w := exportAudit(t, "")
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/csv") {
+		t.Errorf("CSV Content-Type = %q", ct)
+	}
+	if cd := w.Header().Get("Content-Disposition"); !strings.Contains(cd, ".csv") {
+		t.Errorf("CSV Content-Disposition = %q", cd)
+	}
+	records, err := csv.NewReader(w.Body).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != rows+1 || strings.Join(records[0], ",") != strings.Join(auditCSVHeader, ",") {
+		t.Fatalf("CSV = %d records headed %v, want the header and %d rows", len(records), records[0], rows)
+	}
+	prev := 0
+	for _, rec := range records[1:] {
+		id, _ := strconv.Atoi(rec[0])
+		if rec[2] != testOrg || (prev != 0 && id >= prev) {
+			t.Fatalf("CSV row %v after id %d, want %s rows newest first", rec[:3], prev, testOrg)
+		}
+		prev = id
+	}
+
+	w = exportAudit(t, "format=ndjson&username=bob")
+	if ct := w.Header().Get("Content-Type"); ct != "application/x-ndjson" {
+		t.Errorf("NDJSON Content-Type = %q", ct)
+	}
+	n := 0
+	sc := bufio.NewScanner(w.Body)
+	sc.Buffer(nil, auditMaxData*4)
+	for sc.Scan() {
+		var e model.AuditLog
+		if err := json.Unmarshal(sc.Bytes(), &e); err != nil {
+			t.Fatalf("NDJSON line %q: %v", sc.Text(), err)
+		}
+		if e.Username != "bob" {
+			t.Errorf("NDJSON row of %q, want only bob", e.Username)
+		}
+		n++
+	}
+	if n != rows/2 {
+		t.Errorf("NDJSON = %d rows, want %d", n, rows/2)
+	}
+
+	if code := do(t, auditRouter(), http.MethodGet, "/api/v1/audit_log/export?format=xml", accessToken(t), nil, nil); code != http.StatusBadRequest {
+		t.Errorf("format=xml: %d, want 400", code)
+	}
+}
@@ -1,10 +1,13 @@
 package handler
 
 import (
-	"crypto/subtle"
+	"context"
+	"errors"
 	"fmt"
 	"mainPackage/config"
+	"mainPackage/credential"
 	"mainPackage/model"
+	"mainPackage/store"
 	"net/http"
 	"os"
 	"strconv"
@@ -13,52 +16,140 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
-func GetVariableFromToken(c *gin.Context, varname string) interface{} {
-	var_return, exists := c.Get(varname)
-	if !exists {
-		c.JSON(401, gin.H{"error": "Unauthorized"})
-		return nil
+// tokenString returns a string claim set by the auth middleware, or "" when
+// it is missing. It never writes a response, so it is safe outside the
+// protected routes too.
+func tokenString(c *gin.Context, varname string) string {
+	return c.GetString(varname)
+}
+
+// loginUser copies a stored user into the login response shape.
+func loginUser(u *model.Um_User) model.Um_User_Login {
+	return model.Um_User_Login{
+		ID: u.ID, OrgID: u.OrgID, OrgName: u.OrgName, DisplayName: u.DisplayName, Title: u.Title,
+		FirstName: u.FirstName, MiddleName: u.MiddleName, LastName: u.LastName, CitizenID: u.CitizenID,
+		Bod: u.Bod, Blood: u.Blood, Gender: u.Gender, MobileNo: u.MobileNo, Address: u.Address, Photo: u.Photo,
+		Username: u.Username, Password: u.Password, Email: u.Email, RoleID: u.RoleID, RoleName: u.RoleName,
+		UserType: u.UserType, EmpID: u.EmpID, DeptID: u.DeptID, CommID: u.CommID, StnID: u.StnID,
+		Active: u.Active, ActivationToken: u.ActivationToken, LastActivationRequest: u.LastActivationRequest,
+		LostPasswordRequest: u.LostPasswordRequest, SignupStamp: u.SignupStamp, IsLogin: u.IsLogin,
+		LastLogin: u.LastLogin, CreatedAt: u.CreatedAt, UpdatedAt: u.UpdatedAt, CreatedBy: u.CreatedBy,
+		UpdatedBy: u.UpdatedBy,
 	}
-	return var_return
 }
 
-func CreateToken(username string, orgId string) (string, string, error) {
+// checkPassword verifies password against the user's stored value. A legacy
+// AES value, or a hash made with older parameters, is replaced by a fresh
+// hash once it has matched; a failed upgrade is logged and retried on the
+// next login.
+func checkPassword(ctx context.Context, repo *store.Store, user *model.Um_User, password string) (bool, error) {
+	logger := config.GetLog()
+	ok, rehash, err := credential.Verify(user.Password, password)
+	if err != nil || !ok {
+		return false, err
+	}
+	if rehash {
+		hash, err := credential.Hash(password)
+		if err == nil {
+			err = repo.Users.SetPassword(ctx, user.ID, hash)
+		}
+		if err != nil {
+			logger.Warn("Password re-hash failed", zap.String("username", user.Username), zap.Error(err))
+		} else {
+			logger.Info("Password re-hashed", zap.String("username", user.Username))
+		}
+	}
+	return true, nil
+}
 
+// errRefreshReused is returned when a refresh token that was already
+// rotated, or revoked, is presented again.
+var errRefreshReused = errors.New("refresh token reuse detected")
+
+// tokenTimeout reads a lifetime in minutes from the environment.
+func tokenTimeout(name string) time.Duration {
+	minutes, _ := strconv.Atoi(os.Getenv(name))
+	return time.Minute * time.Duration(minutes)
+}
+
+// CreateToken signs an access token and a refresh token for one session of
+// a user. The refresh token gets a fresh jti within familyId, or within a new
+// family when familyId is empty; the returned record is what the caller must
+// store for the refresh token to be accepted later.
+func CreateToken(username string, orgId string, familyId string) (string, string, model.RefreshTokenRecord, error) {
 	var secretKey = []byte(os.Getenv("TOKEN_SECRET_KEY"))
 	var refreshKey = []byte(os.Getenv("REFRESH_TOKEN_KEY"))
-	TimeoutStr := os.Getenv("TOKEN_TIMEOUT")
-	timeoutInt, _ := strconv.Atoi(TimeoutStr)
-	var TIMEOUT = time.Minute * time.Duration(timeoutInt)
+	if familyId == "" {
+		familyId = uuid.NewString()
+	}
+	now := time.Now()
+	rec := model.RefreshTokenRecord{
+		JTI:       uuid.NewString(),
+		FamilyID:  familyId,
+		OrgID:     orgId,
+		Username:  username,
+		IssuedAt:  now,
+		ExpiresAt: now.Add(tokenTimeout("REFRESH_TOKEN_TIMEOUT")),
+	}
+
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256,
 		jwt.MapClaims{
 			"username": username,
 			"orgId":    orgId,
-			"exp":      time.Now().Add(TIMEOUT).Unix(),
+			"sid":      familyId,
+			"exp":      now.Add(tokenTimeout("TOKEN_TIMEOUT")).Unix(),
 		})
-
 	tokenString, err := token.SignedString(secretKey)
 	if err != nil {
-		return "", "", err
+		return "", "", rec, err
 	}
-	TimeoutStr = os.Getenv("REFRESH_TOKEN_TIMEOUT")
-	timeoutInt, _ = strconv.Atoi(TimeoutStr)
-	TIMEOUT = time.Minute * time.Duration(timeoutInt)
+
 	refreshtoken := jwt.NewWithClaims(jwt.SigningMethodHS256,
 		jwt.MapClaims{
 			"username": username,
 			"orgId":    orgId,
-			"exp":      time.Now().Add(TIMEOUT).Unix(),
+			"jti":      rec.JTI,
+			"fam":      familyId,
+			"exp":      rec.ExpiresAt.Unix(),
 		})
-
 	refreshtokenString, err := refreshtoken.SignedString(refreshKey)
 	if err != nil {
+		return "", "", rec, err
+	}
+
+	return tokenString, refreshtokenString, rec, nil
+}
+
+// issueTokens starts a new session for a user who has just signed in.
+func issueTokens(c *gin.Context, ctx context.Context, repo *store.Store, username, orgId string) (string, string, error) {
+	tokenString, refreshtoken, rec, err := CreateToken(username, orgId, "")
+	if err != nil {
+		return "", "", err
+	}
+	rec.UserAgent, rec.ClientIP = c.Request.UserAgent(), c.ClientIP()
+	if err := repo.Tokens.Create(ctx, rec); err != nil {
 		return "", "", err
 	}
+	return tokenString, refreshtoken, nil
+}
 
-	return tokenString, refreshtokenString, nil
+// revokeUserSessions revokes every refresh token of a user, ending all of
+// their sessions once the current access tokens expire.
+func revokeUserSessions(ctx context.Context, repo *store.Store, orgId, username, reason string) (int64, error) {
+	logger := config.GetLog()
+	n, err := repo.Tokens.RevokeUser(ctx, orgId, username, reason, time.Now())
+	if err != nil {
+		logger.Warn("Session revoke failed", zap.String("username", username), zap.Error(err))
+		return 0, err
+	}
+	if n > 0 {
+		logger.Info("Sessions revoked", zap.String("username", username), zap.String("reason", reason), zap.Int64("count", n))
+	}
+	return n, nil
 }
 
 func verifyToken(tokenString string) (*jwt.Token, error) {
@@ -104,7 +195,6 @@ func verifyRefreshToken(tokenString string) (*jwt.Token, error) {
 func ProtectedHandler(c *gin.Context) {
 	logger := config.GetLog()
 	authHeader := c.GetHeader("Authorization")
-	logger.Debug("Authorization header", zap.String("Authorization", authHeader))
 
 	if authHeader == "" {
 		c.JSON(http.StatusUnauthorized, model.Response{
@@ -128,7 +218,6 @@ func ProtectedHandler(c *gin.Context) {
 	}
 
 	tokenString := strings.TrimPrefix(authHeader, prefix)
-	logger.Debug("Token: " + tokenString)
 
 	parsedToken, err := verifyToken(tokenString)
 	if err != nil {
@@ -145,7 +234,7 @@ func ProtectedHandler(c *gin.Context) {
 		username, uOK := claims["username"].(string)
 		orgId, orgOK := claims["orgId"].(string)
 
-		if uOK && orgOK {
+		if uOK && orgOK && username != "" && orgId != "" {
 			logger.Debug("Verified user",
 				zap.String("username", username),
 				zap.String("orgId", orgId),
@@ -153,11 +242,20 @@ func ProtectedHandler(c *gin.Context) {
 			c.Set("username", username)
 			c.Set("orgId", orgId)
 			c.Set("tokenString", tokenString)
+			if sid, ok := claims["sid"].(string); ok {
+				c.Set("sessionId", sid)
+			}
 			c.Next()
 			return
 		}
 	}
 
+	c.JSON(http.StatusUnauthorized, model.Response{
+		Status: "-1",
+		Msg:    "Failed",
+		Desc:   "token has no user",
+	})
+	c.Abort()
 }
 
 // @summary Login
@@ -176,19 +274,11 @@ func UserLogin(c *gin.Context) {
 	username := c.Query("username")
 	password := c.Query("password")
 	organization := c.Query("organization")
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
-	defer cancel()
-	defer conn.Close(ctx)
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
 
-	var id string
-	query := `SELECT id FROM public.organizations WHERE name = $1`
-	logger.Debug(`Query`, zap.String("query", query))
 	logger.Debug(`request input`, zap.Any("organization", organization))
-	err := conn.QueryRow(ctx, query, organization).Scan(&id)
+	id, err := repo.Users.GetOrgIDByName(ctx, organization)
 	if err != nil {
 		logger.Debug(err.Error())
 		c.JSON(http.StatusUnauthorized, model.Response{
@@ -199,21 +289,8 @@ func UserLogin(c *gin.Context) {
 		return
 	}
 
-	query = `SELECT id,"orgId", "displayName", title, "firstName", "middleName", "lastName", "citizenId", bod, blood,
-	 gender, "mobileNo", address, photo, username, password, email, "roleId", "userType", "empId", "deptId", "commId",
-	  "stnId", active, "activationToken", "lastActivationRequest", "lostPasswordRequest", "signupStamp", islogin,
-	   "lastLogin", "createdAt", "updatedAt", "createdBy", "updatedBy" 
-	   FROM public.um_users WHERE username = $1 AND active = true`
-	logger.Debug(`Query`, zap.String("query", query))
 	logger.Debug(`request input`, zap.Any("username", username))
-	var UserOpt model.Um_User
-	err = conn.QueryRow(ctx, query, username).Scan(&UserOpt.ID,
-		&UserOpt.OrgID, &UserOpt.DisplayName, &UserOpt.Title, &UserOpt.FirstName, &UserOpt.MiddleName, &UserOpt.LastName,
-		&UserOpt.CitizenID, &UserOpt.Bod, &UserOpt.Blood, &UserOpt.Gender, &UserOpt.MobileNo, &UserOpt.Address,
-		&UserOpt.Photo, &UserOpt.Username, &UserOpt.Password, &UserOpt.Email, &UserOpt.RoleID, &UserOpt.UserType,
-		&UserOpt.EmpID, &UserOpt.DeptID, &UserOpt.CommID, &UserOpt.StnID, &UserOpt.Active, &UserOpt.ActivationToken,
-		&UserOpt.LastActivationRequest, &UserOpt.LostPasswordRequest, &UserOpt.SignupStamp, &UserOpt.IsLogin, &UserOpt.LastLogin,
-		&UserOpt.CreatedAt, &UserOpt.UpdatedAt, &UserOpt.CreatedBy, &UserOpt.UpdatedBy)
+	UserOpt, err := repo.Users.GetActiveByUsername(ctx, username)
 	if err != nil {
 		logger.Debug(err.Error())
 		c.JSON(http.StatusUnauthorized, model.Response{
@@ -223,15 +300,14 @@ func UserLogin(c *gin.Context) {
 		})
 		return
 	}
-	var dec string
-	dec, err = decrypt(UserOpt.Password)
+	ok, err := checkPassword(ctx, repo, UserOpt, password)
 	if err != nil {
-		logger.Debug(err.Error())
-		return
+		logger.Warn("Password check failed", zap.String("username", username), zap.Error(err))
 	}
+	UserOpt.Password = ""
 
-	if subtle.ConstantTimeCompare([]byte(dec), []byte(password)) == 1 {
-		tokenString, refreshtoken, err := CreateToken(username, id)
+	if ok {
+		tokenString, refreshtoken, err := issueTokens(c, ctx, repo, username, id)
 		if err != nil {
 			c.JSON(http.StatusUnauthorized, gin.H{
 				"error":   "Token creation failed",
@@ -271,12 +347,8 @@ func UserLogin(c *gin.Context) {
 // @Router /api/v1/auth/login [post]
 func UserLoginPost(c *gin.Context) {
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
 	var req model.Login
 	if err := c.ShouldBindJSON(&req); err != nil {
 		logger.Warn("Update failed", zap.Error(err))
@@ -291,11 +363,8 @@ func UserLoginPost(c *gin.Context) {
 	organization := req.Organization
 	username := req.Username
 	password := req.Password
-	var id string
-	query := `SELECT id FROM public.organizations WHERE name = $1`
-	logger.Debug(`Query`, zap.String("query", query))
 	logger.Debug(`request input`, zap.Any("organization", organization))
-	err := conn.QueryRow(ctx, query, organization).Scan(&id)
+	id, err := repo.Users.GetOrgIDByName(ctx, organization)
 	if err != nil {
 		logger.Debug(err.Error())
 		c.JSON(http.StatusUnauthorized, model.Response{
@@ -306,21 +375,8 @@ func UserLoginPost(c *gin.Context) {
 		return
 	}
 
-	query = `SELECT id,"orgId", "displayName", title, "firstName", "middleName", "lastName", "citizenId", bod, blood,
-	 gender, "mobileNo", address, photo, username, password, email, "roleId", "userType", "empId", "deptId", "commId",
-	  "stnId", active, "activationToken", "lastActivationRequest", "lostPasswordRequest", "signupStamp", islogin,
-	   "lastLogin", "createdAt", "updatedAt", "createdBy", "updatedBy" 
-	   FROM public.um_users WHERE username = $1 AND active = true`
-	logger.Debug(`Query`, zap.String("query", query))
 	logger.Debug(`request input`, zap.Any("username", username))
-	var UserOpt model.Um_User_Login
-	err = conn.QueryRow(ctx, query, username).Scan(&UserOpt.ID,
-		&UserOpt.OrgID, &UserOpt.DisplayName, &UserOpt.Title, &UserOpt.FirstName, &UserOpt.MiddleName, &UserOpt.LastName,
-		&UserOpt.CitizenID, &UserOpt.Bod, &UserOpt.Blood, &UserOpt.Gender, &UserOpt.MobileNo, &UserOpt.Address,
-		&UserOpt.Photo, &UserOpt.Username, &UserOpt.Password, &UserOpt.Email, &UserOpt.RoleID, &UserOpt.UserType,
-		&UserOpt.EmpID, &UserOpt.DeptID, &UserOpt.CommID, &UserOpt.StnID, &UserOpt.Active, &UserOpt.ActivationToken,
-		&UserOpt.LastActivationRequest, &UserOpt.LostPasswordRequest, &UserOpt.SignupStamp, &UserOpt.IsLogin, &UserOpt.LastLogin,
-		&UserOpt.CreatedAt, &UserOpt.UpdatedAt, &UserOpt.CreatedBy, &UserOpt.UpdatedBy)
+	user, err := repo.Users.GetActiveByUsername(ctx, username)
 	if err != nil {
 		logger.Debug(err.Error())
 		c.JSON(http.StatusUnauthorized, model.Response{
@@ -330,16 +386,14 @@ func UserLoginPost(c *gin.Context) {
 		})
 		return
 	}
-	var dec string
-	dec, err = decrypt(UserOpt.Password)
+	ok, err := checkPassword(ctx, repo, user, password)
 	if err != nil {
-		logger.Warn("Decryption failed", zap.Error(err)) // Use Warn for visibility
-		return
+		logger.Warn("Password check failed", zap.String("username", username), zap.Error(err))
 	}
-	// logger.Debug(UserOpt.Password)
-	// logger.Debug(dec)
-	if subtle.ConstantTimeCompare([]byte(dec), []byte(password)) == 1 {
-		tokenString, refreshtoken, err := CreateToken(username, id)
+	UserOpt := loginUser(user)
+	UserOpt.Password = ""
+	if ok {
+		tokenString, refreshtoken, err := issueTokens(c, ctx, repo, username, id)
 		if err != nil {
 			c.JSON(http.StatusUnauthorized, gin.H{
 				"error":   "Token creation failed",
@@ -349,12 +403,8 @@ func UserLoginPost(c *gin.Context) {
 			return
 		}
 
-		query = `SELECT "permId" FROM public.um_role_with_permissions 
-				WHERE "orgId"=$1 AND "roleId"=$2 AND active = true`
-		logger.Debug(`Query`, zap.String("query", query), zap.Any("input", []any{UserOpt.OrgID, UserOpt.RoleID}))
-		var permId string
-		var RolePermissionList []string
-		rows, err := conn.Query(ctx, query, UserOpt.OrgID, UserOpt.RoleID)
+		logger.Debug(`Permissions`, zap.Any("input", []any{UserOpt.OrgID, UserOpt.RoleID}))
+		RolePermissionList, err := repo.Users.ListPermissionIDs(ctx, UserOpt.OrgID, UserOpt.RoleID)
 		if err != nil {
 			logger.Warn("Query failed", zap.Error(err))
 			c.JSON(http.StatusInternalServerError, model.Response{
@@ -364,20 +414,6 @@ func UserLoginPost(c *gin.Context) {
 			})
 			return
 		}
-		for rows.Next() {
-			err = rows.Scan(&permId)
-			if err != nil {
-				logger.Warn("Scan failed", zap.Error(err))
-				response := model.Response{
-					Status: "-1",
-					Msg:    "Failed",
-					Desc:   err.Error(),
-				}
-				c.JSON(http.StatusInternalServerError, response)
-			}
-			RolePermissionList = append(RolePermissionList, permId)
-
-		}
 		UserOpt.Permission = RolePermissionList
 		c.JSON(http.StatusOK, model.Response{
 			Status: "0",
@@ -414,12 +450,8 @@ func UserLoginPost(c *gin.Context) {
 // @Router /api/v1/auth/add [post]
 func UserAddAuth(c *gin.Context) {
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
 
 	var req model.UserAdminInput
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -434,46 +466,45 @@ func UserAddAuth(c *gin.Context) {
 
 	// now req is ready to use
 
-	var enc string
-	var err error
-	var id int
-	enc, err = encrypt(req.Password)
+	enc, err := credential.Hash(req.Password)
 	if err != nil {
+		c.JSON(http.StatusInternalServerError, model.Response{
+			Status: "-1",
+			Msg:    "Failure",
+			Desc:   err.Error(),
+		})
+		logger.Warn("Password hash failed", zap.Error(err))
 		return
 	}
-	now := time.Now()
-	query := `
-		INSERT INTO public.um_users(
-		"orgId", "displayName", title, "firstName", "middleName", "lastName", "citizenId", bod, blood, gender,
-		"mobileNo", address, photo, username, password, email, "roleId", "userType", "empId", "deptId",
-		"commId", "stnId", active, "activationToken", "lastActivationRequest", "lostPasswordRequest",
-		"signupStamp", islogin, "lastLogin", "createdAt", "updatedAt", "createdBy", "updatedBy"
-			)
-	VALUES (
-		$1, $2, $3, $4, $5, $6, $7,
-		$8, $9, $10, $11,
-		$12, $13, $14, $15, $16,
-		$17, $18, $19, $20, $21, 
-		$22, $23, $24, $25, $26,
-		$27, $28, $29, $30, $31, 
-		$32, $33
-	)
-	RETURNING id;
-	`
-	logger.Debug(`Query`, zap.String("query", query))
-	logger.Debug(`request input`, zap.Any("Input", []any{req}))
-	logger.Debug(`Encrypt Password :` + enc)
-	err = conn.QueryRow(ctx, query,
-		req.OrgID, req.DisplayName, req.Title, req.FirstName, req.MiddleName,
-		req.LastName, req.CitizenID, req.Bod, req.Blood,
-		req.Gender, req.MobileNo, req.Address, req.Photo, req.Username,
-		enc, req.Email, req.RoleID, req.UserType, req.EmpID, req.DeptID, req.CommID, req.StnID,
-		req.Active, req.ActivationToken, req.LastActivationRequest, req.LostPasswordRequest, req.SignupStamp,
-		req.IsLogin, req.LastLogin, now, now, "system", "system",
-	).Scan(&id)
 
+	var bod *time.Time
+	if req.Bod != "" {
+		t, err := parseDate(req.Bod)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, model.Response{
+				Status: "-1",
+				Msg:    "Failure",
+				Desc:   err.Error(),
+			})
+			logger.Warn("Insert failed", zap.Error(err))
+			return
+		}
+		bod = &t
+	}
+	user := model.UserWrite{
+		OrgID: req.OrgID, DisplayName: req.DisplayName, Title: req.Title, FirstName: req.FirstName,
+		MiddleName: req.MiddleName, LastName: req.LastName, CitizenID: req.CitizenID, Bod: bod,
+		Blood: req.Blood, Gender: int64Ptr(req.Gender), MobileNo: req.MobileNo, Address: req.Address,
+		Photo: req.Photo, Username: req.Username, Password: enc, Email: req.Email, RoleID: req.RoleID,
+		UserType: int64Ptr(req.UserType), EmpID: req.EmpID, DeptID: req.DeptID, CommID: req.CommID,
+		StnID: req.StnID, Active: req.Active, ActivationToken: &req.ActivationToken,
+		LastActivationRequest: int64Ptr(req.LastActivationRequest),
+		LostPasswordRequest:   int64Ptr(req.LostPasswordRequest),
+		SignupStamp:           int64Ptr(req.SignupStamp),
+		IsLogin:               req.IsLogin, LastLogin: req.LastLogin,
+	}
+	_, err = repo.Users.Create(ctx, user, "system")
 	if err != nil {
-		// log.Printf("Insert failed: %v", err)
 		c.JSON(http.StatusUnauthorized, model.Response{
 			Status: "-1",
 			Msg:    "Failure",
@@ -492,6 +523,7 @@ func UserAddAuth(c *gin.Context) {
 }
 
 // @summary Refresh Token
+// @description Exchanges a refresh token for a new access token and a new refresh token. Each refresh token can be used once; presenting one again revokes its whole session.
 // @tags Authentication
 // @security ApiKeyAuth
 // @id Refresh Token
@@ -502,6 +534,8 @@ func UserAddAuth(c *gin.Context) {
 // @Router /api/v1/auth/refresh [post]
 func RefreshToken(c *gin.Context) {
 	logger := config.GetLog()
+	repo, ctx, cancel := getStore(c.Request.Context())
+	defer cancel()
 	var req model.RefreshInput
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, model.Response{
@@ -513,42 +547,210 @@ func RefreshToken(c *gin.Context) {
 		return
 	}
 
-	parsedToken, err := verifyRefreshToken(req.RefreshToken)
-	if err != nil {
+	unauthorized := func(desc string) {
 		c.JSON(http.StatusUnauthorized, model.Response{
 			Status: "-1",
 			Msg:    "Failed",
-			Desc:   err.Error(),
+			Desc:   desc,
 		})
 		c.Abort()
+	}
+
+	parsedToken, err := verifyRefreshToken(req.RefreshToken)
+	if err != nil {
+		unauthorized(err.Error())
+		return
+	}
+	claims, _ := parsedToken.Claims.(jwt.MapClaims)
+	username, _ := claims["username"].(string)
+	orgId, _ := claims["orgId"].(string)
+	jti, _ := claims["jti"].(string)
+	if username == "" || orgId == "" || jti == "" {
+		unauthorized("refresh token is no longer accepted, sign in again")
 		return
 	}
 
-	if claims, ok := parsedToken.Claims.(jwt.MapClaims); ok {
-		username, uOK := claims["username"].(string)
-		orgId, orgOK := claims["orgId"].(string)
+	current, err := repo.Tokens.Get(ctx, jti)
+	if err != nil || current.Username != username || current.OrgID != orgId {
+		if err != nil && !errors.Is(err, store.ErrNotFound) {
+			logger.Warn("Refresh failed", zap.Error(err))
+		}
+		unauthorized("unknown refresh token")
+		return
+	}
+	if current.RevokedAt != nil {
+		unauthorized("session has been revoked")
+		return
+	}
+	if user, err := repo.Users.GetByUsername(ctx, orgId, username); err != nil || !user.Active {
+		revokeUserSessions(ctx, repo, orgId, username, "inactive")
+		unauthorized("user is not active")
+		return
+	}
 
-		if uOK && orgOK {
-			tokenString, _, err := CreateToken(username, orgId)
-			if err != nil {
-				c.JSON(http.StatusUnauthorized, gin.H{
-					"error":   "Token creation failed",
-					"message": err.Error(),
-				})
-				logger.Debug(err.Error())
-				return
-			}
+	var tokenString, refreshtoken string
+	err = repo.InTx(ctx, func(tx *store.Store) error {
+		var rec model.RefreshTokenRecord
+		var err error
+		tokenString, refreshtoken, rec, err = CreateToken(username, orgId, current.FamilyID)
+		if err != nil {
+			return err
+		}
+		rec.UserAgent, rec.ClientIP = c.Request.UserAgent(), c.ClientIP()
+		ok, err := tx.Tokens.Rotate(ctx, jti, rec.JTI, rec.IssuedAt)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return errRefreshReused
+		}
+		return tx.Tokens.Create(ctx, rec)
+	})
+	if errors.Is(err, errRefreshReused) {
+		// A rotated token came back: whoever holds the family now cannot be
+		// told apart from the legitimate client, so end the session.
+		logger.Warn("Refresh token reuse detected",
+			zap.String("username", username), zap.String("familyId", current.FamilyID))
+		if _, err := repo.Tokens.RevokeFamily(ctx, current.FamilyID, "reuse", time.Now()); err != nil {
+			logger.Warn("Session revoke failed", zap.Error(err))
+		}
+		unauthorized(errRefreshReused.Error())
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, model.Response{
+			Status: "-1",
+			Msg:    "Failure",
+			Desc:   err.Error(),
+		})
+		logger.Warn("Refresh failed", zap.Error(err))
+		return
+	}
+	c.JSON(http.StatusOK, model.Response{
+		Status: "0",
+		Msg:    "Success",
+		Desc:   "",
+		Data: gin.H{
+			"accessToken":  tokenString,
+			"refreshToken": refreshtoken,
+			"token_type":   "bearer",
+		},
+	})
+}
+
+// @summary Logout
+// @description Revokes the session of the refresh token in the body, or of the access token when the body is empty.
+// @tags Authentication
+// @security ApiKeyAuth
+// @id Logout
+// @accept json
+// @produce json
+// @param Case body model.LogoutInput false "Body"
+// @response 200 {object} model.Response "OK - Request successful"
+// @Router /api/v1/auth/logout [post]
+func Logout(c *gin.Context) {
+	logger := config.GetLog()
+	repo, ctx, cancel := getStore(c.Request.Context())
+	defer cancel()
+	var req model.LogoutInput
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, model.Response{
+				Status: "-1",
+				Msg:    "Failure",
+				Desc:   err.Error(),
+			})
+			return
+		}
+	}
+	orgId := tokenString(c, "orgId")
+	username := tokenString(c, "username")
+
+	familyId, _ := c.Get("sessionId")
+	sessionId, _ := familyId.(string)
+	if req.RefreshToken != "" {
+		parsedToken, err := verifyRefreshToken(req.RefreshToken)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, model.Response{
+				Status: "-1",
+				Msg:    "Failure",
+				Desc:   err.Error(),
+			})
+			return
+		}
+		claims, _ := parsedToken.Claims.(jwt.MapClaims)
+		if claims["username"] != username || claims["orgId"] != orgId {
+			c.JSON(http.StatusForbidden, model.Response{
+				Status: "-1",
+				Msg:    "Failure",
+				Desc:   "refresh token belongs to another user",
+			})
+			return
+		}
+		sessionId, _ = claims["fam"].(string)
+	}
+	if sessionId == "" {
+		c.JSON(http.StatusBadRequest, model.Response{
+			Status: "-1",
+			Msg:    "Failure",
+			Desc:   "no session to log out of",
+		})
+		return
+	}
+
+	if _, err := repo.Tokens.RevokeFamily(ctx, sessionId, "logout", time.Now()); err != nil {
+		c.JSON(http.StatusInternalServerError, model.Response{
+			Status: "-1",
+			Msg:    "Failure",
+			Desc:   err.Error(),
+		})
+		logger.Warn("Logout failed", zap.Error(err))
+		return
+	}
+	c.JSON(http.StatusOK, model.Response{
+		Status: "0",
+		Msg:    "Success",
+		Desc:   "Logged out",
+	})
+}
+
+// @summary Revoke User Sessions
+// @description Revokes every refresh token of a user, signing them out everywhere once their access tokens expire.
+// @tags User
+// @security ApiKeyAuth
+// @id Revoke User Sessions
+// @accept json
+// @produce json
+// @Param id path int true "id"
+// @response 200 {object} model.Response "OK - Request successful"
+// @Router /api/v1/users/{id}/sessions/revoke [post]
+func RevokeUserSessions(c *gin.Context) {
+	logger := config.GetLog()
+	repo, ctx, cancel := getStore(c.Request.Context())
+	defer cancel()
+	orgId := tokenString(c, "orgId")
+
+	user, err := repo.Users.GetByID(ctx, orgId, c.Param("id"))
+	if err == nil {
+		var n int64
+		if n, err = revokeUserSessions(ctx, repo, orgId, user.Username, "admin"); err == nil {
 			c.JSON(http.StatusOK, model.Response{
 				Status: "0",
 				Msg:    "Success",
-				Desc:   "",
-				Data: gin.H{
-					"accessToken": tokenString,
-					// "refreshToken": refreshtoken,
-					"token_type": "bearer",
-				},
+				Desc:   "Sessions revoked",
+				Data:   gin.H{"revoked": n},
 			})
-
+			return
 		}
 	}
+	status := http.StatusInternalServerError
+	if errors.Is(err, store.ErrNotFound) {
+		status = http.StatusNotFound
+	}
+	c.JSON(status, model.Response{
+		Status: "-1",
+		Msg:    "Failure",
+		Desc:   err.Error(),
+	})
+	logger.Warn("Revoke failed", zap.Error(err))
 }
@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"context"
+	"mainPackage/model"
+	"net/http"
+	"testing"
+)
+
+type tokenResponse struct {
+	Status string `json:"status"`
+	Desc   string `json:"desc"`
+	Data   struct {
+		AccessToken  string `json:"accessToken"`
+		RefreshToken string `json:"refreshToken"`
+	} `json:"data"`
+}
+
+func TestRefreshTokenRotation(t *testing.T) {
+	m := newTestStore(t)
+	ctx := context.Background()
+	_, refresh, rec, err := CreateToken("alice", testOrg, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Tokens.Create(ctx, rec); err != nil {
+		t.Fatal(err)
+	}
+	r := newRouter()
+
+	var first tokenResponse
+	if code := do(t, r, http.MethodPost, "/auth/refresh", "", model.RefreshInput{RefreshToken: refresh}, &first); code != http.StatusOK {
+		t.Fatalf("refresh: %d %+v", code, first)
+	}
+	if first.Data.RefreshToken == "" || first.Data.RefreshToken == refresh {
+		t.Fatal("refresh did not rotate the refresh token")
+	}
+	if code := do(t, r, http.MethodGet, "/api/v1/notifications/unread_count", first.Data.AccessToken, nil, nil); code != http.StatusOK {
+		t.Errorf("new access token rejected: %d", code)
+	}
+
+	// The rotated token comes back: the whole session is revoked, so the
+	// token issued by the rotation stops working too.
+	if code := do(t, r, http.MethodPost, "/auth/refresh", "", model.RefreshInput{RefreshToken: refresh}, nil); code != http.StatusUnauthorized {
+		t.Fatalf("reusing a rotated token: %d, want 401", code)
+	}
+	var again tokenResponse
+	if code := do(t, r, http.MethodPost, "/auth/refresh", "", model.RefreshInput{RefreshToken: first.Data.RefreshToken}, &again); code != http.StatusUnauthorized {
+		t.Errorf("refresh after reuse: %d, want 401", code)
+	}
+	stored, err := m.Tokens.Get(ctx, rec.JTI)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.RevokedAt == nil {
+		t.Error("reused token not revoked")
+	}
+}
+
+func TestRefreshTokenUnknown(t *testing.T) {
+	newTestStore(t)
+	r := newRouter()
+
+	// Signed correctly but never stored.
+	_, refresh, _, err := CreateToken("alice", testOrg, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if code := do(t, r, http.MethodPost, "/auth/refresh", "", model.RefreshInput{RefreshToken: refresh}, nil); code != http.StatusUnauthorized {
+		t.Errorf("unknown refresh token: %d, want 401", code)
+	}
+	if code := do(t, r, http.MethodPost, "/auth/refresh", "", model.RefreshInput{RefreshToken: "junk"}, nil); code != http.StatusUnauthorized {
+		t.Errorf("malformed refresh token: %d, want 401", code)
+	}
+}
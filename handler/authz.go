@@ -0,0 +1,203 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"mainPackage/config"
+	"mainPackage/model"
+	"mainPackage/store"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// Permission ids checked by RequirePermission. They are the permId values of
+// um_permissions; migrations 0011 and 0024 seed them.
+const (
+	PermCaseView           = "case.view"
+	PermCaseCreate         = "case.create"
+	PermCaseUpdate         = "case.update"
+	PermCaseDelete         = "case.delete"
+	PermFormView           = "form.view"
+	PermFormManage         = "form.manage"
+	PermMasterView         = "master.view"
+	PermMasterManage       = "master.manage"
+	PermCustomerView       = "customer.view"
+	PermCustomerManage     = "customer.manage"
+	PermUserView           = "user.view"
+	PermUserManage         = "user.manage"
+	PermRoleView           = "role.view"
+	PermRoleManage         = "role.manage"
+	PermAuditView          = "audit.view"
+	PermSystemView         = "system.view"
+	PermNotificationManage = "notification.manage"
+)
+
+// PermissionCatalogue lists every permission the routes in main.go declare.
+var PermissionCatalogue = []model.PermissionEntry{
+	{PermID: PermCaseView, GroupName: "Case", PermName: "View cases, their history, stages and form submissions"},
+	{PermID: PermCaseCreate, GroupName: "Case", PermName: "Create cases"},
+	{PermID: PermCaseUpdate, GroupName: "Case", PermName: "Update and advance cases, submit case forms"},
+	{PermID: PermCaseDelete, GroupName: "Case", PermName: "Delete cases and case history"},
+	{PermID: PermFormView, GroupName: "Form and Workflow", PermName: "View forms and workflows"},
+	{PermID: PermFormManage, GroupName: "Form and Workflow", PermName: "Edit, publish and roll back forms and workflows"},
+	{PermID: PermMasterView, GroupName: "Master Data", PermName: "View case types, statuses, areas, organisation units and mdm data"},
+	{PermID: PermMasterManage, GroupName: "Master Data", PermName: "Edit case types, statuses, organisation units and mdm data"},
+	{PermID: PermCustomerView, GroupName: "Customer", PermName: "View customers"},
+	{PermID: PermCustomerManage, GroupName: "Customer", PermName: "Edit customers"},
+	{PermID: PermUserView, GroupName: "User", PermName: "View users"},
+	{PermID: PermUserManage, GroupName: "User", PermName: "Create, edit and delete users"},
+	{PermID: PermRoleView, GroupName: "Role", PermName: "View roles and permissions"},
+	{PermID: PermRoleManage, GroupName: "Role", PermName: "Edit roles and grant permissions"},
+	{PermID: PermAuditView, GroupName: "Audit", PermName: "View the audit log"},
+	{PermID: PermSystemView, GroupName: "System", PermName: "View system statistics"},
+	{PermID: PermNotificationManage, GroupName: "Notification", PermName: "Send, edit and delete notifications"},
+}
+
+// permTTL bounds how long a cached grant survives. Changes made through this
+// instance invalidate the cache at once; the TTL covers other instances.
+const permTTL = 5 * time.Minute
+
+type permEntry struct {
+	roleId  string
+	perms   map[string]bool
+	expires time.Time
+}
+
+// permCache holds the resolved permissions per user, keyed by orgId and
+// username.
+var permCache = struct {
+	sync.Mutex
+	users map[string]permEntry
+}{users: map[string]permEntry{}}
+
+func permKey(orgId, username string) string {
+	return orgId + "/" + username
+}
+
+// userPermissions returns the active permissions of the user's role.
+func userPermissions(ctx context.Context, repo *store.Store, orgId, username string) (map[string]bool, error) {
+	key := permKey(orgId, username)
+	permCache.Lock()
+	entry, ok := permCache.users[key]
+	permCache.Unlock()
+	if ok && time.Now().Before(entry.expires) {
+		return entry.perms, nil
+	}
+
+	user, err := repo.Users.GetByUsername(ctx, orgId, username)
+	if err != nil {
+		return nil, err
+	}
+	entry = permEntry{roleId: user.RoleID, perms: map[string]bool{}, expires: time.Now().Add(permTTL)}
+	if user.Active && user.RoleID != "" {
+		ids, err := repo.Users.ListPermissionIDs(ctx, orgId, user.RoleID)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range ids {
+			entry.perms[id] = true
+		}
+	}
+	permCache.Lock()
+	permCache.users[key] = entry
+	permCache.Unlock()
+	return entry.perms, nil
+}
+
+// invalidatePermissions drops the cached permissions of everyone holding
+// roleId in the organization, or of the whole organization when roleId is
+// empty.
+func invalidatePermissions(orgId, roleId string) {
+	permCache.Lock()
+	defer permCache.Unlock()
+	for key, entry := range permCache.users {
+		if strings.HasPrefix(key, orgId+"/") && (roleId == "" || entry.roleId == roleId) {
+			delete(permCache.users, key)
+		}
+	}
+}
+
+// invalidateUserPermissions drops one user's cached permissions, for when the
+// user's role or state changes.
+func invalidateUserPermissions(orgId, username string) {
+	permCache.Lock()
+	defer permCache.Unlock()
+	delete(permCache.users, permKey(orgId, username))
+}
+
+// hasPermission reports whether the caller's role grants perm. It must run
+// after ProtectedHandler.
+func hasPermission(c *gin.Context, perm string) bool {
+	repo, ctx, cancel := getStore(c.Request.Context())
+	defer cancel()
+	granted, err := userPermissions(ctx, repo, tokenString(c, "orgId"), tokenString(c, "username"))
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		config.GetLog().Warn("Permission lookup failed", zap.Error(err))
+	}
+	return granted[perm]
+}
+
+// RequirePermission lets the request through only when the caller's role
+// grants every one of perms. It must run after ProtectedHandler.
+func RequirePermission(perms ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		logger := config.GetLog()
+		user, org := tokenString(c, "username"), tokenString(c, "orgId")
+		if user == "" || org == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, model.Response{
+				Status: "-1",
+				Msg:    "Failed",
+				Desc:   "Unauthorized",
+			})
+			return
+		}
+
+		repo, ctx, cancel := getStore(c.Request.Context())
+		granted, err := userPermissions(ctx, repo, org, user)
+		cancel()
+		if err != nil && !errors.Is(err, store.ErrNotFound) {
+			logger.Warn("Permission lookup failed", zap.Error(err))
+			c.AbortWithStatusJSON(http.StatusInternalServerError, model.Response{
+				Status: "-1",
+				Msg:    "Failure",
+				Desc:   err.Error(),
+			})
+			return
+		}
+		for _, perm := range perms {
+			if !granted[perm] {
+				logger.Debug("Permission denied", zap.String("username", user), zap.String("permId", perm),
+					zap.String("path", c.FullPath()))
+				c.AbortWithStatusJSON(http.StatusForbidden, model.Response{
+					Status: "-1",
+					Msg:    "Forbidden",
+					Desc:   "missing permission " + perm,
+				})
+				return
+			}
+		}
+		c.Next()
+	}
+}
+
+// @summary Get Permission Catalogue
+// @description Lists the permissions the API enforces.
+// @tags Role
+// @security ApiKeyAuth
+// @id Get Permission Catalogue
+// @accept json
+// @produce json
+// @response 200 {object} model.Response{data=[]model.PermissionEntry} "OK - Request successful"
+// @Router /api/v1/permission/catalogue [get]
+func GetPermissionCatalogue(c *gin.Context) {
+	c.JSON(http.StatusOK, model.Response{
+		Status: "0",
+		Msg:    "Success",
+		Data:   PermissionCatalogue,
+	})
+}
@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"context"
+	"mainPackage/model"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func permRouter(perms ...string) *gin.Engine {
+	r := gin.New()
+	r.GET("/guarded", ProtectedHandler, RequirePermission(perms...), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	return r
+}
+
+func TestRequirePermission(t *testing.T) {
+	newTestStore(t, PermCaseView, PermCaseUpdate)
+	tok := accessToken(t)
+
+	if code := do(t, permRouter(PermCaseView), http.MethodGet, "/guarded", tok, nil, nil); code != http.StatusOK {
+		t.Errorf("granted permission: %d, want 200", code)
+	}
+	if code := do(t, permRouter(PermCaseView, PermCaseUpdate), http.MethodGet, "/guarded", tok, nil, nil); code != http.StatusOK {
+		t.Errorf("every permission granted: %d, want 200", code)
+	}
+	if code := do(t, permRouter(PermCaseView, PermCaseDelete), http.MethodGet, "/guarded", tok, nil, nil); code != http.StatusForbidden {
+		t.Errorf("one permission missing: %d, want 403", code)
+	}
+	if code := do(t, permRouter(PermCaseView), http.MethodGet, "/guarded", "", nil, nil); code != http.StatusUnauthorized {
+		t.Errorf("without a token: %d, want 401", code)
+	}
+}
+
+func TestRequirePermissionInactiveUser(t *testing.T) {
+	m := newTestStore(t, PermCaseView)
+	in := model.UserWrite{Username: "alice", EmpID: "E1", RoleID: "r1", Active: false}
+	if err := m.Users.UpdateByUsername(context.Background(), testOrg, "alice", in, "admin"); err != nil {
+		t.Fatal(err)
+	}
+	if code := do(t, permRouter(PermCaseView), http.MethodGet, "/guarded", accessToken(t), nil, nil); code != http.StatusForbidden {
+		t.Errorf("inactive user: %d, want 403", code)
+	}
+}
+
+func TestPermissionCacheInvalidation(t *testing.T) {
+	m := newTestStore(t, PermCaseView)
+	m.AddRole(testOrg, "r2", "Supervisor", PermCaseView, PermCaseDelete)
+	r := permRouter(PermCaseDelete)
+	tok := accessToken(t)
+
+	if code := do(t, r, http.MethodGet, "/guarded", tok, nil, nil); code != http.StatusForbidden {
+		t.Fatalf("before the role change: %d, want 403", code)
+	}
+	in := model.UserWrite{Username: "alice", EmpID: "E1", RoleID: "r2", Active: true}
+	if err := m.Users.UpdateByUsername(context.Background(), testOrg, "alice", in, "admin"); err != nil {
+		t.Fatal(err)
+	}
+	// The grant is cached until something invalidates it.
+	if code := do(t, r, http.MethodGet, "/guarded", tok, nil, nil); code != http.StatusForbidden {
+		t.Fatalf("cached grant: %d, want 403", code)
+	}
+
+	invalidatePermissions(testOrg, "r2")
+	if code := do(t, r, http.MethodGet, "/guarded", tok, nil, nil); code != http.StatusForbidden {
+		t.Errorf("invalidating another role: %d, want the cached 403", code)
+	}
+	invalidateUserPermissions(testOrg, "alice")
+	if code := do(t, r, http.MethodGet, "/guarded", tok, nil, nil); code != http.StatusOK {
+		t.Errorf("after invalidating the user: %d, want 200", code)
+	}
+
+	m.AddRole(testOrg, "r1", "Operator", PermCaseDelete)
+	in.RoleID = "r1"
+	if err := m.Users.UpdateByUsername(context.Background(), testOrg, "alice", in, "admin"); err != nil {
+		t.Fatal(err)
+	}
+	invalidatePermissions(testOrg, "r2")
+	if code := do(t, r, http.MethodGet, "/guarded", tok, nil, nil); code != http.StatusOK {
+		t.Errorf("after invalidating the cached role: %d, want 200", code)
+	}
+}
+
+func TestProtectedHandlerRejectsTokenWithoutUser(t *testing.T) {
+	newTestStore(t)
+	claims := jwt.MapClaims{"orgId": testOrg, "exp": time.Now().Add(time.Minute).Unix()}
+	tok, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(os.Getenv("TOKEN_SECRET_KEY")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := gin.New()
+	r.GET("/guarded", ProtectedHandler, func(c *gin.Context) { c.Status(http.StatusOK) })
+	if code := do(t, r, http.MethodGet, "/guarded", tok, nil, nil); code != http.StatusUnauthorized {
+		t.Errorf("token without a username: %d, want 401", code)
+	}
+}
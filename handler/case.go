@@ -1,10 +1,15 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"mainPackage/config"
+	"mainPackage/formdata"
 	"mainPackage/model"
+	"mainPackage/store"
+	"mainPackage/workflow"
 	"net/http"
 	"strconv"
 	"time"
@@ -75,14 +80,10 @@ func genCaseID() string {
 // @Router /api/v1/case [get]
 func ListCase(c *gin.Context) {
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
 
-	orgId := GetVariableFromToken(c, "orgId")
+	orgId := tokenString(c, "orgId")
 	startStr := c.DefaultQuery("start", "0")
 	start, err := strconv.Atoi(startStr)
 	if err != nil {
@@ -94,84 +95,21 @@ func ListCase(c *gin.Context) {
 		length = 1000
 	}
 
-	caseType := c.Query("caseType")
-	caseSType := c.Query("caseSType")
-	detail := c.Query("detail")
-	startDate := c.Query("start_date")
-	endDate := c.Query("end_date")
-	category := c.Query("category")
-	countryId := c.Query("countryId")
-	provId := c.Query("provId")
-	distId := c.Query("distId")
-
-	// Dynamic query builder
-	baseQuery := `SELECT id, "orgId", "caseId", "caseVersion", "referCaseId", "caseTypeId", "caseSTypeId", priority, "wfId", source, "deviceId", "phoneNo", "phoneNoHide", "caseDetail", "extReceive", "statusId", "caseLat", "caseLon", "caselocAddr", "caselocAddrDecs", "countryId", "provId", "distId", "caseDuration", "createdAt", "startedDate", "commandedDate", "receivedDate", "arrivedDate", "closedDate", usercreate, usercommand, userreceive, userarrive, userclose, "resId", "resDetail",  "scheduleFlag", "scheduleDate", "createdAt", "updatedAt", "createdBy", "updatedBy"
-	FROM public.tix_cases WHERE "orgId" = $1`
-
-	params := []interface{}{orgId}
-	paramIndex := 2 // start at $2 because $1 is already used for orgId
-
-	// Add conditions dynamically
-	if caseType != "" {
-		baseQuery += fmt.Sprintf(" AND \"caseTypeId\" = $%d", paramIndex)
-		params = append(params, caseType)
-		paramIndex++
-	}
-
-	if caseSType != "" {
-		baseQuery += fmt.Sprintf(" AND \"caseSTypeId\" = $%d", paramIndex)
-		params = append(params, caseSType)
-		paramIndex++
-	}
-
-	if detail != "" {
-		baseQuery += fmt.Sprintf(" AND \"caseDetail\" ILIKE $%d", paramIndex)
-		params = append(params, "%"+detail+"%")
-		paramIndex++
-	}
-
-	if startDate != "" {
-		baseQuery += fmt.Sprintf(" AND \"createdAt\" >= $%d", paramIndex)
-		params = append(params, startDate)
-		paramIndex++
-	}
-
-	if endDate != "" {
-		baseQuery += fmt.Sprintf(" AND \"createdAt\" <= $%d", paramIndex)
-		params = append(params, endDate)
-		paramIndex++
-	}
-
-	if category != "" {
-		baseQuery += fmt.Sprintf(" AND \"statusId\" = $%d", paramIndex)
-		params = append(params, category)
-		paramIndex++
-	}
-
-	// New filters
-	if countryId != "" {
-		baseQuery += fmt.Sprintf(" AND \"countryId\" = $%d", paramIndex)
-		params = append(params, countryId)
-		paramIndex++
-	}
-
-	if provId != "" {
-		baseQuery += fmt.Sprintf(" AND \"provId\" = $%d", paramIndex)
-		params = append(params, provId)
-		paramIndex++
-	}
-
-	if distId != "" {
-		baseQuery += fmt.Sprintf(" AND \"distId\" = $%d", paramIndex)
-		params = append(params, distId)
-		paramIndex++
-	}
-
-	// Add pagination
-	baseQuery += fmt.Sprintf(" ORDER BY \"createdAt\" DESC LIMIT $%d OFFSET $%d", paramIndex, paramIndex+1)
-	params = append(params, length, start)
-
-	rows, err := conn.Query(ctx, baseQuery, params...)
+	filter := model.CaseFilter{
+		CaseTypeID:  c.Query("caseType"),
+		CaseSTypeID: c.Query("caseSType"),
+		Detail:      c.Query("detail"),
+		StartDate:   c.Query("start_date"),
+		EndDate:     c.Query("end_date"),
+		StatusID:    c.Query("category"),
+		CountryID:   c.Query("countryId"),
+		ProvID:      c.Query("provId"),
+		DistID:      c.Query("distId"),
+		Start:       start,
+		Length:      length,
+	}
+	logger.Debug(`Filter`, zap.Any("filter", filter))
+	caseLists, err := repo.Cases.List(ctx, orgId, filter)
 	if err != nil {
 		logger.Warn("Query failed", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, model.Response{
@@ -181,73 +119,8 @@ func ListCase(c *gin.Context) {
 		})
 		return
 	}
-	defer rows.Close()
-
-	var caseLists []model.Case
-	found := false
-	for rows.Next() {
-		var cusCase model.Case
-		err := rows.Scan(
-			&cusCase.ID,
-			&cusCase.OrgID,
-			&cusCase.CaseID,
-			&cusCase.CaseVersion,
-			&cusCase.ReferCaseID,
-			&cusCase.CaseTypeID,
-			&cusCase.CaseSTypeID,
-			&cusCase.Priority,
-			&cusCase.WfID,
-			&cusCase.Source,
-			&cusCase.DeviceID,
-			&cusCase.PhoneNo,
-			&cusCase.PhoneNoHide,
-			&cusCase.CaseDetail,
-			&cusCase.ExtReceive,
-			&cusCase.StatusID,
-			&cusCase.CaseLat,
-			&cusCase.CaseLon,
-			&cusCase.CaseLocAddr,
-			&cusCase.CaseLocAddrDecs,
-			&cusCase.CountryID,
-			&cusCase.ProvID,
-			&cusCase.DistID,
-			&cusCase.CaseDuration,
-			&cusCase.CreatedDate,
-			&cusCase.StartedDate,
-			&cusCase.CommandedDate,
-			&cusCase.ReceivedDate,
-			&cusCase.ArrivedDate,
-			&cusCase.ClosedDate,
-			&cusCase.UserCreate,
-			&cusCase.UserCommand,
-			&cusCase.UserReceive,
-			&cusCase.UserArrive,
-			&cusCase.UserClose,
-			&cusCase.ResID,
-			&cusCase.ResDetail,
-			&cusCase.ScheduleFlag,
-			&cusCase.ScheduleDate,
-			&cusCase.CreatedAt,
-			&cusCase.UpdatedAt,
-			&cusCase.CreatedBy,
-			&cusCase.UpdatedBy,
-		)
-
-		if err != nil {
-			logger.Warn("Query failed", zap.Error(err))
-			c.JSON(http.StatusInternalServerError, model.Response{
-				Status: "-1",
-				Msg:    "Failed",
-				Desc:   err.Error(),
-			})
-			return
-		}
-
-		caseLists = append(caseLists, cusCase)
-		found = true
-	}
 
-	if !found {
+	if len(caseLists) == 0 {
 		c.JSON(http.StatusInternalServerError, model.Response{
 			Status: "-1",
 			Msg:    "Failed",
@@ -279,65 +152,16 @@ func ListCase(c *gin.Context) {
 // @Router /api/v1/case/{id} [get]
 func CaseById(c *gin.Context) {
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
-	orgId := GetVariableFromToken(c, "orgId")
+	orgId := tokenString(c, "orgId")
 	id := c.Param("id")
 
-	query := `SELECT id, "orgId", "caseId", "caseVersion", "referCaseId", "caseTypeId", "caseSTypeId", priority, "wfId", "versions", source, "deviceId", "phoneNo", "phoneNoHide", "caseDetail", "extReceive", "statusId", "caseLat", "caseLon", "caselocAddr", "caselocAddrDecs", "countryId", "provId", "distId", "caseDuration", "createdDate", "startedDate", "commandedDate", "receivedDate", "arrivedDate", "closedDate", usercreate, usercommand, userreceive, userarrive, userclose, "resId", "resDetail", "ScheduleFlag", "scheduleDate", "createdAt", "updatedAt", "createdBy", "updatedBy"
-	FROM public.tix_cases WHERE "orgId"=$1 AND id=$2`
-	logger.Debug(`Query`, zap.String("query", query))
-	var cusCase model.Case
-	err := conn.QueryRow(ctx, query, orgId, id).Scan(
-		&cusCase.ID,
-		&cusCase.OrgID,
-		&cusCase.CaseID,
-		&cusCase.CaseVersion,
-		&cusCase.ReferCaseID,
-		&cusCase.CaseTypeID,
-		&cusCase.CaseSTypeID,
-		&cusCase.Priority,
-		&cusCase.WfID,
-		&cusCase.WfVersions,
-		&cusCase.Source,
-		&cusCase.DeviceID,
-		&cusCase.PhoneNo,
-		&cusCase.PhoneNoHide,
-		&cusCase.CaseDetail,
-		&cusCase.ExtReceive,
-		&cusCase.StatusID,
-		&cusCase.CaseLat,
-		&cusCase.CaseLon,
-		&cusCase.CaseLocAddr,
-		&cusCase.CaseLocAddrDecs,
-		&cusCase.CountryID,
-		&cusCase.ProvID,
-		&cusCase.DistID,
-		&cusCase.CaseDuration,
-		&cusCase.CreatedDate,
-		&cusCase.StartedDate,
-		&cusCase.CommandedDate,
-		&cusCase.ReceivedDate,
-		&cusCase.ArrivedDate,
-		&cusCase.ClosedDate,
-		&cusCase.UserCreate,
-		&cusCase.UserCommand,
-		&cusCase.UserReceive,
-		&cusCase.UserArrive,
-		&cusCase.UserClose,
-		&cusCase.ResID,
-		&cusCase.ResDetail,
-		&cusCase.ScheduleFlag,
-		&cusCase.ScheduleDate,
-		&cusCase.CreatedAt,
-		&cusCase.UpdatedAt,
-		&cusCase.CreatedBy,
-		&cusCase.UpdatedBy,
-	)
+	cusCase, err := repo.Cases.GetByID(ctx, orgId, id)
+	if err == nil {
+		cusCase.FormSubmissions, err = repo.Forms.ListSubmissions(ctx, orgId, cusCase.CaseID,
+			model.FormSubmissionFilter{Latest: true})
+	}
 	if err != nil {
 		logger.Warn("Query failed", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, model.Response{
@@ -363,22 +187,18 @@ func CaseById(c *gin.Context) {
 }
 
 // @summary Create Case
+// @description Creates a case in the organization's initial status, the target of its case status transition without fromStatusId, or "new" when it has none; 409 when that transition is inactive or when wfId names a workflow that was never published. With wfId the case opens on the start node of the workflow's published version; nodeId may be left out and is rejected when it names another node. The lifecycle dates and users are left for status changes to set.
 // @id Create Case
 // @security ApiKeyAuth
 // @tags Cases
 // @accept json
 // @produce json
 // @param Body body model.CaseInsert true "Create Data"
-// @response 200 {object} model.Response "OK - Request successful"
+// @response 200 {object} model.Response{data=model.CaseCreated} "OK - Request successful"
 // @Router /api/v1/case/add [post]
 func InsertCase(c *gin.Context) {
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
-	defer cancel()
-	defer conn.Close(ctx)
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
 
 	var req model.CaseInsert
@@ -391,44 +211,107 @@ func InsertCase(c *gin.Context) {
 		logger.Warn("Insert failed", zap.Error(err))
 		return
 	}
-	username := GetVariableFromToken(c, "username")
+	username := tokenString(c, "username")
 
-	now := time.Now()
 	var caseId string
 	if req.CaseId == nil || *req.CaseId == "" || *req.CaseId == "null" {
 		caseId = genCaseID()
 	} else {
 		caseId = *req.CaseId
 	}
-	var id int
-	orgId := GetVariableFromToken(c, "orgId")
-	query := `
-	INSERT INTO public."tix_cases"(
-	"orgId", "caseId", "caseVersion", "referCaseId", "caseTypeId", "caseSTypeId", priority, "wfId", "versions",source, "deviceId",
-	"phoneNo", "phoneNoHide", "caseDetail", "extReceive", "statusId", "caseLat", "caseLon", "caselocAddr",
-	"caselocAddrDecs", "countryId", "provId", "distId", "caseDuration", "createdDate", "startedDate",
-	"commandedDate", "receivedDate", "arrivedDate", "closedDate", usercreate, usercommand, userreceive,
-	userarrive, userclose, "resId", "resDetail", "scheduleFlag", "scheduleDate", "createdAt", "updatedAt", "createdBy", "updatedBy")
-	VALUES (
-		$1, $2, $3, $4, $5, $6, $7, $8, $9, $10,
-		$11, $12, $13, $14, $15, $16, $17, $18, $19, $20,
-		$21, $22, $23, $24, $25, $26, $27, $28, $29, $30,
-		$31, $32, $33, $34, $35, $36, $37, $38, $39 , $40, $41, $42, $43
-	) RETURNING id ;
-	`
-
-	logger.Debug(`Query`, zap.String("query", query), zap.Any("req", req))
-	err := conn.QueryRow(ctx, query,
-		orgId, caseId, req.CaseVersion, req.ReferCaseID, req.CaseTypeID, req.CaseSTypeID, req.Priority, req.WfID, req.WfVersions,
-		req.Source, req.DeviceID, req.PhoneNo, req.PhoneNoHide, req.CaseDetail, req.ExtReceive, req.StatusID,
-		req.CaseLat, req.CaseLon, req.CaseLocAddr, req.CaseLocAddrDecs, req.CountryID, req.ProvID, req.DistID,
-		req.CaseDuration, req.CreatedDate, req.StartedDate, req.CommandedDate, req.ReceivedDate, req.ArrivedDate,
-		req.ClosedDate, req.UserCreate, req.UserCommand, req.UserReceive, req.UserArrive, req.UserClose, req.ResID,
-		req.ResDetail, req.ScheduleFlag, req.ScheduleDate, now, now, username, username).Scan(&id)
+	orgId := tokenString(c, "orgId")
+
+	logger.Debug(`Insert case`, zap.String("caseId", caseId), zap.Any("req", req))
 
+	// Case, current stage, history and notification commit or roll back
+	// together; the broadcast only happens once everything is stored.
+	created := model.CaseCreated{CaseID: caseId}
+	var notis []model.Notification
+	err := repo.InTx(ctx, func(tx *store.Store) error {
+		statusId, err := workflow.InitialStatus(ctx, tx, orgId)
+		if err != nil {
+			return err
+		}
+		req.StatusID = statusId
+
+		// Pin the case to the workflow version that is current now, so later
+		// edits and publishes do not change its SOP, and open it on that
+		// version's start node.
+		var start *model.WfNode
+		if req.WfID != nil && *req.WfID != "" {
+			def, err := tx.Workflows.GetDefinition(ctx, orgId, *req.WfID)
+			if err != nil {
+				if errors.Is(err, store.ErrNotFound) {
+					return fmt.Errorf("workflow %s: %w", *req.WfID, err)
+				}
+				return err
+			}
+			if def.Versions == "" || def.Versions == store.Draft {
+				return fmt.Errorf("%w: %s", workflow.ErrNotPublished, *req.WfID)
+			}
+			req.WfVersions = &def.Versions
+			start, err = workflow.Start(ctx, tx, orgId, *req.WfID, def.Versions)
+			if err != nil {
+				return err
+			}
+			if req.NodeID != "" && req.NodeID != start.NodeID {
+				return fmt.Errorf("%w: nodeId %s is not the start node %s", errInvalidCase, req.NodeID, start.NodeID)
+			}
+		} else if req.NodeID != "" {
+			return fmt.Errorf("%w: nodeId needs a wfId", errInvalidCase)
+		} else {
+			req.WfVersions = nil
+		}
+
+		id, err := tx.Cases.Create(ctx, orgId, caseId, username, req)
+		if err != nil {
+			return err
+		}
+		created.ID = id
+
+		if start != nil {
+			if err := tx.Cases.CreateCurrentStage(ctx, orgId, caseId, username, *start); err != nil {
+				return err
+			}
+			created.CurrentStage, err = tx.Cases.GetCurrentStage(ctx, orgId, caseId)
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err = tx.Cases.AddHistory(ctx, orgId, username, model.CaseHistoryInsert{
+			CaseID:  caseId,
+			Type:    "create",
+			FullMsg: "Create case : " + caseId,
+		})
+		if err != nil {
+			return err
+		}
+
+		//Noti Custom
+		data := []model.Data{
+			{Key: "Create", Value: "2"},
+		}
+		recipients := []model.Recipient{
+			{Type: "provId", Value: req.ProvID},
+		}
+		notis, err = createNotifications(ctx, tx, []model.NotificationCreateRequest{
+			notiCustomRequest(orgId, "System", username, "", "Create", data, "เปิด Work order สำเร็จ : "+caseId, recipients, "", "User"),
+		})
+		return err
+	})
 	if err != nil {
-		// log.Printf("Insert failed: %v", err)
-		c.JSON(http.StatusInternalServerError, model.Response{
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, errInvalidCase):
+			status = http.StatusBadRequest
+		case errors.Is(err, workflow.ErrNoInitialStatus), errors.Is(err, workflow.ErrNotPublished),
+			errors.Is(err, workflow.ErrNoStartNode):
+			status = http.StatusConflict
+		}
+		c.JSON(status, model.Response{
 			Status: "-1",
 			Msg:    "Failure",
 			Desc:   err.Error(),
@@ -436,38 +319,15 @@ func InsertCase(c *gin.Context) {
 		logger.Warn("Insert failed", zap.Error(err))
 		return
 	}
-	fmt.Printf("=======xxxx========")
-	if req.NodeID != "" {
-		var data = model.CustomCaseCurrentStage{
-			CaseID: caseId,
-			WfID:   req.WfID,
-			NodeID: req.NodeID,
-		}
-		fmt.Printf("=======yyy========")
-		err = CaseCurrentStageInsert(conn, ctx, c, data)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, model.Response{
-				Status: "-1",
-				Msg:    "Failure",
-				Desc:   err.Error(),
-			})
-			return
-		}
-	}
-
-	//Noti Custom
-	data := []model.Data{
-		{Key: "Create", Value: "2"},
-	}
 
-	recipients := []model.Recipient{
-		{Type: "provId", Value: req.ProvID},
+	if len(notis) > 0 {
+		kickOutbox()
 	}
-	genNotiCustom(c, orgId.(string), "System", username.(string), "", "Create", data, "เปิด Work order สำเร็จ : "+caseId, recipients, "", "User")
 
 	c.JSON(http.StatusOK, model.Response{
 		Status: "0",
 		Msg:    "Success",
+		Data:   created,
 		Desc:   "Create successfully",
 	})
 
@@ -485,12 +345,7 @@ func InsertCase(c *gin.Context) {
 // @Router /api/v1/case/{id} [patch]
 func UpdateCase(c *gin.Context) {
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
-	defer cancel()
-	defer conn.Close(ctx)
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
 
 	id := c.Param("id")
@@ -505,36 +360,14 @@ func UpdateCase(c *gin.Context) {
 		})
 		return
 	}
-	now := time.Now()
-	username := GetVariableFromToken(c, "username")
-	orgId := GetVariableFromToken(c, "orgId")
-	query := `UPDATE public."tix_cases"
-	SET "caseVersion"=$3, "referCaseId"=$4, "caseTypeId"=$5, "caseSTypeId"=$6,
-	 priority=$7, source=$8, "deviceId"=$9, "phoneNo"=$10, "phoneNoHide"=$11, "caseDetail"=$12, "extReceive"=$13,
-	  "statusId"=$14, "caseLat"=$15, "caseLon"=$16, "caselocAddr"=$17, "caselocAddrDecs"=$18, "countryId"=$19,
-	   "provId"=$20, "distId"=$21, "caseDuration"=$22, "createdDate"=$23, "startedDate"=$24, "commandedDate"=$25,
-	    "receivedDate"=$26, "arrivedDate"=$27, "closedDate"=$28, usercreate=$29, usercommand=$30, userreceive=$31,
-		 userarrive=$32, userclose=$33, "resId"=$34, "resDetail"=$35, "scheduleFlag"=$36 , "scheduleDate"=$37, "updatedAt"=$38,"updatedBy"=$39 ,"wfId"=$40
-	WHERE id = $1 AND "orgId"=$2`
-	_, err := conn.Exec(ctx, query,
-		id, orgId, req.CaseVersion, req.ReferCaseID, req.CaseTypeID, req.CaseSTypeID, req.Priority,
-		req.Source, req.DeviceID, req.PhoneNo, req.PhoneNoHide, req.CaseDetail, req.ExtReceive, req.StatusID,
-		req.CaseLat, req.CaseLon, req.CaseLocAddr, req.CaseLocAddrDecs, req.CountryID, req.ProvID, req.DistID,
-		req.CaseDuration, req.CreatedDate, req.StartedDate, req.CommandedDate, req.ReceivedDate, req.ArrivedDate,
-		req.ClosedDate, req.UserCreate, req.UserCommand, req.UserReceive, req.UserArrive, req.UserClose, req.ResID,
-		req.ResDetail, req.ScheduleFlag, req.ScheduleDate, now, username, req.WfID)
-	logger.Debug("Update Case SQL Args",
-		zap.String("query", query),
-		zap.Any("Input", []any{
-			id, orgId, req.CaseVersion, req.ReferCaseID, req.CaseTypeID, req.CaseSTypeID, req.Priority,
-			req.Source, req.DeviceID, req.PhoneNo, req.PhoneNoHide, req.CaseDetail, req.ExtReceive, req.StatusID,
-			req.CaseLat, req.CaseLon, req.CaseLocAddr, req.CaseLocAddrDecs, req.CountryID, req.ProvID, req.DistID,
-			req.CaseDuration, req.CreatedDate, req.StartedDate, req.CommandedDate, req.ReceivedDate, req.ArrivedDate,
-			req.ClosedDate, req.UserCreate, req.UserCommand, req.UserReceive, req.UserArrive, req.UserClose, req.ResID,
-			req.ResDetail, req.ScheduleFlag, req.ScheduleDate, now, username, req.WfID,
-		}))
+	username := tokenString(c, "username")
+	orgId := tokenString(c, "orgId")
+	logger.Debug("Update case", zap.String("id", id), zap.Any("Input", req))
+	if prior, err := repo.Cases.GetByID(ctx, orgId, id); err == nil {
+		auditPrior(c, prior)
+	}
+	err := repo.Cases.Update(ctx, orgId, id, username, req)
 	if err != nil {
-		// log.Printf("Insert failed: %v", err)
 		c.JSON(http.StatusInternalServerError, model.Response{
 			Status: "-1",
 			Msg:    "Failure",
@@ -564,20 +397,16 @@ func UpdateCase(c *gin.Context) {
 func DeleteCase(c *gin.Context) {
 
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
-	defer cancel()
-	orgId := GetVariableFromToken(c, "orgId")
+	orgId := tokenString(c, "orgId")
 	id := c.Param("id")
-	query := `DELETE FROM public."tix_cases" WHERE id = $1 AND "orgId"=$2`
-	logger.Debug("Query", zap.String("query", query), zap.Any("id", id))
-	_, err := conn.Exec(ctx, query, id, orgId)
+	logger.Debug("Delete case", zap.Any("id", id))
+	if prior, err := repo.Cases.GetByID(ctx, orgId, id); err == nil {
+		auditPrior(c, prior)
+	}
+	err := repo.Cases.Delete(ctx, orgId, id)
 	if err != nil {
-		// log.Printf("Insert failed: %v", err)
 		c.JSON(http.StatusInternalServerError, model.Response{
 			Status: "-1",
 			Msg:    "Failure",
@@ -605,23 +434,10 @@ func DeleteCase(c *gin.Context) {
 // @Router /api/v1/casetypes_with_subtype [get]
 func ListCaseTypeWithSubtype(c *gin.Context) {
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
-	orgId := GetVariableFromToken(c, "orgId")
-
-	query := `SELECT t1."typeId",t1."orgId",t1."en",t1."th",t1."active",t2."sTypeId",t2."sTypeCode",
-	t2."en",t2."th",t2."wfId", t2."caseSla", t2.priority, t2."userSkillList", t2."unitPropLists", t2.active
-	FROM public.case_types t1
-	FULL JOIN public.case_sub_types t2
-	ON t1."typeId" = t2."typeId"
-	WHERE t1."orgId"=$1`
-	logger.Debug(`Query`, zap.String("query", query))
-
-	rows, err := conn.Query(ctx, query, orgId)
+
+	list, err := repo.CaseTypes.ListWithSubTypes(ctx, tokenString(c, "orgId"))
 	if err != nil {
 		logger.Warn("Query failed", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, model.Response{
@@ -631,33 +447,10 @@ func ListCaseTypeWithSubtype(c *gin.Context) {
 		})
 		return
 	}
-	defer rows.Close()
-
-	var caseLists []model.CaseTypeWithSubType
-	var errorMsg string
-	for rows.Next() {
-		var cusCase model.CaseTypeWithSubType
-		err := rows.Scan(
-			&cusCase.TypeID, &cusCase.OrgID, &cusCase.TypeEN, &cusCase.TypeTH, &cusCase.TypeActive,
-			&cusCase.SubTypeID, &cusCase.SubTypeCode, &cusCase.SubTypeEN, &cusCase.SubTypeTH,
-			&cusCase.WfID, &cusCase.CaseSla, &cusCase.Priority,
-			&cusCase.UserSkillList, &cusCase.UnitPropLists, &cusCase.SubTypeActive,
-		)
-		if err != nil {
-			logger.Warn("Query failed", zap.Error(err))
-			errorMsg = err.Error()
-			continue
-		}
-
-		caseLists = append(caseLists, cusCase)
-	}
-
-	// Final JSON
 	response := model.Response{
 		Status: "0",
 		Msg:    "Success",
-		Data:   caseLists,
-		Desc:   errorMsg,
+		Data:   list,
 	}
 	c.JSON(http.StatusOK, response)
 
@@ -678,28 +471,11 @@ func ListCaseTypeWithSubtype(c *gin.Context) {
 // @Router /api/v1/casetypes [get]
 func ListCaseType(c *gin.Context) {
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
-	orgId := GetVariableFromToken(c, "orgId")
-	startStr := c.DefaultQuery("start", "0")
-	start, err := strconv.Atoi(startStr)
-	if err != nil {
-		start = 0
-	}
-	lengthStr := c.DefaultQuery("length", "1000")
-	length, err := strconv.Atoi(lengthStr)
-	if err != nil {
-		length = 1000
-	}
-	query := `SELECT id,"typeId", "orgId", en, th, active, "createdAt", "updatedAt", "createdBy", "updatedBy" 
-	FROM public.case_types WHERE "orgId"=$1 LIMIT $2 OFFSET $3`
-	logger.Debug(`Query`, zap.String("query", query))
+	start, length := pageQuery(c)
 
-	rows, err := conn.Query(ctx, query, orgId, length, start)
+	list, err := repo.CaseTypes.List(ctx, tokenString(c, "orgId"), length, start)
 	if err != nil {
 		logger.Warn("Query failed", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, model.Response{
@@ -709,29 +485,10 @@ func ListCaseType(c *gin.Context) {
 		})
 		return
 	}
-	defer rows.Close()
-
-	var caseLists []model.CaseType
-	var errorMsg string
-	for rows.Next() {
-		var cusCase model.CaseType
-		err := rows.Scan(&cusCase.Id, &cusCase.TypeId, &cusCase.OrgId, &cusCase.En, &cusCase.Th, &cusCase.Active, &cusCase.CreatedAt,
-			&cusCase.UpdatedAt, &cusCase.CreatedBy, &cusCase.UpdatedBy)
-		if err != nil {
-			logger.Warn("Query failed", zap.Error(err))
-			errorMsg = err.Error()
-			continue
-		}
-
-		caseLists = append(caseLists, cusCase)
-	}
-
-	// Final JSON
 	response := model.Response{
 		Status: "0",
 		Msg:    "Success",
-		Data:   caseLists,
-		Desc:   errorMsg,
+		Data:   list,
 	}
 	c.JSON(http.StatusOK, response)
 
@@ -751,12 +508,7 @@ func ListCaseType(c *gin.Context) {
 // @Router /api/v1/casetypes/add [post]
 func InsertCaseType(c *gin.Context) {
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
-	defer cancel()
-	defer conn.Close(ctx)
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
 
 	var req model.CaseTypeInsert
@@ -769,24 +521,10 @@ func InsertCaseType(c *gin.Context) {
 		logger.Warn("Insert failed", zap.Error(err))
 		return
 	}
-	username := GetVariableFromToken(c, "username")
-	now := time.Now()
-	var id int
-	orgId := GetVariableFromToken(c, "orgId")
-	uuid := uuid.New()
-	query := `
-	INSERT INTO public."case_types"(
-	"typeId", "orgId", en, th, active, "createdAt", "updatedAt", "createdBy", "updatedBy")
-	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
-	RETURNING id ;
-	`
-
-	err := conn.QueryRow(ctx, query,
-		uuid, orgId, req.En, req.Th, req.Active, now,
-		now, username, username).Scan(&id)
+	username := tokenString(c, "username")
+	orgId := tokenString(c, "orgId")
 
-	if err != nil {
-		// log.Printf("Insert failed: %v", err)
+	if err := repo.CaseTypes.Create(ctx, orgId, uuid.NewString(), username, req); err != nil {
 		c.JSON(http.StatusInternalServerError, model.Response{
 			Status: "-1",
 			Msg:    "Failure",
@@ -795,14 +533,11 @@ func InsertCaseType(c *gin.Context) {
 		logger.Warn("Insert failed", zap.Error(err))
 		return
 	}
-
-	// Continue logic...
 	c.JSON(http.StatusOK, model.Response{
 		Status: "0",
 		Msg:    "Success",
 		Desc:   "Create successfully",
 	})
-
 }
 
 // @summary Update CaseType
@@ -817,46 +552,24 @@ func InsertCaseType(c *gin.Context) {
 // @Router /api/v1/casetypes/{id} [patch]
 func UpdateCaseType(c *gin.Context) {
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
-	defer cancel()
-	defer conn.Close(ctx)
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
 
-	id := c.Param("id")
-
 	var req model.CaseTypeUpdate
 	if err := c.ShouldBindJSON(&req); err != nil {
-		logger.Warn("Update failed", zap.Error(err))
 		c.JSON(http.StatusBadRequest, model.Response{
 			Status: "-1",
 			Msg:    "Failure",
 			Desc:   err.Error(),
 		})
+		logger.Warn("Update failed", zap.Error(err))
 		return
 	}
-	now := time.Now()
-	username := GetVariableFromToken(c, "username")
-	orgId := GetVariableFromToken(c, "orgId")
-	query := `UPDATE public."case_types"
-	SET en=$2, th=$3, active=$4,
-	 "updatedAt"=$5, "updatedBy"=$6
-	WHERE id = $1 AND "orgId"=$7`
-	_, err := conn.Exec(ctx, query,
-		id, req.En, req.Th, req.Active,
-		now, username, orgId,
-	)
-	logger.Debug("Update Case SQL Args",
-		zap.String("query", query),
-		zap.Any("Input", []any{
-			id, req.En, req.Th, req.Active,
-			now, username, orgId,
-		}))
-	if err != nil {
-		// log.Printf("Insert failed: %v", err)
-		c.JSON(http.StatusInternalServerError, model.Response{
+	username := tokenString(c, "username")
+	orgId := tokenString(c, "orgId")
+
+	if err := repo.CaseTypes.Update(ctx, orgId, c.Param("id"), username, req); err != nil {
+		c.JSON(storeStatus(err), model.Response{
 			Status: "-1",
 			Msg:    "Failure",
 			Desc:   err.Error(),
@@ -864,8 +577,6 @@ func UpdateCaseType(c *gin.Context) {
 		logger.Warn("Update failed", zap.Error(err))
 		return
 	}
-
-	// Continue logic...
 	c.JSON(http.StatusOK, model.Response{
 		Status: "0",
 		Msg:    "Success",
@@ -883,32 +594,19 @@ func UpdateCaseType(c *gin.Context) {
 // @response 200 {object} model.Response "OK - Request successful"
 // @Router /api/v1/casetypes/{id} [delete]
 func DeleteCaseType(c *gin.Context) {
-
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
-	defer cancel()
-	defer conn.Close(ctx)
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	orgId := GetVariableFromToken(c, "orgId")
-	id := c.Param("id")
-	query := `DELETE FROM public."case_types" WHERE id = $1 AND "orgId"=$2`
-	logger.Debug("Query", zap.String("query", query), zap.Any("id", id))
-	_, err := conn.Exec(ctx, query, id, orgId)
-	if err != nil {
-		// log.Printf("Insert failed: %v", err)
-		c.JSON(http.StatusInternalServerError, model.Response{
+
+	if err := repo.CaseTypes.Delete(ctx, tokenString(c, "orgId"), c.Param("id")); err != nil {
+		c.JSON(storeStatus(err), model.Response{
 			Status: "-1",
 			Msg:    "Failure",
 			Desc:   err.Error(),
 		})
-		logger.Warn("Update failed", zap.Error(err))
+		logger.Warn("Delete failed", zap.Error(err))
 		return
 	}
-
-	// Continue logic...
 	c.JSON(http.StatusOK, model.Response{
 		Status: "0",
 		Msg:    "Success",
@@ -928,28 +626,11 @@ func DeleteCaseType(c *gin.Context) {
 // @Router /api/v1/casesubtypes [get]
 func ListCaseSubType(c *gin.Context) {
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
-	startStr := c.DefaultQuery("start", "0")
-	start, err := strconv.Atoi(startStr)
-	if err != nil {
-		start = 0
-	}
-	lengthStr := c.DefaultQuery("length", "1000")
-	length, err := strconv.Atoi(lengthStr)
-	if err != nil {
-		length = 1000
-	}
-	orgId := GetVariableFromToken(c, "orgId")
-	query := `SELECT id, "typeId", "sTypeId", "sTypeCode", "orgId", en, th, "wfId", "caseSla", priority, "userSkillList", "unitPropLists",
-	 active, "createdAt", "updatedAt", "createdBy", "updatedBy" FROM public.case_sub_types WHERE "orgId"=$1 LIMIT $2 OFFSET $3`
-	logger.Debug(`Query`, zap.String("query", query))
+	start, length := pageQuery(c)
 
-	rows, err := conn.Query(ctx, query, orgId, length, start)
+	list, err := repo.CaseTypes.ListSubTypes(ctx, tokenString(c, "orgId"), length, start)
 	if err != nil {
 		logger.Warn("Query failed", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, model.Response{
@@ -959,30 +640,10 @@ func ListCaseSubType(c *gin.Context) {
 		})
 		return
 	}
-	defer rows.Close()
-
-	var caseLists []model.CaseSubType
-	var errorMsg string
-	for rows.Next() {
-		var cusCase model.CaseSubType
-		err := rows.Scan(&cusCase.Id, &cusCase.TypeID, &cusCase.STypeID, &cusCase.STypeCode, &cusCase.OrgID, &cusCase.EN, &cusCase.TH, &cusCase.WFID,
-			&cusCase.CaseSLA, &cusCase.Priority, &cusCase.UserSkillList, &cusCase.UnitPropLists, &cusCase.Active,
-			&cusCase.CreatedAt, &cusCase.UpdatedAt, &cusCase.CreatedBy, &cusCase.UpdatedBy)
-		if err != nil {
-			logger.Warn("Query failed", zap.Error(err))
-			errorMsg = err.Error()
-			continue
-		}
-
-		caseLists = append(caseLists, cusCase)
-	}
-
-	// Final JSON
 	response := model.Response{
 		Status: "0",
 		Msg:    "Success",
-		Data:   caseLists,
-		Desc:   errorMsg,
+		Data:   list,
 	}
 	c.JSON(http.StatusOK, response)
 
@@ -1002,12 +663,7 @@ func ListCaseSubType(c *gin.Context) {
 // @Router /api/v1/casesubtypes/add [post]
 func InsertCaseSubType(c *gin.Context) {
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
-	defer cancel()
-	defer conn.Close(ctx)
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
 
 	var req model.CaseSubTypeInsert
@@ -1020,25 +676,10 @@ func InsertCaseSubType(c *gin.Context) {
 		logger.Warn("Insert failed", zap.Error(err))
 		return
 	}
-	username := GetVariableFromToken(c, "username")
-	now := time.Now()
-	var id int
-	orgId := GetVariableFromToken(c, "orgId")
-	uuid := uuid.New()
-	query := `
-	INSERT INTO public."case_sub_types"(
-	"typeId", "sTypeId", "sTypeCode", "orgId", en, th, "wfId", "caseSla", priority,
-	 "userSkillList", "unitPropLists", active, "createdAt", "updatedAt", "createdBy", "updatedBy")
-	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
-	RETURNING id ;
-	`
-
-	err := conn.QueryRow(ctx, query, req.TypeID, uuid, req.STypeCode, orgId, req.EN, req.TH,
-		req.WFID, req.CaseSLA, req.Priority, req.UserSkillList, req.UnitPropLists, req.Active, now,
-		now, username, username).Scan(&id)
+	username := tokenString(c, "username")
+	orgId := tokenString(c, "orgId")
 
-	if err != nil {
-		// log.Printf("Insert failed: %v", err)
+	if err := repo.CaseTypes.CreateSubType(ctx, orgId, uuid.NewString(), username, req); err != nil {
 		c.JSON(http.StatusInternalServerError, model.Response{
 			Status: "-1",
 			Msg:    "Failure",
@@ -1047,14 +688,11 @@ func InsertCaseSubType(c *gin.Context) {
 		logger.Warn("Insert failed", zap.Error(err))
 		return
 	}
-
-	// Continue logic...
 	c.JSON(http.StatusOK, model.Response{
 		Status: "0",
 		Msg:    "Success",
 		Desc:   "Create successfully",
 	})
-
 }
 
 // @summary Update CaseSubType
@@ -1069,47 +707,24 @@ func InsertCaseSubType(c *gin.Context) {
 // @Router /api/v1/casesubtypes/{id} [patch]
 func UpdateCaseSubType(c *gin.Context) {
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
-	defer cancel()
-	defer conn.Close(ctx)
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
 
-	id := c.Param("id")
-
 	var req model.CaseSubTypeUpdate
 	if err := c.ShouldBindJSON(&req); err != nil {
-		logger.Warn("Update failed", zap.Error(err))
 		c.JSON(http.StatusBadRequest, model.Response{
 			Status: "-1",
 			Msg:    "Failure",
 			Desc:   err.Error(),
 		})
+		logger.Warn("Update failed", zap.Error(err))
 		return
 	}
-	now := time.Now()
-	username := GetVariableFromToken(c, "username")
-	orgId := GetVariableFromToken(c, "orgId")
-	query := `UPDATE public."case_sub_types"
-	SET "sTypeCode"=$3, en=$4, th=$5, "wfId"=$6, "caseSla"=$7,
-	 priority=$8, "userSkillList"=$9, "unitPropLists"=$10, active=$11, "updatedAt"=$12,
-	  "updatedBy"=$13
-	WHERE id = $1 AND "orgId"=$2`
-	_, err := conn.Exec(ctx, query,
-		id, orgId, req.STypeCode, req.EN, req.TH, req.WFID, req.CaseSLA, req.Priority, req.UserSkillList, req.UnitPropLists, req.Active,
-		now, username,
-	)
-	logger.Debug("Update Case SQL Args",
-		zap.String("query", query),
-		zap.Any("Input", []any{
-			id, orgId, req.STypeCode, req.EN, req.TH, req.WFID, req.CaseSLA, req.Priority, req.UserSkillList, req.UnitPropLists, req.Active,
-			now, username,
-		}))
-	if err != nil {
-		// log.Printf("Insert failed: %v", err)
-		c.JSON(http.StatusInternalServerError, model.Response{
+	username := tokenString(c, "username")
+	orgId := tokenString(c, "orgId")
+
+	if err := repo.CaseTypes.UpdateSubType(ctx, orgId, c.Param("id"), username, req); err != nil {
+		c.JSON(storeStatus(err), model.Response{
 			Status: "-1",
 			Msg:    "Failure",
 			Desc:   err.Error(),
@@ -1117,8 +732,6 @@ func UpdateCaseSubType(c *gin.Context) {
 		logger.Warn("Update failed", zap.Error(err))
 		return
 	}
-
-	// Continue logic...
 	c.JSON(http.StatusOK, model.Response{
 		Status: "0",
 		Msg:    "Success",
@@ -1136,35 +749,517 @@ func UpdateCaseSubType(c *gin.Context) {
 // @response 200 {object} model.Response "OK - Request successful"
 // @Router /api/v1/casesubtypes/{id} [delete]
 func DeleteCaseSubType(c *gin.Context) {
+	logger := config.GetLog()
+	repo, ctx, cancel := getStore(c.Request.Context())
+	defer cancel()
+
+	if err := repo.CaseTypes.DeleteSubType(ctx, tokenString(c, "orgId"), c.Param("id")); err != nil {
+		c.JSON(storeStatus(err), model.Response{
+			Status: "-1",
+			Msg:    "Failure",
+			Desc:   err.Error(),
+		})
+		logger.Warn("Delete failed", zap.Error(err))
+		return
+	}
+	c.JSON(http.StatusOK, model.Response{
+		Status: "0",
+		Msg:    "Success",
+		Desc:   "Delete successfully",
+	})
+}
 
+// @summary Advance Case
+// @description Moves the case along one outgoing edge of its current workflow node. The label is only needed when several edges leave the node. A node that carries a form is left only after the form was submitted on it; a concurrent move of the same case returns 409.
+// @id Advance Case
+// @security ApiKeyAuth
+// @tags Cases
+// @accept json
+// @produce json
+// @Param id path int true "id"
+// @param Body body model.CaseAdvance false "Edge to follow"
+// @response 200 {object} model.Response{data=model.CaseAdvanced} "OK - Request successful"
+// @Router /api/v1/case/{id}/advance [post]
+func AdvanceCase(c *gin.Context) {
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
+	repo, ctx, cancel := getStore(c.Request.Context())
+	defer cancel()
+
+	var req model.CaseAdvance
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, model.Response{
+				Status: "-1",
+				Msg:    "Failure",
+				Desc:   err.Error(),
+			})
+			logger.Warn("Advance failed", zap.Error(err))
+			return
+		}
+	}
+	username := tokenString(c, "username")
+	orgId := tokenString(c, "orgId")
+	id := c.Param("id")
+
+	var result model.CaseAdvanced
+	var notis []model.Notification
+	err := repo.InTx(ctx, func(tx *store.Store) error {
+		cusCase, err := tx.Cases.GetByID(ctx, orgId, id)
+		if err != nil {
+			return err
+		}
+		step, err := workflow.Advance(ctx, tx, orgId, cusCase.CaseID, username, req.Label)
+		if err != nil {
+			return err
+		}
+		notis, err = runNodeEffects(ctx, tx, orgId, username, cusCase, step)
+		if err != nil {
+			return err
+		}
+		result.CaseID = cusCase.CaseID
+		result.FromNodeID = step.From.NodeId
+		result.Label = step.Edge.Label
+		result.CurrentStage, err = tx.Cases.GetCurrentStage(ctx, orgId, cusCase.CaseID)
+		return err
+	})
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, workflow.ErrNoCurrentStage), errors.Is(err, workflow.ErrNoTransition),
+			errors.Is(err, workflow.ErrLabelRequired), errors.Is(err, workflow.ErrUnknownLabel),
+			errors.Is(err, workflow.ErrFormRequired):
+			status = http.StatusBadRequest
+		case errors.Is(err, workflow.ErrStageChanged):
+			status = http.StatusConflict
+		}
+		c.JSON(status, model.Response{
+			Status: "-1",
+			Msg:    "Failure",
+			Desc:   err.Error(),
+		})
+		logger.Warn("Advance failed", zap.Error(err))
 		return
 	}
+
+	if len(notis) > 0 {
+		kickOutbox()
+	}
+
+	c.JSON(http.StatusOK, model.Response{
+		Status: "0",
+		Msg:    "Success",
+		Data:   result,
+		Desc:   "Advance successfully",
+	})
+}
+
+// @summary Change Case Status
+// @description Moves the case to statusId if the organization allows the change from its current status, and stamps the lifecycle date and user the transition names. caseDuration is recomputed, and the change is written to the case history and notified.
+// @id Change Case Status
+// @security ApiKeyAuth
+// @tags Cases
+// @accept json
+// @produce json
+// @Param id path int true "id"
+// @param Body body model.CaseStatusChange true "Status to move to"
+// @response 200 {object} model.Response{data=model.CaseStatusChanged} "OK - Request successful"
+// @Router /api/v1/case/{id}/status [post]
+func ChangeCaseStatus(c *gin.Context) {
+	logger := config.GetLog()
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
+
+	var req model.CaseStatusChange
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, model.Response{
+			Status: "-1",
+			Msg:    "Failure",
+			Desc:   err.Error(),
+		})
+		logger.Warn("Status change failed", zap.Error(err))
+		return
+	}
+	username := tokenString(c, "username")
+	orgId := tokenString(c, "orgId")
+	id := c.Param("id")
+
+	var result model.CaseStatusChanged
+	var notis []model.Notification
+	err := repo.InTx(ctx, func(tx *store.Store) error {
+		cusCase, err := tx.Cases.GetByID(ctx, orgId, id)
+		if err != nil {
+			return err
+		}
+		auditPrior(c, cusCase)
+		result.FromStatusID = cusCase.StatusID
+		t, err := workflow.ChangeStatus(ctx, tx, orgId, cusCase, req.StatusID, username, time.Now())
+		if err != nil {
+			return err
+		}
+		result.ToStatusID, result.Stamp = t.ToStatusID, t.Stamp
+		notis, err = runStatusEffects(ctx, tx, orgId, username, cusCase, result, req.Note)
+		if err != nil {
+			return err
+		}
+		result.Case, err = tx.Cases.GetByID(ctx, orgId, id)
+		return err
+	})
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, workflow.ErrStatusTransition), errors.Is(err, workflow.ErrStatusChanged):
+			status = http.StatusConflict
+		}
+		c.JSON(status, model.Response{
+			Status: "-1",
+			Msg:    "Failure",
+			Desc:   err.Error(),
+		})
+		logger.Warn("Status change failed", zap.Error(err))
+		return
+	}
+
+	if len(notis) > 0 {
+		kickOutbox()
+	}
+
+	c.JSON(http.StatusOK, model.Response{
+		Status: "0",
+		Msg:    "Success",
+		Data:   result,
+		Desc:   "Status changed successfully",
+	})
+}
+
+// runStatusEffects records a status change in the case history and notifies
+// the case's province and its creator.
+func runStatusEffects(ctx context.Context, repo *store.Store, orgId, username string, cusCase *model.Case, change model.CaseStatusChanged, note string) ([]model.Notification, error) {
+	jsonData, err := json.Marshal(map[string]string{
+		"fromStatusId": change.FromStatusID,
+		"toStatusId":   change.ToStatusID,
+		"stamp":        change.Stamp,
+		"note":         note,
+	})
+	if err != nil {
+		return nil, err
+	}
+	_, err = repo.Cases.AddHistory(ctx, orgId, username, model.CaseHistoryInsert{
+		CaseID:   cusCase.CaseID,
+		Type:     "status",
+		FullMsg:  "Change status : " + change.FromStatusID + " -> " + change.ToStatusID,
+		JSONData: string(jsonData),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var recipients []model.Recipient
+	if cusCase.ProvID != "" {
+		recipients = append(recipients, model.Recipient{Type: "provId", Value: cusCase.ProvID})
+	}
+	if cusCase.CreatedBy != "" && cusCase.CreatedBy != username {
+		recipients = append(recipients, model.Recipient{Type: "username", Value: cusCase.CreatedBy})
+	}
+	if len(recipients) == 0 {
+		return nil, nil
+	}
+	data := []model.Data{
+		{Key: "caseId", Value: cusCase.CaseID},
+		{Key: "fromStatusId", Value: change.FromStatusID},
+		{Key: "statusId", Value: change.ToStatusID},
+	}
+	return createNotifications(ctx, repo, []model.NotificationCreateRequest{
+		notiCustomRequest(orgId, username, username, "", "Status", data, "เปลี่ยนสถานะ "+cusCase.CaseID+" : "+change.ToStatusID, recipients, "", "User"),
+	})
+}
+
+// runNodeEffects applies what the node the case just entered asks for: a
+// history line for the move, a pending form when the node carries formId
+// (Advance will not leave the node until it is submitted), and a notification
+// to the node's pic and group.
+func runNodeEffects(ctx context.Context, repo *store.Store, orgId, username string, cusCase *model.Case, step *workflow.Transition) ([]model.Notification, error) {
+	to := step.To
+	name := nodeLabel(to)
+	jsonData, err := json.Marshal(map[string]string{
+		"fromNodeId": step.From.NodeId,
+		"toNodeId":   to.NodeID,
+		"label":      step.Edge.Label,
+	})
+	if err != nil {
+		return nil, err
+	}
+	_, err = repo.Cases.AddHistory(ctx, orgId, username, model.CaseHistoryInsert{
+		CaseID:   cusCase.CaseID,
+		Type:     "stage",
+		FullMsg:  "Move to : " + name,
+		JSONData: string(jsonData),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	data := []model.Data{
+		{Key: "caseId", Value: cusCase.CaseID},
+		{Key: "nodeId", Value: to.NodeID},
+	}
+	if to.FormID != nil && *to.FormID != "" {
+		_, err = repo.Cases.AddHistory(ctx, orgId, username, model.CaseHistoryInsert{
+			CaseID:  cusCase.CaseID,
+			Type:    "form",
+			FullMsg: "Form required : " + *to.FormID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, model.Data{Key: "formId", Value: *to.FormID})
+	}
+
+	var recipients []model.Recipient
+	if to.Pic != nil && *to.Pic != "" {
+		recipients = append(recipients, model.Recipient{Type: "username", Value: *to.Pic})
+	}
+	if to.Group != nil && *to.Group != "" {
+		recipients = append(recipients, model.Recipient{Type: "grpId", Value: *to.Group})
+	}
+	if len(recipients) == 0 {
+		return nil, nil
+	}
+	return createNotifications(ctx, repo, []model.NotificationCreateRequest{
+		notiCustomRequest(orgId, username, username, "", "Assign", data, "ได้รับมอบหมายงาน "+cusCase.CaseID+" : "+name, recipients, "", "User"),
+	})
+}
+
+// nodeLabel returns the label the designer gave a node, falling back to its
+// id.
+func nodeLabel(node model.WfNode) string {
+	if data, ok := node.Data["data"].(map[string]interface{}); ok {
+		if label, ok := data["label"].(string); ok && label != "" {
+			return label
+		}
+	}
+	return node.NodeID
+}
+
+// @summary Get Case Stage History
+// @id Get Case Stage History
+// @security ApiKeyAuth
+// @tags Cases
+// @accept json
+// @produce json
+// @Param id path int true "id"
+// @response 200 {object} model.Response{data=[]model.CaseStageHistory} "OK - Request successful"
+// @Router /api/v1/case/{id}/stages [get]
+func CaseStageHistory(c *gin.Context) {
+	logger := config.GetLog()
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	orgId := GetVariableFromToken(c, "orgId")
+	orgId := tokenString(c, "orgId")
 	id := c.Param("id")
-	query := `DELETE FROM public."case_sub_types" WHERE id = $1 AND "orgId"=$2`
-	logger.Debug("Query", zap.String("query", query), zap.Any("id", id))
-	_, err := conn.Exec(ctx, query, id, orgId)
+
+	cusCase, err := repo.Cases.GetByID(ctx, orgId, id)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, store.ErrNotFound) {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, model.Response{
+			Status: "-1",
+			Msg:    "Failure",
+			Desc:   err.Error(),
+		})
+		logger.Warn("Query failed", zap.Error(err))
+		return
+	}
+	list, err := repo.Cases.ListStageHistory(ctx, orgId, cusCase.CaseID)
 	if err != nil {
-		// log.Printf("Insert failed: %v", err)
 		c.JSON(http.StatusInternalServerError, model.Response{
 			Status: "-1",
 			Msg:    "Failure",
 			Desc:   err.Error(),
 		})
-		logger.Warn("Update failed", zap.Error(err))
+		logger.Warn("Query failed", zap.Error(err))
 		return
 	}
+	c.JSON(http.StatusOK, model.Response{
+		Status: "0",
+		Msg:    "Success",
+		Data:   list,
+	})
+}
 
-	// Continue logic...
+// @summary Submit Case Form
+// @id Submit Case Form
+// @security ApiKeyAuth
+// @tags Cases
+// @accept json
+// @produce json
+// @Param id path int true "id"
+// @Param formId path string true "formId"
+// @param Body body model.FormSubmissionInsert true "Submission, nodeId defaults to the current stage"
+// @response 200 {object} model.Response{data=model.FormSubmission} "OK - Request successful"
+// @response 400 {object} model.Response{data=[]model.FormFieldError} "Validation failed"
+// @Router /api/v1/case/{id}/forms/{formId}/submissions [post]
+func SubmitCaseForm(c *gin.Context) {
+	logger := config.GetLog()
+	repo, ctx, cancel := getStore(c.Request.Context())
+	defer cancel()
+
+	var req model.FormSubmissionInsert
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, model.Response{
+			Status: "-1",
+			Msg:    "Failure",
+			Desc:   err.Error(),
+		})
+		logger.Warn("Submit failed", zap.Error(err))
+		return
+	}
+	username := tokenString(c, "username")
+	orgId := tokenString(c, "orgId")
+	id := c.Param("id")
+	formId := c.Param("formId")
+
+	var fieldErrs []model.FormFieldError
+	var sub *model.FormSubmission
+	err := repo.InTx(ctx, func(tx *store.Store) error {
+		cusCase, err := tx.Cases.GetByID(ctx, orgId, id)
+		if err != nil {
+			return err
+		}
+		if req.NodeID == "" {
+			if stage, err := tx.Cases.GetCurrentStage(ctx, orgId, cusCase.CaseID); err == nil {
+				req.NodeID = stage.NodeId
+			} else if !errors.Is(err, store.ErrNotFound) {
+				return err
+			}
+		}
+		req.FormVersions, err = tx.Forms.CurrentVersion(ctx, orgId, formId)
+		if err != nil {
+			return err
+		}
+		form, err := tx.Forms.Get(ctx, orgId, formId, req.FormVersions)
+		if err != nil {
+			return err
+		}
+		if fieldErrs = formdata.Validate(form.FormFieldJson, req.Data); len(fieldErrs) > 0 {
+			return errInvalidSubmission
+		}
+		sub, err = tx.Forms.CreateSubmission(ctx, orgId, cusCase.CaseID, formId, username, req)
+		if err != nil {
+			return err
+		}
+		_, err = tx.Cases.AddHistory(ctx, orgId, username, model.CaseHistoryInsert{
+			CaseID:  cusCase.CaseID,
+			Type:    "form",
+			FullMsg: fmt.Sprintf("Form submitted : %s v%s (revision %d)", formId, sub.FormVersions, sub.Revision),
+		})
+		return err
+	})
+	if err != nil {
+		status := http.StatusInternalServerError
+		var data interface{}
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, errInvalidSubmission):
+			status = http.StatusBadRequest
+			data = fieldErrs
+		}
+		c.JSON(status, model.Response{
+			Status: "-1",
+			Msg:    "Failure",
+			Desc:   err.Error(),
+			Data:   data,
+		})
+		logger.Warn("Submit failed", zap.Error(err))
+		return
+	}
 	c.JSON(http.StatusOK, model.Response{
 		Status: "0",
 		Msg:    "Success",
-		Desc:   "Delete successfully",
+		Data:   sub,
+	})
+}
+
+var errInvalidSubmission = errors.New("submission does not match the form")
+
+var errInvalidCase = errors.New("invalid case")
+
+// @summary List Case Form Submissions
+// @id List Case Form Submissions
+// @security ApiKeyAuth
+// @tags Cases
+// @accept json
+// @produce json
+// @Param id path int true "id"
+// @Param formId path string true "formId"
+// @Param nodeId query string false "nodeId"
+// @Param latest query bool false "latest revision only"
+// @response 200 {object} model.Response{data=[]model.FormSubmission} "OK - Request successful"
+// @Router /api/v1/case/{id}/forms/{formId}/submissions [get]
+func ListCaseFormSubmissions(c *gin.Context) {
+	listSubmissions(c, c.Param("formId"))
+}
+
+// @summary List Case Submissions
+// @id List Case Submissions
+// @security ApiKeyAuth
+// @tags Cases
+// @accept json
+// @produce json
+// @Param id path int true "id"
+// @Param formId query string false "formId"
+// @Param nodeId query string false "nodeId"
+// @Param latest query bool false "latest revision only"
+// @response 200 {object} model.Response{data=[]model.FormSubmission} "OK - Request successful"
+// @Router /api/v1/case/{id}/submissions [get]
+func ListCaseSubmissions(c *gin.Context) {
+	listSubmissions(c, c.Query("formId"))
+}
+
+func listSubmissions(c *gin.Context, formId string) {
+	logger := config.GetLog()
+	repo, ctx, cancel := getStore(c.Request.Context())
+	defer cancel()
+	orgId := tokenString(c, "orgId")
+	id := c.Param("id")
+
+	cusCase, err := repo.Cases.GetByID(ctx, orgId, id)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, store.ErrNotFound) {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, model.Response{
+			Status: "-1",
+			Msg:    "Failure",
+			Desc:   err.Error(),
+		})
+		logger.Warn("Query failed", zap.Error(err))
+		return
+	}
+	latest, _ := strconv.ParseBool(c.Query("latest"))
+	list, err := repo.Forms.ListSubmissions(ctx, orgId, cusCase.CaseID, model.FormSubmissionFilter{
+		FormID: formId,
+		NodeID: c.Query("nodeId"),
+		Latest: latest,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, model.Response{
+			Status: "-1",
+			Msg:    "Failure",
+			Desc:   err.Error(),
+		})
+		logger.Warn("Query failed", zap.Error(err))
+		return
+	}
+	c.JSON(http.StatusOK, model.Response{
+		Status: "0",
+		Msg:    "Success",
+		Data:   list,
 	})
 }
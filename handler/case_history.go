@@ -5,10 +5,8 @@ import (
 	"mainPackage/model"
 	"net/http"
 	"strconv"
-	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/jackc/pgx/v5"
 	"go.uber.org/zap"
 )
 
@@ -25,12 +23,8 @@ import (
 func GetCaseHistory(c *gin.Context) {
 	logger := config.GetLog()
 
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
 	startStr := c.DefaultQuery("start", "0")
 	start, err := strconv.Atoi(startStr)
 	if err != nil {
@@ -41,13 +35,8 @@ func GetCaseHistory(c *gin.Context) {
 	if err != nil {
 		length = 1000
 	}
-	orgId := GetVariableFromToken(c, "orgId")
-	query := `SELECT id, "orgId", "caseId", username, type, "fullMsg", "jsonData", "createdAt", "createdBy"
-	FROM public.tix_case_history_events WHERE "orgId"=$1 LIMIT $2 OFFSET $3`
-
-	var rows pgx.Rows
-	logger.Debug(`Query`, zap.String("query", query))
-	rows, err = conn.Query(ctx, query, orgId, length, start)
+	orgId := tokenString(c, "orgId")
+	CaseHistoryList, err := repo.Cases.ListHistory(ctx, orgId, length, start)
 	if err != nil {
 		logger.Warn("Query failed", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, model.Response{
@@ -57,41 +46,11 @@ func GetCaseHistory(c *gin.Context) {
 		})
 		return
 	}
-	defer rows.Close()
-	var errorMsg string
-	var CaseHistory model.CaseHistory
-	var CaseHistoryList []model.CaseHistory
-	found := false
-	for rows.Next() {
-		err := rows.Scan(
-			&CaseHistory.ID,
-			&CaseHistory.OrgID,
-			&CaseHistory.CaseID,
-			&CaseHistory.Username,
-			&CaseHistory.Type,
-			&CaseHistory.FullMsg,
-			&CaseHistory.JSONData,
-			&CaseHistory.CreatedAt,
-			&CaseHistory.CreatedBy,
-		)
-		if err != nil {
-			logger.Warn("Scan failed", zap.Error(err))
-			response := model.Response{
-				Status: "-1",
-				Msg:    "Failed",
-				Desc:   errorMsg,
-			}
-			c.JSON(http.StatusInternalServerError, response)
-			return
-		}
-		CaseHistoryList = append(CaseHistoryList, CaseHistory)
-		found = true
-	}
-	if !found {
+	if len(CaseHistoryList) == 0 {
 		response := model.Response{
 			Status: "-1",
 			Msg:    "Failed",
-			Desc:   errorMsg,
+			Desc:   "",
 		}
 		c.JSON(http.StatusInternalServerError, response)
 	} else {
@@ -117,22 +76,13 @@ func GetCaseHistory(c *gin.Context) {
 func GetCaseHistoryByCaseId(c *gin.Context) {
 	logger := config.GetLog()
 
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
 
 	caseId := c.Param("caseId")
-	orgId := GetVariableFromToken(c, "orgId")
-
-	query := `SELECT id, "orgId", "caseId", username, type, "fullMsg", "jsonData", "createdAt", "createdBy"
-	FROM public.tix_case_history_events WHERE "orgId"=$1 AND "caseId"=$2`
-
-	logger.Debug("Query", zap.String("query", query))
+	orgId := tokenString(c, "orgId")
 
-	rows, err := conn.Query(ctx, query, orgId, caseId)
+	CaseHistoryList, err := repo.Cases.ListHistoryByCaseID(ctx, orgId, caseId)
 	if err != nil {
 		logger.Warn("Query failed", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, model.Response{
@@ -142,34 +92,6 @@ func GetCaseHistoryByCaseId(c *gin.Context) {
 		})
 		return
 	}
-	defer rows.Close()
-
-	var CaseHistory model.CaseHistory
-	var CaseHistoryList []model.CaseHistory
-
-	for rows.Next() {
-		err := rows.Scan(
-			&CaseHistory.ID,
-			&CaseHistory.OrgID,
-			&CaseHistory.CaseID,
-			&CaseHistory.Username,
-			&CaseHistory.Type,
-			&CaseHistory.FullMsg,
-			&CaseHistory.JSONData,
-			&CaseHistory.CreatedAt,
-			&CaseHistory.CreatedBy,
-		)
-		if err != nil {
-			logger.Warn("Scan failed", zap.Error(err))
-			c.JSON(http.StatusInternalServerError, model.Response{
-				Status: "-1",
-				Msg:    "Failed",
-				Desc:   err.Error(),
-			})
-			return
-		}
-		CaseHistoryList = append(CaseHistoryList, CaseHistory)
-	}
 
 	// ✅ If no rows found, return 200 with "NoData"
 	if len(CaseHistoryList) == 0 {
@@ -201,12 +123,8 @@ func GetCaseHistoryByCaseId(c *gin.Context) {
 // @Router /api/v1/case_history/add [post]
 func InsertCaseHistory(c *gin.Context) {
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
 
 	var req model.CaseHistoryInsert
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -218,23 +136,12 @@ func InsertCaseHistory(c *gin.Context) {
 		logger.Warn("Insert failed", zap.Error(err))
 		return
 	}
-	username := GetVariableFromToken(c, "username")
-	orgId := GetVariableFromToken(c, "orgId")
-	now := time.Now()
-	var id int
-	query := `
-	INSERT INTO public.tix_case_history_events(
-	"orgId", "caseId", username, type, "fullMsg", "jsonData", "createdAt", "createdBy")
-	VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-	RETURNING id ;
-	`
-
-	err := conn.QueryRow(ctx, query,
-		orgId, req.CaseID, username, "comment", req.FullMsg, req.JSONData,
-		now, username).Scan(&id)
+	username := tokenString(c, "username")
+	orgId := tokenString(c, "orgId")
+	req.Type = "comment"
 
+	_, err := repo.Cases.AddHistory(ctx, orgId, username, req)
 	if err != nil {
-		// log.Printf("Insert failed: %v", err)
 		c.JSON(http.StatusInternalServerError, model.Response{
 			Status: "-1",
 			Msg:    "Failure",
@@ -265,12 +172,8 @@ func InsertCaseHistory(c *gin.Context) {
 // @Router /api/v1/case_history/{id} [patch]
 func UpdateCaseHistory(c *gin.Context) {
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
 
 	id := c.Param("id")
 
@@ -284,22 +187,11 @@ func UpdateCaseHistory(c *gin.Context) {
 		})
 		return
 	}
-	now := time.Now()
-	username := GetVariableFromToken(c, "username")
-	orgId := GetVariableFromToken(c, "orgId")
-	query := `UPDATE public."tix_case_history_events"
-	SET "username"=$3, type=$4, "fullMsg"=$5, "jsonData"=$6,
-	WHERE id = $1 AND "orgId"=$2`
-	_, err := conn.Exec(ctx, query,
-		id, orgId, username, req.Type, req.FullMsg, req.JSONData, now, username,
-	)
-	logger.Debug("Update Case SQL Args",
-		zap.String("query", query),
-		zap.Any("Input", []any{
-			id, orgId, username, req.Type, req.FullMsg, req.JSONData, now, username,
-		}))
+	username := tokenString(c, "username")
+	orgId := tokenString(c, "orgId")
+	logger.Debug("Update case history", zap.String("id", id), zap.Any("Input", req))
+	err := repo.Cases.UpdateHistory(ctx, orgId, id, username, req)
 	if err != nil {
-		// log.Printf("Insert failed: %v", err)
 		c.JSON(http.StatusInternalServerError, model.Response{
 			Status: "-1",
 			Msg:    "Failure",
@@ -329,19 +221,13 @@ func UpdateCaseHistory(c *gin.Context) {
 func DeleteCaseHistory(c *gin.Context) {
 
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
-	orgId := GetVariableFromToken(c, "orgId")
+	orgId := tokenString(c, "orgId")
 	id := c.Param("id")
-	query := `DELETE FROM public.tix_case_history_events WHERE id = $1 AND "orgId"=$2`
-	logger.Debug("Query", zap.String("query", query), zap.Any("id", id))
-	_, err := conn.Exec(ctx, query, id, orgId)
+	logger.Debug("Delete case history", zap.Any("id", id))
+	err := repo.Cases.DeleteHistory(ctx, orgId, id)
 	if err != nil {
-		// log.Printf("Insert failed: %v", err)
 		c.JSON(http.StatusInternalServerError, model.Response{
 			Status: "-1",
 			Msg:    "Failure",
@@ -4,12 +4,9 @@ import (
 	"mainPackage/config"
 	"mainPackage/model"
 	"net/http"
-	"strconv"
-	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
-	"github.com/jackc/pgx/v5"
 	"go.uber.org/zap"
 )
 
@@ -26,31 +23,11 @@ import (
 // @Router /api/v1/case_status [get]
 func GetCaseStatus(c *gin.Context) {
 	logger := config.GetLog()
-
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
-	startStr := c.DefaultQuery("start", "0")
-	start, err := strconv.Atoi(startStr)
-	if err != nil {
-		start = 0
-	}
-	lengthStr := c.DefaultQuery("length", "1000")
-	length, err := strconv.Atoi(lengthStr)
-	if err != nil || length > 100 {
-		length = 1000
-	}
-
-	// orgId := GetVariableFromToken(c, "orgId")
-	query := `SELECT id, "statusId", th, en, color, active, "createdAt", "updatedAt", "createdBy", "updatedBy"
-	FROM public.case_status LIMIT $1 OFFSET $2`
+	start, length := pageQuery(c)
 
-	var rows pgx.Rows
-	logger.Debug(`Query`, zap.String("query", query), zap.Any("query", []any{length, start}))
-	rows, err = conn.Query(ctx, query, length, start)
+	list, err := repo.CaseTypes.ListStatuses(ctx, length, start)
 	if err != nil {
 		logger.Warn("Query failed", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, model.Response{
@@ -60,42 +37,11 @@ func GetCaseStatus(c *gin.Context) {
 		})
 		return
 	}
-	defer rows.Close()
-	var Department model.CaseStatus
-	var DepartmentList []model.CaseStatus
-	found := false
-	for rows.Next() {
-		err := rows.Scan(&Department.ID, &Department.StatusID, &Department.Th, &Department.En, &Department.Color,
-			&Department.Active, &Department.CreatedAt, &Department.UpdatedAt, &Department.CreatedBy, &Department.UpdatedBy)
-		if err != nil {
-			logger.Warn("Scan failed", zap.Error(err))
-			response := model.Response{
-				Status: "-1",
-				Msg:    "Failed",
-				Desc:   err.Error(),
-			}
-			c.JSON(http.StatusInternalServerError, response)
-			return
-		}
-		DepartmentList = append(DepartmentList, Department)
-		found = true
-	}
-	if !found {
-		response := model.Response{
-			Status: "-1",
-			Msg:    "Failed",
-			Desc:   "not found",
-		}
-		c.JSON(http.StatusInternalServerError, response)
-	} else {
-		response := model.Response{
-			Status: "0",
-			Msg:    "Success",
-			Data:   DepartmentList,
-			Desc:   "",
-		}
-		c.JSON(http.StatusOK, response)
-	}
+	c.JSON(http.StatusOK, model.Response{
+		Status: "0",
+		Msg:    "Success",
+		Data:   list,
+	})
 }
 
 // ListCase Status godoc
@@ -110,54 +56,24 @@ func GetCaseStatus(c *gin.Context) {
 // @Router /api/v1/case_status/{id} [get]
 func GetCaseStatusById(c *gin.Context) {
 	logger := config.GetLog()
-	id := c.Param("id")
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
-	// orgId := GetVariableFromToken(c, "orgId")
-	query := `SELECT id, "statusId", th, en, color, active, "createdAt", "updatedAt", "createdBy", "updatedBy"
-	FROM public.case_status WHERE "id"=$1 `
 
-	var rows pgx.Rows
-	logger.Debug(`Query`, zap.String("query", query))
-	rows, err := conn.Query(ctx, query, id)
+	status, err := repo.CaseTypes.GetStatus(ctx, c.Param("id"))
 	if err != nil {
 		logger.Warn("Query failed", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, model.Response{
+		c.JSON(storeStatus(err), model.Response{
 			Status: "-1",
 			Msg:    "Failure",
 			Desc:   err.Error(),
 		})
 		return
 	}
-	defer rows.Close()
-	var errorMsg string
-	var Department model.CaseStatus
-	err = rows.Scan(&Department.ID, &Department.StatusID, &Department.Th, &Department.En, &Department.Color,
-		&Department.Active, &Department.CreatedAt, &Department.UpdatedAt, &Department.CreatedBy, &Department.UpdatedBy)
-	if err != nil {
-		logger.Warn("Scan failed", zap.Error(err))
-		errorMsg = err.Error()
-		response := model.Response{
-			Status: "-1",
-			Msg:    "Failed",
-			Desc:   errorMsg,
-		}
-		c.JSON(http.StatusInternalServerError, response)
-		return
-	}
-
-	response := model.Response{
+	c.JSON(http.StatusOK, model.Response{
 		Status: "0",
 		Msg:    "Success",
-		Data:   Department,
-		Desc:   "",
-	}
-	c.JSON(http.StatusOK, response)
-
+		Data:   status,
+	})
 }
 
 // @summary Create Case Status
@@ -171,12 +87,7 @@ func GetCaseStatusById(c *gin.Context) {
 // @Router /api/v1/case_status/add [post]
 func InsertCaseStatus(c *gin.Context) {
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
-	defer cancel()
-	defer conn.Close(ctx)
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
 
 	var req model.CaseStatusInsert
@@ -189,29 +100,9 @@ func InsertCaseStatus(c *gin.Context) {
 		logger.Warn("Insert failed", zap.Error(err))
 		return
 	}
-	username := GetVariableFromToken(c, "username")
-	// orgId := GetVariableFromToken(c, "orgId")
-	uuid := uuid.New()
-	now := time.Now()
-	var id int
-	query := `
-	INSERT INTO public."case_status"(
-	"statusId", th, en, color, active, "createdAt", "updatedAt", "createdBy", "updatedBy")
-	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
-	RETURNING id ;
-	`
-	logger.Debug("Update Case SQL Args",
-		zap.String("query", query),
-		zap.Any("Input", []any{
-			uuid, req.Th, req.En, req.Color, req.Active, now,
-			now, username, username,
-		}))
-	err := conn.QueryRow(ctx, query,
-		uuid, req.Th, req.En, req.Color, req.Active, now,
-		now, username, username).Scan(&id)
+	username := tokenString(c, "username")
 
-	if err != nil {
-		// log.Printf("Insert failed: %v", err)
+	if err := repo.CaseTypes.CreateStatus(ctx, uuid.NewString(), username, req); err != nil {
 		c.JSON(http.StatusInternalServerError, model.Response{
 			Status: "-1",
 			Msg:    "Failure",
@@ -220,14 +111,11 @@ func InsertCaseStatus(c *gin.Context) {
 		logger.Warn("Insert failed", zap.Error(err))
 		return
 	}
-
-	// Continue logic...
 	c.JSON(http.StatusOK, model.Response{
 		Status: "0",
 		Msg:    "Success",
 		Desc:   "Create successfully",
 	})
-
 }
 
 // @summary Update Case Status
@@ -242,46 +130,23 @@ func InsertCaseStatus(c *gin.Context) {
 // @Router /api/v1/case_status/{id} [patch]
 func UpdateCaseStatus(c *gin.Context) {
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
-	defer cancel()
-	defer conn.Close(ctx)
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
 
-	id := c.Param("id")
-
 	var req model.CaseStatusUpdate
 	if err := c.ShouldBindJSON(&req); err != nil {
-		logger.Warn("Update failed", zap.Error(err))
 		c.JSON(http.StatusBadRequest, model.Response{
 			Status: "-1",
 			Msg:    "Failure",
 			Desc:   err.Error(),
 		})
+		logger.Warn("Update failed", zap.Error(err))
 		return
 	}
-	now := time.Now()
-	username := GetVariableFromToken(c, "username")
-	// orgId := GetVariableFromToken(c, "orgId")
-	query := `UPDATE public."case_status"
-	SET th=$2, en=$3, "color"=$4,active=$5,
-	 "updatedAt"=$6, "updatedBy"=$7
-	WHERE id = $1 `
-	_, err := conn.Exec(ctx, query,
-		id, req.Th, req.En, req.Color, req.Active,
-		now, username,
-	)
-	logger.Debug("Update Case SQL Args",
-		zap.String("query", query),
-		zap.Any("Input", []any{
-			id, req.Th, req.En, req.Color, req.Active,
-			now, username,
-		}))
-	if err != nil {
-		// log.Printf("Insert failed: %v", err)
-		c.JSON(http.StatusInternalServerError, model.Response{
+	username := tokenString(c, "username")
+
+	if err := repo.CaseTypes.UpdateStatus(ctx, c.Param("id"), username, req); err != nil {
+		c.JSON(storeStatus(err), model.Response{
 			Status: "-1",
 			Msg:    "Failure",
 			Desc:   err.Error(),
@@ -289,8 +154,6 @@ func UpdateCaseStatus(c *gin.Context) {
 		logger.Warn("Update failed", zap.Error(err))
 		return
 	}
-
-	// Continue logic...
 	c.JSON(http.StatusOK, model.Response{
 		Status: "0",
 		Msg:    "Success",
@@ -308,32 +171,19 @@ func UpdateCaseStatus(c *gin.Context) {
 // @response 200 {object} model.Response "OK - Request successful"
 // @Router /api/v1/case_status/{id} [delete]
 func DeleteCaseStatus(c *gin.Context) {
-
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
-	defer cancel()
-	orgId := GetVariableFromToken(c, "orgId")
-	id := c.Param("id")
-	query := `DELETE FROM public."case_status" WHERE id = $1`
-	logger.Debug("Query", zap.String("query", query), zap.Any("id", id))
-	_, err := conn.Exec(ctx, query, id, orgId)
-	if err != nil {
-		// log.Printf("Insert failed: %v", err)
-		c.JSON(http.StatusInternalServerError, model.Response{
+
+	if err := repo.CaseTypes.DeleteStatus(ctx, c.Param("id")); err != nil {
+		c.JSON(storeStatus(err), model.Response{
 			Status: "-1",
 			Msg:    "Failure",
 			Desc:   err.Error(),
 		})
-		logger.Warn("Update failed", zap.Error(err))
+		logger.Warn("Delete failed", zap.Error(err))
 		return
 	}
-
-	// Continue logic...
 	c.JSON(http.StatusOK, model.Response{
 		Status: "0",
 		Msg:    "Success",
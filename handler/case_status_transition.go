@@ -0,0 +1,215 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"mainPackage/model"
+	"mainPackage/store"
+	"mainPackage/workflow"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// transitionError maps store errors of the transition endpoints to a status.
+func transitionError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	if errors.Is(err, store.ErrNotFound) {
+		status = http.StatusNotFound
+	}
+	c.JSON(status, model.Response{
+		Status: "-1",
+		Msg:    "Failure",
+		Desc:   err.Error(),
+	})
+}
+
+// bindTransition reads and validates the transition body, answering 400 or
+// 409 itself when it is unusable.
+func bindTransition(c *gin.Context, ctx context.Context, repo *store.Store, orgId, id string) (model.CaseStatusTransitionInput, bool) {
+	var in model.CaseStatusTransitionInput
+	err := c.ShouldBindJSON(&in)
+	switch {
+	case err != nil:
+	case in.FromStatusID == in.ToStatusID:
+		err = errors.New("fromStatusId and toStatusId must differ")
+	case !workflow.ValidStamp(in.Stamp):
+		err = errors.New("stamp must be empty or one of commanded, received, arrived, closed, reopened")
+	case in.FromStatusID == "" && in.Stamp != "":
+		err = errors.New("the initial transition, without fromStatusId, cannot carry a stamp")
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.Response{
+			Status: "-1",
+			Msg:    "Failure",
+			Desc:   err.Error(),
+		})
+		return in, false
+	}
+	var existing *model.CaseStatusTransition
+	desc := "a transition from " + in.FromStatusID + " to " + in.ToStatusID + " already exists"
+	if in.FromStatusID == "" {
+		existing, err = repo.Transitions.Initial(ctx, orgId)
+		desc = "the organization already has an initial transition"
+	} else {
+		existing, err = repo.Transitions.Find(ctx, orgId, in.FromStatusID, in.ToStatusID)
+	}
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		transitionError(c, err)
+		return in, false
+	}
+	if err == nil && existing.ID != id {
+		c.JSON(http.StatusConflict, model.Response{
+			Status: "-1",
+			Msg:    "Failure",
+			Desc:   desc,
+		})
+		return in, false
+	}
+	return in, true
+}
+
+// @summary List Case Status Transitions
+// @description Lists the status changes the organization allows on its cases.
+// @tags Cases
+// @security ApiKeyAuth
+// @id List Case Status Transitions
+// @accept json
+// @produce json
+// @response 200 {object} model.Response{data=[]model.CaseStatusTransition} "OK - Request successful"
+// @Router /api/v1/case_status_transitions [get]
+func ListCaseStatusTransitions(c *gin.Context) {
+	repo, ctx, cancel := getStore(c.Request.Context())
+	defer cancel()
+
+	list, err := repo.Transitions.List(ctx, tokenString(c, "orgId"))
+	if err != nil {
+		transitionError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, model.Response{
+		Status: "0",
+		Msg:    "Success",
+		Data:   list,
+	})
+}
+
+// @summary Get Case Status Transition
+// @tags Cases
+// @security ApiKeyAuth
+// @id Get Case Status Transition
+// @accept json
+// @produce json
+// @Param id path string true "transition id"
+// @response 200 {object} model.Response{data=model.CaseStatusTransition} "OK - Request successful"
+// @Router /api/v1/case_status_transitions/{id} [get]
+func GetCaseStatusTransition(c *gin.Context) {
+	repo, ctx, cancel := getStore(c.Request.Context())
+	defer cancel()
+
+	t, err := repo.Transitions.Get(ctx, tokenString(c, "orgId"), c.Param("id"))
+	if err != nil {
+		transitionError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, model.Response{
+		Status: "0",
+		Msg:    "Success",
+		Data:   t,
+	})
+}
+
+// @summary Create Case Status Transition
+// @description Allows cases to move from one case_status to another. stamp names the lifecycle date and user the move sets: commanded, received, arrived or closed; reopened clears closedDate and userclose. Leaving fromStatusId empty makes toStatusId the status new cases start in; an organization has one such transition and it carries no stamp.
+// @tags Cases
+// @security ApiKeyAuth
+// @id Create Case Status Transition
+// @accept json
+// @produce json
+// @param Body body model.CaseStatusTransitionInput true "Transition to create"
+// @response 200 {object} model.Response "OK - Request successful"
+// @Router /api/v1/case_status_transitions/add [post]
+func InsertCaseStatusTransition(c *gin.Context) {
+	repo, ctx, cancel := getStore(c.Request.Context())
+	defer cancel()
+	orgId := tokenString(c, "orgId")
+
+	in, ok := bindTransition(c, ctx, repo, orgId, "")
+	if !ok {
+		return
+	}
+	id, err := repo.Transitions.Create(ctx, orgId, tokenString(c, "username"), in)
+	if err != nil {
+		transitionError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, model.Response{
+		Status: "0",
+		Msg:    "Success",
+		Desc:   "Create successfully",
+		Data:   gin.H{"id": id},
+	})
+}
+
+// @summary Update Case Status Transition
+// @tags Cases
+// @security ApiKeyAuth
+// @id Update Case Status Transition
+// @accept json
+// @produce json
+// @Param id path string true "transition id"
+// @param Body body model.CaseStatusTransitionInput true "Transition to update"
+// @response 200 {object} model.Response "OK - Request successful"
+// @Router /api/v1/case_status_transitions/{id} [patch]
+func UpdateCaseStatusTransition(c *gin.Context) {
+	repo, ctx, cancel := getStore(c.Request.Context())
+	defer cancel()
+	orgId := tokenString(c, "orgId")
+	id := c.Param("id")
+
+	in, ok := bindTransition(c, ctx, repo, orgId, id)
+	if !ok {
+		return
+	}
+	if prior, err := repo.Transitions.Get(ctx, orgId, id); err == nil {
+		auditPrior(c, prior)
+	}
+	if err := repo.Transitions.Update(ctx, orgId, id, tokenString(c, "username"), in); err != nil {
+		transitionError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, model.Response{
+		Status: "0",
+		Msg:    "Success",
+		Desc:   "Update successfully",
+	})
+}
+
+// @summary Delete Case Status Transition
+// @tags Cases
+// @security ApiKeyAuth
+// @id Delete Case Status Transition
+// @accept json
+// @produce json
+// @Param id path string true "transition id"
+// @response 200 {object} model.Response "OK - Request successful"
+// @Router /api/v1/case_status_transitions/{id} [delete]
+func DeleteCaseStatusTransition(c *gin.Context) {
+	repo, ctx, cancel := getStore(c.Request.Context())
+	defer cancel()
+	orgId := tokenString(c, "orgId")
+	id := c.Param("id")
+
+	if prior, err := repo.Transitions.Get(ctx, orgId, id); err == nil {
+		auditPrior(c, prior)
+	}
+	if err := repo.Transitions.Delete(ctx, orgId, id); err != nil {
+		transitionError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, model.Response{
+		Status: "0",
+		Msg:    "Success",
+		Desc:   "Delete successfully",
+	})
+}
@@ -0,0 +1,293 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"mainPackage/model"
+	"mainPackage/store"
+	"net/http"
+	"testing"
+)
+
+func addTransition(t *testing.T, m *store.Memory, from, to, stamp string) {
+	t.Helper()
+	in := model.CaseStatusTransitionInput{FromStatusID: from, ToStatusID: to, Stamp: stamp, Active: true}
+	if _, err := m.Transitions.Create(context.Background(), testOrg, "admin", in); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// newCase is the smallest body InsertCase accepts.
+func newCase(caseId string) map[string]any {
+	return map[string]any{"caseId": caseId, "caseVersion": "draft"}
+}
+
+type caseResponse struct {
+	Status string            `json:"status"`
+	Desc   string            `json:"desc"`
+	Data   model.CaseCreated `json:"data"`
+}
+
+func TestInsertCaseOnFreshOrg(t *testing.T) {
+	m := newTestStore(t, PermCaseCreate)
+	r := newRouter()
+
+	var res caseResponse
+	if code := do(t, r, http.MethodPost, "/api/v1/case/add", accessToken(t), newCase("C1"), &res); code != http.StatusOK {
+		t.Fatalf("create on an org without transitions: %d %+v", code, res)
+	}
+	c, err := m.Cases.GetByCaseID(context.Background(), testOrg, "C1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.StatusID != model.CaseStatusNew {
+		t.Errorf("statusId = %q, want %s", c.StatusID, model.CaseStatusNew)
+	}
+}
+
+func TestInsertCaseInactiveInitialStatus(t *testing.T) {
+	m := newTestStore(t, PermCaseCreate)
+	in := model.CaseStatusTransitionInput{ToStatusID: "new", Active: false}
+	if _, err := m.Transitions.Create(context.Background(), testOrg, "admin", in); err != nil {
+		t.Fatal(err)
+	}
+	r := newRouter()
+
+	var res caseResponse
+	code := do(t, r, http.MethodPost, "/api/v1/case/add", accessToken(t), newCase("C1"), &res)
+	if code != http.StatusConflict {
+		t.Fatalf("create with an inactive initial transition: %d %+v, want 409", code, res)
+	}
+	if _, err := m.Cases.GetByCaseID(context.Background(), testOrg, "C1"); err == nil {
+		t.Error("case stored although the create failed")
+	}
+}
+
+func TestInsertCaseIgnoresClientStatus(t *testing.T) {
+	m := newTestStore(t, PermCaseCreate)
+	addTransition(t, m, "", "open", "")
+	r := newRouter()
+
+	body := map[string]any{
+		"caseId":      "C1",
+		"caseVersion": "draft",
+		"statusId":    "closed",
+		"closedDate":  "2024-01-01T00:00:00Z",
+		"userclose":   "mallory",
+	}
+	var res caseResponse
+	if code := do(t, r, http.MethodPost, "/api/v1/case/add", accessToken(t), body, &res); code != http.StatusOK {
+		t.Fatalf("create: %d %+v", code, res)
+	}
+	c, err := m.Cases.GetByCaseID(context.Background(), testOrg, "C1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.StatusID != "open" {
+		t.Errorf("statusId = %q, want the initial status open", c.StatusID)
+	}
+	if c.ClosedDate != nil || c.UserClose != "" {
+		t.Errorf("client stamps kept: closedDate %v userclose %q", c.ClosedDate, c.UserClose)
+	}
+}
+
+func TestChangeCaseStatus(t *testing.T) {
+	m := newTestStore(t, PermCaseCreate, PermCaseUpdate)
+	addTransition(t, m, "", "new", "")
+	addTransition(t, m, "new", "closed", model.CaseStampClosed)
+	r := newRouter()
+	tok := accessToken(t)
+
+	var created caseResponse
+	if code := do(t, r, http.MethodPost, "/api/v1/case/add", tok, newCase("C1"), &created); code != http.StatusOK {
+		t.Fatalf("create: %d %+v", code, created)
+	}
+	path := "/api/v1/case/" + created.Data.ID + "/status"
+
+	if code := do(t, r, http.MethodPost, path, tok, model.CaseStatusChange{StatusID: "hold"}, nil); code != http.StatusConflict {
+		t.Errorf("change without a transition: %d, want 409", code)
+	}
+	if code := do(t, r, http.MethodPost, path, tok, model.CaseStatusChange{StatusID: "closed"}, nil); code != http.StatusOK {
+		t.Fatalf("change to closed: %d", code)
+	}
+	c, err := m.Cases.GetByCaseID(context.Background(), testOrg, "C1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.StatusID != "closed" || c.ClosedDate == nil || c.UserClose != "alice" {
+		t.Errorf("case = status %q closed %v by %q", c.StatusID, c.ClosedDate, c.UserClose)
+	}
+	if code := do(t, r, http.MethodPost, "/api/v1/case/missing/status", tok, model.CaseStatusChange{StatusID: "closed"}, nil); code != http.StatusNotFound {
+		t.Errorf("change on a missing case: %d, want 404", code)
+	}
+}
+
+// addWorkflow saves a start, process, end workflow as wfId and publishes it
+// when publish is set.
+func addWorkflow(t *testing.T, m *store.Memory, wfId string, publish bool) {
+	t.Helper()
+	ctx := context.Background()
+	in := model.WorkFlowInsert{
+		Nodes: []model.WorkFlowNode{{Id: "n1", Type: "start"}, {Id: "n2", Type: "process"}, {Id: "n3", Type: "end"}},
+		Connections: []model.WorkFlowConnection{
+			{Id: "e1", Source: "n1", Target: "n2"},
+			{Id: "e2", Source: "n2", Target: "n3"},
+		},
+	}
+	if err := m.Workflows.Create(ctx, testOrg, wfId, "admin", in); err != nil {
+		t.Fatal(err)
+	}
+	if publish {
+		if _, err := m.Workflows.Publish(ctx, testOrg, wfId, "admin"); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestInsertCasePinsPublishedWorkflow(t *testing.T) {
+	m := newTestStore(t, PermCaseCreate)
+	addWorkflow(t, m, "draft-only", false)
+	addWorkflow(t, m, "wf", true)
+	r := newRouter()
+	tok := accessToken(t)
+
+	body := newCase("C1")
+	body["wfId"], body["nodeId"] = "draft-only", "n1"
+	if code := do(t, r, http.MethodPost, "/api/v1/case/add", tok, body, nil); code != http.StatusConflict {
+		t.Errorf("create on a workflow that was never published: %d, want 409", code)
+	}
+
+	body = newCase("C2")
+	body["wfId"], body["nodeId"] = "wf", "n1"
+	var res caseResponse
+	if code := do(t, r, http.MethodPost, "/api/v1/case/add", tok, body, &res); code != http.StatusOK {
+		t.Fatalf("create on a published workflow: %d %+v", code, res)
+	}
+	c, err := m.Cases.GetByCaseID(context.Background(), testOrg, "C2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.WfVersions == nil || *c.WfVersions != "1" {
+		t.Errorf("case pinned to %v, want version 1", c.WfVersions)
+	}
+}
+
+func TestInsertCaseOpensOnStartNode(t *testing.T) {
+	m := newTestStore(t, PermCaseCreate)
+	addWorkflow(t, m, "wf", true)
+	r := newRouter()
+	tok := accessToken(t)
+
+	body := newCase("C1")
+	body["wfId"] = "wf"
+	var res caseResponse
+	if code := do(t, r, http.MethodPost, "/api/v1/case/add", tok, body, &res); code != http.StatusOK {
+		t.Fatalf("create without nodeId: %d %+v", code, res)
+	}
+	if res.Data.CurrentStage == nil || res.Data.CurrentStage.NodeId != "n1" || res.Data.CurrentStage.Versions != "1" {
+		t.Errorf("current stage = %+v, want start node n1 of version 1", res.Data.CurrentStage)
+	}
+
+	tests := []struct {
+		name string
+		body map[string]any
+		want int
+	}{
+		{"another node", map[string]any{"wfId": "wf", "nodeId": "n2"}, http.StatusBadRequest},
+		{"nodeId without wfId", map[string]any{"nodeId": "n1"}, http.StatusBadRequest},
+		{"unknown workflow", map[string]any{"wfId": "missing"}, http.StatusNotFound},
+	}
+	for i, tt := range tests {
+		body := newCase(fmt.Sprintf("C%d", i+2))
+		for k, v := range tt.body {
+			body[k] = v
+		}
+		if code := do(t, r, http.MethodPost, "/api/v1/case/add", tok, body, nil); code != tt.want {
+			t.Errorf("%s: %d, want %d", tt.name, code, tt.want)
+		}
+	}
+}
+
+type submissionResponse struct {
+	Status string               `json:"status"`
+	Desc   string               `json:"desc"`
+	Data   model.FormSubmission `json:"data"`
+}
+
+func TestSubmitCaseForm(t *testing.T) {
+	m := newTestStore(t, PermCaseCreate)
+	addWorkflow(t, m, "wf", true)
+	ctx := context.Background()
+	name := "Report"
+	form := model.FormInsert{FormName: &name, Active: true, FormFieldJson: []map[string]interface{}{
+		{"id": "name", "type": "textInput", "required": true},
+	}}
+	if err := m.Forms.Create(ctx, testOrg, "f1", "admin", form); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Forms.Publish(ctx, testOrg, "f1", "admin"); err != nil {
+		t.Fatal(err)
+	}
+	r := newRouter()
+	tok := accessToken(t)
+
+	body := newCase("C1")
+	body["wfId"] = "wf"
+	var created caseResponse
+	if code := do(t, r, http.MethodPost, "/api/v1/case/add", tok, body, &created); code != http.StatusOK {
+		t.Fatalf("create: %d %+v", code, created)
+	}
+	path := "/api/v1/case/" + created.Data.ID + "/forms/f1/submissions"
+	valid := model.FormSubmissionInsert{Data: map[string]interface{}{"name": "Alice"}}
+
+	for rev := 1; rev <= 2; rev++ {
+		var res submissionResponse
+		if code := do(t, r, http.MethodPost, path, tok, valid, &res); code != http.StatusOK {
+			t.Fatalf("submission %d: %d %+v", rev, code, res)
+		}
+		sub := res.Data
+		if sub.CaseID != "C1" || sub.NodeID != "n1" || sub.FormVersions != "1" || sub.Revision != rev || sub.CreatedBy != "alice" {
+			t.Errorf("submission %d = %+v, want revision %d of version 1 on the current node n1", rev, sub, rev)
+		}
+	}
+	history, err := m.Cases.ListHistoryByCaseID(ctx, testOrg, "C1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	forms := 0
+	for _, h := range history {
+		if h.Type == "form" {
+			forms++
+		}
+	}
+	if forms != 2 {
+		t.Errorf("case history has %d form entries, want 2", forms)
+	}
+
+	var res struct {
+		Data []model.FormFieldError `json:"data"`
+	}
+	invalid := model.FormSubmissionInsert{Data: map[string]interface{}{"name": ""}}
+	if code := do(t, r, http.MethodPost, path, tok, invalid, &res); code != http.StatusBadRequest {
+		t.Errorf("invalid submission: %d, want 400", code)
+	}
+	if len(res.Data) != 1 || res.Data[0].Field != "name" {
+		t.Errorf("field errors = %+v, want one on name", res.Data)
+	}
+
+	tests := []struct {
+		name string
+		path string
+		body any
+		want int
+	}{
+		{"without data", path, map[string]any{"nodeId": "n1"}, http.StatusBadRequest},
+		{"unknown form", "/api/v1/case/" + created.Data.ID + "/forms/missing/submissions", valid, http.StatusNotFound},
+		{"unknown case", "/api/v1/case/999/forms/f1/submissions", valid, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		if code := do(t, r, http.MethodPost, tt.path, tok, tt.body, nil); code != tt.want {
+			t.Errorf("%s: %d, want %d", tt.name, code, tt.want)
+		}
+	}
+}
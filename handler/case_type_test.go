@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"mainPackage/model"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func caseTypeRouter() *gin.Engine {
+	r := gin.New()
+	v1 := r.Group("/api/v1", ProtectedHandler)
+	v1.GET("/casetypes_with_subtype", ListCaseTypeWithSubtype)
+	v1.GET("/casetypes", ListCaseType)
+	v1.POST("/casetypes/add", InsertCaseType)
+	v1.POST("/casesubtypes/add", InsertCaseSubType)
+	v1.DELETE("/casesubtypes/:id", DeleteCaseSubType)
+	v1.GET("/casesubtypes", ListCaseSubType)
+	return r
+}
+
+func TestCaseTypeWithSubtype(t *testing.T) {
+	newTestStore(t)
+	r := caseTypeRouter()
+	tok := accessToken(t)
+
+	en := "Fire"
+	if code := do(t, r, http.MethodPost, "/api/v1/casetypes/add", tok, model.CaseTypeInsert{En: &en, Active: true}, nil); code != http.StatusOK {
+		t.Fatalf("add type: %d", code)
+	}
+	var types struct {
+		Data []model.CaseType `json:"data"`
+	}
+	if code := do(t, r, http.MethodGet, "/api/v1/casetypes", tok, nil, &types); code != http.StatusOK || len(types.Data) != 1 {
+		t.Fatalf("types: %d %+v", code, types.Data)
+	}
+	typeId := types.Data[0].TypeId
+
+	var joined struct {
+		Data []model.CaseTypeWithSubType `json:"data"`
+	}
+	if do(t, r, http.MethodGet, "/api/v1/casetypes_with_subtype", tok, nil, &joined); len(joined.Data) != 1 || joined.Data[0].SubTypeID != nil {
+		t.Fatalf("type without sub types = %+v, want one row without a sub type", joined.Data)
+	}
+
+	sub := model.CaseSubTypeInsert{TypeID: typeId, EN: "Building fire", Priority: "2", Active: true}
+	if code := do(t, r, http.MethodPost, "/api/v1/casesubtypes/add", tok, sub, nil); code != http.StatusOK {
+		t.Fatalf("add sub type: %d", code)
+	}
+	if do(t, r, http.MethodGet, "/api/v1/casetypes_with_subtype", tok, nil, &joined); len(joined.Data) != 1 ||
+		joined.Data[0].SubTypeEN == nil || *joined.Data[0].SubTypeEN != "Building fire" ||
+		joined.Data[0].Priority == nil || *joined.Data[0].Priority != 2 || joined.Data[0].TypeEN != "Fire" {
+		t.Fatalf("joined = %+v, want Fire with Building fire", joined.Data)
+	}
+
+	var subs struct {
+		Data []model.CaseSubType `json:"data"`
+	}
+	if do(t, r, http.MethodGet, "/api/v1/casesubtypes", tok, nil, &subs); len(subs.Data) != 1 || subs.Data[0].UserSkillList == nil {
+		t.Fatalf("sub types = %+v, want one with an empty skill list", subs.Data)
+	}
+	if code := do(t, r, http.MethodDelete, "/api/v1/casesubtypes/"+subs.Data[0].Id, tok, nil, nil); code != http.StatusOK {
+		t.Fatalf("delete: %d", code)
+	}
+	if code := do(t, r, http.MethodDelete, "/api/v1/casesubtypes/"+subs.Data[0].Id, tok, nil, nil); code != http.StatusNotFound {
+		t.Errorf("second delete: %d, want 404", code)
+	}
+}
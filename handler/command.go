@@ -4,12 +4,9 @@ import (
 	"mainPackage/config"
 	"mainPackage/model"
 	"net/http"
-	"strconv"
-	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
-	"github.com/jackc/pgx/v5"
 	"go.uber.org/zap"
 )
 
@@ -26,30 +23,11 @@ import (
 // @Router /api/v1/commands [get]
 func GetCommand(c *gin.Context) {
 	logger := config.GetLog()
-
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
-	startStr := c.DefaultQuery("start", "0")
-	start, err := strconv.Atoi(startStr)
-	if err != nil {
-		start = 0
-	}
-	lengthStr := c.DefaultQuery("length", "1000")
-	length, err := strconv.Atoi(lengthStr)
-	if err != nil {
-		length = 1000
-	}
-	orgId := GetVariableFromToken(c, "orgId")
-	query := `SELECT  id,"deptId", "orgId", "commId", en, th, active, "createdAt", "updatedAt", "createdBy", "updatedBy" 
-	FROM public.sec_commands WHERE "orgId"=$1 LIMIT $2 OFFSET $3`
+	start, length := pageQuery(c)
 
-	var rows pgx.Rows
-	logger.Debug(`Query`, zap.String("query", query))
-	rows, err = conn.Query(ctx, query, orgId, length, start)
+	list, err := repo.Org.ListCommands(ctx, tokenString(c, "orgId"), length, start)
 	if err != nil {
 		logger.Warn("Query failed", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, model.Response{
@@ -59,42 +37,11 @@ func GetCommand(c *gin.Context) {
 		})
 		return
 	}
-	defer rows.Close()
-	var errorMsg string
-	var Department model.Command
-	var DepartmentList []model.Command
-	rowIndex := 0
-	for rows.Next() {
-		rowIndex++
-		err := rows.Scan(&Department.ID, &Department.DeptID, &Department.OrgID, &Department.CommID, &Department.En, &Department.Th,
-			&Department.Active, &Department.CreatedAt, &Department.UpdatedAt, &Department.CreatedBy, &Department.UpdatedBy)
-		if err != nil {
-			logger.Warn("Scan failed", zap.Error(err))
-			response := model.Response{
-				Status: "-1",
-				Msg:    "Failed",
-				Desc:   errorMsg,
-			}
-			c.JSON(http.StatusInternalServerError, response)
-		}
-		DepartmentList = append(DepartmentList, Department)
-	}
-	if errorMsg != "" {
-		response := model.Response{
-			Status: "-1",
-			Msg:    "Failed",
-			Desc:   errorMsg,
-		}
-		c.JSON(http.StatusInternalServerError, response)
-	} else {
-		response := model.Response{
-			Status: "0",
-			Msg:    "Success",
-			Data:   DepartmentList,
-			Desc:   "",
-		}
-		c.JSON(http.StatusOK, response)
-	}
+	c.JSON(http.StatusOK, model.Response{
+		Status: "0",
+		Msg:    "Success",
+		Data:   list,
+	})
 }
 
 // ListCommands godoc
@@ -109,54 +56,24 @@ func GetCommand(c *gin.Context) {
 // @Router /api/v1/commands/{id} [get]
 func GetCommandById(c *gin.Context) {
 	logger := config.GetLog()
-	id := c.Param("id")
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
-	orgId := GetVariableFromToken(c, "orgId")
-	query := `SELECT id, "deptId", "orgId", "commId", en, th, active, "createdAt", "updatedAt", "createdBy", "updatedBy" 
-	FROM public.sec_commands WHERE "commId"=$1 AND "orgId"=$2`
 
-	var rows pgx.Rows
-	logger.Debug(`Query`, zap.String("query", query))
-	rows, err := conn.Query(ctx, query, id, orgId)
+	comm, err := repo.Org.GetCommand(ctx, tokenString(c, "orgId"), c.Param("id"))
 	if err != nil {
 		logger.Warn("Query failed", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, model.Response{
+		c.JSON(storeStatus(err), model.Response{
 			Status: "-1",
 			Msg:    "Failure",
 			Desc:   err.Error(),
 		})
 		return
 	}
-	defer rows.Close()
-	var errorMsg string
-	var Department model.Command
-	err = rows.Scan(&Department.ID, &Department.DeptID, &Department.OrgID, &Department.CommID, &Department.En, &Department.Th,
-		&Department.Active, &Department.CreatedAt, &Department.UpdatedAt, &Department.CreatedBy, &Department.UpdatedBy)
-	if err != nil {
-		logger.Warn("Scan failed", zap.Error(err))
-		errorMsg = err.Error()
-		response := model.Response{
-			Status: "-1",
-			Msg:    "Failed",
-			Desc:   errorMsg,
-		}
-		c.JSON(http.StatusInternalServerError, response)
-		return
-	}
-
-	response := model.Response{
+	c.JSON(http.StatusOK, model.Response{
 		Status: "0",
 		Msg:    "Success",
-		Data:   Department,
-		Desc:   "",
-	}
-	c.JSON(http.StatusOK, response)
-
+		Data:   comm,
+	})
 }
 
 // @summary Create Commands
@@ -170,12 +87,7 @@ func GetCommandById(c *gin.Context) {
 // @Router /api/v1/commands/add [post]
 func InsertCommand(c *gin.Context) {
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
-	defer cancel()
-	defer conn.Close(ctx)
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
 
 	var req model.CommandInsert
@@ -188,24 +100,10 @@ func InsertCommand(c *gin.Context) {
 		logger.Warn("Insert failed", zap.Error(err))
 		return
 	}
-	username := GetVariableFromToken(c, "username")
-	orgId := GetVariableFromToken(c, "orgId")
-	uuid := uuid.New()
-	now := time.Now()
-	var id int
-	query := `
-	INSERT INTO public."sec_commands"(
-	"deptId", "orgId", "commId", en, th, active, "createdAt", "updatedAt", "createdBy", "updatedBy")
-	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
-	RETURNING id ;
-	`
+	username := tokenString(c, "username")
+	orgId := tokenString(c, "orgId")
 
-	err := conn.QueryRow(ctx, query,
-		req.DeptID, orgId, uuid, req.En, req.Th, req.Active, now,
-		now, username, username).Scan(&id)
-
-	if err != nil {
-		// log.Printf("Insert failed: %v", err)
+	if err := repo.Org.CreateCommand(ctx, orgId, uuid.NewString(), username, req); err != nil {
 		c.JSON(http.StatusInternalServerError, model.Response{
 			Status: "-1",
 			Msg:    "Failure",
@@ -214,14 +112,11 @@ func InsertCommand(c *gin.Context) {
 		logger.Warn("Insert failed", zap.Error(err))
 		return
 	}
-
-	// Continue logic...
 	c.JSON(http.StatusOK, model.Response{
 		Status: "0",
 		Msg:    "Success",
 		Desc:   "Create successfully",
 	})
-
 }
 
 // @summary Update Commands
@@ -236,15 +131,8 @@ func InsertCommand(c *gin.Context) {
 // @Router /api/v1/commands/{id} [patch]
 func UpdateCommand(c *gin.Context) {
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
-	defer cancel()
-
-	id := c.Param("id")
 
 	var req model.CommandUpdate
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -256,26 +144,11 @@ func UpdateCommand(c *gin.Context) {
 		})
 		return
 	}
-	now := time.Now()
-	username := GetVariableFromToken(c, "username")
-	orgId := GetVariableFromToken(c, "orgId")
-	query := `UPDATE public."sec_commands"
-	SET "deptId"=$2, "orgId"=$3, en=$4, th=$5, active=$6,
-	 "updatedAt"=$7, "updatedBy"=$8
-	WHERE id = $1 AND "orgId"=$9`
-	_, err := conn.Exec(ctx, query,
-		id, req.DeptID, orgId, req.En, req.Th, req.Active,
-		now, username, orgId,
-	)
-	logger.Debug("Update Case SQL Args",
-		zap.String("query", query),
-		zap.Any("Input", []any{
-			id, req.DeptID, orgId, req.En, req.Th, req.Active,
-			now, username, orgId,
-		}))
-	if err != nil {
-		// log.Printf("Insert failed: %v", err)
-		c.JSON(http.StatusInternalServerError, model.Response{
+	username := tokenString(c, "username")
+	orgId := tokenString(c, "orgId")
+
+	if err := repo.Org.UpdateCommand(ctx, orgId, c.Param("id"), username, req); err != nil {
+		c.JSON(storeStatus(err), model.Response{
 			Status: "-1",
 			Msg:    "Failure",
 			Desc:   err.Error(),
@@ -283,8 +156,6 @@ func UpdateCommand(c *gin.Context) {
 		logger.Warn("Update failed", zap.Error(err))
 		return
 	}
-
-	// Continue logic...
 	c.JSON(http.StatusOK, model.Response{
 		Status: "0",
 		Msg:    "Success",
@@ -302,32 +173,19 @@ func UpdateCommand(c *gin.Context) {
 // @response 200 {object} model.Response "OK - Request successful"
 // @Router /api/v1/commands/{id} [delete]
 func DeleteCommand(c *gin.Context) {
-
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
-	defer cancel()
-	orgId := GetVariableFromToken(c, "orgId")
-	id := c.Param("id")
-	query := `DELETE FROM public."sec_commands" WHERE id = $1 AND "orgId"=$2`
-	logger.Debug("Query", zap.String("query", query), zap.Any("id", id))
-	_, err := conn.Exec(ctx, query, id, orgId)
-	if err != nil {
-		// log.Printf("Insert failed: %v", err)
-		c.JSON(http.StatusInternalServerError, model.Response{
+
+	if err := repo.Org.DeleteCommand(ctx, tokenString(c, "orgId"), c.Param("id")); err != nil {
+		c.JSON(storeStatus(err), model.Response{
 			Status: "-1",
 			Msg:    "Failure",
 			Desc:   err.Error(),
 		})
-		logger.Warn("Update failed", zap.Error(err))
+		logger.Warn("Delete failed", zap.Error(err))
 		return
 	}
-
-	// Continue logic...
 	c.JSON(http.StatusOK, model.Response{
 		Status: "0",
 		Msg:    "Success",
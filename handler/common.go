@@ -2,26 +2,17 @@ package handler
 
 import (
 	"context"
-	"crypto/aes"
-	"crypto/cipher"
-	"crypto/rand"
-	"crypto/sha256"
-	"encoding/base64"
 	"encoding/json"
 	"fmt"
-	"io"
 	"log"
-	"mainPackage/config"
 	"mainPackage/model"
-	"os"
+	"mainPackage/store"
 	"reflect"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/jackc/pgx/v5"
-	"go.uber.org/zap"
 )
 
 func ToString(value interface{}) string {
@@ -66,66 +57,18 @@ func ToInt(value interface{}) int {
 	}
 }
 
-func deriveKey(passphrase string) []byte {
-	hash := sha256.Sum256([]byte(passphrase))
-	return hash[:] // 32 bytes
-}
-
-func encrypt(plaintext string) (string, error) {
-	key := deriveKey(os.Getenv("SECRET_KEY"))
-
-	block, err := aes.NewCipher(key)
-	if err != nil {
-		return "", err
-	}
-
-	aesGCM, err := cipher.NewGCM(block)
-	if err != nil {
-		return "", err
+// parseDate reads a date sent as text, either a full timestamp or a plain
+// yyyy-mm-dd date.
+func parseDate(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
 	}
-
-	nonce := make([]byte, aesGCM.NonceSize())
-	if _, err = io.ReadFull(rand.Reader, nonce); err != nil {
-		return "", err
-	}
-
-	ciphertext := aesGCM.Seal(nonce, nonce, []byte(plaintext), nil)
-
-	// base64-encode so it's printable
-	return base64.StdEncoding.EncodeToString(ciphertext), nil
+	return time.Parse("2006-01-02", value)
 }
 
-func decrypt(ciphertextBase64 string) (string, error) {
-	// logger := config.GetLog()
-	key := deriveKey(os.Getenv("SECRET_KEY"))
-
-	ciphertext, err := base64.StdEncoding.DecodeString(ciphertextBase64)
-	if err != nil {
-		return "", err
-	}
-	block, err := aes.NewCipher(key)
-	if err != nil {
-		return "", err
-	}
-
-	aesGCM, err := cipher.NewGCM(block)
-	if err != nil {
-		return "", err
-	}
-
-	nonceSize := aesGCM.NonceSize()
-	if len(ciphertext) < nonceSize {
-
-		return "", fmt.Errorf("ciphertext too short")
-	}
-
-	nonce, ciphertext := ciphertext[:nonceSize], ciphertext[nonceSize:]
-
-	plaintext, err := aesGCM.Open(nil, nonce, ciphertext, nil)
-	if err != nil {
-		return "", err
-	}
-	return string(plaintext), nil
+func int64Ptr(v int) *int64 {
+	n := int64(v)
+	return &n
 }
 
 func unmarshalToMap(data []byte) (map[string]interface{}, error) {
@@ -146,94 +89,35 @@ func unmarshalToSliceOfMaps(data []byte) ([]map[string]interface{}, error) {
 	return result, nil
 }
 
-func CaseCurrentStageInsert(conn *pgx.Conn, ctx context.Context, c *gin.Context, req model.CustomCaseCurrentStage) error {
-	logger := config.GetLog()
-
-	username := GetVariableFromToken(c, "username")
-	orgId := GetVariableFromToken(c, "orgId")
-	now := time.Now()
-
-	// Step 1: Load workflow node from DB
-	query := `
-	SELECT t1.id, t1."orgId", t1."wfId", t1."nodeId", t1.versions, t1.type, t1.section, t1.data,
-	       t1.pic, t1."group", t1."formId", t1."createdAt", t1."updatedAt", t1."createdBy", t1."updatedBy"
-	FROM public.wf_nodes t1
-	JOIN public.wf_definitions t2
-	  ON t1."versions" = t2."versions" AND t1."wfId" = t2."wfId"
-	WHERE t2."wfId" = $1 AND t1."nodeId" = $2 AND t2."orgId" = $3
-	`
-
-	logger.Debug("Loading workflow node",
-		zap.String("query", query),
-		zap.Any("params", []any{req.WfID, req.NodeID, orgId}),
-	)
-
-	var workflow model.WfNode
-	err := conn.QueryRow(ctx, query, req.WfID, req.NodeID, orgId).Scan(
-		&workflow.ID, &workflow.OrgID, &workflow.WfID, &workflow.NodeID,
-		&workflow.Versions, &workflow.Type, &workflow.Section,
-		&workflow.Data, &workflow.Pic, &workflow.Group, &workflow.FormID,
-		&workflow.CreatedAt, &workflow.UpdatedAt, &workflow.CreatedBy, &workflow.UpdatedBy,
-	)
-
-	if err != nil {
-		if err == pgx.ErrNoRows {
-			logger.Warn("No workflow node found")
-			return fmt.Errorf("workflow node not found")
-		}
-		logger.Error("Failed to load workflow node", zap.Error(err))
-		return err
-	}
-
-	// Step 2: Insert into tix_case_current_stage
-	insertQuery := `
-	INSERT INTO public.tix_case_current_stage(
-		"orgId", "caseId", "wfId", "nodeId", "stageType", "unitId", "username", versions, type, section, data, pic, "group", "formId",
-		"createdAt", "updatedAt", "createdBy", "updatedBy"
-	) VALUES (
-		$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11,
-		$12, $13, $14, $15, $16, $17, $18
-	)
-	`
-
-	args := []interface{}{
-		workflow.OrgID, req.CaseID, workflow.WfID, req.NodeID, "case", "", "", workflow.Versions,
-		workflow.Type, workflow.Section, workflow.Data, workflow.Pic,
-		workflow.Group, workflow.FormID, now, now, username, username,
-	}
-
-	logger.Debug("Inserting current stage",
-		zap.String("query", insertQuery),
-		zap.Any("args", args),
-	)
-
-	_, err = conn.Exec(ctx, insertQuery, args...)
-	if err != nil {
-		logger.Error("Insert failed", zap.Error(err))
-		return err
-	}
-
-	logger.Info("Insert success", zap.String("caseId", req.CaseID))
-	return nil
-}
-
 func CoreNotifications(ctx context.Context, inputs []model.NotificationCreateRequest) ([]model.Notification, error) {
 	if len(inputs) == 0 {
 		return nil, fmt.Errorf("notification array cannot be empty")
 	}
 
-	conn, ctx, cancel := config.ConnectDB()
+	repo, ctx, cancel := getStore(ctx)
 	defer cancel()
-	defer conn.Close(ctx)
 
-	tx, err := conn.Begin(ctx)
+	var createdNotifications []model.Notification
+	err := repo.InTx(ctx, func(tx *store.Store) error {
+		var err error
+		createdNotifications, err = createNotifications(ctx, tx, inputs)
+		return err
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+		return nil, err
 	}
-	defer tx.Rollback(ctx)
 
-	var createdNotifications []model.Notification
+	// The outbox rows are committed; let the relay deliver them
+	kickOutbox()
+
+	return createdNotifications, nil
+}
 
+// createNotifications inserts the notifications and their outbox entries
+// through repo, so callers can include them in a wider transaction; the relay
+// delivers them once it commits.
+func createNotifications(ctx context.Context, repo *store.Store, inputs []model.NotificationCreateRequest) ([]model.Notification, error) {
+	var createdNotifications []model.Notification
 	for _, input := range inputs {
 		noti := model.Notification{
 			OrgID:       input.OrgID, // ใช้ orgId จาก input แทนที่จะใช้ orgId[0]
@@ -249,40 +133,15 @@ func CoreNotifications(ctx context.Context, inputs []model.NotificationCreateReq
 			ExpiredAt:   input.ExpiredAt,
 			Recipients:  input.Recipients,
 		}
-
-		recipientsJSON, err := json.Marshal(noti.Recipients)
-		if err != nil {
-			return nil, fmt.Errorf("failed to process recipients: %w", err)
-		}
-		dataJSON, err := json.Marshal(noti.Data)
-		if err != nil {
-			return nil, fmt.Errorf("failed to process custom data: %w", err)
-		}
-
-		err = tx.QueryRow(ctx, `
-			INSERT INTO notifications 
-			("orgId", "senderType", "sender", "senderPhoto", "message", "eventType", "redirectUrl", "createdAt", "createdBy", "expiredAt", "recipients", "data")
-			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) RETURNING "id"
-		`, noti.OrgID, noti.SenderType, noti.Sender, noti.SenderPhoto, noti.Message,
-			noti.EventType, noti.RedirectUrl, noti.CreatedAt, noti.CreatedBy, noti.ExpiredAt, string(recipientsJSON), dataJSON).Scan(&noti.ID)
-
-		if err != nil {
+		if err := repo.Notifications.Create(ctx, &noti); err != nil {
 			return nil, fmt.Errorf("database insert failed: %w", err)
 		}
-
+		if err := enqueueDeliveries(ctx, repo, noti); err != nil {
+			return nil, err
+		}
 		log.Printf("Database (Tx): Queued insert for notification ID: %d", noti.ID)
-
-		// Broadcast async
-		notiCopy := noti
-		go BroadcastNotification(notiCopy)
-
 		createdNotifications = append(createdNotifications, noti)
 	}
-
-	if err := tx.Commit(ctx); err != nil {
-		return nil, fmt.Errorf("transaction commit failed: %w", err)
-	}
-
 	return createdNotifications, nil
 }
 
@@ -300,19 +159,7 @@ func genNotiCustom(
 	senderType string,
 ) error {
 	// เตรียม request ชุดเดียว
-	req := model.NotificationCreateRequest{
-		OrgID:       orgId,
-		SenderType:  senderType,
-		Sender:      senderName,
-		SenderPhoto: senderPhoto,
-		Message:     message,
-		EventType:   eventType,
-		RedirectUrl: redirectUrl,
-		Data:        data,
-		Recipients:  recipients,
-		CreatedBy:   createdBy,
-		ExpiredAt:   time.Now().Add(24 * time.Hour), // default TTL 24 ชม.
-	}
+	req := notiCustomRequest(orgId, createdBy, senderName, senderPhoto, eventType, data, message, recipients, redirectUrl, senderType)
 
 	// ยิงเข้า CoreNotifications
 	created, err := CoreNotifications(c.Request.Context(), []model.NotificationCreateRequest{req})
@@ -328,8 +175,34 @@ func genNotiCustom(
 		log.Println(string(b))
 	}
 
-	// ถ้า CoreNotifications ยัง "ไม่" broadcast ภายใน ให้เปิดบรรทัดนี้
-	// go BroadcastNotification(created[0])
-
 	return nil
 }
+
+// notiCustomRequest builds the single notification request sent by
+// genNotiCustom.
+func notiCustomRequest(
+	orgId string,
+	createdBy string,
+	senderName string,
+	senderPhoto string,
+	eventType string,
+	data []model.Data,
+	message string,
+	recipients []model.Recipient,
+	redirectUrl string,
+	senderType string,
+) model.NotificationCreateRequest {
+	return model.NotificationCreateRequest{
+		OrgID:       orgId,
+		SenderType:  senderType,
+		Sender:      senderName,
+		SenderPhoto: senderPhoto,
+		Message:     message,
+		EventType:   eventType,
+		RedirectUrl: redirectUrl,
+		Data:        data,
+		Recipients:  recipients,
+		CreatedBy:   createdBy,
+		ExpiredAt:   time.Now().Add(24 * time.Hour), // default TTL 24 ชม.
+	}
+}
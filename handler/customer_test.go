@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"mainPackage/model"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestCustomerContactUpdate(t *testing.T) {
+	newTestStore(t)
+	r := gin.New()
+	v1 := r.Group("/api/v1", ProtectedHandler)
+	v1.GET("/customer_contacts", CustomerContactList)
+	v1.POST("/customer_contacts/add", CustomerContactAdd)
+	v1.PATCH("/customer_contacts/:id", CustomerContactUpdate)
+	tok := accessToken(t)
+
+	in := model.CustomerContactInsert{CustID: 7, ContactName: "Bob", ContactAddr: map[string]interface{}{"city": "Bangkok"}}
+	if code := do(t, r, http.MethodPost, "/api/v1/customer_contacts/add", tok, in, nil); code != http.StatusOK {
+		t.Fatalf("add: %d", code)
+	}
+	var res struct {
+		Data []model.CustomerContact `json:"data"`
+	}
+	if do(t, r, http.MethodGet, "/api/v1/customer_contacts", tok, nil, &res); len(res.Data) != 1 || res.Data[0].OrgID != testOrg {
+		t.Fatalf("contacts = %+v, want one in %s", res.Data, testOrg)
+	}
+	id := res.Data[0].ID
+
+	up := model.CustomerContactUpdate{CustID: 7, ContactName: "Robert", ContactAddr: in.ContactAddr}
+	if code := do(t, r, http.MethodPatch, "/api/v1/customer_contacts/"+id, tok, up, nil); code != http.StatusOK {
+		t.Fatalf("update: %d", code)
+	}
+	if do(t, r, http.MethodGet, "/api/v1/customer_contacts", tok, nil, &res); len(res.Data) != 1 ||
+		res.Data[0].ContactName != "Robert" || res.Data[0].ContactAddr["city"] != "Bangkok" {
+		t.Fatalf("after the update = %+v, want Robert in Bangkok", res.Data)
+	}
+	if code := do(t, r, http.MethodPatch, "/api/v1/customer_contacts/999", tok, up, nil); code != http.StatusNotFound {
+		t.Errorf("update of a missing contact: %d, want 404", code)
+	}
+}
@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"mainPackage/config"
+	"mainPackage/model"
+	"mainPackage/store"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+var (
+	dbPool       *pgxpool.Pool
+	appStore     *store.Store
+	queryTimeout = 5 * time.Second
+)
+
+// SetPool injects the application-wide connection pool, whose statistics the
+// stats endpoint and logger report, and the query timeout. It must be called
+// once at startup before any handler, scheduler or WebSocket code runs.
+func SetPool(pool *pgxpool.Pool, timeout time.Duration) {
+	dbPool = pool
+	if timeout > 0 {
+		queryTimeout = timeout
+	}
+}
+
+// SetStore injects the repositories every handler reads and writes through.
+func SetStore(s *store.Store) {
+	appStore = s
+}
+
+// getStore returns the repositories with a context bounded by the parent and
+// the configured query timeout.
+func getStore(parent context.Context) (*store.Store, context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithTimeout(parent, queryTimeout)
+	return appStore, ctx, cancel
+}
+
+// storeStatus is the status a handler answers a repository error with.
+func storeStatus(err error) int {
+	if errors.Is(err, store.ErrNotFound) {
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
+
+// pageQuery reads the start and length parameters of the listing
+// endpoints, falling back to the first 1000 rows.
+func pageQuery(c *gin.Context) (int, int) {
+	start, err := strconv.Atoi(c.DefaultQuery("start", "0"))
+	if err != nil || start < 0 {
+		start = 0
+	}
+	length, err := strconv.Atoi(c.DefaultQuery("length", "1000"))
+	if err != nil || length < 0 {
+		length = 1000
+	}
+	return start, length
+}
+
+// @summary Get DB Pool Stats
+// @tags System
+// @security ApiKeyAuth
+// @id Get DB Pool Stats
+// @accept json
+// @produce json
+// @response 200 {object} model.Response "OK - Request successful"
+// @Router /api/v1/system/db_stats [get]
+func GetDBStats(c *gin.Context) {
+	stat := dbPool.Stat()
+	c.JSON(http.StatusOK, model.Response{
+		Status: "0",
+		Msg:    "Success",
+		Data: model.DBPoolStats{
+			MaxConns:                stat.MaxConns(),
+			TotalConns:              stat.TotalConns(),
+			AcquiredConns:           stat.AcquiredConns(),
+			IdleConns:               stat.IdleConns(),
+			ConstructingConns:       stat.ConstructingConns(),
+			AcquireCount:            stat.AcquireCount(),
+			AcquireDurationMs:       stat.AcquireDuration().Milliseconds(),
+			EmptyAcquireCount:       stat.EmptyAcquireCount(),
+			CanceledAcquireCount:    stat.CanceledAcquireCount(),
+			NewConnsCount:           stat.NewConnsCount(),
+			MaxLifetimeDestroyCount: stat.MaxLifetimeDestroyCount(),
+			MaxIdleDestroyCount:     stat.MaxIdleDestroyCount(),
+		},
+	})
+}
+
+// StartPoolStatsLogger periodically writes pool statistics to the log so
+// connection pressure is visible without polling the stats endpoint.
+func StartPoolStatsLogger(interval time.Duration) {
+	logger := config.GetLog()
+	ticker := time.NewTicker(interval)
+	go func() {
+		for range ticker.C {
+			stat := dbPool.Stat()
+			logger.Debug("Database pool stats",
+				zap.Int32("total", stat.TotalConns()),
+				zap.Int32("acquired", stat.AcquiredConns()),
+				zap.Int32("idle", stat.IdleConns()),
+				zap.Int64("emptyAcquire", stat.EmptyAcquireCount()),
+			)
+		}
+	}()
+}
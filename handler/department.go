@@ -4,12 +4,9 @@ import (
 	"mainPackage/config"
 	"mainPackage/model"
 	"net/http"
-	"strconv"
-	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
-	"github.com/jackc/pgx/v5"
 	"go.uber.org/zap"
 )
 
@@ -26,29 +23,11 @@ import (
 // @Router /api/v1/departments [get]
 func GetDepartment(c *gin.Context) {
 	logger := config.GetLog()
-	orgId := GetVariableFromToken(c, "orgId")
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
-	startStr := c.DefaultQuery("start", "0")
-	start, err := strconv.Atoi(startStr)
-	if err != nil {
-		start = 0
-	}
-	lengthStr := c.DefaultQuery("length", "1000")
-	length, err := strconv.Atoi(lengthStr)
-	if err != nil {
-		length = 1000
-	}
-	query := `SELECT id,"deptId", "orgId", en, th, active, "createdAt", "updatedAt", "createdBy", "updatedBy" 
-	FROM public.sec_departments WHERE "orgId"=$1 LIMIT $2 OFFSET $3`
+	start, length := pageQuery(c)
 
-	var rows pgx.Rows
-	logger.Debug(`Query`, zap.String("query", query))
-	rows, err = conn.Query(ctx, query, orgId, length, start)
+	list, err := repo.Org.ListDepartments(ctx, tokenString(c, "orgId"), length, start)
 	if err != nil {
 		logger.Warn("Query failed", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, model.Response{
@@ -58,42 +37,11 @@ func GetDepartment(c *gin.Context) {
 		})
 		return
 	}
-	defer rows.Close()
-	var errorMsg string
-	var Department model.Department
-	var DepartmentList []model.Department
-	rowIndex := 0
-	for rows.Next() {
-		rowIndex++
-		err := rows.Scan(&Department.ID, &Department.DeptID, &Department.OrgID, &Department.En, &Department.Th,
-			&Department.Active, &Department.CreatedAt, &Department.UpdatedAt, &Department.CreatedBy, &Department.UpdatedBy)
-		if err != nil {
-			logger.Warn("Scan failed", zap.Error(err))
-			response := model.Response{
-				Status: "-1",
-				Msg:    "Failed",
-				Desc:   errorMsg,
-			}
-			c.JSON(http.StatusInternalServerError, response)
-		}
-		DepartmentList = append(DepartmentList, Department)
-	}
-	if errorMsg != "" {
-		response := model.Response{
-			Status: "-1",
-			Msg:    "Failed",
-			Desc:   errorMsg,
-		}
-		c.JSON(http.StatusInternalServerError, response)
-	} else {
-		response := model.Response{
-			Status: "0",
-			Msg:    "Success",
-			Data:   DepartmentList,
-			Desc:   "",
-		}
-		c.JSON(http.StatusOK, response)
-	}
+	c.JSON(http.StatusOK, model.Response{
+		Status: "0",
+		Msg:    "Success",
+		Data:   list,
+	})
 }
 
 // ListDepartment godoc
@@ -108,62 +56,24 @@ func GetDepartment(c *gin.Context) {
 // @Router /api/v1/departments/{id} [get]
 func GetDepartmentbyId(c *gin.Context) {
 	logger := config.GetLog()
-	id := c.Param("id")
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
-	orgId := GetVariableFromToken(c, "orgId")
-	query := `SELECT id,"deptId", "orgId", en, th, active, "createdAt", "updatedAt", "createdBy", "updatedBy" 
-	FROM public.sec_departments WHERE "deptId" = $1 AND "orgId"=$2`
 
-	var rows pgx.Rows
-	logger.Debug(`Query`, zap.String("query", query))
-	rows, err := conn.Query(ctx, query, id, orgId)
+	dept, err := repo.Org.GetDepartment(ctx, tokenString(c, "orgId"), c.Param("id"))
 	if err != nil {
 		logger.Warn("Query failed", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, model.Response{
+		c.JSON(storeStatus(err), model.Response{
 			Status: "-1",
 			Msg:    "Failure",
 			Desc:   err.Error(),
 		})
 		return
 	}
-	defer rows.Close()
-	var errorMsg string
-	var Department model.Department
-	err = rows.Scan(&Department.ID, &Department.DeptID, &Department.OrgID, &Department.En, &Department.Th,
-		&Department.Active, &Department.CreatedAt, &Department.UpdatedAt, &Department.CreatedBy, &Department.UpdatedBy)
-	if err != nil {
-		logger.Warn("Scan failed", zap.Error(err))
-		errorMsg = err.Error()
-		response := model.Response{
-			Status: "-1",
-			Msg:    "Failed",
-			Desc:   errorMsg,
-		}
-		c.JSON(http.StatusInternalServerError, response)
-		return
-	}
-
-	if errorMsg != "" {
-		response := model.Response{
-			Status: "-1",
-			Msg:    "Failed",
-			Desc:   errorMsg,
-		}
-		c.JSON(http.StatusInternalServerError, response)
-	} else {
-		response := model.Response{
-			Status: "0",
-			Msg:    "Success",
-			Data:   Department,
-			Desc:   "",
-		}
-		c.JSON(http.StatusOK, response)
-	}
+	c.JSON(http.StatusOK, model.Response{
+		Status: "0",
+		Msg:    "Success",
+		Data:   dept,
+	})
 }
 
 // @summary Create Department
@@ -177,12 +87,7 @@ func GetDepartmentbyId(c *gin.Context) {
 // @Router /api/v1/departments/add [post]
 func InsertDepartment(c *gin.Context) {
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
-	defer cancel()
-	defer conn.Close(ctx)
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
 
 	var req model.DepartmentInsert
@@ -195,24 +100,10 @@ func InsertDepartment(c *gin.Context) {
 		logger.Warn("Insert failed", zap.Error(err))
 		return
 	}
-	username := GetVariableFromToken(c, "username")
-	orgId := GetVariableFromToken(c, "orgId")
-	now := time.Now()
-	uuid := uuid.New()
-	var id int
-	query := `
-	INSERT INTO public."sec_departments"(
-	"deptId", "orgId", en, th, active, "createdAt", "updatedAt", "createdBy", "updatedBy")
-	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
-	RETURNING id ;
-	`
+	username := tokenString(c, "username")
+	orgId := tokenString(c, "orgId")
 
-	err := conn.QueryRow(ctx, query,
-		uuid, orgId, req.En, req.Th, req.Active, now,
-		now, username, username).Scan(&id)
-
-	if err != nil {
-		// log.Printf("Insert failed: %v", err)
+	if err := repo.Org.CreateDepartment(ctx, orgId, uuid.NewString(), username, req); err != nil {
 		c.JSON(http.StatusInternalServerError, model.Response{
 			Status: "-1",
 			Msg:    "Failure",
@@ -221,14 +112,11 @@ func InsertDepartment(c *gin.Context) {
 		logger.Warn("Insert failed", zap.Error(err))
 		return
 	}
-
-	// Continue logic...
 	c.JSON(http.StatusOK, model.Response{
 		Status: "0",
 		Msg:    "Success",
 		Desc:   "Create successfully",
 	})
-
 }
 
 // @summary Update Department
@@ -243,15 +131,8 @@ func InsertDepartment(c *gin.Context) {
 // @Router /api/v1/departments/{id} [patch]
 func UpdateDepartment(c *gin.Context) {
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
-	defer cancel()
-
-	id := c.Param("id")
 
 	var req model.DepartmentUpdate
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -263,26 +144,11 @@ func UpdateDepartment(c *gin.Context) {
 		})
 		return
 	}
-	now := time.Now()
-	username := GetVariableFromToken(c, "username")
-	orgId := GetVariableFromToken(c, "orgId")
-	query := `UPDATE public."sec_departments"
-	SET en=$2, th=$3, active=$4,
-	 "updatedAt"=$5, "updatedBy"=$6
-	WHERE id = $1 AND "orgId"=$7`
-	_, err := conn.Exec(ctx, query,
-		id, req.En, req.Th, req.Active,
-		now, username, orgId,
-	)
-	logger.Debug("Update Case SQL Args",
-		zap.String("query", query),
-		zap.Any("Input", []any{
-			id, req.En, req.Th, req.Active,
-			now, username, orgId,
-		}))
-	if err != nil {
-		// log.Printf("Insert failed: %v", err)
-		c.JSON(http.StatusInternalServerError, model.Response{
+	username := tokenString(c, "username")
+	orgId := tokenString(c, "orgId")
+
+	if err := repo.Org.UpdateDepartment(ctx, orgId, c.Param("id"), username, req); err != nil {
+		c.JSON(storeStatus(err), model.Response{
 			Status: "-1",
 			Msg:    "Failure",
 			Desc:   err.Error(),
@@ -290,8 +156,6 @@ func UpdateDepartment(c *gin.Context) {
 		logger.Warn("Update failed", zap.Error(err))
 		return
 	}
-
-	// Continue logic...
 	c.JSON(http.StatusOK, model.Response{
 		Status: "0",
 		Msg:    "Success",
@@ -309,32 +173,19 @@ func UpdateDepartment(c *gin.Context) {
 // @response 200 {object} model.Response "OK - Request successful"
 // @Router /api/v1/departments/{id} [delete]
 func DeleteDepartment(c *gin.Context) {
-
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
-	defer cancel()
-	orgId := GetVariableFromToken(c, "orgId")
-	id := c.Param("id")
-	query := `DELETE FROM public."sec_departments" WHERE id = $1 AND "orgId"=$2`
-	logger.Debug("Query", zap.String("query", query), zap.Any("id", id))
-	_, err := conn.Exec(ctx, query, id, orgId)
-	if err != nil {
-		// log.Printf("Insert failed: %v", err)
-		c.JSON(http.StatusInternalServerError, model.Response{
+
+	if err := repo.Org.DeleteDepartment(ctx, tokenString(c, "orgId"), c.Param("id")); err != nil {
+		c.JSON(storeStatus(err), model.Response{
 			Status: "-1",
 			Msg:    "Failure",
 			Desc:   err.Error(),
 		})
-		logger.Warn("Update failed", zap.Error(err))
+		logger.Warn("Delete failed", zap.Error(err))
 		return
 	}
-
-	// Continue logic...
 	c.JSON(http.StatusOK, model.Response{
 		Status: "0",
 		Msg:    "Success",
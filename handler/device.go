@@ -5,11 +5,10 @@ import (
 	"fmt"
 	"mainPackage/config"
 	"mainPackage/model"
+	"mainPackage/store"
 	"net/http"
-	"strconv"
 
 	"github.com/gin-gonic/gin"
-	"github.com/jackc/pgx/v5"
 	"go.uber.org/zap"
 )
 
@@ -25,32 +24,11 @@ import (
 // @Router /api/v1/devices [get]
 func GetDeviceIoT(c *gin.Context) {
 	logger := config.GetLog()
-
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
-	orgId := GetVariableFromToken(c, "orgId")
-	startStr := c.DefaultQuery("start", "0")
-	start, err := strconv.Atoi(startStr)
-	if err != nil {
-		start = 0
-	}
-	lengthStr := c.DefaultQuery("length", "1000")
-	length, err := strconv.Atoi(lengthStr)
-	if err != nil {
-		length = 1000
-	}
+	start, length := pageQuery(c)
 
-	query := `SELECT "orgId", "deviceId", "deviceType", "model", "firmwareVer",
-	                 "latitude", "longitude", "ipAddress", "macAddress", 
-	                 "createdAt", "updatedAt", "createdBy", "updatedBy"
-	          FROM public.device_iot WHERE "orgId" = $1
-	          LIMIT $2 OFFSET $3`
-
-	rows, err := conn.Query(ctx, query, orgId, length, start)
+	devices, err := repo.Devices.List(ctx, tokenString(c, "orgId"), length, start)
 	if err != nil {
 		logger.Warn("Query failed", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, model.Response{
@@ -60,37 +38,6 @@ func GetDeviceIoT(c *gin.Context) {
 		})
 		return
 	}
-	defer rows.Close()
-
-	var devices []model.DeviceIoT
-	for rows.Next() {
-		var d model.DeviceIoT
-		err := rows.Scan(
-			&d.OrgID,
-			&d.DeviceID,
-			&d.DeviceType,
-			&d.Model,
-			&d.FirmwareVer,
-			&d.Latitude,
-			&d.Longitude,
-			&d.IPAddress,
-			&d.MacAddress,
-			&d.CreatedAt,
-			&d.UpdatedAt,
-			&d.CreatedBy,
-			&d.UpdatedBy,
-		)
-		if err != nil {
-			logger.Warn("Scan failed", zap.Error(err))
-			c.JSON(http.StatusInternalServerError, model.Response{
-				Status: "-1",
-				Msg:    "Failure",
-				Desc:   err.Error(),
-			})
-			return
-		}
-		devices = append(devices, d)
-	}
 
 	c.JSON(http.StatusOK, model.Response{
 		Status: "0",
@@ -112,50 +59,12 @@ func GetDeviceIoTById(c *gin.Context) {
 	logger := config.GetLog()
 	deviceId := c.Param("id") // path param like /device-iot/:id
 
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
-	orgId := GetVariableFromToken(c, "orgId")
-	query := `SELECT
-				"orgId",
-				"deviceId",
-				"deviceType",
-				"model",
-				"firmwareVer",
-				"latitude",
-				"longitude",
-				"ipAddress",
-				"macAddress",
-				"createdAt",
-				"updatedAt",
-				"createdBy",
-				"updatedBy"
-			  FROM public.device_iot
-			  WHERE "deviceId"=$1 AND "orgId"=$2`
-
-	var device model.DeviceIoT
-	logger.Debug("Executing query", zap.String("query", query), zap.String("deviceId", deviceId))
 
-	err := conn.QueryRow(ctx, query, deviceId, orgId).Scan(
-		&device.OrgID,
-		&device.DeviceID,
-		&device.DeviceType,
-		&device.Model,
-		&device.FirmwareVer,
-		&device.Latitude,
-		&device.Longitude,
-		&device.IPAddress,
-		&device.MacAddress,
-		&device.CreatedAt,
-		&device.UpdatedAt,
-		&device.CreatedBy,
-		&device.UpdatedBy,
-	)
+	device, err := repo.Devices.Get(ctx, tokenString(c, "orgId"), deviceId)
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
+		if errors.Is(err, store.ErrNotFound) {
 			c.JSON(http.StatusNotFound, model.Response{
 				Status: "-1",
 				Msg:    "Not Found",
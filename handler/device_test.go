@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"mainPackage/model"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestDeviceIoT(t *testing.T) {
+	m := newTestStore(t)
+	m.AddDevice(model.DeviceIoT{OrgID: testOrg, DeviceID: "D1", DeviceType: "camera"})
+	m.AddDevice(model.DeviceIoT{OrgID: testOrg, DeviceID: "D2", DeviceType: "sensor"})
+	m.AddDevice(model.DeviceIoT{OrgID: "other-org", DeviceID: "D3"})
+	r := gin.New()
+	v1 := r.Group("/api/v1", ProtectedHandler)
+	v1.GET("/devices", GetDeviceIoT)
+	v1.GET("/devices/:id", GetDeviceIoTById)
+	tok := accessToken(t)
+
+	var list struct {
+		Data []model.DeviceIoT `json:"data"`
+	}
+	if do(t, r, http.MethodGet, "/api/v1/devices?length=1&start=1", tok, nil, &list); len(list.Data) != 1 || list.Data[0].DeviceID != "D2" {
+		t.Fatalf("second page = %+v, want D2", list.Data)
+	}
+	var got struct {
+		Data model.DeviceIoT `json:"data"`
+	}
+	if do(t, r, http.MethodGet, "/api/v1/devices/D1", tok, nil, &got); got.Data.DeviceType != "camera" {
+		t.Errorf("D1 = %+v", got.Data)
+	}
+	if code := do(t, r, http.MethodGet, "/api/v1/devices/D3", tok, nil, nil); code != http.StatusNotFound {
+		t.Errorf("device of another organization: %d, want 404", code)
+	}
+}
+
+func TestCheckUserInProvince(t *testing.T) {
+	m := newTestStore(t)
+	m.AddDistrict(testOrg, "1001", "10", "Phra Nakhon", "พระนคร")
+	m.AddDistrict("other-org", "5001", "50", "Mueang", "เมือง")
+
+	if !checkUserInProvince(testOrg, []string{"9999", "1001"}, "10") {
+		t.Error("1001 lies in province 10")
+	}
+	if checkUserInProvince(testOrg, []string{"5001"}, "50") {
+		t.Error("5001 belongs to another organization")
+	}
+	if checkUserInProvince(testOrg, nil, "10") {
+		t.Error("no districts is never in a province")
+	}
+}
@@ -2,12 +2,15 @@ package handler
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"mainPackage/config"
 	"mainPackage/model"
+	"mainPackage/store"
+	"mainPackage/workflow"
 	"net/http"
-
-	"log"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
@@ -24,73 +27,13 @@ import (
 // @Router /api/v1/dispatch/{caseId}/SOP [get]
 func GetSOP(c *gin.Context) {
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
-
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
 
-	if conn == nil {
-		return
-	}
-
-	fmt.Println("=xcxxxx==xx=x=x=x=x=x")
-	log.Println("===")
-
-	orgId := GetVariableFromToken(c, "orgId")
+	orgId := tokenString(c, "orgId")
 	caseId := c.Param("caseId")
 
-	query := `SELECT id, "orgId", "caseId", "caseVersion", "referCaseId", "caseTypeId", "caseSTypeId", priority, "wfId", "versions", source, "deviceId", "phoneNo", "phoneNoHide", "caseDetail", "extReceive", "statusId", "caseLat", "caseLon", "caselocAddr", "caselocAddrDecs", "countryId", "provId", "distId", "caseDuration", "createdDate", "startedDate", "commandedDate", "receivedDate", "arrivedDate", "closedDate", usercreate, usercommand, userreceive, userarrive, userclose, "resId", "resDetail", "createdAt", "updatedAt", "createdBy", "updatedBy"
-	FROM public.tix_cases WHERE "orgId"=$1 AND "caseId"=$2`
-	logger.Debug(`Query`, zap.String("query", query))
-	var cusCase model.Case
-	err := conn.QueryRow(ctx, query, orgId, caseId).Scan(
-		&cusCase.ID,
-		&cusCase.OrgID,
-		&cusCase.CaseID,
-		&cusCase.CaseVersion,
-		&cusCase.ReferCaseID,
-		&cusCase.CaseTypeID,
-		&cusCase.CaseSTypeID,
-		&cusCase.Priority,
-		&cusCase.WfID,
-		&cusCase.WfVersions,
-		&cusCase.Source,
-		&cusCase.DeviceID,
-		&cusCase.PhoneNo,
-		&cusCase.PhoneNoHide,
-		&cusCase.CaseDetail,
-		&cusCase.ExtReceive,
-		&cusCase.StatusID,
-		&cusCase.CaseLat,
-		&cusCase.CaseLon,
-		&cusCase.CaseLocAddr,
-		&cusCase.CaseLocAddrDecs,
-		&cusCase.CountryID,
-		&cusCase.ProvID,
-		&cusCase.DistID,
-		&cusCase.CaseDuration,
-		&cusCase.CreatedDate,
-		&cusCase.StartedDate,
-		&cusCase.CommandedDate,
-		&cusCase.ReceivedDate,
-		&cusCase.ArrivedDate,
-		&cusCase.ClosedDate,
-		&cusCase.UserCreate,
-		&cusCase.UserCommand,
-		&cusCase.UserReceive,
-		&cusCase.UserArrive,
-		&cusCase.UserClose,
-		&cusCase.ResID,
-		&cusCase.ResDetail,
-		&cusCase.CreatedAt,
-		&cusCase.UpdatedAt,
-		&cusCase.CreatedBy,
-		&cusCase.UpdatedBy,
-	)
-
+	cusCase, err := repo.Cases.GetByCaseID(ctx, orgId, caseId)
 	if err != nil {
 		logger.Warn("Query failed", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, model.Response{
@@ -100,9 +43,7 @@ func GetSOP(c *gin.Context) {
 		})
 		return
 	}
-	log.Println("=orgId--")
-	log.Println(orgId)
-	allNodes, currentNode, err := GetWorkflowAndCurrentNode(c, orgId.(string), caseId)
+	allNodes, currentNode, err := GetWorkflowAndCurrentNode(c, orgId, caseId)
 	if err != nil {
 		response := model.Response{
 			Status: "-1",
@@ -115,10 +56,6 @@ func GetSOP(c *gin.Context) {
 
 	cusCase.SOP = allNodes
 	cusCase.CurrentStage = currentNode
-	log.Println(cusCase)
-	log.Println("=xcxxxx==allNodes=x=x=x=x=x")
-	log.Println(allNodes)
-	log.Println(currentNode)
 	// Final JSON
 	response := model.Response{
 		Status: "0",
@@ -135,67 +72,27 @@ func GetSOP(c *gin.Context) {
 
 func GetWorkflowAndCurrentNode(c *gin.Context, orgId, caseId string) ([]model.WorkflowNode, *model.CurrentStage, error) {
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return nil, nil, nil
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
 
 	// 🔹 Step 1: Get current node and wfId
-	currentQuery := `
-		SELECT "wfId", "caseId", "nodeId", "versions", "type", "section", "data", "pic", "group", "formId"
-		FROM tix_case_current_stage
-		WHERE "orgId"=$1 AND "caseId"=$2
-	`
-
-	var current model.CurrentStage
-	var wfId string
-
-	err := conn.QueryRow(ctx, currentQuery, orgId, caseId).
-		Scan(&wfId, &current.CaseId, &current.NodeId, &current.Versions, &current.Type, &current.Section, &current.Data, &current.Pic, &current.Group, &current.FormId)
+	current := &model.CurrentStage{}
+	stage, err := repo.Cases.GetCurrentStage(ctx, orgId, caseId)
 	if err != nil {
 		logger.Error("Failed to fetch current stage", zap.Error(err))
-		//return nil, nil, fmt.Errorf("current node not found for caseId=%s", caseId)
+	} else {
+		current = stage
 	}
-
-	log.Println("===== current stage =====")
-	log.Printf("wfId: %s, current: %+v\n", wfId, current)
+	logger.Debug("Current stage", zap.String("wfId", current.WfId), zap.Any("current", current))
 
 	// 🔹 Step 2: Get all workflow nodes using wfId
-	nodesQuery := `
-		SELECT "nodeId", "type", "section", "data"
-		FROM wf_nodes
-		WHERE "orgId"=$1 AND "wfId"=$2 AND "versions"=$3
-		ORDER BY 
-			CASE 
-				WHEN "section" = 'nodes' THEN 1 
-				WHEN "section" = 'connections' THEN 2 
-				ELSE 3 
-			END
-	`
-
-	rows, err := conn.Query(ctx, nodesQuery, orgId, wfId, current.Versions)
+	allNodes, err := repo.Workflows.ListNodes(ctx, orgId, current.WfId, current.Versions)
 	if err != nil {
 		logger.Error("Failed to fetch workflow nodes", zap.Error(err))
 		return nil, nil, err
 	}
-	defer rows.Close()
-
-	var allNodes []model.WorkflowNode
-	for rows.Next() {
-		var node model.WorkflowNode
-		if err := rows.Scan(&node.NodeId, &node.Type, &node.Section, &node.Data); err != nil {
-			logger.Error("Row scan failed", zap.Error(err))
-			return nil, nil, err
-		}
-		allNodes = append(allNodes, node)
-	}
-
-	log.Println("===== all workflow nodes =====")
-	log.Println(allNodes)
 
-	return allNodes, &current, nil
+	return allNodes, current, nil
 }
 
 // @summary Get Unit
@@ -209,164 +106,345 @@ func GetWorkflowAndCurrentNode(c *gin.Context, orgId, caseId string) ([]model.Wo
 // @Router /api/v1/dispatch/{caseId}/units [get]
 func GetUnit(c *gin.Context) {
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
+	repo, ctx, cancel := getStore(c.Request.Context())
+	defer cancel()
+
+	orgId := tokenString(c, "orgId")
+	caseId := c.Param("caseId")
+
+	results, err := repo.Units.FindCandidates(ctx, orgId, caseId)
+	if err != nil {
+		logger.Warn("Query failed", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, model.Response{
+			Status: "-1",
+			Msg:    "Failure",
+			Desc:   err.Error(),
+		})
 		return
 	}
 
+	c.JSON(http.StatusOK, model.Response{
+		Status: "0",
+		Msg:    "OK",
+		Data:   results,
+	})
+}
+
+// dispatchError maps errors of the dispatch endpoints to a status.
+func dispatchError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, store.ErrNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, errNotUnitUser):
+		status = http.StatusForbidden
+	case errors.Is(err, workflow.ErrUnitBusy), errors.Is(err, workflow.ErrUnitInactive),
+		errors.Is(err, workflow.ErrDispatchStep), errors.Is(err, workflow.ErrDispatchChanged),
+		errors.Is(err, workflow.ErrStatusChanged):
+		status = http.StatusConflict
+	}
+	c.JSON(status, model.Response{
+		Status: "-1",
+		Msg:    "Failure",
+		Desc:   err.Error(),
+	})
+	config.GetLog().Warn("Dispatch failed", zap.Error(err))
+}
+
+// errNotUnitUser is returned when someone other than the unit's user reports
+// for it without case.update.
+var errNotUnitUser = errors.New("only the unit's user may report its status")
+
+// openDispatchOn returns the unit's open dispatch when it is on caseId.
+func openDispatchOn(ctx context.Context, repo *store.Store, orgId, caseId, unitId string) (*model.CaseDispatch, error) {
+	d, err := repo.Dispatches.GetOpen(ctx, orgId, unitId)
+	if err == nil && d.CaseID != caseId {
+		err = store.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open dispatch of unit %s on case %s: %w", unitId, caseId, err)
+	}
+	return d, nil
+}
+
+// @summary List Case Dispatches
+// @description Lists every unit dispatched to the case, released ones included.
+// @tags Dispatch
+// @security ApiKeyAuth
+// @id List Case Dispatches
+// @accept json
+// @produce json
+// @Param caseId path string true "caseId"
+// @response 200 {object} model.Response{data=[]model.CaseDispatch} "OK - Request successful"
+// @Router /api/v1/dispatch/{caseId}/assignments [get]
+func ListCaseDispatches(c *gin.Context) {
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
 
-	if conn == nil {
+	list, err := repo.Dispatches.ListByCase(ctx, tokenString(c, "orgId"), c.Param("caseId"))
+	if err != nil {
+		dispatchError(c, err)
 		return
 	}
+	c.JSON(http.StatusOK, model.Response{
+		Status: "0",
+		Msg:    "Success",
+		Data:   list,
+	})
+}
 
-	fmt.Println("=xcxxxx==xx=x=x=x=x=x")
-	log.Println("===")
+// @summary Assign Units
+// @description Dispatches units to the case. Every unit must be active and free; the first assignment stamps the case's commandedDate.
+// @tags Dispatch
+// @security ApiKeyAuth
+// @id Assign Units
+// @accept json
+// @produce json
+// @Param caseId path string true "caseId"
+// @param Body body model.DispatchUnits true "Units to assign"
+// @response 200 {object} model.Response{data=[]model.CaseDispatch} "OK - Request successful"
+// @Router /api/v1/dispatch/{caseId}/assign [post]
+func AssignUnits(c *gin.Context) {
+	repo, ctx, cancel := getStore(c.Request.Context())
+	defer cancel()
 
-	orgId := GetVariableFromToken(c, "orgId")
+	var req model.DispatchUnits
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, model.Response{
+			Status: "-1",
+			Msg:    "Failure",
+			Desc:   err.Error(),
+		})
+		return
+	}
+	orgId := tokenString(c, "orgId")
+	username := tokenString(c, "username")
 	caseId := c.Param("caseId")
 
-	query := `
-  WITH case_info AS (
-  SELECT 
-    c."caseSTypeId", 
-    c."countryId",
-    c."provId",
-    c."distId",
-    s."unitPropLists", 
-    s."userSkillList"
-  FROM "tix_cases" c
-  JOIN "case_sub_types" s ON c."caseSTypeId" = s."sTypeId"
-  WHERE c."caseId" = $1
-    AND s."active" = TRUE
-),
-unit_with_props AS (
-  SELECT 
-    "unitId", 
-    array_agg("propId") AS props
-  FROM "mdm_unit_with_properties"
-  WHERE "active" = TRUE
-  GROUP BY "unitId"
-),
-units_matched AS (
-  SELECT u."unitId", u."unitName", p.props
-  FROM "mdm_units" u
-  JOIN unit_with_props p ON u."unitId" = p."unitId"
-  CROSS JOIN case_info c
-  WHERE u."active" = TRUE
-    AND (
-      SELECT COUNT(DISTINCT prop_uuid)
-      FROM (
-        SELECT (jsonb_array_elements_text(c."unitPropLists"::jsonb))::uuid AS prop_uuid
-      ) AS required_props
-      WHERE prop_uuid = ANY(p.props)
-    ) = (SELECT jsonb_array_length(c."unitPropLists"::jsonb))
-),
-users_on_units AS (
-  SELECT u."unitId", mdm."username"
-  FROM units_matched u
-  JOIN "mdm_units" mdm ON mdm."unitId" = u."unitId" AND mdm."active" = TRUE
-  JOIN "um_users" um ON um."username" = mdm."username" AND um."active" = TRUE
-),
-users_with_skill AS (
-  SELECT DISTINCT "userName"
-  FROM "um_user_with_skills"
-  WHERE "skillId" IN (
-    SELECT (jsonb_array_elements_text(ci."userSkillList"::jsonb))::uuid
-    FROM case_info ci
-  )
-  AND "active" = TRUE
-),
-users_in_area AS (
-  SELECT "username"
-  FROM "um_user_with_area_response" ua
-  CROSS JOIN case_info c
-  WHERE ua."orgId" = $2
-    AND EXISTS (
-      SELECT 1
-      FROM jsonb_array_elements_text(ua."distIdLists") AS distId
-      WHERE distId.value = c."distId"
-    )
-)
-SELECT mu."orgId",
-    mu."unitId",
-    mu."unitName",
-    mu."unitSourceId",
-    mu."unitTypeId",
-    mu."priority",
-    mu."compId",
-    mu."deptId",
-    mu."commId",
-    mu."stnId",
-    mu."plateNo",
-    mu."provinceCode",
-    mu."active",
-    mu."username",
-    mu."isLogin",
-    mu."isFreeze",
-    mu."isOutArea",
-    mu."locLat",
-    mu."locLon",
-    mu."locAlt",
-    mu."locBearing",
-    mu."locSpeed",
-    mu."locProvider",
-    mu."locGpsTime",
-    mu."locSatellites",
-    mu."locAccuracy",
-    mu."locLastUpdateTime",
-    mu."breakDuration",
-    mu."healthChk",
-    mu."healthChkTime",
-    mu."sttId",
-    mu."createdBy",
-    mu."updatedBy"
-FROM users_on_units u
-JOIN users_with_skill us ON u."username" = us."userName"
-JOIN users_in_area ua ON u."username" = ua."username"
-JOIN "mdm_units" mu ON mu."unitId" = u."unitId";
-`
-	logger.Debug(`Query`, zap.String("query", query))
-	rows, err := conn.Query(context.Background(), query, caseId, orgId)
+	var result []model.CaseDispatch
+	var notis []model.Notification
+	err := repo.InTx(ctx, func(tx *store.Store) error {
+		cusCase, err := tx.Cases.GetByCaseID(ctx, orgId, caseId)
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		for _, unitId := range req.UnitIDs {
+			unit, err := tx.Units.GetByUnitID(ctx, orgId, unitId)
+			if err != nil {
+				return fmt.Errorf("unit %s: %w", unitId, err)
+			}
+			d, err := workflow.Assign(ctx, tx, orgId, cusCase, unit, username, now)
+			if err != nil {
+				return err
+			}
+			sent, err := runDispatchEffects(ctx, tx, orgId, username, cusCase, d, req.Note)
+			if err != nil {
+				return err
+			}
+			result = append(result, *d)
+			notis = append(notis, sent...)
+		}
+		return nil
+	})
 	if err != nil {
-		logger.Warn("Query failed", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, model.Response{
+		dispatchError(c, err)
+		return
+	}
+	if len(notis) > 0 {
+		kickOutbox()
+	}
+	c.JSON(http.StatusOK, model.Response{
+		Status: "0",
+		Msg:    "Success",
+		Data:   result,
+		Desc:   "Assign successfully",
+	})
+}
+
+// @summary Unassign Units
+// @description Releases the open dispatches of units on the case and makes the units available again.
+// @tags Dispatch
+// @security ApiKeyAuth
+// @id Unassign Units
+// @accept json
+// @produce json
+// @Param caseId path string true "caseId"
+// @param Body body model.DispatchUnits true "Units to unassign"
+// @response 200 {object} model.Response{data=[]model.CaseDispatch} "OK - Request successful"
+// @Router /api/v1/dispatch/{caseId}/unassign [post]
+func UnassignUnits(c *gin.Context) {
+	repo, ctx, cancel := getStore(c.Request.Context())
+	defer cancel()
+
+	var req model.DispatchUnits
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, model.Response{
 			Status: "-1",
 			Msg:    "Failure",
 			Desc:   err.Error(),
 		})
 		return
 	}
-	defer rows.Close()
-
-	var results []model.UnitUser
+	orgId := tokenString(c, "orgId")
+	username := tokenString(c, "username")
+	caseId := c.Param("caseId")
 
-	for rows.Next() {
-		var u model.UnitUser
-		if err := rows.Scan(
-			&u.OrgID, &u.UnitID, &u.UnitName, &u.UnitSourceID, &u.UnitTypeID, &u.Priority,
-			&u.CompID, &u.DeptID, &u.CommID, &u.StnID, &u.PlateNo, &u.ProvinceCode,
-			&u.Active, &u.Username, &u.IsLogin, &u.IsFreeze, &u.IsOutArea,
-			&u.LocLat, &u.LocLon, &u.LocAlt, &u.LocBearing, &u.LocSpeed, &u.LocProvider,
-			&u.LocGpsTime, &u.LocSatellites, &u.LocAccuracy, &u.LocLastUpdateTime,
-			&u.BreakDuration, &u.HealthChk, &u.HealthChkTime, &u.SttID,
-			&u.CreatedBy, &u.UpdatedBy,
-		); err != nil {
-			logger.Warn("Row scan failed", zap.Error(err))
-			c.JSON(http.StatusInternalServerError, model.Response{
-				Status: "-1",
-				Msg:    "Failure",
-				Desc:   err.Error(),
-			})
-			return
+	var result, prior []model.CaseDispatch
+	var notis []model.Notification
+	err := repo.InTx(ctx, func(tx *store.Store) error {
+		cusCase, err := tx.Cases.GetByCaseID(ctx, orgId, caseId)
+		if err != nil {
+			return err
 		}
-		results = append(results, u)
+		now := time.Now()
+		for _, unitId := range req.UnitIDs {
+			d, err := openDispatchOn(ctx, tx, orgId, caseId, unitId)
+			if err != nil {
+				return err
+			}
+			prior = append(prior, *d)
+			if err := workflow.Report(ctx, tx, orgId, cusCase, d, model.DispatchReleased, username, now); err != nil {
+				return err
+			}
+			sent, err := runDispatchEffects(ctx, tx, orgId, username, cusCase, d, req.Note)
+			if err != nil {
+				return err
+			}
+			result = append(result, *d)
+			notis = append(notis, sent...)
+		}
+		return nil
+	})
+	if err != nil {
+		dispatchError(c, err)
+		return
+	}
+	auditPrior(c, prior)
+	if len(notis) > 0 {
+		kickOutbox()
+	}
+	c.JSON(http.StatusOK, model.Response{
+		Status: "0",
+		Msg:    "Success",
+		Data:   result,
+		Desc:   "Unassign successfully",
+	})
+}
+
+// @summary Report Unit Status
+// @description Moves a unit's open dispatch on the case to acknowledged, en-route, on-scene, completed or released. Only the unit's user, or a user with case.update, may report. Acknowledged and on-scene stamp the case's receivedDate and arrivedDate the first time any unit reaches them; a step reported past a skipped one stamps the skipped step's date too.
+// @tags Dispatch
+// @security ApiKeyAuth
+// @id Report Unit Status
+// @accept json
+// @produce json
+// @Param caseId path string true "caseId"
+// @Param unitId path string true "unitId"
+// @param Body body model.DispatchStatusReport true "Next step"
+// @response 200 {object} model.Response{data=model.CaseDispatch} "OK - Request successful"
+// @Router /api/v1/dispatch/{caseId}/units/{unitId}/status [post]
+func ReportUnitStatus(c *gin.Context) {
+	repo, ctx, cancel := getStore(c.Request.Context())
+	defer cancel()
+
+	var req model.DispatchStatusReport
+	err := c.ShouldBindJSON(&req)
+	switch {
+	case err != nil:
+	// Every step a unit can report follows assigned.
+	case !workflow.ValidDispatchStep(model.DispatchAssigned, req.Status):
+		err = errors.New("status must be one of acknowledged, en-route, on-scene, completed, released")
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.Response{
+			Status: "-1",
+			Msg:    "Failure",
+			Desc:   err.Error(),
+		})
+		return
 	}
+	orgId := tokenString(c, "orgId")
+	username := tokenString(c, "username")
+	caseId, unitId := c.Param("caseId"), c.Param("unitId")
+	// Resolved before the transaction: the permission lookup goes through
+	// the outer store.
+	canUpdate := hasPermission(c, PermCaseUpdate)
 
+	var result *model.CaseDispatch
+	var notis []model.Notification
+	err = repo.InTx(ctx, func(tx *store.Store) error {
+		cusCase, err := tx.Cases.GetByCaseID(ctx, orgId, caseId)
+		if err != nil {
+			return err
+		}
+		d, err := openDispatchOn(ctx, tx, orgId, caseId, unitId)
+		if err != nil {
+			return err
+		}
+		if d.Username != username && !canUpdate {
+			return errNotUnitUser
+		}
+		auditPrior(c, d)
+		if err := workflow.Report(ctx, tx, orgId, cusCase, d, req.Status, username, time.Now()); err != nil {
+			return err
+		}
+		notis, err = runDispatchEffects(ctx, tx, orgId, username, cusCase, d, req.Note)
+		result = d
+		return err
+	})
+	if err != nil {
+		dispatchError(c, err)
+		return
+	}
+	if len(notis) > 0 {
+		kickOutbox()
+	}
 	c.JSON(http.StatusOK, model.Response{
 		Status: "0",
-		Msg:    "OK",
-		Data:   results,
+		Msg:    "Success",
+		Data:   result,
+		Desc:   "Status reported successfully",
+	})
+}
+
+// runDispatchEffects records a dispatch step in the case history and
+// notifies the unit's user.
+func runDispatchEffects(ctx context.Context, repo *store.Store, orgId, username string, cusCase *model.Case, d *model.CaseDispatch, note string) ([]model.Notification, error) {
+	jsonData, err := json.Marshal(map[string]string{
+		"dispatchId": d.ID,
+		"unitId":     d.UnitID,
+		"status":     d.Status,
+		"note":       note,
+	})
+	if err != nil {
+		return nil, err
+	}
+	_, err = repo.Cases.AddHistory(ctx, orgId, username, model.CaseHistoryInsert{
+		CaseID:   cusCase.CaseID,
+		Type:     "dispatch",
+		FullMsg:  "Dispatch " + d.UnitID + " : " + d.Status,
+		JSONData: string(jsonData),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if d.Username == "" {
+		return nil, nil
+	}
+	data := []model.Data{
+		{Key: "caseId", Value: cusCase.CaseID},
+		{Key: "unitId", Value: d.UnitID},
+		{Key: "dispatchId", Value: d.ID},
+		{Key: "status", Value: d.Status},
+	}
+	message := "สถานะหน่วย " + d.UnitID + " : " + d.Status
+	if d.Status == model.DispatchAssigned {
+		message = "ได้รับมอบหมายงาน " + cusCase.CaseID + " : " + d.UnitID
+	}
+	recipients := []model.Recipient{{Type: "username", Value: d.Username}}
+	return createNotifications(ctx, repo, []model.NotificationCreateRequest{
+		notiCustomRequest(orgId, username, username, "", "Dispatch", data, message, recipients, "", "User"),
 	})
 }
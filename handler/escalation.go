@@ -0,0 +1,425 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"mainPackage/model"
+	"mainPackage/store"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// escalationPoll is how often the worker looks for unacknowledged
+// notifications that are due for their next step.
+const escalationPoll = time.Minute
+
+// escalationRanks orders the recipient types an escalation may widen
+// through; a step must go to a wider type than the one before it.
+var escalationRanks = map[string]int{"stnId": 0, "commId": 1, "deptId": 2, "orgId": 3}
+
+// validateEscalationPolicy checks that the steps widen the recipients and
+// come at increasing times.
+func validateEscalationPolicy(in model.EscalationPolicyInput) error {
+	if strings.TrimSpace(in.EventType) == "" {
+		return errors.New("eventType is required")
+	}
+	if len(in.Steps) == 0 {
+		return errors.New("at least one step is required")
+	}
+	prevMinutes, prevRank := 0, escalationRanks["stnId"]
+	for i, step := range in.Steps {
+		rank, ok := escalationRanks[step.RecipientType]
+		if !ok || rank == escalationRanks["stnId"] {
+			return fmt.Errorf("step %d: recipientType must be commId, deptId or orgId", i+1)
+		}
+		if rank <= prevRank {
+			return fmt.Errorf("step %d: recipientType must be wider than the step before", i+1)
+		}
+		if step.AfterMinutes <= prevMinutes {
+			return fmt.Errorf("step %d: afterMinutes must be greater than %d", i+1, prevMinutes)
+		}
+		prevMinutes, prevRank = step.AfterMinutes, rank
+	}
+	return nil
+}
+
+// widenRecipients returns the recipients of type to that cover the stations,
+// commands and departments noti is addressed to, leaving out those it already
+// has.
+func widenRecipients(ctx context.Context, repo *store.Store, noti model.Notification, to string) ([]model.Recipient, error) {
+	have := make(map[string]bool)
+	var stnIds, commIds, deptIds []string
+	for _, r := range noti.Recipients {
+		for _, v := range strings.Split(r.Value, ",") {
+			v = strings.TrimSpace(v)
+			if v == "" {
+				continue
+			}
+			switch r.Type {
+			case "stnId":
+				stnIds = append(stnIds, v)
+			case "commId":
+				commIds = append(commIds, v)
+			case "deptId":
+				deptIds = append(deptIds, v)
+			}
+			if r.Type == to {
+				have[v] = true
+			}
+		}
+	}
+
+	var values []string
+	switch to {
+	case "orgId":
+		values = []string{noti.OrgID}
+	case "commId", "deptId":
+		units, err := repo.Escalations.ResolveUnits(ctx, noti.OrgID, stnIds, commIds)
+		if err != nil {
+			return nil, err
+		}
+		for _, u := range units {
+			if to == "commId" {
+				values = append(values, u.CommID)
+			} else {
+				values = append(values, u.DeptID)
+			}
+		}
+		if to == "deptId" {
+			values = append(values, deptIds...)
+		}
+	}
+
+	var added []model.Recipient
+	for _, v := range values {
+		if v == "" || have[v] {
+			continue
+		}
+		have[v] = true
+		added = append(added, model.Recipient{Type: to, Value: v})
+	}
+	return added, nil
+}
+
+// escalateNotification takes step level of policy on noti: it widens the
+// recipients, queues the notification again and notes the step in the
+// history of the case named by its caseId data, if that case still exists.
+// It reports false when
+// another instance took the step first.
+func escalateNotification(ctx context.Context, repo *store.Store, noti model.Notification, policy model.EscalationPolicy, level int) (bool, error) {
+	step := policy.Steps[level]
+	added, err := widenRecipients(ctx, repo, noti, step.RecipientType)
+	if err != nil {
+		return false, err
+	}
+	record := model.NotificationEscalation{
+		Level:         level + 1,
+		RecipientType: step.RecipientType,
+		Added:         added,
+		EscalatedAt:   time.Now(),
+	}
+
+	taken := true
+	err = repo.InTx(ctx, func(tx *store.Store) error {
+		if err := tx.Notifications.Escalate(ctx, int64(noti.ID), record); err != nil {
+			if errors.Is(err, store.ErrNotFound) {
+				taken = false
+				return nil
+			}
+			return err
+		}
+		if err := enqueueDeliveries(ctx, tx, noti); err != nil {
+			return err
+		}
+		caseId := ""
+		for _, d := range noti.Data {
+			if d.Key == "caseId" {
+				caseId = d.Value
+			}
+		}
+		if caseId == "" {
+			return nil
+		}
+		if _, err := tx.Cases.GetByCaseID(ctx, noti.OrgID, caseId); err != nil {
+			if errors.Is(err, store.ErrNotFound) {
+				return nil
+			}
+			return err
+		}
+		jsonData, err := json.Marshal(map[string]interface{}{
+			"notificationId": noti.ID,
+			"eventType":      noti.EventType,
+			"level":          record.Level,
+			"recipientType":  record.RecipientType,
+			"added":          record.Added,
+		})
+		if err != nil {
+			return err
+		}
+		_, err = tx.Cases.AddHistory(ctx, noti.OrgID, "system", model.CaseHistoryInsert{
+			CaseID:   caseId,
+			Type:     "escalation",
+			FullMsg:  fmt.Sprintf("Escalate notification %d to %s (level %d)", noti.ID, record.RecipientType, record.Level),
+			JSONData: string(jsonData),
+		})
+		return err
+	})
+	return taken && err == nil, err
+}
+
+// EscalateNotifications takes every escalation step that has come due under
+// the active policies.
+func EscalateNotifications() {
+	repo, ctx, cancel := getStore(context.Background())
+	policies, err := repo.Escalations.ListActive(ctx)
+	cancel()
+	if err != nil {
+		log.Printf("Scheduler Error: escalation policy load failed: %v", err)
+		return
+	}
+	escalated := 0
+	for _, policy := range policies {
+		for level, step := range policy.Steps {
+			now := time.Now()
+			repo, ctx, cancel := getStore(context.Background())
+			due, err := repo.Notifications.ListEscalationDue(ctx, policy.OrgID, policy.EventType, level,
+				now.Add(-time.Duration(step.AfterMinutes)*time.Minute), now)
+			cancel()
+			if err != nil {
+				log.Printf("Scheduler Error: escalation query failed for %s: %v", policy.EventType, err)
+				continue
+			}
+			for _, noti := range due {
+				repo, ctx, cancel := getStore(context.Background())
+				ok, err := escalateNotification(ctx, repo, noti, policy, level)
+				cancel()
+				if ok || err != nil {
+					auditService(noti.OrgID, "notification", "EscalateNotifications", http.MethodPatch,
+						gin.H{"notificationId": noti.ID, "policyId": policy.ID, "level": level + 1}, nil, err)
+				}
+				if err != nil {
+					log.Printf("Scheduler Error: escalation of notification %d failed: %v", noti.ID, err)
+					continue
+				}
+				if ok {
+					escalated++
+				}
+			}
+		}
+	}
+	if escalated > 0 {
+		log.Printf("Scheduler: Escalated %d notifications.", escalated)
+		kickOutbox()
+	}
+}
+
+// StartEscalationWorker runs EscalateNotifications every escalationPoll,
+// next to the hourly StartAutoDeleteScheduler.
+func StartEscalationWorker() {
+	log.Println("Starting background worker for notification escalation...")
+	ticker := time.NewTicker(escalationPoll)
+	go func() {
+		for {
+			<-ticker.C
+			EscalateNotifications()
+		}
+	}()
+}
+
+// escalationError maps store errors of the policy endpoints to a status.
+func escalationError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	if errors.Is(err, store.ErrNotFound) {
+		status = http.StatusNotFound
+	}
+	c.JSON(status, model.Response{
+		Status: "-1",
+		Msg:    "Failure",
+		Desc:   err.Error(),
+	})
+}
+
+// bindEscalationPolicy reads and validates the policy body, answering 400 or
+// 409 itself when it is unusable.
+func bindEscalationPolicy(c *gin.Context, ctx context.Context, repo *store.Store, orgId, id string) (model.EscalationPolicyInput, bool) {
+	var in model.EscalationPolicyInput
+	err := c.ShouldBindJSON(&in)
+	if err == nil {
+		err = validateEscalationPolicy(in)
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.Response{
+			Status: "-1",
+			Msg:    "Failure",
+			Desc:   err.Error(),
+		})
+		return in, false
+	}
+	list, err := repo.Escalations.List(ctx, orgId)
+	if err != nil {
+		escalationError(c, err)
+		return in, false
+	}
+	for _, p := range list {
+		if p.EventType == in.EventType && p.ID != id {
+			c.JSON(http.StatusConflict, model.Response{
+				Status: "-1",
+				Msg:    "Failure",
+				Desc:   "a policy for eventType " + in.EventType + " already exists",
+			})
+			return in, false
+		}
+	}
+	return in, true
+}
+
+// @summary List Notification Escalation Policies
+// @description Lists the organization's escalation policies by event type.
+// @tags Notifications
+// @security ApiKeyAuth
+// @id List Notification Escalation Policies
+// @accept json
+// @produce json
+// @response 200 {object} model.Response{data=[]model.EscalationPolicy} "OK - Request successful"
+// @Router /api/v1/notification_escalation [get]
+func ListEscalationPolicies(c *gin.Context) {
+	repo, ctx, cancel := getStore(c.Request.Context())
+	defer cancel()
+
+	list, err := repo.Escalations.List(ctx, tokenString(c, "orgId"))
+	if err != nil {
+		escalationError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, model.Response{
+		Status: "0",
+		Msg:    "Success",
+		Data:   list,
+	})
+}
+
+// @summary Get Notification Escalation Policy
+// @tags Notifications
+// @security ApiKeyAuth
+// @id Get Notification Escalation Policy
+// @accept json
+// @produce json
+// @Param id path string true "policy id"
+// @response 200 {object} model.Response{data=model.EscalationPolicy} "OK - Request successful"
+// @Router /api/v1/notification_escalation/{id} [get]
+func GetEscalationPolicy(c *gin.Context) {
+	repo, ctx, cancel := getStore(c.Request.Context())
+	defer cancel()
+
+	policy, err := repo.Escalations.Get(ctx, tokenString(c, "orgId"), c.Param("id"))
+	if err != nil {
+		escalationError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, model.Response{
+		Status: "0",
+		Msg:    "Success",
+		Data:   policy,
+	})
+}
+
+// @summary Create Notification Escalation Policy
+// @description Sets how unacknowledged notifications of one event type escalate. Each step widens the recipients to commId, deptId or orgId once afterMinutes have passed since the notification was sent; steps must widen and come later in turn.
+// @tags Notifications
+// @security ApiKeyAuth
+// @id Create Notification Escalation Policy
+// @accept json
+// @produce json
+// @param Body body model.EscalationPolicyInput true "Policy to create"
+// @response 200 {object} model.Response "OK - Request successful"
+// @Router /api/v1/notification_escalation/add [post]
+func InsertEscalationPolicy(c *gin.Context) {
+	repo, ctx, cancel := getStore(c.Request.Context())
+	defer cancel()
+	orgId := tokenString(c, "orgId")
+
+	in, ok := bindEscalationPolicy(c, ctx, repo, orgId, "")
+	if !ok {
+		return
+	}
+	id, err := repo.Escalations.Create(ctx, orgId, tokenString(c, "username"), in)
+	if err != nil {
+		escalationError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, model.Response{
+		Status: "0",
+		Msg:    "Success",
+		Desc:   "Create successfully",
+		Data:   gin.H{"id": id},
+	})
+}
+
+// @summary Update Notification Escalation Policy
+// @tags Notifications
+// @security ApiKeyAuth
+// @id Update Notification Escalation Policy
+// @accept json
+// @produce json
+// @Param id path string true "policy id"
+// @param Body body model.EscalationPolicyInput true "Policy to update"
+// @response 200 {object} model.Response "OK - Request successful"
+// @Router /api/v1/notification_escalation/{id} [patch]
+func UpdateEscalationPolicy(c *gin.Context) {
+	repo, ctx, cancel := getStore(c.Request.Context())
+	defer cancel()
+	orgId := tokenString(c, "orgId")
+	id := c.Param("id")
+
+	in, ok := bindEscalationPolicy(c, ctx, repo, orgId, id)
+	if !ok {
+		return
+	}
+	if prior, err := repo.Escalations.Get(ctx, orgId, id); err == nil {
+		auditPrior(c, prior)
+	}
+	if err := repo.Escalations.Update(ctx, orgId, id, tokenString(c, "username"), in); err != nil {
+		escalationError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, model.Response{
+		Status: "0",
+		Msg:    "Success",
+		Desc:   "Update successfully",
+	})
+}
+
+// @summary Delete Notification Escalation Policy
+// @tags Notifications
+// @security ApiKeyAuth
+// @id Delete Notification Escalation Policy
+// @accept json
+// @produce json
+// @Param id path string true "policy id"
+// @response 200 {object} model.Response "OK - Request successful"
+// @Router /api/v1/notification_escalation/{id} [delete]
+func DeleteEscalationPolicy(c *gin.Context) {
+	repo, ctx, cancel := getStore(c.Request.Context())
+	defer cancel()
+	orgId := tokenString(c, "orgId")
+	id := c.Param("id")
+
+	if prior, err := repo.Escalations.Get(ctx, orgId, id); err == nil {
+		auditPrior(c, prior)
+	}
+	if err := repo.Escalations.Delete(ctx, orgId, id); err != nil {
+		escalationError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, model.Response{
+		Status: "0",
+		Msg:    "Success",
+		Desc:   "Delete successfully",
+	})
+}
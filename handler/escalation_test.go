@@ -0,0 +1,142 @@
+package handler
+
+import (
+	"context"
+	"mainPackage/model"
+	"testing"
+	"time"
+)
+
+func TestValidateEscalationPolicy(t *testing.T) {
+	step := func(after int, to string) model.EscalationStep {
+		return model.EscalationStep{AfterMinutes: after, RecipientType: to}
+	}
+	tests := []struct {
+		name  string
+		steps []model.EscalationStep
+		ok    bool
+	}{
+		{"widening", []model.EscalationStep{step(5, "commId"), step(15, "deptId"), step(30, "orgId")}, true},
+		{"skipping a level", []model.EscalationStep{step(5, "deptId")}, true},
+		{"no steps", nil, false},
+		{"to stations", []model.EscalationStep{step(5, "stnId")}, false},
+		{"unknown type", []model.EscalationStep{step(5, "grpId")}, false},
+		{"narrowing", []model.EscalationStep{step(5, "deptId"), step(10, "commId")}, false},
+		{"same type twice", []model.EscalationStep{step(5, "commId"), step(10, "commId")}, false},
+		{"not later", []model.EscalationStep{step(10, "commId"), step(10, "deptId")}, false},
+		{"at once", []model.EscalationStep{step(0, "commId")}, false},
+	}
+	for _, tt := range tests {
+		err := validateEscalationPolicy(model.EscalationPolicyInput{EventType: "sos", Steps: tt.steps})
+		if (err == nil) != tt.ok {
+			t.Errorf("%s: validateEscalationPolicy = %v, want ok %v", tt.name, err, tt.ok)
+		}
+	}
+	if err := validateEscalationPolicy(model.EscalationPolicyInput{Steps: []model.EscalationStep{step(5, "commId")}}); err == nil {
+		t.Error("policy without an eventType accepted")
+	}
+}
+
+func TestWidenRecipients(t *testing.T) {
+	m := newTestStore(t)
+	m.AddStation(testOrg, "d1", "c1", "s1")
+	m.AddStation(testOrg, "d1", "c1", "s2")
+	m.AddCommand(testOrg, "d2", "c2")
+	noti := model.Notification{OrgID: testOrg, Recipients: []model.Recipient{
+		{Type: "stnId", Value: "s1, s2"},
+		{Type: "commId", Value: "c2"},
+		{Type: "deptId", Value: "d3"},
+	}}
+
+	tests := []struct {
+		to   string
+		want []string
+	}{
+		{"commId", []string{"c1"}},
+		{"deptId", []string{"d1", "d2"}},
+		{"orgId", []string{testOrg}},
+	}
+	for _, tt := range tests {
+		added, err := widenRecipients(context.Background(), m.Store, noti, tt.to)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for _, r := range added {
+			if r.Type != tt.to {
+				t.Errorf("widen to %s added a %s recipient", tt.to, r.Type)
+			}
+			got = append(got, r.Value)
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("widen to %s = %v, want %v", tt.to, got, tt.want)
+			continue
+		}
+		seen := make(map[string]bool)
+		for _, v := range got {
+			seen[v] = true
+		}
+		for _, v := range tt.want {
+			if !seen[v] {
+				t.Errorf("widen to %s = %v, want %v", tt.to, got, tt.want)
+			}
+		}
+	}
+}
+
+func TestEscalateNotifications(t *testing.T) {
+	m := newTestStore(t)
+	m.AddStation(testOrg, "d1", "c1", "s1")
+	ctx := context.Background()
+	policy := model.EscalationPolicyInput{EventType: "sos", Active: true, Steps: []model.EscalationStep{
+		{AfterMinutes: 5, RecipientType: "commId"},
+		{AfterMinutes: 30, RecipientType: "orgId"},
+	}}
+	if _, err := m.Escalations.Create(ctx, testOrg, "admin", policy); err != nil {
+		t.Fatal(err)
+	}
+	send := func(eventType string, age time.Duration) int64 {
+		t.Helper()
+		n := model.Notification{OrgID: testOrg, EventType: eventType, CreatedAt: time.Now().Add(-age),
+			ExpiredAt: time.Now().Add(time.Hour), Recipients: []model.Recipient{{Type: "stnId", Value: "s1"}}}
+		if err := m.Notifications.Create(ctx, &n); err != nil {
+			t.Fatal(err)
+		}
+		return int64(n.ID)
+	}
+	due := send("sos", 10*time.Minute)
+	fresh := send("sos", time.Minute)
+	acked := send("sos", 10*time.Minute)
+	other := send("fire", 10*time.Minute)
+	if _, err := m.Notifications.SetState(ctx, testOrg, "alice", []int64{acked}, model.NotificationAcknowledged, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+
+	EscalateNotifications()
+	EscalateNotifications()
+
+	for id, want := range map[int64]int{due: 1, fresh: 0, acked: 0, other: 0} {
+		n, err := m.Notifications.Get(ctx, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(n.Escalations) != want {
+			t.Errorf("notification %d took %d steps, want %d", id, len(n.Escalations), want)
+		}
+	}
+	n, err := m.Notifications.Get(ctx, due)
+	if err != nil {
+		t.Fatal(err)
+	}
+	last := n.Recipients[len(n.Recipients)-1]
+	if len(n.Recipients) != 2 || last.Type != "commId" || last.Value != "c1" {
+		t.Errorf("recipients after the first step = %+v, want command c1 added", n.Recipients)
+	}
+	list, err := m.Outbox.List(ctx, testOrg, model.OutboxFilter{Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != len(outboxChannels) || list[0].NotificationID != due {
+		t.Errorf("outbox = %+v, want one delivery per channel of notification %d", list, due)
+	}
+}
@@ -1,16 +1,18 @@
 package handler
 
 import (
-	"encoding/json"
+	"errors"
+	"fmt"
 	"mainPackage/config"
+	"mainPackage/formdata"
 	"mainPackage/model"
+	"mainPackage/store"
+	"mainPackage/workflow"
 	"net/http"
 	"strconv"
-	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
-	"github.com/jackc/pgx/v5"
 	"go.uber.org/zap"
 )
 
@@ -28,21 +30,15 @@ func GetForm(c *gin.Context) {
 	logger := config.GetLog()
 	id := c.Query("id")
 	version := c.Query("version")
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
-	orgId := GetVariableFromToken(c, "orgId")
-	query := `SELECT form_builder."formId",form_builder."formName",form_builder."formColSpan",form_elements."eleData" 
-	FROM public.form_builder INNER JOIN public.form_elements ON form_builder."formId"=form_elements."formId" 
-	WHERE form_builder."formId" = $1 AND form_builder."orgId"=$2 AND form_elements."versions"=$3`
-
-	var rows pgx.Rows
-	logger.Debug(`Query`, zap.String("query", query))
+	orgId := tokenString(c, "orgId")
+
 	logger.Debug(`id :` + id)
-	rows, err := conn.Query(ctx, query, id, orgId, version)
+	form, err := repo.Forms.Get(ctx, orgId, id, version)
+	if errors.Is(err, store.ErrNotFound) {
+		form, err = &model.Form{}, nil
+	}
 	if err != nil {
 		logger.Warn("Query failed", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, model.Response{
@@ -52,44 +48,13 @@ func GetForm(c *gin.Context) {
 		})
 		return
 	}
-	defer rows.Close()
-	var errorMsg string
-	var formFields []map[string]interface{}
-	var form model.Form
-	for rows.Next() {
-		var rawJSON []byte
-		err := rows.Scan(&form.FormId, &form.FormName, &form.FormColSpan, &rawJSON)
-		if err != nil {
-			logger.Warn("Scan failed", zap.Error(err))
-			continue
-		}
-
-		var field map[string]interface{}
-		if err := json.Unmarshal(rawJSON, &field); err != nil {
-			logger.Warn("unmarshal field failed", zap.Error(err))
-			continue
-		}
-
-		formFields = append(formFields, field)
-		form.FormFieldJson = formFields
-	}
-	if errorMsg != "" {
-		response := model.Response{
-			Status: "-1",
-			Msg:    "Failed",
-			Data:   form,
-			Desc:   errorMsg,
-		}
-		c.JSON(http.StatusInternalServerError, response)
-	} else {
-		response := model.Response{
-			Status: "0",
-			Msg:    "Success",
-			Data:   form,
-			Desc:   "",
-		}
-		c.JSON(http.StatusOK, response)
+	response := model.Response{
+		Status: "0",
+		Msg:    "Success",
+		Data:   form,
+		Desc:   "",
 	}
+	c.JSON(http.StatusOK, response)
 }
 
 // @summary Get All Form
@@ -102,14 +67,10 @@ func GetForm(c *gin.Context) {
 // @Router /api/v1/forms/getAllForms [get]
 func GetAllForm(c *gin.Context) {
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
 
-	orgId := GetVariableFromToken(c, "orgId")
+	orgId := tokenString(c, "orgId")
 	if orgId == "" {
 		c.JSON(http.StatusBadRequest, model.Response{
 			Status: "-1",
@@ -118,18 +79,8 @@ func GetAllForm(c *gin.Context) {
 		})
 		return
 	}
-	query := `SELECT form_builder."formId", form_builder."versions",form_builder."active",form_builder."publish",
-    form_builder."formName",form_builder."locks", form_builder."formColSpan", form_elements."eleData" , form_elements."createdBy" 
-              ,form_elements."createdAt", form_elements."updatedAt",  form_elements."updatedBy"
-              FROM public.form_builder 
-              INNER JOIN public.form_elements 
-              ON form_builder."formId" = form_elements."formId" 
-              WHERE form_builder."orgId" = $1
-              ORDER BY public.form_elements."eleNumber" ASC`
-
-	logger.Debug("Query", zap.String("query", query))
-
-	rows, err := conn.Query(ctx, query, orgId)
+
+	result, err := repo.Forms.List(ctx, orgId)
 	if err != nil {
 		logger.Warn("Query failed", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, model.Response{
@@ -139,58 +90,8 @@ func GetAllForm(c *gin.Context) {
 		})
 		return
 	}
-	defer rows.Close()
-
-	var forms []model.FormsManager
-
-	for rows.Next() {
-		var rawJSON []byte
-		var form model.FormsManager
-
-		err := rows.Scan(
-			&form.FormId,
-			&form.Versions,
-			&form.Active,
-			&form.Publish,
-			&form.FormName,
-			&form.Locks,
-			&form.FormColSpan,
-			&rawJSON,
-			&form.CreatedBy,
-			&form.CreatedAt,
-			&form.UpdatedAt,
-			&form.UpdatedBy,
-		)
-
-		if err != nil {
-			logger.Warn("Scan failed", zap.Error(err))
-			continue
-		}
 
-		var field map[string]interface{}
-		if err := json.Unmarshal(rawJSON, &field); err != nil {
-			logger.Warn("Unmarshal field failed", zap.Error(err))
-			continue
-		}
-
-		form.FormFieldJson = []map[string]interface{}{field}
-		forms = append(forms, form)
-		// logger.Debug("Row data", zap.Any("form", form))
-	}
-
-	var result []model.FormsManager
-	formMap := make(map[string]int)
-	for _, form := range forms {
-		if idx, exists := formMap[*form.FormName]; exists {
-			result[idx].FormFieldJson = append(result[idx].FormFieldJson, form.FormFieldJson...)
-		} else {
-			result = append(result, form)
-			formMap[*form.FormName] = len(result) - 1
-			logger.Debug("DEBUG", zap.Any("formMap", formMap))
-		}
-	}
-
-	if len(forms) == 0 {
+	if len(result) == 0 {
 		c.JSON(http.StatusNotFound, model.Response{
 			Status: "-1",
 			Msg:    "No data found",
@@ -217,12 +118,8 @@ func GetAllForm(c *gin.Context) {
 // @Router /api/v1/forms [post]
 func FormInsert(c *gin.Context) {
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
 
 	var req model.FormInsert
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -235,19 +132,13 @@ func FormInsert(c *gin.Context) {
 		return
 	}
 
-	username := GetVariableFromToken(c, "username")
-	orgId := GetVariableFromToken(c, "orgId")
-	uuid := uuid.New()
-	now := time.Now()
-	var id int
-	var exists bool
-	checkQuery := `
-	SELECT EXISTS (
-		SELECT 1 FROM public."form_builder" 
-		WHERE "orgId" = $1 AND "formName" = $2
-	);
-`
-	err := conn.QueryRow(ctx, checkQuery, orgId, req.FormName).Scan(&exists)
+	username := tokenString(c, "username")
+	orgId := tokenString(c, "orgId")
+	var formName string
+	if req.FormName != nil {
+		formName = *req.FormName
+	}
+	exists, err := repo.Forms.NameExists(ctx, orgId, formName)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, model.Response{
 			Status: "-1",
@@ -266,18 +157,20 @@ func FormInsert(c *gin.Context) {
 		logger.Warn("Insert failed form name already exists")
 		return
 	}
-	query := `
-	INSERT INTO public."form_builder"(
-	"orgId", "formId", "formName", "formColSpan", active, publish, versions, locks, "createdAt", "updatedAt", "createdBy", "updatedBy")
-	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
-	RETURNING id ;
-	`
-	err = conn.QueryRow(ctx, query,
-		orgId, uuid, req.FormName, req.FormColSpan, req.Active, req.Publish, "draft", req.Locks, now,
-		now, username, username).Scan(&id)
 
+	logger.Debug("Insert form", zap.Any("req", req))
+	err = repo.InTx(ctx, func(tx *store.Store) error {
+		formId := uuid.New().String()
+		if err := tx.Forms.Create(ctx, orgId, formId, username, req); err != nil {
+			return err
+		}
+		if !req.Publish {
+			return nil
+		}
+		_, err := tx.Forms.Publish(ctx, orgId, formId, username)
+		return err
+	})
 	if err != nil {
-		// log.Printf("Insert failed: %v", err)
 		c.JSON(http.StatusInternalServerError, model.Response{
 			Status: "-1",
 			Msg:    "Failure",
@@ -287,30 +180,6 @@ func FormInsert(c *gin.Context) {
 		return
 	}
 
-	for i, item := range req.FormFieldJson {
-		logger.Debug("eleNumber", zap.Int("i", i+1))
-		logger.Debug("JsonArray", zap.Any("Json", item))
-		query := `
-				INSERT INTO public.form_elements(
-				"orgId", "formId", versions, "eleNumber", "eleData", "createdAt", "updatedAt", "createdBy", "updatedBy")
-				VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
-				RETURNING id ;
-				`
-		err = conn.QueryRow(ctx, query,
-			orgId, uuid, "draft", i+1, item, now, now, username, username).Scan(&id)
-
-		if err != nil {
-			// log.Printf("Insert failed: %v", err)
-			c.JSON(http.StatusInternalServerError, model.Response{
-				Status: "-1",
-				Msg:    "Failure",
-				Desc:   err.Error(),
-			})
-			logger.Warn("Insert failed", zap.Error(err))
-			return
-		}
-	}
-
 	c.JSON(http.StatusOK, model.Response{
 		Status: "0",
 		Msg:    "Success",
@@ -319,6 +188,7 @@ func FormInsert(c *gin.Context) {
 }
 
 // @summary Update Form
+// @description Replaces the draft. Published versions are not changed and publish in the body is ignored; use Update Form Publish. A locked form is refused with 409.
 // @tags Form and Workflow
 // @security ApiKeyAuth
 // @id Update Form
@@ -330,12 +200,8 @@ func FormInsert(c *gin.Context) {
 // @Router /api/v1/forms/{uuid} [patch]
 func FormUpdate(c *gin.Context) {
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
 	uuid := c.Param("uuid")
 	var req model.FormUpdate
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -348,41 +214,20 @@ func FormUpdate(c *gin.Context) {
 		return
 	}
 
-	username := GetVariableFromToken(c, "username")
-	orgId := GetVariableFromToken(c, "orgId")
-	now := time.Now()
-	var id int
-
-	query := `
-	UPDATE public.form_builder
-	SET "formName"=$2, "formColSpan"=$3, active=$4, publish=$5,
-	 versions=$6, locks=$7, "updatedAt"=$8,"updatedBy"=$9
-	WHERE "formId"=$1 AND "orgId"=$10;
-	`
-	_, err := conn.Exec(ctx, query, uuid,
-		req.FormName, req.FormColSpan, req.Active, req.Publish, "draft", req.Locks,
-		now, username, orgId)
+	username := tokenString(c, "username")
+	orgId := tokenString(c, "orgId")
 
+	logger.Debug("Update form", zap.String("formId", uuid), zap.Any("req", req))
+	err := repo.Forms.Update(ctx, orgId, uuid, username, req)
 	if err != nil {
-		// log.Printf("Insert failed: %v", err)
-		c.JSON(http.StatusInternalServerError, model.Response{
-			Status: "-1",
-			Msg:    "Failure",
-			Desc:   err.Error(),
-		})
-		logger.Warn("Insert failed", zap.Error(err))
-		return
-	}
-
-	query = `
-	DELETE FROM public.form_elements
-	WHERE "formId"=$1;
-	`
-	_, err = conn.Exec(ctx, query, uuid)
-
-	if err != nil {
-		// log.Printf("Insert failed: %v", err)
-		c.JSON(http.StatusInternalServerError, model.Response{
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, store.ErrLocked):
+			status = http.StatusConflict
+		}
+		c.JSON(status, model.Response{
 			Status: "-1",
 			Msg:    "Failure",
 			Desc:   err.Error(),
@@ -391,31 +236,6 @@ func FormUpdate(c *gin.Context) {
 		return
 	}
 
-	for i, item := range req.FormFieldJson {
-		now := time.Now()
-		logger.Debug("eleNumber", zap.Int("i", i+1))
-		logger.Debug("JsonArray", zap.Any("Json", item))
-		query := `
-				INSERT INTO public.form_elements(
-				"orgId", "formId", versions, "eleNumber", "eleData", "createdAt", "updatedAt", "createdBy", "updatedBy")
-				VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
-				RETURNING id ;
-				`
-		err = conn.QueryRow(ctx, query,
-			orgId, uuid, "draft", i+1, item, now, now, username, username).Scan(&id)
-
-		if err != nil {
-			// log.Printf("Insert failed: %v", err)
-			c.JSON(http.StatusInternalServerError, model.Response{
-				Status: "-1",
-				Msg:    "Failure",
-				Desc:   err.Error(),
-			})
-			logger.Warn("Insert failed", zap.Error(err))
-			return
-		}
-	}
-
 	c.JSON(http.StatusOK, model.Response{
 		Status: "0",
 		Msg:    "Success",
@@ -424,6 +244,7 @@ func FormUpdate(c *gin.Context) {
 }
 
 // @summary Update Form Publish
+// @description Publishing copies the draft into the next numbered version, which becomes the one cases fill in. Unpublishing only clears the flag.
 // @tags Form and Workflow
 // @security ApiKeyAuth
 // @id Update Form Publish
@@ -435,12 +256,8 @@ func FormUpdate(c *gin.Context) {
 func FormPublish(c *gin.Context) {
 
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
 	var req model.FormPublish
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, model.Response{
@@ -452,38 +269,151 @@ func FormPublish(c *gin.Context) {
 		return
 	}
 
-	username := GetVariableFromToken(c, "username")
-	orgId := GetVariableFromToken(c, "orgId")
-	now := time.Now()
-
-	query := `
-	UPDATE public.form_builder
-	SET publish=$2, "updatedAt"=$3,"updatedBy"=$4
-	WHERE "formId"=$1 AND "orgId"=$5;
-	`
-	_, err := conn.Exec(ctx, query, req.FormID, req.Publish, now, username, orgId)
-	logger.Debug("Update Case SQL Args",
-		zap.String("query", query),
-		zap.Any("Input", []any{
-			req.FormID, req.Publish, now, username, orgId,
-		}))
+	username := tokenString(c, "username")
+	orgId := tokenString(c, "orgId")
+
+	logger.Debug("Update form publish", zap.Any("Input", req))
+	var versions string
+	var err error
+	if req.Publish {
+		versions, err = repo.Forms.Publish(ctx, orgId, req.FormID, username)
+	} else {
+		err = repo.Forms.SetPublish(ctx, orgId, req.FormID, username, false)
+	}
 	if err != nil {
-		// log.Printf("Insert failed: %v", err)
-		c.JSON(http.StatusInternalServerError, model.Response{
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, store.ErrEmptyDraft):
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, model.Response{
 			Status: "-1",
 			Msg:    "Failure",
 			Desc:   err.Error(),
 		})
-		logger.Warn("Insert failed", zap.Error(err))
+		logger.Warn("Publish failed", zap.Error(err))
 		return
 	}
+	var data interface{}
+	if req.Publish {
+		data = gin.H{"formId": req.FormID, "versions": versions}
+	}
 	c.JSON(http.StatusOK, model.Response{
 		Status: "0",
 		Msg:    "Success",
+		Data:   data,
 		Desc:   "Update successfully",
 	})
 }
 
+// @summary Get Form Versions
+// @tags Form and Workflow
+// @security ApiKeyAuth
+// @id Get Form Versions
+// @accept json
+// @produce json
+// @Param uuid path string true "uuid"
+// @response 200 {object} model.Response{data=[]model.FormVersion} "OK - Request successful"
+// @Router /api/v1/forms/{uuid}/versions [get]
+func GetFormVersions(c *gin.Context) {
+	logger := config.GetLog()
+	repo, ctx, cancel := getStore(c.Request.Context())
+	defer cancel()
+	id := c.Param("uuid")
+	orgId := tokenString(c, "orgId")
+
+	list, err := repo.Forms.ListVersions(ctx, orgId, id)
+	if err != nil {
+		logger.Warn("Query failed", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, model.Response{
+			Status: "-1",
+			Msg:    "Failure",
+			Desc:   err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, model.Response{
+		Status: "0",
+		Msg:    "Success",
+		Data:   list,
+	})
+}
+
+// @summary Diff Form Versions
+// @description Compares two versions of a form field by field. from defaults to the current version and to defaults to the draft.
+// @tags Form and Workflow
+// @security ApiKeyAuth
+// @id Diff Form Versions
+// @accept json
+// @produce json
+// @Param uuid path string true "uuid"
+// @Param from query string false "version, the current one when empty"
+// @Param to query string false "version, the draft when empty"
+// @response 200 {object} model.Response{data=model.FormDiff} "OK - Request successful"
+// @Router /api/v1/forms/{uuid}/diff [get]
+func FormDiff(c *gin.Context) {
+	logger := config.GetLog()
+	repo, ctx, cancel := getStore(c.Request.Context())
+	defer cancel()
+	id := c.Param("uuid")
+	orgId := tokenString(c, "orgId")
+
+	diff := model.FormDiff{FormID: id, From: c.Query("from"), To: c.DefaultQuery("to", "draft")}
+	var before, after *model.Form
+	err := func() error {
+		var err error
+		if diff.From == "" {
+			if diff.From, err = repo.Forms.CurrentVersion(ctx, orgId, id); err != nil {
+				return err
+			}
+		}
+		versions, err := repo.Forms.ListVersions(ctx, orgId, id)
+		if err != nil {
+			return err
+		}
+		for _, v := range []string{diff.From, diff.To} {
+			if v != "draft" && !hasFormVersion(versions, v) {
+				return fmt.Errorf("form version %s: %w", v, store.ErrNotFound)
+			}
+		}
+		if before, err = repo.Forms.Get(ctx, orgId, id, diff.From); err != nil {
+			return err
+		}
+		after, err = repo.Forms.Get(ctx, orgId, id, diff.To)
+		return err
+	}()
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, store.ErrNotFound) {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, model.Response{
+			Status: "-1",
+			Msg:    "Failure",
+			Desc:   err.Error(),
+		})
+		logger.Warn("Query failed", zap.Error(err))
+		return
+	}
+	diff.Changes = formdata.Diff(before.FormFieldJson, after.FormFieldJson)
+	c.JSON(http.StatusOK, model.Response{
+		Status: "0",
+		Msg:    "Success",
+		Data:   diff,
+	})
+}
+
+func hasFormVersion(list []model.FormVersion, versions string) bool {
+	for _, v := range list {
+		if v.Versions == versions {
+			return true
+		}
+	}
+	return false
+}
+
 // @summary Update Form Lock
 // @tags Form and Workflow
 // @security ApiKeyAuth
@@ -495,12 +425,8 @@ func FormPublish(c *gin.Context) {
 // @Router /api/v1/forms/lock [patch]
 func FormLock(c *gin.Context) {
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
 	var req model.FormLock
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, model.Response{
@@ -512,24 +438,12 @@ func FormLock(c *gin.Context) {
 		return
 	}
 
-	username := GetVariableFromToken(c, "username")
-	orgId := GetVariableFromToken(c, "orgId")
-	now := time.Now()
-
-	query := `
-	UPDATE public.form_builder
-	SET locks=$2, "updatedAt"=$3,"updatedBy"=$4
-	WHERE "formId"=$1 AND "orgId"=$5;
-	`
-	_, err := conn.Exec(ctx, query, req.FormID, req.Locks, now, username, orgId)
-	logger.Debug("Update Case SQL Args",
-		zap.String("query", query),
-		zap.Any("Input", []any{
-			req.FormID, req.Locks, now, username, orgId,
-		}))
+	username := tokenString(c, "username")
+	orgId := tokenString(c, "orgId")
 
+	logger.Debug("Update form lock", zap.Any("Input", req))
+	err := repo.Forms.SetLock(ctx, orgId, req.FormID, username, req.Locks)
 	if err != nil {
-		// log.Printf("Insert failed: %v", err)
 		c.JSON(http.StatusInternalServerError, model.Response{
 			Status: "-1",
 			Msg:    "Failure",
@@ -556,12 +470,8 @@ func FormLock(c *gin.Context) {
 // @Router /api/v1/forms/active [patch]
 func FormActive(c *gin.Context) {
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
 	var req model.FormActive
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, model.Response{
@@ -573,23 +483,12 @@ func FormActive(c *gin.Context) {
 		return
 	}
 
-	username := GetVariableFromToken(c, "username")
-	orgId := GetVariableFromToken(c, "orgId")
-	now := time.Now()
-
-	query := `
-	UPDATE public.form_builder
-	SET active=$2, "updatedAt"=$3,"updatedBy"=$4
-	WHERE "formId"=$1 AND "orgId"=$5;
-	`
-	_, err := conn.Exec(ctx, query, req.FormID, req.Active, now, username, orgId)
-	logger.Debug("Update Case SQL Args",
-		zap.String("query", query),
-		zap.Any("Input", []any{
-			req.FormID, req.Active, now, username, orgId,
-		}))
+	username := tokenString(c, "username")
+	orgId := tokenString(c, "orgId")
+
+	logger.Debug("Update form active", zap.Any("Input", req))
+	err := repo.Forms.SetActive(ctx, orgId, req.FormID, username, req.Active)
 	if err != nil {
-		// log.Printf("Insert failed: %v", err)
 		c.JSON(http.StatusInternalServerError, model.Response{
 			Status: "-1",
 			Msg:    "Failure",
@@ -623,27 +522,23 @@ func DeleteForm(c *gin.Context) {}
 // @accept json
 // @produce json
 // @Param id path string true "id"
+// @Param versions query string false "published version, the draft when empty"
 // @response 200 {object} model.Response "OK - Request successful"
 // @Router /api/v1/workflows/{id} [get]
 func GetWorkFlow(c *gin.Context) {
 	logger := config.GetLog()
 	id := c.Param("id")
+	versions := c.Query("versions")
 
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
-	orgId := GetVariableFromToken(c, "orgId")
-	query := `SELECT "section","data",title,"desc",wf_definitions."versions",wf_definitions."createdAt",wf_definitions."updatedAt" 
-	FROM public.wf_definitions Inner join public.wf_nodes
-	ON wf_definitions."wfId" = wf_nodes."wfId" WHERE wf_definitions."wfId" = $1 AND wf_nodes."orgId"=$2`
-
-	var rows pgx.Rows
-	logger.Debug(`Query`, zap.String("query", query))
+	orgId := tokenString(c, "orgId")
+
 	logger.Debug(`id :` + id)
-	rows, err := conn.Query(ctx, query, id, orgId)
+	workflow, err := repo.Workflows.Get(ctx, orgId, id, versions)
+	if errors.Is(err, store.ErrNotFound) {
+		workflow, err = &model.WorkFlow{}, nil
+	}
 	if err != nil {
 		logger.Warn("Query failed", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, model.Response{
@@ -653,70 +548,13 @@ func GetWorkFlow(c *gin.Context) {
 		})
 		return
 	}
-	defer rows.Close()
-	var errorMsg string
-	var NodesArray []map[string]interface{}
-	var ConnectionArray []map[string]interface{}
-	var workflow model.WorkFlow
-	var workflowMetaData model.WorkFlowMetadata
-	rowIndex := 0
-	for rows.Next() {
-		rowIndex++
-		var rawJSON []byte
-		var rowsType string
-		err := rows.Scan(&rowsType, &rawJSON, &workflowMetaData.Title, &workflowMetaData.Desc,
-			&workflowMetaData.Status, &workflowMetaData.CreatedAt, &workflowMetaData.UpdatedAt)
-		if err != nil {
-			logger.Warn("Scan failed", zap.Error(err))
-			response := model.Response{
-				Status: "-1",
-				Msg:    "Failed",
-				Data:   workflow,
-				Desc:   errorMsg,
-			}
-			c.JSON(http.StatusInternalServerError, response)
-		}
-
-		switch rowsType {
-		case "nodes":
-			field, err := unmarshalToMap(rawJSON)
-			if err != nil {
-				logger.Warn("Unmarshal nodes failed", zap.Error(err))
-				continue
-			}
-			NodesArray = append(NodesArray, field)
-		case "connections":
-			fields, err := unmarshalToSliceOfMaps(rawJSON)
-			if err != nil {
-				logger.Warn("Unmarshal connections failed", zap.Error(err))
-				continue
-			}
-			ConnectionArray = append(ConnectionArray, fields...)
-		default:
-			logger.Warn("Unknown rowsType", zap.String("rowsType", rowsType))
-			continue
-		}
-		workflow.Nodes = NodesArray
-		workflow.Connections = ConnectionArray
-		workflow.MetaData = workflowMetaData
-	}
-	if errorMsg != "" {
-		response := model.Response{
-			Status: "-1",
-			Msg:    "Failed",
-			Data:   workflow,
-			Desc:   errorMsg,
-		}
-		c.JSON(http.StatusInternalServerError, response)
-	} else {
-		response := model.Response{
-			Status: "0",
-			Msg:    "Success",
-			Data:   workflow,
-			Desc:   "",
-		}
-		c.JSON(http.StatusOK, response)
+	response := model.Response{
+		Status: "0",
+		Msg:    "Success",
+		Data:   workflow,
+		Desc:   "",
 	}
+	c.JSON(http.StatusOK, response)
 }
 
 // @summary Get Workflow List
@@ -732,12 +570,8 @@ func GetWorkFlow(c *gin.Context) {
 func GetWorkFlowList(c *gin.Context) {
 	logger := config.GetLog()
 
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
 	startStr := c.DefaultQuery("start", "0")
 	start, err := strconv.Atoi(startStr)
 	if err != nil {
@@ -748,14 +582,9 @@ func GetWorkFlowList(c *gin.Context) {
 	if err != nil || length > 100 {
 		length = 1000
 	}
-	orgId := GetVariableFromToken(c, "orgId")
-	query := `SELECT id, "orgId", "wfId", title, "desc", active, publish, locks, versions, "createdAt", "updatedAt", "createdBy", "updatedBy"
-	FROM public.wf_definitions WHERE "orgId"=$1 LIMIT $2 OFFSET $3`
-
-	var rows pgx.Rows
-	logger.Debug(`Query`, zap.String("query", query),
-		zap.Any("Input", []any{orgId, length, start}))
-	rows, err = conn.Query(ctx, query, orgId, length, start)
+	orgId := tokenString(c, "orgId")
+	logger.Debug(`List workflows`, zap.Any("Input", []any{orgId, length, start}))
+	Data, err := repo.Workflows.List(ctx, orgId, length, start)
 	if err != nil {
 		logger.Warn("Query failed", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, model.Response{
@@ -765,30 +594,8 @@ func GetWorkFlowList(c *gin.Context) {
 		})
 		return
 	}
-	defer rows.Close()
-	var Workflow model.WorkflowModel
-	var Data []model.WorkflowModel
-	found := false
-	for rows.Next() {
-		err := rows.Scan(&Workflow.ID, &Workflow.OrgID, &Workflow.WfID, &Workflow.Title, &Workflow.Desc, &Workflow.Active,
-			&Workflow.Publish, &Workflow.Locks, &Workflow.Versions, &Workflow.CreatedAt, &Workflow.UpdatedAt, &Workflow.CreatedBy, &Workflow.UpdatedBy)
-		if err != nil {
-			logger.Warn("Scan failed", zap.Error(err))
-			response := model.Response{
-				Status: "-1",
-				Msg:    "Failed",
-				Data:   nil,
-				Desc:   err.Error(),
-			}
-			c.JSON(http.StatusInternalServerError, response)
-			return
-		}
-		// Store metadata (assuming same metadata per workflowId)
-		Data = append(Data, Workflow)
-		found = true
-	}
 
-	if !found {
+	if len(Data) == 0 {
 		response := model.Response{
 			Status: "-1",
 			Msg:    "Failed",
@@ -808,137 +615,6 @@ func GetWorkFlowList(c *gin.Context) {
 
 }
 
-func GetWorkFlowListOld(c *gin.Context) {
-	logger := config.GetLog()
-
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
-	defer cancel()
-	defer conn.Close(ctx)
-	startStr := c.DefaultQuery("start", "0")
-	start, err := strconv.Atoi(startStr)
-	if err != nil {
-		start = 0
-	}
-	lengthStr := c.DefaultQuery("length", "1000")
-	length, err := strconv.Atoi(lengthStr)
-	if err != nil || length > 100 {
-		length = 1000
-	}
-	orgId := GetVariableFromToken(c, "orgId")
-	query := `SELECT t1."id",t1."wfId","section","data",title,"desc",t1."versions",t1."createdAt",t1."updatedAt" 
-	FROM public.wf_definitions t1
-	Inner join public.wf_nodes t2
-	ON t1."wfId" = t2."wfId" WHERE t2."orgId"=$1 LIMIT $2 OFFSET $3`
-
-	var rows pgx.Rows
-	logger.Debug(`Query`, zap.String("query", query),
-		zap.Any("Input", []any{orgId, length, start}))
-	rows, err = conn.Query(ctx, query, orgId, length, start)
-	if err != nil {
-		logger.Warn("Query failed", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, model.Response{
-			Status: "-1",
-			Msg:    "Failure",
-			Desc:   err.Error(),
-		})
-		return
-	}
-	defer rows.Close()
-	var errorMsg string
-	var WfId string
-	var workflowList []model.WorkFlow
-	var workflowMetaData model.WorkFlowMetadata
-	rowIndex := 0
-
-	// Temporary maps to store nodes/connections grouped by WfId
-	workflowNodesMap := make(map[string][]map[string]interface{})
-	workflowConnectionsMap := make(map[string][]map[string]interface{})
-	workflowMetaMap := make(map[string]model.WorkFlowMetadata)
-	found := false
-	for rows.Next() {
-		rowIndex++
-		var rawJSON []byte
-		var rowsType string
-		err := rows.Scan(&workflowMetaData.Id, &WfId, &rowsType, &rawJSON, &workflowMetaData.Title, &workflowMetaData.Desc,
-			&workflowMetaData.Status, &workflowMetaData.CreatedAt, &workflowMetaData.UpdatedAt)
-		if err != nil {
-			logger.Warn("Scan failed", zap.Error(err))
-			response := model.Response{
-				Status: "-1",
-				Msg:    "Failed",
-				Data:   nil,
-				Desc:   errorMsg,
-			}
-			c.JSON(http.StatusInternalServerError, response)
-			return
-		}
-		switch rowsType {
-		case "nodes":
-			field, err := unmarshalToMap(rawJSON)
-			if err != nil {
-				logger.Warn("Unmarshal nodes failed", zap.Error(err))
-				continue
-			}
-			workflowNodesMap[WfId] = append(workflowNodesMap[WfId], field)
-		case "connections":
-			fields, err := unmarshalToSliceOfMaps(rawJSON)
-			if err != nil {
-				logger.Warn("Unmarshal connections failed", zap.Error(err))
-				continue
-			}
-			workflowConnectionsMap[WfId] = append(workflowConnectionsMap[WfId], fields...)
-		default:
-			logger.Warn("Unknown rowsType", zap.String("rowsType", rowsType))
-			continue
-		}
-
-		// Store metadata (assuming same metadata per workflowId)
-		workflowMetaMap[WfId] = workflowMetaData
-		found = true
-	}
-
-	if !found {
-		response := model.Response{
-			Status: "-1",
-			Msg:    "Failed",
-			Desc:   "Not found",
-		}
-		c.JSON(http.StatusInternalServerError, response)
-		return
-	}
-	uniqueWfIDs := make(map[string]bool)
-	for id := range workflowNodesMap {
-		uniqueWfIDs[id] = true
-	}
-	for id := range workflowConnectionsMap {
-		uniqueWfIDs[id] = true
-	}
-	for id := range workflowMetaMap {
-		uniqueWfIDs[id] = true
-	}
-	for wfId := range uniqueWfIDs {
-		workflow := model.WorkFlow{
-			// Nodes:       workflowNodesMap[wfId],
-			// Connections: workflowConnectionsMap[wfId],
-			MetaData: workflowMetaMap[wfId],
-		}
-		workflowList = append(workflowList, workflow)
-
-	}
-
-	response := model.Response{
-		Status: "0",
-		Msg:    "Success",
-		Data:   workflowList,
-		Desc:   "",
-	}
-	c.JSON(http.StatusOK, response)
-
-}
-
 // @summary Create Workflow
 // @tags Form and Workflow
 // @security ApiKeyAuth
@@ -951,12 +627,8 @@ func GetWorkFlowListOld(c *gin.Context) {
 func WorkFlowInsert(c *gin.Context) {
 	logger := config.GetLog()
 
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
 	var req model.WorkFlowInsert
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, model.Response{
@@ -967,92 +639,18 @@ func WorkFlowInsert(c *gin.Context) {
 		logger.Warn("Insert failed", zap.Error(err))
 		return
 	}
-	username := GetVariableFromToken(c, "username")
-	uuid := uuid.New()
-	orgId := GetVariableFromToken(c, "orgId")
-	now := time.Now()
-	query := `INSERT INTO public.wf_definitions(
-	"orgId", "wfId", title, "desc", active, publish, locks, versions, "createdAt", "updatedAt", "createdBy", "updatedBy")
-	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`
-
-	logger.Debug(`Query`, zap.String("query", query), zap.Any("req", req))
-	_, err := conn.Exec(ctx, query, orgId, uuid, req.MetaData.Title, req.MetaData.Desc,
-		true, true, true, "draft", now, now, username, username)
-	if err != nil {
-		logger.Warn("Query failed", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, model.Response{
-			Status: "-1",
-			Msg:    "Failure",
-			Desc:   err.Error(),
-		})
-		return
-	}
-
-	for i, item := range req.Nodes {
-		now = time.Now()
-		logger.Debug("eleNumber", zap.Int("i", i+1))
-		logger.Debug("JsonArray", zap.Any("Json", item))
-		var pic, group, formID interface{}
-		if item.Data != nil && item.Data.Config != nil {
-			config := *item.Data.Config
-
-			if val, ok := config["pic"].(string); ok {
-				pic = val
-			}
-			if val, ok := config["group"].(string); ok {
-				group = val
-			}
-			if val, ok := config["formId"].(string); ok {
-				formID = val
-			}
-		}
-
-		query := `
-		INSERT INTO public.wf_nodes(
-	"orgId", "wfId", "nodeId", versions, type, section, data,pic,"group","formId", "createdAt", "updatedAt", "createdBy", "updatedBy")
-	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14);
-		`
-		logger.Debug(`Query`, zap.String("query", query),
-			zap.Any("Input", []any{
-				req, item,
-			}))
-
-		_, err := conn.Exec(ctx, query,
-			orgId, uuid, item.Id, "draft", item.Type, "nodes", item, pic, group, formID, now, now, username, username)
-
-		if err != nil {
-			// log.Printf("Insert failed: %v", err)
-			c.JSON(http.StatusInternalServerError, model.Response{
-				Status: "-1",
-				Msg:    "Failure",
-				Desc:   err.Error(),
-			})
-			logger.Warn("Insert failed", zap.Error(err))
-			return
-		}
-	}
-
-	query = `
-		INSERT INTO public.wf_nodes(
-	"orgId", "wfId", "nodeId", versions, type, section, data, "createdAt", "updatedAt", "createdBy", "updatedBy")
-	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11);
-		`
-	logger.Debug(`Query`, zap.String("query", query),
-		zap.Any("Input", []any{
-			req, req.Connections,
-		}))
-
-	_, err = conn.Exec(ctx, query,
-		orgId, uuid, "", "draft", "", "connections", req.Connections, now, now, username, username)
+	username := tokenString(c, "username")
+	orgId := tokenString(c, "orgId")
 
+	logger.Debug(`Insert workflow`, zap.Any("req", req))
+	err := repo.Workflows.Create(ctx, orgId, uuid.New().String(), username, req)
 	if err != nil {
-		// log.Printf("Insert failed: %v", err)
+		logger.Warn("Insert failed", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, model.Response{
 			Status: "-1",
 			Msg:    "Failure",
 			Desc:   err.Error(),
 		})
-		logger.Warn("Insert failed", zap.Error(err))
 		return
 	}
 
@@ -1078,12 +676,8 @@ func WorkFlowInsert(c *gin.Context) {
 func WorkFlowUpdate(c *gin.Context) {
 	logger := config.GetLog()
 
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
 	var req model.WorkFlowInsert
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, model.Response{
@@ -1095,19 +689,13 @@ func WorkFlowUpdate(c *gin.Context) {
 		return
 	}
 	uuid := c.Param("uuid")
-	username := GetVariableFromToken(c, "username")
-	orgId := GetVariableFromToken(c, "orgId")
-	now := time.Now()
-	query := `UPDATE public.wf_definitions
-	SET title=$3, "desc"=$4, active=$5, publish=$6, locks=$7, versions=$8, "updatedAt"=$9,"updatedBy"=$10
-		WHERE "wfId"=$1 AND "orgId"=$2;`
-
-	logger.Debug(`Query`, zap.String("query", query), zap.Any("req", req))
-	_, err := conn.Exec(ctx, query,
-		uuid, orgId, req.MetaData.Title, req.MetaData.Desc,
-		true, true, true, "draft", now, username)
+	username := tokenString(c, "username")
+	orgId := tokenString(c, "orgId")
+
+	logger.Debug(`Update workflow`, zap.String("wfId", uuid), zap.Any("req", req))
+	err := repo.Workflows.Update(ctx, orgId, uuid, username, req)
 	if err != nil {
-		logger.Warn("Query failed", zap.Error(err))
+		logger.Warn("Update failed", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, model.Response{
 			Status: "-1",
 			Msg:    "Failure",
@@ -1116,151 +704,218 @@ func WorkFlowUpdate(c *gin.Context) {
 		return
 	}
 
-	query = `
-	DELETE FROM public.wf_nodes
-	WHERE "wfId"=$1;
-	`
-	_, err = conn.Exec(ctx, query, uuid)
+	response := model.Response{
+		Status: "0",
+		Msg:    "Success",
+		Desc:   "Update successfully",
+	}
+	c.JSON(http.StatusOK, response)
+
+}
 
+// @summary Delete Workflow
+// @tags Form and Workflow
+// @security ApiKeyAuth
+// @id Delete Workflow
+// @accept json
+// @produce json
+// @Param uuid path string true "uuid"
+// @response 200 {object} model.Response "OK - Request successful"
+// @Router /api/v1/workflows/{uuid} [delete]
+func WorkflowDelete(c *gin.Context) {
+	logger := config.GetLog()
+	repo, ctx, cancel := getStore(c.Request.Context())
+	defer cancel()
+	id := c.Param("uuid")
+	orgId := tokenString(c, "orgId")
+	logger.Debug("Delete workflow", zap.Any("id", id))
+	err := repo.Workflows.Delete(ctx, orgId, id)
 	if err != nil {
-		// log.Printf("Insert failed: %v", err)
 		c.JSON(http.StatusInternalServerError, model.Response{
 			Status: "-1",
 			Msg:    "Failure",
 			Desc:   err.Error(),
 		})
-		logger.Warn("Insert failed", zap.Error(err))
+		logger.Warn("Update failed", zap.Error(err))
 		return
 	}
 
-	for i, item := range req.Nodes {
-		now = time.Now()
-		logger.Debug("eleNumber", zap.Int("i", i+1))
-		logger.Debug("JsonArray", zap.Any("Json", item))
-		var pic, group, formID interface{}
-		if item.Data != nil && item.Data.Config != nil {
-			config := *item.Data.Config
-
-			if val, ok := config["pic"].(string); ok {
-				pic = val
-			}
-			if val, ok := config["group"].(string); ok {
-				group = val
-			}
-			if val, ok := config["formId"].(string); ok {
-				formID = val
-			}
-		}
-
-		query := `
-		INSERT INTO public.wf_nodes(
-	"orgId", "wfId", "nodeId", versions, type, section, data,pic,"group","formId", "createdAt", "updatedAt", "createdBy", "updatedBy")
-	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14);
-		`
-		logger.Debug(`Query`, zap.String("query", query),
-			zap.Any("Input", []any{
-				req, item,
-			}))
+	// Continue logic...
+	c.JSON(http.StatusOK, model.Response{
+		Status: "0",
+		Msg:    "Success",
+		Desc:   "Delete successfully",
+	})
+}
 
-		_, err := conn.Exec(ctx, query,
-			orgId, uuid, item.Id, "draft", item.Type, "nodes", item, pic, group, formID, now, now, username, username)
+// @summary Publish Workflow
+// @description Validates the draft and freezes it into the next numbered version. New cases start on it; running cases keep the version they started on. A draft with validation errors is refused with the report in data.
+// @tags Form and Workflow
+// @security ApiKeyAuth
+// @id Publish Workflow
+// @accept json
+// @produce json
+// @Param uuid path string true "uuid"
+// @response 200 {object} model.Response "OK - Request successful"
+// @Router /api/v1/workflows/{uuid}/publish [post]
+func WorkflowPublish(c *gin.Context) {
+	logger := config.GetLog()
+	repo, ctx, cancel := getStore(c.Request.Context())
+	defer cancel()
+	id := c.Param("uuid")
+	username := tokenString(c, "username")
+	orgId := tokenString(c, "orgId")
 
-		if err != nil {
-			// log.Printf("Insert failed: %v", err)
-			c.JSON(http.StatusInternalServerError, model.Response{
-				Status: "-1",
-				Msg:    "Failure",
-				Desc:   err.Error(),
-			})
-			logger.Warn("Insert failed", zap.Error(err))
-			return
+	var versions string
+	var report model.WorkflowValidation
+	err := repo.InTx(ctx, func(tx *store.Store) error {
+		var err error
+		versions, report, err = workflow.Publish(ctx, tx, orgId, id, username)
+		return err
+	})
+	if err != nil {
+		status := http.StatusInternalServerError
+		var data interface{}
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, workflow.ErrInvalid):
+			status, data = http.StatusBadRequest, report
 		}
+		c.JSON(status, model.Response{
+			Status: "-1",
+			Msg:    "Failure",
+			Data:   data,
+			Desc:   err.Error(),
+		})
+		logger.Warn("Publish failed", zap.Error(err))
+		return
 	}
 
-	query = `
-		INSERT INTO public.wf_nodes(
-	"orgId", "wfId", "nodeId", versions, type, section, data, "createdAt", "updatedAt", "createdBy", "updatedBy")
-	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11);
-		`
-	logger.Debug(`Query`, zap.String("query", query),
-		zap.Any("Input", []any{
-			req, req.Connections,
-		}))
+	c.JSON(http.StatusOK, model.Response{
+		Status: "0",
+		Msg:    "Success",
+		Data:   gin.H{"wfId": id, "versions": versions, "warnings": report.Warnings},
+		Desc:   "Publish successfully",
+	})
+}
 
-	_, err = conn.Exec(ctx, query,
-		orgId, uuid, "", "draft", "", "connections", req.Connections, now, now, username, username)
+// @summary Validate Workflow
+// @description Checks a workflow graph without saving it: edges must join existing nodes, there must be one start node, every node must be reachable and lead to an end node, and referenced forms must exist.
+// @tags Form and Workflow
+// @security ApiKeyAuth
+// @id Validate Workflow
+// @accept json
+// @produce json
+// @param Body body model.WorkFlowInsert true "Workflow to check"
+// @response 200 {object} model.Response{data=model.WorkflowValidation} "OK - Request successful"
+// @Router /api/v1/workflows/validate [post]
+func WorkflowValidate(c *gin.Context) {
+	logger := config.GetLog()
+	repo, ctx, cancel := getStore(c.Request.Context())
+	defer cancel()
 
+	var req model.WorkFlowInsert
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, model.Response{
+			Status: "-1",
+			Msg:    "Failure",
+			Desc:   err.Error(),
+		})
+		logger.Warn("Validate failed", zap.Error(err))
+		return
+	}
+	orgId := tokenString(c, "orgId")
+
+	report, err := workflow.Check(ctx, repo, orgId, req)
 	if err != nil {
-		// log.Printf("Insert failed: %v", err)
 		c.JSON(http.StatusInternalServerError, model.Response{
 			Status: "-1",
 			Msg:    "Failure",
 			Desc:   err.Error(),
 		})
-		logger.Warn("Insert failed", zap.Error(err))
+		logger.Warn("Validate failed", zap.Error(err))
 		return
 	}
-
-	response := model.Response{
+	c.JSON(http.StatusOK, model.Response{
 		Status: "0",
 		Msg:    "Success",
-		Desc:   "Update successfully",
-	}
-	c.JSON(http.StatusOK, response)
-
+		Data:   report,
+	})
 }
 
-// @summary Delete Workflow
+// @summary Get Workflow Versions
 // @tags Form and Workflow
 // @security ApiKeyAuth
-// @id Delete Workflow
+// @id Get Workflow Versions
 // @accept json
 // @produce json
-// @Param uuid path string true "uuid"
-// @response 200 {object} model.Response "OK - Request successful"
-// @Router /api/v1/workflows/{uuid} [delete]
-func WorkflowDelete(c *gin.Context) {
+// @Param id path string true "id"
+// @response 200 {object} model.Response{data=[]model.WorkflowVersion} "OK - Request successful"
+// @Router /api/v1/workflows/{id}/versions [get]
+func GetWorkflowVersions(c *gin.Context) {
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
-	id := c.Param("uuid")
-	orgId := GetVariableFromToken(c, "orgId")
-	query := `DELETE FROM public."wf_definitions" WHERE "wfId" = $1 AND "orgId"=$2`
-	logger.Debug("Query", zap.String("query", query), zap.Any("id", id))
-	_, err := conn.Exec(ctx, query, id, orgId)
+	id := c.Param("id")
+	orgId := tokenString(c, "orgId")
+
+	list, err := repo.Workflows.ListVersions(ctx, orgId, id)
 	if err != nil {
-		// log.Printf("Insert failed: %v", err)
+		logger.Warn("Query failed", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, model.Response{
 			Status: "-1",
 			Msg:    "Failure",
 			Desc:   err.Error(),
 		})
-		logger.Warn("Update failed", zap.Error(err))
 		return
 	}
-	query = `DELETE FROM public."wf_nodes" WHERE "wfId" = $1 AND "orgId"=$2`
-	logger.Debug("Query", zap.String("query", query), zap.Any("id", id))
-	_, err = conn.Exec(ctx, query, id, orgId)
+	c.JSON(http.StatusOK, model.Response{
+		Status: "0",
+		Msg:    "Success",
+		Data:   list,
+	})
+}
+
+// @summary Rollback Workflow Draft
+// @description Replaces the draft with a copy of a published version. Published versions are not changed.
+// @tags Form and Workflow
+// @security ApiKeyAuth
+// @id Rollback Workflow Draft
+// @accept json
+// @produce json
+// @Param uuid path string true "uuid"
+// @Param versions path string true "published version"
+// @response 200 {object} model.Response "OK - Request successful"
+// @Router /api/v1/workflows/{uuid}/rollback/{versions} [post]
+func WorkflowRollback(c *gin.Context) {
+	logger := config.GetLog()
+	repo, ctx, cancel := getStore(c.Request.Context())
+	defer cancel()
+	id := c.Param("uuid")
+	versions := c.Param("versions")
+	username := tokenString(c, "username")
+	orgId := tokenString(c, "orgId")
+
+	err := repo.Workflows.Rollback(ctx, orgId, id, versions, username)
 	if err != nil {
-		// log.Printf("Insert failed: %v", err)
-		c.JSON(http.StatusInternalServerError, model.Response{
+		status := http.StatusInternalServerError
+		if errors.Is(err, store.ErrNotFound) {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, model.Response{
 			Status: "-1",
 			Msg:    "Failure",
 			Desc:   err.Error(),
 		})
-		logger.Warn("Update failed", zap.Error(err))
+		logger.Warn("Rollback failed", zap.Error(err))
 		return
 	}
 
-	// Continue logic...
 	c.JSON(http.StatusOK, model.Response{
 		Status: "0",
 		Msg:    "Success",
-		Desc:   "Delete successfully",
+		Desc:   "Rollback successfully",
 	})
 }
 
@@ -1275,12 +930,8 @@ func WorkflowDelete(c *gin.Context) {
 // @Router /api/v1/forms/casesubtype [post]
 func GetFormByCaseSubType(c *gin.Context) {
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
 
 	var req model.FormByCasesubtype
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -1292,15 +943,13 @@ func GetFormByCaseSubType(c *gin.Context) {
 		logger.Warn("Insert failed", zap.Error(err))
 		return
 	}
-	var wfId string
-	orgId := GetVariableFromToken(c, "orgId")
-	// username := GetVariableFromToken(c, "username")
-	query := `SELECT "wfId" FROM public.case_sub_types WHERE "orgId"=$1 AND "sTypeId"=$2`
-	logger.Debug(`Query`, zap.String("query", query),
-		zap.Any("Input", []any{
-			orgId, req.CaseSubType,
-		}))
-	err := conn.QueryRow(ctx, query, orgId, req.CaseSubType).Scan(&wfId)
+	orgId := tokenString(c, "orgId")
+	var sTypeId string
+	if req.CaseSubType != nil {
+		sTypeId = *req.CaseSubType
+	}
+	logger.Debug(`Form by case sub type`, zap.Any("Input", []any{orgId, sTypeId}))
+	wfId, err := repo.Workflows.GetIDByCaseSubType(ctx, orgId, sTypeId)
 	if err != nil {
 		logger.Warn("Query failed", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, model.Response{
@@ -1311,24 +960,7 @@ func GetFormByCaseSubType(c *gin.Context) {
 		return
 	}
 
-	query = `SELECT 
-    t2."section",
-    t2."data",
-    t2."nodeId",
-    t1."versions"
-FROM public.wf_definitions t1
-INNER JOIN public.wf_nodes t2
-    ON t1."wfId" = t2."wfId" AND t1."versions" = t2."versions"
-WHERE t1."wfId" = $1
-  AND t1."orgId" = $2
-  AND LOWER(t2."type") = 'process'  
-  AND t2."data"->'data'->'config'->>'action' = 'S001'`
-	logger.Debug(`Query`, zap.String("query", query), zap.Any("Input", []any{
-		wfId, orgId,
-	}))
-
-	var rows pgx.Rows
-	rows, err = conn.Query(ctx, query, wfId, orgId)
+	nodes, err := repo.Workflows.ListProcessNodes(ctx, orgId, wfId)
 	if err != nil {
 		logger.Warn("Query failed", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, model.Response{
@@ -1338,40 +970,18 @@ WHERE t1."wfId" = $1
 		})
 		return
 	}
-	defer rows.Close()
 	var formId string
 	var nodeId string
 	var versions string
-	for rows.Next() {
-		var rawJSON []byte
-		var rowsType string
-		var tempNodeId, tempVersions string
-
-		err := rows.Scan(&rowsType, &rawJSON, &tempNodeId, &tempVersions)
-		if err != nil {
-			logger.Warn("Scan failed", zap.Error(err))
-			continue
-		}
-
-		// Only handle nodes
-		if rowsType != "nodes" {
-			continue
-		}
-
-		var nodeMap map[string]interface{}
-		if err := json.Unmarshal(rawJSON, &nodeMap); err != nil {
-			logger.Warn("Unmarshal failed", zap.Error(err))
-			continue
-		}
-
+	for _, node := range nodes {
 		// Access data -> config -> formId if action = S002
-		if data, ok := nodeMap["data"].(map[string]interface{}); ok {
+		if data, ok := node.Data["data"].(map[string]interface{}); ok {
 			if config, ok := data["config"].(map[string]interface{}); ok {
 				if action, ok := config["action"].(string); ok && action == "S002" {
 					if formVal, ok := config["formId"].(string); ok {
 						formId = formVal
-						nodeId = tempNodeId
-						versions = tempVersions
+						nodeId = node.NodeID
+						versions = node.Versions
 						break // found, exit loop
 					}
 				}
@@ -1379,61 +989,13 @@ WHERE t1."wfId" = $1
 		}
 	}
 	logger.Debug(formId)
-	rows.Close()
-	query = `SELECT t1."formId",t1."formName",t1."formColSpan",t2."eleData" 
-	FROM public.form_builder t1
-	INNER JOIN public.form_elements t2
-	ON t1."formId"=t2."formId" AND t2."versions"=t1."versions"
-	WHERE t1."formId" = $1 AND t1."orgId"=$2 `
-
-	logger.Debug(`Query`, zap.String("query", query), zap.Any("Input", []any{
-		formId, orgId,
-	}))
-	rows, err = conn.Query(ctx, query, formId, orgId)
-	if err != nil {
-		logger.Warn("Query failed", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, model.Response{
-			Status: "-1",
-			Msg:    "Failure",
-			Desc:   err.Error(),
-		})
-		return
-	}
-	defer rows.Close()
-	var formFields []map[string]interface{}
-	var form model.FormByCasesubtypeOpt
-	found := false
-	for rows.Next() {
-		var rawJSON []byte
-		err := rows.Scan(&form.FormId, &form.FormName, &form.FormColSpan, &rawJSON)
-		if err != nil {
-			logger.Warn("Scan failed", zap.Error(err))
-			response := model.Response{
-				Status: "-1",
-				Msg:    "Failed",
-				Desc:   err.Error(),
-			}
-			c.JSON(http.StatusOK, response)
-			return
-		}
 
-		var field map[string]interface{}
-		if err := json.Unmarshal(rawJSON, &field); err != nil {
-			logger.Warn("unmarshal field failed", zap.Error(err))
-			response := model.Response{
-				Status: "-1",
-				Msg:    "Failed",
-				Desc:   err.Error(),
-			}
-			c.JSON(http.StatusOK, response)
-			return
-		}
-
-		formFields = append(formFields, field)
-		form.FormFieldJson = formFields
-		found = true
+	current, err := repo.Forms.GetCurrent(ctx, orgId, formId)
+	if err == nil && len(current.FormFieldJson) == 0 {
+		err = store.ErrNotFound
 	}
-	if !found {
+	if err != nil {
+		logger.Warn("Query failed", zap.Error(err))
 		response := model.Response{
 			Status: "-1",
 			Msg:    "Failed",
@@ -1442,9 +1004,15 @@ WHERE t1."wfId" = $1
 		c.JSON(http.StatusInternalServerError, response)
 		return
 	}
-	form.NextNodeId = &nodeId
-	form.WfVersions = &versions
-	form.WfId = &wfId
+	form := model.FormByCasesubtypeOpt{
+		NextNodeId:    &nodeId,
+		WfVersions:    &versions,
+		WfId:          &wfId,
+		FormId:        current.FormId,
+		FormName:      current.FormName,
+		FormColSpan:   current.FormColSpan,
+		FormFieldJson: current.FormFieldJson,
+	}
 	response := model.Response{
 		Status: "0",
 		Msg:    "Success",
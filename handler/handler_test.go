@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"mainPackage/model"
+	"mainPackage/store"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+const testOrg = "o1"
+
+func TestMain(m *testing.M) {
+	gin.SetMode(gin.TestMode)
+	os.Setenv("TOKEN_SECRET_KEY", "test-access-key")
+	os.Setenv("REFRESH_TOKEN_KEY", "test-refresh-key")
+	os.Setenv("TOKEN_TIMEOUT", "60")
+	os.Setenv("REFRESH_TOKEN_TIMEOUT", "60")
+	os.Exit(m.Run())
+}
+
+// newTestStore installs an in-memory store with one organization and an
+// active user "alice" whose role grants perms.
+func newTestStore(t *testing.T, perms ...string) *store.Memory {
+	t.Helper()
+	m := store.NewMemory()
+	m.AddOrganization(testOrg, "Org")
+	m.AddRole(testOrg, "r1", "Operator", perms...)
+	user := model.UserWrite{OrgID: testOrg, Username: "alice", EmpID: "E1", RoleID: "r1", Active: true}
+	if _, err := m.Users.Create(context.Background(), user, "admin"); err != nil {
+		t.Fatal(err)
+	}
+	SetStore(m.Store)
+	invalidatePermissions(testOrg, "")
+	t.Cleanup(func() { SetStore(nil) })
+	return m
+}
+
+// newRouter returns a router whose routes run behind ProtectedHandler, as
+// the /api/v1 group does.
+func newRouter() *gin.Engine {
+	r := gin.New()
+	r.POST("/auth/refresh", RefreshToken)
+	v1 := r.Group("/api/v1", ProtectedHandler)
+	v1.POST("/case/add", InsertCase)
+	v1.POST("/case/:id/status", ChangeCaseStatus)
+	v1.POST("/case/:id/forms/:formId/submissions", SubmitCaseForm)
+	v1.GET("/notifications/me", GetMyNotifications)
+	v1.GET("/notifications/unread_count", GetUnreadNotificationCount)
+	v1.POST("/notifications/read", MarkNotificationsRead)
+	return r
+}
+
+// accessToken signs an access token for alice.
+func accessToken(t *testing.T) string {
+	t.Helper()
+	tok, _, _, err := CreateToken("alice", testOrg, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tok
+}
+
+// do sends body as JSON, with token as the bearer token unless it is empty,
+// and decodes the response into out unless it is nil.
+func do(t *testing.T, r http.Handler, method, path, token string, body, out any) int {
+	t.Helper()
+	var buf bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&buf).Encode(body); err != nil {
+			t.Fatal(err)
+		}
+	}
+	req := httptest.NewRequest(method, path, &buf)
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if out != nil {
+		if err := json.Unmarshal(w.Body.Bytes(), out); err != nil {
+			t.Fatalf("%s %s: decoding %q: %v", method, path, w.Body.String(), err)
+		}
+	}
+	return w.Code
+}
+
+func TestProtectedHandlerRejectsMissingToken(t *testing.T) {
+	newTestStore(t)
+	r := newRouter()
+	if code := do(t, r, http.MethodGet, "/api/v1/notifications/unread_count", "", nil, nil); code != http.StatusUnauthorized {
+		t.Errorf("without a token: %d, want 401", code)
+	}
+	if code := do(t, r, http.MethodGet, "/api/v1/notifications/unread_count", "junk", nil, nil); code != http.StatusUnauthorized {
+		t.Errorf("with a bad token: %d, want 401", code)
+	}
+}
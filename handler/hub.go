@@ -0,0 +1,234 @@
+package handler
+
+import (
+	"context"
+	"log"
+	"mainPackage/broker"
+	"mainPackage/model"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// wsSendQueue is how many messages may wait for one socket; a client
+	// that falls this far behind is disconnected rather than waited on.
+	wsSendQueue = 64
+	// wsWriteWait bounds a single write, including pings and close frames.
+	wsWriteWait = 10 * time.Second
+	// wsPongWait is how long a socket may stay silent before it is
+	// considered dead; pings go out at 9/10 of it.
+	wsPongWait   = 60 * time.Second
+	wsPingPeriod = wsPongWait * 9 / 10
+	// wsMaxMessage caps frames read from clients, which only send auth
+	// frames.
+	wsMaxMessage = 16 * 1024
+	// wsCloseSlowConsumer is the close code sent to a client whose send
+	// queue overflowed.
+	wsCloseSlowConsumer = 4008
+)
+
+// wsClient is one registered socket. Only its writer goroutine writes to
+// conn, so nothing else has to hold a lock around a network write.
+type wsClient struct {
+	info *model.UserConnectionInfo
+	conn *websocket.Conn
+	send chan interface{}
+
+	done      chan struct{}
+	closeOnce sync.Once
+	closeCode int
+	closeText string
+}
+
+// wsHub holds the open sockets of this instance, keyed by session id.
+type wsHub struct {
+	mu      sync.RWMutex
+	clients map[string]*wsClient
+
+	sent        atomic.Int64
+	dropped     atomic.Int64
+	slowClosed  atomic.Int64
+	writeFailed atomic.Int64
+}
+
+var hub = &wsHub{clients: make(map[string]*wsClient)}
+
+// register adds a socket to the hub and starts its writer.
+func (h *wsHub) register(info *model.UserConnectionInfo, conn *websocket.Conn) *wsClient {
+	cl := &wsClient{
+		info: info,
+		conn: conn,
+		send: make(chan interface{}, wsSendQueue),
+		done: make(chan struct{}),
+	}
+	h.mu.Lock()
+	h.clients[info.SessionID] = cl
+	h.mu.Unlock()
+	go cl.writePump(h)
+	return cl
+}
+
+// unregister removes a socket from the hub and stops its writer.
+func (h *wsHub) unregister(cl *wsClient) {
+	h.mu.Lock()
+	delete(h.clients, cl.info.SessionID)
+	h.mu.Unlock()
+	cl.close(websocket.CloseNormalClosure, "")
+}
+
+// snapshot returns the registered clients so callers can match and enqueue
+// without holding the hub lock.
+func (h *wsHub) snapshot() []*wsClient {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	list := make([]*wsClient, 0, len(h.clients))
+	for _, cl := range h.clients {
+		list = append(list, cl)
+	}
+	return list
+}
+
+// enqueue hands v to the client's writer without blocking. A full queue
+// means the client cannot keep up, so it is disconnected and v is dropped.
+func (h *wsHub) enqueue(cl *wsClient, v interface{}) bool {
+	select {
+	case <-cl.done:
+		return false
+	default:
+	}
+	select {
+	case cl.send <- v:
+		return true
+	default:
+		h.dropped.Add(1)
+		h.slowClosed.Add(1)
+		log.Printf("Dropping slow consumer: EmpID=%s SessionID=%s", cl.info.ID, cl.info.SessionID)
+		cl.close(wsCloseSlowConsumer, "send queue full")
+		return false
+	}
+}
+
+// close stops the writer, which sends a close frame with code and drops the
+// connection; the handler's read loop then ends and unregisters the client.
+func (cl *wsClient) close(code int, reason string) {
+	cl.closeOnce.Do(func() {
+		cl.closeCode, cl.closeText = code, reason
+		close(cl.done)
+	})
+}
+
+func (cl *wsClient) writePump(h *wsHub) {
+	ticker := time.NewTicker(wsPingPeriod)
+	defer func() {
+		ticker.Stop()
+		_ = cl.conn.Close()
+	}()
+	for {
+		select {
+		case v := <-cl.send:
+			_ = cl.conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+			if err := cl.conn.WriteJSON(v); err != nil {
+				h.writeFailed.Add(1)
+				log.Printf("    ❌ Failed to send to EmpID %s SessionID %s: %v", cl.info.ID, cl.info.SessionID, err)
+				cl.close(websocket.CloseAbnormalClosure, "")
+				return
+			}
+			h.sent.Add(1)
+		case <-ticker.C:
+			if err := cl.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteWait)); err != nil {
+				cl.close(websocket.CloseAbnormalClosure, "")
+				return
+			}
+		case <-cl.done:
+			if cl.closeCode != websocket.CloseAbnormalClosure {
+				msg := websocket.FormatCloseMessage(cl.closeCode, cl.closeText)
+				_ = cl.conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(wsWriteWait))
+			}
+			return
+		}
+	}
+}
+
+// stats reports the hub's current size, queue depth and counters.
+func (h *wsHub) stats() model.WsHubStats {
+	st := model.WsHubStats{
+		Sent:               h.sent.Load(),
+		Dropped:            h.dropped.Load(),
+		SlowConsumerClosed: h.slowClosed.Load(),
+		WriteFailed:        h.writeFailed.Load(),
+		QueueCapacity:      wsSendQueue,
+	}
+	users := make(map[string]bool)
+	for _, cl := range h.snapshot() {
+		depth := len(cl.send)
+		st.Sessions++
+		st.QueueDepth += depth
+		if depth > st.MaxQueueDepth {
+			st.MaxQueueDepth = depth
+		}
+		users[cl.info.ID] = true
+	}
+	st.Users = len(users)
+	return st
+}
+
+// @summary Get WebSocket Hub Stats
+// @description Reports the notification sockets open on this instance, their send queue depth and the messages sent, dropped or failed since start.
+// @tags System
+// @security ApiKeyAuth
+// @id Get WebSocket Hub Stats
+// @accept json
+// @produce json
+// @response 200 {object} model.Response{data=model.WsHubStats} "OK - Request successful"
+// @Router /api/v1/system/ws_stats [get]
+func GetWsHubStats(c *gin.Context) {
+	c.JSON(http.StatusOK, model.Response{
+		Status: "0",
+		Msg:    "Success",
+		Data:   hub.stats(),
+	})
+}
+
+// notiBroker spreads committed notifications to every instance. The local
+// default only reaches this process; main swaps in the Postgres broker.
+var notiBroker broker.Broker = broker.NewLocal()
+
+// SetBroker sets the broker notifications are published through. Call it
+// before ListenNotifications.
+func SetBroker(b broker.Broker) {
+	notiBroker = b
+}
+
+// ListenNotifications delivers every published notification and unread
+// count change to the sockets of this instance until ctx is done.
+func ListenNotifications(ctx context.Context) {
+	err := notiBroker.Subscribe(ctx, func(m broker.Message) {
+		switch m.Kind {
+		case broker.KindNotification:
+			go deliverNotification(m.NotificationID)
+		case broker.KindUnread:
+			go pushUnreadCount(m.OrgID, m.Username)
+		}
+	})
+	if err != nil && ctx.Err() == nil {
+		log.Printf("ERROR: Notification listener stopped: %v", err)
+	}
+}
+
+// deliverNotification loads a published notification and broadcasts it to
+// the local sockets.
+func deliverNotification(id int64) {
+	repo, ctx, cancel := getStore(context.Background())
+	defer cancel()
+	noti, err := repo.Notifications.Get(ctx, id)
+	if err != nil {
+		log.Printf("ERROR: Failed to load published notification %d: %v", id, err)
+		return
+	}
+	BroadcastNotification(*noti)
+}
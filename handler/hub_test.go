@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"mainPackage/model"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// wsPair returns the server end of a socket and the client dialled to it.
+func wsPair(t *testing.T) (*websocket.Conn, *websocket.Conn) {
+	t.Helper()
+	conns := make(chan *websocket.Conn, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		conns <- conn
+	}))
+	t.Cleanup(srv.Close)
+	client, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = client.Close() })
+	return <-conns, client
+}
+
+func TestHubDropsSlowConsumer(t *testing.T) {
+	server, client := wsPair(t)
+	h := &wsHub{clients: make(map[string]*wsClient)}
+	// Registered by hand so no writer drains the queue until it is full.
+	cl := &wsClient{
+		info: &model.UserConnectionInfo{ID: "E1", SessionID: "s1"},
+		conn: server,
+		send: make(chan interface{}, wsSendQueue),
+		done: make(chan struct{}),
+	}
+	h.clients["s1"] = cl
+
+	for i := range wsSendQueue {
+		if !h.enqueue(cl, i) {
+			t.Fatalf("enqueue %d of %d refused", i+1, wsSendQueue)
+		}
+	}
+	if st := h.stats(); st.QueueDepth != wsSendQueue || st.MaxQueueDepth != wsSendQueue {
+		t.Errorf("stats of a full queue = %+v", st)
+	}
+	if h.enqueue(cl, "overflow") {
+		t.Fatal("enqueue past a full queue accepted")
+	}
+	if h.enqueue(cl, "after") {
+		t.Error("enqueue to a dropped client accepted")
+	}
+	if st := h.stats(); st.Dropped != 1 || st.SlowConsumerClosed != 1 {
+		t.Errorf("stats after the overflow = %+v, want 1 dropped and 1 closed", st)
+	}
+
+	go cl.writePump(h)
+	for {
+		var v interface{}
+		err := client.ReadJSON(&v)
+		if err == nil {
+			continue
+		}
+		var ce *websocket.CloseError
+		if !errors.As(err, &ce) || ce.Code != wsCloseSlowConsumer {
+			t.Errorf("client read %v, want close code %d", err, wsCloseSlowConsumer)
+		}
+		break
+	}
+}
+
+func TestSessionPresence(t *testing.T) {
+	m := newTestStore(t)
+	ctx := context.Background()
+	info, err := getUserProfileFromDB(ctx, m.Store, testOrg, "alice")
+	if err != nil || info.ID != "E1" {
+		t.Fatalf("profile = %+v, %v", info, err)
+	}
+	for _, sid := range []string{"s1", "s2"} {
+		info.SessionID = sid
+		if err := addUserSessionToDB(info); err != nil {
+			t.Fatal(err)
+		}
+	}
+	removeUserSessionFromDB(info)
+	if stale, _ := m.Sessions.ListStale(ctx, time.Hour); len(stale) != 0 {
+		t.Fatalf("stale = %v, want none while s1 is open", stale)
+	}
+	if _, err := m.Sessions.GetProfile(ctx, testOrg, "alice"); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := m.Sessions.Sweep(ctx, "E1", 0); err != nil || n != 1 {
+		t.Fatalf("swept %d, %v; want 1", n, err)
+	}
+	if stale, _ := m.Sessions.ListStale(ctx, 0); len(stale) != 0 {
+		t.Fatalf("stale = %v, want presence dropped with the last session", stale)
+	}
+}
@@ -5,11 +5,9 @@ import (
 	"mainPackage/model"
 	"net/http"
 	"strconv"
-	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
-	"github.com/jackc/pgx/v5"
 	"go.uber.org/zap"
 )
 
@@ -25,30 +23,11 @@ import (
 // @Router /api/v1/mdm/properties [get]
 func GetMmdProperty(c *gin.Context) {
 	logger := config.GetLog()
-
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
-	startStr := c.DefaultQuery("start", "0")
-	start, err := strconv.Atoi(startStr)
-	if err != nil {
-		start = 0
-	}
-	lengthStr := c.DefaultQuery("length", "1000")
-	length, err := strconv.Atoi(lengthStr)
-	if err != nil {
-		length = 1000
-	}
-	orgId := GetVariableFromToken(c, "orgId")
-	query := `SELECT  id, "propId", "orgId", en, th, active, "createdAt", "updatedAt", "createdBy", "updatedBy"
-	FROM public.mdm_properties WHERE "orgId"=$1 LIMIT $2 OFFSET $3`
+	start, length := pageQuery(c)
 
-	var rows pgx.Rows
-	logger.Debug(`Query`, zap.String("query", query))
-	rows, err = conn.Query(ctx, query, orgId, length, start)
+	list, err := repo.MasterData.ListProperties(ctx, tokenString(c, "orgId"), length, start)
 	if err != nil {
 		logger.Warn("Query failed", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, model.Response{
@@ -58,43 +37,11 @@ func GetMmdProperty(c *gin.Context) {
 		})
 		return
 	}
-	defer rows.Close()
-	var errorMsg string
-	var Property model.MmdProperty
-	var PropertyList []model.MmdProperty
-	rowIndex := 0
-	for rows.Next() {
-		rowIndex++
-		err := rows.Scan(&Property.ID, &Property.PropID, &Property.OrgID, &Property.EN, &Property.TH,
-			&Property.Active, &Property.CreatedAt, &Property.UpdatedAt, &Property.CreatedBy, &Property.UpdatedBy)
-		if err != nil {
-			logger.Warn("Scan failed", zap.Error(err))
-			response := model.Response{
-				Status: "-1",
-				Msg:    "Failed",
-				Desc:   errorMsg,
-			}
-			c.JSON(http.StatusInternalServerError, response)
-			return
-		}
-		PropertyList = append(PropertyList, Property)
-	}
-	if errorMsg != "" {
-		response := model.Response{
-			Status: "-1",
-			Msg:    "Failed",
-			Desc:   errorMsg,
-		}
-		c.JSON(http.StatusInternalServerError, response)
-	} else {
-		response := model.Response{
-			Status: "0",
-			Msg:    "Success",
-			Data:   PropertyList,
-			Desc:   "",
-		}
-		c.JSON(http.StatusOK, response)
-	}
+	c.JSON(http.StatusOK, model.Response{
+		Status: "0",
+		Msg:    "Success",
+		Data:   list,
+	})
 }
 
 // @summary Get Mmd Properties by Id
@@ -108,39 +55,24 @@ func GetMmdProperty(c *gin.Context) {
 // @Router /api/v1/mdm/properties/{id} [get]
 func GetMmdPropertyById(c *gin.Context) {
 	logger := config.GetLog()
-	id := c.Param("id")
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
-	orgId := GetVariableFromToken(c, "orgId")
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
-	query := `SELECT  id, "propId", "orgId", en, th, active, "createdAt", "updatedAt", "createdBy", "updatedBy"
-	FROM public.mdm_properties WHERE id=$1 AND "orgId"=$2`
-
-	var Property model.MmdProperty
-	logger.Debug(`Query`, zap.String("query", query))
-	err := conn.QueryRow(ctx, query, id, orgId).Scan(&Property.ID, &Property.PropID, &Property.OrgID, &Property.EN, &Property.TH,
-		&Property.Active, &Property.CreatedAt, &Property.UpdatedAt, &Property.CreatedBy, &Property.UpdatedBy)
+
+	property, err := repo.MasterData.GetProperty(ctx, tokenString(c, "orgId"), c.Param("id"))
 	if err != nil {
 		logger.Warn("Query failed", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, model.Response{
+		c.JSON(storeStatus(err), model.Response{
 			Status: "-1",
 			Msg:    "Failure",
 			Desc:   err.Error(),
 		})
 		return
 	}
-
-	response := model.Response{
+	c.JSON(http.StatusOK, model.Response{
 		Status: "0",
 		Msg:    "Success",
-		Data:   Property,
-		Desc:   "",
-	}
-	c.JSON(http.StatusOK, response)
-
+		Data:   property,
+	})
 }
 
 // @summary Create Mmd Properties
@@ -154,12 +86,7 @@ func GetMmdPropertyById(c *gin.Context) {
 // @Router /api/v1/mdm/properties/add [post]
 func InsertMmdProperty(c *gin.Context) {
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
-	defer cancel()
-	defer conn.Close(ctx)
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
 
 	var req model.MmdPropertyInsert
@@ -172,23 +99,10 @@ func InsertMmdProperty(c *gin.Context) {
 		logger.Warn("Insert failed", zap.Error(err))
 		return
 	}
-	username := GetVariableFromToken(c, "username")
-	now := time.Now()
-	var id int
-	orgId := GetVariableFromToken(c, "orgId")
-	uuid := uuid.New()
-	query := `
-	INSERT INTO public."mdm_properties"(
-	"propId", "orgId", en, th, active, "createdAt", "updatedAt", "createdBy", "updatedBy")
-	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
-	RETURNING id ;
-	`
-
-	err := conn.QueryRow(ctx, query,
-		uuid, orgId, req.EN, req.TH, req.Active, now, now, username, username).Scan(&id)
+	username := tokenString(c, "username")
+	orgId := tokenString(c, "orgId")
 
-	if err != nil {
-		// log.Printf("Insert failed: %v", err)
+	if err := repo.MasterData.CreateProperty(ctx, orgId, uuid.NewString(), username, req); err != nil {
 		c.JSON(http.StatusInternalServerError, model.Response{
 			Status: "-1",
 			Msg:    "Failure",
@@ -197,14 +111,11 @@ func InsertMmdProperty(c *gin.Context) {
 		logger.Warn("Insert failed", zap.Error(err))
 		return
 	}
-
-	// Continue logic...
 	c.JSON(http.StatusOK, model.Response{
 		Status: "0",
 		Msg:    "Success",
 		Desc:   "Create successfully",
 	})
-
 }
 
 // @summary Update Mmd Properties
@@ -219,46 +130,24 @@ func InsertMmdProperty(c *gin.Context) {
 // @Router /api/v1/mdm/properties/{id} [patch]
 func UpdateMmdProperty(c *gin.Context) {
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
-	defer cancel()
-
-	id := c.Param("id")
 
 	var req model.MmdPropertyUpdate
 	if err := c.ShouldBindJSON(&req); err != nil {
-		logger.Warn("Update failed", zap.Error(err))
 		c.JSON(http.StatusBadRequest, model.Response{
 			Status: "-1",
 			Msg:    "Failure",
 			Desc:   err.Error(),
 		})
+		logger.Warn("Update failed", zap.Error(err))
 		return
 	}
-	now := time.Now()
-	username := GetVariableFromToken(c, "username")
-	orgId := GetVariableFromToken(c, "orgId")
-	query := `UPDATE public."mdm_properties"
-	SET en=$2, th=$3, active=$4,
-	 "updatedAt"=$5, "updatedBy"=$6
-	WHERE id = $1 AND "orgId"=$7`
-	_, err := conn.Exec(ctx, query,
-		id, req.EN, req.TH, req.Active,
-		now, username, orgId,
-	)
-	logger.Debug("Update Case SQL Args",
-		zap.String("query", query),
-		zap.Any("Input", []any{
-			id, req.EN, req.TH, req.Active,
-			now, username, orgId,
-		}))
-	if err != nil {
-		// log.Printf("Insert failed: %v", err)
-		c.JSON(http.StatusInternalServerError, model.Response{
+	username := tokenString(c, "username")
+	orgId := tokenString(c, "orgId")
+
+	if err := repo.MasterData.UpdateProperty(ctx, orgId, c.Param("id"), username, req); err != nil {
+		c.JSON(storeStatus(err), model.Response{
 			Status: "-1",
 			Msg:    "Failure",
 			Desc:   err.Error(),
@@ -266,8 +155,6 @@ func UpdateMmdProperty(c *gin.Context) {
 		logger.Warn("Update failed", zap.Error(err))
 		return
 	}
-
-	// Continue logic...
 	c.JSON(http.StatusOK, model.Response{
 		Status: "0",
 		Msg:    "Success",
@@ -285,30 +172,19 @@ func UpdateMmdProperty(c *gin.Context) {
 // @response 200 {object} model.Response "OK - Request successful"
 // @Router /api/v1/mdm/properties/{id} [delete]
 func DeleteMmdProperty(c *gin.Context) {
-
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
-	defer cancel()
-	defer conn.Close(ctx)
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	orgId := GetVariableFromToken(c, "orgId")
-	id := c.Param("id")
-	query := `DELETE FROM public."mdm_properties" WHERE id = $1 AND "orgId"=$2`
-	logger.Debug("Query", zap.String("query", query), zap.Any("id", id))
-	_, err := conn.Exec(ctx, query, id, orgId)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, model.Response{
+
+	if err := repo.MasterData.DeleteProperty(ctx, tokenString(c, "orgId"), c.Param("id")); err != nil {
+		c.JSON(storeStatus(err), model.Response{
 			Status: "-1",
 			Msg:    "Failure",
 			Desc:   err.Error(),
 		})
-		logger.Warn("Update failed", zap.Error(err))
+		logger.Warn("Delete failed", zap.Error(err))
 		return
 	}
-
 	c.JSON(http.StatusOK, model.Response{
 		Status: "0",
 		Msg:    "Success",
@@ -328,30 +204,11 @@ func DeleteMmdProperty(c *gin.Context) {
 // @Router /api/v1/mdm/sources [get]
 func GetMmdUnitSources(c *gin.Context) {
 	logger := config.GetLog()
-
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
-	startStr := c.DefaultQuery("start", "0")
-	start, err := strconv.Atoi(startStr)
-	if err != nil {
-		start = 0
-	}
-	lengthStr := c.DefaultQuery("length", "1000")
-	length, err := strconv.Atoi(lengthStr)
-	if err != nil {
-		length = 1000
-	}
-	orgId := GetVariableFromToken(c, "orgId")
-	query := `SELECT  id, "unitSourceId", "orgId", en, th, active, "createdAt", "updatedAt", "createdBy", "updatedBy"
-	FROM public.mdm_unit_sources WHERE "orgId"=$1 LIMIT $2 OFFSET $3`
+	start, length := pageQuery(c)
 
-	var rows pgx.Rows
-	logger.Debug(`Query`, zap.String("query", query))
-	rows, err = conn.Query(ctx, query, orgId, length, start)
+	list, err := repo.MasterData.ListUnitSources(ctx, tokenString(c, "orgId"), length, start)
 	if err != nil {
 		logger.Warn("Query failed", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, model.Response{
@@ -361,43 +218,11 @@ func GetMmdUnitSources(c *gin.Context) {
 		})
 		return
 	}
-	defer rows.Close()
-	var errorMsg string
-	var Property model.MmdUnitSource
-	var PropertyList []model.MmdUnitSource
-	rowIndex := 0
-	for rows.Next() {
-		rowIndex++
-		err := rows.Scan(&Property.ID, &Property.UnitSourceID, &Property.OrgID, &Property.EN, &Property.TH,
-			&Property.Active, &Property.CreatedAt, &Property.UpdatedAt, &Property.CreatedBy, &Property.UpdatedBy)
-		if err != nil {
-			logger.Warn("Scan failed", zap.Error(err))
-			response := model.Response{
-				Status: "-1",
-				Msg:    "Failed",
-				Desc:   errorMsg,
-			}
-			c.JSON(http.StatusInternalServerError, response)
-			return
-		}
-		PropertyList = append(PropertyList, Property)
-	}
-	if errorMsg != "" {
-		response := model.Response{
-			Status: "-1",
-			Msg:    "Failed",
-			Desc:   errorMsg,
-		}
-		c.JSON(http.StatusInternalServerError, response)
-	} else {
-		response := model.Response{
-			Status: "0",
-			Msg:    "Success",
-			Data:   PropertyList,
-			Desc:   "",
-		}
-		c.JSON(http.StatusOK, response)
-	}
+	c.JSON(http.StatusOK, model.Response{
+		Status: "0",
+		Msg:    "Success",
+		Data:   list,
+	})
 }
 
 // @summary Get Mmd Unit Sources by Id
@@ -411,39 +236,24 @@ func GetMmdUnitSources(c *gin.Context) {
 // @Router /api/v1/mdm/sources/{id} [get]
 func GetMmdUnitSourcesById(c *gin.Context) {
 	logger := config.GetLog()
-	id := c.Param("id")
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
-	orgId := GetVariableFromToken(c, "orgId")
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
-	query := `SELECT  id, "unitSourceId", "orgId", en, th, active, "createdAt", "updatedAt", "createdBy", "updatedBy"
-	FROM public.mdm_unit_sources WHERE id=$1 AND "orgId"=$2`
-
-	var Property model.MmdUnitSource
-	logger.Debug(`Query`, zap.String("query", query))
-	err := conn.QueryRow(ctx, query, id, orgId).Scan(&Property.ID, &Property.UnitSourceID, &Property.OrgID, &Property.EN, &Property.TH,
-		&Property.Active, &Property.CreatedAt, &Property.UpdatedAt, &Property.CreatedBy, &Property.UpdatedBy)
+
+	source, err := repo.MasterData.GetUnitSource(ctx, tokenString(c, "orgId"), c.Param("id"))
 	if err != nil {
 		logger.Warn("Query failed", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, model.Response{
+		c.JSON(storeStatus(err), model.Response{
 			Status: "-1",
 			Msg:    "Failure",
 			Desc:   err.Error(),
 		})
 		return
 	}
-
-	response := model.Response{
+	c.JSON(http.StatusOK, model.Response{
 		Status: "0",
 		Msg:    "Success",
-		Data:   Property,
-		Desc:   "",
-	}
-	c.JSON(http.StatusOK, response)
-
+		Data:   source,
+	})
 }
 
 // @summary Create Mmd Unit Sources
@@ -457,12 +267,7 @@ func GetMmdUnitSourcesById(c *gin.Context) {
 // @Router /api/v1/mdm/sources/add [post]
 func InsertMmdUnitSources(c *gin.Context) {
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
-	defer cancel()
-	defer conn.Close(ctx)
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
 
 	var req model.MmdUnitSourceInsert
@@ -475,23 +280,10 @@ func InsertMmdUnitSources(c *gin.Context) {
 		logger.Warn("Insert failed", zap.Error(err))
 		return
 	}
-	username := GetVariableFromToken(c, "username")
-	now := time.Now()
-	var id int
-	orgId := GetVariableFromToken(c, "orgId")
-	uuid := uuid.New()
-	query := `
-	INSERT INTO public."mdm_unit_sources"(
-	"unitSourceId", "orgId", en, th, active, "createdAt", "updatedAt", "createdBy", "updatedBy")
-	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
-	RETURNING id ;
-	`
-
-	err := conn.QueryRow(ctx, query,
-		uuid, orgId, req.EN, req.TH, req.Active, now, now, username, username).Scan(&id)
+	username := tokenString(c, "username")
+	orgId := tokenString(c, "orgId")
 
-	if err != nil {
-		// log.Printf("Insert failed: %v", err)
+	if err := repo.MasterData.CreateUnitSource(ctx, orgId, uuid.NewString(), username, req); err != nil {
 		c.JSON(http.StatusInternalServerError, model.Response{
 			Status: "-1",
 			Msg:    "Failure",
@@ -500,14 +292,11 @@ func InsertMmdUnitSources(c *gin.Context) {
 		logger.Warn("Insert failed", zap.Error(err))
 		return
 	}
-
-	// Continue logic...
 	c.JSON(http.StatusOK, model.Response{
 		Status: "0",
 		Msg:    "Success",
 		Desc:   "Create successfully",
 	})
-
 }
 
 // @summary Update Mmd Unit Sources
@@ -522,46 +311,24 @@ func InsertMmdUnitSources(c *gin.Context) {
 // @Router /api/v1/mdm/sources/{id} [patch]
 func UpdateMmdUnitSources(c *gin.Context) {
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
-	defer cancel()
-	defer conn.Close(ctx)
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
 
-	id := c.Param("id")
-
 	var req model.MmdUnitSourceUpdate
 	if err := c.ShouldBindJSON(&req); err != nil {
-		logger.Warn("Update failed", zap.Error(err))
 		c.JSON(http.StatusBadRequest, model.Response{
 			Status: "-1",
 			Msg:    "Failure",
 			Desc:   err.Error(),
 		})
+		logger.Warn("Update failed", zap.Error(err))
 		return
 	}
-	now := time.Now()
-	username := GetVariableFromToken(c, "username")
-	orgId := GetVariableFromToken(c, "orgId")
-	query := `UPDATE public."mdm_unit_sources"
-	SET en=$2, th=$3, active=$4,
-	 "updatedAt"=$5, "updatedBy"=$6
-	WHERE id = $1 AND "orgId"=$7`
-	_, err := conn.Exec(ctx, query,
-		id, req.EN, req.TH, req.Active,
-		now, username, orgId,
-	)
-	logger.Debug("Update Case SQL Args",
-		zap.String("query", query),
-		zap.Any("Input", []any{
-			id, req.EN, req.TH, req.Active,
-			now, username, orgId,
-		}))
-	if err != nil {
-		// log.Printf("Insert failed: %v", err)
-		c.JSON(http.StatusInternalServerError, model.Response{
+	username := tokenString(c, "username")
+	orgId := tokenString(c, "orgId")
+
+	if err := repo.MasterData.UpdateUnitSource(ctx, orgId, c.Param("id"), username, req); err != nil {
+		c.JSON(storeStatus(err), model.Response{
 			Status: "-1",
 			Msg:    "Failure",
 			Desc:   err.Error(),
@@ -569,8 +336,6 @@ func UpdateMmdUnitSources(c *gin.Context) {
 		logger.Warn("Update failed", zap.Error(err))
 		return
 	}
-
-	// Continue logic...
 	c.JSON(http.StatusOK, model.Response{
 		Status: "0",
 		Msg:    "Success",
@@ -588,30 +353,19 @@ func UpdateMmdUnitSources(c *gin.Context) {
 // @response 200 {object} model.Response "OK - Request successful"
 // @Router /api/v1/mdm/sources/{id} [delete]
 func DeleteMmdUnitSources(c *gin.Context) {
-
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
-	defer cancel()
-	orgId := GetVariableFromToken(c, "orgId")
-	id := c.Param("id")
-	query := `DELETE FROM public."mdm_unit_sources" WHERE id = $1 AND "orgId"=$2`
-	logger.Debug("Query", zap.String("query", query), zap.Any("id", id))
-	_, err := conn.Exec(ctx, query, id, orgId)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, model.Response{
+
+	if err := repo.MasterData.DeleteUnitSource(ctx, tokenString(c, "orgId"), c.Param("id")); err != nil {
+		c.JSON(storeStatus(err), model.Response{
 			Status: "-1",
 			Msg:    "Failure",
 			Desc:   err.Error(),
 		})
-		logger.Warn("Update failed", zap.Error(err))
+		logger.Warn("Delete failed", zap.Error(err))
 		return
 	}
-
 	c.JSON(http.StatusOK, model.Response{
 		Status: "0",
 		Msg:    "Success",
@@ -631,30 +385,11 @@ func DeleteMmdUnitSources(c *gin.Context) {
 // @Router /api/v1/mdm/types [get]
 func GetMmdUnitType(c *gin.Context) {
 	logger := config.GetLog()
-
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
-	startStr := c.DefaultQuery("start", "0")
-	start, err := strconv.Atoi(startStr)
-	if err != nil {
-		start = 0
-	}
-	lengthStr := c.DefaultQuery("length", "1000")
-	length, err := strconv.Atoi(lengthStr)
-	if err != nil {
-		length = 1000
-	}
-	orgId := GetVariableFromToken(c, "orgId")
-	query := `SELECT  id, "unitTypeId", "orgId", en, th, active, "createdAt", "updatedAt", "createdBy", "updatedBy"
-	FROM public.mdm_unit_types WHERE "orgId"=$1 LIMIT $2 OFFSET $3`
+	start, length := pageQuery(c)
 
-	var rows pgx.Rows
-	logger.Debug(`Query`, zap.String("query", query))
-	rows, err = conn.Query(ctx, query, orgId, length, start)
+	list, err := repo.MasterData.ListUnitTypes(ctx, tokenString(c, "orgId"), length, start)
 	if err != nil {
 		logger.Warn("Query failed", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, model.Response{
@@ -664,43 +399,11 @@ func GetMmdUnitType(c *gin.Context) {
 		})
 		return
 	}
-	defer rows.Close()
-	var errorMsg string
-	var Property model.MmdUnitType
-	var PropertyList []model.MmdUnitType
-	found := false
-	for rows.Next() {
-		err := rows.Scan(&Property.ID, &Property.UnitTypeId, &Property.OrgID, &Property.EN, &Property.TH,
-			&Property.Active, &Property.CreatedAt, &Property.UpdatedAt, &Property.CreatedBy, &Property.UpdatedBy)
-		if err != nil {
-			logger.Warn("Scan failed", zap.Error(err))
-			response := model.Response{
-				Status: "-1",
-				Msg:    "Failed",
-				Desc:   errorMsg,
-			}
-			c.JSON(http.StatusInternalServerError, response)
-			return
-		}
-		PropertyList = append(PropertyList, Property)
-		found = true
-	}
-	if !found {
-		response := model.Response{
-			Status: "-1",
-			Msg:    "Failed",
-			Desc:   errorMsg,
-		}
-		c.JSON(http.StatusInternalServerError, response)
-	} else {
-		response := model.Response{
-			Status: "0",
-			Msg:    "Success",
-			Data:   PropertyList,
-			Desc:   "",
-		}
-		c.JSON(http.StatusOK, response)
-	}
+	c.JSON(http.StatusOK, model.Response{
+		Status: "0",
+		Msg:    "Success",
+		Data:   list,
+	})
 }
 
 // @summary Get Mmd Unit Types by Id
@@ -714,39 +417,24 @@ func GetMmdUnitType(c *gin.Context) {
 // @Router /api/v1/mdm/types/{id} [get]
 func GetMmdUnitTypeById(c *gin.Context) {
 	logger := config.GetLog()
-	id := c.Param("id")
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
-	orgId := GetVariableFromToken(c, "orgId")
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
-	query := `SELECT  id, "unitTypeId", "orgId", en, th, active, "createdAt", "updatedAt", "createdBy", "updatedBy"
-	FROM public.mdm_unit_types WHERE id=$1 AND "orgId"=$2`
-
-	var Property model.MmdUnitType
-	logger.Debug(`Query`, zap.String("query", query))
-	err := conn.QueryRow(ctx, query, id, orgId).Scan(&Property.ID, &Property.UnitTypeId, &Property.OrgID, &Property.EN, &Property.TH,
-		&Property.Active, &Property.CreatedAt, &Property.UpdatedAt, &Property.CreatedBy, &Property.UpdatedBy)
+
+	unitType, err := repo.MasterData.GetUnitType(ctx, tokenString(c, "orgId"), c.Param("id"))
 	if err != nil {
 		logger.Warn("Query failed", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, model.Response{
+		c.JSON(storeStatus(err), model.Response{
 			Status: "-1",
 			Msg:    "Failure",
 			Desc:   err.Error(),
 		})
 		return
 	}
-
-	response := model.Response{
+	c.JSON(http.StatusOK, model.Response{
 		Status: "0",
 		Msg:    "Success",
-		Data:   Property,
-		Desc:   "",
-	}
-	c.JSON(http.StatusOK, response)
-
+		Data:   unitType,
+	})
 }
 
 // @summary Create Mmd Unit Types
@@ -760,12 +448,7 @@ func GetMmdUnitTypeById(c *gin.Context) {
 // @Router /api/v1/mdm/types/add [post]
 func InsertMmdUnitType(c *gin.Context) {
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
-	defer cancel()
-	defer conn.Close(ctx)
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
 
 	var req model.MmdUnitTypeInsert
@@ -778,23 +461,10 @@ func InsertMmdUnitType(c *gin.Context) {
 		logger.Warn("Insert failed", zap.Error(err))
 		return
 	}
-	username := GetVariableFromToken(c, "username")
-	now := time.Now()
-	var id int
-	orgId := GetVariableFromToken(c, "orgId")
-	uuid := uuid.New()
-	query := `
-	INSERT INTO public."mdm_unit_types"(
-	"unitTypeId", "orgId", en, th, active, "createdAt", "updatedAt", "createdBy", "updatedBy")
-	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
-	RETURNING id ;
-	`
-
-	err := conn.QueryRow(ctx, query,
-		uuid, orgId, req.EN, req.TH, req.Active, now, now, username, username).Scan(&id)
+	username := tokenString(c, "username")
+	orgId := tokenString(c, "orgId")
 
-	if err != nil {
-		// log.Printf("Insert failed: %v", err)
+	if err := repo.MasterData.CreateUnitType(ctx, orgId, uuid.NewString(), username, req); err != nil {
 		c.JSON(http.StatusInternalServerError, model.Response{
 			Status: "-1",
 			Msg:    "Failure",
@@ -803,14 +473,11 @@ func InsertMmdUnitType(c *gin.Context) {
 		logger.Warn("Insert failed", zap.Error(err))
 		return
 	}
-
-	// Continue logic...
 	c.JSON(http.StatusOK, model.Response{
 		Status: "0",
 		Msg:    "Success",
 		Desc:   "Create successfully",
 	})
-
 }
 
 // @summary Update Mmd Types Sources
@@ -825,46 +492,24 @@ func InsertMmdUnitType(c *gin.Context) {
 // @Router /api/v1/mdm/types/{id} [patch]
 func UpdateMmdUnitType(c *gin.Context) {
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
-	defer cancel()
-	defer conn.Close(ctx)
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
 
-	id := c.Param("id")
-
 	var req model.MmdUnitTypeUpdate
 	if err := c.ShouldBindJSON(&req); err != nil {
-		logger.Warn("Update failed", zap.Error(err))
 		c.JSON(http.StatusBadRequest, model.Response{
 			Status: "-1",
 			Msg:    "Failure",
 			Desc:   err.Error(),
 		})
+		logger.Warn("Update failed", zap.Error(err))
 		return
 	}
-	now := time.Now()
-	username := GetVariableFromToken(c, "username")
-	orgId := GetVariableFromToken(c, "orgId")
-	query := `UPDATE public."mdm_unit_sources"
-	SET en=$2, th=$3, active=$4,
-	 "updatedAt"=$5, "updatedBy"=$6
-	WHERE id = $1 AND "orgId"=$7`
-	_, err := conn.Exec(ctx, query,
-		id, req.EN, req.TH, req.Active,
-		now, username, orgId,
-	)
-	logger.Debug("Update Case SQL Args",
-		zap.String("query", query),
-		zap.Any("Input", []any{
-			id, req.EN, req.TH, req.Active,
-			now, username, orgId,
-		}))
-	if err != nil {
-		// log.Printf("Insert failed: %v", err)
-		c.JSON(http.StatusInternalServerError, model.Response{
+	username := tokenString(c, "username")
+	orgId := tokenString(c, "orgId")
+
+	if err := repo.MasterData.UpdateUnitType(ctx, orgId, c.Param("id"), username, req); err != nil {
+		c.JSON(storeStatus(err), model.Response{
 			Status: "-1",
 			Msg:    "Failure",
 			Desc:   err.Error(),
@@ -872,8 +517,6 @@ func UpdateMmdUnitType(c *gin.Context) {
 		logger.Warn("Update failed", zap.Error(err))
 		return
 	}
-
-	// Continue logic...
 	c.JSON(http.StatusOK, model.Response{
 		Status: "0",
 		Msg:    "Success",
@@ -891,29 +534,19 @@ func UpdateMmdUnitType(c *gin.Context) {
 // @response 200 {object} model.Response "OK - Request successful"
 // @Router /api/v1/mdm/types/{id} [delete]
 func DeleteMmdUnitType(c *gin.Context) {
-
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
-	orgId := GetVariableFromToken(c, "orgId")
-	id := c.Param("id")
-	query := `DELETE FROM public."mdm_unit_types" WHERE id = $1 AND "orgId"=$2`
-	logger.Debug("Query", zap.String("query", query), zap.Any("id", id))
-	_, err := conn.Exec(ctx, query, id, orgId)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, model.Response{
+
+	if err := repo.MasterData.DeleteUnitType(ctx, tokenString(c, "orgId"), c.Param("id")); err != nil {
+		c.JSON(storeStatus(err), model.Response{
 			Status: "-1",
 			Msg:    "Failure",
 			Desc:   err.Error(),
 		})
-		logger.Warn("Update failed", zap.Error(err))
+		logger.Warn("Delete failed", zap.Error(err))
 		return
 	}
-
 	c.JSON(http.StatusOK, model.Response{
 		Status: "0",
 		Msg:    "Success",
@@ -933,30 +566,11 @@ func DeleteMmdUnitType(c *gin.Context) {
 // @Router /api/v1/mdm/companies [get]
 func GetMmdCompanies(c *gin.Context) {
 	logger := config.GetLog()
-
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
-	startStr := c.DefaultQuery("start", "0")
-	start, err := strconv.Atoi(startStr)
-	if err != nil {
-		start = 0
-	}
-	lengthStr := c.DefaultQuery("length", "1000")
-	length, err := strconv.Atoi(lengthStr)
-	if err != nil {
-		length = 1000
-	}
-	// orgId := GetVariableFromToken(c, "orgId")
-	query := `SELECT id, name, "legalName", domain, email, "phoneNumber", address, "logoUrl", "websiteUrl", description, "createdAt", "updatedAt", "createdBy", "updatedBy"
-	FROM public.mdm_companies LIMIT $1 OFFSET $2`
+	start, length := pageQuery(c)
 
-	var rows pgx.Rows
-	logger.Debug(`Query`, zap.String("query", query))
-	rows, err = conn.Query(ctx, query, length, start)
+	list, err := repo.MasterData.ListCompanies(ctx, length, start)
 	if err != nil {
 		logger.Warn("Query failed", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, model.Response{
@@ -966,57 +580,11 @@ func GetMmdCompanies(c *gin.Context) {
 		})
 		return
 	}
-	defer rows.Close()
-	var errorMsg string
-	var org model.MmdCompanies
-	var orgList []model.MmdCompanies
-	found := false
-	for rows.Next() {
-		err := rows.Scan(
-			&org.ID,
-			&org.Name,
-			&org.LegalName,
-			&org.Domain,
-			&org.Email,
-			&org.PhoneNumber,
-			&org.Address,
-			&org.LogoURL,
-			&org.WebsiteURL,
-			&org.Description,
-			&org.CreatedAt,
-			&org.UpdatedAt,
-			&org.CreatedBy,
-			&org.UpdatedBy,
-		)
-		if err != nil {
-			logger.Warn("Scan failed", zap.Error(err))
-			response := model.Response{
-				Status: "-1",
-				Msg:    "Failed",
-				Desc:   errorMsg,
-			}
-			c.JSON(http.StatusInternalServerError, response)
-			return
-		}
-		orgList = append(orgList, org)
-		found = true
-	}
-	if !found {
-		response := model.Response{
-			Status: "-1",
-			Msg:    "Failed",
-			Desc:   errorMsg,
-		}
-		c.JSON(http.StatusInternalServerError, response)
-	} else {
-		response := model.Response{
-			Status: "0",
-			Msg:    "Success",
-			Data:   orgList,
-			Desc:   "",
-		}
-		c.JSON(http.StatusOK, response)
-	}
+	c.JSON(http.StatusOK, model.Response{
+		Status: "0",
+		Msg:    "Success",
+		Data:   list,
+	})
 }
 
 // @summary Get Mmd Companies by Id
@@ -1030,53 +598,24 @@ func GetMmdCompanies(c *gin.Context) {
 // @Router /api/v1/mdm/companies/{id} [get]
 func GetMmdCompaniesById(c *gin.Context) {
 	logger := config.GetLog()
-	id := c.Param("id")
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
-	// orgId := GetVariableFromToken(c, "orgId")
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
-	query := `SELECT id, name, "legalName", domain, email, "phoneNumber", address, "logoUrl", "websiteUrl", description, "createdAt", "updatedAt", "createdBy", "updatedBy"
-	FROM public.mdm_companies WHERE id=$1`
-
-	var org model.MmdCompanies
-	logger.Debug(`Query`, zap.String("query", query))
-	err := conn.QueryRow(ctx, query, id).Scan(
-		&org.ID,
-		&org.Name,
-		&org.LegalName,
-		&org.Domain,
-		&org.Email,
-		&org.PhoneNumber,
-		&org.Address,
-		&org.LogoURL,
-		&org.WebsiteURL,
-		&org.Description,
-		&org.CreatedAt,
-		&org.UpdatedAt,
-		&org.CreatedBy,
-		&org.UpdatedBy,
-	)
+
+	company, err := repo.MasterData.GetCompany(ctx, c.Param("id"))
 	if err != nil {
 		logger.Warn("Query failed", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, model.Response{
+		c.JSON(storeStatus(err), model.Response{
 			Status: "-1",
 			Msg:    "Failure",
 			Desc:   err.Error(),
 		})
 		return
 	}
-
-	response := model.Response{
+	c.JSON(http.StatusOK, model.Response{
 		Status: "0",
 		Msg:    "Success",
-		Data:   org,
-		Desc:   "",
-	}
-	c.JSON(http.StatusOK, response)
-
+		Data:   company,
+	})
 }
 
 // @summary Create Mmd Companies Types
@@ -1090,12 +629,7 @@ func GetMmdCompaniesById(c *gin.Context) {
 // @Router /api/v1/mdm/companies/add [post]
 func InsertMmdCompanies(c *gin.Context) {
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
-	defer cancel()
-	defer conn.Close(ctx)
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
 
 	var req model.MmdCompaniesInsert
@@ -1108,25 +642,9 @@ func InsertMmdCompanies(c *gin.Context) {
 		logger.Warn("Insert failed", zap.Error(err))
 		return
 	}
-	username := GetVariableFromToken(c, "username")
-	now := time.Now()
-	var id int
-	// orgId := GetVariableFromToken(c, "orgId")
-	uuid := uuid.New()
-	query := `
-	INSERT INTO public."mdm_companies"(
-		id, name, "legalName", domain, email, "phoneNumber", address, "logoUrl", "websiteUrl",
-		 description, "createdAt", "updatedAt", "createdBy", "updatedBy")
-	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
-	RETURNING id ;
-	`
-
-	err := conn.QueryRow(ctx, query,
-		uuid, req.Name, req.LegalName, req.Domain, req.Email, req.PhoneNumber, req.Address, req.LogoURL,
-		req.WebsiteURL, req.Description, now, now, username, username).Scan(&id)
+	username := tokenString(c, "username")
 
-	if err != nil {
-		// log.Printf("Insert failed: %v", err)
+	if err := repo.MasterData.CreateCompany(ctx, uuid.NewString(), username, req); err != nil {
 		c.JSON(http.StatusInternalServerError, model.Response{
 			Status: "-1",
 			Msg:    "Failure",
@@ -1135,13 +653,11 @@ func InsertMmdCompanies(c *gin.Context) {
 		logger.Warn("Insert failed", zap.Error(err))
 		return
 	}
-
 	c.JSON(http.StatusOK, model.Response{
 		Status: "0",
 		Msg:    "Success",
 		Desc:   "Create successfully",
 	})
-
 }
 
 // @summary Update Mmd Companies
@@ -1156,45 +672,23 @@ func InsertMmdCompanies(c *gin.Context) {
 // @Router /api/v1/mdm/companies/{id} [patch]
 func UpdateMmdCompanies(c *gin.Context) {
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
-
-	id := c.Param("id")
 
 	var req model.MmdCompaniesUpdate
 	if err := c.ShouldBindJSON(&req); err != nil {
-		logger.Warn("Update failed", zap.Error(err))
 		c.JSON(http.StatusBadRequest, model.Response{
 			Status: "-1",
 			Msg:    "Failure",
 			Desc:   err.Error(),
 		})
+		logger.Warn("Update failed", zap.Error(err))
 		return
 	}
-	now := time.Now()
-	username := GetVariableFromToken(c, "username")
-	// orgId := GetVariableFromToken(c, "orgId")
-	query := `UPDATE public."mdm_companies"
-	SET name= $2, "legalName"= $3, domain= $4, email= $5, "phoneNumber"= $6, address= $7, "logoUrl"= $8,
-	 "websiteUrl"= $9, description= $10, "updatedAt"= $11, "updatedBy"= $12
-	WHERE id = $1`
-	_, err := conn.Exec(ctx, query,
-		id, req.Name, req.LegalName, req.Domain, req.Email, req.PhoneNumber, req.Address, req.LogoURL,
-		req.WebsiteURL, req.Description, now, username,
-	)
-	logger.Debug("Update Case SQL Args",
-		zap.String("query", query),
-		zap.Any("Input", []any{
-			id, req.Name, req.LegalName, req.Domain, req.Email, req.PhoneNumber, req.Address, req.LogoURL,
-			req.WebsiteURL, req.Description, now, username,
-		}))
-	if err != nil {
-		// log.Printf("Insert failed: %v", err)
-		c.JSON(http.StatusInternalServerError, model.Response{
+	username := tokenString(c, "username")
+
+	if err := repo.MasterData.UpdateCompany(ctx, c.Param("id"), username, req); err != nil {
+		c.JSON(storeStatus(err), model.Response{
 			Status: "-1",
 			Msg:    "Failure",
 			Desc:   err.Error(),
@@ -1202,8 +696,6 @@ func UpdateMmdCompanies(c *gin.Context) {
 		logger.Warn("Update failed", zap.Error(err))
 		return
 	}
-
-	// Continue logic...
 	c.JSON(http.StatusOK, model.Response{
 		Status: "0",
 		Msg:    "Success",
@@ -1221,29 +713,19 @@ func UpdateMmdCompanies(c *gin.Context) {
 // @response 200 {object} model.Response "OK - Request successful"
 // @Router /api/v1/mdm/companies/{id} [delete]
 func DeleteMmdCompanies(c *gin.Context) {
-
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
-	// orgId := GetVariableFromToken(c, "orgId")
-	id := c.Param("id")
-	query := `DELETE FROM public."mdm_companies" WHERE id = $1`
-	logger.Debug("Query", zap.String("query", query), zap.Any("id", id))
-	_, err := conn.Exec(ctx, query, id)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, model.Response{
+
+	if err := repo.MasterData.DeleteCompany(ctx, c.Param("id")); err != nil {
+		c.JSON(storeStatus(err), model.Response{
 			Status: "-1",
 			Msg:    "Failure",
 			Desc:   err.Error(),
 		})
-		logger.Warn("Update failed", zap.Error(err))
+		logger.Warn("Delete failed", zap.Error(err))
 		return
 	}
-
 	c.JSON(http.StatusOK, model.Response{
 		Status: "0",
 		Msg:    "Success",
@@ -1263,30 +745,11 @@ func DeleteMmdCompanies(c *gin.Context) {
 // @Router /api/v1/mdm/status [get]
 func GetMmdUnitStatus(c *gin.Context) {
 	logger := config.GetLog()
-
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
-	startStr := c.DefaultQuery("start", "0")
-	start, err := strconv.Atoi(startStr)
-	if err != nil {
-		start = 0
-	}
-	lengthStr := c.DefaultQuery("length", "1000")
-	length, err := strconv.Atoi(lengthStr)
-	if err != nil {
-		length = 1000
-	}
-	// orgId := GetVariableFromToken(c, "orgId")
-	query := `SELECT id, "sttId", "sttName", "createdAt", "updatedAt", "createdBy", "updatedBy"
-	FROM public.mdm_unit_statuses LIMIT $1 OFFSET $2`
+	start, length := pageQuery(c)
 
-	var rows pgx.Rows
-	logger.Debug(`Query`, zap.String("query", query))
-	rows, err = conn.Query(ctx, query, length, start)
+	list, err := repo.MasterData.ListUnitStatuses(ctx, length, start)
 	if err != nil {
 		logger.Warn("Query failed", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, model.Response{
@@ -1296,50 +759,11 @@ func GetMmdUnitStatus(c *gin.Context) {
 		})
 		return
 	}
-	defer rows.Close()
-	var errorMsg string
-	var org model.MmdUnitStatus
-	var orgList []model.MmdUnitStatus
-	found := false
-	for rows.Next() {
-		err := rows.Scan(
-			&org.ID,
-			&org.SttID,
-			&org.SttName,
-			&org.CreatedAt,
-			&org.UpdatedAt,
-			&org.CreatedBy,
-			&org.UpdatedBy,
-		)
-		if err != nil {
-			logger.Warn("Scan failed", zap.Error(err))
-			response := model.Response{
-				Status: "-1",
-				Msg:    "Failed",
-				Desc:   errorMsg,
-			}
-			c.JSON(http.StatusInternalServerError, response)
-			return
-		}
-		orgList = append(orgList, org)
-		found = true
-	}
-	if !found {
-		response := model.Response{
-			Status: "-1",
-			Msg:    "Failed",
-			Desc:   errorMsg,
-		}
-		c.JSON(http.StatusInternalServerError, response)
-	} else {
-		response := model.Response{
-			Status: "0",
-			Msg:    "Success",
-			Data:   orgList,
-			Desc:   "",
-		}
-		c.JSON(http.StatusOK, response)
-	}
+	c.JSON(http.StatusOK, model.Response{
+		Status: "0",
+		Msg:    "Success",
+		Data:   list,
+	})
 }
 
 // @summary Get Mmd Unit Status by Id
@@ -1353,46 +777,24 @@ func GetMmdUnitStatus(c *gin.Context) {
 // @Router /api/v1/mdm/status/{id} [get]
 func GetMmdUnitStatusById(c *gin.Context) {
 	logger := config.GetLog()
-	id := c.Param("id")
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
-	// orgId := GetVariableFromToken(c, "orgId")
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
-	query := `SELECT id, "sttId", "sttName", "createdAt", "updatedAt", "createdBy", "updatedBy"
-	FROM public.mdm_unit_statuses WHERE id=$1`
-
-	var org model.MmdUnitStatus
-	logger.Debug(`Query`, zap.String("query", query))
-	err := conn.QueryRow(ctx, query, id).Scan(
-		&org.ID,
-		&org.SttID,
-		&org.SttName,
-		&org.CreatedAt,
-		&org.UpdatedAt,
-		&org.CreatedBy,
-		&org.UpdatedBy,
-	)
+
+	status, err := repo.MasterData.GetUnitStatus(ctx, c.Param("id"))
 	if err != nil {
 		logger.Warn("Query failed", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, model.Response{
+		c.JSON(storeStatus(err), model.Response{
 			Status: "-1",
 			Msg:    "Failure",
 			Desc:   err.Error(),
 		})
 		return
 	}
-
-	response := model.Response{
+	c.JSON(http.StatusOK, model.Response{
 		Status: "0",
 		Msg:    "Success",
-		Data:   org,
-		Desc:   "",
-	}
-	c.JSON(http.StatusOK, response)
-
+		Data:   status,
+	})
 }
 
 // @summary Create Mmd Unit Status
@@ -1406,12 +808,8 @@ func GetMmdUnitStatusById(c *gin.Context) {
 // @Router /api/v1/mdm/status/add [post]
 func InsertMmdUnitStatus(c *gin.Context) {
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
 
 	var req model.MmdUnitStatusInsert
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -1423,23 +821,9 @@ func InsertMmdUnitStatus(c *gin.Context) {
 		logger.Warn("Insert failed", zap.Error(err))
 		return
 	}
-	username := GetVariableFromToken(c, "username")
-	now := time.Now()
-	var id int
-	// orgId := GetVariableFromToken(c, "orgId")
-	uuid := uuid.New()
-	query := `
-	INSERT INTO public."mdm_unit_statuses"(
-		"sttId", "sttName", "createdAt", "updatedAt", "createdBy", "updatedBy")
-	VALUES ($1, $2, $3, $4, $5, $6)
-	RETURNING id ;
-	`
-
-	err := conn.QueryRow(ctx, query,
-		uuid, req.SttID, req.SttName, now, now, username, username).Scan(&id)
+	username := tokenString(c, "username")
 
-	if err != nil {
-		// log.Printf("Insert failed: %v", err)
+	if err := repo.MasterData.CreateUnitStatus(ctx, username, req); err != nil {
 		c.JSON(http.StatusInternalServerError, model.Response{
 			Status: "-1",
 			Msg:    "Failure",
@@ -1448,13 +832,11 @@ func InsertMmdUnitStatus(c *gin.Context) {
 		logger.Warn("Insert failed", zap.Error(err))
 		return
 	}
-
 	c.JSON(http.StatusOK, model.Response{
 		Status: "0",
 		Msg:    "Success",
 		Desc:   "Create successfully",
 	})
-
 }
 
 // @summary Update Mmd Unit Status
@@ -1469,42 +851,23 @@ func InsertMmdUnitStatus(c *gin.Context) {
 // @Router /api/v1/mdm/status/{id} [patch]
 func UpdateMmdUnitStatus(c *gin.Context) {
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
-
-	id := c.Param("id")
 
 	var req model.MmdUnitStatusUpdate
 	if err := c.ShouldBindJSON(&req); err != nil {
-		logger.Warn("Update failed", zap.Error(err))
 		c.JSON(http.StatusBadRequest, model.Response{
 			Status: "-1",
 			Msg:    "Failure",
 			Desc:   err.Error(),
 		})
+		logger.Warn("Update failed", zap.Error(err))
 		return
 	}
-	now := time.Now()
-	username := GetVariableFromToken(c, "username")
-	// orgId := GetVariableFromToken(c, "orgId")
-	query := `UPDATE public."mdm_unit_statuses"
-	SET sttId= $2, "sttName"= $3, "updatedAt"= $4, "updatedBy"= $5
-	WHERE id = $1`
-	_, err := conn.Exec(ctx, query,
-		id, req.SttID, req.SttName, now, username,
-	)
-	logger.Debug("Update Case SQL Args",
-		zap.String("query", query),
-		zap.Any("Input", []any{
-			id, req.SttID, req.SttName, now, username,
-		}))
-	if err != nil {
-		// log.Printf("Insert failed: %v", err)
-		c.JSON(http.StatusInternalServerError, model.Response{
+	username := tokenString(c, "username")
+
+	if err := repo.MasterData.UpdateUnitStatus(ctx, c.Param("id"), username, req); err != nil {
+		c.JSON(storeStatus(err), model.Response{
 			Status: "-1",
 			Msg:    "Failure",
 			Desc:   err.Error(),
@@ -1512,8 +875,6 @@ func UpdateMmdUnitStatus(c *gin.Context) {
 		logger.Warn("Update failed", zap.Error(err))
 		return
 	}
-
-	// Continue logic...
 	c.JSON(http.StatusOK, model.Response{
 		Status: "0",
 		Msg:    "Success",
@@ -1531,29 +892,19 @@ func UpdateMmdUnitStatus(c *gin.Context) {
 // @response 200 {object} model.Response "OK - Request successful"
 // @Router /api/v1/mdm/status/{id} [delete]
 func DeleteMmdUnitStatus(c *gin.Context) {
-
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
-	// orgId := GetVariableFromToken(c, "orgId")
-	id := c.Param("id")
-	query := `DELETE FROM public."mdm_unit_statuses" WHERE id = $1`
-	logger.Debug("Query", zap.String("query", query), zap.Any("id", id))
-	_, err := conn.Exec(ctx, query, id)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, model.Response{
+
+	if err := repo.MasterData.DeleteUnitStatus(ctx, c.Param("id")); err != nil {
+		c.JSON(storeStatus(err), model.Response{
 			Status: "-1",
 			Msg:    "Failure",
 			Desc:   err.Error(),
 		})
-		logger.Warn("Update failed", zap.Error(err))
+		logger.Warn("Delete failed", zap.Error(err))
 		return
 	}
-
 	c.JSON(http.StatusOK, model.Response{
 		Status: "0",
 		Msg:    "Success",
@@ -1574,12 +925,8 @@ func DeleteMmdUnitStatus(c *gin.Context) {
 func GetMmdUnit(c *gin.Context) {
 	logger := config.GetLog()
 
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
 	startStr := c.DefaultQuery("start", "0")
 	start, err := strconv.Atoi(startStr)
 	if err != nil {
@@ -1590,13 +937,8 @@ func GetMmdUnit(c *gin.Context) {
 	if err != nil {
 		length = 1000
 	}
-	orgId := GetVariableFromToken(c, "orgId")
-	query := `SELECT id, "orgId", "unitId", "unitName", "unitSourceId", "unitTypeId", priority, "compId", "deptId", "commId", "stnId", "plateNo", "provinceCode", active, username, "isLogin", "isFreeze", "isOutArea", "locLat", "locLon", "locAlt", "locBearing", "locSpeed", "locProvider", "locGpsTime", "locSatellites", "locAccuracy", "locLastUpdateTime", "breakDuration", "healthChk", "healthChkTime", "sttId", "createdAt", "updatedAt", "createdBy", "updatedBy"
-	FROM public.mdm_units WHERE "orgId"= $3 LIMIT $1 OFFSET $2`
-
-	var rows pgx.Rows
-	logger.Debug(`Query`, zap.String("query", query))
-	rows, err = conn.Query(ctx, query, length, start, orgId)
+	orgId := tokenString(c, "orgId")
+	unitList, err := repo.Units.List(ctx, orgId, length, start)
 	if err != nil {
 		logger.Warn("Query failed", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, model.Response{
@@ -1606,68 +948,11 @@ func GetMmdUnit(c *gin.Context) {
 		})
 		return
 	}
-	defer rows.Close()
-	var errorMsg string
-	var unit model.MmdUnit
-	var unitList []model.MmdUnit
-	found := false
-	for rows.Next() {
-		err := rows.Scan(
-			&unit.ID,
-			&unit.OrgID,
-			&unit.UnitID,
-			&unit.UnitName,
-			&unit.UnitSourceID,
-			&unit.UnitTypeID,
-			&unit.Priority,
-			&unit.CompID,
-			&unit.DeptID,
-			&unit.CommID,
-			&unit.StnID,
-			&unit.PlateNo,
-			&unit.ProvinceCode,
-			&unit.Active,
-			&unit.Username,
-			&unit.IsLogin,
-			&unit.IsFreeze,
-			&unit.IsOutArea,
-			&unit.LocLat,
-			&unit.LocLon,
-			&unit.LocAlt,
-			&unit.LocBearing,
-			&unit.LocSpeed,
-			&unit.LocProvider,
-			&unit.LocGpsTime,
-			&unit.LocSatellites,
-			&unit.LocAccuracy,
-			&unit.LocLastUpdateTime,
-			&unit.BreakDuration,
-			&unit.HealthChk,
-			&unit.HealthChkTime,
-			&unit.SttID,
-			&unit.CreatedAt,
-			&unit.UpdatedAt,
-			&unit.CreatedBy,
-			&unit.UpdatedBy,
-		)
-		if err != nil {
-			logger.Warn("Scan failed", zap.Error(err))
-			response := model.Response{
-				Status: "-1",
-				Msg:    "Failed",
-				Desc:   errorMsg,
-			}
-			c.JSON(http.StatusInternalServerError, response)
-			return
-		}
-		unitList = append(unitList, unit)
-		found = true
-	}
-	if !found {
+	if len(unitList) == 0 {
 		response := model.Response{
 			Status: "-1",
 			Msg:    "Failed",
-			Desc:   errorMsg,
+			Desc:   "",
 		}
 		c.JSON(http.StatusInternalServerError, response)
 	} else {
@@ -1693,56 +978,11 @@ func GetMmdUnit(c *gin.Context) {
 func GetMmdUnitById(c *gin.Context) {
 	logger := config.GetLog()
 	id := c.Param("id")
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
-	orgId := GetVariableFromToken(c, "orgId")
+	repo, ctx, cancel := getStore(c.Request.Context())
+	orgId := tokenString(c, "orgId")
 	defer cancel()
-	defer conn.Close(ctx)
-	query := `SELECT id, "orgId", "unitId", "unitName", "unitSourceId", "unitTypeId", priority, "compId", "deptId", "commId", "stnId", "plateNo", "provinceCode", active, username, "isLogin", "isFreeze", "isOutArea", "locLat", "locLon", "locAlt", "locBearing", "locSpeed", "locProvider", "locGpsTime", "locSatellites", "locAccuracy", "locLastUpdateTime", "breakDuration", "healthChk", "healthChkTime", "sttId", "createdAt", "updatedAt", "createdBy", "updatedBy"
-	FROM public.mdm_units WHERE id=$1 AND "orgId"=$2`
-
-	var unit model.MmdUnit
-	logger.Debug(`Query`, zap.String("query", query))
-	err := conn.QueryRow(ctx, query, id, orgId).Scan(
-		&unit.ID,
-		&unit.OrgID,
-		&unit.UnitID,
-		&unit.UnitName,
-		&unit.UnitSourceID,
-		&unit.UnitTypeID,
-		&unit.Priority,
-		&unit.CompID,
-		&unit.DeptID,
-		&unit.CommID,
-		&unit.StnID,
-		&unit.PlateNo,
-		&unit.ProvinceCode,
-		&unit.Active,
-		&unit.Username,
-		&unit.IsLogin,
-		&unit.IsFreeze,
-		&unit.IsOutArea,
-		&unit.LocLat,
-		&unit.LocLon,
-		&unit.LocAlt,
-		&unit.LocBearing,
-		&unit.LocSpeed,
-		&unit.LocProvider,
-		&unit.LocGpsTime,
-		&unit.LocSatellites,
-		&unit.LocAccuracy,
-		&unit.LocLastUpdateTime,
-		&unit.BreakDuration,
-		&unit.HealthChk,
-		&unit.HealthChkTime,
-		&unit.SttID,
-		&unit.CreatedAt,
-		&unit.UpdatedAt,
-		&unit.CreatedBy,
-		&unit.UpdatedBy,
-	)
+
+	unit, err := repo.Units.GetByID(ctx, orgId, id)
 	if err != nil {
 		logger.Warn("Query failed", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, model.Response{
@@ -1774,12 +1014,8 @@ func GetMmdUnitById(c *gin.Context) {
 // @Router /api/v1/mdm/units/add [post]
 func InsertMmdUnit(c *gin.Context) {
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
 
 	var unit model.MmdUnitInsert
 	if err := c.ShouldBindJSON(&unit); err != nil {
@@ -1791,57 +1027,11 @@ func InsertMmdUnit(c *gin.Context) {
 		logger.Warn("Insert failed", zap.Error(err))
 		return
 	}
-	username := GetVariableFromToken(c, "username")
-	now := time.Now()
-	var id int
-	orgId := GetVariableFromToken(c, "orgId")
-	query := `INSERT INTO public.mdm_units(
-	"orgId", "unitId", "unitName", "unitSourceId", "unitTypeId", priority, "compId", "deptId", "commId",
-	 "stnId", "plateNo", "provinceCode", active, username, "isLogin", "isFreeze", "isOutArea", "locLat",
-	  "locLon", "locAlt", "locBearing", "locSpeed", "locProvider", "locGpsTime", "locSatellites", "locAccuracy",
-	   "locLastUpdateTime", "breakDuration", "healthChk", "healthChkTime", "sttId", "createdAt", "updatedAt",
-	    "createdBy", "updatedBy")
-	VALUES ($1, $2, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15,
-	 $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28, $29,
-	  $30, $31, $32, $33, $34, $35)
-	RETURNING id ;
-	`
-
-	err := conn.QueryRow(ctx, query,
-		orgId,
-		unit.UnitID,
-		unit.UnitName,
-		unit.UnitSourceID,
-		unit.UnitTypeID,
-		unit.Priority,
-		unit.CompID,
-		unit.DeptID,
-		unit.CommID,
-		unit.StnID,
-		unit.PlateNo,
-		unit.ProvinceCode,
-		unit.Active,
-		unit.Username,
-		unit.IsLogin,
-		unit.IsFreeze,
-		unit.IsOutArea,
-		unit.LocLat,
-		unit.LocLon,
-		unit.LocAlt,
-		unit.LocBearing,
-		unit.LocSpeed,
-		unit.LocProvider,
-		unit.LocGpsTime,
-		unit.LocSatellites,
-		unit.LocAccuracy,
-		unit.LocLastUpdateTime,
-		unit.BreakDuration,
-		unit.HealthChk,
-		unit.HealthChkTime,
-		unit.SttID, now, now, username, username).Scan(&id)
+	username := tokenString(c, "username")
+	orgId := tokenString(c, "orgId")
 
+	_, err := repo.Units.Create(ctx, orgId, username, unit)
 	if err != nil {
-		// log.Printf("Insert failed: %v", err)
 		c.JSON(http.StatusInternalServerError, model.Response{
 			Status: "-1",
 			Msg:    "Failure",
@@ -1871,12 +1061,8 @@ func InsertMmdUnit(c *gin.Context) {
 // @Router /api/v1/mdm/units/{id} [patch]
 func UpdateMmdUnit(c *gin.Context) {
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
 
 	id := c.Param("id")
 
@@ -1890,35 +1076,11 @@ func UpdateMmdUnit(c *gin.Context) {
 		})
 		return
 	}
-	now := time.Now()
-	username := GetVariableFromToken(c, "username")
-	orgId := GetVariableFromToken(c, "orgId")
-	query := `UPDATE public."mdm_units"
-	SET "unitId"=$2, "unitName"=$3, "unitSourceId"=$4, "unitTypeId"=$5, priority=$6, "compId"=$7,
-	 "deptId"=$8, "commId"=$9, "stnId"=$10, "plateNo"=$11, "provinceCode"=$12, active=$13, username=$14,
-	  "isLogin"=$15, "isFreeze"=$16, "isOutArea"=$17, "locLat"=$18, "locLon"=$19, "locAlt"=$20, "locBearing"=$21,
-	   "locSpeed"=$22, "locProvider"=$23, "locGpsTime"=$24, "locSatellites"=$25, "locAccuracy"=$26,
-	    "locLastUpdateTime"=$27, "breakDuration"=$28, "healthChk"=$29, "healthChkTime"=$30, "sttId"=$31,
-		 "updatedAt"=$32, "updatedBy"=$33
-	WHERE id = $1 AND "orgId"=$34`
-	_, err := conn.Exec(ctx, query,
-		id, req.UnitID, req.UnitName, req.UnitSourceID, req.UnitTypeID, req.Priority, req.CompID,
-		req.DeptID, req.CommID, req.StnID, req.PlateNo, req.ProvinceCode, req.Active, req.Username,
-		req.IsLogin, req.IsFreeze, req.IsOutArea, req.LocLat, req.LocLon, req.LocAlt, req.LocBearing,
-		req.LocSpeed, req.LocProvider, req.LocGpsTime, req.LocSatellites, req.LocAccuracy,
-		req.LocLastUpdateTime, req.BreakDuration, req.HealthChk, req.HealthChkTime, req.SttID, now, username, orgId,
-	)
-	logger.Debug("Update Case SQL Args",
-		zap.String("query", query),
-		zap.Any("Input", []any{
-			id, req.UnitID, req.UnitName, req.UnitSourceID, req.UnitTypeID, req.Priority, req.CompID,
-			req.DeptID, req.CommID, req.StnID, req.PlateNo, req.ProvinceCode, req.Active, req.Username,
-			req.IsLogin, req.IsFreeze, req.IsOutArea, req.LocLat, req.LocLon, req.LocAlt, req.LocBearing,
-			req.LocSpeed, req.LocProvider, req.LocGpsTime, req.LocSatellites, req.LocAccuracy,
-			req.LocLastUpdateTime, req.BreakDuration, req.HealthChk, req.HealthChkTime, req.SttID, now, username, orgId,
-		}))
+	username := tokenString(c, "username")
+	orgId := tokenString(c, "orgId")
+	logger.Debug("Update unit", zap.String("id", id), zap.Any("Input", req))
+	err := repo.Units.Update(ctx, orgId, id, username, req)
 	if err != nil {
-		// log.Printf("Insert failed: %v", err)
 		c.JSON(http.StatusInternalServerError, model.Response{
 			Status: "-1",
 			Msg:    "Failure",
@@ -1948,17 +1110,12 @@ func UpdateMmdUnit(c *gin.Context) {
 func DeleteMmdUnit(c *gin.Context) {
 
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
-	orgId := GetVariableFromToken(c, "orgId")
+	orgId := tokenString(c, "orgId")
 	id := c.Param("id")
-	query := `DELETE FROM public."mdm_units" WHERE id = $1 AND "orgId"=$2`
-	logger.Debug("Query", zap.String("query", query), zap.Any("id", id))
-	_, err := conn.Exec(ctx, query, id, orgId)
+	logger.Debug("Delete unit", zap.Any("id", id))
+	err := repo.Units.Delete(ctx, orgId, id)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, model.Response{
 			Status: "-1",
@@ -1990,12 +1147,8 @@ func DeleteMmdUnit(c *gin.Context) {
 func GetMmdUnitWithProperty(c *gin.Context) {
 	logger := config.GetLog()
 
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
 	startStr := c.DefaultQuery("start", "0")
 	start, err := strconv.Atoi(startStr)
 	if err != nil {
@@ -2007,13 +1160,8 @@ func GetMmdUnitWithProperty(c *gin.Context) {
 		length = 1000
 	}
 	unitId := c.Param("unitId")
-	orgId := GetVariableFromToken(c, "orgId")
-	query := `SELECT id, "orgId", "unitId", "propId", active, "createdAt", "updatedAt", "createdBy", "updatedBy"
-	FROM public.mdm_unit_with_properties WHERE "orgId"= $3 AND "unitId"=$4 LIMIT $1 OFFSET $2`
-
-	var rows pgx.Rows
-	logger.Debug(`Query`, zap.String("query", query))
-	rows, err = conn.Query(ctx, query, length, start, orgId, unitId)
+	orgId := tokenString(c, "orgId")
+	unitList, err := repo.Units.ListProperties(ctx, orgId, unitId, length, start)
 	if err != nil {
 		logger.Warn("Query failed", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, model.Response{
@@ -2023,41 +1171,11 @@ func GetMmdUnitWithProperty(c *gin.Context) {
 		})
 		return
 	}
-	defer rows.Close()
-	var errorMsg string
-	var unit model.MdmUnitProperty
-	var unitList []model.MdmUnitProperty
-	found := false
-	for rows.Next() {
-		err := rows.Scan(
-			&unit.ID,
-			&unit.OrgID,
-			&unit.UnitID,
-			&unit.PropID,
-			&unit.Active,
-			&unit.CreatedAt,
-			&unit.UpdatedAt,
-			&unit.CreatedBy,
-			&unit.UpdatedBy,
-		)
-		if err != nil {
-			logger.Warn("Scan failed", zap.Error(err))
-			response := model.Response{
-				Status: "-1",
-				Msg:    "Failed",
-				Desc:   errorMsg,
-			}
-			c.JSON(http.StatusInternalServerError, response)
-			return
-		}
-		unitList = append(unitList, unit)
-		found = true
-	}
-	if !found {
+	if len(unitList) == 0 {
 		response := model.Response{
 			Status: "-1",
 			Msg:    "Failed",
-			Desc:   errorMsg,
+			Desc:   "",
 		}
 		c.JSON(http.StatusInternalServerError, response)
 	} else {
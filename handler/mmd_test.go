@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"mainPackage/model"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestMmdUnitStatus(t *testing.T) {
+	newTestStore(t)
+	r := gin.New()
+	v1 := r.Group("/api/v1", ProtectedHandler)
+	v1.GET("/mdm/status", GetMmdUnitStatus)
+	v1.GET("/mdm/status/:id", GetMmdUnitStatusById)
+	v1.POST("/mdm/status/add", InsertMmdUnitStatus)
+	v1.PATCH("/mdm/status/:id", UpdateMmdUnitStatus)
+	v1.DELETE("/mdm/status/:id", DeleteMmdUnitStatus)
+	tok := accessToken(t)
+
+	for _, in := range []model.MmdUnitStatusInsert{{SttID: "S001", SttName: "Available"}, {SttID: "S002", SttName: "Busy"}} {
+		if code := do(t, r, http.MethodPost, "/api/v1/mdm/status/add", tok, in, nil); code != http.StatusOK {
+			t.Fatalf("add %s: %d", in.SttID, code)
+		}
+	}
+	if code := do(t, r, http.MethodPost, "/api/v1/mdm/status/add", tok, model.MmdUnitStatusInsert{SttID: "S001"}, nil); code != http.StatusInternalServerError {
+		t.Errorf("duplicate sttId: %d, want 500", code)
+	}
+	var list struct {
+		Data []model.MmdUnitStatus `json:"data"`
+	}
+	if do(t, r, http.MethodGet, "/api/v1/mdm/status?length=1&start=1", tok, nil, &list); len(list.Data) != 1 || list.Data[0].SttID != "S002" {
+		t.Fatalf("second page = %+v, want S002", list.Data)
+	}
+	id := list.Data[0].ID
+
+	if code := do(t, r, http.MethodPatch, "/api/v1/mdm/status/"+id, tok, model.MmdUnitStatusUpdate{SttID: "S002", SttName: "On scene"}, nil); code != http.StatusOK {
+		t.Fatalf("update: %d", code)
+	}
+	var got struct {
+		Data model.MmdUnitStatus `json:"data"`
+	}
+	if do(t, r, http.MethodGet, "/api/v1/mdm/status/"+id, tok, nil, &got); got.Data.SttName != "On scene" || got.Data.UpdatedBy != "alice" {
+		t.Errorf("after the update = %+v", got.Data)
+	}
+	if code := do(t, r, http.MethodDelete, "/api/v1/mdm/status/"+id, tok, nil, nil); code != http.StatusOK {
+		t.Fatalf("delete: %d", code)
+	}
+	if code := do(t, r, http.MethodGet, "/api/v1/mdm/status/"+id, tok, nil, nil); code != http.StatusNotFound {
+		t.Errorf("get after the delete: %d, want 404", code)
+	}
+}
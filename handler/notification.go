@@ -1,11 +1,12 @@
 package handler
 
 import (
-	"encoding/json"
-	"fmt"
+	"context"
+	"encoding/base64"
+	"errors"
 	"log"
-	"mainPackage/config"
 	"mainPackage/model"
+	"mainPackage/store"
 	"math/rand"
 	"net/http"
 	"strconv"
@@ -13,8 +14,7 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgtype" // Import pgtype to handle nullable fields
+	// Import pgtype to handle nullable fields
 )
 
 // --- Helper Functions ---
@@ -41,6 +41,7 @@ func RandomString(n int) string {
 // @Param notifications body []model.NotificationCreateRequest true "A JSON array of notification objects. The `data` field should be an array of key-value objects, e.g., `\"data\": [{\"key\": \"caseId\", \"value\": \"C1122\"}]`. Do not include 'id' or 'createdAt' fields."
 // @Success 201 {array} model.Notification "Notifications created successfully"
 // @Failure 400 {object} map[string]string "Invalid request body"
+// @Failure 403 {object} map[string]string "orgId of another organization"
 // @Failure 500 {object} map[string]string "Internal server error (e.g., database transaction failure)"
 // @Router /api/v1/notifications [post]
 func CreateNotifications(c *gin.Context) {
@@ -50,6 +51,17 @@ func CreateNotifications(c *gin.Context) {
 		return
 	}
 
+	orgId := tokenString(c, "orgId")
+	for i := range inputs {
+		if inputs[i].OrgID == "" {
+			inputs[i].OrgID = orgId
+		}
+		if inputs[i].OrgID != orgId {
+			c.JSON(http.StatusForbidden, gin.H{"error": "notification orgId does not match the caller's organization"})
+			return
+		}
+	}
+
 	createdNotifications, err := CoreNotifications(c.Request.Context(), inputs)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -87,73 +99,47 @@ func UpdateNotification(c *gin.Context) {
 		return
 	}
 
-	recipientsJSON, err := json.Marshal(input.Recipients)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to process recipients", "detail": err.Error()})
-		return
-	}
-	dataJSON, err := json.Marshal(input.Data)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to process custom data", "detail": err.Error()})
-		return
-	}
-
-	conn, ctx, cancel := config.ConnectDB()
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
 
-	// UPDATED: SQL UPDATE statement to include new updatable fields like expiredAt
-	tag, err := conn.Exec(ctx, `
-        UPDATE notifications
-        SET "message" = $1, "eventType" = $2, "redirectUrl" = $3, "recipients" = $4, "data" = $5, "expiredAt" = $6
-        WHERE "id" = $7
-    `, input.Message, input.EventType, input.RedirectUrl, recipientsJSON, dataJSON, input.ExpiredAt, id)
-
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "database update failed", "detail": err.Error()})
+	// the row and its notification_recipients are rewritten together, so a
+	// failure part way cannot leave the notification without recipients
+	err = repo.InTx(ctx, func(tx *store.Store) error {
+		if err := ownNotification(ctx, tx, c, id); err != nil {
+			return err
+		}
+		return tx.Notifications.Update(ctx, id, input)
+	})
+	if errors.Is(err, store.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "notification not found with the given ID"})
 		return
 	}
-
-	if tag.RowsAffected() == 0 {
-		c.JSON(http.StatusNotFound, gin.H{"error": "notification not found with the given ID"})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "database update failed", "detail": err.Error()})
 		return
 	}
 
-	var updatedNoti model.Notification
-	var recipientsStr, dataStr []byte
-	// FIX: Use pgtype for nullable fields to prevent scan errors
-	var senderPhoto, createdBy pgtype.Text
-	var expiredAt pgtype.Timestamptz
-
-	// UPDATED: SQL SELECT to retrieve all fields from the new model
-	err = conn.QueryRow(ctx, `
-		SELECT "id", "orgId", "senderType", "sender", "senderPhoto", "message", "eventType", "redirectUrl", "createdAt", "createdBy", "expiredAt", "recipients", "data" 
-		FROM notifications WHERE "id" = $1
-	`, id).Scan(
-		&updatedNoti.ID, &updatedNoti.OrgID, &updatedNoti.SenderType, &updatedNoti.Sender, &senderPhoto, &updatedNoti.Message,
-		&updatedNoti.EventType, &updatedNoti.RedirectUrl, &updatedNoti.CreatedAt, &createdBy, &expiredAt, &recipientsStr, &dataStr,
-	)
-
+	updatedNoti, err := repo.Notifications.Get(ctx, id)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve updated notification", "detail": err.Error()})
 		return
 	}
 
-	// FIX: Assign values from pgtype variables to the struct if they are valid (not NULL)
-	if senderPhoto.Valid {
-		updatedNoti.SenderPhoto = senderPhoto.String
-	}
-	if createdBy.Valid {
-		updatedNoti.CreatedBy = createdBy.String
+	c.JSON(http.StatusOK, updatedNoti)
+}
+
+// ownNotification returns ErrNotFound unless notification id belongs to the
+// caller's organization, so other organizations' ids look absent.
+func ownNotification(ctx context.Context, repo *store.Store, c *gin.Context, id int64) error {
+	noti, err := repo.Notifications.Get(ctx, id)
+	if err != nil {
+		return err
 	}
-	if expiredAt.Valid {
-		updatedNoti.ExpiredAt = expiredAt.Time
+	if noti.OrgID != tokenString(c, "orgId") {
+		return store.ErrNotFound
 	}
-
-	json.Unmarshal(recipientsStr, &updatedNoti.Recipients)
-	json.Unmarshal(dataStr, &updatedNoti.Data)
-
-	c.JSON(http.StatusOK, updatedNoti)
+	auditPrior(c, noti)
+	return nil
 }
 
 // DeleteNotification godoc
@@ -175,278 +161,227 @@ func DeleteNotification(c *gin.Context) {
 		return
 	}
 
-	conn, ctx, cancel := config.ConnectDB()
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
 
-	tag, err := conn.Exec(ctx, `DELETE FROM notifications WHERE "id" = $1`, id)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "database delete failed", "detail": err.Error()})
-		return
+	err = ownNotification(ctx, repo, c, id)
+	if err == nil {
+		err = repo.Notifications.Delete(ctx, id)
 	}
-
-	if tag.RowsAffected() == 0 {
+	if errors.Is(err, store.ErrNotFound) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "notification not found with the given ID"})
 		return
 	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "database delete failed", "detail": err.Error()})
+		return
+	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "notification deleted successfully", "id": id})
 }
 
 // GetNotificationsForUser godoc
 // @Summary Get all notifications for a specific user
-// @Description Retrieves all notifications intended for a user by their username and organization ID.
+// @Description Retrieves all notifications intended for a user by their username and organization ID. The organization must be the caller's, and reading another user's notifications needs the user.view permission. Deprecated: use GET /api/v1/notifications/me, which pages and filters the caller's own inbox.
+// @Deprecated
 // @Tags Notifications
 // @security ApiKeyAuth
 // @Produce json
 // @Param orgId path string true "Organization ID of the user"
 // @Param username path string true "Username to fetch notifications for"
 // @Success 200 {array} model.Notification
+// @Failure 403 {object} map[string]string "Another organization or user"
 // @Failure 404 {object} map[string]string "User not found"
 // @Failure 500 {object} map[string]string "Database error"
 // @Router /api/v1/notifications/{orgId}/{username} [get]
 func GetNotificationsForUser(c *gin.Context) {
 	orgId := c.Param("orgId")
 	username := c.Param("username")
+	if orgId != tokenString(c, "orgId") ||
+		(username != tokenString(c, "username") && !hasPermission(c, PermUserView)) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "cannot read another user's notifications"})
+		return
+	}
 	log.Printf("Fetching notifications for username: %s in org: %s", username, orgId)
 
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "could not connect to the database"})
+	repo, ctx, cancel := getStore(c.Request.Context())
+	defer cancel()
+
+	notifications, err := repo.Notifications.ListForUser(ctx, orgId, username)
+	if errors.Is(err, store.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "user not found in the specified organization"})
 		return
 	}
-	defer cancel()
-	defer conn.Close(ctx)
-
-	// 1) ดึงโปรไฟล์ผู้ใช้ - แคสต์เป็น text ให้หมด และแก้ COALESCE grpId
-	var userProfile model.UserProfile
-	err := conn.QueryRow(ctx, `
-		SELECT 
-			u."empId"::text,
-			u."orgId"::text,
-			u."roleId"::text,
-			u."deptId"::text,
-			u."stnId"::text,
-			u."commId"::text,
-			COALESCE(ug."grpId"::text, '') AS "grpId"
-		FROM um_users u
-		LEFT JOIN um_user_with_groups ug ON u."username" = ug."username"
-		WHERE u."username" = $1 
-		  AND u."orgId"::text = $2 
-		  AND u."active" = true
-	`, username, orgId).Scan(
-		&userProfile.EmpID,
-		&userProfile.OrgID,
-		&userProfile.RoleID,
-		&userProfile.DeptID,
-		&userProfile.StnID,
-		&userProfile.CommID,
-		&userProfile.GrpID,
-	)
 	if err != nil {
-		if err == pgx.ErrNoRows {
-			c.JSON(http.StatusNotFound, gin.H{"error": "user not found in the specified organization"})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":  "failed to fetch user profile",
-			"detail": err.Error(),
-		})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch notifications", "detail": err.Error()})
 		return
 	}
 
-	// 2) สร้างเงื่อนไขค้นหาด้วย recipients
-	conditions := []string{}
-	args := []interface{}{userProfile.OrgID} // $1 = orgId เสมอ
+	c.JSON(http.StatusOK, notifications)
+}
 
-	// helper: เพิ่มเงื่อนไข type/value (รองรับ value ที่คั่นด้วยคอมมาในฝั่ง notification)
-	addCond := func(recipientType, userValue string) {
-		if strings.TrimSpace(userValue) == "" {
-			return
-		}
-		// ณ ตอนนี้ len(args) = จำนวน args ที่มีอยู่แล้ว
-		// จะใช้ $N = type, $N+1 = value (อ้างซ้ำได้ใน LIKE)
-		typeIdx := len(args) + 1
-		valIdx := len(args) + 2
-
-		cond := fmt.Sprintf(`(
-			EXISTS (
-				SELECT 1
-				FROM jsonb_array_elements("recipients") AS recipient
-				WHERE recipient->>'type' = $%d
-				  AND (
-						recipient->>'value' = $%d
-					 OR recipient->>'value' LIKE $%d || ',%%'
-					 OR recipient->>'value' LIKE '%%,' || $%d
-					 OR recipient->>'value' LIKE '%%,' || $%d || ',%%'
-				  )
-			)
-		)`, typeIdx, valIdx, valIdx, valIdx, valIdx)
-
-		conditions = append(conditions, cond)
-		args = append(args, recipientType, userValue) // เพิ่มแค่ 2 ค่า: type, value
-	}
-
-	// เพิ่มเงื่อนไขพื้นฐาน
-	addCond("empId", userProfile.EmpID)
-	addCond("roleId", userProfile.RoleID)
-	addCond("deptId", userProfile.DeptID)
-	addCond("stnId", userProfile.StnID)
-	addCond("commId", userProfile.CommID)
-	addCond("orgId", userProfile.OrgID)
-	addCond("username", username)
-	addCond("grpId", userProfile.GrpID)
-
-	// 3) provId: user อยู่จังหวัดไหน (เทียบจาก distIdLists -> join area_districts)
-	// เพิ่ม $ สำหรับ empId
-	// --- provId: ผู้ใช้สังกัดจังหวัดไหนจาก distIdLists ---
-	provIdx := len(args) + 1
-	args = append(args, username) // <— ใช้ username แทน empId
-	provCondition := fmt.Sprintf(`(
-    EXISTS (
-        SELECT 1
-        FROM jsonb_array_elements("recipients") AS recipient
-        WHERE recipient->>'type' = 'provId'
-          AND EXISTS (
-                SELECT 1
-                FROM um_user_with_area_response uar
-                JOIN LATERAL jsonb_array_elements_text(uar."distIdLists"::jsonb) AS d(distId) ON TRUE
-                JOIN area_districts ad ON ad."distId" = d.distId
-                WHERE uar."username" = $%d
-                  AND (
-                        recipient->>'value' = ad."provId"
-                     OR recipient->>'value' LIKE ad."provId" || ',%%'
-                     OR recipient->>'value' LIKE '%%,' || ad."provId"
-                     OR recipient->>'value' LIKE '%%,' || ad."provId" || ',%%'
-                  )
-          )
-    )
-)`, provIdx)
-	conditions = append(conditions, provCondition)
-
-	// 4) distId: user อยู่ในอำเภอไหน (เทียบตรงกับ distIdLists ของ user)
-	distIdx := len(args) + 1
-	args = append(args, username) // <— ใช้ username แทน empId
-	distCondition := fmt.Sprintf(`(
-    EXISTS (
-        SELECT 1
-        FROM jsonb_array_elements("recipients") AS recipient
-        WHERE recipient->>'type' = 'distId'
-          AND EXISTS (
-                SELECT 1
-                FROM um_user_with_area_response uar
-                JOIN LATERAL jsonb_array_elements_text(uar."distIdLists"::jsonb) AS d(distId) ON TRUE
-                WHERE uar."username" = $%d
-                  AND (
-                        recipient->>'value' = d.distId
-                     OR recipient->>'value' LIKE d.distId || ',%%'
-                     OR recipient->>'value' LIKE '%%,' || d.distId
-                     OR recipient->>'value' LIKE '%%,' || d.distId || ',%%'
-                  )
-          )
-    )
-)`, distIdx)
-	conditions = append(conditions, distCondition)
-	fullCondition := strings.Join(conditions, " OR ")
-	if fullCondition == "" {
-		// กันเคสไม่มีเงื่อนไขเลย (ไม่น่าเกิด แต่กันไว้)
-		fullCondition = "TRUE"
-	}
-
-	query := fmt.Sprintf(`
-		SELECT 
-			"id", "orgId", "senderType", "sender", "senderPhoto",
-			"message", "eventType", "redirectUrl", "createdAt", 
-			"createdBy", "expiredAt", "recipients", "data"
-		FROM notifications
-		WHERE "orgId" = $1 AND (%s)
-		ORDER BY "createdAt" DESC
-	`, fullCondition)
-
-	rows, err := conn.Query(ctx, query, args...)
+const (
+	inboxDefaultLimit = 20
+	inboxMaxLimit     = 100
+)
+
+// encodeInboxCursor turns the last row of a page into the opaque cursor of
+// the next one.
+func encodeInboxCursor(n model.Notification) string {
+	raw := n.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + strconv.Itoa(n.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeInboxCursor(cursor string) (*model.NotificationCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":  "failed to query notifications",
-			"detail": err.Error(),
-			"query":  query,
-		})
-		return
+		return nil, errors.New("invalid cursor")
 	}
-	defer rows.Close()
-
-	var notifications []model.Notification
-	for rows.Next() {
-		var n model.Notification
-		var recipientsStr, dataStr []byte
-		var senderPhoto, createdBy pgtype.Text
-		var expiredAt pgtype.Timestamptz
-		var redirectUrl pgtype.Text
-
-		if err := rows.Scan(
-			&n.ID, &n.OrgID, &n.SenderType, &n.Sender, &senderPhoto,
-			&n.Message, &n.EventType, &redirectUrl, &n.CreatedAt,
-			&createdBy, &expiredAt, &recipientsStr, &dataStr,
-		); err != nil {
-			log.Printf("Error scanning notification row: %v", err)
-			continue
-		}
+	at, id, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return nil, errors.New("invalid cursor")
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, at)
+	if err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+	n, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+	return &model.NotificationCursor{CreatedAt: createdAt, ID: n}, nil
+}
 
-		if senderPhoto.Valid {
-			n.SenderPhoto = senderPhoto.String
+// inboxFilter reads the query parameters of GetMyNotifications.
+func inboxFilter(c *gin.Context) (model.NotificationInboxFilter, error) {
+	f := model.NotificationInboxFilter{EventType: c.Query("eventType"), State: c.Query("state"), Limit: inboxDefaultLimit}
+	switch f.State {
+	case "", model.NotificationUnread, model.NotificationRead, model.NotificationAcknowledged, model.NotificationDismissed:
+	default:
+		return f, errors.New("state must be unread, read, acknowledged or dismissed")
+	}
+	for name, dst := range map[string]**time.Time{"from": &f.From, "to": &f.To} {
+		v := c.Query(name)
+		if v == "" {
+			continue
 		}
-		if createdBy.Valid {
-			n.CreatedBy = createdBy.String
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return f, errors.New(name + " must be an RFC 3339 time")
 		}
-		if redirectUrl.Valid {
-			n.RedirectUrl = redirectUrl.String
+		*dst = &t
+	}
+	if v := c.Query("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return f, errors.New("limit must be a positive number")
 		}
-		if expiredAt.Valid {
-			n.ExpiredAt = expiredAt.Time
+		f.Limit = min(n, inboxMaxLimit)
+	}
+	if v := c.Query("cursor"); v != "" {
+		cursor, err := decodeInboxCursor(v)
+		if err != nil {
+			return f, err
 		}
+		f.Before = cursor
+	}
+	return f, nil
+}
 
-		_ = json.Unmarshal(recipientsStr, &n.Recipients)
-		_ = json.Unmarshal(dataStr, &n.Data)
-		notifications = append(notifications, n)
+// GetMyNotifications godoc
+// @Summary Get the caller's notifications
+// @Description Lists the notifications addressed to the caller, newest first, one page at a time. Pass the nextCursor of a page as cursor to get the next one; nextCursor is empty on the last page.
+// @Tags Notifications
+// @security ApiKeyAuth
+// @Produce json
+// @Param eventType query string false "Only this event type"
+// @Param from query string false "Created at or after (RFC 3339)"
+// @Param to query string false "Created before (RFC 3339)"
+// @Param state query string false "unread, read, acknowledged or dismissed"
+// @Param cursor query string false "nextCursor of the previous page"
+// @Param limit query int false "Page size, at most 100" default(20)
+// @Success 200 {object} model.NotificationPage
+// @Failure 400 {object} map[string]string "Invalid filter or cursor"
+// @Failure 404 {object} map[string]string "User not found"
+// @Failure 500 {object} map[string]string "Database error"
+// @Router /api/v1/notifications/me [get]
+func GetMyNotifications(c *gin.Context) {
+	f, err := inboxFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	repo, ctx, cancel := getStore(c.Request.Context())
+	defer cancel()
+
+	limit := f.Limit
+	f.Limit = limit + 1
+	list, err := repo.Notifications.ListInbox(ctx, tokenString(c, "orgId"), tokenString(c, "username"), f)
+	if errors.Is(err, store.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "user not found in the specified organization"})
+		return
 	}
-	if err := rows.Err(); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "error iterating notification rows", "detail": err.Error()})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch notifications", "detail": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, notifications)
+	page := model.NotificationPage{Items: list}
+	if len(list) > limit {
+		page.Items = list[:limit]
+		page.NextCursor = encodeInboxCursor(list[limit-1])
+	}
+	if page.Items == nil {
+		page.Items = []model.Notification{}
+	}
+	c.JSON(http.StatusOK, page)
 }
 
 // --- Background Job for Auto-Deletion ---
 
-// DeleteExpiredNotifications connects to the database and deletes all notifications
+// DeleteExpiredNotifications uses the shared pool to delete all notifications
 // where the 'expiredAt' timestamp is in the past.
 func DeleteExpiredNotifications() {
 	log.Println("Scheduler: Running job to delete expired notifications...")
 
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		log.Println("Scheduler Error: could not connect to the database")
-		return
-	}
+	repo, ctx, cancel := getStore(context.Background())
 	defer cancel()
-	defer conn.Close(ctx)
 
 	// Delete notifications where expiredAt is not NULL and is older than the current time
-	tag, err := conn.Exec(ctx, `DELETE FROM notifications WHERE "expiredAt" IS NOT NULL AND "expiredAt" < NOW()`)
+	deleted, err := repo.Notifications.DeleteExpired(ctx, time.Now())
 	if err != nil {
 		log.Printf("Scheduler Error: database delete failed: %v", err)
 		return
 	}
 
-	if tag.RowsAffected() > 0 {
-		log.Printf("Scheduler: Successfully deleted %d expired notifications.", tag.RowsAffected())
+	if deleted > 0 {
+		log.Printf("Scheduler: Successfully deleted %d expired notifications.", deleted)
 	} else {
 		log.Println("Scheduler: No expired notifications to delete.")
 	}
 }
 
-// StartAutoDeleteScheduler starts a ticker that runs the DeleteExpiredNotifications
-// function at a regular interval (e.g., every hour).
+// DeleteExpiredRefreshTokens drops refresh token rows past their expiry; an
+// expired token is rejected on signature alone, so the row is no longer
+// needed for reuse detection.
+func DeleteExpiredRefreshTokens() {
+	repo, ctx, cancel := getStore(context.Background())
+	defer cancel()
+
+	deleted, err := repo.Tokens.DeleteExpired(ctx, time.Now())
+	if err != nil {
+		log.Printf("Scheduler Error: refresh token delete failed: %v", err)
+		return
+	}
+	if deleted > 0 {
+		log.Printf("Scheduler: Deleted %d expired refresh tokens.", deleted)
+	}
+}
+
+// StartAutoDeleteScheduler starts a ticker that runs DeleteExpiredNotifications,
+// DeleteExpiredRefreshTokens and DeleteDeliveredOutbox at a regular interval
+// (e.g., every hour).
 func StartAutoDeleteScheduler() {
 	log.Println("Starting background scheduler for auto-deleting notifications...")
 	// Run the cleanup job every 1 hour.
@@ -459,6 +394,8 @@ func StartAutoDeleteScheduler() {
 			<-ticker.C
 			// Run the deletion job
 			DeleteExpiredNotifications()
+			DeleteExpiredRefreshTokens()
+			DeleteDeliveredOutbox()
 		}
 	}()
 }
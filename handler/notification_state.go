@@ -0,0 +1,236 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log"
+	"mainPackage/broker"
+	"mainPackage/model"
+	"mainPackage/store"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// unreadCount counts the notifications visible to the user that are neither
+// read nor dismissed.
+func unreadCount(ctx context.Context, repo *store.Store, orgId, username string) (int, error) {
+	return repo.Notifications.CountUnread(ctx, orgId, username)
+}
+
+// pushUnreadCount sends the user's unread count to every session the user has
+// open on this instance.
+func pushUnreadCount(orgId, username string) {
+	var sessions []*wsClient
+	for _, cl := range hub.snapshot() {
+		if cl.info.OrgID == orgId && cl.info.Username == username {
+			sessions = append(sessions, cl)
+		}
+	}
+	if len(sessions) == 0 {
+		return
+	}
+	repo, ctx, cancel := getStore(context.Background())
+	defer cancel()
+	count, err := unreadCount(ctx, repo, orgId, username)
+	if err != nil {
+		log.Printf("ERROR: Failed to count unread notifications for %s: %v", username, err)
+		return
+	}
+	for _, cl := range sessions {
+		hub.enqueue(cl, gin.H{"type": "unread", "count": count})
+	}
+}
+
+// publishUnread tells every instance that the user's unread count changed.
+// If the broker is down the sessions on this instance are still updated.
+func publishUnread(ctx context.Context, orgId, username string) {
+	err := notiBroker.Publish(ctx, broker.Message{Kind: broker.KindUnread, OrgID: orgId, Username: username})
+	if err != nil {
+		log.Printf("WARNING: Failed to publish unread count for %s: %v", username, err)
+		go pushUnreadCount(orgId, username)
+	}
+}
+
+// GetUnreadNotificationCount godoc
+// @Summary Get the caller's unread notification count
+// @Description Counts the notifications addressed to the caller that are neither read nor dismissed.
+// @Tags Notifications
+// @security ApiKeyAuth
+// @Produce json
+// @Success 200 {object} map[string]int "Unread count"
+// @Failure 404 {object} map[string]string "User not found"
+// @Failure 500 {object} map[string]string "Database error"
+// @Router /api/v1/notifications/unread_count [get]
+func GetUnreadNotificationCount(c *gin.Context) {
+	repo, ctx, cancel := getStore(c.Request.Context())
+	defer cancel()
+
+	count, err := unreadCount(ctx, repo, tokenString(c, "orgId"), tokenString(c, "username"))
+	if errors.Is(err, store.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "user not found in the specified organization"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to count notifications", "detail": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"count": count})
+}
+
+// MarkNotificationsRead godoc
+// @Summary Mark notifications read
+// @Description Marks the given notifications, or with all=true every notification addressed to the caller, as read by the caller. Ids the caller cannot see are ignored.
+// @Tags Notifications
+// @security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param input body model.NotificationReadInput true "ids to mark, or all"
+// @Success 200 {object} map[string]int "Number of notifications newly marked and the unread count"
+// @Failure 400 {object} map[string]string "Neither ids nor all given"
+// @Failure 500 {object} map[string]string "Database error"
+// @Router /api/v1/notifications/read [post]
+func MarkNotificationsRead(c *gin.Context) {
+	var input model.NotificationReadInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body", "detail": err.Error()})
+		return
+	}
+	if !input.All && len(input.IDs) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ids or all is required"})
+		return
+	}
+
+	orgId := tokenString(c, "orgId")
+	username := tokenString(c, "username")
+	repo, ctx, cancel := getStore(c.Request.Context())
+	defer cancel()
+
+	var among []int64
+	if !input.All {
+		among = input.IDs
+	}
+	ids, err := repo.Notifications.UnreadIDs(ctx, orgId, username, among)
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch notifications", "detail": err.Error()})
+		return
+	}
+
+	var marked int64
+	if len(ids) > 0 {
+		marked, err = repo.Notifications.SetState(ctx, orgId, username, ids, model.NotificationRead, time.Now())
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "database update failed", "detail": err.Error()})
+			return
+		}
+		publishUnread(ctx, orgId, username)
+	}
+	unread, err := unreadCount(ctx, repo, orgId, username)
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to count notifications", "detail": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"marked": marked, "unread": unread})
+}
+
+// MarkNotificationRead godoc
+// @Summary Mark a notification read
+// @Description Marks one notification addressed to the caller as read.
+// @Tags Notifications
+// @security ApiKeyAuth
+// @Produce json
+// @Param id path integer true "Notification ID"
+// @Success 200 {object} model.Notification "Notification with the caller's state"
+// @Failure 404 {object} map[string]string "Notification not found"
+// @Failure 500 {object} map[string]string "Database error"
+// @Router /api/v1/notifications/{id}/read [post]
+func MarkNotificationRead(c *gin.Context) {
+	setNotificationState(c, model.NotificationRead)
+}
+
+// AcknowledgeNotification godoc
+// @Summary Acknowledge a notification
+// @Description Records that the caller acknowledged one notification addressed to them. Acknowledging also marks it read.
+// @Tags Notifications
+// @security ApiKeyAuth
+// @Produce json
+// @Param id path integer true "Notification ID"
+// @Success 200 {object} model.Notification "Notification with the caller's state"
+// @Failure 404 {object} map[string]string "Notification not found"
+// @Failure 500 {object} map[string]string "Database error"
+// @Router /api/v1/notifications/{id}/acknowledge [post]
+func AcknowledgeNotification(c *gin.Context) {
+	setNotificationState(c, model.NotificationAcknowledged, model.NotificationRead)
+}
+
+// DismissNotification godoc
+// @Summary Dismiss a notification
+// @Description Hides one notification addressed to the caller from their unread count.
+// @Tags Notifications
+// @security ApiKeyAuth
+// @Produce json
+// @Param id path integer true "Notification ID"
+// @Success 200 {object} model.Notification "Notification with the caller's state"
+// @Failure 404 {object} map[string]string "Notification not found"
+// @Failure 500 {object} map[string]string "Database error"
+// @Router /api/v1/notifications/{id}/dismiss [post]
+func DismissNotification(c *gin.Context) {
+	setNotificationState(c, model.NotificationDismissed)
+}
+
+// setNotificationState stamps states on the :id notification for the caller,
+// who must be one of its recipients.
+func setNotificationState(c *gin.Context, states ...string) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid notification ID format"})
+		return
+	}
+	orgId := tokenString(c, "orgId")
+	username := tokenString(c, "username")
+	repo, ctx, cancel := getStore(c.Request.Context())
+	defer cancel()
+
+	noti, err := visibleNotification(ctx, repo, orgId, username, id)
+	if errors.Is(err, store.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "notification not found with the given ID"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch notifications", "detail": err.Error()})
+		return
+	}
+
+	wasUnread := noti.State.Unread()
+	now := time.Now()
+	err = repo.InTx(ctx, func(tx *store.Store) error {
+		for _, state := range states {
+			if _, err := tx.Notifications.SetState(ctx, orgId, username, []int64{id}, state, now); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "database update failed", "detail": err.Error()})
+		return
+	}
+
+	noti, err = visibleNotification(ctx, repo, orgId, username, id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve updated notification", "detail": err.Error()})
+		return
+	}
+	if wasUnread && !noti.State.Unread() {
+		publishUnread(ctx, orgId, username)
+	}
+	c.JSON(http.StatusOK, noti)
+}
+
+// visibleNotification returns notification id with the user's state, or
+// ErrNotFound when it is not addressed to the user.
+func visibleNotification(ctx context.Context, repo *store.Store, orgId, username string, id int64) (*model.Notification, error) {
+	return repo.Notifications.GetForUser(ctx, orgId, username, id)
+}
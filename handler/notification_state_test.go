@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"context"
+	"mainPackage/model"
+	"mainPackage/store"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// notify stores a notification for recipients and returns its id.
+func notify(t *testing.T, m *store.Memory, recipients ...model.Recipient) int64 {
+	t.Helper()
+	n := model.Notification{
+		OrgID:      testOrg,
+		SenderType: "SYSTEM",
+		Message:    "hello",
+		CreatedAt:  time.Now(),
+		ExpiredAt:  time.Now().Add(time.Hour),
+		Recipients: recipients,
+	}
+	if err := m.Notifications.Create(context.Background(), &n); err != nil {
+		t.Fatal(err)
+	}
+	return int64(n.ID)
+}
+
+func unreadFor(t *testing.T, r http.Handler, tok string) int {
+	t.Helper()
+	var res struct {
+		Count int `json:"count"`
+	}
+	if code := do(t, r, http.MethodGet, "/api/v1/notifications/unread_count", tok, nil, &res); code != http.StatusOK {
+		t.Fatalf("unread count: %d", code)
+	}
+	return res.Count
+}
+
+func TestMarkNotificationsRead(t *testing.T) {
+	m := newTestStore(t)
+	mine := model.Recipient{Type: "username", Value: "alice"}
+	first := notify(t, m, mine)
+	notify(t, m, model.Recipient{Type: "roleId", Value: "r1"})
+	notify(t, m, mine)
+	other := notify(t, m, model.Recipient{Type: "username", Value: "bob"})
+	r := newRouter()
+	tok := accessToken(t)
+
+	if n := unreadFor(t, r, tok); n != 3 {
+		t.Fatalf("unread = %d, want 3", n)
+	}
+
+	type readResponse struct {
+		Marked int64 `json:"marked"`
+		Unread int   `json:"unread"`
+	}
+	var res readResponse
+	in := model.NotificationReadInput{IDs: []int64{first, other}}
+	if code := do(t, r, http.MethodPost, "/api/v1/notifications/read", tok, in, &res); code != http.StatusOK {
+		t.Fatalf("mark read: %d", code)
+	}
+	if res.Marked != 1 || res.Unread != 2 {
+		t.Errorf("mark read = %+v, want 1 marked and 2 unread; another user's id must be ignored", res)
+	}
+
+	res = readResponse{}
+	if code := do(t, r, http.MethodPost, "/api/v1/notifications/read", tok, in, &res); code != http.StatusOK || res.Marked != 0 {
+		t.Errorf("marking again: %d %+v, want nothing marked", code, res)
+	}
+
+	res = readResponse{}
+	in = model.NotificationReadInput{All: true}
+	if code := do(t, r, http.MethodPost, "/api/v1/notifications/read", tok, in, &res); code != http.StatusOK {
+		t.Fatalf("mark all read: %d", code)
+	}
+	if res.Marked != 2 || res.Unread != 0 {
+		t.Errorf("mark all read = %+v, want 2 marked and none unread", res)
+	}
+	if n := unreadFor(t, r, tok); n != 0 {
+		t.Errorf("unread after mark all = %d, want 0", n)
+	}
+
+	if code := do(t, r, http.MethodPost, "/api/v1/notifications/read", tok, model.NotificationReadInput{}, nil); code != http.StatusBadRequest {
+		t.Errorf("neither ids nor all: %d, want 400", code)
+	}
+}
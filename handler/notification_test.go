@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"context"
+	"mainPackage/model"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestGetMyNotificationsPaging(t *testing.T) {
+	m := newTestStore(t)
+	base := time.Now().Truncate(time.Second)
+	var want []int
+	// Two of the five share a createdAt, so the cursor has to break the
+	// tie by id.
+	for i, age := range []int{4, 3, 3, 2, 1} {
+		eventType := "sos"
+		if i == 0 {
+			eventType = "fire"
+		}
+		n := model.Notification{OrgID: testOrg, EventType: eventType, CreatedAt: base.Add(-time.Duration(age) * time.Minute),
+			ExpiredAt: base.Add(time.Hour), Recipients: []model.Recipient{{Type: "username", Value: "alice"}}}
+		if err := m.Notifications.Create(context.Background(), &n); err != nil {
+			t.Fatal(err)
+		}
+		want = append([]int{n.ID}, want...)
+	}
+	r := newRouter()
+	tok := accessToken(t)
+
+	var got []int
+	cursor, pages := "", 0
+	for {
+		var page model.NotificationPage
+		path := "/api/v1/notifications/me?limit=2"
+		if cursor != "" {
+			path += "&cursor=" + cursor
+		}
+		if code := do(t, r, http.MethodGet, path, tok, nil, &page); code != http.StatusOK {
+			t.Fatalf("page %d: %d", pages+1, code)
+		}
+		pages++
+		for _, n := range page.Items {
+			got = append(got, n.ID)
+		}
+		if cursor = page.NextCursor; cursor == "" || pages > len(want) {
+			break
+		}
+	}
+	if pages != 3 || len(got) != len(want) {
+		t.Fatalf("paged %v in %d pages, want %v in 3", got, pages, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("paged %v, want %v", got, want)
+		}
+	}
+
+	var page model.NotificationPage
+	if code := do(t, r, http.MethodGet, "/api/v1/notifications/me?eventType=fire", tok, nil, &page); code != http.StatusOK {
+		t.Fatalf("filtered: %d", code)
+	}
+	if len(page.Items) != 1 || page.Items[0].ID != want[len(want)-1] || page.NextCursor != "" {
+		t.Errorf("eventType=fire = %+v, want only the oldest", page)
+	}
+
+	for _, q := range []string{"cursor=junk", "limit=0", "state=gone", "from=yesterday"} {
+		if code := do(t, r, http.MethodGet, "/api/v1/notifications/me?"+q, tok, nil, nil); code != http.StatusBadRequest {
+			t.Errorf("%s: %d, want 400", q, code)
+		}
+	}
+}
+
+func TestInboxCursorRoundTrip(t *testing.T) {
+	n := model.Notification{ID: 42, CreatedAt: time.Date(2024, 5, 1, 10, 0, 0, 123456789, time.FixedZone("ICT", 7*3600))}
+	cursor, err := decodeInboxCursor(encodeInboxCursor(n))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cursor.ID != 42 || !cursor.CreatedAt.Equal(n.CreatedAt) {
+		t.Errorf("cursor = %+v, want id 42 at %s", cursor, n.CreatedAt)
+	}
+}
@@ -0,0 +1,275 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"mainPackage/broker"
+	"mainPackage/model"
+	"mainPackage/store"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// outboxBatch is how many entries one relay pass claims at a time.
+	outboxBatch = 50
+	// outboxPoll is how often the relay looks for due entries when nothing
+	// has kicked it.
+	outboxPoll = 5 * time.Second
+	// outboxLease is how long a claimed entry is hidden from other relays;
+	// an entry whose relay dies mid-delivery comes back after it.
+	outboxLease = 30 * time.Second
+	// outboxMaxAttempts is the attempt after which an entry is dead-lettered.
+	outboxMaxAttempts = 8
+	// outboxMaxBackoff caps the wait between attempts.
+	outboxMaxBackoff = 5 * time.Minute
+	// outboxKeep is how long delivered entries stay visible.
+	outboxKeep = 7 * 24 * time.Hour
+)
+
+// outboxChannel delivers one outbox entry. Deliveries are at least once, so
+// a channel must tolerate seeing the same notification again.
+type outboxChannel struct {
+	Name    string
+	Deliver func(ctx context.Context, entry model.OutboxEntry) error
+}
+
+// outboxChannels lists where every notification is delivered; each gets its
+// own outbox entry.
+var outboxChannels = []outboxChannel{
+	{Name: "websocket", Deliver: func(ctx context.Context, entry model.OutboxEntry) error {
+		return notiBroker.Publish(ctx, broker.Message{Kind: broker.KindNotification, NotificationID: entry.NotificationID})
+	}},
+}
+
+func findOutboxChannel(name string) *outboxChannel {
+	for i := range outboxChannels {
+		if outboxChannels[i].Name == name {
+			return &outboxChannels[i]
+		}
+	}
+	return nil
+}
+
+// enqueueDeliveries writes the outbox entries of a notification through repo,
+// so they commit or roll back with it.
+func enqueueDeliveries(ctx context.Context, repo *store.Store, noti model.Notification) error {
+	for _, ch := range outboxChannels {
+		if err := repo.Outbox.Enqueue(ctx, noti.OrgID, int64(noti.ID), ch.Name); err != nil {
+			return fmt.Errorf("outbox insert failed: %w", err)
+		}
+	}
+	return nil
+}
+
+var outboxKick = make(chan struct{}, 1)
+
+// kickOutbox wakes the relay after a commit so fresh notifications go out
+// without waiting for the next poll.
+func kickOutbox() {
+	select {
+	case outboxKick <- struct{}{}:
+	default:
+	}
+}
+
+// StartOutboxRelay delivers due outbox entries until ctx is done. Every
+// instance may run one; claims are exclusive.
+func StartOutboxRelay(ctx context.Context) {
+	log.Println("Starting notification outbox relay...")
+	ticker := time.NewTicker(outboxPoll)
+	defer ticker.Stop()
+	for {
+		for {
+			n, err := relayOutbox(ctx)
+			if err != nil {
+				log.Printf("ERROR: Outbox relay failed: %v", err)
+			}
+			if err != nil || n < outboxBatch {
+				break
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-outboxKick:
+		}
+	}
+}
+
+// relayOutbox claims one batch of due entries and delivers it, returning how
+// many entries it claimed. Deliveries may take a while each, so the claim
+// and every mark run under their own query timeout rather than one shared
+// across the batch.
+func relayOutbox(ctx context.Context) (int, error) {
+	repo, qctx, cancel := getStore(ctx)
+	entries, err := repo.Outbox.Claim(qctx, time.Now(), outboxLease, outboxBatch)
+	cancel()
+	if err != nil {
+		return 0, err
+	}
+	for _, entry := range entries {
+		markOutbox(ctx, entry, deliverOutbox(ctx, entry))
+	}
+	return len(entries), nil
+}
+
+// markOutbox records the outcome of one delivery: delivered when err is nil,
+// otherwise failed and scheduled for a retry or dead-lettered.
+func markOutbox(ctx context.Context, entry model.OutboxEntry, err error) {
+	repo, qctx, cancel := getStore(ctx)
+	defer cancel()
+	now := time.Now()
+	if err == nil {
+		if err := repo.Outbox.MarkDelivered(qctx, entry.ID, now); err != nil {
+			log.Printf("ERROR: Failed to mark outbox entry %d delivered: %v", entry.ID, err)
+		}
+		return
+	}
+	dead := entry.Attempts >= outboxMaxAttempts || errors.Is(err, errUnknownChannel)
+	if dead {
+		log.Printf("ERROR: Outbox entry %d dead-lettered after %d attempts: %v", entry.ID, entry.Attempts, err)
+	} else {
+		log.Printf("WARNING: Outbox entry %d failed (attempt %d): %v", entry.ID, entry.Attempts, err)
+	}
+	if err := repo.Outbox.MarkFailed(qctx, entry.ID, err.Error(), now.Add(outboxBackoff(entry.Attempts)), dead); err != nil {
+		log.Printf("ERROR: Failed to record outbox failure %d: %v", entry.ID, err)
+	}
+}
+
+var errUnknownChannel = errors.New("unknown outbox channel")
+
+func deliverOutbox(ctx context.Context, entry model.OutboxEntry) error {
+	ch := findOutboxChannel(entry.Channel)
+	if ch == nil {
+		return fmt.Errorf("%w %q", errUnknownChannel, entry.Channel)
+	}
+	dctx, cancel := context.WithTimeout(ctx, outboxLease/2)
+	defer cancel()
+	return ch.Deliver(dctx, entry)
+}
+
+// outboxBackoff doubles the wait after every failed attempt, from one second
+// up to outboxMaxBackoff.
+func outboxBackoff(attempts int) time.Duration {
+	d := time.Second
+	for i := 1; i < attempts && d < outboxMaxBackoff; i++ {
+		d *= 2
+	}
+	if d > outboxMaxBackoff {
+		d = outboxMaxBackoff
+	}
+	return d
+}
+
+// DeleteDeliveredOutbox drops outbox entries delivered longer than outboxKeep
+// ago.
+func DeleteDeliveredOutbox() {
+	repo, ctx, cancel := getStore(context.Background())
+	defer cancel()
+	deleted, err := repo.Outbox.DeleteDelivered(ctx, time.Now().Add(-outboxKeep))
+	if err != nil {
+		log.Printf("Scheduler Error: outbox delete failed: %v", err)
+		return
+	}
+	if deleted > 0 {
+		log.Printf("Scheduler: Deleted %d delivered outbox entries.", deleted)
+	}
+}
+
+// @summary Get Notification Outbox
+// @description Lists the organization's notification deliveries, newest first. Use status=dead for the dead-letter view.
+// @tags System
+// @security ApiKeyAuth
+// @id Get Notification Outbox
+// @accept json
+// @produce json
+// @Param status query string false "pending, delivered or dead"
+// @Param start query int false "start" default(0)
+// @Param length query int false "length" default(10)
+// @response 200 {object} model.Response{data=[]model.OutboxEntry} "OK - Request successful"
+// @Router /api/v1/system/outbox [get]
+func GetNotificationOutbox(c *gin.Context) {
+	repo, ctx, cancel := getStore(c.Request.Context())
+	defer cancel()
+	orgId := tokenString(c, "orgId")
+
+	status := c.Query("status")
+	switch status {
+	case "", model.OutboxPending, model.OutboxDelivered, model.OutboxDead:
+	default:
+		c.JSON(http.StatusBadRequest, model.Response{
+			Status: "-1",
+			Msg:    "Failure",
+			Desc:   "status must be pending, delivered or dead",
+		})
+		return
+	}
+	start, _ := strconv.Atoi(c.DefaultQuery("start", "0"))
+	length, _ := strconv.Atoi(c.DefaultQuery("length", "10"))
+
+	list, err := repo.Outbox.List(ctx, orgId, model.OutboxFilter{Status: status, Limit: length, Offset: start})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, model.Response{
+			Status: "-1",
+			Msg:    "Failure",
+			Desc:   err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, model.Response{
+		Status: "0",
+		Msg:    "Success",
+		Data:   list,
+	})
+}
+
+// @summary Retry Notification Outbox Entry
+// @description Moves a dead-lettered delivery back to pending so the relay tries it again.
+// @tags System
+// @security ApiKeyAuth
+// @id Retry Notification Outbox Entry
+// @accept json
+// @produce json
+// @Param id path int true "outbox entry id"
+// @response 200 {object} model.Response "OK - Request successful"
+// @Router /api/v1/system/outbox/{id}/retry [post]
+func RetryNotificationOutbox(c *gin.Context) {
+	repo, ctx, cancel := getStore(c.Request.Context())
+	defer cancel()
+	orgId := tokenString(c, "orgId")
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err == nil {
+		err = repo.Outbox.Requeue(ctx, orgId, id, time.Now())
+	}
+	if err != nil {
+		status := http.StatusInternalServerError
+		var numErr *strconv.NumError
+		switch {
+		case errors.As(err, &numErr):
+			status = http.StatusBadRequest
+		case errors.Is(err, store.ErrNotFound):
+			status = http.StatusNotFound
+			err = errors.New("no dead-lettered entry with this id")
+		}
+		c.JSON(status, model.Response{
+			Status: "-1",
+			Msg:    "Failure",
+			Desc:   err.Error(),
+		})
+		return
+	}
+	kickOutbox()
+	c.JSON(http.StatusOK, model.Response{
+		Status: "0",
+		Msg:    "Success",
+		Desc:   "Queued for retry",
+	})
+}
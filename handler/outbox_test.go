@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"mainPackage/model"
+	"testing"
+	"time"
+)
+
+// fakeOutbox swaps the outbox channels for one that fails while fail is set
+// and counts its deliveries.
+func fakeOutbox(t *testing.T, fail *error) *int {
+	t.Helper()
+	saved := outboxChannels
+	delivered := new(int)
+	outboxChannels = []outboxChannel{{Name: "test", Deliver: func(ctx context.Context, entry model.OutboxEntry) error {
+		if *fail != nil {
+			return *fail
+		}
+		*delivered++
+		return nil
+	}}}
+	t.Cleanup(func() { outboxChannels = saved })
+	return delivered
+}
+
+// outboxEntry returns the outbox entry of notification id.
+func outboxEntry(t *testing.T, id int64) model.OutboxEntry {
+	t.Helper()
+	list, err := appStore.Outbox.List(context.Background(), testOrg, model.OutboxFilter{Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range list {
+		if e.NotificationID == id {
+			return e
+		}
+	}
+	t.Fatalf("outbox entry %d not found", id)
+	return model.OutboxEntry{}
+}
+
+func TestRelayOutboxRetries(t *testing.T) {
+	m := newTestStore(t)
+	fail := error(errors.New("broker down"))
+	delivered := fakeOutbox(t, &fail)
+	ctx := context.Background()
+	if err := m.Outbox.Enqueue(ctx, testOrg, 1, "test"); err != nil {
+		t.Fatal(err)
+	}
+
+	before := time.Now()
+	if n, err := relayOutbox(ctx); n != 1 || err != nil {
+		t.Fatalf("relayOutbox = %d, %v, want 1 claimed", n, err)
+	}
+	e := outboxEntry(t, 1)
+	if e.Status != model.OutboxPending || e.Attempts != 1 || e.LastError == nil || *e.LastError != "broker down" {
+		t.Fatalf("after a failure = %+v, want pending after 1 attempt", e)
+	}
+	if e.NextAttemptAt.Before(before.Add(outboxBackoff(1))) {
+		t.Errorf("retry at %s, want at least %s after the attempt", e.NextAttemptAt, outboxBackoff(1))
+	}
+	if n, _ := relayOutbox(ctx); n != 0 {
+		t.Errorf("relayOutbox claimed %d entries before the retry was due", n)
+	}
+
+	fail = nil
+	if _, err := m.Outbox.Claim(ctx, e.NextAttemptAt, outboxLease, outboxBatch); err != nil {
+		t.Fatal(err)
+	}
+	markOutbox(ctx, outboxEntry(t, 1), deliverOutbox(ctx, outboxEntry(t, 1)))
+	if e := outboxEntry(t, 1); e.Status != model.OutboxDelivered || e.DeliveredAt == nil || *delivered != 1 {
+		t.Errorf("after a delivery = %+v, %d delivered", e, *delivered)
+	}
+}
+
+func TestMarkOutboxDeadLetters(t *testing.T) {
+	m := newTestStore(t)
+	ctx := context.Background()
+	for id := int64(1); id <= 3; id++ {
+		if err := m.Outbox.Enqueue(ctx, testOrg, id, "test"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	failure := errors.New("broker down")
+
+	markOutbox(ctx, model.OutboxEntry{ID: outboxEntry(t, 1).ID, Attempts: outboxMaxAttempts - 1}, failure)
+	markOutbox(ctx, model.OutboxEntry{ID: outboxEntry(t, 2).ID, Attempts: outboxMaxAttempts}, failure)
+	markOutbox(ctx, model.OutboxEntry{ID: outboxEntry(t, 3).ID, Attempts: 1}, deliverOutbox(ctx, model.OutboxEntry{Channel: "gone"}))
+
+	want := map[int64]string{1: model.OutboxPending, 2: model.OutboxDead, 3: model.OutboxDead}
+	for id, status := range want {
+		if e := outboxEntry(t, id); e.Status != status {
+			t.Errorf("entry %d = %s, want %s", id, e.Status, status)
+		}
+	}
+
+	if err := m.Outbox.Requeue(ctx, testOrg, outboxEntry(t, 2).ID, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	if e := outboxEntry(t, 2); e.Status != model.OutboxPending || e.Attempts != 0 {
+		t.Errorf("requeued entry = %+v, want pending with no attempts", e)
+	}
+}
+
+func TestOutboxBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{0, time.Second},
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{4, 8 * time.Second},
+		{outboxMaxAttempts, 128 * time.Second},
+		{20, outboxMaxBackoff},
+	}
+	for _, tt := range tests {
+		if got := outboxBackoff(tt.attempts); got != tt.want {
+			t.Errorf("outboxBackoff(%d) = %s, want %s", tt.attempts, got, tt.want)
+		}
+	}
+}
@@ -4,12 +4,8 @@ import (
 	"mainPackage/config"
 	"mainPackage/model"
 	"net/http"
-	"strconv"
-	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/google/uuid"
-	"github.com/jackc/pgx/v5"
 	"go.uber.org/zap"
 )
 
@@ -26,29 +22,11 @@ import (
 // @Router /api/v1/role [get]
 func GetRole(c *gin.Context) {
 	logger := config.GetLog()
-	orgId := GetVariableFromToken(c, "orgId")
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
-	startStr := c.DefaultQuery("start", "0")
-	start, err := strconv.Atoi(startStr)
-	if err != nil {
-		start = 0
-	}
-	lengthStr := c.DefaultQuery("length", "1000")
-	length, err := strconv.Atoi(lengthStr)
-	if err != nil {
-		length = 1000
-	}
-	query := `SELECT id, "orgId", "roleName", active, "createdAt", "updatedAt", "createdBy", "updatedBy"
-	FROM public.um_roles WHERE "orgId"=$1 LIMIT $2 OFFSET $3`
+	start, length := pageQuery(c)
 
-	var rows pgx.Rows
-	logger.Debug(`Query`, zap.String("query", query))
-	rows, err = conn.Query(ctx, query, orgId, length, start)
+	list, err := repo.Roles.List(ctx, tokenString(c, "orgId"), length, start)
 	if err != nil {
 		logger.Warn("Query failed", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, model.Response{
@@ -58,42 +36,11 @@ func GetRole(c *gin.Context) {
 		})
 		return
 	}
-	defer rows.Close()
-	var errorMsg string
-	var Role model.Role
-	var RoleList []model.Role
-	rowIndex := 0
-	for rows.Next() {
-		rowIndex++
-		err := rows.Scan(&Role.ID, &Role.OrgID, &Role.RoleName,
-			&Role.Active, &Role.CreatedAt, &Role.UpdatedAt, &Role.CreatedBy, &Role.UpdatedBy)
-		if err != nil {
-			logger.Warn("Scan failed", zap.Error(err))
-			response := model.Response{
-				Status: "-1",
-				Msg:    "Failed",
-				Desc:   errorMsg,
-			}
-			c.JSON(http.StatusInternalServerError, response)
-		}
-		RoleList = append(RoleList, Role)
-	}
-	if errorMsg != "" {
-		response := model.Response{
-			Status: "-1",
-			Msg:    "Failed",
-			Desc:   errorMsg,
-		}
-		c.JSON(http.StatusInternalServerError, response)
-	} else {
-		response := model.Response{
-			Status: "0",
-			Msg:    "Success",
-			Data:   RoleList,
-			Desc:   "",
-		}
-		c.JSON(http.StatusOK, response)
-	}
+	c.JSON(http.StatusOK, model.Response{
+		Status: "0",
+		Msg:    "Success",
+		Data:   list,
+	})
 }
 
 // ListRole godoc
@@ -108,50 +55,24 @@ func GetRole(c *gin.Context) {
 // @Router /api/v1/role/{id} [get]
 func GetRolebyId(c *gin.Context) {
 	logger := config.GetLog()
-	id := c.Param("id")
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
-	orgId := GetVariableFromToken(c, "orgId")
-	query := `SELECT id, "orgId", "roleName", active, "createdAt", "updatedAt", "createdBy", "updatedBy"
-	FROM public.um_roles WHERE id = $1 AND "orgId"=$2`
 
-	logger.Debug(`Query`, zap.String("query", query),
-		zap.Any("Input", []any{
-			id, orgId,
-		}))
-	var Role model.Role
-	err := conn.QueryRow(ctx, query, id, orgId).Scan(
-		&Role.ID,
-		&Role.OrgID,
-		&Role.RoleName,
-		&Role.Active,
-		&Role.CreatedAt,
-		&Role.UpdatedAt,
-		&Role.CreatedBy,
-		&Role.UpdatedBy,
-	)
+	role, err := repo.Roles.Get(ctx, tokenString(c, "orgId"), c.Param("id"))
 	if err != nil {
 		logger.Warn("Query failed", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, model.Response{
+		c.JSON(storeStatus(err), model.Response{
 			Status: "-1",
 			Msg:    "Failure",
 			Desc:   err.Error(),
 		})
 		return
 	}
-
-	response := model.Response{
+	c.JSON(http.StatusOK, model.Response{
 		Status: "0",
 		Msg:    "Success",
-		Data:   Role,
-		Desc:   "",
-	}
-	c.JSON(http.StatusOK, response)
-
+		Data:   role,
+	})
 }
 
 // @summary Create Role
@@ -165,12 +86,7 @@ func GetRolebyId(c *gin.Context) {
 // @Router /api/v1/role/add [post]
 func InsertRole(c *gin.Context) {
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
-	defer cancel()
-	defer conn.Close(ctx)
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
 
 	var req model.RoleInsert
@@ -183,24 +99,10 @@ func InsertRole(c *gin.Context) {
 		logger.Warn("Insert failed", zap.Error(err))
 		return
 	}
-	username := GetVariableFromToken(c, "username")
-	orgId := GetVariableFromToken(c, "orgId")
-	now := time.Now()
-	uuid := uuid.New()
-	var id int
-	query := `
-	INSERT INTO public."um_roles"(
-	id,"orgId", "roleName", active, "createdAt", "updatedAt", "createdBy", "updatedBy")
-	VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-	RETURNING id ;
-	`
-
-	err := conn.QueryRow(ctx, query, uuid,
-		orgId, req.RoleName, req.Active, now,
-		now, username, username).Scan(&id)
+	username := tokenString(c, "username")
+	orgId := tokenString(c, "orgId")
 
-	if err != nil {
-		// log.Printf("Insert failed: %v", err)
+	if _, err := repo.Roles.Create(ctx, orgId, username, req); err != nil {
 		c.JSON(http.StatusInternalServerError, model.Response{
 			Status: "-1",
 			Msg:    "Failure",
@@ -209,14 +111,11 @@ func InsertRole(c *gin.Context) {
 		logger.Warn("Insert failed", zap.Error(err))
 		return
 	}
-
-	// Continue logic...
 	c.JSON(http.StatusOK, model.Response{
 		Status: "0",
 		Msg:    "Success",
 		Desc:   "Create successfully",
 	})
-
 }
 
 // @summary Update Role
@@ -231,46 +130,24 @@ func InsertRole(c *gin.Context) {
 // @Router /api/v1/role/{id} [patch]
 func UpdateRole(c *gin.Context) {
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
-	defer cancel()
-	defer conn.Close(ctx)
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
 
-	id := c.Param("id")
-
 	var req model.RoleUpdate
 	if err := c.ShouldBindJSON(&req); err != nil {
-		logger.Warn("Update failed", zap.Error(err))
 		c.JSON(http.StatusBadRequest, model.Response{
 			Status: "-1",
 			Msg:    "Failure",
 			Desc:   err.Error(),
 		})
+		logger.Warn("Update failed", zap.Error(err))
 		return
 	}
-	now := time.Now()
-	username := GetVariableFromToken(c, "username")
-	orgId := GetVariableFromToken(c, "orgId")
-	query := `UPDATE public."um_roles"
-	SET roleName=$2, active=$3,
-	 "updatedAt"=$4, "updatedBy"=$5
-	WHERE id = $1 AND "orgId"=$6`
-	_, err := conn.Exec(ctx, query,
-		id, req.RoleName, req.Active,
-		now, username, orgId,
-	)
-	logger.Debug("Update Case SQL Args",
-		zap.String("query", query),
-		zap.Any("Input", []any{
-			id, req.RoleName, req.Active,
-			now, username, orgId,
-		}))
-	if err != nil {
-		// log.Printf("Insert failed: %v", err)
-		c.JSON(http.StatusInternalServerError, model.Response{
+	username := tokenString(c, "username")
+	orgId := tokenString(c, "orgId")
+
+	if err := repo.Roles.Update(ctx, orgId, c.Param("id"), username, req); err != nil {
+		c.JSON(storeStatus(err), model.Response{
 			Status: "-1",
 			Msg:    "Failure",
 			Desc:   err.Error(),
@@ -278,8 +155,6 @@ func UpdateRole(c *gin.Context) {
 		logger.Warn("Update failed", zap.Error(err))
 		return
 	}
-
-	// Continue logic...
 	c.JSON(http.StatusOK, model.Response{
 		Status: "0",
 		Msg:    "Success",
@@ -297,32 +172,23 @@ func UpdateRole(c *gin.Context) {
 // @response 200 {object} model.Response "OK - Request successful"
 // @Router /api/v1/role/{id} [delete]
 func DeleteRole(c *gin.Context) {
-
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
-	defer cancel()
-	defer conn.Close(ctx)
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	orgId := GetVariableFromToken(c, "orgId")
+	orgId := tokenString(c, "orgId")
 	id := c.Param("id")
-	query := `DELETE FROM public."um_roles" WHERE id = $1 AND "orgId"=$2`
-	logger.Debug("Query", zap.String("query", query), zap.Any("id", id))
-	_, err := conn.Exec(ctx, query, id, orgId)
-	if err != nil {
-		// log.Printf("Insert failed: %v", err)
-		c.JSON(http.StatusInternalServerError, model.Response{
+
+	if err := repo.Roles.Delete(ctx, orgId, id); err != nil {
+		c.JSON(storeStatus(err), model.Response{
 			Status: "-1",
 			Msg:    "Failure",
 			Desc:   err.Error(),
 		})
-		logger.Warn("Update failed", zap.Error(err))
+		logger.Warn("Delete failed", zap.Error(err))
 		return
 	}
 
-	// Continue logic...
+	invalidatePermissions(orgId, id)
 	c.JSON(http.StatusOK, model.Response{
 		Status: "0",
 		Msg:    "Success",
@@ -3,12 +3,10 @@ package handler
 import (
 	"mainPackage/config"
 	"mainPackage/model"
+	"mainPackage/store"
 	"net/http"
-	"strconv"
-	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/jackc/pgx/v5"
 	"go.uber.org/zap"
 )
 
@@ -25,29 +23,11 @@ import (
 // @Router /api/v1/role_permission [get]
 func GetRolePermission(c *gin.Context) {
 	logger := config.GetLog()
-	orgId := GetVariableFromToken(c, "orgId")
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
-	startStr := c.DefaultQuery("start", "0")
-	start, err := strconv.Atoi(startStr)
-	if err != nil {
-		start = 0
-	}
-	lengthStr := c.DefaultQuery("length", "1000")
-	length, err := strconv.Atoi(lengthStr)
-	if err != nil {
-		length = 1000
-	}
-	query := `SELECT id, "orgId", "roleId", "permId", active, "createdAt", "updatedAt", "createdBy", "updatedBy"
-	FROM public.um_role_with_permissions WHERE "orgId"=$1 LIMIT $2 OFFSET $3`
+	start, length := pageQuery(c)
 
-	var rows pgx.Rows
-	logger.Debug(`Query`, zap.String("query", query))
-	rows, err = conn.Query(ctx, query, orgId, length, start)
+	list, err := repo.Roles.ListGrants(ctx, tokenString(c, "orgId"), length, start)
 	if err != nil {
 		logger.Warn("Query failed", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, model.Response{
@@ -57,42 +37,11 @@ func GetRolePermission(c *gin.Context) {
 		})
 		return
 	}
-	defer rows.Close()
-	var errorMsg string
-	var RolePermission model.RolePermission
-	var RolePermissionList []model.RolePermission
-	rowIndex := 0
-	for rows.Next() {
-		rowIndex++
-		err := rows.Scan(&RolePermission.ID, &RolePermission.OrgID, &RolePermission.RoleID, &RolePermission.PermID,
-			&RolePermission.Active, &RolePermission.CreatedAt, &RolePermission.UpdatedAt, &RolePermission.CreatedBy, &RolePermission.UpdatedBy)
-		if err != nil {
-			logger.Warn("Scan failed", zap.Error(err))
-			response := model.Response{
-				Status: "-1",
-				Msg:    "Failed",
-				Desc:   errorMsg,
-			}
-			c.JSON(http.StatusInternalServerError, response)
-		}
-		RolePermissionList = append(RolePermissionList, RolePermission)
-	}
-	if errorMsg != "" {
-		response := model.Response{
-			Status: "-1",
-			Msg:    "Failed",
-			Desc:   errorMsg,
-		}
-		c.JSON(http.StatusInternalServerError, response)
-	} else {
-		response := model.Response{
-			Status: "0",
-			Msg:    "Success",
-			Data:   RolePermissionList,
-			Desc:   "",
-		}
-		c.JSON(http.StatusOK, response)
-	}
+	c.JSON(http.StatusOK, model.Response{
+		Status: "0",
+		Msg:    "Success",
+		Data:   list,
+	})
 }
 
 // ListRolePermission godoc
@@ -107,54 +56,24 @@ func GetRolePermission(c *gin.Context) {
 // @Router /api/v1/role_permission/{id} [get]
 func GetRolePermissionbyId(c *gin.Context) {
 	logger := config.GetLog()
-	id := c.Param("id")
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
-
-	orgId := GetVariableFromToken(c, "orgId")
-
-	query := `SELECT id, "orgId", "roleId", "permId", active, "createdAt", "updatedAt", "createdBy", "updatedBy"
-	FROM public.um_role_with_permissions WHERE id = $1 AND "orgId" = $2`
-
-	logger.Debug("Query", zap.String("query", query),
-		zap.Any("Input", []any{id, orgId}),
-	)
-
-	var RolePermission model.RolePermission
-	err := conn.QueryRow(ctx, query, id, orgId).Scan(
-		&RolePermission.ID,
-		&RolePermission.OrgID,
-		&RolePermission.RoleID,
-		&RolePermission.PermID,
-		&RolePermission.Active,
-		&RolePermission.CreatedAt,
-		&RolePermission.UpdatedAt,
-		&RolePermission.CreatedBy,
-		&RolePermission.UpdatedBy,
-	)
 
+	grant, err := repo.Roles.GetGrant(ctx, tokenString(c, "orgId"), c.Param("id"))
 	if err != nil {
 		logger.Warn("Query failed", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, model.Response{
+		c.JSON(storeStatus(err), model.Response{
 			Status: "-1",
 			Msg:    "Failure",
 			Desc:   err.Error(),
 		})
 		return
 	}
-
-	response := model.Response{
+	c.JSON(http.StatusOK, model.Response{
 		Status: "0",
 		Msg:    "Success",
-		Data:   RolePermission,
-		Desc:   "",
-	}
-	c.JSON(http.StatusOK, response)
-
+		Data:   grant,
+	})
 }
 
 // ListRolePermission godoc
@@ -169,22 +88,10 @@ func GetRolePermissionbyId(c *gin.Context) {
 // @Router /api/v1/role_permission/roleId/{roleId} [get]
 func GetRolePermissionbyroleId(c *gin.Context) {
 	logger := config.GetLog()
-	id := c.Param("roleId")
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
-
-	orgId := GetVariableFromToken(c, "orgId")
-
-	query := `SELECT id, "orgId", "roleId", "permId", active, "createdAt", "updatedAt", "createdBy", "updatedBy"
-	FROM public.um_role_with_permissions WHERE "orgId"=$1 AND "roleId"=$2`
 
-	var rows pgx.Rows
-	logger.Debug(`Query`, zap.String("query", query))
-	rows, err := conn.Query(ctx, query, orgId, id)
+	list, err := repo.Roles.ListRoleGrants(ctx, tokenString(c, "orgId"), c.Param("roleId"))
 	if err != nil {
 		logger.Warn("Query failed", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, model.Response{
@@ -194,43 +101,11 @@ func GetRolePermissionbyroleId(c *gin.Context) {
 		})
 		return
 	}
-	defer rows.Close()
-	var RolePermission model.RolePermission
-	var RolePermissionList []model.RolePermission
-	rowIndex := 0
-	found := false
-	for rows.Next() {
-		rowIndex++
-		err := rows.Scan(&RolePermission.ID, &RolePermission.OrgID, &RolePermission.RoleID, &RolePermission.PermID,
-			&RolePermission.Active, &RolePermission.CreatedAt, &RolePermission.UpdatedAt, &RolePermission.CreatedBy, &RolePermission.UpdatedBy)
-		if err != nil {
-			logger.Warn("Scan failed", zap.Error(err))
-			response := model.Response{
-				Status: "-1",
-				Msg:    "Failed",
-				Desc:   err.Error(),
-			}
-			c.JSON(http.StatusInternalServerError, response)
-		}
-		RolePermissionList = append(RolePermissionList, RolePermission)
-		found = true
-	}
-	if !found {
-		response := model.Response{
-			Status: "-1",
-			Msg:    "Failed",
-			Desc:   "Not Found",
-		}
-		c.JSON(http.StatusInternalServerError, response)
-	} else {
-		response := model.Response{
-			Status: "0",
-			Msg:    "Success",
-			Data:   RolePermissionList,
-			Desc:   "",
-		}
-		c.JSON(http.StatusOK, response)
-	}
+	c.JSON(http.StatusOK, model.Response{
+		Status: "0",
+		Msg:    "Success",
+		Data:   list,
+	})
 }
 
 // @summary Create RolePermission
@@ -244,15 +119,8 @@ func GetRolePermissionbyroleId(c *gin.Context) {
 // @Router /api/v1/role_permission/add [post]
 func InsertRolePermission(c *gin.Context) {
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
-	username := GetVariableFromToken(c, "username")
-	orgId := GetVariableFromToken(c, "orgId")
-	now := time.Now()
 
 	var req model.RolePermissionInsert
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -264,47 +132,25 @@ func InsertRolePermission(c *gin.Context) {
 		logger.Warn("Insert failed", zap.Error(err))
 		return
 	}
+	username := tokenString(c, "username")
+	orgId := tokenString(c, "orgId")
 
-	for i, item := range req.PermID {
-		logger.Debug("eleNumber", zap.Int("i", i+1))
-		logger.Debug("JsonArray", zap.Any("Json", item))
-		// logger.Debug("JsonArray", zap.Any("active", item.Active))
-		// logger.Debug("JsonArray", zap.Any("permId", item.PermID))
-
-		var id int
-		query := `
-		INSERT INTO public."um_role_with_permissions"(
-		"orgId", "roleId", "permId", active, "createdAt", "updatedAt", "createdBy", "updatedBy")
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-		RETURNING id ;
-		`
-		logger.Debug(`Query`, zap.String("query", query),
-			zap.Any("Input", []any{
-				req, item,
-			}))
-
-		err := conn.QueryRow(ctx, query,
-			orgId, req.RoleID, item.PermID, item.Active, now, now, username, username).Scan(&id)
-
-		if err != nil {
-			// log.Printf("Insert failed: %v", err)
-			c.JSON(http.StatusInternalServerError, model.Response{
-				Status: "-1",
-				Msg:    "Failure",
-				Desc:   err.Error(),
-			})
-			logger.Warn("Insert failed", zap.Error(err))
-			return
-		}
-
+	if err := repo.Roles.AddGrants(ctx, orgId, req.RoleID, username, req.PermID); err != nil {
+		c.JSON(http.StatusInternalServerError, model.Response{
+			Status: "-1",
+			Msg:    "Failure",
+			Desc:   err.Error(),
+		})
+		logger.Warn("Insert failed", zap.Error(err))
+		return
 	}
 
+	invalidatePermissions(orgId, req.RoleID)
 	c.JSON(http.StatusOK, model.Response{
 		Status: "0",
 		Msg:    "Success",
 		Desc:   "Create successfully",
 	})
-
 }
 
 // @summary Update RolePermission
@@ -319,39 +165,24 @@ func InsertRolePermission(c *gin.Context) {
 // @Router /api/v1/role_permission/{roleId} [patch]
 func UpdateRolePermission(c *gin.Context) {
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
-	defer cancel()
-
-	id := c.Param("roleId")
 
 	var req model.RolePermissionUpdate
 	if err := c.ShouldBindJSON(&req); err != nil {
-		logger.Warn("Update failed", zap.Error(err))
 		c.JSON(http.StatusBadRequest, model.Response{
 			Status: "-1",
 			Msg:    "Failure",
 			Desc:   err.Error(),
 		})
+		logger.Warn("Update failed", zap.Error(err))
 		return
 	}
-	now := time.Now()
-	username := GetVariableFromToken(c, "username")
-	orgId := GetVariableFromToken(c, "orgId")
-	query := `DELETE FROM public."um_role_with_permissions" WHERE "roleId" = $1 AND "orgId"=$2`
-
-	logger.Debug(`Query`, zap.String("query", query),
-		zap.Any("Input", []any{
-			id, orgId,
-		}))
+	username := tokenString(c, "username")
+	orgId := tokenString(c, "orgId")
+	roleId := c.Param("roleId")
 
-	_, err := conn.Exec(ctx, query, id, orgId)
-	if err != nil {
-		// log.Printf("Insert failed: %v", err)
+	if err := repo.Roles.SetGrants(ctx, orgId, roleId, username, req.PermID); err != nil {
 		c.JSON(http.StatusInternalServerError, model.Response{
 			Status: "-1",
 			Msg:    "Failure",
@@ -360,45 +191,8 @@ func UpdateRolePermission(c *gin.Context) {
 		logger.Warn("Update failed", zap.Error(err))
 		return
 	}
-	logger.Debug("Update Case SQL Args",
-		zap.String("query", query),
-		zap.Any("Input", []any{id, orgId}))
-
-	for i, item := range req.PermID {
-		logger.Debug("eleNumber", zap.Int("i", i+1))
-		logger.Debug("JsonArray", zap.Any("Json", item))
-		// logger.Debug("JsonArray", zap.Any("active", item.Active))
-		// logger.Debug("JsonArray", zap.Any("permId", item.PermID))
-
-		var id int
-		query := `
-		INSERT INTO public."um_role_with_permissions"(
-		"orgId", "roleId", "permId", active, "createdAt", "updatedAt", "createdBy", "updatedBy")
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-		RETURNING id ;
-		`
-		logger.Debug(`Query`, zap.String("query", query),
-			zap.Any("Input", []any{
-				req, item,
-			}))
-
-		err := conn.QueryRow(ctx, query,
-			orgId, id, item.PermID, item.Active, now, now, username, username).Scan(&id)
-
-		if err != nil {
-			// log.Printf("Insert failed: %v", err)
-			c.JSON(http.StatusInternalServerError, model.Response{
-				Status: "-1",
-				Msg:    "Failure",
-				Desc:   err.Error(),
-			})
-			logger.Warn("Insert failed", zap.Error(err))
-			return
-		}
-
-	}
 
-	// Continue logic...
+	invalidatePermissions(orgId, roleId)
 	c.JSON(http.StatusOK, model.Response{
 		Status: "0",
 		Msg:    "Success",
@@ -417,82 +211,40 @@ func UpdateRolePermission(c *gin.Context) {
 // @Router /api/v1/role_permission/multi [patch]
 func UpdateMultiRolePermission(c *gin.Context) {
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
-	defer cancel()
-	defer conn.Close(ctx)
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
 
 	var req model.MultiRolePermissionUpdate
 	if err := c.ShouldBindJSON(&req); err != nil {
-		logger.Warn("Update failed", zap.Error(err))
 		c.JSON(http.StatusBadRequest, model.Response{
 			Status: "-1",
 			Msg:    "Failure",
 			Desc:   err.Error(),
 		})
+		logger.Warn("Update failed", zap.Error(err))
 		return
 	}
+	username := tokenString(c, "username")
+	orgId := tokenString(c, "orgId")
 
-	for _, item := range req.Body {
-		logger.Debug("JsonArray", zap.Any("roleId", item.RoleID))
-		id := item.RoleID
-		now := time.Now()
-		username := GetVariableFromToken(c, "username")
-		orgId := GetVariableFromToken(c, "orgId")
-		query := `DELETE FROM public."um_role_with_permissions" WHERE "roleId" = $1 AND "orgId"=$2`
-
-		logger.Debug(`Query`, zap.String("query", query),
-			zap.Any("Input", []any{
-				id, orgId,
-			}))
-		_, err := conn.Exec(ctx, query, id, orgId)
-		if err != nil {
-			// log.Printf("Insert failed: %v", err)
-			c.JSON(http.StatusInternalServerError, model.Response{
-				Status: "-1",
-				Msg:    "Failure",
-				Desc:   err.Error(),
-			})
-			logger.Warn("Update failed", zap.Error(err))
-			return
-		}
-		logger.Debug("Update Case SQL Args",
-			zap.String("query", query),
-			zap.Any("Input", []any{id, orgId}))
-
-		for _, items := range item.PermID {
-			logger.Debug("JsonArray", zap.Any("PermID", items.PermID))
-
-			query := `
-				INSERT INTO public."um_role_with_permissions"(
-				"orgId", "roleId", "permId", active, "createdAt", "updatedAt", "createdBy", "updatedBy")
-				VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-				`
-			logger.Debug(`Query`, zap.String("query", query),
-				zap.Any("Input", []any{
-					req, items,
-				}))
-
-			_, err := conn.Exec(ctx, query,
-				orgId, id, items.PermID, items.Active, now, now, username, username)
-
-			if err != nil {
-				// log.Printf("Insert failed: %v", err)
-				c.JSON(http.StatusInternalServerError, model.Response{
-					Status: "-1",
-					Msg:    "Failure",
-					Desc:   err.Error(),
-				})
-				logger.Warn("Insert failed", zap.Error(err))
-				return
+	if err := repo.InTx(ctx, func(tx *store.Store) error {
+		for _, item := range req.Body {
+			if err := tx.Roles.SetGrants(ctx, orgId, item.RoleID, username, item.PermID); err != nil {
+				return err
 			}
-
 		}
+		return nil
+	}); err != nil {
+		c.JSON(http.StatusInternalServerError, model.Response{
+			Status: "-1",
+			Msg:    "Failure",
+			Desc:   err.Error(),
+		})
+		logger.Warn("Update failed", zap.Error(err))
+		return
 	}
 
+	invalidatePermissions(orgId, "")
 	c.JSON(http.StatusOK, model.Response{
 		Status: "0",
 		Msg:    "Success",
@@ -510,32 +262,22 @@ func UpdateMultiRolePermission(c *gin.Context) {
 // @response 200 {object} model.Response "OK - Request successful"
 // @Router /api/v1/role_permission/{id} [delete]
 func DeleteRolePermission(c *gin.Context) {
-
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
-	defer cancel()
-	defer conn.Close(ctx)
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	orgId := GetVariableFromToken(c, "orgId")
-	id := c.Param("id")
-	query := `DELETE FROM public."um_role_with_permissions" WHERE id = $1 AND "orgId"=$2`
-	logger.Debug("Query", zap.String("query", query), zap.Any("id", id))
-	_, err := conn.Exec(ctx, query, id, orgId)
-	if err != nil {
-		// log.Printf("Insert failed: %v", err)
-		c.JSON(http.StatusInternalServerError, model.Response{
+	orgId := tokenString(c, "orgId")
+
+	if err := repo.Roles.DeleteGrant(ctx, orgId, c.Param("id")); err != nil {
+		c.JSON(storeStatus(err), model.Response{
 			Status: "-1",
 			Msg:    "Failure",
 			Desc:   err.Error(),
 		})
-		logger.Warn("Update failed", zap.Error(err))
+		logger.Warn("Delete failed", zap.Error(err))
 		return
 	}
 
-	// Continue logic...
+	invalidatePermissions(orgId, "")
 	c.JSON(http.StatusOK, model.Response{
 		Status: "0",
 		Msg:    "Success",
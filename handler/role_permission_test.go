@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"context"
+	"mainPackage/model"
+	"net/http"
+	"testing"
+)
+
+func TestRolePermissionGrants(t *testing.T) {
+	m := newTestStore(t, PermCaseView)
+	r := permRouter(PermCaseDelete)
+	r.GET("/api/v1/role_permission/roleId/:roleId", ProtectedHandler, GetRolePermissionbyroleId)
+	r.PATCH("/api/v1/role_permission/:roleId", ProtectedHandler, UpdateRolePermission)
+	r.DELETE("/api/v1/role/:id", ProtectedHandler, DeleteRole)
+	tok := accessToken(t)
+
+	if code := do(t, r, http.MethodGet, "/guarded", tok, nil, nil); code != http.StatusForbidden {
+		t.Fatalf("before the grant: %d, want 403", code)
+	}
+	update := model.RolePermissionUpdate{PermID: []model.RolePermissionBody{
+		{PermID: PermCaseDelete, Active: true},
+		{PermID: PermCaseUpdate, Active: false},
+	}}
+	if code := do(t, r, http.MethodPatch, "/api/v1/role_permission/r1", tok, update, nil); code != http.StatusOK {
+		t.Fatalf("update: %d", code)
+	}
+	var res struct {
+		Data []model.RolePermission `json:"data"`
+	}
+	if code := do(t, r, http.MethodGet, "/api/v1/role_permission/roleId/r1", tok, nil, &res); code != http.StatusOK {
+		t.Fatalf("list: %d", code)
+	}
+	if len(res.Data) != 2 || res.Data[0].PermID != PermCaseDelete || res.Data[1].PermID != PermCaseUpdate {
+		t.Errorf("grants = %+v, want exactly the two sent", res.Data)
+	}
+	if code := do(t, r, http.MethodGet, "/guarded", tok, nil, nil); code != http.StatusOK {
+		t.Errorf("after the grant: %d, want 200", code)
+	}
+
+	if code := do(t, r, http.MethodDelete, "/api/v1/role/r1", tok, nil, nil); code != http.StatusOK {
+		t.Fatalf("delete: %d", code)
+	}
+	if grants, err := m.Roles.ListRoleGrants(context.Background(), testOrg, "r1"); err != nil || len(grants) != 0 {
+		t.Errorf("grants of a deleted role = %+v, %v", grants, err)
+	}
+	if code := do(t, r, http.MethodGet, "/guarded", tok, nil, nil); code != http.StatusForbidden {
+		t.Errorf("after the role is deleted: %d, want 403", code)
+	}
+	if code := do(t, r, http.MethodDelete, "/api/v1/role/r1", tok, nil, nil); code != http.StatusNotFound {
+		t.Errorf("second delete: %d, want 404", code)
+	}
+}
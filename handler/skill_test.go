@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"mainPackage/model"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func skillRouter() *gin.Engine {
+	r := gin.New()
+	v1 := r.Group("/api/v1", ProtectedHandler)
+	v1.GET("/skill", GetSkill)
+	v1.POST("/skill/add", InsertSkill)
+	v1.GET("/users_with_skills/skillId/:skillId", GetUserWithSkillsBySkillId)
+	v1.POST("/users_with_skills/add", InsertUserWithSkills)
+	v1.PATCH("/users_with_skills/:id", UpdateUserWithSkills)
+	v1.DELETE("/users_with_skills/:id", DeleteUserWithSkills)
+	return r
+}
+
+func TestUserWithSkills(t *testing.T) {
+	newTestStore(t)
+	r := skillRouter()
+	tok := accessToken(t)
+
+	if code := do(t, r, http.MethodPost, "/api/v1/skill/add", tok, model.SkillInsert{En: "First aid", Active: true}, nil); code != http.StatusOK {
+		t.Fatalf("add skill: %d", code)
+	}
+	var skills struct {
+		Data []model.Skill `json:"data"`
+	}
+	if code := do(t, r, http.MethodGet, "/api/v1/skill", tok, nil, &skills); code != http.StatusOK || len(skills.Data) != 1 {
+		t.Fatalf("skills: %d %+v", code, skills.Data)
+	}
+	skillId := skills.Data[0].SkillID
+
+	// The organization comes from the token, not the body.
+	in := model.UserSkillInsert{OrgID: "o2", UserName: "alice", SkillID: skillId, Active: true}
+	if code := do(t, r, http.MethodPost, "/api/v1/users_with_skills/add", tok, in, nil); code != http.StatusOK {
+		t.Fatalf("add user skill: %d", code)
+	}
+	var held struct {
+		Data []model.UserSkill `json:"data"`
+	}
+	path := "/api/v1/users_with_skills/skillId/" + skillId
+	if code := do(t, r, http.MethodGet, path, tok, nil, &held); code != http.StatusOK {
+		t.Fatalf("users with the skill: %d", code)
+	}
+	if len(held.Data) != 1 || held.Data[0].UserName != "alice" || held.Data[0].OrgID != testOrg || held.Data[0].ID == 0 {
+		t.Fatalf("users with the skill = %+v, want alice in %s", held.Data, testOrg)
+	}
+
+	id := strconv.FormatInt(held.Data[0].ID, 10)
+	if code := do(t, r, http.MethodPatch, "/api/v1/users_with_skills/"+id, tok, model.UserSkillUpdate{SkillID: skillId}, nil); code != http.StatusOK {
+		t.Fatalf("update: %d", code)
+	}
+	if do(t, r, http.MethodGet, path, tok, nil, &held); len(held.Data) != 1 || held.Data[0].Active {
+		t.Errorf("after the update = %+v, want the skill inactive", held.Data)
+	}
+	if code := do(t, r, http.MethodDelete, "/api/v1/users_with_skills/"+id, tok, nil, nil); code != http.StatusOK {
+		t.Fatalf("delete: %d", code)
+	}
+	if code := do(t, r, http.MethodPatch, "/api/v1/users_with_skills/"+id, tok, model.UserSkillUpdate{SkillID: skillId}, nil); code != http.StatusNotFound {
+		t.Errorf("update after the delete: %d, want 404", code)
+	}
+}
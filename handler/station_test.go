@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"context"
+	"mainPackage/model"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func orgRouter() *gin.Engine {
+	r := gin.New()
+	v1 := r.Group("/api/v1", ProtectedHandler)
+	v1.GET("/department_command_stations", GetDepartmentCommandStation)
+	v1.GET("/stations/:id", GetStationbyId)
+	v1.PATCH("/stations/:id", UpdateStations)
+	v1.DELETE("/stations/:id", DeleteStations)
+	return r
+}
+
+func TestStationTree(t *testing.T) {
+	m := newTestStore(t)
+	ctx := context.Background()
+	for _, err := range []error{
+		m.Org.CreateDepartment(ctx, testOrg, "d1", "admin", model.DepartmentInsert{En: "Dept 1", Active: true}),
+		m.Org.CreateDepartment(ctx, testOrg, "d2", "admin", model.DepartmentInsert{En: "Dept 2", Active: true}),
+		m.Org.CreateCommand(ctx, testOrg, "c1", "admin", model.CommandInsert{DeptID: "d1", En: "Comm 1", Active: true}),
+		m.Org.CreateStation(ctx, testOrg, "s2", "admin", model.StationInsert{DeptID: "d1", CommID: "c1", En: "Stn 2"}),
+		m.Org.CreateStation(ctx, testOrg, "s1", "admin", model.StationInsert{DeptID: "d1", CommID: "c1", En: "Stn 1"}),
+		m.Org.CreateStation(ctx, "o2", "s3", "admin", model.StationInsert{DeptID: "d1", CommID: "c1"}),
+	} {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	r := orgRouter()
+	tok := accessToken(t)
+
+	var res struct {
+		Data []model.StationWithCommandDept `json:"data"`
+	}
+	if code := do(t, r, http.MethodGet, "/api/v1/department_command_stations", tok, nil, &res); code != http.StatusOK {
+		t.Fatalf("tree: %d", code)
+	}
+	if len(res.Data) != 2 || res.Data[0].StnId != "s1" || res.Data[1].StnId != "s2" {
+		t.Fatalf("tree = %+v, want s1 and s2 of %s", res.Data, testOrg)
+	}
+	if e := res.Data[0]; e.StationEn != "Stn 1" || e.CommandEn != "Comm 1" || e.DeptEn != "Dept 1" {
+		t.Errorf("tree row = %+v, want the names of s1, c1 and d1", e)
+	}
+
+	var got struct {
+		Data model.Station `json:"data"`
+	}
+	if code := do(t, r, http.MethodGet, "/api/v1/stations/s1", tok, nil, &got); code != http.StatusOK {
+		t.Fatalf("get s1: %d", code)
+	}
+	update := model.StationUpdate{DeptID: "d2", CommID: "c1", En: "Renamed"}
+	if code := do(t, r, http.MethodPatch, "/api/v1/stations/"+got.Data.ID, tok, update, nil); code != http.StatusOK {
+		t.Fatalf("update: %d", code)
+	}
+	if st, err := m.Org.GetStation(ctx, testOrg, "s1"); err != nil || st.DeptID != "d2" || st.En != "Renamed" {
+		t.Errorf("after the update = %+v, %v", st, err)
+	}
+	if code := do(t, r, http.MethodDelete, "/api/v1/stations/"+got.Data.ID, tok, nil, nil); code != http.StatusOK {
+		t.Fatalf("delete: %d", code)
+	}
+	for _, path := range []string{"/api/v1/stations/s1", "/api/v1/stations/s3"} {
+		if code := do(t, r, http.MethodGet, path, tok, nil, nil); code != http.StatusNotFound {
+			t.Errorf("get %s: %d, want 404", path, code)
+		}
+	}
+	if code := do(t, r, http.MethodDelete, "/api/v1/stations/"+got.Data.ID, tok, nil, nil); code != http.StatusNotFound {
+		t.Errorf("second delete: %d, want 404", code)
+	}
+}
@@ -1,14 +1,16 @@
 package handler
 
 import (
+	"errors"
 	"mainPackage/config"
+	"mainPackage/credential"
 	"mainPackage/model"
+	"mainPackage/store"
 	"net/http"
 	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/jackc/pgx/v5"
 	"go.uber.org/zap"
 )
 
@@ -24,13 +26,9 @@ import (
 // @Router /api/v1/users [get]
 func GetUmUserList(c *gin.Context) {
 	logger := config.GetLog()
-	orgId := GetVariableFromToken(c, "orgId")
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	orgId := tokenString(c, "orgId")
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
 
 	startStr := c.DefaultQuery("start", "0")
 	start, err := strconv.Atoi(startStr)
@@ -44,16 +42,7 @@ func GetUmUserList(c *gin.Context) {
 	}
 	logger.Debug(`Query`, zap.Any("start", start))
 	logger.Debug(`Query`, zap.Any("length", length))
-	query := `SELECT "id","orgId", "displayName", title, "firstName", "middleName", "lastName", "citizenId", bod,
-	blood, gender, "mobileNo", address, photo, username, password, email, "roleId", "userType", "empId",
-	"deptId", "commId", "stnId", active, "activationToken", "lastActivationRequest", "lostPasswordRequest",
-	"signupStamp", islogin, "lastLogin", "createdAt", "updatedAt", "createdBy", "updatedBy" 
-	FROM public.um_users 
-	WHERE "orgId"=$1 LIMIT $2 OFFSET $3`
-
-	var rows pgx.Rows
-	logger.Debug(`Query`, zap.String("query", query))
-	rows, err = conn.Query(ctx, query, orgId, length, start)
+	userList, err := repo.Users.List(ctx, orgId, length, start)
 	if err != nil {
 		logger.Warn("Query failed", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, model.Response{
@@ -63,78 +52,13 @@ func GetUmUserList(c *gin.Context) {
 		})
 		return
 	}
-	defer rows.Close()
-	var errorMsg string
-	var u model.Um_User
-	var userList []model.Um_User
-	rowIndex := 0
-	for rows.Next() {
-		rowIndex++
-		err := rows.Scan(
-			&u.ID,
-			&u.OrgID,
-			&u.DisplayName,
-			&u.Title,
-			&u.FirstName,
-			&u.MiddleName,
-			&u.LastName,
-			&u.CitizenID,
-			&u.Bod,
-			&u.Blood,
-			&u.Gender,
-			&u.MobileNo,
-			&u.Address,
-			&u.Photo,
-			&u.Username,
-			&u.Password,
-			&u.Email,
-			&u.RoleID,
-			&u.UserType,
-			&u.EmpID,
-			&u.DeptID,
-			&u.CommID,
-			&u.StnID,
-			&u.Active,
-			&u.ActivationToken,
-			&u.LastActivationRequest,
-			&u.LostPasswordRequest,
-			&u.SignupStamp,
-			&u.IsLogin,
-			&u.LastLogin,
-			&u.CreatedAt,
-			&u.UpdatedAt,
-			&u.CreatedBy,
-			&u.UpdatedBy,
-		)
-		if err != nil {
-			logger.Warn("Scan failed", zap.Error(err))
-			errorMsg = err.Error()
-			response := model.Response{
-				Status: "-1",
-				Msg:    "Failed",
-				Desc:   errorMsg,
-			}
-			c.JSON(http.StatusInternalServerError, response)
-			return
-		}
-		userList = append(userList, u)
-	}
-	if errorMsg != "" {
-		response := model.Response{
-			Status: "-1",
-			Msg:    "Failed",
-			Desc:   errorMsg,
-		}
-		c.JSON(http.StatusInternalServerError, response)
-	} else {
-		response := model.Response{
-			Status: "0",
-			Msg:    "Success",
-			Data:   userList,
-			Desc:   "",
-		}
-		c.JSON(http.StatusOK, response)
+	response := model.Response{
+		Status: "0",
+		Msg:    "Success",
+		Data:   userList,
+		Desc:   "",
 	}
+	c.JSON(http.StatusOK, response)
 }
 
 // @summary Get User by username
@@ -149,30 +73,15 @@ func GetUmUserList(c *gin.Context) {
 func GetUmUserByUsername(c *gin.Context) {
 	logger := config.GetLog()
 	username := c.Param("username")
-	orgId := GetVariableFromToken(c, "orgId")
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	orgId := tokenString(c, "orgId")
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
-	query := `
-	SELECT t1."id",t1."orgId", t1."displayName", t1.title, t1."firstName", t1."middleName", t1."lastName",
-		t1."citizenId", t1.bod, t1.blood, t1.gender, t1."mobileNo", t1.address, t1.photo, t1.username, t1.password,
-		t1.email, t1."roleId", t1."userType", t1."empId", t1."deptId", t1."commId", t1."stnId", t1.active,
-		t1."activationToken",t1."lastActivationRequest", t1."lostPasswordRequest", t1."signupStamp",
-		t1.islogin, t1."lastLogin",t1."createdAt", t1."updatedAt", t1."createdBy", t1."updatedBy",t2.name,t3."roleName"
-		FROM public.um_users t1
-		JOIN public.organizations t2 ON t1."orgId" = t2.id
-		JOIN public.um_roles t3 ON t1."roleId" = t3.id
-		WHERE t1.username=$1 AND t1."orgId"=$2`
-
-	var rows pgx.Rows
-	logger.Debug(`Query`, zap.String("query", query),
-		zap.Any("Input", []any{
-			username, orgId,
-		}))
-	rows, err := conn.Query(ctx, query, username, orgId)
+
+	logger.Debug(`Get user by username`, zap.Any("Input", []any{username, orgId}))
+	u, err := repo.Users.GetByUsername(ctx, orgId, username)
+	if errors.Is(err, store.ErrNotFound) {
+		u, err = &model.Um_User{}, nil
+	}
 	if err != nil {
 		logger.Warn("Query failed", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, model.Response{
@@ -182,29 +91,6 @@ func GetUmUserByUsername(c *gin.Context) {
 		})
 		return
 	}
-	defer rows.Close()
-	var errorMsg string
-	var u model.Um_User
-	if rows.Next() {
-		err = rows.Scan(&u.ID,
-			&u.OrgID, &u.DisplayName, &u.Title, &u.FirstName, &u.MiddleName, &u.LastName, &u.CitizenID, &u.Bod, &u.Blood,
-			&u.Gender, &u.MobileNo, &u.Address, &u.Photo, &u.Username, &u.Password, &u.Email, &u.RoleID, &u.UserType,
-			&u.EmpID, &u.DeptID, &u.CommID, &u.StnID, &u.Active, &u.ActivationToken, &u.LastActivationRequest,
-			&u.LostPasswordRequest, &u.SignupStamp, &u.IsLogin, &u.LastLogin, &u.CreatedAt, &u.UpdatedAt,
-			&u.CreatedBy, &u.UpdatedBy, &u.OrgName, &u.RoleName,
-		)
-		if err != nil {
-			errorMsg = err.Error()
-			logger.Warn("Scan failed", zap.Error(err))
-			response := model.Response{
-				Status: "-1",
-				Msg:    "Failed",
-				Desc:   errorMsg,
-			}
-			c.JSON(http.StatusInternalServerError, response)
-			return
-		}
-	}
 	response := model.Response{
 		Status: "0",
 		Msg:    "Success",
@@ -226,31 +112,12 @@ func GetUmUserByUsername(c *gin.Context) {
 func GetUmUserById(c *gin.Context) {
 	logger := config.GetLog()
 	id := c.Param("id")
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
-	orgId := GetVariableFromToken(c, "orgId")
+	repo, ctx, cancel := getStore(c.Request.Context())
+	orgId := tokenString(c, "orgId")
 	defer cancel()
-	defer conn.Close(ctx)
-
-	// Step 1: Get user profile
-	queryUser := `
-		SELECT "orgId", "displayName", title, "firstName", "middleName", "lastName", "citizenId", bod, blood, gender, 
-		       "mobileNo", address, photo, username, password, email, "roleId", "userType", "empId", "deptId", "commId", 
-		       "stnId", active, "activationToken", "lastActivationRequest", "lostPasswordRequest", "signupStamp", 
-		       islogin, "lastLogin", "createdAt", "updatedAt", "createdBy", "updatedBy"
-		FROM public.um_users 
-		WHERE id=$1 AND "orgId"=$2
-	`
-	var u model.Um_User
-	err := conn.QueryRow(ctx, queryUser, id, orgId).Scan(
-		&u.OrgID, &u.DisplayName, &u.Title, &u.FirstName, &u.MiddleName, &u.LastName, &u.CitizenID,
-		&u.Bod, &u.Blood, &u.Gender, &u.MobileNo, &u.Address, &u.Photo, &u.Username, &u.Password, &u.Email,
-		&u.RoleID, &u.UserType, &u.EmpID, &u.DeptID, &u.CommID, &u.StnID, &u.Active, &u.ActivationToken,
-		&u.LastActivationRequest, &u.LostPasswordRequest, &u.SignupStamp, &u.IsLogin, &u.LastLogin,
-		&u.CreatedAt, &u.UpdatedAt, &u.CreatedBy, &u.UpdatedBy,
-	)
+
+	// Profile, skills and areas
+	u, err := repo.Users.GetByID(ctx, orgId, id)
 	if err != nil {
 		logger.Warn("Query user failed", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, model.Response{
@@ -261,66 +128,6 @@ func GetUmUserById(c *gin.Context) {
 		return
 	}
 
-	// Step 2: Get skills
-	querySkills := `
-		SELECT s."skillId", s."en", s."th"
-		FROM public.um_user_with_skills us
-		JOIN public.um_skills s ON us."skillId" = s."skillId"
-		WHERE us."orgId" = $1 AND us."userName" = $2 AND us.active = TRUE
-	`
-	rows, err := conn.Query(ctx, querySkills, orgId, u.Username)
-	if err != nil {
-		logger.Warn("Query skills failed", zap.Error(err))
-	}
-	defer rows.Close()
-
-	var skills []map[string]interface{}
-	for rows.Next() {
-		var skillId, en, th string
-		if err := rows.Scan(&skillId, &en, &th); err == nil {
-			skills = append(skills, map[string]interface{}{
-				"skillId": skillId,
-				"en":      en,
-				"th":      th,
-			})
-		}
-	}
-
-	// Step 3: Get areas from um_user_with_area_response
-	queryAreas := `
-		SELECT
-		ad."distId",
-		ad."th",
-		ad."en"
-		FROM "um_user_with_area_response" ua
-		LEFT JOIN "area_districts" ad ON ad."orgId" = ua."orgId"
-		AND ad."distId" IN (
-			SELECT jsonb_array_elements_text(ua."distIdLists")
-		)
-		WHERE ua."orgId" = $1 AND ua."username" = $2 
-	`
-
-	areaRows, err := conn.Query(ctx, queryAreas, orgId, u.Username)
-	if err != nil {
-		logger.Warn("Query areas failed", zap.Error(err))
-	}
-	defer areaRows.Close()
-
-	var areas []map[string]interface{}
-	for areaRows.Next() {
-		var distId, th, en string
-		if err := areaRows.Scan(&distId, &th, &en); err == nil {
-			areas = append(areas, map[string]interface{}{
-				"distId": distId,
-				"th":     th,
-				"en":     en,
-			})
-		}
-	}
-
-	// Step 4: Combine into response
-	u.Skills = skills
-	u.Areas = areas
 	response := model.Response{
 		Status: "0",
 		Msg:    "Success",
@@ -342,12 +149,8 @@ func GetUmUserById(c *gin.Context) {
 // @Router /api/v1/users/add [post]
 func UserAdd(c *gin.Context) {
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
 
 	var req model.UserInput
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -362,54 +165,33 @@ func UserAdd(c *gin.Context) {
 
 	// now req is ready to use
 
-	var enc string
-	var err error
-	var id int
-	enc, err = encrypt(req.Password)
+	enc, err := credential.Hash(req.Password)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, model.Response{
+		c.JSON(http.StatusInternalServerError, model.Response{
 			Status: "-1",
 			Msg:    "Failure",
 			Desc:   err.Error(),
 		})
+		logger.Warn("Password hash failed", zap.Error(err))
 		return
 	}
-	orgId := GetVariableFromToken(c, "orgId")
-	username := GetVariableFromToken(c, "username")
-	tokenString := GetVariableFromToken(c, "tokenString")
-	now := time.Now()
-	query := `
-		INSERT INTO public.um_users(
-		"orgId", "displayName", title, "firstName", "middleName", "lastName", "citizenId", bod, blood, gender,
-		"mobileNo", address, photo, username, password, email, "roleId", "userType", "empId", "deptId",
-		"commId", "stnId", active, "activationToken", "lastActivationRequest", "lostPasswordRequest",
-		"signupStamp", islogin, "lastLogin", "createdAt", "updatedAt", "createdBy", "updatedBy"
-			)
-	VALUES (
-		$1, $2, $3, $4, $5, $6, $7,
-		$8, $9, $10, $11,
-		$12, $13, $14, $15, $16,
-		$17, $18, $19, $20, $21, 
-		$22, $23, $24, $25, $26,
-		$27, $28, $29, $30, $31, 
-		$32, $33
-	)
-	RETURNING id;
-	`
-	logger.Debug(`Query`, zap.String("query", query))
-	logger.Debug(`request input`, zap.Any("Input", []any{req}))
-	logger.Debug(`Encrypt Password :` + enc)
-	err = conn.QueryRow(ctx, query,
-		orgId, req.DisplayName, req.Title, req.FirstName, req.MiddleName,
-		req.LastName, req.CitizenID, req.Bod, req.Blood,
-		req.Gender, req.MobileNo, req.Address, req.Photo, req.Username,
-		enc, req.Email, req.RoleID, req.UserType, req.EmpID, req.DeptID, req.CommID, req.StnID,
-		req.Active, tokenString, req.LastActivationRequest, req.LostPasswordRequest, req.SignupStamp,
-		req.IsLogin, req.LastLogin, now, now, username, username,
-	).Scan(&id)
-
+	orgId := tokenString(c, "orgId")
+	username := tokenString(c, "username")
+	activationToken := tokenString(c, "tokenString")
+
+	bod := req.Bod
+	user := model.UserWrite{
+		OrgID: orgId, DisplayName: req.DisplayName, Title: req.Title, FirstName: req.FirstName,
+		MiddleName: req.MiddleName, LastName: req.LastName, CitizenID: req.CitizenID, Bod: &bod,
+		Blood: req.Blood, Gender: req.Gender, MobileNo: req.MobileNo, Address: req.Address, Photo: req.Photo,
+		Username: req.Username, Password: enc, Email: req.Email, RoleID: req.RoleID, UserType: req.UserType,
+		EmpID: req.EmpID, DeptID: req.DeptID, CommID: req.CommID, StnID: req.StnID, Active: req.Active,
+		ActivationToken: &activationToken, LastActivationRequest: req.LastActivationRequest,
+		LostPasswordRequest: req.LostPasswordRequest, SignupStamp: req.SignupStamp,
+		IsLogin: req.IsLogin, LastLogin: req.LastLogin,
+	}
+	_, err = repo.Users.Create(ctx, user, username)
 	if err != nil {
-		// log.Printf("Insert failed: %v", err)
 		c.JSON(http.StatusUnauthorized, model.Response{
 			Status: "-1",
 			Msg:    "Failure",
@@ -427,6 +209,28 @@ func UserAdd(c *gin.Context) {
 	})
 }
 
+// userUpdateWrite converts an update request into the stored user. bod is
+// sent as a date string and an empty one clears the column.
+func userUpdateWrite(req model.UserUpdate, password string) (model.UserWrite, error) {
+	var bod *time.Time
+	if req.Bod != "" {
+		t, err := parseDate(req.Bod)
+		if err != nil {
+			return model.UserWrite{}, err
+		}
+		bod = &t
+	}
+	return model.UserWrite{
+		DisplayName: req.DisplayName, Title: req.Title, FirstName: req.FirstName,
+		MiddleName: req.MiddleName, LastName: req.LastName, CitizenID: req.CitizenID, Bod: bod,
+		Blood: req.Blood, Gender: req.Gender, MobileNo: req.MobileNo, Address: req.Address, Photo: req.Photo,
+		Username: req.Username, Password: password, Email: req.Email, RoleID: req.RoleID, UserType: req.UserType,
+		EmpID: req.EmpID, DeptID: req.DeptID, CommID: req.CommID, StnID: req.StnID, Active: req.Active,
+		LastActivationRequest: req.LastActivationRequest, LostPasswordRequest: req.LostPasswordRequest,
+		SignupStamp: req.SignupStamp, IsLogin: req.IsLogin, LastLogin: req.LastLogin,
+	}, nil
+}
+
 // @summary Update User
 // @tags User
 // @security ApiKeyAuth
@@ -439,12 +243,8 @@ func UserAdd(c *gin.Context) {
 // @Router /api/v1/users/{id} [patch]
 func UserUpdate(c *gin.Context) {
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
 
 	var req model.UserUpdate
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -459,38 +259,30 @@ func UserUpdate(c *gin.Context) {
 
 	// now req is ready to use
 
-	var enc string
-	var err error
 	id := c.Param("id")
-	enc, err = encrypt(req.Password)
+	enc, err := credential.Hash(req.Password)
 	if err != nil {
+		c.JSON(http.StatusInternalServerError, model.Response{
+			Status: "-1",
+			Msg:    "Failure",
+			Desc:   err.Error(),
+		})
+		logger.Warn("Password hash failed", zap.Error(err))
 		return
 	}
-	orgId := GetVariableFromToken(c, "orgId")
-	username := GetVariableFromToken(c, "username")
-	now := time.Now()
-	query := `
-	UPDATE public.um_users
-	SET "displayName"=$1, title=$2, "firstName"=$3, "middleName"=$4, "lastName"=$5, "citizenId"=$6,
-	bod=$7, blood=$8, gender=$9, "mobileNo"=$10, address=$11, photo=$12, username=$13, password=$14, email=$15, "roleId"=$16,
-	"userType"=$17, "empId"=$18, "deptId"=$19, "commId"=$20, "stnId"=$21, active=$22,
-	"lastActivationRequest"=$23, "lostPasswordRequest"=$24, "signupStamp"=$25, islogin=$26, "lastLogin"=$27,
-	"updatedAt"=$28,"updatedBy"=$29 WHERE id = $30 AND "orgId"=$31`
-
-	logger.Debug(`Query`, zap.String("query", query))
-	logger.Debug(`request input`, zap.Any("Input", []any{req}))
-	logger.Debug(`Encrypt Password :` + enc)
-	_, err = conn.Exec(ctx, query,
-		req.DisplayName, req.Title, req.FirstName, req.MiddleName,
-		req.LastName, req.CitizenID, req.Bod, req.Blood,
-		req.Gender, req.MobileNo, req.Address, req.Photo, req.Username,
-		enc, req.Email, req.RoleID, req.UserType, req.EmpID, req.DeptID, req.CommID, req.StnID,
-		req.Active, req.LastActivationRequest, req.LostPasswordRequest, req.SignupStamp,
-		req.IsLogin, req.LastLogin, now, username, id, orgId,
-	)
+	orgId := tokenString(c, "orgId")
+	username := tokenString(c, "username")
 
+	previous, err := repo.Users.GetByID(ctx, orgId, id)
+	var user model.UserWrite
+	if err == nil {
+		auditPrior(c, previous)
+		user, err = userUpdateWrite(req, enc)
+	}
+	if err == nil {
+		err = repo.Users.Update(ctx, orgId, id, user, username)
+	}
 	if err != nil {
-		// log.Printf("Insert failed: %v", err)
 		c.JSON(http.StatusUnauthorized, model.Response{
 			Status: "-1",
 			Msg:    "Failure",
@@ -500,6 +292,10 @@ func UserUpdate(c *gin.Context) {
 		return
 	}
 
+	invalidatePermissions(orgId, "")
+	if !req.Active || req.Username != previous.Username {
+		revokeUserSessions(ctx, repo, orgId, previous.Username, "deactivated")
+	}
 	// Continue logic...
 	c.JSON(http.StatusOK, model.Response{
 		Status: "0",
@@ -520,12 +316,8 @@ func UserUpdate(c *gin.Context) {
 // @Router /api/v1/users/username/{username} [patch]
 func UserUpdateByUsername(c *gin.Context) {
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
 
 	var req model.UserUpdate
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -540,38 +332,28 @@ func UserUpdateByUsername(c *gin.Context) {
 
 	// now req is ready to use
 
-	var enc string
-	var err error
 	id := c.Param("username")
-	enc, err = encrypt(req.Password)
+	enc, err := credential.Hash(req.Password)
 	if err != nil {
+		c.JSON(http.StatusInternalServerError, model.Response{
+			Status: "-1",
+			Msg:    "Failure",
+			Desc:   err.Error(),
+		})
+		logger.Warn("Password hash failed", zap.Error(err))
 		return
 	}
-	orgId := GetVariableFromToken(c, "orgId")
-	username := GetVariableFromToken(c, "username")
-	now := time.Now()
-	query := `
-	UPDATE public.um_users
-	SET "displayName"=$1, title=$2, "firstName"=$3, "middleName"=$4, "lastName"=$5, "citizenId"=$6,
-	bod=$7, blood=$8, gender=$9, "mobileNo"=$10, address=$11, photo=$12, username=$13, password=$14, email=$15, "roleId"=$16,
-	"userType"=$17, "empId"=$18, "deptId"=$19, "commId"=$20, "stnId"=$21, active=$22,
-	"lastActivationRequest"=$23, "lostPasswordRequest"=$24, "signupStamp"=$25, islogin=$26, "lastLogin"=$27,
-	"updatedAt"=$28,"updatedBy"=$29 WHERE username = $30 AND "orgId"=$31`
-
-	logger.Debug(`Query`, zap.String("query", query))
-	logger.Debug(`request input`, zap.Any("Input", []any{req}))
-	logger.Debug(`Encrypt Password :` + enc)
-	_, err = conn.Exec(ctx, query,
-		req.DisplayName, req.Title, req.FirstName, req.MiddleName,
-		req.LastName, req.CitizenID, req.Bod, req.Bod, req.Blood,
-		req.Gender, req.MobileNo, req.Address, req.Photo, req.Username,
-		enc, req.Email, req.RoleID, req.UserType, req.EmpID, req.DeptID, req.CommID, req.StnID,
-		req.Active, req.LastActivationRequest, req.LostPasswordRequest, req.SignupStamp,
-		req.IsLogin, req.LastLogin, now, username, id, orgId,
-	)
+	orgId := tokenString(c, "orgId")
+	username := tokenString(c, "username")
 
+	if prior, err := repo.Users.GetByUsername(ctx, orgId, id); err == nil {
+		auditPrior(c, prior)
+	}
+	user, err := userUpdateWrite(req, enc)
+	if err == nil {
+		err = repo.Users.UpdateByUsername(ctx, orgId, id, user, username)
+	}
 	if err != nil {
-		// log.Printf("Insert failed: %v", err)
 		c.JSON(http.StatusUnauthorized, model.Response{
 			Status: "-1",
 			Msg:    "Failure",
@@ -581,6 +363,10 @@ func UserUpdateByUsername(c *gin.Context) {
 		return
 	}
 
+	invalidateUserPermissions(orgId, id)
+	if !req.Active || req.Username != id {
+		revokeUserSessions(ctx, repo, orgId, id, "deactivated")
+	}
 	// Continue logic...
 	c.JSON(http.StatusOK, model.Response{
 		Status: "0",
@@ -600,19 +386,17 @@ func UserUpdateByUsername(c *gin.Context) {
 // @Router /api/v1/users/{id} [delete]
 func UserDelete(c *gin.Context) {
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
 	id := c.Param("id")
-	orgId := GetVariableFromToken(c, "orgId")
-	query := `DELETE FROM public."um_users" WHERE id = $1 AND "orgId"=$2`
-	logger.Debug("Query", zap.String("query", query), zap.Any("id", id))
-	_, err := conn.Exec(ctx, query, id, orgId)
+	orgId := tokenString(c, "orgId")
+	logger.Debug("Delete user", zap.Any("id", id))
+	user, err := repo.Users.GetByID(ctx, orgId, id)
+	if err == nil {
+		auditPrior(c, user)
+		err = repo.Users.Delete(ctx, orgId, id)
+	}
 	if err != nil {
-		// log.Printf("Insert failed: %v", err)
 		c.JSON(http.StatusInternalServerError, model.Response{
 			Status: "-1",
 			Msg:    "Failure",
@@ -622,6 +406,8 @@ func UserDelete(c *gin.Context) {
 		return
 	}
 
+	invalidatePermissions(orgId, "")
+	revokeUserSessions(ctx, repo, orgId, user.Username, "deleted")
 	// Continue logic...
 	c.JSON(http.StatusOK, model.Response{
 		Status: "0",
@@ -642,31 +428,11 @@ func UserDelete(c *gin.Context) {
 // @Router /api/v1/users_with_skills [get]
 func GetUserWithSkills(c *gin.Context) {
 	logger := config.GetLog()
-	orgId := GetVariableFromToken(c, "orgId")
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
+	start, length := pageQuery(c)
 
-	startStr := c.DefaultQuery("start", "0")
-	start, err := strconv.Atoi(startStr)
-	if err != nil {
-		start = 0
-	}
-	lengthStr := c.DefaultQuery("length", "1000")
-	length, err := strconv.Atoi(lengthStr)
-	if err != nil {
-		length = 1000
-	}
-
-	query := `SELECT "orgId", "userName", "skillId", active, "createdAt", "updatedAt", "createdBy", "updatedBy" 
-	FROM public.um_user_with_skills WHERE "orgId"=$1 LIMIT $2 OFFSET $3`
-
-	var rows pgx.Rows
-	logger.Debug(`Query`, zap.String("query", query))
-	rows, err = conn.Query(ctx, query, orgId, length, start)
+	list, err := repo.Skills.ListUserSkills(ctx, tokenString(c, "orgId"), length, start)
 	if err != nil {
 		logger.Warn("Query failed", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, model.Response{
@@ -676,51 +442,11 @@ func GetUserWithSkills(c *gin.Context) {
 		})
 		return
 	}
-	defer rows.Close()
-	var errorMsg string
-	var u model.UserSkill
-	var userList []model.UserSkill
-	rowIndex := 0
-	for rows.Next() {
-		rowIndex++
-		err := rows.Scan(
-			&u.OrgID,
-			&u.UserName,
-			&u.SkillID,
-			&u.Active,
-			&u.CreatedAt,
-			&u.UpdatedAt,
-			&u.CreatedBy,
-			&u.UpdatedBy,
-		)
-
-		if err != nil {
-			logger.Warn("Scan failed", zap.Error(err))
-			response := model.Response{
-				Status: "-1",
-				Msg:    "Failed",
-				Desc:   errorMsg,
-			}
-			c.JSON(http.StatusInternalServerError, response)
-		}
-		userList = append(userList, u)
-	}
-	if errorMsg != "" {
-		response := model.Response{
-			Status: "-1",
-			Msg:    "Failed",
-			Desc:   errorMsg,
-		}
-		c.JSON(http.StatusInternalServerError, response)
-	} else {
-		response := model.Response{
-			Status: "0",
-			Msg:    "Success",
-			Data:   userList,
-			Desc:   "",
-		}
-		c.JSON(http.StatusOK, response)
-	}
+	c.JSON(http.StatusOK, model.Response{
+		Status: "0",
+		Msg:    "Success",
+		Data:   list,
+	})
 }
 
 // @summary Get User with skills by id
@@ -734,61 +460,24 @@ func GetUserWithSkills(c *gin.Context) {
 // @Router /api/v1/users_with_skills/{id} [get]
 func GetUserWithSkillsById(c *gin.Context) {
 	logger := config.GetLog()
-	id := c.Param("id")
-	orgId := GetVariableFromToken(c, "orgId")
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
-	query := `SELECT "orgId", "userName", "skillId", active, "createdAt", "updatedAt", "createdBy", "updatedBy" 
-	FROM public.um_user_with_skills WHERE id=$1 AND "orgId"=$2`
 
-	var rows pgx.Rows
-	logger.Debug(`Query`, zap.String("query", query))
-	rows, err := conn.Query(ctx, query, id, orgId)
+	skill, err := repo.Skills.GetUserSkill(ctx, tokenString(c, "orgId"), c.Param("id"))
 	if err != nil {
 		logger.Warn("Query failed", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, model.Response{
+		c.JSON(storeStatus(err), model.Response{
 			Status: "-1",
 			Msg:    "Failure",
 			Desc:   err.Error(),
 		})
 		return
 	}
-	defer rows.Close()
-	var u model.UserSkill
-	err = rows.Scan(
-		&u.OrgID,
-		&u.UserName,
-		&u.SkillID,
-		&u.Active,
-		&u.CreatedAt,
-		&u.UpdatedAt,
-		&u.CreatedBy,
-		&u.UpdatedBy,
-	)
-
-	if err != nil {
-		logger.Warn("Scan failed", zap.Error(err))
-		response := model.Response{
-			Status: "-1",
-			Msg:    "Failed",
-			Desc:   err.Error(),
-		}
-		c.JSON(http.StatusInternalServerError, response)
-		return
-	}
-
-	response := model.Response{
+	c.JSON(http.StatusOK, model.Response{
 		Status: "0",
 		Msg:    "Success",
-		Data:   u,
-		Desc:   "",
-	}
-	c.JSON(http.StatusOK, response)
-
+		Data:   skill,
+	})
 }
 
 // @summary Get User with skills by skill id
@@ -802,48 +491,10 @@ func GetUserWithSkillsById(c *gin.Context) {
 // @Router /api/v1/users_with_skills/skillId/{skillId} [get]
 func GetUserWithSkillsBySkillId(c *gin.Context) {
 	logger := config.GetLog()
-	skillId := c.Param("skillId")
-	orgId := GetVariableFromToken(c, "orgId")
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
-	query := `SELECT "orgId", "userName", "skillId", active, "createdAt", "updatedAt", "createdBy", "updatedBy" 
-	FROM public.um_user_with_skills WHERE "skillId" = $1 AND "orgId" = $2`
-
-	var rows pgx.Rows
-	logger.Debug(`Query`, zap.String("query", query))
-	rows, err := conn.Query(ctx, query, skillId, orgId)
-	var userList []model.UserSkill
-	var u model.UserSkill
-	var errorMsg string
-	rowIndex := 0
-	for rows.Next() {
-		rowIndex++
-		err := rows.Scan(
-			&u.OrgID,
-			&u.UserName,
-			&u.SkillID,
-			&u.Active,
-			&u.CreatedAt,
-			&u.UpdatedAt,
-			&u.CreatedBy,
-			&u.UpdatedBy,
-		)
 
-		if err != nil {
-			logger.Warn("Scan failed", zap.Error(err))
-			response := model.Response{
-				Status: "-1",
-				Msg:    "Failed",
-				Desc:   errorMsg,
-			}
-			c.JSON(http.StatusInternalServerError, response)
-		}
-		userList = append(userList, u)
-	}
+	list, err := repo.Skills.ListSkillUsers(ctx, tokenString(c, "orgId"), c.Param("skillId"))
 	if err != nil {
 		logger.Warn("Query failed", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, model.Response{
@@ -853,25 +504,11 @@ func GetUserWithSkillsBySkillId(c *gin.Context) {
 		})
 		return
 	}
-	defer rows.Close()
-	// 	logger.Warn("Scan failed", zap.Error(err))
-	// 	response := model.Response{
-	// 		Status: "-1",
-	// 		Msg:    "Failed",
-	// 		Desc:   err.Error(),
-	// 	}
-	// 	c.JSON(http.StatusInternalServerError, response)
-	// 	return
-	// }
-
-	response := model.Response{
+	c.JSON(http.StatusOK, model.Response{
 		Status: "0",
 		Msg:    "Success",
-		Data:   userList,
-		Desc:   "",
-	}
-	c.JSON(http.StatusOK, response)
-
+		Data:   list,
+	})
 }
 
 // @summary Create User with skill
@@ -885,14 +522,9 @@ func GetUserWithSkillsBySkillId(c *gin.Context) {
 // @Router /api/v1/users_with_skills/add [post]
 func InsertUserWithSkills(c *gin.Context) {
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
-	defer cancel()
-	defer conn.Close(ctx)
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	username := GetVariableFromToken(c, "username")
+
 	var req model.UserSkillInsert
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, model.Response{
@@ -903,23 +535,10 @@ func InsertUserWithSkills(c *gin.Context) {
 		logger.Warn("Insert failed", zap.Error(err))
 		return
 	}
+	username := tokenString(c, "username")
+	orgId := tokenString(c, "orgId")
 
-	// now req is ready to use
-	now := time.Now()
-	var id int
-	query := `
-	INSERT INTO public."um_user_with_skills"(
-	"orgId", "userName", "skillId", active, "createdAt", "updatedAt", "createdBy", "updatedBy")
-	VALUES ($1, $2, $3, $4, $5, $6, $7,$8)
-	RETURNING id ;
-	`
-
-	err := conn.QueryRow(ctx, query,
-		req.OrgID, req.UserName, req.SkillID, req.Active, now,
-		now, username, username).Scan(&id)
-
-	if err != nil {
-		// log.Printf("Insert failed: %v", err)
+	if err := repo.Skills.CreateUserSkill(ctx, orgId, username, req); err != nil {
 		c.JSON(http.StatusInternalServerError, model.Response{
 			Status: "-1",
 			Msg:    "Failure",
@@ -928,14 +547,11 @@ func InsertUserWithSkills(c *gin.Context) {
 		logger.Warn("Insert failed", zap.Error(err))
 		return
 	}
-
-	// Continue logic...
 	c.JSON(http.StatusOK, model.Response{
 		Status: "0",
 		Msg:    "Success",
 		Desc:   "Create successfully",
 	})
-
 }
 
 // @summary Update User with skill
@@ -950,49 +566,24 @@ func InsertUserWithSkills(c *gin.Context) {
 // @Router /api/v1/users_with_skills/{id} [patch]
 func UpdateUserWithSkills(c *gin.Context) {
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
-	defer cancel()
-
-	id := c.Param("id")
 
 	var req model.UserSkillUpdate
 	if err := c.ShouldBindJSON(&req); err != nil {
-		logger.Warn("Update failed", zap.Error(err))
-		c.JSON(http.StatusBadRequest, model.UpdateCaseResponse{
+		c.JSON(http.StatusBadRequest, model.Response{
 			Status: "-1",
 			Msg:    "Failure",
 			Desc:   err.Error(),
-			ID:     ToInt(id),
 		})
+		logger.Warn("Update failed", zap.Error(err))
 		return
 	}
-	username := GetVariableFromToken(c, "username")
-	now := time.Now()
-	query := `UPDATE public."um_user_with_skills"
-	SET 
-    "skillId"=$2,
-    active=$3,
-	"updatedAt"=$4,
-	"updatedBy"=$5
-	WHERE id = $1 `
-	_, err := conn.Exec(ctx, query,
-		id, req.SkillID, req.Active,
-		now, username, username,
-	)
-	logger.Debug("Update Case SQL Args",
-		zap.String("query", query),
-		zap.Any("Input", []any{
-			id, req.SkillID, req.Active,
-			now, username,
-		}))
-	if err != nil {
-		// log.Printf("Insert failed: %v", err)
-		c.JSON(http.StatusInternalServerError, model.Response{
+	username := tokenString(c, "username")
+	orgId := tokenString(c, "orgId")
+
+	if err := repo.Skills.UpdateUserSkill(ctx, orgId, c.Param("id"), username, req); err != nil {
+		c.JSON(storeStatus(err), model.Response{
 			Status: "-1",
 			Msg:    "Failure",
 			Desc:   err.Error(),
@@ -1000,8 +591,6 @@ func UpdateUserWithSkills(c *gin.Context) {
 		logger.Warn("Update failed", zap.Error(err))
 		return
 	}
-
-	// Continue logic...
 	c.JSON(http.StatusOK, model.Response{
 		Status: "0",
 		Msg:    "Success",
@@ -1019,32 +608,19 @@ func UpdateUserWithSkills(c *gin.Context) {
 // @response 200 {object} model.Response "OK - Request successful"
 // @Router /api/v1/users_with_skills/{id} [delete]
 func DeleteUserWithSkills(c *gin.Context) {
-
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
-	defer cancel()
-	defer conn.Close(ctx)
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
 
-	id := c.Param("id")
-	query := `DELETE FROM public."um_user_with_skills" WHERE id = $1 `
-	logger.Debug("Query", zap.String("query", query), zap.Any("id", id))
-	_, err := conn.Exec(ctx, query, id)
-	if err != nil {
-		// log.Printf("Insert failed: %v", err)
-		c.JSON(http.StatusInternalServerError, model.Response{
+	if err := repo.Skills.DeleteUserSkill(ctx, tokenString(c, "orgId"), c.Param("id")); err != nil {
+		c.JSON(storeStatus(err), model.Response{
 			Status: "-1",
 			Msg:    "Failure",
 			Desc:   err.Error(),
 		})
-		logger.Warn("Update failed", zap.Error(err))
+		logger.Warn("Delete failed", zap.Error(err))
 		return
 	}
-
-	// Continue logic...
 	c.JSON(http.StatusOK, model.Response{
 		Status: "0",
 		Msg:    "Success",
@@ -1064,32 +640,11 @@ func DeleteUserWithSkills(c *gin.Context) {
 // @Router /api/v1/users_with_contacts [get]
 func GetUserWithContacts(c *gin.Context) {
 	logger := config.GetLog()
-
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
-	orgId := GetVariableFromToken(c, "orgId")
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
-
-	startStr := c.DefaultQuery("start", "0")
-	start, err := strconv.Atoi(startStr)
-	if err != nil {
-		start = 0
-	}
-	lengthStr := c.DefaultQuery("length", "1000")
-	length, err := strconv.Atoi(lengthStr)
-	if err != nil {
-		length = 1000
-	}
-
-	query := `SELECT  "orgId", username, "contactName", "contactPhone", "contactAddr", "createdAt", "updatedAt", "createdBy", "updatedBy" 	
-	FROM public.um_user_contacts WHERE "orgId"=$1 LIMIT $2 OFFSET $3`
+	start, length := pageQuery(c)
 
-	var rows pgx.Rows
-	logger.Debug(`Query`, zap.String("query", query))
-	rows, err = conn.Query(ctx, query, orgId, length, start)
+	list, err := repo.Profiles.ListContacts(ctx, tokenString(c, "orgId"), length, start)
 	if err != nil {
 		logger.Warn("Query failed", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, model.Response{
@@ -1099,53 +654,11 @@ func GetUserWithContacts(c *gin.Context) {
 		})
 		return
 	}
-	defer rows.Close()
-	var errorMsg string
-	var u model.UserContact
-	var userList []model.UserContact
-	rowIndex := 0
-	for rows.Next() {
-		rowIndex++
-		err := rows.Scan(
-			&u.OrgID,
-			&u.Username,
-			&u.ContactName,
-			&u.ContactPhone,
-			&u.ContactAddr,
-			&u.CreatedAt,
-			&u.UpdatedAt,
-			&u.CreatedBy,
-			&u.UpdatedBy,
-		)
-		if err != nil {
-			logger.Warn("Scan failed", zap.Error(err))
-			response := model.Response{
-				Status: "-1",
-				Msg:    "Failed",
-				Desc:   errorMsg,
-			}
-			c.JSON(http.StatusInternalServerError, response)
-			return
-		}
-
-		userList = append(userList, u)
-	}
-	if errorMsg != "" {
-		response := model.Response{
-			Status: "-1",
-			Msg:    "Failed",
-			Desc:   errorMsg,
-		}
-		c.JSON(http.StatusInternalServerError, response)
-	} else {
-		response := model.Response{
-			Status: "0",
-			Msg:    "Success",
-			Data:   userList,
-			Desc:   "",
-		}
-		c.JSON(http.StatusOK, response)
-	}
+	c.JSON(http.StatusOK, model.Response{
+		Status: "0",
+		Msg:    "Success",
+		Data:   list,
+	})
 }
 
 // @summary Get User with contacts by id
@@ -1159,63 +672,24 @@ func GetUserWithContacts(c *gin.Context) {
 // @Router /api/v1/users_with_contacts/{id} [get]
 func GetUserWithContactsById(c *gin.Context) {
 	logger := config.GetLog()
-	id := c.Param("id")
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
-	orgId := GetVariableFromToken(c, "orgId")
-	query := `SELECT  "orgId", username, "contactName", "contactPhone", "contactAddr", "createdAt", "updatedAt", "createdBy", "updatedBy" 
-	FROM public.um_user_contacts WHERE id=$1 AND "orgId"=$2`
-
-	var rows pgx.Rows
-	logger.Debug(`Query`, zap.String("query", query))
-	rows, err := conn.Query(ctx, query, id, orgId)
+
+	contact, err := repo.Profiles.GetContact(ctx, tokenString(c, "orgId"), c.Param("id"))
 	if err != nil {
 		logger.Warn("Query failed", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, model.Response{
+		c.JSON(storeStatus(err), model.Response{
 			Status: "-1",
 			Msg:    "Failure",
 			Desc:   err.Error(),
 		})
 		return
 	}
-	defer rows.Close()
-	var u model.UserContact
-
-	err = rows.Scan(
-		&u.OrgID,
-		&u.Username,
-		&u.ContactName,
-		&u.ContactPhone,
-		&u.ContactAddr,
-		&u.CreatedAt,
-		&u.UpdatedAt,
-		&u.CreatedBy,
-		&u.UpdatedBy,
-	)
-
-	if err != nil {
-		logger.Warn("Scan failed", zap.Error(err))
-		response := model.Response{
-			Status: "-1",
-			Msg:    "Failed",
-			Desc:   err.Error(),
-		}
-		c.JSON(http.StatusInternalServerError, response)
-		return
-	}
-
-	response := model.Response{
+	c.JSON(http.StatusOK, model.Response{
 		Status: "0",
 		Msg:    "Success",
-		Data:   u,
-		Desc:   "",
-	}
-	c.JSON(http.StatusOK, response)
-
+		Data:   contact,
+	})
 }
 
 // @summary Create User with contacts
@@ -1229,12 +703,7 @@ func GetUserWithContactsById(c *gin.Context) {
 // @Router /api/v1/users_with_contacts/add [post]
 func InsertUserWithContacts(c *gin.Context) {
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
-	defer cancel()
-	defer conn.Close(ctx)
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
 
 	var req model.UserContactInsert
@@ -1247,23 +716,10 @@ func InsertUserWithContacts(c *gin.Context) {
 		logger.Warn("Insert failed", zap.Error(err))
 		return
 	}
+	username := tokenString(c, "username")
+	orgId := tokenString(c, "orgId")
 
-	now := time.Now()
-	username := GetVariableFromToken(c, "username")
-	var id int
-	query := `
-	INSERT INTO public."um_user_contacts"(
-	"orgId", username, "contactName", "contactPhone", "contactAddr", "createdAt", "updatedAt", "createdBy", "updatedBy")
-	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
-	RETURNING id ;
-	`
-
-	err := conn.QueryRow(ctx, query,
-		req.OrgID, req.Username, req.ContactName, req.ContactPhone, req.ContactAddr, now,
-		now, username, username).Scan(&id)
-
-	if err != nil {
-		// log.Printf("Insert failed: %v", err)
+	if err := repo.Profiles.CreateContact(ctx, orgId, username, req); err != nil {
 		c.JSON(http.StatusInternalServerError, model.Response{
 			Status: "-1",
 			Msg:    "Failure",
@@ -1272,14 +728,11 @@ func InsertUserWithContacts(c *gin.Context) {
 		logger.Warn("Insert failed", zap.Error(err))
 		return
 	}
-
-	// Continue logic...
 	c.JSON(http.StatusOK, model.Response{
 		Status: "0",
 		Msg:    "Success",
 		Desc:   "Create successfully",
 	})
-
 }
 
 // @summary Update User with contacts
@@ -1294,43 +747,24 @@ func InsertUserWithContacts(c *gin.Context) {
 // @Router /api/v1/users_with_contacts/{id} [patch]
 func UpdateUserWithContacts(c *gin.Context) {
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
-	defer cancel()
-
-	id := c.Param("id")
 
 	var req model.UserContactInsertUpdate
 	if err := c.ShouldBindJSON(&req); err != nil {
-		logger.Warn("Update failed", zap.Error(err))
 		c.JSON(http.StatusBadRequest, model.Response{
 			Status: "-1",
 			Msg:    "Failure",
 			Desc:   err.Error(),
 		})
+		logger.Warn("Update failed", zap.Error(err))
 		return
 	}
-	username := GetVariableFromToken(c, "username")
-	orgId := GetVariableFromToken(c, "orgId")
-	now := time.Now()
-	query := `UPDATE public."um_user_contacts"
-	SET "contactName"=$2, "contactPhone"=$3, "contactAddr"=$4,"updatedAt"=$5,"updatedBy"=$6
-	WHERE id = $1 AND "orgId"=$7`
-	_, err := conn.Exec(ctx, query, id,
-		req.ContactName, req.ContactPhone, req.ContactAddr, now, username, orgId)
-
-	logger.Debug("Update Case SQL Args",
-		zap.String("query", query),
-		zap.Any("Input", []any{
-			id, req.ContactName, req.ContactPhone, req.ContactAddr, now, username, orgId,
-		}))
-	if err != nil {
-		// log.Printf("Insert failed: %v", err)
-		c.JSON(http.StatusInternalServerError, model.Response{
+	username := tokenString(c, "username")
+	orgId := tokenString(c, "orgId")
+
+	if err := repo.Profiles.UpdateContact(ctx, orgId, c.Param("id"), username, req); err != nil {
+		c.JSON(storeStatus(err), model.Response{
 			Status: "-1",
 			Msg:    "Failure",
 			Desc:   err.Error(),
@@ -1338,8 +772,6 @@ func UpdateUserWithContacts(c *gin.Context) {
 		logger.Warn("Update failed", zap.Error(err))
 		return
 	}
-
-	// Continue logic...
 	c.JSON(http.StatusOK, model.Response{
 		Status: "0",
 		Msg:    "Success",
@@ -1357,33 +789,19 @@ func UpdateUserWithContacts(c *gin.Context) {
 // @response 200 {object} model.Response "OK - Request successful"
 // @Router /api/v1/users_with_contacts/{id} [delete]
 func DeleteUserWithContacts(c *gin.Context) {
-
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
-	defer cancel()
-	defer conn.Close(ctx)
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
 
-	id := c.Param("id")
-	orgId := GetVariableFromToken(c, "orgId")
-	query := `DELETE FROM public."um_user_contacts" WHERE id = $1 AND "orgId"=$2`
-	logger.Debug("Query", zap.String("query", query), zap.Any("id", id))
-	_, err := conn.Exec(ctx, query, id, orgId)
-	if err != nil {
-		// log.Printf("Insert failed: %v", err)
-		c.JSON(http.StatusInternalServerError, model.Response{
+	if err := repo.Profiles.DeleteContact(ctx, tokenString(c, "orgId"), c.Param("id")); err != nil {
+		c.JSON(storeStatus(err), model.Response{
 			Status: "-1",
 			Msg:    "Failure",
 			Desc:   err.Error(),
 		})
-		logger.Warn("Update failed", zap.Error(err))
+		logger.Warn("Delete failed", zap.Error(err))
 		return
 	}
-
-	// Continue logic...
 	c.JSON(http.StatusOK, model.Response{
 		Status: "0",
 		Msg:    "Success",
@@ -1403,32 +821,11 @@ func DeleteUserWithContacts(c *gin.Context) {
 // @Router /api/v1/users_with_socials [get]
 func GetUserWithSocials(c *gin.Context) {
 	logger := config.GetLog()
-
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
-	orgId := GetVariableFromToken(c, "orgId")
-	startStr := c.DefaultQuery("start", "0")
-	start, err := strconv.Atoi(startStr)
-	if err != nil {
-		start = 0
-	}
-	lengthStr := c.DefaultQuery("length", "1000")
-	length, err := strconv.Atoi(lengthStr)
-	if err != nil {
-		length = 1000
-	}
+	start, length := pageQuery(c)
 
-	query := `SELECT  "orgId", username, "socialType", "socialId", "socialName", "createdAt", "updatedAt", "createdBy", "updatedBy" 	
-	FROM public.um_user_with_socials WHERE "orgId"=$1 
-	LIMIT $2 OFFSET $3`
-
-	var rows pgx.Rows
-	logger.Debug(`Query`, zap.String("query", query))
-	rows, err = conn.Query(ctx, query, orgId, length, start)
+	list, err := repo.Profiles.ListSocials(ctx, tokenString(c, "orgId"), length, start)
 	if err != nil {
 		logger.Warn("Query failed", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, model.Response{
@@ -1438,53 +835,11 @@ func GetUserWithSocials(c *gin.Context) {
 		})
 		return
 	}
-	defer rows.Close()
-	var errorMsg string
-	var u model.UserSocial
-	var userList []model.UserSocial
-	rowIndex := 0
-	for rows.Next() {
-		rowIndex++
-		err := rows.Scan(
-			&u.OrgID,
-			&u.Username,
-			&u.SocialType,
-			&u.SocialID,
-			&u.SocialName,
-			&u.CreatedAt,
-			&u.UpdatedAt,
-			&u.CreatedBy,
-			&u.UpdatedBy,
-		)
-		if err != nil {
-			logger.Warn("Scan failed", zap.Error(err))
-			response := model.Response{
-				Status: "-1",
-				Msg:    "Failed",
-				Desc:   errorMsg,
-			}
-			c.JSON(http.StatusInternalServerError, response)
-			return
-		}
-
-		userList = append(userList, u)
-	}
-	if errorMsg != "" {
-		response := model.Response{
-			Status: "-1",
-			Msg:    "Failed",
-			Desc:   errorMsg,
-		}
-		c.JSON(http.StatusInternalServerError, response)
-	} else {
-		response := model.Response{
-			Status: "0",
-			Msg:    "Success",
-			Data:   userList,
-			Desc:   "",
-		}
-		c.JSON(http.StatusOK, response)
-	}
+	c.JSON(http.StatusOK, model.Response{
+		Status: "0",
+		Msg:    "Success",
+		Data:   list,
+	})
 }
 
 // @summary Get User with Socials by id
@@ -1498,61 +853,24 @@ func GetUserWithSocials(c *gin.Context) {
 // @Router /api/v1/users_with_socials/{id} [get]
 func GetUserWithSocialsById(c *gin.Context) {
 	logger := config.GetLog()
-	id := c.Param("id")
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
-	orgId := GetVariableFromToken(c, "orgId")
-	query := `SELECT  "orgId", username, "socialType", "socialId", "socialName", "createdAt", "updatedAt", "createdBy", "updatedBy" 	
-	FROM public.um_user_with_socials WHERE id=$1 AND "orgId"=$2`
-
-	var rows pgx.Rows
-	logger.Debug(`Query`, zap.String("query", query))
-	rows, err := conn.Query(ctx, query, id, orgId)
+
+	social, err := repo.Profiles.GetSocial(ctx, tokenString(c, "orgId"), c.Param("id"))
 	if err != nil {
 		logger.Warn("Query failed", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, model.Response{
+		c.JSON(storeStatus(err), model.Response{
 			Status: "-1",
 			Msg:    "Failure",
 			Desc:   err.Error(),
 		})
 		return
 	}
-	defer rows.Close()
-	var u model.UserSocial
-	err = rows.Scan(
-		&u.OrgID,
-		&u.Username,
-		&u.SocialType,
-		&u.SocialID,
-		&u.SocialName,
-		&u.CreatedAt,
-		&u.UpdatedAt,
-		&u.CreatedBy,
-		&u.UpdatedBy,
-	)
-
-	if err != nil {
-		logger.Warn("Scan failed", zap.Error(err))
-		response := model.Response{
-			Status: "-1",
-			Msg:    "Failed",
-			Desc:   err.Error(),
-		}
-		c.JSON(http.StatusInternalServerError, response)
-	}
-
-	response := model.Response{
+	c.JSON(http.StatusOK, model.Response{
 		Status: "0",
 		Msg:    "Success",
-		Data:   u,
-		Desc:   "",
-	}
-	c.JSON(http.StatusOK, response)
-
+		Data:   social,
+	})
 }
 
 // @summary Create User with socials
@@ -1566,12 +884,7 @@ func GetUserWithSocialsById(c *gin.Context) {
 // @Router /api/v1/users_with_socials/add [post]
 func InsertUserWithSocials(c *gin.Context) {
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
-	defer cancel()
-	defer conn.Close(ctx)
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
 
 	var req model.UserSocialInsert
@@ -1584,23 +897,10 @@ func InsertUserWithSocials(c *gin.Context) {
 		logger.Warn("Insert failed", zap.Error(err))
 		return
 	}
-	username := GetVariableFromToken(c, "username")
-	// now req is ready to use
-	now := time.Now()
-	var id int
-	query := `
-	INSERT INTO public."um_user_with_socials"(
-	"orgId", username, "socialType", "socialId", "socialName", "createdAt", "updatedAt", "createdBy", "updatedBy")
-	VALUES ($1, $2, $3, $4, $5, $6, $7,$8,$9)
-	RETURNING id ;
-	`
-
-	err := conn.QueryRow(ctx, query,
-		req.OrgID, req.Username, req.SocialType, req.SocialID, req.SocialName, now,
-		now, username, username).Scan(&id)
+	username := tokenString(c, "username")
+	orgId := tokenString(c, "orgId")
 
-	if err != nil {
-		// log.Printf("Insert failed: %v", err)
+	if err := repo.Profiles.CreateSocial(ctx, orgId, username, req); err != nil {
 		c.JSON(http.StatusInternalServerError, model.Response{
 			Status: "-1",
 			Msg:    "Failure",
@@ -1609,14 +909,11 @@ func InsertUserWithSocials(c *gin.Context) {
 		logger.Warn("Insert failed", zap.Error(err))
 		return
 	}
-
-	// Continue logic...
 	c.JSON(http.StatusOK, model.Response{
 		Status: "0",
 		Msg:    "Success",
 		Desc:   "Create successfully",
 	})
-
 }
 
 // @summary Update User with socials
@@ -1631,46 +928,24 @@ func InsertUserWithSocials(c *gin.Context) {
 // @Router /api/v1/users_with_socials/{id} [patch]
 func UpdateUserWithSocials(c *gin.Context) {
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
-	defer cancel()
-
-	id := c.Param("id")
 
 	var req model.UserSocialUpdate
 	if err := c.ShouldBindJSON(&req); err != nil {
-		logger.Warn("Update failed", zap.Error(err))
 		c.JSON(http.StatusBadRequest, model.Response{
 			Status: "-1",
 			Msg:    "Failure",
 			Desc:   err.Error(),
 		})
+		logger.Warn("Update failed", zap.Error(err))
 		return
 	}
-	now := time.Now()
-	username := GetVariableFromToken(c, "username")
-	orgId := GetVariableFromToken(c, "orgId")
-	query := `UPDATE public."um_user_with_socials"
-	SET "orgId"=$2, username=$3, "socialType"=$4, "socialId"=$5, "socialName"=$6, "updatedAt"=$7, "updatedBy"=$8
-	WHERE id = $1 AND "orgId"=$9`
-	_, err := conn.Exec(ctx, query,
-		id, req.OrgID, req.Username, req.SocialType, req.SocialID, req.SocialName,
-		now, username, orgId,
-	)
-	logger.Debug("Update Case SQL Args",
-		zap.String("query", query),
-		zap.Any("Input", []any{
-			id,
-			req.OrgID, req.Username, req.SocialType, req.SocialID, req.SocialName,
-			now, username, orgId,
-		}))
-	if err != nil {
-		// log.Printf("Insert failed: %v", err)
-		c.JSON(http.StatusInternalServerError, model.Response{
+	username := tokenString(c, "username")
+	orgId := tokenString(c, "orgId")
+
+	if err := repo.Profiles.UpdateSocial(ctx, orgId, c.Param("id"), username, req); err != nil {
+		c.JSON(storeStatus(err), model.Response{
 			Status: "-1",
 			Msg:    "Failure",
 			Desc:   err.Error(),
@@ -1678,8 +953,6 @@ func UpdateUserWithSocials(c *gin.Context) {
 		logger.Warn("Update failed", zap.Error(err))
 		return
 	}
-
-	// Continue logic...
 	c.JSON(http.StatusOK, model.Response{
 		Status: "0",
 		Msg:    "Success",
@@ -1697,32 +970,19 @@ func UpdateUserWithSocials(c *gin.Context) {
 // @response 200 {object} model.Response "OK - Request successful"
 // @Router /api/v1/users_with_socials/{id} [delete]
 func DeleteUserWithSocials(c *gin.Context) {
-
 	logger := config.GetLog()
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
-	defer cancel()
-	orgId := GetVariableFromToken(c, "orgId")
-	id := c.Param("id")
-	query := `DELETE FROM public."um_user_with_socials" WHERE id = $1 AND "orgId"=$2`
-	logger.Debug("Query", zap.String("query", query), zap.Any("id", id))
-	_, err := conn.Exec(ctx, query, id, orgId)
-	if err != nil {
-		// log.Printf("Insert failed: %v", err)
-		c.JSON(http.StatusInternalServerError, model.Response{
+
+	if err := repo.Profiles.DeleteSocial(ctx, tokenString(c, "orgId"), c.Param("id")); err != nil {
+		c.JSON(storeStatus(err), model.Response{
 			Status: "-1",
 			Msg:    "Failure",
 			Desc:   err.Error(),
 		})
-		logger.Warn("Update failed", zap.Error(err))
+		logger.Warn("Delete failed", zap.Error(err))
 		return
 	}
-
-	// Continue logic...
 	c.JSON(http.StatusOK, model.Response{
 		Status: "0",
 		Msg:    "Success",
@@ -1742,36 +1002,11 @@ func DeleteUserWithSocials(c *gin.Context) {
 // @Router /api/v1/user_groups/all [get]
 func GetUmGroupList(c *gin.Context) {
 	logger := config.GetLog()
-	orgId := GetVariableFromToken(c, "orgId")
-	conn, ctx, cancel := config.ConnectDB()
-	if conn == nil {
-		return
-	}
+	repo, ctx, cancel := getStore(c.Request.Context())
 	defer cancel()
-	defer conn.Close(ctx)
+	start, length := pageQuery(c)
 
-	startStr := c.DefaultQuery("start", "0")
-	start, err := strconv.Atoi(startStr)
-	if err != nil {
-		start = 0
-	}
-	lengthStr := c.DefaultQuery("length", "1000")
-	length, err := strconv.Atoi(lengthStr)
-	if err != nil {
-		length = 1000
-	}
-	logger.Debug(`Query`, zap.Any("start", start))
-	logger.Debug(`Query`, zap.Any("length", length))
-
-	query := `
-	SELECT "id", "orgId", "grpId", "en", "th", "active", "createdAt", "updatedAt", "createdBy", "updatedBy"
-	FROM public.um_groups
-	WHERE "orgId"=$1
-	LIMIT $2 OFFSET $3
-	`
-	logger.Debug(`Query`, zap.String("query", query))
-
-	rows, err := conn.Query(ctx, query, orgId, length, start)
+	list, err := repo.Profiles.ListGroups(ctx, tokenString(c, "orgId"), length, start)
 	if err != nil {
 		logger.Warn("Query failed", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, model.Response{
@@ -1781,45 +1016,9 @@ func GetUmGroupList(c *gin.Context) {
 		})
 		return
 	}
-	defer rows.Close()
-
-	var group model.UmGroup
-	var groupList []model.UmGroup
-	var errorMsg string
-
-	for rows.Next() {
-		err := rows.Scan(
-			&group.ID,
-			&group.OrgID,
-			&group.GrpID,
-			&group.En,
-			&group.Th,
-			&group.Active,
-			&group.CreatedAt,
-			&group.UpdatedAt,
-			&group.CreatedBy,
-			&group.UpdatedBy,
-		)
-		if err != nil {
-			logger.Warn("Scan failed", zap.Error(err))
-			errorMsg = err.Error()
-			break
-		}
-		groupList = append(groupList, group)
-	}
-
-	if errorMsg != "" {
-		c.JSON(http.StatusInternalServerError, model.Response{
-			Status: "-1",
-			Msg:    "Failed",
-			Desc:   errorMsg,
-		})
-	} else {
-		c.JSON(http.StatusOK, model.Response{
-			Status: "0",
-			Msg:    "Success",
-			Data:   groupList,
-			Desc:   "",
-		})
-	}
+	c.JSON(http.StatusOK, model.Response{
+		Status: "0",
+		Msg:    "Success",
+		Data:   list,
+	})
 }
@@ -4,68 +4,102 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
-	"fmt"
 	"log"
-	"mainPackage/config"
 	"mainPackage/model"
+	"mainPackage/store"
 	"net/http"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
-	"github.com/jackc/pgx/v5"
 )
 
 // --- WebSocket Connection Management ---
 
-var (
-	// เก็บ connection ที่ online อยู่ ณ ตอนนี้ key = empId
-	userConnections = make(map[string]*model.UserConnectionInfo)
-	connMutex       = &sync.Mutex{}
-)
-
 // อนุญาตทุก origin (โปรดปรับสำหรับ production)
 var upgrader = websocket.Upgrader{
-	CheckOrigin: func(r *http.Request) bool { return true },
+	CheckOrigin:  func(r *http.Request) bool { return true },
+	Subprotocols: []string{wsSubprotocol},
 }
 
-// ---------- Helpers: พื้นที่/จังหวัด ----------
+const (
+	// wsSubprotocol is offered by browser clients as the first entry of
+	// Sec-WebSocket-Protocol, followed by the access token.
+	wsSubprotocol = "bearer"
+	// wsAuthTimeout bounds how long a socket may stay open before its first
+	// frame authenticates it.
+	wsAuthTimeout = 10 * time.Second
+	// wsCloseUnauthorized and wsCloseTokenExpired are the close codes sent
+	// when a socket fails to authenticate or its token runs out.
+	wsCloseUnauthorized = 4401
+	wsCloseTokenExpired = 4001
+)
 
-func checkUserInProvince(userDistIdLists []string, provId string) bool {
-	if len(userDistIdLists) == 0 {
-		return false
+// accessClaims is the identity carried by a verified access token.
+type accessClaims struct {
+	OrgID     string
+	Username  string
+	ExpiresAt time.Time
+}
+
+// parseAccessToken verifies an access token and extracts its identity.
+func parseAccessToken(tokenString string) (*accessClaims, error) {
+	if tokenString == "" {
+		return nil, errors.New("missing access token")
+	}
+	parsedToken, err := verifyToken(tokenString)
+	if err != nil {
+		return nil, err
+	}
+	claims, _ := parsedToken.Claims.(jwt.MapClaims)
+	username, _ := claims["username"].(string)
+	orgId, _ := claims["orgId"].(string)
+	exp, err := claims.GetExpirationTime()
+	if username == "" || orgId == "" || err != nil || exp == nil {
+		return nil, errors.New("invalid token claims")
 	}
+	return &accessClaims{OrgID: orgId, Username: username, ExpiresAt: exp.Time}, nil
+}
 
-	dbConn, ctx, cancel := config.ConnectDB()
-	if dbConn == nil {
-		return false
+// wsHandshakeToken returns the access token offered with the upgrade
+// request as the subprotocol pair "bearer, <token>". A ?token= query
+// parameter is not read: the access log records query strings, so a token
+// there would outlive the socket.
+func wsHandshakeToken(r *http.Request) string {
+	protocols := websocket.Subprotocols(r)
+	if len(protocols) == 2 && protocols[0] == wsSubprotocol {
+		return protocols[1]
 	}
-	defer cancel()
-	defer dbConn.Close(ctx)
+	return ""
+}
 
-	placeholders := make([]string, len(userDistIdLists))
-	args := make([]interface{}, len(userDistIdLists)+1)
-	args[0] = provId
+// closeWS sends a close frame with code and drops a connection that was
+// never registered with the hub; registered ones close through wsClient.
+func closeWS(wsConn *websocket.Conn, code int, reason string) {
+	msg := websocket.FormatCloseMessage(code, reason)
+	_ = wsConn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(time.Second))
+	_ = wsConn.Close()
+}
 
-	for i, distId := range userDistIdLists {
-		placeholders[i] = fmt.Sprintf("$%d", i+2)
-		args[i+1] = distId
+// ---------- Helpers: พื้นที่/จังหวัด ----------
+
+func checkUserInProvince(orgId string, userDistIdLists []string, provId string) bool {
+	if len(userDistIdLists) == 0 {
+		return false
 	}
 
-	var count int
-	query := fmt.Sprintf(`
-		SELECT COUNT(*)
-		FROM area_districts 
-		WHERE "provId" = $1 AND "distId" IN (%s)
-	`, strings.Join(placeholders, ","))
+	repo, ctx, cancel := getStore(context.Background())
+	defer cancel()
 
-	if err := dbConn.QueryRow(ctx, query, args...).Scan(&count); err != nil {
+	found, err := repo.Areas.InProvince(ctx, orgId, provId, userDistIdLists)
+	if err != nil {
 		log.Printf("ERROR: Failed to check user in province %s: %v", provId, err)
 		return false
 	}
-	return count > 0
+	return found
 }
 
 func checkUserInDistrict(userDistIdLists []string, distId string) bool {
@@ -88,254 +122,275 @@ func contains(ss []string, v string) bool {
 
 // ---------- Query User Profile ----------
 
-func getUserProfileFromDB(ctx context.Context, dbConn *pgx.Conn, orgId, username string) (*model.UserConnectionInfo, error) {
+func getUserProfileFromDB(ctx context.Context, repo *store.Store, orgId, username string) (*model.UserConnectionInfo, error) {
 	log.Printf("Database: Querying for user '%s' in organization '%s'", username, orgId)
 
-	var userProfile model.UserConnectionInfo
-	var roleID string
-	var distIdListsJSON []byte
-	var GrpID []string
-
-	// 1) ลองอ่านจาก user_connections ก่อน (เก็บ grpId เป็น array อยู่แล้ว)
-	connectionQuery := `
-        SELECT "empId", "username", "orgId", "deptId", "commId", "stnId", "roleId", "grpId", "distIdLists"
-        FROM user_connections
-        WHERE "orgId" = $1 AND "username" = $2
-        LIMIT 1;
-    `
-	err := dbConn.QueryRow(ctx, connectionQuery, orgId, username).Scan(
-		&userProfile.ID, &userProfile.Username, &userProfile.OrgID,
-		&userProfile.DeptID, &userProfile.CommID, &userProfile.StnID,
-		&roleID, &GrpID, &userProfile.DistIdLists, // scan array -> []string
-	)
-	if err == nil {
-		userProfile.RoleID = roleID
-		userProfile.GrpID = GrpID
-		log.Printf("Database: Found existing connection for '%s'", username)
-		return &userProfile, nil
-	}
-	if !errors.Is(err, pgx.ErrNoRows) {
-		log.Printf("ERROR: Failed to query user connections for '%s': %v", username, err)
-		return nil, err
-	}
-
-	// 2) ไม่เจอใน user_connections -> ไปอ่านจาก um_users + um_user_with_groups
-	//    รวมหลายแถวของ grpId ให้เป็น array ด้วย array_agg(DISTINCT ...)
-	query := `
-        SELECT 
-          COALESCE(u."empId"::text, '')  AS "empId",
-          u."username",
-          COALESCE(u."orgId"::text, '')  AS "orgId",
-          COALESCE(u."deptId"::text, '') AS "deptId",
-          COALESCE(u."commId"::text, '') AS "commId",
-          COALESCE(u."stnId"::text, '')  AS "stnId",
-          COALESCE(u."roleId"::text, '') AS "roleId",
-          COALESCE(array_agg(DISTINCT ug."grpId"::text) FILTER (WHERE ug."grpId" IS NOT NULL), '{}') AS "grpIds",
-          COALESCE(uar."distIdLists", '[]'::jsonb) AS "distIdLists"
-        FROM um_users u
-        LEFT JOIN um_user_with_groups ug 
-               ON u."username" = ug."username"
-        LEFT JOIN um_user_with_area_response uar 
-               ON u."username" = uar."username"
-        WHERE u."orgId"::text = $1 
-          AND u."username" = $2 
-          AND u."active" = true
-        GROUP BY u."empId", u."username", u."orgId", u."deptId", u."commId", u."stnId", u."roleId", uar."distIdLists"
-        LIMIT 1;
-    `
-	err = dbConn.QueryRow(ctx, query, orgId, username).Scan(
-		&userProfile.ID, &userProfile.Username, &userProfile.OrgID,
-		&userProfile.DeptID, &userProfile.CommID, &userProfile.StnID,
-		&roleID, &GrpID, &distIdListsJSON,
-	)
+	userProfile, err := repo.Sessions.GetProfile(ctx, orgId, username)
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
+		if errors.Is(err, store.ErrNotFound) {
 			return nil, errors.New("user not found or is not active")
 		}
 		log.Printf("ERROR: Failed to query user profile for '%s': %v", username, err)
 		return nil, err
 	}
-
-	userProfile.RoleID = roleID
-	userProfile.GrpID = GrpID
-
-	// distIdLists จากตาราง uar เป็น jsonb -> unmarshal เป็น []string
-	if len(distIdListsJSON) > 0 {
-		if err := json.Unmarshal(distIdListsJSON, &userProfile.DistIdLists); err != nil {
-			log.Printf("WARNING: Failed to parse distIdLists for user '%s': %v", username, err)
-			userProfile.DistIdLists = []string{}
-		}
-	}
-
-	return &userProfile, nil
+	return userProfile, nil
 }
 
 // ---------- Upsert Connection ----------
 
-func upsertUserConnectionToDB(userInfo *model.UserConnectionInfo) error {
-	dbConn, ctx, cancel := config.ConnectDB()
-	if dbConn == nil {
-		log.Println("ERROR: Failed to get DB connection for upsert.")
-		return errors.New("could not connect to database")
-	}
+// addUserSessionToDB records a session and upserts the user's presence row.
+func addUserSessionToDB(userInfo *model.UserConnectionInfo) error {
+	repo, ctx, cancel := getStore(context.Background())
 	defer cancel()
-	defer dbConn.Close(ctx)
-
-	// หมายเหตุ: คอลัมน์ "grpId" และ "distIdLists" ใน user_connections ควรเป็น text[]/varchar[]
-	query := `
-    INSERT INTO user_connections ("empId", "username", "orgId", "deptId", "commId", "stnId", "roleId", "grpId", "distIdLists", "connectedAt")
-    VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''), NULLIF($6, ''), NULLIF($7, ''), $8, $9, $10)
-    ON CONFLICT ("empId") DO UPDATE SET
-        "username"    = EXCLUDED."username",
-        "orgId"       = EXCLUDED."orgId",
-        "deptId"      = EXCLUDED."deptId",
-        "commId"      = EXCLUDED."commId",
-        "stnId"       = EXCLUDED."stnId",
-        "roleId"      = EXCLUDED."roleId",
-        "grpId"       = EXCLUDED."grpId",
-        "distIdLists" = EXCLUDED."distIdLists",
-        "connectedAt" = EXCLUDED."connectedAt";
-    `
-
-	// ส่ง []string ตรง ๆ (pgx v5 encode เป็น array ให้ ถ้าคอลัมน์เป็น text[]/varchar[])
-	var grpIDParam any
-	if len(userInfo.GrpID) == 0 {
-		grpIDParam = nil
-	} else {
-		grpIDParam = userInfo.GrpID
-	}
-
-	var distListsParam any
-	if len(userInfo.DistIdLists) == 0 {
-		distListsParam = nil
-	} else {
-		distListsParam = userInfo.DistIdLists
-	}
 
-	_, err := dbConn.Exec(ctx, query,
-		userInfo.ID, userInfo.Username, userInfo.OrgID,
-		userInfo.DeptID, userInfo.CommID, userInfo.StnID,
-		userInfo.RoleID, grpIDParam, distListsParam, time.Now(),
-	)
+	err := repo.Sessions.Add(ctx, instanceID, *userInfo)
 	if err != nil {
-		log.Printf("ERROR: Failed to upsert user connection to DB for EmpID %s: %v", userInfo.ID, err)
+		log.Printf("ERROR: Failed to add session %s to DB for EmpID %s: %v", userInfo.SessionID, userInfo.ID, err)
 	} else {
-		log.Printf("Database: Successfully upserted connection for EmpID %s", userInfo.ID)
+		log.Printf("Database: Successfully added session %s for EmpID %s", userInfo.SessionID, userInfo.ID)
 	}
 	return err
 }
 
 // ---------- Remove Connection ----------
 
-func removeUserConnectionFromDB(userID string) {
-	dbConn, ctx, cancel := config.ConnectDB()
-	if dbConn == nil {
-		log.Printf("ERROR: Failed to get DB connection to remove user %s", userID)
-		return
-	}
+// removeUserSessionFromDB drops a session, and the user's presence row once
+// no other session of theirs is left.
+func removeUserSessionFromDB(userInfo *model.UserConnectionInfo) {
+	repo, ctx, cancel := getStore(context.Background())
 	defer cancel()
-	defer dbConn.Close(ctx)
 
-	if _, err := dbConn.Exec(ctx, `DELETE FROM user_connections WHERE "empId" = $1`, userID); err != nil {
-		log.Printf("ERROR: Failed to remove user connection from DB for EmpID %s: %v", userID, err)
+	if err := repo.Sessions.Remove(ctx, userInfo.ID, userInfo.SessionID); err != nil {
+		log.Printf("ERROR: Failed to remove session %s from DB for EmpID %s: %v", userInfo.SessionID, userInfo.ID, err)
 	} else {
-		log.Printf("Database: Successfully removed connection for EmpID %s", userID)
+		log.Printf("Database: Successfully removed session %s for EmpID %s", userInfo.SessionID, userInfo.ID)
+	}
+}
+
+// ---------- Session Heartbeat ----------
+
+const (
+	// sessionHeartbeat is how often an instance refreshes "seenAt" on its
+	// sessions and sweeps the ones nobody refreshes.
+	sessionHeartbeat = 30 * time.Second
+	// sessionStale is how long a session may go unrefreshed before it is
+	// taken to belong to an instance that died.
+	sessionStale = 4 * sessionHeartbeat
+)
+
+// instanceID tells this process's sessions apart from those of other
+// instances, and of earlier runs of this one.
+var instanceID = uuid.NewString()
+
+// StartSessionHeartbeat sweeps sessions left by instances that stopped,
+// then keeps this instance's sessions fresh and sweeps again on every beat.
+func StartSessionHeartbeat() {
+	log.Printf("Starting session heartbeat for instance %s...", instanceID)
+	sweepStaleSessions()
+	ticker := time.NewTicker(sessionHeartbeat)
+	go func() {
+		for {
+			<-ticker.C
+			beatSessions()
+			sweepStaleSessions()
+		}
+	}()
+}
+
+// beatSessions refreshes "seenAt" on the sessions this instance holds.
+func beatSessions() {
+	repo, ctx, cancel := getStore(context.Background())
+	defer cancel()
+	if err := repo.Sessions.Beat(ctx, instanceID); err != nil {
+		log.Printf("ERROR: Session heartbeat failed: %v", err)
+	}
+}
+
+// sweepStaleSessions removes the sessions nobody has refreshed within
+// sessionStale, and the presence rows left without a session. Each employee
+// is swept on its own, like a normal disconnect.
+func sweepStaleSessions() {
+	repo, ctx, cancel := getStore(context.Background())
+	defer cancel()
+	empIds, err := repo.Sessions.ListStale(ctx, sessionStale)
+	if err != nil {
+		log.Printf("ERROR: Failed to find stale sessions: %v", err)
+		return
+	}
+	var swept int64
+	for _, empId := range empIds {
+		n, err := repo.Sessions.Sweep(ctx, empId, sessionStale)
+		if err != nil {
+			log.Printf("ERROR: Failed to sweep sessions of EmpID %s: %v", empId, err)
+		}
+		swept += n
+	}
+	if swept > 0 {
+		log.Printf("Database: Swept %d stale sessions", swept)
 	}
 }
 
 // ---------- WebSocket Handler ----------
 
 // @Summary WebSocket endpoint for real-time notifications
-// @Description Establishes a WebSocket connection. The client must send a JSON message with `orgId` and `username` to register the session.
+// @Description Establishes a WebSocket connection bound to the caller's access token. The token is taken from the subprotocol pair `bearer, <token>` or from a first frame `{"type":"auth","token":"..."}` sent within 10 seconds. The socket is closed with code 4001 when the token expires, unless the client sends a newer token for the same user as another `auth` frame.
 // @Tags Notifications
 // @security ApiKeyAuth
 // @Success 101 "Switching Protocols"
-// @Failure 400 "Bad Request (invalid registration message)"
-// @Failure 404 "Not Found (User not found)"
+// @Failure 401 "Unauthorized (invalid access token)"
 // @Failure 500 "Internal Server Error"
 // @Router /api/v1/notifications/register [get]
 func WebSocketHandler(c *gin.Context) {
-	wsConn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
-	if err != nil {
-		log.Println("WebSocket upgrade error:", err)
-		return
-	}
-	defer wsConn.Close()
-
-	// อ่าน registration message แรกจาก client
-	_, msg, err := wsConn.ReadMessage()
-	if err != nil {
-		log.Println("Failed to read registration message:", err)
+	if c.Query("token") != "" {
+		c.JSON(http.StatusBadRequest, model.Response{
+			Status: "-1",
+			Msg:    "Failed",
+			Desc:   "send the access token as the bearer subprotocol or in the first auth frame, not in the URL",
+		})
 		return
 	}
-
-	var regMsg model.RegistrationMessage
-	if err := json.Unmarshal(msg, &regMsg); err != nil {
-		log.Println("Invalid registration message format:", err)
-		_ = wsConn.WriteJSON(gin.H{"error": "invalid registration format"})
-		return
+	var claims *accessClaims
+	if token := wsHandshakeToken(c.Request); token != "" {
+		var err error
+		if claims, err = parseAccessToken(token); err != nil {
+			c.JSON(http.StatusUnauthorized, model.Response{
+				Status: "-1",
+				Msg:    "Failed",
+				Desc:   err.Error(),
+			})
+			return
+		}
 	}
 
-	// (แนะนำ) validate ค่าว่างเบื้องต้น
-	if strings.TrimSpace(regMsg.OrgID) == "" || strings.TrimSpace(regMsg.Username) == "" {
-		_ = wsConn.WriteJSON(gin.H{"error": "orgId and username are required"})
+	wsConn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		log.Println("WebSocket upgrade error:", err)
 		return
 	}
+	defer wsConn.Close()
 
-	dbConn, ctx, cancel := config.ConnectDB()
-	if dbConn == nil {
-		_ = wsConn.WriteJSON(gin.H{"error": "could not connect to the database"})
-		return
+	if claims == nil {
+		// อ่าน auth frame แรกจาก client
+		_ = wsConn.SetReadDeadline(time.Now().Add(wsAuthTimeout))
+		_, msg, err := wsConn.ReadMessage()
+		if err != nil {
+			log.Println("Failed to read registration message:", err)
+			return
+		}
+		var regMsg model.RegistrationMessage
+		if err := json.Unmarshal(msg, &regMsg); err != nil {
+			log.Println("Invalid registration message format:", err)
+			closeWS(wsConn, wsCloseUnauthorized, "invalid registration format")
+			return
+		}
+		if claims, err = parseAccessToken(regMsg.Token); err != nil {
+			closeWS(wsConn, wsCloseUnauthorized, err.Error())
+			return
+		}
+		// orgId/username จาก client เก่าต้องตรงกับ token
+		if (regMsg.OrgID != "" && regMsg.OrgID != claims.OrgID) ||
+			(regMsg.Username != "" && regMsg.Username != claims.Username) {
+			closeWS(wsConn, wsCloseUnauthorized, "registration does not match token")
+			return
+		}
+		_ = wsConn.SetReadDeadline(time.Time{})
 	}
 
-	connInfo, err := getUserProfileFromDB(ctx, dbConn, regMsg.OrgID, regMsg.Username)
-
-	dbConn.Close(ctx)
+	repo, ctx, cancel := getStore(c.Request.Context())
+	connInfo, err := getUserProfileFromDB(ctx, repo, claims.OrgID, claims.Username)
 	cancel()
 
 	if err != nil {
-		log.Printf("User registration failed for '%s': %v", regMsg.Username, err)
-		_ = wsConn.WriteJSON(gin.H{"error": "user not found or invalid credentials"})
+		log.Printf("User registration failed for '%s': %v", claims.Username, err)
+		closeWS(wsConn, wsCloseUnauthorized, "user not found or inactive")
 		return
 	}
-	connInfo.Conn = wsConn
+	connInfo.SessionID = uuid.NewString()
+	connInfo.UserAgent = c.Request.UserAgent()
+	connInfo.ClientIP = c.ClientIP()
+	connInfo.ConnectedAt = time.Now()
 
-	connMutex.Lock()
-	userConnections[connInfo.ID] = connInfo
-	connMutex.Unlock()
+	cl := hub.register(connInfo, wsConn)
+	go addUserSessionToDB(connInfo)
 
-	go upsertUserConnectionToDB(connInfo)
+	expiry := time.AfterFunc(time.Until(claims.ExpiresAt), func() {
+		log.Printf("Token expired: EmpID=%s", connInfo.ID)
+		cl.close(wsCloseTokenExpired, "token expired")
+	})
 
 	defer func() {
-		connMutex.Lock()
-		delete(userConnections, connInfo.ID)
-		connMutex.Unlock()
+		expiry.Stop()
+		hub.unregister(cl)
 
-		go removeUserConnectionFromDB(connInfo.ID)
-		log.Printf("❌ Disconnected: EmpID=%s", connInfo.ID)
+		go removeUserSessionFromDB(connInfo)
+		log.Printf("❌ Disconnected: EmpID=%s SessionID=%s", connInfo.ID, connInfo.SessionID)
 	}()
 
-	// keep-alive: รอจน connection ปิด
+	hub.enqueue(cl, gin.H{"type": "auth", "status": "ok", "sessionId": connInfo.SessionID, "expiresAt": claims.ExpiresAt})
+	go pushUnreadCount(connInfo.OrgID, connInfo.Username)
+
+	// pong หรือ frame ใด ๆ จาก client ต่ออายุ read deadline
+	wsConn.SetReadLimit(wsMaxMessage)
+	_ = wsConn.SetReadDeadline(time.Now().Add(wsPongWait))
+	wsConn.SetPongHandler(func(string) error {
+		return wsConn.SetReadDeadline(time.Now().Add(wsPongWait))
+	})
+
+	// รอจน connection ปิด; frame "auth" ใช้ต่ออายุ token
 	for {
-		if _, _, err := wsConn.ReadMessage(); err != nil {
+		_, msg, err := wsConn.ReadMessage()
+		if err != nil {
 			break
 		}
+		_ = wsConn.SetReadDeadline(time.Now().Add(wsPongWait))
+		var in model.RegistrationMessage
+		if json.Unmarshal(msg, &in) != nil || in.Type != "auth" {
+			continue
+		}
+		next, err := parseAccessToken(in.Token)
+		if err == nil && (next.OrgID != claims.OrgID || next.Username != claims.Username) {
+			err = errors.New("token belongs to another user")
+		}
+		if err != nil {
+			hub.enqueue(cl, gin.H{"type": "auth", "status": "error", "error": err.Error()})
+			continue
+		}
+		claims = next
+		expiry.Reset(time.Until(claims.ExpiresAt))
+		hub.enqueue(cl, gin.H{"type": "auth", "status": "ok", "expiresAt": claims.ExpiresAt})
 	}
 }
 
 // ---------- Broadcast ----------
 
+// BroadcastNotification queues noti for every matching session of this
+// instance. Matching runs outside the hub lock and queuing never blocks, so a
+// slow client only ever delays itself.
 func BroadcastNotification(noti model.Notification) {
-	connMutex.Lock()
-	defer connMutex.Unlock()
-
-	log.Printf("📢 Broadcasting notification ID: %s", noti.ID)
-	sentTo := make(map[string]bool)
+	log.Printf("📢 Broadcasting notification ID: %d", noti.ID)
+
+	// ผลการเช็คจังหวัดต่อ broadcast เพื่อไม่ query ซ้ำสำหรับพื้นที่เดียวกัน
+	provinces := make(map[string]bool)
+	inProvince := func(distIds []string, provId string) bool {
+		key := provId + "|" + strings.Join(distIds, ",")
+		ok, seen := provinces[key]
+		if !seen {
+			ok = checkUserInProvince(noti.OrgID, distIds, provId)
+			provinces[key] = ok
+		}
+		return ok
+	}
 
-	for _, connInfo := range userConnections {
-		if sentTo[connInfo.ID] {
-			continue
+	// ผู้รับที่ส่งถึงแล้ว ใช้บันทึกสถานะ delivered
+	delivered := make(map[string]bool)
+	send := func(cl *wsClient) {
+		if hub.enqueue(cl, noti) {
+			delivered[cl.info.Username] = true
 		}
+	}
+
+	for _, cl := range hub.snapshot() {
+		connInfo := cl.info
 		if connInfo.OrgID != noti.OrgID {
 			continue
 		}
@@ -343,11 +398,8 @@ func BroadcastNotification(noti model.Notification) {
 		isTrueBroadcast := noti.Recipients == nil || len(noti.Recipients) == 0
 
 		if isTrueBroadcast {
-			log.Printf("  🚀 True broadcast! Sending to EmpID: %s (OrgID: %s)", connInfo.ID, connInfo.OrgID)
-			if err := connInfo.Conn.WriteJSON(noti); err != nil {
-				log.Printf("    ❌ Failed to send to EmpID %s: %v", connInfo.ID, err)
-			}
-			sentTo[connInfo.ID] = true
+			log.Printf("  🚀 True broadcast! Sending to EmpID: %s SessionID: %s (OrgID: %s)", connInfo.ID, connInfo.SessionID, connInfo.OrgID)
+			send(cl)
 			continue
 		}
 
@@ -378,7 +430,7 @@ func BroadcastNotification(noti model.Notification) {
 					// เช็คสมาชิกใน array
 					shouldReceive = contains(connInfo.GrpID, value)
 				case "provid":
-					shouldReceive = checkUserInProvince(connInfo.DistIdLists, value)
+					shouldReceive = inProvince(connInfo.DistIdLists, value)
 				case "distid":
 					shouldReceive = checkUserInDistrict(connInfo.DistIdLists, value)
 				}
@@ -389,14 +441,32 @@ func BroadcastNotification(noti model.Notification) {
 			}
 
 			if shouldReceive {
-				log.Printf("  🚀 Match found! Sending to EmpID: %s (Rule: %s:%s)", connInfo.ID, recipient.Type, recipient.Value)
-				if err := connInfo.Conn.WriteJSON(noti); err != nil {
-					log.Printf("    ❌ Failed to send to EmpID %s: %v", connInfo.ID, err)
-				}
-				sentTo[connInfo.ID] = true
+				log.Printf("  🚀 Match found! Sending to EmpID: %s SessionID: %s (Rule: %s:%s)", connInfo.ID, connInfo.SessionID, recipient.Type, recipient.Value)
+				send(cl)
 				break
 			}
 		}
 	}
-	log.Printf("✅ Broadcasting finished for notification ID: %s", noti.ID)
+	if len(delivered) > 0 {
+		markDelivered(noti, delivered)
+	}
+	log.Printf("✅ Broadcasting finished for notification ID: %d", noti.ID)
+}
+
+// markDelivered records that noti reached the sockets of usernames and
+// refreshes their unread counts.
+func markDelivered(noti model.Notification, usernames map[string]bool) {
+	repo, ctx, cancel := getStore(context.Background())
+	defer cancel()
+	list := make([]string, 0, len(usernames))
+	for u := range usernames {
+		list = append(list, u)
+	}
+	if err := repo.Notifications.MarkDelivered(ctx, noti.OrgID, int64(noti.ID), list, time.Now()); err != nil {
+		log.Printf("ERROR: Failed to mark notification %d delivered: %v", noti.ID, err)
+	}
+	// every instance broadcasts, so each refreshes the counts of its own sessions
+	for _, u := range list {
+		pushUnreadCount(noti.OrgID, u)
+	}
 }
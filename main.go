@@ -17,9 +17,13 @@
 package main
 
 import (
+	"context"
+	"mainPackage/broker"
 	"mainPackage/config"
 	_ "mainPackage/docs"
 	"mainPackage/handler"
+	"mainPackage/store"
+	"os"
 	"time"
 
 	"github.com/gin-contrib/cors"
@@ -42,13 +46,46 @@ func init() {
 	}
 }
 func main() {
+	logger := config.GetLog()
+	if len(os.Args) > 1 {
+		if ok, err := runOfflineCommand(os.Args[1:]); ok {
+			if err != nil {
+				logger.Fatal("Command failed", zap.Error(err))
+			}
+			return
+		}
+	}
+	dbConfig := config.GetDBConfig()
+	pool, err := config.NewPool(context.Background(), dbConfig)
+	if err != nil {
+		logger.Fatal("Unable to connect to database", zap.Error(err))
+	}
+	defer pool.Close()
+	handler.SetPool(pool, dbConfig.QueryTimeout)
+	handler.SetStore(store.NewPostgres(pool))
+
+	if len(os.Args) > 1 {
+		if err := runCommand(context.Background(), pool, os.Args[1:]); err != nil {
+			logger.Fatal("Command failed", zap.Error(err))
+		}
+		return
+	}
+
 	rate := limiter.Rate{
 		Period: 1 * time.Minute,
 		Limit:  50,
 	}
+	handler.SetBroker(broker.NewPostgres(pool, logger))
+	go handler.ListenNotifications(context.Background())
+	go handler.StartOutboxRelay(context.Background())
 	go handler.StartAutoDeleteScheduler()
-	store := memory.NewStore()
-	instance := limiter.New(store, rate)
+	go handler.StartEscalationWorker()
+	go handler.StartAuditWriter(context.Background())
+	go handler.StartAuditCheckpoints()
+	handler.StartSessionHeartbeat()
+	handler.StartPoolStatsLogger(5 * time.Minute)
+	limiterStore := memory.NewStore()
+	instance := limiter.New(limiterStore, rate)
 	gin.SetMode(gin.ReleaseMode)
 	router := gin.Default()
 	// router.Use(cors.Default())
@@ -65,201 +102,269 @@ func main() {
 	}))
 	router.Use(gin.Recovery())
 	router.Use(ginlimiter.NewMiddleware(instance))
+	router.Use(handler.AuditTrail())
 	auth := router.Group("/api/v1/auth")
 	{
 		auth.GET("/login", handler.UserLogin)
 		auth.POST("/login", handler.UserLoginPost)
-		auth.POST("/add", handler.UserAddAuth)
+		auth.POST("/add", handler.ProtectedHandler, handler.RequirePermission(handler.PermUserManage), handler.UserAddAuth)
 		auth.POST("/refresh", handler.RefreshToken)
+		auth.POST("/logout", handler.ProtectedHandler, handler.Logout)
 	}
 	v1 := router.Group("/api/v1")
 	{
 		v1.Use(handler.ProtectedHandler)
-		v1.GET("/area/country_province_districts", handler.GetCountryProvinceDistricts)
-
-		v1.GET("/forms", handler.GetForm)
-		v1.GET("/forms/getAllForms", handler.GetAllForm)
-		v1.POST("/forms", handler.FormInsert)
-		v1.PATCH("/forms/:uuid", handler.FormUpdate)
-		v1.PATCH("/forms/active", handler.FormActive)
-		v1.PATCH("/forms/lock", handler.FormLock)
-		v1.PATCH("/forms/publish", handler.FormPublish)
-		v1.POST("/forms/casesubtype", handler.GetFormByCaseSubType)
-		v1.GET("/workflows", handler.GetWorkFlowList)
-		v1.GET("/workflows/:id", handler.GetWorkFlow)
-		v1.POST("/workflows", handler.WorkFlowInsert)
-		v1.PATCH("/workflows/:uuid", handler.WorkFlowUpdate)
-		v1.DELETE("/workflows/:uuid", handler.WorkflowDelete)
-
-		v1.GET("/case", handler.ListCase)
-		v1.GET("/case/:id", handler.CaseById)
-		v1.POST("/case/add", handler.InsertCase)
-		v1.PATCH("/case/:id", handler.UpdateCase)
-		v1.DELETE("/case/:id", handler.DeleteCase)
-
-		v1.GET("/case_status", handler.GetCaseStatus)
-		v1.GET("/case_status/:id", handler.GetCaseStatusById)
-		v1.POST("/case_status/add", handler.InsertCaseStatus)
-		v1.PATCH("/case_status/:id", handler.UpdateCaseStatus)
-		v1.DELETE("/case_status/:id", handler.DeleteCaseStatus)
-
-		v1.GET("/casetypes", handler.ListCaseType)
-		v1.POST("/casetypes/add", handler.InsertCaseType)
-		v1.PATCH("/casetypes/:id", handler.UpdateCaseType)
-		v1.DELETE("/casetypes/:id", handler.DeleteCaseType)
-		v1.GET("/casetypes_with_subtype", handler.ListCaseTypeWithSubtype)
-		v1.GET("/casesubtypes", handler.ListCaseSubType)
-		v1.POST("/casesubtypes/add", handler.InsertCaseSubType)
-		v1.PATCH("/casesubtypes/:id", handler.UpdateCaseSubType)
-		v1.DELETE("/casesubtypes/:id", handler.DeleteCaseSubType)
-
-		v1.GET("/departments", handler.GetDepartment)
-		v1.GET("/departments/:id", handler.GetDepartmentbyId)
-		v1.POST("/departments/add", handler.InsertDepartment)
-		v1.PATCH("/departments/:id", handler.UpdateDepartment)
-		v1.DELETE("/departments/:id", handler.DeleteDepartment)
-
-		v1.GET("/commands", handler.GetCommand)
-		v1.GET("/commands/:id", handler.GetCommandById)
-		v1.POST("/commands/add", handler.InsertCommand)
-		v1.PATCH("/commands/:id", handler.UpdateCommand)
-		v1.DELETE("/commands/:id", handler.DeleteCommand)
-
-		v1.GET("/department_command_stations", handler.GetDepartmentCommandStation)
-		v1.GET("/stations", handler.GetStation)
-		v1.GET("/stations/:id", handler.GetStationbyId)
-		v1.POST("/stations/add", handler.InsertStations)
-		v1.PATCH("/stations/:id", handler.UpdateStations)
-		v1.DELETE("/stations/:id", handler.DeleteStations)
-
-		v1.GET("/role", handler.GetRole)
-		v1.GET("/role/:id", handler.GetRolebyId)
-		v1.POST("/role/add", handler.InsertRole)
-		v1.PATCH("/role/:id", handler.UpdateRole)
-		v1.DELETE("/role/:id", handler.DeleteRole)
-
-		v1.GET("/permission", handler.GetPermission)
-		v1.GET("/permission/:permId", handler.GetPermissionById)
-		v1.POST("/permission/add", handler.InsertPermission)
-		v1.PATCH("/permission/:permId", handler.UpdatePermission)
-		v1.DELETE("/permission/:permId", handler.DeletePermission)
-
-		v1.GET("/role_permission", handler.GetRolePermission)
-		v1.GET("/role_permission/:id", handler.GetRolePermissionbyId)
-		v1.GET("/role_permission/roleId/:roleId", handler.GetRolePermissionbyroleId)
-		v1.POST("/role_permission/add", handler.InsertRolePermission)
-		v1.PATCH("/role_permission/:roleId", handler.UpdateRolePermission)
-		v1.PATCH("/role_permission/multi", handler.UpdateMultiRolePermission)
-		v1.DELETE("/role_permission/:id", handler.DeleteRolePermission)
-
-		v1.GET("/customer", handler.CustomerList)
-		v1.POST("/customer/add", handler.CustomerAdd)
-		v1.GET("/customer/:id", handler.CustomerById)
-		v1.PATCH("/customer/:id", handler.CustomerUpdate)
-		v1.DELETE("/customer/:id", handler.CustomerDelete)
-
-		v1.GET("/customer_contacts", handler.CustomerContactList)
-		v1.POST("/customer_contacts/add", handler.CustomerContactAdd)
-		v1.GET("/customer_contacts/:id", handler.CustomerContactById)
-		v1.PATCH("/customer_contacts/:id", handler.CustomerContactUpdate)
-		v1.DELETE("/customer_contacts/:id", handler.CustomerContactDelete)
-
-		v1.GET("/customer_with_socials", handler.CustomerSocialList)
-		v1.POST("/customer_with_socials/add", handler.CustomerSocialAdd)
-		v1.GET("/customer_with_socials/:id", handler.CustomerWithSocialById)
-		v1.PATCH("/customer_with_socials/:id", handler.CustomerSocialUpdate)
-		v1.DELETE("/customer_with_socials/:id", handler.CustomerSocialDelete)
-
-		v1.GET("/users", handler.GetUmUserList)
-		v1.GET("/users/:id", handler.GetUmUserById)
-		v1.POST("/users/add", handler.UserAdd)
-		v1.PATCH("/users/:id", handler.UserUpdate)
-		v1.DELETE("/users/:id", handler.UserDelete)
-		v1.GET("/users/username/:username", handler.GetUmUserByUsername)
-		v1.PATCH("/users/username/:username", handler.UserUpdateByUsername)
-		v1.GET("/users_with_skills", handler.GetUserWithSkills)
-		v1.GET("/users_with_skills/:id", handler.GetUserWithSkillsById)
-		v1.GET("/users_with_skills/skillId/:skillId", handler.GetUserWithSkillsBySkillId)
-		v1.POST("/users_with_skills/add", handler.InsertUserWithSkills)
-		v1.PATCH("/users_with_skills/:id", handler.UpdateUserWithSkills)
-		v1.DELETE("/users_with_skills/:id", handler.DeleteUserWithSkills)
-		v1.GET("/users_with_contacts", handler.GetUserWithContacts)
-		v1.GET("/users_with_contacts/:id", handler.GetUserWithContactsById)
-		v1.POST("/users_with_contacts/add", handler.InsertUserWithContacts)
-		v1.PATCH("/users_with_contacts/:id", handler.UpdateUserWithContacts)
-		v1.DELETE("/users_with_contacts/:id", handler.DeleteUserWithContacts)
-		v1.GET("/users_with_socials", handler.GetUserWithSocials)
-		v1.GET("/users_with_socials/:id", handler.GetUserWithSocialsById)
-		v1.POST("/users_with_socials/add", handler.InsertUserWithSocials)
-		v1.PATCH("/users_with_socials/:id", handler.UpdateUserWithSocials)
-		v1.DELETE("/users_with_socials/:id", handler.DeleteUserWithSocials)
-		v1.GET("/user_groups/all", handler.GetUmGroupList)
-
-		v1.GET("/mdm/properties", handler.GetMmdProperty)
-		v1.GET("/mdm/properties/:id", handler.GetMmdPropertyById)
-		v1.POST("/mdm/properties/add", handler.InsertMmdProperty)
-		v1.PATCH("/mdm/properties/:id", handler.UpdateMmdProperty)
-		v1.DELETE("/mdm/properties/:id", handler.DeleteMmdProperty)
-
-		v1.GET("/mdm/sources", handler.GetMmdUnitSources)
-		v1.GET("/mdm/sources/:id", handler.GetMmdUnitSourcesById)
-		v1.POST("/mdm/sources/add", handler.InsertMmdUnitSources)
-		v1.PATCH("/mdm/sources/:id", handler.UpdateMmdUnitSources)
-		v1.DELETE("/mdm/sources/:id", handler.DeleteMmdUnitSources)
-
-		v1.GET("/mdm/types", handler.GetMmdUnitType)
-		v1.GET("/mdm/types/:id", handler.GetMmdUnitTypeById)
-		v1.POST("/mdm/types/add", handler.InsertMmdUnitType)
-		v1.PATCH("/mdm/types/:id", handler.UpdateMmdUnitType)
-		v1.DELETE("/mdm/types/:id", handler.DeleteMmdUnitType)
-
-		v1.GET("/mdm/companies", handler.GetMmdCompanies)
-		v1.GET("/mdm/companies/:id", handler.GetMmdCompaniesById)
-		v1.POST("/mdm/companies/add", handler.InsertMmdCompanies)
-		v1.PATCH("/mdm/companies/:id", handler.UpdateMmdCompanies)
-		v1.DELETE("/mdm/companies/:id", handler.DeleteMmdCompanies)
-
-		v1.GET("/mdm/status", handler.GetMmdUnitStatus)
-		v1.GET("/mdm/status/:id", handler.GetMmdUnitStatusById)
-		v1.POST("/mdm/status/add", handler.InsertMmdUnitStatus)
-		v1.PATCH("/mdm/status/:id", handler.UpdateMmdUnitStatus)
-		v1.DELETE("/mdm/status/:id", handler.DeleteMmdUnitStatus)
-
-		v1.GET("/mdm/units", handler.GetMmdUnit)
-		v1.GET("/mdm/units/:id", handler.GetMmdUnitById)
-		v1.POST("/mdm/units/add", handler.InsertMmdUnit)
-		v1.PATCH("/mdm/units/:id", handler.UpdateMmdUnit)
-		v1.DELETE("/mdm/units/:id", handler.DeleteMmdUnit)
-
-		v1.GET("/mdm/units/properties/unitId", handler.GetMmdUnitWithProperty)
-
-		v1.GET("/dispatch/:caseId/SOP", handler.GetSOP)
-		v1.GET("/dispatch/:caseId/units", handler.GetUnit)
-
-		v1.GET("/audit_log", handler.GetAuditlog)
-		v1.GET("/audit_log/:username", handler.GetAuditlogByUsername)
-
-		v1.GET("/case_history", handler.GetCaseHistory)
-		v1.GET("/case_history/:caseId", handler.GetCaseHistoryByCaseId)
-		v1.POST("/case_history/add", handler.InsertCaseHistory)
-		v1.PATCH("/case_history/:id", handler.UpdateCaseHistory)
-		v1.DELETE("/case_history/:id", handler.DeleteCaseHistory)
-
-		v1.GET("/devices", handler.GetDeviceIoT)
-		v1.GET("/devices/:id", handler.GetDeviceIoTById)
+		// Every route below is declared with the permission it needs; see
+		// handler.PermissionCatalogue.
+		caseView := v1.Group("", handler.RequirePermission(handler.PermCaseView))
+		caseCreate := v1.Group("", handler.RequirePermission(handler.PermCaseCreate))
+		caseUpdate := v1.Group("", handler.RequirePermission(handler.PermCaseUpdate))
+		caseDelete := v1.Group("", handler.RequirePermission(handler.PermCaseDelete))
+		formView := v1.Group("", handler.RequirePermission(handler.PermFormView))
+		formManage := v1.Group("", handler.RequirePermission(handler.PermFormManage))
+		masterView := v1.Group("", handler.RequirePermission(handler.PermMasterView))
+		masterManage := v1.Group("", handler.RequirePermission(handler.PermMasterManage))
+		customerView := v1.Group("", handler.RequirePermission(handler.PermCustomerView))
+		customerManage := v1.Group("", handler.RequirePermission(handler.PermCustomerManage))
+		userView := v1.Group("", handler.RequirePermission(handler.PermUserView))
+		userManage := v1.Group("", handler.RequirePermission(handler.PermUserManage))
+		roleView := v1.Group("", handler.RequirePermission(handler.PermRoleView))
+		roleManage := v1.Group("", handler.RequirePermission(handler.PermRoleManage))
+		auditView := v1.Group("", handler.RequirePermission(handler.PermAuditView))
+		systemView := v1.Group("", handler.RequirePermission(handler.PermSystemView))
+
+		masterView.GET("/area/country_province_districts", handler.GetCountryProvinceDistricts)
+
+		formView.GET("/forms", handler.GetForm)
+		formView.GET("/forms/getAllForms", handler.GetAllForm)
+		formManage.POST("/forms", handler.FormInsert)
+		formManage.PATCH("/forms/:uuid", handler.FormUpdate)
+		formManage.PATCH("/forms/active", handler.FormActive)
+		formManage.PATCH("/forms/lock", handler.FormLock)
+		formManage.PATCH("/forms/publish", handler.FormPublish)
+		formView.GET("/forms/:uuid/versions", handler.GetFormVersions)
+		formView.GET("/forms/:uuid/diff", handler.FormDiff)
+		formView.POST("/forms/casesubtype", handler.GetFormByCaseSubType)
+		formView.GET("/workflows", handler.GetWorkFlowList)
+		formView.GET("/workflows/:id", handler.GetWorkFlow)
+		formManage.POST("/workflows", handler.WorkFlowInsert)
+		formManage.PATCH("/workflows/:uuid", handler.WorkFlowUpdate)
+		formManage.DELETE("/workflows/:uuid", handler.WorkflowDelete)
+		formManage.POST("/workflows/validate", handler.WorkflowValidate)
+		formManage.POST("/workflows/:uuid/publish", handler.WorkflowPublish)
+		formView.GET("/workflows/:id/versions", handler.GetWorkflowVersions)
+		formManage.POST("/workflows/:uuid/rollback/:versions", handler.WorkflowRollback)
+
+		caseView.GET("/case", handler.ListCase)
+		caseView.GET("/case/:id", handler.CaseById)
+		caseCreate.POST("/case/add", handler.InsertCase)
+		caseUpdate.PATCH("/case/:id", handler.UpdateCase)
+		caseDelete.DELETE("/case/:id", handler.DeleteCase)
+		caseUpdate.POST("/case/:id/advance", handler.AdvanceCase)
+		caseUpdate.POST("/case/:id/status", handler.ChangeCaseStatus)
+		caseView.GET("/case/:id/stages", handler.CaseStageHistory)
+		caseUpdate.POST("/case/:id/forms/:formId/submissions", handler.SubmitCaseForm)
+		caseView.GET("/case/:id/forms/:formId/submissions", handler.ListCaseFormSubmissions)
+		caseView.GET("/case/:id/submissions", handler.ListCaseSubmissions)
+
+		masterView.GET("/case_status", handler.GetCaseStatus)
+		masterView.GET("/case_status/:id", handler.GetCaseStatusById)
+		masterManage.POST("/case_status/add", handler.InsertCaseStatus)
+		masterManage.PATCH("/case_status/:id", handler.UpdateCaseStatus)
+		masterManage.DELETE("/case_status/:id", handler.DeleteCaseStatus)
+		masterView.GET("/case_status_transitions", handler.ListCaseStatusTransitions)
+		masterView.GET("/case_status_transitions/:id", handler.GetCaseStatusTransition)
+		masterManage.POST("/case_status_transitions/add", handler.InsertCaseStatusTransition)
+		masterManage.PATCH("/case_status_transitions/:id", handler.UpdateCaseStatusTransition)
+		masterManage.DELETE("/case_status_transitions/:id", handler.DeleteCaseStatusTransition)
+
+		masterView.GET("/notification_escalation", handler.ListEscalationPolicies)
+		masterView.GET("/notification_escalation/:id", handler.GetEscalationPolicy)
+		masterManage.POST("/notification_escalation/add", handler.InsertEscalationPolicy)
+		masterManage.PATCH("/notification_escalation/:id", handler.UpdateEscalationPolicy)
+		masterManage.DELETE("/notification_escalation/:id", handler.DeleteEscalationPolicy)
+
+		masterView.GET("/casetypes", handler.ListCaseType)
+		masterManage.POST("/casetypes/add", handler.InsertCaseType)
+		masterManage.PATCH("/casetypes/:id", handler.UpdateCaseType)
+		masterManage.DELETE("/casetypes/:id", handler.DeleteCaseType)
+		masterView.GET("/casetypes_with_subtype", handler.ListCaseTypeWithSubtype)
+		masterView.GET("/casesubtypes", handler.ListCaseSubType)
+		masterManage.POST("/casesubtypes/add", handler.InsertCaseSubType)
+		masterManage.PATCH("/casesubtypes/:id", handler.UpdateCaseSubType)
+		masterManage.DELETE("/casesubtypes/:id", handler.DeleteCaseSubType)
+
+		masterView.GET("/departments", handler.GetDepartment)
+		masterView.GET("/departments/:id", handler.GetDepartmentbyId)
+		masterManage.POST("/departments/add", handler.InsertDepartment)
+		masterManage.PATCH("/departments/:id", handler.UpdateDepartment)
+		masterManage.DELETE("/departments/:id", handler.DeleteDepartment)
+
+		masterView.GET("/commands", handler.GetCommand)
+		masterView.GET("/commands/:id", handler.GetCommandById)
+		masterManage.POST("/commands/add", handler.InsertCommand)
+		masterManage.PATCH("/commands/:id", handler.UpdateCommand)
+		masterManage.DELETE("/commands/:id", handler.DeleteCommand)
+
+		masterView.GET("/department_command_stations", handler.GetDepartmentCommandStation)
+		masterView.GET("/stations", handler.GetStation)
+		masterView.GET("/stations/:id", handler.GetStationbyId)
+		masterManage.POST("/stations/add", handler.InsertStations)
+		masterManage.PATCH("/stations/:id", handler.UpdateStations)
+		masterManage.DELETE("/stations/:id", handler.DeleteStations)
+
+		roleView.GET("/role", handler.GetRole)
+		roleView.GET("/role/:id", handler.GetRolebyId)
+		roleManage.POST("/role/add", handler.InsertRole)
+		roleManage.PATCH("/role/:id", handler.UpdateRole)
+		roleManage.DELETE("/role/:id", handler.DeleteRole)
+
+		roleView.GET("/permission", handler.GetPermission)
+		roleView.GET("/permission/catalogue", handler.GetPermissionCatalogue)
+		roleView.GET("/permission/:permId", handler.GetPermissionById)
+		roleManage.POST("/permission/add", handler.InsertPermission)
+		roleManage.PATCH("/permission/:permId", handler.UpdatePermission)
+		roleManage.DELETE("/permission/:permId", handler.DeletePermission)
+
+		roleView.GET("/role_permission", handler.GetRolePermission)
+		roleView.GET("/role_permission/:id", handler.GetRolePermissionbyId)
+		roleView.GET("/role_permission/roleId/:roleId", handler.GetRolePermissionbyroleId)
+		roleManage.POST("/role_permission/add", handler.InsertRolePermission)
+		roleManage.PATCH("/role_permission/:roleId", handler.UpdateRolePermission)
+		roleManage.PATCH("/role_permission/multi", handler.UpdateMultiRolePermission)
+		roleManage.DELETE("/role_permission/:id", handler.DeleteRolePermission)
+
+		customerView.GET("/customer", handler.CustomerList)
+		customerManage.POST("/customer/add", handler.CustomerAdd)
+		customerView.GET("/customer/:id", handler.CustomerById)
+		customerManage.PATCH("/customer/:id", handler.CustomerUpdate)
+		customerManage.DELETE("/customer/:id", handler.CustomerDelete)
+
+		customerView.GET("/customer_contacts", handler.CustomerContactList)
+		customerManage.POST("/customer_contacts/add", handler.CustomerContactAdd)
+		customerView.GET("/customer_contacts/:id", handler.CustomerContactById)
+		customerManage.PATCH("/customer_contacts/:id", handler.CustomerContactUpdate)
+		customerManage.DELETE("/customer_contacts/:id", handler.CustomerContactDelete)
+
+		customerView.GET("/customer_with_socials", handler.CustomerSocialList)
+		customerManage.POST("/customer_with_socials/add", handler.CustomerSocialAdd)
+		customerView.GET("/customer_with_socials/:id", handler.CustomerWithSocialById)
+		customerManage.PATCH("/customer_with_socials/:id", handler.CustomerSocialUpdate)
+		customerManage.DELETE("/customer_with_socials/:id", handler.CustomerSocialDelete)
+
+		userView.GET("/users", handler.GetUmUserList)
+		userView.GET("/users/:id", handler.GetUmUserById)
+		userManage.POST("/users/add", handler.UserAdd)
+		userManage.PATCH("/users/:id", handler.UserUpdate)
+		userManage.DELETE("/users/:id", handler.UserDelete)
+		userManage.POST("/users/:id/sessions/revoke", handler.RevokeUserSessions)
+		userView.GET("/users/username/:username", handler.GetUmUserByUsername)
+		userManage.PATCH("/users/username/:username", handler.UserUpdateByUsername)
+		userView.GET("/users_with_skills", handler.GetUserWithSkills)
+		userView.GET("/users_with_skills/:id", handler.GetUserWithSkillsById)
+		userView.GET("/users_with_skills/skillId/:skillId", handler.GetUserWithSkillsBySkillId)
+		userManage.POST("/users_with_skills/add", handler.InsertUserWithSkills)
+		userManage.PATCH("/users_with_skills/:id", handler.UpdateUserWithSkills)
+		userManage.DELETE("/users_with_skills/:id", handler.DeleteUserWithSkills)
+		userView.GET("/users_with_contacts", handler.GetUserWithContacts)
+		userView.GET("/users_with_contacts/:id", handler.GetUserWithContactsById)
+		userManage.POST("/users_with_contacts/add", handler.InsertUserWithContacts)
+		userManage.PATCH("/users_with_contacts/:id", handler.UpdateUserWithContacts)
+		userManage.DELETE("/users_with_contacts/:id", handler.DeleteUserWithContacts)
+		userView.GET("/users_with_socials", handler.GetUserWithSocials)
+		userView.GET("/users_with_socials/:id", handler.GetUserWithSocialsById)
+		userManage.POST("/users_with_socials/add", handler.InsertUserWithSocials)
+		userManage.PATCH("/users_with_socials/:id", handler.UpdateUserWithSocials)
+		userManage.DELETE("/users_with_socials/:id", handler.DeleteUserWithSocials)
+		userView.GET("/user_groups/all", handler.GetUmGroupList)
+
+		masterView.GET("/mdm/properties", handler.GetMmdProperty)
+		masterView.GET("/mdm/properties/:id", handler.GetMmdPropertyById)
+		masterManage.POST("/mdm/properties/add", handler.InsertMmdProperty)
+		masterManage.PATCH("/mdm/properties/:id", handler.UpdateMmdProperty)
+		masterManage.DELETE("/mdm/properties/:id", handler.DeleteMmdProperty)
+
+		masterView.GET("/mdm/sources", handler.GetMmdUnitSources)
+		masterView.GET("/mdm/sources/:id", handler.GetMmdUnitSourcesById)
+		masterManage.POST("/mdm/sources/add", handler.InsertMmdUnitSources)
+		masterManage.PATCH("/mdm/sources/:id", handler.UpdateMmdUnitSources)
+		masterManage.DELETE("/mdm/sources/:id", handler.DeleteMmdUnitSources)
+
+		masterView.GET("/mdm/types", handler.GetMmdUnitType)
+		masterView.GET("/mdm/types/:id", handler.GetMmdUnitTypeById)
+		masterManage.POST("/mdm/types/add", handler.InsertMmdUnitType)
+		masterManage.PATCH("/mdm/types/:id", handler.UpdateMmdUnitType)
+		masterManage.DELETE("/mdm/types/:id", handler.DeleteMmdUnitType)
+
+		masterView.GET("/mdm/companies", handler.GetMmdCompanies)
+		masterView.GET("/mdm/companies/:id", handler.GetMmdCompaniesById)
+		masterManage.POST("/mdm/companies/add", handler.InsertMmdCompanies)
+		masterManage.PATCH("/mdm/companies/:id", handler.UpdateMmdCompanies)
+		masterManage.DELETE("/mdm/companies/:id", handler.DeleteMmdCompanies)
+
+		masterView.GET("/mdm/status", handler.GetMmdUnitStatus)
+		masterView.GET("/mdm/status/:id", handler.GetMmdUnitStatusById)
+		masterManage.POST("/mdm/status/add", handler.InsertMmdUnitStatus)
+		masterManage.PATCH("/mdm/status/:id", handler.UpdateMmdUnitStatus)
+		masterManage.DELETE("/mdm/status/:id", handler.DeleteMmdUnitStatus)
+
+		masterView.GET("/mdm/units", handler.GetMmdUnit)
+		masterView.GET("/mdm/units/:id", handler.GetMmdUnitById)
+		masterManage.POST("/mdm/units/add", handler.InsertMmdUnit)
+		masterManage.PATCH("/mdm/units/:id", handler.UpdateMmdUnit)
+		masterManage.DELETE("/mdm/units/:id", handler.DeleteMmdUnit)
+
+		masterView.GET("/mdm/units/properties/unitId", handler.GetMmdUnitWithProperty)
+
+		caseView.GET("/dispatch/:caseId/SOP", handler.GetSOP)
+		caseView.GET("/dispatch/:caseId/units", handler.GetUnit)
+		caseView.GET("/dispatch/:caseId/assignments", handler.ListCaseDispatches)
+		caseUpdate.POST("/dispatch/:caseId/assign", handler.AssignUnits)
+		caseUpdate.POST("/dispatch/:caseId/unassign", handler.UnassignUnits)
+		// The unit's own user may report without case.update; the handler
+		// checks.
+		caseView.POST("/dispatch/:caseId/units/:unitId/status", handler.ReportUnitStatus)
+
+		auditView.GET("/audit_log", handler.GetAuditlog)
+		auditView.GET("/audit_log/verify", handler.VerifyAuditlog)
+		auditView.GET("/audit_log/search", handler.SearchAuditlog)
+		auditView.GET("/audit_log/export", handler.ExportAuditlog)
+		auditView.GET("/audit_log/:username", handler.GetAuditlogByUsername)
+
+		caseView.GET("/case_history", handler.GetCaseHistory)
+		caseView.GET("/case_history/:caseId", handler.GetCaseHistoryByCaseId)
+		caseUpdate.POST("/case_history/add", handler.InsertCaseHistory)
+		caseUpdate.PATCH("/case_history/:id", handler.UpdateCaseHistory)
+		caseDelete.DELETE("/case_history/:id", handler.DeleteCaseHistory)
+
+		masterView.GET("/devices", handler.GetDeviceIoT)
+		masterView.GET("/devices/:id", handler.GetDeviceIoTById)
+
+		systemView.GET("/system/db_stats", handler.GetDBStats)
+		systemView.GET("/system/ws_stats", handler.GetWsHubStats)
+		systemView.GET("/system/outbox", handler.GetNotificationOutbox)
+		systemView.POST("/system/outbox/:id/retry", handler.RetryNotificationOutbox)
 	}
 
 	notifications := router.Group("/api/v1/notifications")
 	{
-
-		v1.Use(handler.ProtectedHandler)
+		// The socket checks the access token itself, since browsers cannot
+		// set an Authorization header on the upgrade request.
 		notifications.GET("/register", handler.WebSocketHandler)
-		notifications.POST("/", handler.CreateNotifications)
-		notifications.GET("/:orgId/:username", handler.GetNotificationsForUser)
-		notifications.PUT("/:id", handler.UpdateNotification)
-		notifications.DELETE("/:id", handler.DeleteNotification)
+
+		protected := notifications.Group("", handler.ProtectedHandler)
+		notiManage := protected.Group("", handler.RequirePermission(handler.PermNotificationManage))
+		notiManage.POST("/", handler.CreateNotifications)
+		protected.GET("/me", handler.GetMyNotifications)
+		protected.GET("/unread_count", handler.GetUnreadNotificationCount)
+		protected.POST("/read", handler.MarkNotificationsRead)
+		protected.POST("/:id/read", handler.MarkNotificationRead)
+		protected.POST("/:id/acknowledge", handler.AcknowledgeNotification)
+		protected.POST("/:id/dismiss", handler.DismissNotification)
+		protected.GET("/:orgId/:username", handler.GetNotificationsForUser)
+		notiManage.PUT("/:id", handler.UpdateNotification)
+		notiManage.DELETE("/:id", handler.DeleteNotification)
 	}
-	logger := config.GetLog()
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 	logger.Info("Server started at: http://localhost:8080")
 	logger.Info("Swagger docs available at: http://localhost:8080/swagger/index.html")
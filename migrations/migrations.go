@@ -0,0 +1,249 @@
+package migrations
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"mainPackage/config"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+//go:embed sql/*.sql
+var files embed.FS
+
+// lockKey serialises migration runs across instances sharing one database.
+const lockKey = 7300125
+
+// Migration is one numbered schema change with its up and down scripts.
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Status reports whether a migration has been applied.
+type Status struct {
+	Version   int
+	Name      string
+	Applied   bool
+	AppliedAt *time.Time
+}
+
+// Load reads the embedded scripts named NNNN_name.up.sql / NNNN_name.down.sql
+// and returns them ordered by version.
+func Load() ([]Migration, error) {
+	entries, err := fs.ReadDir(files, "sql")
+	if err != nil {
+		return nil, err
+	}
+	byVersion := map[int]*Migration{}
+	for _, e := range entries {
+		name := e.Name()
+		var direction string
+		switch {
+		case strings.HasSuffix(name, ".up.sql"):
+			direction = "up"
+		case strings.HasSuffix(name, ".down.sql"):
+			direction = "down"
+		default:
+			continue
+		}
+		base := strings.TrimSuffix(name, "."+direction+".sql")
+		prefix, label, ok := strings.Cut(base, "_")
+		if !ok {
+			return nil, fmt.Errorf("migration %s: missing version prefix", name)
+		}
+		version, err := strconv.Atoi(prefix)
+		if err != nil {
+			return nil, fmt.Errorf("migration %s: invalid version: %w", name, err)
+		}
+		body, err := files.ReadFile(path.Join("sql", name))
+		if err != nil {
+			return nil, err
+		}
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: label}
+			byVersion[version] = m
+		} else if m.Name != label {
+			return nil, fmt.Errorf("migration %d: conflicting names %q and %q", version, m.Name, label)
+		}
+		if direction == "up" {
+			m.Up = string(body)
+		} else {
+			m.Down = string(body)
+		}
+	}
+
+	list := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s: both up and down scripts are required", m.Version, m.Name)
+		}
+		list = append(list, *m)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Version < list[j].Version })
+	return list, nil
+}
+
+// Up applies every pending migration in order, each in its own transaction,
+// and returns how many were applied.
+func Up(ctx context.Context, pool *pgxpool.Pool) (int, error) {
+	logger := config.GetLog()
+	list, err := Load()
+	if err != nil {
+		return 0, err
+	}
+	conn, release, err := lock(ctx, pool)
+	if err != nil {
+		return 0, err
+	}
+	defer release()
+
+	applied, err := appliedVersions(ctx, conn)
+	if err != nil {
+		return 0, err
+	}
+	count := 0
+	for _, m := range list {
+		if _, ok := applied[m.Version]; ok {
+			continue
+		}
+		err := pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+			if _, err := tx.Exec(ctx, m.Up); err != nil {
+				return err
+			}
+			_, err := tx.Exec(ctx, `INSERT INTO public.schema_migrations (version, name) VALUES ($1, $2)`,
+				m.Version, m.Name)
+			return err
+		})
+		if err != nil {
+			return count, fmt.Errorf("migration %04d_%s up: %w", m.Version, m.Name, err)
+		}
+		logger.Info("Migration applied", zap.Int("version", m.Version), zap.String("name", m.Name))
+		count++
+	}
+	return count, nil
+}
+
+// Down rolls back the most recently applied migrations, at most steps of
+// them, and returns how many were reverted.
+func Down(ctx context.Context, pool *pgxpool.Pool, steps int) (int, error) {
+	logger := config.GetLog()
+	list, err := Load()
+	if err != nil {
+		return 0, err
+	}
+	conn, release, err := lock(ctx, pool)
+	if err != nil {
+		return 0, err
+	}
+	defer release()
+
+	applied, err := appliedVersions(ctx, conn)
+	if err != nil {
+		return 0, err
+	}
+	count := 0
+	for i := len(list) - 1; i >= 0 && count < steps; i-- {
+		m := list[i]
+		if _, ok := applied[m.Version]; !ok {
+			continue
+		}
+		err := pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+			if _, err := tx.Exec(ctx, m.Down); err != nil {
+				return err
+			}
+			_, err := tx.Exec(ctx, `DELETE FROM public.schema_migrations WHERE version = $1`, m.Version)
+			return err
+		})
+		if err != nil {
+			return count, fmt.Errorf("migration %04d_%s down: %w", m.Version, m.Name, err)
+		}
+		logger.Info("Migration reverted", zap.Int("version", m.Version), zap.String("name", m.Name))
+		count++
+	}
+	return count, nil
+}
+
+// GetStatus lists every known migration with its applied state.
+func GetStatus(ctx context.Context, pool *pgxpool.Pool) ([]Status, error) {
+	list, err := Load()
+	if err != nil {
+		return nil, err
+	}
+	conn, err := pool.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	applied, err := appliedVersions(ctx, conn.Conn())
+	if err != nil {
+		return nil, err
+	}
+	result := make([]Status, 0, len(list))
+	for _, m := range list {
+		s := Status{Version: m.Version, Name: m.Name}
+		if at, ok := applied[m.Version]; ok {
+			s.Applied = true
+			s.AppliedAt = &at
+		}
+		result = append(result, s)
+	}
+	return result, nil
+}
+
+// lock acquires a dedicated connection holding the migration advisory lock.
+func lock(ctx context.Context, pool *pgxpool.Pool) (*pgx.Conn, func(), error) {
+	conn, err := pool.Acquire(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, err := conn.Exec(ctx, `SELECT pg_advisory_lock($1)`, lockKey); err != nil {
+		conn.Release()
+		return nil, nil, err
+	}
+	release := func() {
+		_, _ = conn.Exec(context.Background(), `SELECT pg_advisory_unlock($1)`, lockKey)
+		conn.Release()
+	}
+	return conn.Conn(), release, nil
+}
+
+func appliedVersions(ctx context.Context, conn *pgx.Conn) (map[int]time.Time, error) {
+	_, err := conn.Exec(ctx, `CREATE TABLE IF NOT EXISTS public.schema_migrations (
+		version     integer PRIMARY KEY,
+		name        text NOT NULL,
+		"appliedAt" timestamptz NOT NULL DEFAULT now()
+	)`)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := conn.Query(ctx, `SELECT version, "appliedAt" FROM public.schema_migrations`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := map[int]time.Time{}
+	for rows.Next() {
+		var version int
+		var at time.Time
+		if err := rows.Scan(&version, &at); err != nil {
+			return nil, err
+		}
+		applied[version] = at
+	}
+	return applied, rows.Err()
+}
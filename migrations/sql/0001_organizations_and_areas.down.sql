@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS public.area_districts;
+DROP TABLE IF EXISTS public.area_provinces;
+DROP TABLE IF EXISTS public.area_countries;
+DROP TABLE IF EXISTS public.organizations;
@@ -0,0 +1,44 @@
+CREATE EXTENSION IF NOT EXISTS pgcrypto;
+
+CREATE TABLE IF NOT EXISTS public.organizations (
+    id          uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    name        text NOT NULL,
+    "createdAt" timestamptz NOT NULL DEFAULT now(),
+    "updatedAt" timestamptz NOT NULL DEFAULT now(),
+    CONSTRAINT organizations_name_key UNIQUE (name)
+);
+
+CREATE TABLE IF NOT EXISTS public.area_countries (
+    id          bigserial PRIMARY KEY,
+    "countryId" text NOT NULL,
+    en          text,
+    th          text,
+    active      boolean NOT NULL DEFAULT true,
+    CONSTRAINT area_countries_country_key UNIQUE ("countryId")
+);
+
+CREATE TABLE IF NOT EXISTS public.area_provinces (
+    id          bigserial PRIMARY KEY,
+    "provId"    text NOT NULL,
+    "countryId" text NOT NULL,
+    en          text,
+    th          text,
+    active      boolean NOT NULL DEFAULT true,
+    CONSTRAINT area_provinces_prov_key UNIQUE ("provId")
+);
+
+CREATE INDEX IF NOT EXISTS area_provinces_country_idx ON public.area_provinces ("countryId");
+
+CREATE TABLE IF NOT EXISTS public.area_districts (
+    id          bigserial PRIMARY KEY,
+    "orgId"     uuid NOT NULL REFERENCES public.organizations (id) ON DELETE CASCADE,
+    "countryId" text NOT NULL,
+    "provId"    text NOT NULL,
+    "distId"    text NOT NULL,
+    en          text,
+    th          text,
+    active      boolean NOT NULL DEFAULT true,
+    CONSTRAINT area_districts_org_dist_key UNIQUE ("orgId", "distId")
+);
+
+CREATE INDEX IF NOT EXISTS area_districts_prov_idx ON public.area_districts ("orgId", "provId");
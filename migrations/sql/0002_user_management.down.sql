@@ -0,0 +1,14 @@
+DROP TABLE IF EXISTS public.um_user_with_groups;
+DROP TABLE IF EXISTS public.um_user_with_area_response;
+DROP TABLE IF EXISTS public.um_user_with_socials;
+DROP TABLE IF EXISTS public.um_user_contacts;
+DROP TABLE IF EXISTS public.um_user_with_skills;
+DROP TABLE IF EXISTS public.um_users;
+DROP TABLE IF EXISTS public.um_groups;
+DROP TABLE IF EXISTS public.um_skills;
+DROP TABLE IF EXISTS public.um_role_with_permissions;
+DROP TABLE IF EXISTS public.um_roles;
+DROP TABLE IF EXISTS public.um_permissions;
+DROP TABLE IF EXISTS public.sec_stations;
+DROP TABLE IF EXISTS public.sec_commands;
+DROP TABLE IF EXISTS public.sec_departments;
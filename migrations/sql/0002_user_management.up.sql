@@ -0,0 +1,222 @@
+CREATE TABLE IF NOT EXISTS public.sec_departments (
+    id          bigserial PRIMARY KEY,
+    "deptId"    text NOT NULL,
+    "orgId"     uuid NOT NULL REFERENCES public.organizations (id) ON DELETE CASCADE,
+    en          text,
+    th          text,
+    active      boolean NOT NULL DEFAULT true,
+    "createdAt" timestamptz NOT NULL DEFAULT now(),
+    "updatedAt" timestamptz NOT NULL DEFAULT now(),
+    "createdBy" text,
+    "updatedBy" text,
+    CONSTRAINT sec_departments_org_dept_key UNIQUE ("orgId", "deptId")
+);
+
+CREATE TABLE IF NOT EXISTS public.sec_commands (
+    id          bigserial PRIMARY KEY,
+    "deptId"    text NOT NULL,
+    "orgId"     uuid NOT NULL REFERENCES public.organizations (id) ON DELETE CASCADE,
+    "commId"    text NOT NULL,
+    en          text,
+    th          text,
+    active      boolean NOT NULL DEFAULT true,
+    "createdAt" timestamptz NOT NULL DEFAULT now(),
+    "updatedAt" timestamptz NOT NULL DEFAULT now(),
+    "createdBy" text,
+    "updatedBy" text,
+    CONSTRAINT sec_commands_org_comm_key UNIQUE ("orgId", "commId")
+);
+
+CREATE TABLE IF NOT EXISTS public.sec_stations (
+    id          bigserial PRIMARY KEY,
+    "orgId"     uuid NOT NULL REFERENCES public.organizations (id) ON DELETE CASCADE,
+    "deptId"    text NOT NULL,
+    "commId"    text NOT NULL,
+    "stnId"     text NOT NULL,
+    en          text,
+    th          text,
+    active      boolean NOT NULL DEFAULT true,
+    "createdAt" timestamptz NOT NULL DEFAULT now(),
+    "updatedAt" timestamptz NOT NULL DEFAULT now(),
+    "createdBy" text,
+    "updatedBy" text,
+    CONSTRAINT sec_stations_org_stn_key UNIQUE ("orgId", "stnId")
+);
+
+CREATE TABLE IF NOT EXISTS public.um_permissions (
+    id          bigserial PRIMARY KEY,
+    "groupName" text,
+    "permId"    text NOT NULL,
+    "permName"  text,
+    active      boolean NOT NULL DEFAULT true,
+    "createdAt" timestamptz NOT NULL DEFAULT now(),
+    "updatedAt" timestamptz NOT NULL DEFAULT now(),
+    "createdBy" text,
+    "updatedBy" text,
+    CONSTRAINT um_permissions_perm_key UNIQUE ("permId")
+);
+
+CREATE TABLE IF NOT EXISTS public.um_roles (
+    id          uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    "orgId"     uuid NOT NULL REFERENCES public.organizations (id) ON DELETE CASCADE,
+    "roleName"  text NOT NULL,
+    active      boolean NOT NULL DEFAULT true,
+    "createdAt" timestamptz NOT NULL DEFAULT now(),
+    "updatedAt" timestamptz NOT NULL DEFAULT now(),
+    "createdBy" text,
+    "updatedBy" text
+);
+
+CREATE INDEX IF NOT EXISTS um_roles_org_idx ON public.um_roles ("orgId");
+
+CREATE TABLE IF NOT EXISTS public.um_role_with_permissions (
+    id          bigserial PRIMARY KEY,
+    "orgId"     uuid NOT NULL REFERENCES public.organizations (id) ON DELETE CASCADE,
+    "roleId"    uuid NOT NULL REFERENCES public.um_roles (id) ON DELETE CASCADE,
+    "permId"    text NOT NULL,
+    active      boolean NOT NULL DEFAULT true,
+    "createdAt" timestamptz NOT NULL DEFAULT now(),
+    "updatedAt" timestamptz NOT NULL DEFAULT now(),
+    "createdBy" text,
+    "updatedBy" text
+);
+
+CREATE INDEX IF NOT EXISTS um_role_with_permissions_role_idx ON public.um_role_with_permissions ("orgId", "roleId");
+
+CREATE TABLE IF NOT EXISTS public.um_skills (
+    id          bigserial PRIMARY KEY,
+    "orgId"     uuid NOT NULL REFERENCES public.organizations (id) ON DELETE CASCADE,
+    "skillId"   uuid NOT NULL,
+    en          text,
+    th          text,
+    active      boolean NOT NULL DEFAULT true,
+    "createdAt" timestamptz NOT NULL DEFAULT now(),
+    "updatedAt" timestamptz NOT NULL DEFAULT now(),
+    "createdBy" text,
+    "updatedBy" text,
+    CONSTRAINT um_skills_org_skill_key UNIQUE ("orgId", "skillId")
+);
+
+CREATE TABLE IF NOT EXISTS public.um_groups (
+    id          bigserial PRIMARY KEY,
+    "orgId"     uuid NOT NULL REFERENCES public.organizations (id) ON DELETE CASCADE,
+    "grpId"     text NOT NULL,
+    en          text,
+    th          text,
+    active      boolean NOT NULL DEFAULT true,
+    "createdAt" timestamptz NOT NULL DEFAULT now(),
+    "updatedAt" timestamptz NOT NULL DEFAULT now(),
+    "createdBy" text,
+    "updatedBy" text,
+    CONSTRAINT um_groups_org_grp_key UNIQUE ("orgId", "grpId")
+);
+
+CREATE TABLE IF NOT EXISTS public.um_users (
+    id                      bigserial PRIMARY KEY,
+    "orgId"                 uuid NOT NULL REFERENCES public.organizations (id) ON DELETE CASCADE,
+    "displayName"           text,
+    title                   text,
+    "firstName"             text,
+    "middleName"            text,
+    "lastName"              text,
+    "citizenId"             text,
+    bod                     date,
+    blood                   text,
+    gender                  integer,
+    "mobileNo"              text,
+    address                 text,
+    photo                   text,
+    username                text NOT NULL,
+    password                text NOT NULL,
+    email                   text,
+    "roleId"                uuid REFERENCES public.um_roles (id) ON DELETE SET NULL,
+    "userType"              integer,
+    "empId"                 text,
+    "deptId"                text,
+    "commId"                text,
+    "stnId"                 text,
+    active                  boolean NOT NULL DEFAULT true,
+    "activationToken"       text,
+    "lastActivationRequest" bigint,
+    "lostPasswordRequest"   bigint,
+    "signupStamp"           bigint,
+    islogin                 boolean NOT NULL DEFAULT false,
+    "lastLogin"             timestamptz,
+    "createdAt"             timestamptz NOT NULL DEFAULT now(),
+    "updatedAt"             timestamptz NOT NULL DEFAULT now(),
+    "createdBy"             text,
+    "updatedBy"             text,
+    CONSTRAINT um_users_username_key UNIQUE (username)
+);
+
+CREATE INDEX IF NOT EXISTS um_users_org_idx ON public.um_users ("orgId");
+CREATE INDEX IF NOT EXISTS um_users_emp_idx ON public.um_users ("empId");
+
+CREATE TABLE IF NOT EXISTS public.um_user_with_skills (
+    id          bigserial PRIMARY KEY,
+    "orgId"     uuid NOT NULL REFERENCES public.organizations (id) ON DELETE CASCADE,
+    "userName"  text NOT NULL REFERENCES public.um_users (username) ON DELETE CASCADE ON UPDATE CASCADE,
+    "skillId"   uuid NOT NULL,
+    active      boolean NOT NULL DEFAULT true,
+    "createdAt" timestamptz NOT NULL DEFAULT now(),
+    "updatedAt" timestamptz NOT NULL DEFAULT now(),
+    "createdBy" text,
+    "updatedBy" text
+);
+
+CREATE INDEX IF NOT EXISTS um_user_with_skills_user_idx ON public.um_user_with_skills ("orgId", "userName");
+
+CREATE TABLE IF NOT EXISTS public.um_user_contacts (
+    id             bigserial PRIMARY KEY,
+    "orgId"        uuid NOT NULL REFERENCES public.organizations (id) ON DELETE CASCADE,
+    username       text NOT NULL REFERENCES public.um_users (username) ON DELETE CASCADE ON UPDATE CASCADE,
+    "contactName"  text,
+    "contactPhone" text,
+    "contactAddr"  text,
+    "createdAt"    timestamptz NOT NULL DEFAULT now(),
+    "updatedAt"    timestamptz NOT NULL DEFAULT now(),
+    "createdBy"    text,
+    "updatedBy"    text
+);
+
+CREATE INDEX IF NOT EXISTS um_user_contacts_user_idx ON public.um_user_contacts ("orgId", username);
+
+CREATE TABLE IF NOT EXISTS public.um_user_with_socials (
+    id           bigserial PRIMARY KEY,
+    "orgId"      uuid NOT NULL REFERENCES public.organizations (id) ON DELETE CASCADE,
+    username     text NOT NULL REFERENCES public.um_users (username) ON DELETE CASCADE ON UPDATE CASCADE,
+    "socialType" text,
+    "socialId"   text,
+    "socialName" text,
+    "createdAt"  timestamptz NOT NULL DEFAULT now(),
+    "updatedAt"  timestamptz NOT NULL DEFAULT now(),
+    "createdBy"  text,
+    "updatedBy"  text
+);
+
+CREATE INDEX IF NOT EXISTS um_user_with_socials_user_idx ON public.um_user_with_socials ("orgId", username);
+
+CREATE TABLE IF NOT EXISTS public.um_user_with_area_response (
+    id            bigserial PRIMARY KEY,
+    "orgId"       uuid NOT NULL REFERENCES public.organizations (id) ON DELETE CASCADE,
+    username      text NOT NULL REFERENCES public.um_users (username) ON DELETE CASCADE ON UPDATE CASCADE,
+    "distIdLists" jsonb NOT NULL DEFAULT '[]'::jsonb,
+    "createdAt"   timestamptz NOT NULL DEFAULT now(),
+    "updatedAt"   timestamptz NOT NULL DEFAULT now(),
+    "createdBy"   text,
+    "updatedBy"   text,
+    CONSTRAINT um_user_with_area_response_user_key UNIQUE ("orgId", username)
+);
+
+CREATE TABLE IF NOT EXISTS public.um_user_with_groups (
+    id          bigserial PRIMARY KEY,
+    "orgId"     uuid NOT NULL REFERENCES public.organizations (id) ON DELETE CASCADE,
+    username    text NOT NULL REFERENCES public.um_users (username) ON DELETE CASCADE ON UPDATE CASCADE,
+    "grpId"     text NOT NULL,
+    active      boolean NOT NULL DEFAULT true,
+    "createdAt" timestamptz NOT NULL DEFAULT now(),
+    "updatedAt" timestamptz NOT NULL DEFAULT now(),
+    "createdBy" text,
+    "updatedBy" text,
+    CONSTRAINT um_user_with_groups_user_grp_key UNIQUE (username, "grpId")
+);
@@ -0,0 +1,14 @@
+DROP TABLE IF EXISTS public.mdm_unit_with_properties;
+DROP TABLE IF EXISTS public.mdm_units;
+DROP TABLE IF EXISTS public.mdm_unit_statuses;
+DROP TABLE IF EXISTS public.mdm_companies;
+DROP TABLE IF EXISTS public.mdm_unit_types;
+DROP TABLE IF EXISTS public.mdm_unit_sources;
+DROP TABLE IF EXISTS public.mdm_properties;
+DROP TABLE IF EXISTS public.cust_contacts;
+DROP TABLE IF EXISTS public.cust_customer_with_socials;
+DROP TABLE IF EXISTS public.cust_customers;
+DROP TABLE IF EXISTS public.device_iot;
+DROP TABLE IF EXISTS public.case_status;
+DROP TABLE IF EXISTS public.case_sub_types;
+DROP TABLE IF EXISTS public.case_types;
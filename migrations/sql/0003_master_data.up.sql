@@ -0,0 +1,253 @@
+CREATE TABLE IF NOT EXISTS public.case_types (
+    id          bigserial PRIMARY KEY,
+    "typeId"    text NOT NULL,
+    "orgId"     uuid NOT NULL REFERENCES public.organizations (id) ON DELETE CASCADE,
+    en          text,
+    th          text,
+    active      boolean NOT NULL DEFAULT true,
+    "createdAt" timestamptz NOT NULL DEFAULT now(),
+    "updatedAt" timestamptz NOT NULL DEFAULT now(),
+    "createdBy" text,
+    "updatedBy" text,
+    CONSTRAINT case_types_org_type_key UNIQUE ("orgId", "typeId")
+);
+
+CREATE TABLE IF NOT EXISTS public.case_sub_types (
+    id              bigserial PRIMARY KEY,
+    "typeId"        text NOT NULL,
+    "sTypeId"       text NOT NULL,
+    "sTypeCode"     text,
+    "orgId"         uuid NOT NULL REFERENCES public.organizations (id) ON DELETE CASCADE,
+    en              text,
+    th              text,
+    "wfId"          text,
+    "caseSla"       text,
+    priority        text,
+    "userSkillList" jsonb NOT NULL DEFAULT '[]'::jsonb,
+    "unitPropLists" jsonb NOT NULL DEFAULT '[]'::jsonb,
+    active          boolean NOT NULL DEFAULT true,
+    "createdAt"     timestamptz NOT NULL DEFAULT now(),
+    "updatedAt"     timestamptz NOT NULL DEFAULT now(),
+    "createdBy"     text,
+    "updatedBy"     text,
+    CONSTRAINT case_sub_types_org_stype_key UNIQUE ("orgId", "sTypeId")
+);
+
+CREATE INDEX IF NOT EXISTS case_sub_types_type_idx ON public.case_sub_types ("orgId", "typeId");
+CREATE INDEX IF NOT EXISTS case_sub_types_stype_idx ON public.case_sub_types ("sTypeId");
+
+CREATE TABLE IF NOT EXISTS public.case_status (
+    id          bigserial PRIMARY KEY,
+    "statusId"  text NOT NULL,
+    th          text,
+    en          text,
+    color       text,
+    active      boolean NOT NULL DEFAULT true,
+    "createdAt" timestamptz NOT NULL DEFAULT now(),
+    "updatedAt" timestamptz NOT NULL DEFAULT now(),
+    "createdBy" text,
+    "updatedBy" text,
+    CONSTRAINT case_status_status_key UNIQUE ("statusId")
+);
+
+CREATE TABLE IF NOT EXISTS public.device_iot (
+    id            bigserial PRIMARY KEY,
+    "orgId"       uuid NOT NULL REFERENCES public.organizations (id) ON DELETE CASCADE,
+    "deviceId"    text NOT NULL,
+    "deviceType"  text,
+    model         text,
+    "firmwareVer" text,
+    latitude      text,
+    longitude     text,
+    "ipAddress"   text,
+    "macAddress"  text,
+    "createdAt"   timestamptz NOT NULL DEFAULT now(),
+    "updatedAt"   timestamptz NOT NULL DEFAULT now(),
+    "createdBy"   text,
+    "updatedBy"   text,
+    CONSTRAINT device_iot_org_device_key UNIQUE ("orgId", "deviceId")
+);
+
+CREATE TABLE IF NOT EXISTS public.cust_customers (
+    id            bigserial PRIMARY KEY,
+    "orgId"       uuid NOT NULL REFERENCES public.organizations (id) ON DELETE CASCADE,
+    "displayName" text,
+    title         text,
+    "firstName"   text,
+    "middleName"  text,
+    "lastName"    text,
+    "citizenId"   text,
+    dob           date,
+    blood         text,
+    gender        text,
+    "mobileNo"    text,
+    address       jsonb,
+    photo         text,
+    email         text,
+    usertype      text,
+    active        boolean NOT NULL DEFAULT true,
+    "createdAt"   timestamptz NOT NULL DEFAULT now(),
+    "updatedAt"   timestamptz NOT NULL DEFAULT now(),
+    "createdBy"   text,
+    "updatedBy"   text
+);
+
+CREATE INDEX IF NOT EXISTS cust_customers_org_idx ON public.cust_customers ("orgId");
+CREATE INDEX IF NOT EXISTS cust_customers_mobile_idx ON public.cust_customers ("orgId", "mobileNo");
+
+CREATE TABLE IF NOT EXISTS public.cust_customer_with_socials (
+    id           bigserial PRIMARY KEY,
+    "orgId"      uuid NOT NULL REFERENCES public.organizations (id) ON DELETE CASCADE,
+    "custId"     text NOT NULL,
+    "socialType" text,
+    "socialId"   text,
+    "socialName" text,
+    "imgUrl"     text,
+    "createdAt"  timestamptz NOT NULL DEFAULT now(),
+    "updatedAt"  timestamptz NOT NULL DEFAULT now(),
+    "createdBy"  text,
+    "updatedBy"  text
+);
+
+CREATE INDEX IF NOT EXISTS cust_customer_with_socials_cust_idx ON public.cust_customer_with_socials ("orgId", "custId");
+
+CREATE TABLE IF NOT EXISTS public.cust_contacts (
+    id             bigserial PRIMARY KEY,
+    "orgId"        uuid NOT NULL REFERENCES public.organizations (id) ON DELETE CASCADE,
+    "custId"       text NOT NULL,
+    "contactName"  text,
+    "contactPhone" text,
+    "contactAddr"  text,
+    "createdAt"    timestamptz NOT NULL DEFAULT now(),
+    "updatedAt"    timestamptz NOT NULL DEFAULT now(),
+    "createdBy"    text,
+    "updatedBy"    text
+);
+
+CREATE INDEX IF NOT EXISTS cust_contacts_cust_idx ON public.cust_contacts ("orgId", "custId");
+
+CREATE TABLE IF NOT EXISTS public.mdm_properties (
+    id          bigserial PRIMARY KEY,
+    "propId"    uuid NOT NULL DEFAULT gen_random_uuid(),
+    "orgId"     uuid NOT NULL REFERENCES public.organizations (id) ON DELETE CASCADE,
+    en          text,
+    th          text,
+    active      boolean NOT NULL DEFAULT true,
+    "createdAt" timestamptz NOT NULL DEFAULT now(),
+    "updatedAt" timestamptz NOT NULL DEFAULT now(),
+    "createdBy" text,
+    "updatedBy" text,
+    CONSTRAINT mdm_properties_prop_key UNIQUE ("propId")
+);
+
+CREATE TABLE IF NOT EXISTS public.mdm_unit_sources (
+    id             bigserial PRIMARY KEY,
+    "unitSourceId" text NOT NULL,
+    "orgId"        uuid NOT NULL REFERENCES public.organizations (id) ON DELETE CASCADE,
+    en             text,
+    th             text,
+    active         boolean NOT NULL DEFAULT true,
+    "createdAt"    timestamptz NOT NULL DEFAULT now(),
+    "updatedAt"    timestamptz NOT NULL DEFAULT now(),
+    "createdBy"    text,
+    "updatedBy"    text,
+    CONSTRAINT mdm_unit_sources_org_source_key UNIQUE ("orgId", "unitSourceId")
+);
+
+CREATE TABLE IF NOT EXISTS public.mdm_unit_types (
+    id           bigserial PRIMARY KEY,
+    "unitTypeId" text NOT NULL,
+    "orgId"      uuid NOT NULL REFERENCES public.organizations (id) ON DELETE CASCADE,
+    en           text,
+    th           text,
+    active       boolean NOT NULL DEFAULT true,
+    "createdAt"  timestamptz NOT NULL DEFAULT now(),
+    "updatedAt"  timestamptz NOT NULL DEFAULT now(),
+    "createdBy"  text,
+    "updatedBy"  text,
+    CONSTRAINT mdm_unit_types_org_type_key UNIQUE ("orgId", "unitTypeId")
+);
+
+CREATE TABLE IF NOT EXISTS public.mdm_companies (
+    id            uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    name          text NOT NULL,
+    "legalName"   text,
+    domain        text,
+    email         text,
+    "phoneNumber" text,
+    address       jsonb,
+    "logoUrl"     text,
+    "websiteUrl"  text,
+    description   text,
+    "createdAt"   timestamptz NOT NULL DEFAULT now(),
+    "updatedAt"   timestamptz NOT NULL DEFAULT now(),
+    "createdBy"   text,
+    "updatedBy"   text
+);
+
+CREATE TABLE IF NOT EXISTS public.mdm_unit_statuses (
+    id          bigserial PRIMARY KEY,
+    "sttId"     text NOT NULL,
+    "sttName"   text,
+    "createdAt" timestamptz NOT NULL DEFAULT now(),
+    "updatedAt" timestamptz NOT NULL DEFAULT now(),
+    "createdBy" text,
+    "updatedBy" text,
+    CONSTRAINT mdm_unit_statuses_stt_key UNIQUE ("sttId")
+);
+
+CREATE TABLE IF NOT EXISTS public.mdm_units (
+    id                  bigserial PRIMARY KEY,
+    "orgId"             uuid NOT NULL REFERENCES public.organizations (id) ON DELETE CASCADE,
+    "unitId"            text NOT NULL,
+    "unitName"          text,
+    "unitSourceId"      text,
+    "unitTypeId"        text,
+    priority            integer NOT NULL DEFAULT 0,
+    "compId"            text,
+    "deptId"            text,
+    "commId"            text,
+    "stnId"             text,
+    "plateNo"           text,
+    "provinceCode"      text,
+    active              boolean NOT NULL DEFAULT true,
+    username            text,
+    "isLogin"           boolean NOT NULL DEFAULT false,
+    "isFreeze"          boolean NOT NULL DEFAULT false,
+    "isOutArea"         boolean NOT NULL DEFAULT false,
+    "locLat"            double precision,
+    "locLon"            double precision,
+    "locAlt"            double precision,
+    "locBearing"        double precision,
+    "locSpeed"          double precision,
+    "locProvider"       text,
+    "locGpsTime"        timestamptz,
+    "locSatellites"     integer,
+    "locAccuracy"       double precision,
+    "locLastUpdateTime" timestamptz,
+    "breakDuration"     integer,
+    "healthChk"         text,
+    "healthChkTime"     timestamptz,
+    "sttId"             text REFERENCES public.mdm_unit_statuses ("sttId") ON UPDATE CASCADE,
+    "createdAt"         timestamptz NOT NULL DEFAULT now(),
+    "updatedAt"         timestamptz NOT NULL DEFAULT now(),
+    "createdBy"         text,
+    "updatedBy"         text,
+    CONSTRAINT mdm_units_unit_key UNIQUE ("unitId")
+);
+
+CREATE INDEX IF NOT EXISTS mdm_units_org_idx ON public.mdm_units ("orgId");
+CREATE INDEX IF NOT EXISTS mdm_units_username_idx ON public.mdm_units (username);
+
+CREATE TABLE IF NOT EXISTS public.mdm_unit_with_properties (
+    id          bigserial PRIMARY KEY,
+    "orgId"     uuid NOT NULL REFERENCES public.organizations (id) ON DELETE CASCADE,
+    "unitId"    text NOT NULL REFERENCES public.mdm_units ("unitId") ON DELETE CASCADE ON UPDATE CASCADE,
+    "propId"    uuid NOT NULL REFERENCES public.mdm_properties ("propId") ON DELETE CASCADE,
+    active      boolean NOT NULL DEFAULT true,
+    "createdAt" timestamptz NOT NULL DEFAULT now(),
+    "updatedAt" timestamptz NOT NULL DEFAULT now(),
+    "createdBy" text,
+    "updatedBy" text,
+    CONSTRAINT mdm_unit_with_properties_unit_prop_key UNIQUE ("unitId", "propId")
+);
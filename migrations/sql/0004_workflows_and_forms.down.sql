@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS public.form_elements;
+DROP TABLE IF EXISTS public.form_builder;
+DROP TABLE IF EXISTS public.wf_nodes;
+DROP TABLE IF EXISTS public.wf_definitions;
@@ -0,0 +1,71 @@
+CREATE TABLE IF NOT EXISTS public.wf_definitions (
+    id          bigserial PRIMARY KEY,
+    "orgId"     uuid NOT NULL REFERENCES public.organizations (id) ON DELETE CASCADE,
+    "wfId"      text NOT NULL,
+    title       text,
+    "desc"      text,
+    active      boolean NOT NULL DEFAULT true,
+    publish     boolean NOT NULL DEFAULT false,
+    locks       boolean NOT NULL DEFAULT false,
+    versions    text NOT NULL DEFAULT 'draft',
+    "createdAt" timestamptz NOT NULL DEFAULT now(),
+    "updatedAt" timestamptz NOT NULL DEFAULT now(),
+    "createdBy" text,
+    "updatedBy" text,
+    CONSTRAINT wf_definitions_org_wf_key UNIQUE ("orgId", "wfId")
+);
+
+CREATE TABLE IF NOT EXISTS public.wf_nodes (
+    id          bigserial PRIMARY KEY,
+    "orgId"     uuid NOT NULL REFERENCES public.organizations (id) ON DELETE CASCADE,
+    "wfId"      text NOT NULL,
+    "nodeId"    text NOT NULL,
+    versions    text NOT NULL DEFAULT 'draft',
+    type        text,
+    section     text NOT NULL,
+    data        jsonb,
+    pic         text,
+    "group"     text,
+    "formId"    text,
+    "createdAt" timestamptz NOT NULL DEFAULT now(),
+    "updatedAt" timestamptz NOT NULL DEFAULT now(),
+    "createdBy" text,
+    "updatedBy" text,
+    CONSTRAINT wf_nodes_node_key UNIQUE ("orgId", "wfId", versions, section, "nodeId"),
+    CONSTRAINT wf_nodes_section_check CHECK (section IN ('nodes', 'connections'))
+);
+
+CREATE INDEX IF NOT EXISTS wf_nodes_wf_idx ON public.wf_nodes ("wfId", versions);
+
+CREATE TABLE IF NOT EXISTS public.form_builder (
+    id            bigserial PRIMARY KEY,
+    "orgId"       uuid NOT NULL REFERENCES public.organizations (id) ON DELETE CASCADE,
+    "formId"      text NOT NULL,
+    "formName"    text,
+    "formColSpan" integer NOT NULL DEFAULT 1,
+    active        boolean NOT NULL DEFAULT true,
+    publish       boolean NOT NULL DEFAULT false,
+    versions      text NOT NULL DEFAULT 'draft',
+    locks         boolean NOT NULL DEFAULT false,
+    "createdAt"   timestamptz NOT NULL DEFAULT now(),
+    "updatedAt"   timestamptz NOT NULL DEFAULT now(),
+    "createdBy"   text,
+    "updatedBy"   text,
+    CONSTRAINT form_builder_org_form_key UNIQUE ("orgId", "formId")
+);
+
+CREATE TABLE IF NOT EXISTS public.form_elements (
+    id          bigserial PRIMARY KEY,
+    "orgId"     uuid NOT NULL REFERENCES public.organizations (id) ON DELETE CASCADE,
+    "formId"    text NOT NULL,
+    versions    text NOT NULL DEFAULT 'draft',
+    "eleNumber" integer NOT NULL,
+    "eleData"   jsonb NOT NULL,
+    "createdAt" timestamptz NOT NULL DEFAULT now(),
+    "updatedAt" timestamptz NOT NULL DEFAULT now(),
+    "createdBy" text,
+    "updatedBy" text,
+    CONSTRAINT form_elements_ele_key UNIQUE ("orgId", "formId", versions, "eleNumber")
+);
+
+CREATE INDEX IF NOT EXISTS form_elements_form_idx ON public.form_elements ("formId", versions);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS public.tix_case_history_events;
+DROP TABLE IF EXISTS public.tix_case_current_stage;
+DROP TABLE IF EXISTS public.tix_cases;
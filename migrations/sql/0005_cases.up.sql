@@ -0,0 +1,88 @@
+CREATE TABLE IF NOT EXISTS public.tix_cases (
+    id                bigserial PRIMARY KEY,
+    "orgId"           uuid NOT NULL REFERENCES public.organizations (id) ON DELETE CASCADE,
+    "caseId"          text NOT NULL,
+    "caseVersion"     text NOT NULL,
+    "referCaseId"     text,
+    "caseTypeId"      text,
+    "caseSTypeId"     text,
+    priority          integer NOT NULL DEFAULT 0,
+    "wfId"            text,
+    versions          text,
+    source            text,
+    "deviceId"        text,
+    "phoneNo"         text,
+    "phoneNoHide"     boolean NOT NULL DEFAULT false,
+    "caseDetail"      text,
+    "extReceive"      text,
+    "statusId"        text,
+    "caseLat"         text,
+    "caseLon"         text,
+    "caselocAddr"     text,
+    "caselocAddrDecs" text,
+    "countryId"       text,
+    "provId"          text,
+    "distId"          text,
+    "caseDuration"    integer NOT NULL DEFAULT 0,
+    "createdDate"     timestamptz,
+    "startedDate"     timestamptz,
+    "commandedDate"   timestamptz,
+    "receivedDate"    timestamptz,
+    "arrivedDate"     timestamptz,
+    "closedDate"      timestamptz,
+    usercreate        text,
+    usercommand       text,
+    userreceive       text,
+    userarrive        text,
+    userclose         text,
+    "resId"           text,
+    "resDetail"       text,
+    "scheduleFlag"    boolean,
+    "scheduleDate"    timestamptz,
+    "createdAt"       timestamptz NOT NULL DEFAULT now(),
+    "updatedAt"       timestamptz NOT NULL DEFAULT now(),
+    "createdBy"       text,
+    "updatedBy"       text,
+    CONSTRAINT tix_cases_case_key UNIQUE ("caseId")
+);
+
+CREATE INDEX IF NOT EXISTS tix_cases_org_created_idx ON public.tix_cases ("orgId", "createdAt" DESC);
+CREATE INDEX IF NOT EXISTS tix_cases_org_status_idx ON public.tix_cases ("orgId", "statusId");
+
+CREATE TABLE IF NOT EXISTS public.tix_case_current_stage (
+    id          bigserial PRIMARY KEY,
+    "orgId"     uuid NOT NULL REFERENCES public.organizations (id) ON DELETE CASCADE,
+    "caseId"    text NOT NULL REFERENCES public.tix_cases ("caseId") ON DELETE CASCADE,
+    "wfId"      text,
+    "nodeId"    text NOT NULL,
+    "stageType" text,
+    "unitId"    text,
+    username    text,
+    versions    text,
+    type        text,
+    section     text,
+    data        jsonb,
+    pic         text,
+    "group"     text,
+    "formId"    text,
+    "createdAt" timestamptz NOT NULL DEFAULT now(),
+    "updatedAt" timestamptz NOT NULL DEFAULT now(),
+    "createdBy" text,
+    "updatedBy" text
+);
+
+CREATE INDEX IF NOT EXISTS tix_case_current_stage_case_idx ON public.tix_case_current_stage ("orgId", "caseId");
+
+CREATE TABLE IF NOT EXISTS public.tix_case_history_events (
+    id          bigserial PRIMARY KEY,
+    "orgId"     uuid NOT NULL REFERENCES public.organizations (id) ON DELETE CASCADE,
+    "caseId"    text NOT NULL REFERENCES public.tix_cases ("caseId") ON DELETE CASCADE,
+    username    text,
+    type        text,
+    "fullMsg"   text,
+    "jsonData"  jsonb,
+    "createdAt" timestamptz NOT NULL DEFAULT now(),
+    "createdBy" text
+);
+
+CREATE INDEX IF NOT EXISTS tix_case_history_events_case_idx ON public.tix_case_history_events ("orgId", "caseId", "createdAt");
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS public.audit_logs;
+DROP TABLE IF EXISTS public.user_connections;
+DROP TABLE IF EXISTS public.notifications;
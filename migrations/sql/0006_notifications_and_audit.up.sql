@@ -0,0 +1,56 @@
+CREATE TABLE IF NOT EXISTS public.notifications (
+    id              serial PRIMARY KEY,
+    "orgId"         uuid NOT NULL REFERENCES public.organizations (id) ON DELETE CASCADE,
+    "senderType"    text,
+    sender          text,
+    "senderPhoto"   text,
+    message         text,
+    "eventType"     text,
+    "redirectUrl"   text,
+    "createdAt"     timestamptz NOT NULL DEFAULT now(),
+    "createdBy"     text,
+    "expiredAt"     timestamptz,
+    recipients      jsonb NOT NULL DEFAULT '[]'::jsonb,
+    data            jsonb NOT NULL DEFAULT '[]'::jsonb
+);
+
+CREATE INDEX IF NOT EXISTS notifications_org_created_idx ON public.notifications ("orgId", "createdAt" DESC);
+CREATE INDEX IF NOT EXISTS notifications_expired_idx ON public.notifications ("expiredAt");
+CREATE INDEX IF NOT EXISTS notifications_recipients_idx ON public.notifications USING gin (recipients);
+
+CREATE TABLE IF NOT EXISTS public.user_connections (
+    "empId"       text PRIMARY KEY,
+    username      text NOT NULL,
+    "orgId"       text NOT NULL,
+    "deptId"      text,
+    "commId"      text,
+    "stnId"       text,
+    "roleId"      text,
+    "grpId"       text[],
+    "distIdLists" text[],
+    "connectedAt" timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS user_connections_org_idx ON public.user_connections ("orgId");
+
+CREATE TABLE IF NOT EXISTS public.audit_logs (
+    id          bigserial PRIMARY KEY,
+    "orgId"     uuid,
+    username    text,
+    "txId"      text,
+    "uniqueId"  text,
+    "mainFunc"  text,
+    "subFunc"   text,
+    "nameFunc"  text,
+    action      text,
+    status      integer,
+    duration    double precision,
+    "newData"   text,
+    "oldData"   text,
+    "resData"   text,
+    message     text,
+    "createdAt" timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS audit_logs_org_created_idx ON public.audit_logs ("orgId", "createdAt" DESC);
+CREATE INDEX IF NOT EXISTS audit_logs_username_idx ON public.audit_logs ("orgId", username);
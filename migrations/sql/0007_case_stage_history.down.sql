@@ -0,0 +1 @@
+DROP TABLE IF EXISTS public.tix_case_stage_history;
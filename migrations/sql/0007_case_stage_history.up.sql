@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS public.tix_case_stage_history (
+    id           bigserial PRIMARY KEY,
+    "orgId"      uuid NOT NULL REFERENCES public.organizations (id) ON DELETE CASCADE,
+    "caseId"     text NOT NULL REFERENCES public.tix_cases ("caseId") ON DELETE CASCADE,
+    "wfId"       text NOT NULL,
+    versions     text NOT NULL,
+    "fromNodeId" text NOT NULL,
+    "toNodeId"   text NOT NULL,
+    label        text,
+    "createdAt"  timestamptz NOT NULL DEFAULT now(),
+    "createdBy"  text
+);
+
+CREATE INDEX IF NOT EXISTS tix_case_stage_history_case_idx ON public.tix_case_stage_history ("orgId", "caseId", id);
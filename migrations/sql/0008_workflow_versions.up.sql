@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS public.wf_versions (
+    id            bigserial PRIMARY KEY,
+    "orgId"       uuid NOT NULL REFERENCES public.organizations (id) ON DELETE CASCADE,
+    "wfId"        text NOT NULL,
+    versions      text NOT NULL,
+    title         text,
+    "desc"        text,
+    "publishedAt" timestamptz NOT NULL DEFAULT now(),
+    "publishedBy" text,
+    CONSTRAINT wf_versions_org_wf_version_key UNIQUE ("orgId", "wfId", versions),
+    CONSTRAINT wf_versions_numbered_check CHECK (versions ~ '^[0-9]+$')
+);
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS public.form_submissions (
+    id          bigserial PRIMARY KEY,
+    "orgId"     uuid NOT NULL REFERENCES public.organizations (id) ON DELETE CASCADE,
+    "caseId"    text NOT NULL REFERENCES public.tix_cases ("caseId") ON DELETE CASCADE,
+    "nodeId"    text NOT NULL DEFAULT '',
+    "formId"    text NOT NULL,
+    revision    integer NOT NULL,
+    data        jsonb NOT NULL,
+    "createdAt" timestamptz NOT NULL DEFAULT now(),
+    "createdBy" text,
+    CONSTRAINT form_submissions_revision_key UNIQUE ("orgId", "caseId", "nodeId", "formId", revision)
+);
+
+CREATE INDEX IF NOT EXISTS form_submissions_case_idx ON public.form_submissions ("orgId", "caseId", "formId");
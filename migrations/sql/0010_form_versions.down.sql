@@ -0,0 +1,2 @@
+ALTER TABLE public.form_submissions DROP COLUMN IF EXISTS "formVersions";
+DROP TABLE IF EXISTS public.form_versions;
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS public.form_versions (
+    id            bigserial PRIMARY KEY,
+    "orgId"       uuid NOT NULL REFERENCES public.organizations (id) ON DELETE CASCADE,
+    "formId"      text NOT NULL,
+    versions      text NOT NULL,
+    "formName"    text,
+    "formColSpan" integer NOT NULL DEFAULT 0,
+    "publishedAt" timestamptz NOT NULL DEFAULT now(),
+    "publishedBy" text,
+    CONSTRAINT form_versions_org_form_version_key UNIQUE ("orgId", "formId", versions),
+    CONSTRAINT form_versions_numbered_check CHECK (versions ~ '^[0-9]+$')
+);
+
+ALTER TABLE public.form_submissions ADD COLUMN IF NOT EXISTS "formVersions" text NOT NULL DEFAULT 'draft';
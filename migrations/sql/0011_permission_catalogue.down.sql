@@ -0,0 +1,5 @@
+DELETE FROM public.um_role_with_permissions
+WHERE "createdBy" = 'migration' AND "permId" IN ('case.view', 'case.create', 'case.update', 'case.delete', 'form.view', 'form.manage', 'master.view', 'master.manage', 'customer.view', 'customer.manage', 'user.view', 'user.manage', 'role.view', 'role.manage', 'audit.view', 'system.view');
+
+DELETE FROM public.um_permissions
+WHERE "permId" IN ('case.view', 'case.create', 'case.update', 'case.delete', 'form.view', 'form.manage', 'master.view', 'master.manage', 'customer.view', 'customer.manage', 'user.view', 'user.manage', 'role.view', 'role.manage', 'audit.view', 'system.view');
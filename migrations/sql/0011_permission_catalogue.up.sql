@@ -0,0 +1,35 @@
+-- Seed the permissions RequirePermission checks.
+INSERT INTO public.um_permissions ("permId", "groupName", "permName", active, "createdBy", "updatedBy")
+SELECT v."permId", v."groupName", v."permName", true, 'migration', 'migration'
+FROM (VALUES
+    ('case.view', 'Case', 'View cases, their history, stages and form submissions'),
+    ('case.create', 'Case', 'Create cases'),
+    ('case.update', 'Case', 'Update and advance cases, submit case forms'),
+    ('case.delete', 'Case', 'Delete cases and case history'),
+    ('form.view', 'Form and Workflow', 'View forms and workflows'),
+    ('form.manage', 'Form and Workflow', 'Edit, publish and roll back forms and workflows'),
+    ('master.view', 'Master Data', 'View case types, statuses, areas, organisation units and mdm data'),
+    ('master.manage', 'Master Data', 'Edit case types, statuses, organisation units and mdm data'),
+    ('customer.view', 'Customer', 'View customers'),
+    ('customer.manage', 'Customer', 'Edit customers'),
+    ('user.view', 'User', 'View users'),
+    ('user.manage', 'User', 'Create, edit and delete users'),
+    ('role.view', 'Role', 'View roles and permissions'),
+    ('role.manage', 'Role', 'Edit roles and grant permissions'),
+    ('audit.view', 'Audit', 'View the audit log'),
+    ('system.view', 'System', 'View system statistics')
+) AS v ("permId", "groupName", "permName")
+ON CONFLICT ("permId") DO NOTHING;
+
+-- Until now every signed-in user could call every route. Grant the catalogue
+-- to the existing roles so nobody loses access on upgrade; narrow the grants
+-- per role afterwards.
+INSERT INTO public.um_role_with_permissions ("orgId", "roleId", "permId", active, "createdBy", "updatedBy")
+SELECT r."orgId", r.id, p."permId", true, 'migration', 'migration'
+FROM public.um_roles r
+CROSS JOIN public.um_permissions p
+WHERE p."permId" IN ('case.view', 'case.create', 'case.update', 'case.delete', 'form.view', 'form.manage', 'master.view', 'master.manage', 'customer.view', 'customer.manage', 'user.view', 'user.manage', 'role.view', 'role.manage', 'audit.view', 'system.view')
+  AND NOT EXISTS (
+    SELECT 1 FROM public.um_role_with_permissions x
+    WHERE x."orgId" = r."orgId" AND x."roleId" = r.id AND x."permId" = p."permId"
+  );
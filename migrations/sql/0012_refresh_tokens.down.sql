@@ -0,0 +1 @@
+DROP TABLE IF EXISTS public.auth_refresh_tokens;
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS public.auth_refresh_tokens (
+    jti            uuid PRIMARY KEY,
+    "familyId"     uuid NOT NULL,
+    "orgId"        uuid NOT NULL REFERENCES public.organizations (id) ON DELETE CASCADE,
+    username       text NOT NULL,
+    "userAgent"    text,
+    "clientIp"     text,
+    "issuedAt"     timestamptz NOT NULL DEFAULT now(),
+    "expiresAt"    timestamptz NOT NULL,
+    "usedAt"       timestamptz,
+    "replacedBy"   uuid,
+    "revokedAt"    timestamptz,
+    "revokeReason" text
+);
+
+CREATE INDEX IF NOT EXISTS auth_refresh_tokens_family_idx ON public.auth_refresh_tokens ("familyId");
+CREATE INDEX IF NOT EXISTS auth_refresh_tokens_user_idx ON public.auth_refresh_tokens ("orgId", username);
+CREATE INDEX IF NOT EXISTS auth_refresh_tokens_expires_idx ON public.auth_refresh_tokens ("expiresAt");
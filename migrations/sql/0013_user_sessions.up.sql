@@ -0,0 +1,14 @@
+-- One row per open notification socket. A user_connections row exists for as
+-- long as its user has at least one session here.
+CREATE TABLE IF NOT EXISTS public.user_sessions (
+    "sessionId"   uuid PRIMARY KEY,
+    "empId"       text NOT NULL,
+    username      text NOT NULL,
+    "orgId"       text NOT NULL,
+    "userAgent"   text,
+    "clientIp"    text,
+    "connectedAt" timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS user_sessions_emp_idx ON public.user_sessions ("empId");
+CREATE INDEX IF NOT EXISTS user_sessions_org_user_idx ON public.user_sessions ("orgId", username);
@@ -0,0 +1,19 @@
+-- Deliveries owed for committed notifications, one row per notification and
+-- channel, written in the same transaction as the notification itself.
+CREATE TABLE IF NOT EXISTS public.notification_outbox (
+    id               bigserial PRIMARY KEY,
+    "orgId"          uuid NOT NULL REFERENCES public.organizations (id) ON DELETE CASCADE,
+    "notificationId" integer NOT NULL REFERENCES public.notifications (id) ON DELETE CASCADE,
+    channel          text NOT NULL,
+    status           text NOT NULL DEFAULT 'pending',
+    attempts         integer NOT NULL DEFAULT 0,
+    "nextAttemptAt"  timestamptz NOT NULL DEFAULT now(),
+    "lastError"      text,
+    "createdAt"      timestamptz NOT NULL DEFAULT now(),
+    "deliveredAt"    timestamptz,
+    CONSTRAINT notification_outbox_status_check CHECK (status IN ('pending', 'delivered', 'dead'))
+);
+
+CREATE INDEX IF NOT EXISTS notification_outbox_due_idx ON public.notification_outbox ("nextAttemptAt")
+    WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS notification_outbox_org_status_idx ON public.notification_outbox ("orgId", status, id DESC);
@@ -0,0 +1,14 @@
+-- What each recipient has done with a notification. Rows appear the first
+-- time a notification is delivered to, or marked by, the user.
+CREATE TABLE IF NOT EXISTS public.notification_states (
+    "notificationId" integer NOT NULL REFERENCES public.notifications (id) ON DELETE CASCADE,
+    "orgId"          uuid NOT NULL REFERENCES public.organizations (id) ON DELETE CASCADE,
+    username         text NOT NULL,
+    "deliveredAt"    timestamptz,
+    "readAt"         timestamptz,
+    "acknowledgedAt" timestamptz,
+    "dismissedAt"    timestamptz,
+    PRIMARY KEY ("notificationId", username)
+);
+
+CREATE INDEX IF NOT EXISTS notification_states_user_idx ON public.notification_states ("orgId", username);
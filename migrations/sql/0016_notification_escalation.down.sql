@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS public.notifications_escalation_idx;
+ALTER TABLE public.notifications DROP COLUMN IF EXISTS escalations;
+DROP TABLE IF EXISTS public.notification_escalation_policies;
@@ -0,0 +1,20 @@
+-- Escalation ladders per event type: an unacknowledged notification gains a
+-- wider recipient set at every step.
+CREATE TABLE IF NOT EXISTS public.notification_escalation_policies (
+    id          uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    "orgId"     uuid NOT NULL REFERENCES public.organizations (id) ON DELETE CASCADE,
+    "eventType" text NOT NULL,
+    steps       jsonb NOT NULL DEFAULT '[]'::jsonb,
+    active      boolean NOT NULL DEFAULT true,
+    "createdAt" timestamptz NOT NULL DEFAULT now(),
+    "updatedAt" timestamptz NOT NULL DEFAULT now(),
+    "createdBy" text,
+    "updatedBy" text,
+    CONSTRAINT notification_escalation_policies_event_key UNIQUE ("orgId", "eventType")
+);
+
+-- The steps already taken on a notification, as NotificationEscalation
+-- objects; its length is the current escalation level.
+ALTER TABLE public.notifications ADD COLUMN IF NOT EXISTS escalations jsonb NOT NULL DEFAULT '[]'::jsonb;
+
+CREATE INDEX IF NOT EXISTS notifications_escalation_idx ON public.notifications ("orgId", "eventType", "createdAt");
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS public.notifications_org_inbox_idx;
+DROP TABLE IF EXISTS public.notification_recipients;
@@ -0,0 +1,22 @@
+-- One row per recipient value of a notification, so inbox queries match a
+-- user by index instead of scanning the recipients jsonb with LIKE. A
+-- comma-joined value in the jsonb gives one row per value.
+CREATE TABLE IF NOT EXISTS public.notification_recipients (
+    "notificationId" integer NOT NULL REFERENCES public.notifications (id) ON DELETE CASCADE,
+    "orgId"          uuid NOT NULL REFERENCES public.organizations (id) ON DELETE CASCADE,
+    type             text NOT NULL,
+    value            text NOT NULL,
+    PRIMARY KEY ("notificationId", type, value)
+);
+
+CREATE INDEX IF NOT EXISTS notification_recipients_match_idx
+    ON public.notification_recipients ("orgId", type, value, "notificationId");
+CREATE INDEX IF NOT EXISTS notifications_org_inbox_idx ON public.notifications ("orgId", "createdAt" DESC, id DESC);
+
+INSERT INTO public.notification_recipients ("notificationId", "orgId", type, value)
+SELECT n.id, n."orgId", r->>'type', trim(v)
+FROM public.notifications n
+CROSS JOIN LATERAL jsonb_array_elements(n.recipients) AS r
+CROSS JOIN LATERAL unnest(string_to_array(r->>'value', ',')) AS v
+WHERE r->>'type' IS NOT NULL AND trim(v) <> ''
+ON CONFLICT DO NOTHING;
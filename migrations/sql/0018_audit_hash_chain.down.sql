@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS public.audit_logs_chain_idx;
+ALTER TABLE public.audit_logs DROP COLUMN IF EXISTS hash;
+ALTER TABLE public.audit_logs DROP COLUMN IF EXISTS "prevHash";
@@ -0,0 +1,7 @@
+-- Each row hashes its content together with the hash of the row before it
+-- in the same org, so an edited, removed or reordered row breaks the chain.
+-- Rows written before this migration stay unchained.
+ALTER TABLE public.audit_logs ADD COLUMN IF NOT EXISTS "prevHash" text;
+ALTER TABLE public.audit_logs ADD COLUMN IF NOT EXISTS hash text;
+
+CREATE INDEX IF NOT EXISTS audit_logs_chain_idx ON public.audit_logs ("orgId", id);
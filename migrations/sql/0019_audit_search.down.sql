@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS public.audit_logs_username_idx;
+CREATE INDEX IF NOT EXISTS audit_logs_username_idx ON public.audit_logs ("orgId", username);
+DROP INDEX IF EXISTS public.audit_logs_entity_idx;
+ALTER TABLE public.audit_logs DROP COLUMN IF EXISTS "entityId";
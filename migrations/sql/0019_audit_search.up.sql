@@ -0,0 +1,7 @@
+-- The id from the route of the call, e.g. the case of PATCH /case/:id, so an
+-- audit search can follow one record.
+ALTER TABLE public.audit_logs ADD COLUMN IF NOT EXISTS "entityId" text;
+
+CREATE INDEX IF NOT EXISTS audit_logs_entity_idx ON public.audit_logs ("orgId", "entityId", id DESC);
+DROP INDEX IF EXISTS public.audit_logs_username_idx;
+CREATE INDEX IF NOT EXISTS audit_logs_username_idx ON public.audit_logs ("orgId", username, id DESC);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS public.case_status_transitions;
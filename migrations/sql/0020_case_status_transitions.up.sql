@@ -0,0 +1,17 @@
+-- The status changes each organization allows on its cases. stamp names the
+-- lifecycle date and user the change sets: commanded, received, arrived or
+-- closed; reopened clears the closing ones.
+CREATE TABLE IF NOT EXISTS public.case_status_transitions (
+    id             uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    "orgId"        uuid NOT NULL REFERENCES public.organizations (id) ON DELETE CASCADE,
+    "fromStatusId" text NOT NULL REFERENCES public.case_status ("statusId") ON UPDATE CASCADE,
+    "toStatusId"   text NOT NULL REFERENCES public.case_status ("statusId") ON UPDATE CASCADE,
+    stamp          text NOT NULL DEFAULT ''
+        CHECK (stamp IN ('', 'commanded', 'received', 'arrived', 'closed', 'reopened')),
+    active         boolean NOT NULL DEFAULT true,
+    "createdAt"    timestamptz NOT NULL DEFAULT now(),
+    "updatedAt"    timestamptz NOT NULL DEFAULT now(),
+    "createdBy"    text,
+    "updatedBy"    text,
+    CONSTRAINT case_status_transitions_pair_key UNIQUE ("orgId", "fromStatusId", "toStatusId")
+);
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS public.case_dispatches;
+
+DELETE FROM public.mdm_unit_statuses s
+WHERE s."createdBy" = 'migration'
+  AND s."sttId" IN ('available', 'assigned', 'acknowledged', 'en-route', 'on-scene', 'completed')
+  AND NOT EXISTS (SELECT 1 FROM public.mdm_units u WHERE u."sttId" = s."sttId");
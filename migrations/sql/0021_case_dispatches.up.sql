@@ -0,0 +1,41 @@
+-- Unit statuses the dispatch lifecycle writes into mdm_units."sttId".
+INSERT INTO public.mdm_unit_statuses ("sttId", "sttName", "createdBy", "updatedBy")
+SELECT v."sttId", v."sttName", 'migration', 'migration'
+FROM (VALUES
+    ('available', 'Available'),
+    ('assigned', 'Assigned'),
+    ('acknowledged', 'Acknowledged'),
+    ('en-route', 'En route'),
+    ('on-scene', 'On scene'),
+    ('completed', 'Completed')
+) AS v("sttId", "sttName")
+ON CONFLICT ("sttId") DO NOTHING;
+
+-- One row per unit sent to a case. A dispatch moves forward through
+-- assigned, acknowledged, en-route, on-scene and completed; released ends it
+-- and frees the unit, which may then hold one open dispatch again.
+CREATE TABLE IF NOT EXISTS public.case_dispatches (
+    id               bigserial PRIMARY KEY,
+    "orgId"          uuid NOT NULL REFERENCES public.organizations (id) ON DELETE CASCADE,
+    "caseId"         text NOT NULL REFERENCES public.tix_cases ("caseId") ON DELETE CASCADE,
+    "unitId"         text NOT NULL REFERENCES public.mdm_units ("unitId") ON DELETE CASCADE ON UPDATE CASCADE,
+    username         text,
+    status           text NOT NULL DEFAULT 'assigned'
+        CHECK (status IN ('assigned', 'acknowledged', 'en-route', 'on-scene', 'completed', 'released')),
+    "assignedBy"     text,
+    "assignedAt"     timestamptz NOT NULL DEFAULT now(),
+    "acknowledgedAt" timestamptz,
+    "enRouteAt"      timestamptz,
+    "onSceneAt"      timestamptz,
+    "completedAt"    timestamptz,
+    "releasedAt"     timestamptz,
+    "releasedBy"     text,
+    "createdAt"      timestamptz NOT NULL DEFAULT now(),
+    "updatedAt"      timestamptz NOT NULL DEFAULT now(),
+    "createdBy"      text,
+    "updatedBy"      text
+);
+
+CREATE INDEX IF NOT EXISTS case_dispatches_case_idx ON public.case_dispatches ("orgId", "caseId", "assignedAt");
+CREATE UNIQUE INDEX IF NOT EXISTS case_dispatches_open_unit_key ON public.case_dispatches ("unitId")
+    WHERE status <> 'released';
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS public.user_sessions_seen_idx;
+DROP INDEX IF EXISTS public.user_sessions_instance_idx;
+
+ALTER TABLE public.user_sessions
+    DROP COLUMN IF EXISTS "seenAt",
+    DROP COLUMN IF EXISTS "instanceId";
@@ -0,0 +1,9 @@
+-- Each instance stamps its sessions with its id and refreshes "seenAt" on a
+-- heartbeat; sessions whose instance stopped beating are swept, so a crash
+-- does not leave presence behind.
+ALTER TABLE public.user_sessions
+    ADD COLUMN IF NOT EXISTS "instanceId" text,
+    ADD COLUMN IF NOT EXISTS "seenAt"     timestamptz NOT NULL DEFAULT now();
+
+CREATE INDEX IF NOT EXISTS user_sessions_instance_idx ON public.user_sessions ("instanceId");
+CREATE INDEX IF NOT EXISTS user_sessions_seen_idx ON public.user_sessions ("seenAt");
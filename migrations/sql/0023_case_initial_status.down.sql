@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS public.case_status_transitions_initial_key;
+
+DELETE FROM public.case_status_transitions WHERE "fromStatusId" IS NULL;
+
+ALTER TABLE public.case_status_transitions DROP CONSTRAINT IF EXISTS case_status_transitions_initial_stamp;
+
+ALTER TABLE public.case_status_transitions ALTER COLUMN "fromStatusId" SET NOT NULL;
@@ -0,0 +1,9 @@
+-- A transition without fromStatusId names the status an organization's new
+-- cases start in. Each organization has at most one, and it stamps nothing.
+ALTER TABLE public.case_status_transitions ALTER COLUMN "fromStatusId" DROP NOT NULL;
+
+ALTER TABLE public.case_status_transitions
+    ADD CONSTRAINT case_status_transitions_initial_stamp CHECK ("fromStatusId" IS NOT NULL OR stamp = '');
+
+CREATE UNIQUE INDEX IF NOT EXISTS case_status_transitions_initial_key
+    ON public.case_status_transitions ("orgId") WHERE "fromStatusId" IS NULL;
@@ -0,0 +1,5 @@
+DELETE FROM public.um_role_with_permissions
+WHERE "createdBy" = 'migration' AND "permId" = 'notification.manage';
+
+DELETE FROM public.um_permissions
+WHERE "permId" = 'notification.manage';
@@ -0,0 +1,15 @@
+-- Creating, editing and deleting notifications now needs notification.manage.
+INSERT INTO public.um_permissions ("permId", "groupName", "permName", active, "createdBy", "updatedBy")
+VALUES ('notification.manage', 'Notification', 'Send, edit and delete notifications', true, 'migration', 'migration')
+ON CONFLICT ("permId") DO NOTHING;
+
+-- Sending to a whole organization is an administrative act, so the grant goes
+-- to the roles that may already manage users rather than to every role.
+INSERT INTO public.um_role_with_permissions ("orgId", "roleId", "permId", active, "createdBy", "updatedBy")
+SELECT DISTINCT g."orgId", g."roleId", 'notification.manage', true, 'migration', 'migration'
+FROM public.um_role_with_permissions g
+WHERE g."permId" = 'user.manage' AND g.active
+  AND NOT EXISTS (
+    SELECT 1 FROM public.um_role_with_permissions x
+    WHERE x."orgId" = g."orgId" AND x."roleId" = g."roleId" AND x."permId" = 'notification.manage'
+  );
@@ -0,0 +1,16 @@
+-- Records where the hash chain begins. Rows up to lastUnchainedId were
+-- written before 0018 and carry no hash; every later row must, so blanking
+-- the hashes of chained rows cannot pass them off as pre-chain rows.
+CREATE TABLE IF NOT EXISTS public.audit_chain_start (
+    id                boolean PRIMARY KEY DEFAULT true CHECK (id),
+    "lastUnchainedId" bigint NOT NULL
+);
+
+-- The first chained row marks the cut-over; with none yet, every row so far
+-- is from before it.
+INSERT INTO public.audit_chain_start ("lastUnchainedId")
+SELECT COALESCE(
+    (SELECT min(id) - 1 FROM public.audit_logs WHERE COALESCE(hash, '') <> ''),
+    (SELECT max(id) FROM public.audit_logs),
+    0)
+ON CONFLICT (id) DO NOTHING;
@@ -0,0 +1,7 @@
+DELETE FROM public.case_status_transitions
+WHERE "createdBy" = 'migration' AND "fromStatusId" IS NULL AND "toStatusId" = 'new';
+
+DELETE FROM public.case_status s
+WHERE s."statusId" = 'new' AND s."createdBy" = 'migration'
+  AND NOT EXISTS (SELECT 1 FROM public.case_status_transitions x WHERE 'new' IN (x."fromStatusId", x."toStatusId"))
+  AND NOT EXISTS (SELECT 1 FROM public.tix_cases c WHERE c."statusId" = 'new');
@@ -0,0 +1,14 @@
+-- Case creation needs an initial status (0023). Give every existing
+-- organization one so creating cases keeps working on upgrade; point it at
+-- another status afterwards through the transition endpoints.
+INSERT INTO public.case_status ("statusId", th, en, active, "createdBy", "updatedBy")
+VALUES ('new', 'ใหม่', 'New', true, 'migration', 'migration')
+ON CONFLICT ("statusId") DO NOTHING;
+
+INSERT INTO public.case_status_transitions ("orgId", "fromStatusId", "toStatusId", stamp, active, "createdBy", "updatedBy")
+SELECT o.id, NULL, 'new', '', true, 'migration', 'migration'
+FROM public.organizations o
+WHERE NOT EXISTS (
+    SELECT 1 FROM public.case_status_transitions x
+    WHERE x."orgId" = o.id AND x."fromStatusId" IS NULL
+);
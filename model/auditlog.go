@@ -1,6 +1,11 @@
 package model
 
-import "time"
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+)
 
 type AuditLog struct {
 	ID        int       `json:"id" db:"id"`
@@ -19,4 +24,88 @@ type AuditLog struct {
 	ResData   string    `json:"resData" db:"resData"`
 	Message   string    `json:"message" db:"message"`
 	CreatedAt time.Time `json:"createdAt" db:"createdAt"`
+	// EntityID is the id in the route of the call, if it has one.
+	EntityID string `json:"entityId" db:"entityId"`
+	// PrevHash is the Hash of the org's previous row; both are empty on rows
+	// written before the chain existed.
+	PrevHash string `json:"prevHash" db:"prevHash"`
+	Hash     string `json:"hash" db:"hash"`
+}
+
+// ChainHash is the hex SHA-256 of the row's content and PrevHash. The id is
+// left out because it is assigned after the hash is taken, and CreatedAt is
+// taken in UTC to the microsecond, as Postgres stores it. EntityID is only
+// hashed when set, so rows chained before it existed still verify.
+func (e *AuditLog) ChainHash() string {
+	fields := []interface{}{
+		e.PrevHash, e.OrgID, e.Username, e.TxID, e.UniqueId, e.MainFunc, e.SubFunc, e.NameFunc,
+		e.Action, e.Status, e.Duration, e.NewData, e.OldData, e.ResData, e.Message,
+		e.CreatedAt.UTC().Truncate(time.Microsecond).Format(time.RFC3339Nano),
+	}
+	if e.EntityID != "" {
+		fields = append(fields, e.EntityID)
+	}
+	raw, _ := json.Marshal(fields)
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:])
+}
+
+// AuditLogFilter narrows an audit search; empty fields are ignored. Rows
+// come newest first.
+type AuditLogFilter struct {
+	From, To *time.Time // createdAt in [From, To)
+	Username string
+	MainFunc string
+	SubFunc  string
+	Action   string
+	EntityID string
+	Status   *int
+	// Query is matched case-insensitively in message, newData, oldData and
+	// resData.
+	Query string
+	// BeforeID continues a search after the last row of the previous page.
+	BeforeID int
+	Limit    int
+	Offset   int
+}
+
+// AuditLogPage is one page of an audit search; NextCursor is empty on the
+// last page.
+type AuditLogPage struct {
+	Items      []AuditLog `json:"items"`
+	NextCursor string     `json:"nextCursor"`
+}
+
+// AuditChainReport is the outcome of walking one org's chain.
+type AuditChainReport struct {
+	OrgID string `json:"orgId"`
+	// Valid is false when BrokenAt is set.
+	Valid bool `json:"valid"`
+	// Checked counts the chained rows verified; Unchained counts the rows
+	// from before the chain that were skipped.
+	Checked   int    `json:"checked"`
+	Unchained int    `json:"unchained"`
+	LastID    int    `json:"lastId"`
+	LastHash  string `json:"lastHash"`
+	// BrokenAt is the first row that does not follow from the one before.
+	BrokenAt *AuditChainBreak `json:"brokenAt,omitempty"`
+}
+
+// AuditChainBreak describes the first broken link of a chain.
+type AuditChainBreak struct {
+	ID       int    `json:"id"`
+	Reason   string `json:"reason"`
+	Expected string `json:"expected"`
+	Found    string `json:"found"`
+}
+
+// AuditCheckpoint vouches for the head of an org's chain at a point in time.
+// Signature is the base64 ed25519 signature of the checkpoint encoded as JSON
+// with Signature empty.
+type AuditCheckpoint struct {
+	OrgID     string    `json:"orgId"`
+	LastID    int       `json:"lastId"`
+	Hash      string    `json:"hash"`
+	CreatedAt time.Time `json:"createdAt"`
+	Signature string    `json:"signature,omitempty"`
 }
@@ -83,3 +83,25 @@ type Login struct {
 	Password     string `json:"password"`
 	Organization string `json:"organization"`
 }
+
+// RefreshTokenRecord is the server-side row behind an issued refresh token.
+// Every token minted by rotating another shares its FamilyID, so a whole
+// login session can be revoked at once.
+type RefreshTokenRecord struct {
+	JTI          string     `json:"jti"`
+	FamilyID     string     `json:"familyId"`
+	OrgID        string     `json:"orgId"`
+	Username     string     `json:"username"`
+	UserAgent    string     `json:"userAgent"`
+	ClientIP     string     `json:"clientIp"`
+	IssuedAt     time.Time  `json:"issuedAt"`
+	ExpiresAt    time.Time  `json:"expiresAt"`
+	UsedAt       *time.Time `json:"usedAt"`
+	ReplacedBy   *string    `json:"replacedBy"`
+	RevokedAt    *time.Time `json:"revokedAt"`
+	RevokeReason *string    `json:"revokeReason"`
+}
+
+type LogoutInput struct {
+	RefreshToken string `json:"refreshToken"`
+}
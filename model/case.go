@@ -51,8 +51,13 @@ type Case struct {
 	UpdatedBy       string      `json:"updatedBy"`
 	SOP             interface{} `json:"sop"`
 	CurrentStage    interface{} `json:"currentStage"`
+	// FormSubmissions holds the latest revision of each form submitted for
+	// the case; only CaseById fills it.
+	FormSubmissions []FormSubmission `json:"formSubmissions,omitempty"`
 }
 
+// CaseInsert creates a case. The case starts in the organization's initial
+// status with no lifecycle stamps; only a status change sets those.
 type CaseInsert struct {
 	CaseId          *string    `json:"caseId" `
 	CaseVersion     string     `json:"caseVersion" binding:"required"`
@@ -62,14 +67,13 @@ type CaseInsert struct {
 	Priority        int        `json:"priority"`
 	WfID            *string    `json:"wfId"`
 	WfVersions      *string    `json:"versions"`
-	NodeID          string     `json:"nodeId" db:"nodeId"`
+	NodeID          string     `json:"nodeId" db:"nodeId"` // optional; must be WfID's start node
 	Source          string     `json:"source"`
 	DeviceID        *string    `json:"deviceId"`
 	PhoneNo         *string    `json:"phoneNo"`
 	PhoneNoHide     bool       `json:"phoneNoHide"`
 	CaseDetail      *string    `json:"caseDetail"`
 	ExtReceive      *string    `json:"extReceive"`
-	StatusID        string     `json:"statusId"`
 	CaseLat         *string    `json:"caseLat"`
 	CaseLon         *string    `json:"caseLon"`
 	CaseLocAddr     *string    `json:"caselocAddr"`
@@ -77,38 +81,54 @@ type CaseInsert struct {
 	CountryID       string     `json:"countryId"`
 	ProvID          string     `json:"provId"`
 	DistID          string     `json:"distId"`
-	CaseDuration    int        `json:"caseDuration"`
 	CreatedDate     *time.Time `json:"createdDate"`
 	StartedDate     *time.Time `json:"startedDate"`
-	CommandedDate   *time.Time `json:"commandedDate"`
-	ReceivedDate    *time.Time `json:"receivedDate"`
-	ArrivedDate     *time.Time `json:"arrivedDate"`
-	ClosedDate      *time.Time `json:"closedDate"`
 	UserCreate      *string    `json:"usercreate"`
-	UserCommand     *string    `json:"usercommand"`
-	UserReceive     *string    `json:"userreceive"`
-	UserArrive      *string    `json:"userarrive"`
-	UserClose       *string    `json:"userclose"`
 	ResID           *string    `json:"resId"`
 	ResDetail       *string    `json:"resDetail"`
 	ScheduleFlag    *bool      `json:"scheduleFlag"`
 	ScheduleDate    *time.Time `json:"scheduleDate"`
+	// StatusID is filled in by the server.
+	StatusID string `json:"-"`
 }
 
+// CaseCreated is returned by case creation.
+type CaseCreated struct {
+	ID           string        `json:"id"`
+	CaseID       string        `json:"caseId"`
+	CurrentStage *CurrentStage `json:"currentStage"`
+}
+
+// CaseFilter narrows a case listing; empty fields are ignored.
+type CaseFilter struct {
+	CaseTypeID  string
+	CaseSTypeID string
+	Detail      string
+	StartDate   string
+	EndDate     string
+	StatusID    string
+	CountryID   string
+	ProvID      string
+	DistID      string
+	Start       int
+	Length      int
+}
+
+// CaseUpdate edits a case. The status, the lifecycle dates and users and
+// caseDuration are left out: only a status change sets them. The workflow
+// is left out too, as the case stays on the versions pinned at creation.
 type CaseUpdate struct {
 	CaseVersion     string     `json:"caseVersion"`
 	ReferCaseID     *string    `json:"referCaseId"`
 	CaseTypeID      string     `json:"caseTypeId"`
 	CaseSTypeID     string     `json:"caseSTypeId"`
 	Priority        int        `json:"priority"`
-	WfID            string     `json:"wfId" db:"wfId"`
 	Source          string     `json:"source"`
 	DeviceID        string     `json:"deviceId"`
 	PhoneNo         string     `json:"phoneNo"`
 	PhoneNoHide     bool       `json:"phoneNoHide"`
 	CaseDetail      *string    `json:"caseDetail"`
 	ExtReceive      string     `json:"extReceive"`
-	StatusID        string     `json:"statusId"`
 	CaseLat         string     `json:"caseLat"`
 	CaseLon         string     `json:"caseLon"`
 	CaseLocAddr     string     `json:"caselocAddr"`
@@ -116,18 +136,9 @@ type CaseUpdate struct {
 	CountryID       string     `json:"countryId"`
 	ProvID          string     `json:"provId"`
 	DistID          string     `json:"distId"`
-	CaseDuration    int        `json:"caseDuration"`
 	CreatedDate     time.Time  `json:"createdDate"`
 	StartedDate     time.Time  `json:"startedDate"`
-	CommandedDate   time.Time  `json:"commandedDate"`
-	ReceivedDate    time.Time  `json:"receivedDate"`
-	ArrivedDate     time.Time  `json:"arrivedDate"`
-	ClosedDate      time.Time  `json:"closedDate"`
 	UserCreate      string     `json:"usercreate"`
-	UserCommand     string     `json:"usercommand"`
-	UserReceive     string     `json:"userreceive"`
-	UserArrive      string     `json:"userarrive"`
-	UserClose       string     `json:"userclose"`
 	ResID           *string    `json:"resId"`
 	ResDetail       *string    `json:"resDetail"`
 	ScheduleFlag    *bool      `json:"scheduleFlag"`
@@ -41,3 +41,39 @@ type CaseCurrentStageInsert struct {
 	Group    string `json:"group" db:"group"`
 	FormID   string `json:"formId" db:"formId"`
 }
+
+// CaseAdvance is the body of POST /case/{id}/advance. Label picks the
+// outgoing edge when the current node has more than one.
+type CaseAdvance struct {
+	Label string `json:"label"`
+}
+
+// CaseAdvanced describes a transition the engine has made.
+type CaseAdvanced struct {
+	CaseID       string        `json:"caseId"`
+	FromNodeID   string        `json:"fromNodeId"`
+	Label        string        `json:"label"`
+	CurrentStage *CurrentStage `json:"currentStage"`
+}
+
+type CaseStageHistory struct {
+	ID         int       `json:"id"`
+	OrgID      string    `json:"orgId"`
+	CaseID     string    `json:"caseId"`
+	WfID       string    `json:"wfId"`
+	Versions   string    `json:"versions"`
+	FromNodeID string    `json:"fromNodeId"`
+	ToNodeID   string    `json:"toNodeId"`
+	Label      string    `json:"label"`
+	CreatedAt  time.Time `json:"createdAt"`
+	CreatedBy  string    `json:"createdBy"`
+}
+
+type CaseStageHistoryInsert struct {
+	CaseID     string
+	WfID       string
+	Versions   string
+	FromNodeID string
+	ToNodeID   string
+	Label      string
+}
@@ -30,3 +30,58 @@ type CaseStatusUpdate struct {
 	Color  *string `json:"color" db:"color"`
 	Active bool    `json:"active" db:"active"`
 }
+
+// Lifecycle stamps a status transition applies to the case, server-side.
+const (
+	CaseStampCommanded = "commanded" // commandedDate and usercommand
+	CaseStampReceived  = "received"  // receivedDate and userreceive
+	CaseStampArrived   = "arrived"   // arrivedDate and userarrive
+	CaseStampClosed    = "closed"    // closedDate and userclose
+	// CaseStampReopened clears closedDate and userclose.
+	CaseStampReopened = "reopened"
+)
+
+// CaseStatusNew is the status migration 0026 adds. New cases start in it
+// when their organization has no initial transition.
+const CaseStatusNew = "new"
+
+// CaseStatusTransition allows an organization's cases to move from one
+// case_status to another; Stamp, which may be empty, names the lifecycle
+// fields the move sets. The transition with an empty FromStatusID names the
+// status new cases start in.
+type CaseStatusTransition struct {
+	ID           string    `json:"id"`
+	OrgID        string    `json:"orgId"`
+	FromStatusID string    `json:"fromStatusId"`
+	ToStatusID   string    `json:"toStatusId"`
+	Stamp        string    `json:"stamp"`
+	Active       bool      `json:"active"`
+	CreatedAt    time.Time `json:"createdAt"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+	CreatedBy    string    `json:"createdBy"`
+	UpdatedBy    string    `json:"updatedBy"`
+}
+
+// CaseStatusTransitionInput leaves FromStatusID empty for the organization's
+// initial status, which carries no stamp.
+type CaseStatusTransitionInput struct {
+	FromStatusID string `json:"fromStatusId"`
+	ToStatusID   string `json:"toStatusId" binding:"required"`
+	Stamp        string `json:"stamp"`
+	Active       bool   `json:"active"`
+}
+
+// CaseStatusChange asks for a case to move to StatusID.
+type CaseStatusChange struct {
+	StatusID string `json:"statusId" binding:"required"`
+	Note     string `json:"note"`
+}
+
+// CaseStatusChanged is returned by a status change, with the case as
+// stored afterwards.
+type CaseStatusChanged struct {
+	FromStatusID string `json:"fromStatusId"`
+	ToStatusID   string `json:"toStatusId"`
+	Stamp        string `json:"stamp"`
+	Case         *Case  `json:"case"`
+}
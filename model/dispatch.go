@@ -16,6 +16,7 @@ type WorkflowNode struct {
 
 type CurrentStage struct {
 	CaseId   string      `json:"caseId"`
+	WfId     string      `json:"wfId"`
 	NodeId   string      `json:"nodeId"`
 	Versions string      `json:"versions"`
 	Type     string      `json:"type"`
@@ -49,7 +50,7 @@ type UnitUser struct {
 	LocAlt            float64   `json:"locAlt"`
 	LocBearing        float64   `json:"locBearing"`
 	LocSpeed          float64   `json:"locSpeed"`
-	LocProvider       float64   `json:"locProvider"`
+	LocProvider       string    `json:"locProvider"`
 	LocGpsTime        time.Time `json:"locGpsTime"`
 	LocSatellites     int       `json:"locSatellites"`
 	LocAccuracy       float64   `json:"locAccuracy"`
@@ -61,3 +62,52 @@ type UnitUser struct {
 	CreatedBy         string    `json:"createdBy"`
 	UpdatedBy         string    `json:"updatedBy"`
 }
+
+// Dispatch lifecycle statuses, in order. A dispatch only moves forward;
+// released ends it from any step. The same ids are written into
+// mdm_units.sttId, except that a released unit goes back to UnitAvailable.
+const (
+	DispatchAssigned     = "assigned"
+	DispatchAcknowledged = "acknowledged"
+	DispatchEnRoute      = "en-route"
+	DispatchOnScene      = "on-scene"
+	DispatchCompleted    = "completed"
+	DispatchReleased     = "released"
+
+	UnitAvailable = "available"
+)
+
+// CaseDispatch is one unit sent to a case. Username is the unit's user at
+// the time it was assigned.
+type CaseDispatch struct {
+	ID             string     `json:"id"`
+	OrgID          string     `json:"orgId"`
+	CaseID         string     `json:"caseId"`
+	UnitID         string     `json:"unitId"`
+	Username       string     `json:"username"`
+	Status         string     `json:"status"`
+	AssignedBy     string     `json:"assignedBy"`
+	AssignedAt     time.Time  `json:"assignedAt"`
+	AcknowledgedAt *time.Time `json:"acknowledgedAt"`
+	EnRouteAt      *time.Time `json:"enRouteAt"`
+	OnSceneAt      *time.Time `json:"onSceneAt"`
+	CompletedAt    *time.Time `json:"completedAt"`
+	ReleasedAt     *time.Time `json:"releasedAt"`
+	ReleasedBy     string     `json:"releasedBy"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	UpdatedAt      time.Time  `json:"updatedAt"`
+	CreatedBy      string     `json:"createdBy"`
+	UpdatedBy      string     `json:"updatedBy"`
+}
+
+// DispatchUnits names the units to assign to, or unassign from, a case.
+type DispatchUnits struct {
+	UnitIDs []string `json:"unitIds" binding:"required,min=1,dive,required"`
+	Note    string   `json:"note"`
+}
+
+// DispatchStatusReport is a unit reporting its next lifecycle step.
+type DispatchStatusReport struct {
+	Status string `json:"status" binding:"required"`
+	Note   string `json:"note"`
+}
@@ -0,0 +1,48 @@
+package model
+
+import "time"
+
+// EscalationStep widens the recipients of a notification to RecipientType
+// once it has gone AfterMinutes since it was sent without anyone
+// acknowledging it.
+type EscalationStep struct {
+	AfterMinutes  int    `json:"afterMinutes"`
+	RecipientType string `json:"recipientType"` // commId, deptId or orgId
+}
+
+// EscalationPolicy is the escalation ladder of one eventType in one
+// organization. Steps run in order and their AfterMinutes must increase.
+type EscalationPolicy struct {
+	ID        string           `json:"id"`
+	OrgID     string           `json:"orgId"`
+	EventType string           `json:"eventType"`
+	Steps     []EscalationStep `json:"steps"`
+	Active    bool             `json:"active"`
+	CreatedAt time.Time        `json:"createdAt"`
+	UpdatedAt time.Time        `json:"updatedAt"`
+	CreatedBy string           `json:"createdBy"`
+	UpdatedBy string           `json:"updatedBy"`
+}
+
+type EscalationPolicyInput struct {
+	EventType string           `json:"eventType"`
+	Steps     []EscalationStep `json:"steps"`
+	Active    bool             `json:"active"`
+}
+
+// NotificationEscalation records one escalation step taken on a
+// notification; Level counts from 1.
+type NotificationEscalation struct {
+	Level         int         `json:"level"`
+	RecipientType string      `json:"recipientType"`
+	Added         []Recipient `json:"added"`
+	EscalatedAt   time.Time   `json:"escalatedAt"`
+}
+
+// OrgUnit is a station or a command with the units above it; StnID is empty
+// for a command.
+type OrgUnit struct {
+	DeptID string `json:"deptId"`
+	CommID string `json:"commId"`
+	StnID  string `json:"stnId"`
+}
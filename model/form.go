@@ -77,6 +77,76 @@ type FormUpdate struct {
 	FormFieldJson []map[string]interface{} `json:"formFieldJson"`
 }
 
+// FormVersion is one frozen copy of a form, made by publishing the draft.
+// Versions are numbered from 1.
+type FormVersion struct {
+	ID          string    `json:"id"`
+	OrgID       string    `json:"orgId"`
+	FormID      string    `json:"formId"`
+	Versions    string    `json:"versions"`
+	FormName    string    `json:"formName"`
+	FormColSpan int       `json:"formColSpan"`
+	PublishedAt time.Time `json:"publishedAt"`
+	PublishedBy string    `json:"publishedBy"`
+}
+
+// FormFieldChange is one field that differs between two versions of a form.
+// Change is "added", "removed" or "changed"; Keys lists the changed
+// attributes of a changed field.
+type FormFieldChange struct {
+	Field  string                 `json:"field"`
+	Change string                 `json:"change"`
+	Keys   []string               `json:"keys,omitempty"`
+	Before map[string]interface{} `json:"before,omitempty"`
+	After  map[string]interface{} `json:"after,omitempty"`
+}
+
+type FormDiff struct {
+	FormID  string            `json:"formId"`
+	From    string            `json:"from"`
+	To      string            `json:"to"`
+	Changes []FormFieldChange `json:"changes"`
+}
+
+// FormSubmission is one filled-in copy of a form for a case. Submitting the
+// same form again at the same node adds the next revision. FormVersions is
+// the version of the form the data was checked against.
+type FormSubmission struct {
+	ID           int64                  `json:"id"`
+	OrgID        string                 `json:"orgId"`
+	CaseID       string                 `json:"caseId"`
+	NodeID       string                 `json:"nodeId"`
+	FormID       string                 `json:"formId"`
+	FormVersions string                 `json:"formVersions"`
+	Revision     int                    `json:"revision"`
+	Data         map[string]interface{} `json:"data"`
+	CreatedAt    time.Time              `json:"createdAt"`
+	CreatedBy    string                 `json:"createdBy"`
+}
+
+// FormSubmissionInsert is the body of a submission. NodeID defaults to the
+// case's current node.
+type FormSubmissionInsert struct {
+	NodeID string                 `json:"nodeId"`
+	Data   map[string]interface{} `json:"data" binding:"required"`
+	// FormVersions is filled in by the server.
+	FormVersions string `json:"-"`
+}
+
+type FormSubmissionFilter struct {
+	FormID string
+	NodeID string
+	// Latest keeps only the newest revision per node and form.
+	Latest bool
+}
+
+// FormFieldError reports one field that failed validation.
+type FormFieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
 type WorkFlow struct {
 	Nodes       []map[string]interface{} `json:"nodes"`
 	Connections []map[string]interface{} `json:"connections"`
@@ -138,6 +208,36 @@ type WorkflowModel struct {
 	UpdatedBy string    `json:"updatedBy"`
 }
 
+// WorkflowVersion is one frozen copy of a workflow, made by publishing the
+// draft. Versions are numbered from 1.
+type WorkflowVersion struct {
+	ID          string    `json:"id"`
+	OrgID       string    `json:"orgId"`
+	WfID        string    `json:"wfId"`
+	Versions    string    `json:"versions"`
+	Title       string    `json:"title"`
+	Desc        string    `json:"desc"`
+	PublishedAt time.Time `json:"publishedAt"`
+	PublishedBy string    `json:"publishedBy"`
+}
+
+// WorkflowIssue is one finding of the graph validator. NodeID or EdgeID
+// points at the part of the graph it is about.
+type WorkflowIssue struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	NodeID  string `json:"nodeId,omitempty"`
+	EdgeID  string `json:"edgeId,omitempty"`
+}
+
+// WorkflowValidation is the validator's report. Errors block publishing,
+// warnings do not.
+type WorkflowValidation struct {
+	Valid    bool            `json:"valid"`
+	Errors   []WorkflowIssue `json:"errors"`
+	Warnings []WorkflowIssue `json:"warnings"`
+}
+
 type WfNode struct {
 	ID        string                 `json:"id" db:"id"`
 	OrgID     string                 `json:"orgId" db:"orgId"`
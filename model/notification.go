@@ -2,22 +2,23 @@ package model
 
 import (
 	"time"
-
-	"github.com/gorilla/websocket"
 )
 
 type UserConnectionInfo struct {
-	Conn        *websocket.Conn `json:"-"`
-	ID          string          `json:"id"`
-	EmpID       string          `json:"empId"`
-	RoleID      string          `json:"roleId"`
-	OrgID       string          `json:"orgID"`
-	StnID       string          `json:"stnID"`
-	DeptID      string          `json:"deptId"`
-	CommID      string          `json:"commId"`
-	Username    string          `json:"username"`
-	GrpID       []string        `json:"grpId"`
-	DistIdLists []string        `json:"distIdLists"`
+	ID          string    `json:"id"`
+	EmpID       string    `json:"empId"`
+	RoleID      string    `json:"roleId"`
+	OrgID       string    `json:"orgID"`
+	StnID       string    `json:"stnID"`
+	DeptID      string    `json:"deptId"`
+	CommID      string    `json:"commId"`
+	Username    string    `json:"username"`
+	GrpID       []string  `json:"grpId"`
+	DistIdLists []string  `json:"distIdLists"`
+	SessionID   string    `json:"sessionId"`
+	UserAgent   string    `json:"userAgent"`
+	ClientIP    string    `json:"clientIp"`
+	ConnectedAt time.Time `json:"connectedAt"`
 }
 type RegistrationMessage struct {
 	OrgID    string `json:"orgId"`    // องค์กร
@@ -28,6 +29,8 @@ type RegistrationMessage struct {
 	CommID   string `json:"commId"`   // หน่วยงานย่อย/สายงาน
 	StnID    string `json:"stnId"`    // สถานี/สาขา
 	GrpID    string `json:"grpId"`    // กลุ่มผู้ใช้
+	Type     string `json:"type"`     // "auth" เมื่อส่ง token มาใน frame
+	Token    string `json:"token"`    // access token
 }
 
 // Notification คือข้อมูลการแจ้งเตือนหลัก
@@ -45,6 +48,68 @@ type Notification struct {
 	ExpiredAt   time.Time   `json:"expiredAt"` // เพิ่มใหม่
 	Data        []Data      `json:"data"`
 	Recipients  []Recipient `json:"recipients"` // ใช้ตอนสร้างเท่านั้น
+	// Escalations lists the escalation steps taken, oldest first.
+	Escalations []NotificationEscalation `json:"escalations,omitempty"`
+	// State is what the listing user has done with the notification.
+	State *NotificationState `json:"state,omitempty"`
+}
+
+// Per-recipient notification states; each stamps its own timestamp.
+const (
+	NotificationDelivered    = "delivered"
+	NotificationRead         = "read"
+	NotificationAcknowledged = "acknowledged"
+	NotificationDismissed    = "dismissed"
+)
+
+// NotificationState holds when one recipient received, read, acknowledged
+// and dismissed a notification.
+type NotificationState struct {
+	DeliveredAt    *time.Time `json:"deliveredAt"`
+	ReadAt         *time.Time `json:"readAt"`
+	AcknowledgedAt *time.Time `json:"acknowledgedAt"`
+	DismissedAt    *time.Time `json:"dismissedAt"`
+}
+
+// Unread reports whether the notification still counts towards the badge.
+func (s *NotificationState) Unread() bool {
+	return s == nil || (s.ReadAt == nil && s.DismissedAt == nil)
+}
+
+// NotificationUnread filters an inbox to what Unread reports; it is not a
+// stamp of its own.
+const NotificationUnread = "unread"
+
+// NotificationInboxFilter narrows a user's inbox. The zero value lists it
+// all.
+type NotificationInboxFilter struct {
+	EventType string
+	From, To  *time.Time // createdAt in [From, To)
+	State     string     // unread, read, acknowledged or dismissed
+	// Before continues a listing after the last row of the previous page.
+	Before *NotificationCursor
+	Limit  int
+}
+
+// NotificationCursor is a position in the inbox order, createdAt then id,
+// both descending.
+type NotificationCursor struct {
+	CreatedAt time.Time
+	ID        int64
+}
+
+// NotificationPage is one page of an inbox; NextCursor is empty on the last
+// page.
+type NotificationPage struct {
+	Items      []Notification `json:"items"`
+	NextCursor string         `json:"nextCursor"`
+}
+
+// NotificationReadInput marks several notifications read, or all of the
+// caller's when All is set.
+type NotificationReadInput struct {
+	IDs []int64 `json:"ids"`
+	All bool    `json:"all"`
 }
 
 // Recipient คือเป้าหมายผู้รับ
@@ -72,3 +137,31 @@ type NotificationCreateRequest struct {
 	Data        []Data      `json:"data"`
 	Recipients  []Recipient `json:"recipients"`
 }
+
+// Outbox entry states.
+const (
+	OutboxPending   = "pending"
+	OutboxDelivered = "delivered"
+	OutboxDead      = "dead"
+)
+
+// OutboxEntry is one delivery owed for a notification on one channel.
+type OutboxEntry struct {
+	ID             int64      `json:"id"`
+	OrgID          string     `json:"orgId"`
+	NotificationID int64      `json:"notificationId"`
+	Channel        string     `json:"channel"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	NextAttemptAt  time.Time  `json:"nextAttemptAt"`
+	LastError      *string    `json:"lastError"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	DeliveredAt    *time.Time `json:"deliveredAt"`
+}
+
+// OutboxFilter narrows an outbox listing; an empty Status lists every state.
+type OutboxFilter struct {
+	Status string
+	Limit  int
+	Offset int
+}
@@ -25,3 +25,12 @@ type PermissionUpdate struct {
 	PermName  string `json:"permName"`
 	Active    bool   `json:"active"`
 }
+
+// PermissionEntry describes one permission the API checks. The catalogue is
+// seeded into um_permissions; roles are granted entries through
+// um_role_with_permissions.
+type PermissionEntry struct {
+	PermID    string `json:"permId"`
+	GroupName string `json:"groupName"`
+	PermName  string `json:"permName"`
+}
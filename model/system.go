@@ -0,0 +1,29 @@
+package model
+
+type DBPoolStats struct {
+	MaxConns                int32 `json:"maxConns"`
+	TotalConns              int32 `json:"totalConns"`
+	AcquiredConns           int32 `json:"acquiredConns"`
+	IdleConns               int32 `json:"idleConns"`
+	ConstructingConns       int32 `json:"constructingConns"`
+	AcquireCount            int64 `json:"acquireCount"`
+	AcquireDurationMs       int64 `json:"acquireDurationMs"`
+	EmptyAcquireCount       int64 `json:"emptyAcquireCount"`
+	CanceledAcquireCount    int64 `json:"canceledAcquireCount"`
+	NewConnsCount           int64 `json:"newConnsCount"`
+	MaxLifetimeDestroyCount int64 `json:"maxLifetimeDestroyCount"`
+	MaxIdleDestroyCount     int64 `json:"maxIdleDestroyCount"`
+}
+
+// WsHubStats describes the notification sockets of one instance.
+type WsHubStats struct {
+	Sessions           int   `json:"sessions"`
+	Users              int   `json:"users"`
+	QueueCapacity      int   `json:"queueCapacity"`
+	QueueDepth         int   `json:"queueDepth"`
+	MaxQueueDepth      int   `json:"maxQueueDepth"`
+	Sent               int64 `json:"sent"`
+	Dropped            int64 `json:"dropped"`
+	SlowConsumerClosed int64 `json:"slowConsumerClosed"`
+	WriteFailed        int64 `json:"writeFailed"`
+}
@@ -145,7 +145,42 @@ type UserUpdate struct {
 	LastLogin             *time.Time `json:"lastLogin"`
 }
 
+// UserWrite is the stored form of a user used by create and update; the
+// request types are converted into it after the password is encrypted.
+type UserWrite struct {
+	OrgID                 string
+	DisplayName           string
+	Title                 string
+	FirstName             string
+	MiddleName            string
+	LastName              string
+	CitizenID             string
+	Bod                   *time.Time
+	Blood                 string
+	Gender                *int64
+	MobileNo              string
+	Address               string
+	Photo                 *string
+	Username              string
+	Password              string
+	Email                 string
+	RoleID                string
+	UserType              *int64
+	EmpID                 string
+	DeptID                string
+	CommID                string
+	StnID                 string
+	Active                bool
+	ActivationToken       *string
+	LastActivationRequest *int64
+	LostPasswordRequest   *int64
+	SignupStamp           *int64
+	IsLogin               bool
+	LastLogin             *time.Time
+}
+
 type UserContact struct {
+	ID           int64     `json:"id"`
 	OrgID        string    `json:"orgId"`
 	Username     string    `json:"username"`
 	ContactName  string    `json:"contactName"`
@@ -158,6 +193,7 @@ type UserContact struct {
 }
 
 type UserSkill struct {
+	ID        int64     `json:"id"`
 	OrgID     string    `json:"orgId"`
 	UserName  string    `json:"userName"`
 	SkillID   string    `json:"skillId"`
@@ -169,6 +205,7 @@ type UserSkill struct {
 }
 
 type UserSocial struct {
+	ID         int64     `json:"id"`
 	OrgID      string    `json:"orgId"`
 	Username   string    `json:"username"`
 	SocialType string    `json:"socialType"`
@@ -247,3 +284,13 @@ type UserProfile struct {
 	CommID string `json:"commId"`
 	GrpID  string `json:"grpId"`
 }
+
+// UserCredential is the stored password of one account, for the credential
+// report.
+type UserCredential struct {
+	ID       string
+	OrgID    string
+	Username string
+	Active   bool
+	Password string
+}
@@ -0,0 +1,429 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"mainPackage/model"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// errDuplicate mirrors a unique constraint violation in the in-memory store.
+var errDuplicate = errors.New("duplicate key value")
+
+// Memory is a Store kept in process memory. It mirrors the Postgres
+// repositories closely enough for tests and local runs; the Add* methods seed
+// the lookup tables the repositories read but do not write.
+type Memory struct {
+	*Store
+	db *memDB
+}
+
+// NewMemory returns an empty in-memory Store.
+func NewMemory() *Memory {
+	db := &memDB{state: memState{orgs: map[string]string{}}}
+	return &Memory{Store: db.store(), db: db}
+}
+
+type memDB struct {
+	mu    sync.Mutex
+	state memState
+}
+
+type memState struct {
+	seq int64
+
+	cases        []model.Case
+	stages       []memStage
+	history      []model.CaseHistory
+	stageHistory []model.CaseStageHistory
+	wfDefs       []model.WorkflowModel
+	wfNodes      []memWfNode
+	wfVersions   []model.WorkflowVersion
+	forms        []memForm
+	elements     []memElement
+	formVersions []model.FormVersion
+	submissions  []model.FormSubmission
+	notis        []model.Notification
+	outbox       []model.OutboxEntry
+	notiStates   []memNotiState
+	policies     []model.EscalationPolicy
+	transitions  []model.CaseStatusTransition
+	dispatches   []model.CaseDispatch
+	audits       []model.AuditLog
+	auditStart   int
+	units        []model.MmdUnit
+	props        []model.MdmUnitProperty
+	users        []model.Um_User
+	tokens       []model.RefreshTokenRecord
+
+	orgs         map[string]string
+	roles        []model.Role
+	grants       []model.RolePermission
+	perms        []model.Permission
+	groups       []memGroup
+	skills       []model.Skill
+	userSkills   []model.UserSkill
+	contacts     []model.UserContact
+	socials      []model.UserSocial
+	umGroups     []model.UmGroup
+	areas        []memArea
+	districts    []memDistrict
+	caseTypes    []model.CaseType
+	subTypes     []model.CaseSubType
+	statuses     []model.CaseStatus
+	customers    []model.Customer
+	custSocials  []model.CustomerSocial
+	custContacts []model.CustomerContact
+	mdmProps     []model.MmdProperty
+	unitSources  []model.MmdUnitSource
+	unitTypes    []model.MmdUnitType
+	companies    []model.MmdCompanies
+	unitStatuses []model.MmdUnitStatus
+	sessions     []memSession
+	presence     []model.UserConnectionInfo
+	devices      []model.DeviceIoT
+	depts        []model.Department
+	commands     []model.Command
+	stations     []model.Station
+}
+
+type memStage struct {
+	OrgID string
+	model.CurrentStage
+}
+
+type memWfNode struct {
+	model.WfNode
+	// raw holds the decoded data column; for the connections row it is the
+	// whole edge list rather than a single object.
+	raw interface{}
+}
+
+type memForm struct {
+	OrgID string
+	model.FormsManager
+}
+
+type memElement struct {
+	OrgID, FormID, Versions string
+	Data                    map[string]interface{}
+}
+
+type memNotiState struct {
+	OrgID, Username string
+	NotificationID  int64
+	model.NotificationState
+}
+
+type memGroup struct{ OrgID, Username, GrpID string }
+
+type memArea struct {
+	OrgID, Username string
+	DistIDs         []string
+}
+
+type memDistrict struct{ OrgID, DistID, ProvID, En, Th string }
+
+type memSession struct {
+	SessionID, EmpID, InstanceID string
+	SeenAt                       time.Time
+}
+
+func (s memState) clone() memState {
+	c := s
+	c.cases = append([]model.Case(nil), s.cases...)
+	c.stages = append([]memStage(nil), s.stages...)
+	c.history = append([]model.CaseHistory(nil), s.history...)
+	c.stageHistory = append([]model.CaseStageHistory(nil), s.stageHistory...)
+	c.wfDefs = append([]model.WorkflowModel(nil), s.wfDefs...)
+	c.wfNodes = append([]memWfNode(nil), s.wfNodes...)
+	c.wfVersions = append([]model.WorkflowVersion(nil), s.wfVersions...)
+	c.forms = append([]memForm(nil), s.forms...)
+	c.elements = append([]memElement(nil), s.elements...)
+	c.formVersions = append([]model.FormVersion(nil), s.formVersions...)
+	c.submissions = append([]model.FormSubmission(nil), s.submissions...)
+	c.notis = append([]model.Notification(nil), s.notis...)
+	c.outbox = append([]model.OutboxEntry(nil), s.outbox...)
+	c.notiStates = append([]memNotiState(nil), s.notiStates...)
+	c.policies = append([]model.EscalationPolicy(nil), s.policies...)
+	c.transitions = append([]model.CaseStatusTransition(nil), s.transitions...)
+	c.dispatches = append([]model.CaseDispatch(nil), s.dispatches...)
+	c.audits = append([]model.AuditLog(nil), s.audits...)
+	c.units = append([]model.MmdUnit(nil), s.units...)
+	c.props = append([]model.MdmUnitProperty(nil), s.props...)
+	c.users = append([]model.Um_User(nil), s.users...)
+	c.tokens = append([]model.RefreshTokenRecord(nil), s.tokens...)
+	c.orgs = make(map[string]string, len(s.orgs))
+	for k, v := range s.orgs {
+		c.orgs[k] = v
+	}
+	c.roles = append([]model.Role(nil), s.roles...)
+	c.grants = append([]model.RolePermission(nil), s.grants...)
+	c.perms = append([]model.Permission(nil), s.perms...)
+	c.groups = append([]memGroup(nil), s.groups...)
+	c.skills = append([]model.Skill(nil), s.skills...)
+	c.userSkills = append([]model.UserSkill(nil), s.userSkills...)
+	c.contacts = append([]model.UserContact(nil), s.contacts...)
+	c.socials = append([]model.UserSocial(nil), s.socials...)
+	c.umGroups = append([]model.UmGroup(nil), s.umGroups...)
+	c.areas = append([]memArea(nil), s.areas...)
+	c.districts = append([]memDistrict(nil), s.districts...)
+	c.caseTypes = append([]model.CaseType(nil), s.caseTypes...)
+	c.subTypes = append([]model.CaseSubType(nil), s.subTypes...)
+	c.statuses = append([]model.CaseStatus(nil), s.statuses...)
+	c.customers = append([]model.Customer(nil), s.customers...)
+	c.custSocials = append([]model.CustomerSocial(nil), s.custSocials...)
+	c.custContacts = append([]model.CustomerContact(nil), s.custContacts...)
+	c.mdmProps = append([]model.MmdProperty(nil), s.mdmProps...)
+	c.unitSources = append([]model.MmdUnitSource(nil), s.unitSources...)
+	c.unitTypes = append([]model.MmdUnitType(nil), s.unitTypes...)
+	c.companies = append([]model.MmdCompanies(nil), s.companies...)
+	c.unitStatuses = append([]model.MmdUnitStatus(nil), s.unitStatuses...)
+	c.sessions = append([]memSession(nil), s.sessions...)
+	c.presence = append([]model.UserConnectionInfo(nil), s.presence...)
+	c.devices = append([]model.DeviceIoT(nil), s.devices...)
+	c.depts = append([]model.Department(nil), s.depts...)
+	c.commands = append([]model.Command(nil), s.commands...)
+	c.stations = append([]model.Station(nil), s.stations...)
+	return c
+}
+
+// nextID hands out ids for every table from one sequence.
+func (s *memState) nextID() string {
+	s.seq++
+	return strconv.FormatInt(s.seq, 10)
+}
+
+func (db *memDB) store() *Store {
+	return &Store{
+		Cases:         &memCases{db: db},
+		Workflows:     &memWorkflows{db: db},
+		Forms:         &memForms{db: db},
+		Notifications: &memNotifications{db: db},
+		Units:         &memUnits{db: db},
+		Users:         &memUsers{db: db},
+		Tokens:        &memTokens{db: db},
+		Outbox:        &memOutbox{db: db},
+		Escalations:   &memEscalations{db: db},
+		Transitions:   &memTransitions{db: db},
+		Dispatches:    &memDispatches{db: db},
+		Audit:         &memAudit{db: db},
+		Org:           &memOrg{db: db},
+		Roles:         &memRoles{db: db},
+		Skills:        &memSkills{db: db},
+		Profiles:      &memProfiles{db: db},
+		CaseTypes:     &memCaseTypes{db: db},
+		Customers:     &memCustomers{db: db},
+		MasterData:    &memMasterData{db: db},
+		Sessions:      &memSessions{db: db},
+		Areas:         &memAreas{db: db},
+		Devices:       &memDevices{db: db},
+		inTx:          db.inTx,
+	}
+}
+
+// inTx runs fn against a copy of the data and keeps the copy only when fn
+// succeeds. The store stays locked until fn returns, so fn must go through
+// the Store it is handed rather than the outer one.
+func (db *memDB) inTx(ctx context.Context, fn func(tx *Store) error) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	tx := &memDB{state: db.state.clone()}
+	if err := fn(tx.store()); err != nil {
+		return err
+	}
+	db.state = tx.state
+	return nil
+}
+
+func (db *memDB) lock() *memState {
+	db.mu.Lock()
+	return &db.state
+}
+
+func (db *memDB) unlock() {
+	db.mu.Unlock()
+}
+
+// AddOrganization seeds an organizations row.
+func (m *Memory) AddOrganization(id, name string) {
+	s := m.db.lock()
+	defer m.db.unlock()
+	s.orgs[id] = name
+}
+
+// AddRole seeds a um_roles row together with its active permissions.
+func (m *Memory) AddRole(orgId, roleId, roleName string, permIds ...string) {
+	s := m.db.lock()
+	defer m.db.unlock()
+	s.roles = append(s.roles, model.Role{ID: roleId, OrgID: orgId, RoleName: roleName, Active: true})
+	for _, permId := range permIds {
+		id, _ := strconv.ParseInt(s.nextID(), 10, 64)
+		s.grants = append(s.grants, model.RolePermission{ID: id, OrgID: orgId, RoleID: roleId, PermID: permId, Active: true})
+	}
+}
+
+// AddUserGroup seeds a um_user_with_groups row.
+func (m *Memory) AddUserGroup(orgId, username, grpId string) {
+	s := m.db.lock()
+	defer m.db.unlock()
+	s.groups = append(s.groups, memGroup{OrgID: orgId, Username: username, GrpID: grpId})
+}
+
+// AddUserSkill seeds an active skill for a user, adding the skill to the
+// catalogue when it is not there yet.
+func (m *Memory) AddUserSkill(orgId, username, skillId, en, th string) {
+	s := m.db.lock()
+	defer m.db.unlock()
+	if index(s.skills, func(sk model.Skill) bool { return sk.OrgID == orgId && sk.SkillID == skillId }) < 0 {
+		s.skills = append(s.skills, model.Skill{ID: s.nextID(), OrgID: orgId, SkillID: skillId, En: en, Th: th, Active: true})
+	}
+	id, _ := strconv.ParseInt(s.nextID(), 10, 64)
+	s.userSkills = append(s.userSkills, model.UserSkill{ID: id, OrgID: orgId, UserName: username, SkillID: skillId, Active: true})
+}
+
+// AddUserArea seeds the districts a user responds to.
+func (m *Memory) AddUserArea(orgId, username string, distIds ...string) {
+	s := m.db.lock()
+	defer m.db.unlock()
+	s.areas = append(s.areas, memArea{OrgID: orgId, Username: username, DistIDs: distIds})
+}
+
+// AddDistrict seeds an area_districts row.
+func (m *Memory) AddDistrict(orgId, distId, provId, en, th string) {
+	s := m.db.lock()
+	defer m.db.unlock()
+	s.districts = append(s.districts, memDistrict{OrgID: orgId, DistID: distId, ProvID: provId, En: en, Th: th})
+}
+
+// AddDevice seeds a device_iot row.
+func (m *Memory) AddDevice(d model.DeviceIoT) {
+	s := m.db.lock()
+	defer m.db.unlock()
+	s.devices = append(s.devices, d)
+}
+
+// AddStation seeds a sec_stations row.
+func (m *Memory) AddStation(orgId, deptId, commId, stnId string) {
+	s := m.db.lock()
+	defer m.db.unlock()
+	now := time.Now()
+	s.stations = append(s.stations, model.Station{ID: s.nextID(), OrgID: orgId, DeptID: deptId, CommID: commId,
+		StnID: stnId, Active: true, CreatedAt: now, UpdatedAt: now})
+}
+
+// AddCommand seeds a sec_commands row.
+func (m *Memory) AddCommand(orgId, deptId, commId string) {
+	s := m.db.lock()
+	defer m.db.unlock()
+	now := time.Now()
+	s.commands = append(s.commands, model.Command{ID: s.nextID(), OrgID: orgId, DeptID: deptId, CommID: commId,
+		Active: true, CreatedAt: now, UpdatedAt: now})
+}
+
+// AddCaseSubType seeds a case_sub_types row.
+func (m *Memory) AddCaseSubType(st model.CaseSubType) {
+	s := m.db.lock()
+	defer m.db.unlock()
+	s.subTypes = append(s.subTypes, st)
+}
+
+// AddUnitProperty attaches an active property to a unit.
+func (m *Memory) AddUnitProperty(orgId, unitId, propId string) {
+	s := m.db.lock()
+	defer m.db.unlock()
+	now := time.Now()
+	s.props = append(s.props, model.MdmUnitProperty{ID: s.nextID(), OrgID: orgId, UnitID: unitId,
+		PropID: propId, Active: true, CreatedAt: now, UpdatedAt: now})
+}
+
+// AddLegacyAudit seeds audit_logs rows from before the hash chain and moves
+// the chain start past them.
+func (m *Memory) AddLegacyAudit(entries ...model.AuditLog) {
+	s := m.db.lock()
+	defer m.db.unlock()
+	for _, e := range entries {
+		e.ID, _ = strconv.Atoi(s.nextID())
+		e.PrevHash, e.Hash = "", ""
+		s.audits = append(s.audits, e)
+		s.auditStart = e.ID
+	}
+}
+
+// roundTrip passes v through JSON the way a jsonb column would.
+func roundTrip(v interface{}) (interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var out interface{}
+	err = json.Unmarshal(b, &out)
+	return out, err
+}
+
+// page applies LIMIT/OFFSET to n items and returns the bounds.
+func page(n, limit, offset int) (int, int) {
+	if offset > n {
+		offset = n
+	}
+	end := offset + limit
+	if limit < 0 || end > n {
+		end = n
+	}
+	return offset, end
+}
+
+// index returns the position of the first of list's rows that match, or -1.
+func index[T any](list []T, match func(T) bool) int {
+	for i, v := range list {
+		if match(v) {
+			return i
+		}
+	}
+	return -1
+}
+
+// pageOf returns the rows of list that keep selects, in id order, paged
+// like LIMIT/OFFSET.
+func pageOf[T any](list []T, keep func(T) bool, id func(T) string, limit, offset int) []T {
+	var out []T
+	for _, v := range list {
+		if keep(v) {
+			out = append(out, v)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return idLess(id(out[i]), id(out[j])) })
+	start, end := page(len(out), limit, offset)
+	return out[start:end]
+}
+
+// idLess orders the numeric ids nextID hands out.
+func idLess(a, b string) bool {
+	if len(a) != len(b) {
+		return len(a) < len(b)
+	}
+	return a < b
+}
+
+// inList reports whether value is one of the comma separated entries of
+// list, matching how recipients store several ids in one value.
+func inList(list, value string) bool {
+	for _, v := range strings.Split(list, ",") {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func deref(p *string) string {
+	if p == nil {
+		return ""
+	}
+	return *p
+}
@@ -0,0 +1,39 @@
+package store
+
+import (
+	"context"
+	"mainPackage/model"
+	"slices"
+)
+
+type memAreas struct {
+	db *memDB
+}
+
+// ListDistricts returns the seeded districts of orgId. The memory store keeps
+// no provinces or countries, so their columns are left nil.
+func (r *memAreas) ListDistricts(ctx context.Context, orgId string) ([]model.AreaDistrictWithDetails, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	var out []model.AreaDistrictWithDetails
+	for _, d := range s.districts {
+		if d.OrgID != orgId {
+			continue
+		}
+		d, active := d, true
+		out = append(out, model.AreaDistrictWithDetails{OrgID: &d.OrgID, ProvID: &d.ProvID, DistID: &d.DistID,
+			DistrictEn: &d.En, DistrictTh: &d.Th, DistrictActive: &active})
+	}
+	return out, nil
+}
+
+func (r *memAreas) InProvince(ctx context.Context, orgId, provId string, distIds []string) (bool, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	for _, d := range s.districts {
+		if d.OrgID == orgId && d.ProvID == provId && slices.Contains(distIds, d.DistID) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
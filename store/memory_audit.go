@@ -0,0 +1,93 @@
+package store
+
+import (
+	"context"
+	"mainPackage/model"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type memAudit struct {
+	db *memDB
+}
+
+func (r *memAudit) Insert(ctx context.Context, entries []model.AuditLog) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	for _, e := range entries {
+		e.ID, _ = strconv.Atoi(s.nextID())
+		e.CreatedAt = e.CreatedAt.Truncate(time.Microsecond)
+		e.PrevHash = ""
+		for i := len(s.audits) - 1; i >= 0; i-- {
+			if s.audits[i].OrgID == e.OrgID {
+				e.PrevHash = s.audits[i].Hash
+				break
+			}
+		}
+		e.Hash = e.ChainHash()
+		s.audits = append(s.audits, e)
+	}
+	return nil
+}
+
+func (r *memAudit) ListChain(ctx context.Context, orgId string, afterID, limit int) ([]model.AuditLog, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	var list []model.AuditLog
+	for _, e := range s.audits {
+		if e.OrgID == orgId && e.ID > afterID && len(list) < limit {
+			list = append(list, e)
+		}
+	}
+	return list, nil
+}
+
+func (r *memAudit) ChainOrgs(ctx context.Context) ([]string, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	seen := make(map[string]bool)
+	var orgs []string
+	for _, e := range s.audits {
+		if !seen[e.OrgID] {
+			seen[e.OrgID] = true
+			orgs = append(orgs, e.OrgID)
+		}
+	}
+	sort.Strings(orgs)
+	return orgs, nil
+}
+
+func (r *memAudit) ChainStart(ctx context.Context) (int, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	return s.auditStart, nil
+}
+
+func (r *memAudit) Search(ctx context.Context, orgId string, f model.AuditLogFilter) ([]model.AuditLog, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	q := strings.ToLower(f.Query)
+	var list []model.AuditLog
+	for i := len(s.audits) - 1; i >= 0; i-- {
+		e := s.audits[i]
+		switch {
+		case e.OrgID != orgId,
+			f.From != nil && e.CreatedAt.Before(*f.From),
+			f.To != nil && !e.CreatedAt.Before(*f.To),
+			f.Username != "" && e.Username != f.Username,
+			f.MainFunc != "" && e.MainFunc != f.MainFunc,
+			f.SubFunc != "" && e.SubFunc != f.SubFunc,
+			f.Action != "" && e.Action != f.Action,
+			f.EntityID != "" && e.EntityID != f.EntityID,
+			f.Status != nil && e.Status != *f.Status,
+			q != "" && !strings.Contains(strings.ToLower(e.Message+"\x00"+e.NewData+"\x00"+e.OldData+"\x00"+e.ResData), q),
+			f.BeforeID > 0 && e.ID >= f.BeforeID:
+			continue
+		}
+		list = append(list, e)
+	}
+	start, end := page(len(list), f.Limit, f.Offset)
+	return list[start:end], nil
+}
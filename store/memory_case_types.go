@@ -0,0 +1,192 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"mainPackage/model"
+	"time"
+)
+
+type memCaseTypes struct {
+	db *memDB
+}
+
+func (r *memCaseTypes) List(ctx context.Context, orgId string, limit, offset int) ([]model.CaseType, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	return pageOf(s.caseTypes, func(t model.CaseType) bool { return t.OrgId == orgId },
+		func(t model.CaseType) string { return t.Id }, limit, offset), nil
+}
+
+func (r *memCaseTypes) ListWithSubTypes(ctx context.Context, orgId string) ([]model.CaseTypeWithSubType, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	var list []model.CaseTypeWithSubType
+	for _, t := range pageOf(s.caseTypes, func(t model.CaseType) bool { return t.OrgId == orgId },
+		func(t model.CaseType) string { return t.Id }, len(s.caseTypes), 0) {
+		row := model.CaseTypeWithSubType{TypeID: t.TypeId, OrgID: t.OrgId, TypeActive: t.Active}
+		if t.En != nil {
+			row.TypeEN = *t.En
+		}
+		if t.Th != nil {
+			row.TypeTH = *t.Th
+		}
+		joined := false
+		for _, st := range s.subTypes {
+			if st.OrgID != orgId || st.TypeID != t.TypeId {
+				continue
+			}
+			st := st
+			row := row
+			row.SubTypeID, row.SubTypeCode, row.SubTypeEN, row.SubTypeTH = &st.STypeID, &st.STypeCode, &st.EN, &st.TH
+			row.WfID, row.CaseSla, row.Priority = &st.WFID, &st.CaseSLA, atoiPtr(&st.Priority)
+			row.UserSkillList, row.UnitPropLists, row.SubTypeActive = st.UserSkillList, st.UnitPropLists, &st.Active
+			list = append(list, row)
+			joined = true
+		}
+		if !joined {
+			list = append(list, row)
+		}
+	}
+	return list, nil
+}
+
+func (r *memCaseTypes) Create(ctx context.Context, orgId, typeId, username string, in model.CaseTypeInsert) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	if index(s.caseTypes, func(t model.CaseType) bool { return t.OrgId == orgId && t.TypeId == typeId }) >= 0 {
+		return fmt.Errorf("case type %s: %w", typeId, errDuplicate)
+	}
+	now := time.Now()
+	s.caseTypes = append(s.caseTypes, model.CaseType{Id: s.nextID(), TypeId: typeId, OrgId: orgId, En: in.En, Th: in.Th,
+		Active: in.Active, CreatedAt: now, UpdatedAt: now, CreatedBy: &username, UpdatedBy: &username})
+	return nil
+}
+
+func (r *memCaseTypes) Update(ctx context.Context, orgId, id, username string, in model.CaseTypeUpdate) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := index(s.caseTypes, func(t model.CaseType) bool { return t.OrgId == orgId && t.Id == id })
+	if i < 0 {
+		return ErrNotFound
+	}
+	t := &s.caseTypes[i]
+	t.En, t.Th, t.Active, t.UpdatedAt, t.UpdatedBy = in.En, in.Th, in.Active, time.Now(), &username
+	return nil
+}
+
+func (r *memCaseTypes) Delete(ctx context.Context, orgId, id string) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := index(s.caseTypes, func(t model.CaseType) bool { return t.OrgId == orgId && t.Id == id })
+	if i < 0 {
+		return ErrNotFound
+	}
+	s.caseTypes = append(s.caseTypes[:i:i], s.caseTypes[i+1:]...)
+	return nil
+}
+
+func (r *memCaseTypes) ListSubTypes(ctx context.Context, orgId string, limit, offset int) ([]model.CaseSubType, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	return pageOf(s.subTypes, func(st model.CaseSubType) bool { return st.OrgID == orgId },
+		func(st model.CaseSubType) string { return st.Id }, limit, offset), nil
+}
+
+func (r *memCaseTypes) CreateSubType(ctx context.Context, orgId, sTypeId, username string, in model.CaseSubTypeInsert) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	if index(s.subTypes, func(st model.CaseSubType) bool { return st.OrgID == orgId && st.STypeID == sTypeId }) >= 0 {
+		return fmt.Errorf("case sub type %s: %w", sTypeId, errDuplicate)
+	}
+	now := time.Now()
+	s.subTypes = append(s.subTypes, model.CaseSubType{Id: s.nextID(), TypeID: in.TypeID, STypeID: sTypeId,
+		STypeCode: in.STypeCode, OrgID: orgId, EN: in.EN, TH: in.TH, WFID: in.WFID, CaseSLA: in.CaseSLA,
+		Priority: in.Priority, UserSkillList: jsonList(in.UserSkillList), UnitPropLists: jsonList(in.UnitPropLists),
+		Active: in.Active, CreatedAt: now, UpdatedAt: now, CreatedBy: username, UpdatedBy: username})
+	return nil
+}
+
+func (r *memCaseTypes) UpdateSubType(ctx context.Context, orgId, id, username string, in model.CaseSubTypeUpdate) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := index(s.subTypes, func(st model.CaseSubType) bool { return st.OrgID == orgId && st.Id == id })
+	if i < 0 {
+		return ErrNotFound
+	}
+	st := &s.subTypes[i]
+	st.STypeCode, st.EN, st.TH, st.WFID, st.CaseSLA, st.Priority = in.STypeCode, in.EN, in.TH, in.WFID, in.CaseSLA, in.Priority
+	st.UserSkillList, st.UnitPropLists = jsonList(in.UserSkillList), jsonList(in.UnitPropLists)
+	st.Active, st.UpdatedAt, st.UpdatedBy = in.Active, time.Now(), username
+	return nil
+}
+
+func (r *memCaseTypes) DeleteSubType(ctx context.Context, orgId, id string) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := index(s.subTypes, func(st model.CaseSubType) bool { return st.OrgID == orgId && st.Id == id })
+	if i < 0 {
+		return ErrNotFound
+	}
+	s.subTypes = append(s.subTypes[:i:i], s.subTypes[i+1:]...)
+	return nil
+}
+
+func caseStatusID(cs model.CaseStatus) string {
+	if cs.ID == nil {
+		return ""
+	}
+	return *cs.ID
+}
+
+func (r *memCaseTypes) ListStatuses(ctx context.Context, limit, offset int) ([]model.CaseStatus, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	return pageOf(s.statuses, func(cs model.CaseStatus) bool { return true }, caseStatusID, limit, offset), nil
+}
+
+func (r *memCaseTypes) GetStatus(ctx context.Context, id string) (*model.CaseStatus, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := index(s.statuses, func(cs model.CaseStatus) bool { return caseStatusID(cs) == id })
+	if i < 0 {
+		return nil, ErrNotFound
+	}
+	cs := s.statuses[i]
+	return &cs, nil
+}
+
+func (r *memCaseTypes) CreateStatus(ctx context.Context, statusId, username string, in model.CaseStatusInsert) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	if index(s.statuses, func(cs model.CaseStatus) bool { return cs.StatusID != nil && *cs.StatusID == statusId }) >= 0 {
+		return fmt.Errorf("case status %s: %w", statusId, errDuplicate)
+	}
+	id, now := s.nextID(), time.Now()
+	s.statuses = append(s.statuses, model.CaseStatus{ID: &id, StatusID: &statusId, Th: in.Th, En: in.En,
+		Color: in.Color, Active: in.Active, CreatedAt: now, UpdatedAt: now, CreatedBy: &username, UpdatedBy: &username})
+	return nil
+}
+
+func (r *memCaseTypes) UpdateStatus(ctx context.Context, id, username string, in model.CaseStatusUpdate) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := index(s.statuses, func(cs model.CaseStatus) bool { return caseStatusID(cs) == id })
+	if i < 0 {
+		return ErrNotFound
+	}
+	cs := &s.statuses[i]
+	cs.Th, cs.En, cs.Color, cs.Active, cs.UpdatedAt, cs.UpdatedBy = in.Th, in.En, in.Color, in.Active, time.Now(), &username
+	return nil
+}
+
+func (r *memCaseTypes) DeleteStatus(ctx context.Context, id string) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := index(s.statuses, func(cs model.CaseStatus) bool { return caseStatusID(cs) == id })
+	if i < 0 {
+		return ErrNotFound
+	}
+	s.statuses = append(s.statuses[:i:i], s.statuses[i+1:]...)
+	return nil
+}
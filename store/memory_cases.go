@@ -0,0 +1,350 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"mainPackage/model"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type memCases struct {
+	db *memDB
+}
+
+func (r *memCases) List(ctx context.Context, orgId string, f model.CaseFilter) ([]model.Case, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	from, err := parseBound(f.StartDate)
+	if err != nil {
+		return nil, err
+	}
+	to, err := parseBound(f.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	var list []model.Case
+	for _, c := range s.cases {
+		switch {
+		case c.OrgID != orgId,
+			f.CaseTypeID != "" && c.CaseTypeID != f.CaseTypeID,
+			f.CaseSTypeID != "" && c.CaseSTypeID != f.CaseSTypeID,
+			f.Detail != "" && !strings.Contains(strings.ToLower(deref(c.CaseDetail)), strings.ToLower(f.Detail)),
+			from != nil && c.CreatedAt.Before(*from),
+			to != nil && c.CreatedAt.After(*to),
+			f.StatusID != "" && c.StatusID != f.StatusID,
+			f.CountryID != "" && c.CountryID != f.CountryID,
+			f.ProvID != "" && c.ProvID != f.ProvID,
+			f.DistID != "" && c.DistID != f.DistID:
+			continue
+		}
+		list = append(list, c)
+	}
+	sort.SliceStable(list, func(i, j int) bool { return list[i].CreatedAt.After(*list[j].CreatedAt) })
+	start, end := page(len(list), f.Length, f.Start)
+	return list[start:end], nil
+}
+
+// parseBound reads a date filter the way Postgres would cast it.
+func parseBound(v string) (*time.Time, error) {
+	if v == "" {
+		return nil, nil
+	}
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02 15:04:05", "2006-01-02"} {
+		if t, err := time.Parse(layout, v); err == nil {
+			return &t, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid date %q", v)
+}
+
+func (r *memCases) find(s *memState, orgId string, match func(c model.Case) bool) int {
+	for i, c := range s.cases {
+		if c.OrgID == orgId && match(c) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (r *memCases) GetByID(ctx context.Context, orgId, id string) (*model.Case, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := r.find(s, orgId, func(c model.Case) bool { return c.ID == id })
+	if i < 0 {
+		return nil, ErrNotFound
+	}
+	c := s.cases[i]
+	return &c, nil
+}
+
+func (r *memCases) GetByCaseID(ctx context.Context, orgId, caseId string) (*model.Case, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := r.find(s, orgId, func(c model.Case) bool { return c.CaseID == caseId })
+	if i < 0 {
+		return nil, ErrNotFound
+	}
+	c := s.cases[i]
+	return &c, nil
+}
+
+func (r *memCases) Create(ctx context.Context, orgId, caseId, username string, in model.CaseInsert) (string, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	if caseExists(s, caseId) {
+		return "", fmt.Errorf("case %s: %w", caseId, errDuplicate)
+	}
+	now := time.Now()
+	c := model.Case{
+		ID: s.nextID(), OrgID: orgId, CaseID: caseId, CaseVersion: in.CaseVersion, ReferCaseID: in.ReferCaseID,
+		CaseTypeID: in.CaseTypeID, CaseSTypeID: in.CaseSTypeID, Priority: in.Priority, WfID: in.WfID,
+		WfVersions: in.WfVersions, Source: in.Source, DeviceID: deref(in.DeviceID), PhoneNo: deref(in.PhoneNo),
+		PhoneNoHide: in.PhoneNoHide, CaseDetail: in.CaseDetail, ExtReceive: deref(in.ExtReceive),
+		StatusID: in.StatusID, CaseLat: deref(in.CaseLat), CaseLon: deref(in.CaseLon),
+		CaseLocAddr: deref(in.CaseLocAddr), CaseLocAddrDecs: deref(in.CaseLocAddrDecs), CountryID: in.CountryID,
+		ProvID: in.ProvID, DistID: in.DistID, CreatedDate: in.CreatedDate, StartedDate: in.StartedDate,
+		UserCreate: deref(in.UserCreate), ResID: in.ResID, ResDetail: in.ResDetail, ScheduleFlag: in.ScheduleFlag,
+		ScheduleDate: in.ScheduleDate, CreatedAt: &now, UpdatedAt: &now, CreatedBy: username, UpdatedBy: username,
+	}
+	s.cases = append(s.cases, c)
+	return c.ID, nil
+}
+
+func caseExists(s *memState, caseId string) bool {
+	for _, c := range s.cases {
+		if c.CaseID == caseId {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *memCases) Update(ctx context.Context, orgId, id, username string, in model.CaseUpdate) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := r.find(s, orgId, func(c model.Case) bool { return c.ID == id })
+	if i < 0 {
+		return nil
+	}
+	c := &s.cases[i]
+	now := time.Now()
+	date := func(t time.Time) *time.Time { return &t }
+	c.CaseVersion, c.ReferCaseID, c.CaseTypeID, c.CaseSTypeID = in.CaseVersion, in.ReferCaseID, in.CaseTypeID, in.CaseSTypeID
+	c.Priority, c.Source, c.DeviceID, c.PhoneNo, c.PhoneNoHide = in.Priority, in.Source, in.DeviceID, in.PhoneNo, in.PhoneNoHide
+	c.CaseDetail, c.ExtReceive = in.CaseDetail, in.ExtReceive
+	c.CaseLat, c.CaseLon, c.CaseLocAddr, c.CaseLocAddrDecs = in.CaseLat, in.CaseLon, in.CaseLocAddr, in.CaseLocAddrDecs
+	c.CountryID, c.ProvID, c.DistID = in.CountryID, in.ProvID, in.DistID
+	c.CreatedDate, c.StartedDate, c.UserCreate = date(in.CreatedDate), date(in.StartedDate), in.UserCreate
+	c.ResID, c.ResDetail, c.ScheduleFlag, c.ScheduleDate = in.ResID, in.ResDetail, in.ScheduleFlag, in.ScheduleDate
+	c.UpdatedAt, c.UpdatedBy = &now, username
+	return nil
+}
+
+func (r *memCases) SetStatus(ctx context.Context, orgId, fromStatusId, username string, in model.Case) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := r.find(s, orgId, func(c model.Case) bool { return c.ID == in.ID && c.StatusID == fromStatusId })
+	if i < 0 {
+		return ErrNotFound
+	}
+	c := &s.cases[i]
+	now := time.Now()
+	c.StatusID, c.CaseDuration = in.StatusID, in.CaseDuration
+	c.CommandedDate, c.ReceivedDate, c.ArrivedDate, c.ClosedDate = in.CommandedDate, in.ReceivedDate, in.ArrivedDate, in.ClosedDate
+	c.UserCommand, c.UserReceive, c.UserArrive, c.UserClose = in.UserCommand, in.UserReceive, in.UserArrive, in.UserClose
+	c.UpdatedAt, c.UpdatedBy = &now, username
+	return nil
+}
+
+func (r *memCases) Delete(ctx context.Context, orgId, id string) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := r.find(s, orgId, func(c model.Case) bool { return c.ID == id })
+	if i < 0 {
+		return nil
+	}
+	caseId := s.cases[i].CaseID
+	s.cases = append(s.cases[:i:i], s.cases[i+1:]...)
+
+	// tix_case_current_stage, tix_case_history_events,
+	// tix_case_stage_history and form_submissions cascade.
+	stages := s.stages[:0:0]
+	for _, st := range s.stages {
+		if st.CaseId != caseId {
+			stages = append(stages, st)
+		}
+	}
+	s.stages = stages
+	history := s.history[:0:0]
+	for _, h := range s.history {
+		if h.CaseID != caseId {
+			history = append(history, h)
+		}
+	}
+	s.history = history
+	stageHistory := s.stageHistory[:0:0]
+	for _, h := range s.stageHistory {
+		if h.CaseID != caseId {
+			stageHistory = append(stageHistory, h)
+		}
+	}
+	s.stageHistory = stageHistory
+	submissions := s.submissions[:0:0]
+	for _, sub := range s.submissions {
+		if sub.CaseID != caseId {
+			submissions = append(submissions, sub)
+		}
+	}
+	s.submissions = submissions
+	return nil
+}
+
+func (r *memCases) GetCurrentStage(ctx context.Context, orgId, caseId string) (*model.CurrentStage, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	for i := len(s.stages) - 1; i >= 0; i-- {
+		if st := s.stages[i]; st.OrgID == orgId && st.CaseId == caseId {
+			cs := st.CurrentStage
+			return &cs, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (r *memCases) CreateCurrentStage(ctx context.Context, orgId, caseId, username string, node model.WfNode) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	if !caseExists(s, caseId) {
+		return fmt.Errorf("case %s: %w", caseId, ErrNotFound)
+	}
+	data, err := roundTrip(node.Data)
+	if err != nil {
+		return err
+	}
+	s.stages = append(s.stages, memStage{OrgID: orgId, CurrentStage: model.CurrentStage{
+		CaseId: caseId, WfId: node.WfID, NodeId: node.NodeID, Versions: node.Versions, Type: node.Type,
+		Section: node.Section, Data: data, Pic: node.Pic, Group: node.Group, FormId: node.FormID,
+	}})
+	return nil
+}
+
+func (r *memCases) UpdateCurrentStage(ctx context.Context, orgId, caseId, username, fromNodeId string, node model.WfNode) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	data, err := roundTrip(node.Data)
+	if err != nil {
+		return err
+	}
+	for i := len(s.stages) - 1; i >= 0; i-- {
+		if st := &s.stages[i]; st.OrgID == orgId && st.CaseId == caseId {
+			if st.NodeId != fromNodeId {
+				return ErrNotFound
+			}
+			st.CurrentStage = model.CurrentStage{
+				CaseId: caseId, WfId: node.WfID, NodeId: node.NodeID, Versions: node.Versions, Type: node.Type,
+				Section: node.Section, Data: data, Pic: node.Pic, Group: node.Group, FormId: node.FormID,
+			}
+			return nil
+		}
+	}
+	return ErrNotFound
+}
+
+func (r *memCases) ListStageHistory(ctx context.Context, orgId, caseId string) ([]model.CaseStageHistory, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	var list []model.CaseStageHistory
+	for _, h := range s.stageHistory {
+		if h.OrgID == orgId && h.CaseID == caseId {
+			list = append(list, h)
+		}
+	}
+	return list, nil
+}
+
+func (r *memCases) AddStageHistory(ctx context.Context, orgId, username string, in model.CaseStageHistoryInsert) (int, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	if !caseExists(s, in.CaseID) {
+		return 0, fmt.Errorf("case %s: %w", in.CaseID, ErrNotFound)
+	}
+	id, _ := strconv.Atoi(s.nextID())
+	s.stageHistory = append(s.stageHistory, model.CaseStageHistory{
+		ID: id, OrgID: orgId, CaseID: in.CaseID, WfID: in.WfID, Versions: in.Versions, FromNodeID: in.FromNodeID,
+		ToNodeID: in.ToNodeID, Label: in.Label, CreatedAt: time.Now(), CreatedBy: username,
+	})
+	return id, nil
+}
+
+func (r *memCases) ListHistory(ctx context.Context, orgId string, limit, offset int) ([]model.CaseHistory, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	var list []model.CaseHistory
+	for _, h := range s.history {
+		if h.OrgID == orgId {
+			list = append(list, h)
+		}
+	}
+	start, end := page(len(list), limit, offset)
+	return list[start:end], nil
+}
+
+func (r *memCases) ListHistoryByCaseID(ctx context.Context, orgId, caseId string) ([]model.CaseHistory, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	var list []model.CaseHistory
+	for _, h := range s.history {
+		if h.OrgID == orgId && h.CaseID == caseId {
+			list = append(list, h)
+		}
+	}
+	sort.SliceStable(list, func(i, j int) bool { return list[i].CreatedAt.Before(list[j].CreatedAt) })
+	return list, nil
+}
+
+func (r *memCases) AddHistory(ctx context.Context, orgId, username string, in model.CaseHistoryInsert) (int, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	if !caseExists(s, in.CaseID) {
+		return 0, fmt.Errorf("case %s: %w", in.CaseID, ErrNotFound)
+	}
+	id, _ := strconv.Atoi(s.nextID())
+	s.history = append(s.history, model.CaseHistory{
+		ID: id, OrgID: orgId, CaseID: in.CaseID, Username: username, Type: in.Type, FullMsg: in.FullMsg,
+		JSONData: jsonData(in.JSONData), CreatedAt: time.Now(), CreatedBy: username,
+	})
+	return id, nil
+}
+
+func jsonData(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func (r *memCases) UpdateHistory(ctx context.Context, orgId, id, username string, in model.CaseHistoryUpdate) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	for i := range s.history {
+		if h := &s.history[i]; h.OrgID == orgId && strconv.Itoa(h.ID) == id {
+			h.Username, h.Type, h.FullMsg, h.JSONData = username, in.Type, in.FullMsg, jsonData(in.JSONData)
+		}
+	}
+	return nil
+}
+
+func (r *memCases) DeleteHistory(ctx context.Context, orgId, id string) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	for i, h := range s.history {
+		if h.OrgID == orgId && strconv.Itoa(h.ID) == id {
+			s.history = append(s.history[:i:i], s.history[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
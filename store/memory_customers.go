@@ -0,0 +1,171 @@
+package store
+
+import (
+	"context"
+	"mainPackage/model"
+	"time"
+)
+
+type memCustomers struct {
+	db *memDB
+}
+
+func (r *memCustomers) List(ctx context.Context, orgId string, limit, offset int) ([]model.Customer, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	return pageOf(s.customers, func(cu model.Customer) bool { return cu.OrgID == orgId },
+		func(cu model.Customer) string { return cu.ID }, limit, offset), nil
+}
+
+func (r *memCustomers) Get(ctx context.Context, orgId, id string) (*model.Customer, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := index(s.customers, func(cu model.Customer) bool { return cu.OrgID == orgId && cu.ID == id })
+	if i < 0 {
+		return nil, ErrNotFound
+	}
+	cu := s.customers[i]
+	return &cu, nil
+}
+
+func (r *memCustomers) Create(ctx context.Context, orgId, username string, in model.CustomerInsert) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	now := time.Now()
+	s.customers = append(s.customers, model.Customer{ID: s.nextID(), OrgID: orgId, DisplayName: in.DisplayName,
+		Title: in.Title, FirstName: in.FirstName, MiddleName: in.MiddleName, LastName: in.LastName,
+		CitizenID: in.CitizenID, DOB: in.DOB, Blood: in.Blood, Gender: in.Gender, MobileNo: in.MobileNo,
+		Address: in.Address, Photo: in.Photo, Email: in.Email, UserType: in.UserType, Active: in.Active,
+		CreatedAt: now, UpdatedAt: now, CreatedBy: username, UpdatedBy: username})
+	return nil
+}
+
+func (r *memCustomers) Update(ctx context.Context, orgId, id, username string, in model.CustomerUpdate) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := index(s.customers, func(cu model.Customer) bool { return cu.OrgID == orgId && cu.ID == id })
+	if i < 0 {
+		return ErrNotFound
+	}
+	cu := &s.customers[i]
+	cu.DisplayName, cu.Title, cu.FirstName, cu.MiddleName, cu.LastName = in.DisplayName, in.Title, in.FirstName, in.MiddleName, in.LastName
+	cu.CitizenID, cu.DOB, cu.Blood, cu.Gender, cu.MobileNo = in.CitizenID, in.DOB, in.Blood, in.Gender, in.MobileNo
+	cu.Address, cu.Photo, cu.Email, cu.UserType, cu.Active = in.Address, in.Photo, in.Email, in.UserType, in.Active
+	cu.UpdatedAt, cu.UpdatedBy = time.Now(), username
+	return nil
+}
+
+func (r *memCustomers) Delete(ctx context.Context, orgId, id string) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := index(s.customers, func(cu model.Customer) bool { return cu.OrgID == orgId && cu.ID == id })
+	if i < 0 {
+		return ErrNotFound
+	}
+	s.customers = append(s.customers[:i:i], s.customers[i+1:]...)
+	return nil
+}
+
+func (r *memCustomers) ListSocials(ctx context.Context, orgId string, limit, offset int) ([]model.CustomerSocial, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	return pageOf(s.custSocials, func(so model.CustomerSocial) bool { return so.OrgID == orgId },
+		func(so model.CustomerSocial) string { return so.ID }, limit, offset), nil
+}
+
+func (r *memCustomers) GetSocial(ctx context.Context, orgId, id string) (*model.CustomerSocial, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := index(s.custSocials, func(so model.CustomerSocial) bool { return so.OrgID == orgId && so.ID == id })
+	if i < 0 {
+		return nil, ErrNotFound
+	}
+	so := s.custSocials[i]
+	return &so, nil
+}
+
+func (r *memCustomers) CreateSocial(ctx context.Context, orgId, username string, in model.CustomerSocialInsert) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	now := time.Now()
+	s.custSocials = append(s.custSocials, model.CustomerSocial{ID: s.nextID(), OrgID: orgId, CustID: in.CustID,
+		SocialType: in.SocialType, SocialID: in.SocialID, SocialName: in.SocialName, ImgURL: in.ImgURL,
+		CreatedAt: now, UpdatedAt: now, CreatedBy: username, UpdatedBy: username})
+	return nil
+}
+
+func (r *memCustomers) UpdateSocial(ctx context.Context, orgId, id, username string, in model.CustomerSocialUpdate) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := index(s.custSocials, func(so model.CustomerSocial) bool { return so.OrgID == orgId && so.ID == id })
+	if i < 0 {
+		return ErrNotFound
+	}
+	so := &s.custSocials[i]
+	so.CustID, so.SocialType, so.SocialID, so.SocialName, so.ImgURL = in.CustID, in.SocialType, in.SocialID, in.SocialName, in.ImgURL
+	so.UpdatedAt, so.UpdatedBy = time.Now(), username
+	return nil
+}
+
+func (r *memCustomers) DeleteSocial(ctx context.Context, orgId, id string) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := index(s.custSocials, func(so model.CustomerSocial) bool { return so.OrgID == orgId && so.ID == id })
+	if i < 0 {
+		return ErrNotFound
+	}
+	s.custSocials = append(s.custSocials[:i:i], s.custSocials[i+1:]...)
+	return nil
+}
+
+func (r *memCustomers) ListContacts(ctx context.Context, orgId string, limit, offset int) ([]model.CustomerContact, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	return pageOf(s.custContacts, func(ct model.CustomerContact) bool { return ct.OrgID == orgId },
+		func(ct model.CustomerContact) string { return ct.ID }, limit, offset), nil
+}
+
+func (r *memCustomers) GetContact(ctx context.Context, orgId, id string) (*model.CustomerContact, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := index(s.custContacts, func(ct model.CustomerContact) bool { return ct.OrgID == orgId && ct.ID == id })
+	if i < 0 {
+		return nil, ErrNotFound
+	}
+	ct := s.custContacts[i]
+	return &ct, nil
+}
+
+func (r *memCustomers) CreateContact(ctx context.Context, orgId, username string, in model.CustomerContactInsert) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	now := time.Now()
+	s.custContacts = append(s.custContacts, model.CustomerContact{ID: s.nextID(), OrgID: orgId, CustID: in.CustID,
+		ContactName: in.ContactName, ContactPhone: in.ContactPhone, ContactAddr: in.ContactAddr,
+		CreatedAt: now, UpdatedAt: now, CreatedBy: username, UpdatedBy: username})
+	return nil
+}
+
+func (r *memCustomers) UpdateContact(ctx context.Context, orgId, id, username string, in model.CustomerContactUpdate) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := index(s.custContacts, func(ct model.CustomerContact) bool { return ct.OrgID == orgId && ct.ID == id })
+	if i < 0 {
+		return ErrNotFound
+	}
+	ct := &s.custContacts[i]
+	ct.CustID, ct.ContactName, ct.ContactPhone, ct.ContactAddr = in.CustID, in.ContactName, in.ContactPhone, in.ContactAddr
+	ct.UpdatedAt, ct.UpdatedBy = time.Now(), username
+	return nil
+}
+
+func (r *memCustomers) DeleteContact(ctx context.Context, orgId, id string) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := index(s.custContacts, func(ct model.CustomerContact) bool { return ct.OrgID == orgId && ct.ID == id })
+	if i < 0 {
+		return ErrNotFound
+	}
+	s.custContacts = append(s.custContacts[:i:i], s.custContacts[i+1:]...)
+	return nil
+}
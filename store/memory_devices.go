@@ -0,0 +1,28 @@
+package store
+
+import (
+	"context"
+	"mainPackage/model"
+)
+
+type memDevices struct {
+	db *memDB
+}
+
+func (r *memDevices) List(ctx context.Context, orgId string, limit, offset int) ([]model.DeviceIoT, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	return pageOf(s.devices, func(d model.DeviceIoT) bool { return d.OrgID == orgId },
+		func(d model.DeviceIoT) string { return d.DeviceID }, limit, offset), nil
+}
+
+func (r *memDevices) Get(ctx context.Context, orgId, deviceId string) (*model.DeviceIoT, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := index(s.devices, func(d model.DeviceIoT) bool { return d.OrgID == orgId && d.DeviceID == deviceId })
+	if i < 0 {
+		return nil, ErrNotFound
+	}
+	d := s.devices[i]
+	return &d, nil
+}
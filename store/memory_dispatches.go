@@ -0,0 +1,81 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"mainPackage/model"
+	"sort"
+	"time"
+)
+
+type memDispatches struct {
+	db *memDB
+}
+
+func (r *memDispatches) ListByCase(ctx context.Context, orgId, caseId string) ([]model.CaseDispatch, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	var list []model.CaseDispatch
+	for _, d := range s.dispatches {
+		if d.OrgID == orgId && d.CaseID == caseId {
+			list = append(list, d)
+		}
+	}
+	sort.SliceStable(list, func(i, j int) bool { return list[i].AssignedAt.Before(list[j].AssignedAt) })
+	return list, nil
+}
+
+// openDispatch mirrors the partial unique index on "unitId" over the rows
+// that are not released.
+func openDispatch(s *memState, unitId string) int {
+	for i, d := range s.dispatches {
+		if d.UnitID == unitId && d.Status != model.DispatchReleased {
+			return i
+		}
+	}
+	return -1
+}
+
+func (r *memDispatches) GetOpen(ctx context.Context, orgId, unitId string) (*model.CaseDispatch, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := openDispatch(s, unitId)
+	if i < 0 || s.dispatches[i].OrgID != orgId {
+		return nil, ErrNotFound
+	}
+	d := s.dispatches[i]
+	return &d, nil
+}
+
+func (r *memDispatches) Create(ctx context.Context, orgId, username string, d model.CaseDispatch) (string, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	if openDispatch(s, d.UnitID) >= 0 {
+		return "", fmt.Errorf("dispatch of unit %s: %w", d.UnitID, errDuplicate)
+	}
+	now := time.Now()
+	d.ID, d.OrgID = s.nextID(), orgId
+	d.AcknowledgedAt, d.EnRouteAt, d.OnSceneAt, d.CompletedAt, d.ReleasedAt, d.ReleasedBy = nil, nil, nil, nil, nil, ""
+	d.CreatedAt, d.UpdatedAt, d.CreatedBy, d.UpdatedBy = now, now, username, username
+	s.dispatches = append(s.dispatches, d)
+	return d.ID, nil
+}
+
+func (r *memDispatches) Update(ctx context.Context, orgId, fromStatus, username string, d model.CaseDispatch) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	for i := range s.dispatches {
+		cur := &s.dispatches[i]
+		if cur.OrgID != orgId || cur.ID != d.ID {
+			continue
+		}
+		if cur.Status != fromStatus {
+			return ErrNotFound
+		}
+		cur.Status, cur.AcknowledgedAt, cur.EnRouteAt, cur.OnSceneAt = d.Status, d.AcknowledgedAt, d.EnRouteAt, d.OnSceneAt
+		cur.CompletedAt, cur.ReleasedAt, cur.ReleasedBy = d.CompletedAt, d.ReleasedAt, d.ReleasedBy
+		cur.UpdatedAt, cur.UpdatedBy = time.Now(), username
+		return nil
+	}
+	return ErrNotFound
+}
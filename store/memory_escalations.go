@@ -0,0 +1,128 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"mainPackage/model"
+	"slices"
+	"sort"
+	"time"
+)
+
+type memEscalations struct {
+	db *memDB
+}
+
+func findPolicy(s *memState, orgId, id string) int {
+	for i, p := range s.policies {
+		if p.ID == id && p.OrgID == orgId {
+			return i
+		}
+	}
+	return -1
+}
+
+// eventTaken mirrors the unique ("orgId", "eventType") constraint.
+func eventTaken(s *memState, orgId, eventType, exceptId string) bool {
+	for _, p := range s.policies {
+		if p.OrgID == orgId && p.EventType == eventType && p.ID != exceptId {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *memEscalations) List(ctx context.Context, orgId string) ([]model.EscalationPolicy, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	var list []model.EscalationPolicy
+	for _, p := range s.policies {
+		if p.OrgID == orgId {
+			list = append(list, p)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].EventType < list[j].EventType })
+	return list, nil
+}
+
+func (r *memEscalations) ListActive(ctx context.Context) ([]model.EscalationPolicy, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	var list []model.EscalationPolicy
+	for _, p := range s.policies {
+		if p.Active {
+			list = append(list, p)
+		}
+	}
+	return list, nil
+}
+
+func (r *memEscalations) Get(ctx context.Context, orgId, id string) (*model.EscalationPolicy, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := findPolicy(s, orgId, id)
+	if i < 0 {
+		return nil, ErrNotFound
+	}
+	p := s.policies[i]
+	return &p, nil
+}
+
+func (r *memEscalations) Create(ctx context.Context, orgId, username string, in model.EscalationPolicyInput) (string, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	if eventTaken(s, orgId, in.EventType, "") {
+		return "", fmt.Errorf("escalation policy %s: %w", in.EventType, errDuplicate)
+	}
+	now := time.Now()
+	p := model.EscalationPolicy{ID: s.nextID(), OrgID: orgId, EventType: in.EventType,
+		Steps: append([]model.EscalationStep(nil), in.Steps...), Active: in.Active,
+		CreatedAt: now, UpdatedAt: now, CreatedBy: username, UpdatedBy: username}
+	s.policies = append(s.policies, p)
+	return p.ID, nil
+}
+
+func (r *memEscalations) Update(ctx context.Context, orgId, id, username string, in model.EscalationPolicyInput) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := findPolicy(s, orgId, id)
+	if i < 0 {
+		return ErrNotFound
+	}
+	if eventTaken(s, orgId, in.EventType, id) {
+		return fmt.Errorf("escalation policy %s: %w", in.EventType, errDuplicate)
+	}
+	p := &s.policies[i]
+	p.EventType, p.Active = in.EventType, in.Active
+	p.Steps = append([]model.EscalationStep(nil), in.Steps...)
+	p.UpdatedAt, p.UpdatedBy = time.Now(), username
+	return nil
+}
+
+func (r *memEscalations) Delete(ctx context.Context, orgId, id string) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := findPolicy(s, orgId, id)
+	if i < 0 {
+		return ErrNotFound
+	}
+	s.policies = append(s.policies[:i:i], s.policies[i+1:]...)
+	return nil
+}
+
+func (r *memEscalations) ResolveUnits(ctx context.Context, orgId string, stnIds, commIds []string) ([]model.OrgUnit, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	var list []model.OrgUnit
+	for _, st := range s.stations {
+		if st.OrgID == orgId && slices.Contains(stnIds, st.StnID) {
+			list = append(list, model.OrgUnit{DeptID: st.DeptID, CommID: st.CommID, StnID: st.StnID})
+		}
+	}
+	for _, cm := range s.commands {
+		if cm.OrgID == orgId && slices.Contains(commIds, cm.CommID) {
+			list = append(list, model.OrgUnit{DeptID: cm.DeptID, CommID: cm.CommID})
+		}
+	}
+	return list, nil
+}
@@ -0,0 +1,276 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"mainPackage/model"
+	"strconv"
+	"time"
+)
+
+type memForms struct {
+	db *memDB
+}
+
+func findForm(s *memState, orgId, formId string) int {
+	for i, f := range s.forms {
+		if f.OrgID == orgId && deref(f.FormId) == formId {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s *memState) formElements(orgId, formId, versions string) []map[string]interface{} {
+	var fields []map[string]interface{}
+	for _, e := range s.elements {
+		if e.OrgID == orgId && e.FormID == formId && e.Versions == versions {
+			fields = append(fields, e.Data)
+		}
+	}
+	return fields
+}
+
+func (r *memForms) Get(ctx context.Context, orgId, formId, versions string) (*model.Form, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := findForm(s, orgId, formId)
+	if i < 0 {
+		return nil, ErrNotFound
+	}
+	form := s.forms[i].Form
+	form.FormFieldJson = s.formElements(orgId, formId, versions)
+	return &form, nil
+}
+
+func (r *memForms) CurrentVersion(ctx context.Context, orgId, formId string) (string, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := findForm(s, orgId, formId)
+	if i < 0 {
+		return "", ErrNotFound
+	}
+	return s.forms[i].Versions, nil
+}
+
+func (r *memForms) GetCurrent(ctx context.Context, orgId, formId string) (*model.Form, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := findForm(s, orgId, formId)
+	if i < 0 {
+		return nil, ErrNotFound
+	}
+	form := s.forms[i].Form
+	form.FormFieldJson = s.formElements(orgId, formId, s.forms[i].Versions)
+	return &form, nil
+}
+
+func (r *memForms) List(ctx context.Context, orgId string) ([]model.FormsManager, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	var forms []model.FormsManager
+	for _, f := range s.forms {
+		if f.OrgID != orgId {
+			continue
+		}
+		// The Postgres listing inner joins the elements, so empty forms drop out.
+		fields := s.formElements(orgId, deref(f.FormId), f.Versions)
+		if len(fields) == 0 {
+			continue
+		}
+		fm := f.FormsManager
+		fm.FormFieldJson = fields
+		forms = append(forms, fm)
+	}
+	return forms, nil
+}
+
+func (r *memForms) NameExists(ctx context.Context, orgId, name string) (bool, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	for _, f := range s.forms {
+		if f.OrgID == orgId && deref(f.FormName) == name {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *memForms) Exists(ctx context.Context, orgId, formId string) (bool, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	for _, f := range s.forms {
+		if f.OrgID == orgId && deref(f.FormId) == formId {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *memForms) Create(ctx context.Context, orgId, formId, username string, in model.FormInsert) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	if findForm(s, orgId, formId) >= 0 {
+		return fmt.Errorf("form %s: %w", formId, errDuplicate)
+	}
+	now := time.Now()
+	id := formId
+	s.forms = append(s.forms, memForm{OrgID: orgId, FormsManager: model.FormsManager{
+		Form:   model.Form{FormId: &id, FormName: in.FormName, FormColSpan: in.FormColSpan},
+		Active: in.Active, Versions: Draft, Locks: in.Locks,
+		CreatedAt: now, UpdatedAt: now, CreatedBy: username, UpdatedBy: username,
+	}})
+	return s.insertElements(orgId, formId, Draft, in.FormFieldJson)
+}
+
+func (r *memForms) Update(ctx context.Context, orgId, formId, username string, in model.FormUpdate) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := findForm(s, orgId, formId)
+	if i < 0 {
+		return ErrNotFound
+	}
+	f := &s.forms[i]
+	if f.Locks {
+		return ErrLocked
+	}
+	f.FormName, f.FormColSpan, f.Active = in.FormName, in.FormColSpan, in.Active
+	f.Locks, f.UpdatedAt, f.UpdatedBy = in.Locks, time.Now(), username
+	elements := s.elements[:0:0]
+	for _, e := range s.elements {
+		if e.OrgID != orgId || e.FormID != formId || e.Versions != Draft {
+			elements = append(elements, e)
+		}
+	}
+	s.elements = elements
+	return s.insertElements(orgId, formId, Draft, in.FormFieldJson)
+}
+
+func (r *memForms) Publish(ctx context.Context, orgId, formId, username string) (string, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := findForm(s, orgId, formId)
+	if i < 0 {
+		return "", ErrNotFound
+	}
+	fields := s.formElements(orgId, formId, Draft)
+	if len(fields) == 0 {
+		return "", ErrEmptyDraft
+	}
+	next := 1
+	for _, v := range s.formVersions {
+		if n, _ := strconv.Atoi(v.Versions); v.OrgID == orgId && v.FormID == formId && n >= next {
+			next = n + 1
+		}
+	}
+	versions := strconv.Itoa(next)
+	now := time.Now()
+	f := &s.forms[i]
+	s.formVersions = append(s.formVersions, model.FormVersion{
+		ID: s.nextID(), OrgID: orgId, FormID: formId, Versions: versions, FormName: deref(f.FormName),
+		FormColSpan: f.FormColSpan, PublishedAt: now, PublishedBy: username,
+	})
+	if err := s.insertElements(orgId, formId, versions, fields); err != nil {
+		return "", err
+	}
+	f.Versions, f.Publish, f.UpdatedAt, f.UpdatedBy = versions, true, now, username
+	return versions, nil
+}
+
+func (r *memForms) ListVersions(ctx context.Context, orgId, formId string) ([]model.FormVersion, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	var list []model.FormVersion
+	for i := len(s.formVersions) - 1; i >= 0; i-- {
+		if v := s.formVersions[i]; v.OrgID == orgId && v.FormID == formId {
+			list = append(list, v)
+		}
+	}
+	return list, nil
+}
+
+func (s *memState) insertElements(orgId, formId, versions string, fields []map[string]interface{}) error {
+	for _, item := range fields {
+		raw, err := roundTrip(item)
+		if err != nil {
+			return err
+		}
+		data, _ := raw.(map[string]interface{})
+		s.elements = append(s.elements, memElement{OrgID: orgId, FormID: formId, Versions: versions, Data: data})
+	}
+	return nil
+}
+
+func (r *memForms) SetActive(ctx context.Context, orgId, formId, username string, active bool) error {
+	return r.setFlag(orgId, formId, username, func(f *memForm) { f.Active = active })
+}
+
+func (r *memForms) SetPublish(ctx context.Context, orgId, formId, username string, publish bool) error {
+	return r.setFlag(orgId, formId, username, func(f *memForm) { f.Publish = publish })
+}
+
+func (r *memForms) SetLock(ctx context.Context, orgId, formId, username string, locks bool) error {
+	return r.setFlag(orgId, formId, username, func(f *memForm) { f.Locks = locks })
+}
+
+func (r *memForms) setFlag(orgId, formId, username string, set func(f *memForm)) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	if i := findForm(s, orgId, formId); i >= 0 {
+		set(&s.forms[i])
+		s.forms[i].UpdatedAt, s.forms[i].UpdatedBy = time.Now(), username
+	}
+	return nil
+}
+
+func (r *memForms) CreateSubmission(ctx context.Context, orgId, caseId, formId, username string, in model.FormSubmissionInsert) (*model.FormSubmission, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	if !caseExists(s, caseId) {
+		return nil, fmt.Errorf("case %s: %w", caseId, ErrNotFound)
+	}
+	data, err := roundTrip(in.Data)
+	if err != nil {
+		return nil, err
+	}
+	revision := 1
+	for _, sub := range s.submissions {
+		if sub.OrgID == orgId && sub.CaseID == caseId && sub.NodeID == in.NodeID && sub.FormID == formId &&
+			sub.Revision >= revision {
+			revision = sub.Revision + 1
+		}
+	}
+	id, _ := strconv.ParseInt(s.nextID(), 10, 64)
+	sub := model.FormSubmission{
+		ID: id, OrgID: orgId, CaseID: caseId, NodeID: in.NodeID, FormID: formId, FormVersions: in.FormVersions,
+		Revision: revision, CreatedAt: time.Now(), CreatedBy: username,
+	}
+	sub.Data, _ = data.(map[string]interface{})
+	s.submissions = append(s.submissions, sub)
+	return &sub, nil
+}
+
+func (r *memForms) ListSubmissions(ctx context.Context, orgId, caseId string, f model.FormSubmissionFilter) ([]model.FormSubmission, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	latest := map[string]int{}
+	key := func(sub model.FormSubmission) string { return sub.NodeID + "\x00" + sub.FormID }
+	for _, sub := range s.submissions {
+		if sub.OrgID == orgId && sub.CaseID == caseId && sub.Revision > latest[key(sub)] {
+			latest[key(sub)] = sub.Revision
+		}
+	}
+	var list []model.FormSubmission
+	for i := len(s.submissions) - 1; i >= 0; i-- {
+		sub := s.submissions[i]
+		switch {
+		case sub.OrgID != orgId, sub.CaseID != caseId,
+			f.FormID != "" && sub.FormID != f.FormID,
+			f.NodeID != "" && sub.NodeID != f.NodeID,
+			f.Latest && sub.Revision != latest[key(sub)]:
+			continue
+		}
+		list = append(list, sub)
+	}
+	return list, nil
+}
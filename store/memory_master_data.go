@@ -0,0 +1,292 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"mainPackage/model"
+	"time"
+)
+
+type memMasterData struct {
+	db *memDB
+}
+
+func (r *memMasterData) ListProperties(ctx context.Context, orgId string, limit, offset int) ([]model.MmdProperty, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	return pageOf(s.mdmProps, func(p model.MmdProperty) bool { return p.OrgID == orgId },
+		func(p model.MmdProperty) string { return p.ID }, limit, offset), nil
+}
+
+func (r *memMasterData) GetProperty(ctx context.Context, orgId, id string) (*model.MmdProperty, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := index(s.mdmProps, func(p model.MmdProperty) bool { return p.OrgID == orgId && p.ID == id })
+	if i < 0 {
+		return nil, ErrNotFound
+	}
+	p := s.mdmProps[i]
+	return &p, nil
+}
+
+func (r *memMasterData) CreateProperty(ctx context.Context, orgId, propId, username string, in model.MmdPropertyInsert) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	if index(s.mdmProps, func(p model.MmdProperty) bool { return p.PropID == propId }) >= 0 {
+		return fmt.Errorf("property %s: %w", propId, errDuplicate)
+	}
+	now := time.Now()
+	s.mdmProps = append(s.mdmProps, model.MmdProperty{ID: s.nextID(), PropID: propId, OrgID: orgId, EN: in.EN, TH: in.TH,
+		Active: in.Active, CreatedAt: now, UpdatedAt: now, CreatedBy: username, UpdatedBy: username})
+	return nil
+}
+
+func (r *memMasterData) UpdateProperty(ctx context.Context, orgId, id, username string, in model.MmdPropertyUpdate) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := index(s.mdmProps, func(p model.MmdProperty) bool { return p.OrgID == orgId && p.ID == id })
+	if i < 0 {
+		return ErrNotFound
+	}
+	p := &s.mdmProps[i]
+	p.EN, p.TH, p.Active, p.UpdatedAt, p.UpdatedBy = in.EN, in.TH, in.Active, time.Now(), username
+	return nil
+}
+
+func (r *memMasterData) DeleteProperty(ctx context.Context, orgId, id string) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := index(s.mdmProps, func(p model.MmdProperty) bool { return p.OrgID == orgId && p.ID == id })
+	if i < 0 {
+		return ErrNotFound
+	}
+	s.mdmProps = append(s.mdmProps[:i:i], s.mdmProps[i+1:]...)
+	return nil
+}
+
+func (r *memMasterData) ListUnitSources(ctx context.Context, orgId string, limit, offset int) ([]model.MmdUnitSource, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	return pageOf(s.unitSources, func(us model.MmdUnitSource) bool { return us.OrgID == orgId },
+		func(us model.MmdUnitSource) string { return us.ID }, limit, offset), nil
+}
+
+func (r *memMasterData) GetUnitSource(ctx context.Context, orgId, id string) (*model.MmdUnitSource, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := index(s.unitSources, func(us model.MmdUnitSource) bool { return us.OrgID == orgId && us.ID == id })
+	if i < 0 {
+		return nil, ErrNotFound
+	}
+	us := s.unitSources[i]
+	return &us, nil
+}
+
+func (r *memMasterData) CreateUnitSource(ctx context.Context, orgId, unitSourceId, username string, in model.MmdUnitSourceInsert) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	if index(s.unitSources, func(us model.MmdUnitSource) bool { return us.OrgID == orgId && us.UnitSourceID == unitSourceId }) >= 0 {
+		return fmt.Errorf("unit source %s: %w", unitSourceId, errDuplicate)
+	}
+	now := time.Now()
+	s.unitSources = append(s.unitSources, model.MmdUnitSource{ID: s.nextID(), UnitSourceID: unitSourceId, OrgID: orgId,
+		EN: in.EN, TH: in.TH, Active: in.Active, CreatedAt: now, UpdatedAt: now, CreatedBy: username, UpdatedBy: username})
+	return nil
+}
+
+func (r *memMasterData) UpdateUnitSource(ctx context.Context, orgId, id, username string, in model.MmdUnitSourceUpdate) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := index(s.unitSources, func(us model.MmdUnitSource) bool { return us.OrgID == orgId && us.ID == id })
+	if i < 0 {
+		return ErrNotFound
+	}
+	us := &s.unitSources[i]
+	us.EN, us.TH, us.Active, us.UpdatedAt, us.UpdatedBy = in.EN, in.TH, in.Active, time.Now(), username
+	return nil
+}
+
+func (r *memMasterData) DeleteUnitSource(ctx context.Context, orgId, id string) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := index(s.unitSources, func(us model.MmdUnitSource) bool { return us.OrgID == orgId && us.ID == id })
+	if i < 0 {
+		return ErrNotFound
+	}
+	s.unitSources = append(s.unitSources[:i:i], s.unitSources[i+1:]...)
+	return nil
+}
+
+func (r *memMasterData) ListUnitTypes(ctx context.Context, orgId string, limit, offset int) ([]model.MmdUnitType, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	return pageOf(s.unitTypes, func(ut model.MmdUnitType) bool { return ut.OrgID == orgId },
+		func(ut model.MmdUnitType) string { return ut.ID }, limit, offset), nil
+}
+
+func (r *memMasterData) GetUnitType(ctx context.Context, orgId, id string) (*model.MmdUnitType, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := index(s.unitTypes, func(ut model.MmdUnitType) bool { return ut.OrgID == orgId && ut.ID == id })
+	if i < 0 {
+		return nil, ErrNotFound
+	}
+	ut := s.unitTypes[i]
+	return &ut, nil
+}
+
+func (r *memMasterData) CreateUnitType(ctx context.Context, orgId, unitTypeId, username string, in model.MmdUnitTypeInsert) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	if index(s.unitTypes, func(ut model.MmdUnitType) bool { return ut.OrgID == orgId && ut.UnitTypeId == unitTypeId }) >= 0 {
+		return fmt.Errorf("unit type %s: %w", unitTypeId, errDuplicate)
+	}
+	now := time.Now()
+	s.unitTypes = append(s.unitTypes, model.MmdUnitType{ID: s.nextID(), UnitTypeId: unitTypeId, OrgID: orgId,
+		EN: in.EN, TH: in.TH, Active: in.Active, CreatedAt: now, UpdatedAt: now, CreatedBy: username, UpdatedBy: username})
+	return nil
+}
+
+func (r *memMasterData) UpdateUnitType(ctx context.Context, orgId, id, username string, in model.MmdUnitTypeUpdate) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := index(s.unitTypes, func(ut model.MmdUnitType) bool { return ut.OrgID == orgId && ut.ID == id })
+	if i < 0 {
+		return ErrNotFound
+	}
+	ut := &s.unitTypes[i]
+	ut.EN, ut.TH, ut.Active, ut.UpdatedAt, ut.UpdatedBy = in.EN, in.TH, in.Active, time.Now(), username
+	return nil
+}
+
+func (r *memMasterData) DeleteUnitType(ctx context.Context, orgId, id string) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := index(s.unitTypes, func(ut model.MmdUnitType) bool { return ut.OrgID == orgId && ut.ID == id })
+	if i < 0 {
+		return ErrNotFound
+	}
+	s.unitTypes = append(s.unitTypes[:i:i], s.unitTypes[i+1:]...)
+	return nil
+}
+
+func (r *memMasterData) ListCompanies(ctx context.Context, limit, offset int) ([]model.MmdCompanies, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	start, end := page(len(s.companies), limit, offset)
+	return append([]model.MmdCompanies(nil), s.companies[start:end]...), nil
+}
+
+func (r *memMasterData) GetCompany(ctx context.Context, id string) (*model.MmdCompanies, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := index(s.companies, func(co model.MmdCompanies) bool { return co.ID == id })
+	if i < 0 {
+		return nil, ErrNotFound
+	}
+	co := s.companies[i]
+	return &co, nil
+}
+
+func (r *memMasterData) CreateCompany(ctx context.Context, id, username string, in model.MmdCompaniesInsert) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	if index(s.companies, func(co model.MmdCompanies) bool { return co.ID == id }) >= 0 {
+		return fmt.Errorf("company %s: %w", id, errDuplicate)
+	}
+	now := time.Now()
+	s.companies = append(s.companies, model.MmdCompanies{ID: id, Name: in.Name, LegalName: in.LegalName,
+		Domain: in.Domain, Email: in.Email, PhoneNumber: in.PhoneNumber, Address: in.Address, LogoURL: in.LogoURL,
+		WebsiteURL: in.WebsiteURL, Description: in.Description, CreatedAt: now, UpdatedAt: now,
+		CreatedBy: username, UpdatedBy: username})
+	return nil
+}
+
+func (r *memMasterData) UpdateCompany(ctx context.Context, id, username string, in model.MmdCompaniesUpdate) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := index(s.companies, func(co model.MmdCompanies) bool { return co.ID == id })
+	if i < 0 {
+		return ErrNotFound
+	}
+	co := &s.companies[i]
+	co.Name, co.LegalName, co.Domain, co.Email, co.PhoneNumber = in.Name, in.LegalName, in.Domain, in.Email, in.PhoneNumber
+	co.Address, co.LogoURL, co.WebsiteURL, co.Description = in.Address, in.LogoURL, in.WebsiteURL, in.Description
+	co.UpdatedAt, co.UpdatedBy = time.Now(), username
+	return nil
+}
+
+func (r *memMasterData) DeleteCompany(ctx context.Context, id string) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := index(s.companies, func(co model.MmdCompanies) bool { return co.ID == id })
+	if i < 0 {
+		return ErrNotFound
+	}
+	s.companies = append(s.companies[:i:i], s.companies[i+1:]...)
+	return nil
+}
+
+func (r *memMasterData) ListUnitStatuses(ctx context.Context, limit, offset int) ([]model.MmdUnitStatus, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	return pageOf(s.unitStatuses, func(st model.MmdUnitStatus) bool { return true },
+		func(st model.MmdUnitStatus) string { return st.ID }, limit, offset), nil
+}
+
+func (r *memMasterData) GetUnitStatus(ctx context.Context, id string) (*model.MmdUnitStatus, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := index(s.unitStatuses, func(st model.MmdUnitStatus) bool { return st.ID == id })
+	if i < 0 {
+		return nil, ErrNotFound
+	}
+	st := s.unitStatuses[i]
+	return &st, nil
+}
+
+func (r *memMasterData) CreateUnitStatus(ctx context.Context, username string, in model.MmdUnitStatusInsert) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	if index(s.unitStatuses, func(st model.MmdUnitStatus) bool { return st.SttID == in.SttID }) >= 0 {
+		return fmt.Errorf("unit status %s: %w", in.SttID, errDuplicate)
+	}
+	now := time.Now()
+	s.unitStatuses = append(s.unitStatuses, model.MmdUnitStatus{ID: s.nextID(), SttID: in.SttID, SttName: in.SttName,
+		CreatedAt: now, UpdatedAt: now, CreatedBy: username, UpdatedBy: username})
+	return nil
+}
+
+// UpdateUnitStatus mirrors the ON UPDATE CASCADE of mdm_units."sttId".
+func (r *memMasterData) UpdateUnitStatus(ctx context.Context, id, username string, in model.MmdUnitStatusUpdate) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := index(s.unitStatuses, func(st model.MmdUnitStatus) bool { return st.ID == id })
+	if i < 0 {
+		return ErrNotFound
+	}
+	st := &s.unitStatuses[i]
+	if in.SttID != st.SttID {
+		if index(s.unitStatuses, func(o model.MmdUnitStatus) bool { return o.SttID == in.SttID }) >= 0 {
+			return fmt.Errorf("unit status %s: %w", in.SttID, errDuplicate)
+		}
+		for u := range s.units {
+			if s.units[u].SttID == st.SttID {
+				s.units[u].SttID = in.SttID
+			}
+		}
+	}
+	st.SttID, st.SttName, st.UpdatedAt, st.UpdatedBy = in.SttID, in.SttName, time.Now(), username
+	return nil
+}
+
+func (r *memMasterData) DeleteUnitStatus(ctx context.Context, id string) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := index(s.unitStatuses, func(st model.MmdUnitStatus) bool { return st.ID == id })
+	if i < 0 {
+		return ErrNotFound
+	}
+	s.unitStatuses = append(s.unitStatuses[:i:i], s.unitStatuses[i+1:]...)
+	return nil
+}
@@ -0,0 +1,358 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"mainPackage/model"
+	"slices"
+	"sort"
+	"strconv"
+	"time"
+)
+
+type memNotifications struct {
+	db *memDB
+}
+
+func (r *memNotifications) Create(ctx context.Context, n *model.Notification) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	n.ID, _ = strconv.Atoi(s.nextID())
+	s.notis = append(s.notis, *n)
+	return nil
+}
+
+func findNotification(s *memState, id int64) int {
+	for i, n := range s.notis {
+		if int64(n.ID) == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func (r *memNotifications) Get(ctx context.Context, id int64) (*model.Notification, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := findNotification(s, id)
+	if i < 0 {
+		return nil, ErrNotFound
+	}
+	n := s.notis[i]
+	return &n, nil
+}
+
+func (r *memNotifications) Update(ctx context.Context, id int64, in model.Notification) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := findNotification(s, id)
+	if i < 0 {
+		return ErrNotFound
+	}
+	n := &s.notis[i]
+	n.Message, n.EventType, n.RedirectUrl = in.Message, in.EventType, in.RedirectUrl
+	n.Recipients, n.Data, n.ExpiredAt = in.Recipients, in.Data, in.ExpiredAt
+	return nil
+}
+
+func (r *memNotifications) Delete(ctx context.Context, id int64) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := findNotification(s, id)
+	if i < 0 {
+		return ErrNotFound
+	}
+	s.notis = append(s.notis[:i:i], s.notis[i+1:]...)
+	pruneNotificationRows(s)
+	return nil
+}
+
+func (r *memNotifications) ListForUser(ctx context.Context, orgId, username string) ([]model.Notification, error) {
+	return r.ListInbox(ctx, orgId, username, model.NotificationInboxFilter{})
+}
+
+// recipientValues returns the recipient values that address the user, by
+// recipient type. ErrNotFound means no active user.
+func recipientValues(s *memState, orgId, username string) (map[string][]string, error) {
+	var user *model.Um_User
+	for i, u := range s.users {
+		if u.Username == username && u.OrgID == orgId && u.Active {
+			user = &s.users[i]
+			break
+		}
+	}
+	if user == nil {
+		return nil, ErrNotFound
+	}
+
+	// Same recipient types as the Postgres query; empty profile fields
+	// never match.
+	values := map[string][]string{
+		"empId":    {user.EmpID},
+		"roleId":   {user.RoleID},
+		"deptId":   {user.DeptID},
+		"stnId":    {user.StnID},
+		"commId":   {user.CommID},
+		"orgId":    {user.OrgID},
+		"username": {username},
+	}
+	for _, g := range s.groups {
+		if g.Username == username {
+			values["grpId"] = []string{g.GrpID}
+			break
+		}
+	}
+	for _, a := range s.areas {
+		if a.Username != username {
+			continue
+		}
+		values["distId"] = append(values["distId"], a.DistIDs...)
+		for _, d := range s.districts {
+			for _, distId := range a.DistIDs {
+				if d.DistID == distId {
+					values["provId"] = append(values["provId"], d.ProvID)
+				}
+			}
+		}
+	}
+	return values, nil
+}
+
+// userState returns the user's state of notification id, or nil.
+func userState(s *memState, orgId, username string, id int64) *model.NotificationState {
+	if j := findNotiState(s, orgId, username, id); j >= 0 {
+		st := s.notiStates[j].NotificationState
+		return &st
+	}
+	return nil
+}
+
+func (r *memNotifications) ListInbox(ctx context.Context, orgId, username string, f model.NotificationInboxFilter) ([]model.Notification, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	values, err := recipientValues(s, orgId, username)
+	if err != nil {
+		return nil, err
+	}
+
+	var list []model.Notification
+	for _, n := range s.notis {
+		if n.OrgID != orgId || !recipientMatches(n.Recipients, values) {
+			continue
+		}
+		if (f.EventType != "" && n.EventType != f.EventType) ||
+			(f.From != nil && n.CreatedAt.Before(*f.From)) || (f.To != nil && !n.CreatedAt.Before(*f.To)) {
+			continue
+		}
+		if f.Before != nil && !(n.CreatedAt.Before(f.Before.CreatedAt) ||
+			(n.CreatedAt.Equal(f.Before.CreatedAt) && int64(n.ID) < f.Before.ID)) {
+			continue
+		}
+		n.State = userState(s, orgId, username, int64(n.ID))
+		ok, err := stateMatches(n.State, f.State)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			list = append(list, n)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if !list[i].CreatedAt.Equal(list[j].CreatedAt) {
+			return list[i].CreatedAt.After(list[j].CreatedAt)
+		}
+		return list[i].ID > list[j].ID
+	})
+	if f.Limit > 0 && len(list) > f.Limit {
+		list = list[:f.Limit]
+	}
+	return list, nil
+}
+
+func (r *memNotifications) GetForUser(ctx context.Context, orgId, username string, id int64) (*model.Notification, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	values, err := recipientValues(s, orgId, username)
+	if err != nil {
+		return nil, err
+	}
+	i := findNotification(s, id)
+	if i < 0 || s.notis[i].OrgID != orgId || !recipientMatches(s.notis[i].Recipients, values) {
+		return nil, ErrNotFound
+	}
+	n := s.notis[i]
+	n.State = userState(s, orgId, username, id)
+	return &n, nil
+}
+
+func (r *memNotifications) CountUnread(ctx context.Context, orgId, username string) (int, error) {
+	ids, err := r.UnreadIDs(ctx, orgId, username, nil)
+	return len(ids), err
+}
+
+func (r *memNotifications) UnreadIDs(ctx context.Context, orgId, username string, among []int64) ([]int64, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	values, err := recipientValues(s, orgId, username)
+	if err != nil {
+		return nil, err
+	}
+	var ids []int64
+	for _, n := range s.notis {
+		id := int64(n.ID)
+		if n.OrgID != orgId || !recipientMatches(n.Recipients, values) || !userState(s, orgId, username, id).Unread() {
+			continue
+		}
+		if among == nil || slices.Contains(among, id) {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// stateMatches applies an inbox state filter to the user's state.
+func stateMatches(st *model.NotificationState, state string) (bool, error) {
+	switch state {
+	case "":
+		return true, nil
+	case model.NotificationUnread:
+		return st.Unread(), nil
+	case model.NotificationRead:
+		return st != nil && st.ReadAt != nil, nil
+	case model.NotificationAcknowledged:
+		return st != nil && st.AcknowledgedAt != nil, nil
+	case model.NotificationDismissed:
+		return st != nil && st.DismissedAt != nil, nil
+	}
+	return false, fmt.Errorf("unknown notification state %q", state)
+}
+
+func findNotiState(s *memState, orgId, username string, id int64) int {
+	for i, st := range s.notiStates {
+		if st.NotificationID == id && st.Username == username && st.OrgID == orgId {
+			return i
+		}
+	}
+	return -1
+}
+
+// stamp sets one state of a user's row, creating the row as needed, and
+// reports whether the stamp was newly set.
+func stamp(s *memState, orgId, username string, id int64, state string, at time.Time) (bool, error) {
+	i := findNotiState(s, orgId, username, id)
+	if i < 0 {
+		s.notiStates = append(s.notiStates, memNotiState{OrgID: orgId, Username: username, NotificationID: id})
+		i = len(s.notiStates) - 1
+	}
+	st := &s.notiStates[i].NotificationState
+	var field **time.Time
+	switch state {
+	case model.NotificationDelivered:
+		field = &st.DeliveredAt
+	case model.NotificationRead:
+		field = &st.ReadAt
+	case model.NotificationAcknowledged:
+		field = &st.AcknowledgedAt
+	case model.NotificationDismissed:
+		field = &st.DismissedAt
+	default:
+		return false, fmt.Errorf("unknown notification state %q", state)
+	}
+	if *field != nil {
+		return false, nil
+	}
+	*field = &at
+	return true, nil
+}
+
+func (r *memNotifications) SetState(ctx context.Context, orgId, username string, ids []int64, state string, at time.Time) (int64, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	var n int64
+	for _, id := range ids {
+		if findNotification(s, id) < 0 {
+			continue
+		}
+		set, err := stamp(s, orgId, username, id, state, at)
+		if err != nil {
+			return n, err
+		}
+		if set {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (r *memNotifications) MarkDelivered(ctx context.Context, orgId string, id int64, usernames []string, at time.Time) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	if findNotification(s, id) < 0 {
+		return nil
+	}
+	for _, username := range usernames {
+		if _, err := stamp(s, orgId, username, id, model.NotificationDelivered, at); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func recipientMatches(recipients []model.Recipient, values map[string][]string) bool {
+	for _, rc := range recipients {
+		for _, v := range values[rc.Type] {
+			if v != "" && inList(rc.Value, v) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (r *memNotifications) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	kept := s.notis[:0:0]
+	for _, n := range s.notis {
+		if n.ExpiredAt.IsZero() || !n.ExpiredAt.Before(now) {
+			kept = append(kept, n)
+		}
+	}
+	deleted := int64(len(s.notis) - len(kept))
+	s.notis = kept
+	pruneNotificationRows(s)
+	return deleted, nil
+}
+
+func (r *memNotifications) ListEscalationDue(ctx context.Context, orgId, eventType string, level int, sentBefore, now time.Time) ([]model.Notification, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	acked := make(map[int64]bool)
+	for _, st := range s.notiStates {
+		if st.AcknowledgedAt != nil {
+			acked[st.NotificationID] = true
+		}
+	}
+	var list []model.Notification
+	for _, n := range s.notis {
+		if n.OrgID == orgId && n.EventType == eventType && len(n.Escalations) == level &&
+			!n.CreatedAt.After(sentBefore) && n.ExpiredAt.After(now) && !acked[int64(n.ID)] {
+			list = append(list, n)
+		}
+	}
+	sort.SliceStable(list, func(i, j int) bool { return list[i].CreatedAt.Before(list[j].CreatedAt) })
+	return list, nil
+}
+
+func (r *memNotifications) Escalate(ctx context.Context, id int64, step model.NotificationEscalation) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := findNotification(s, id)
+	if i < 0 || len(s.notis[i].Escalations) != step.Level-1 {
+		return ErrNotFound
+	}
+	n := &s.notis[i]
+	n.Recipients = append(append([]model.Recipient(nil), n.Recipients...), step.Added...)
+	n.Escalations = append(append([]model.NotificationEscalation(nil), n.Escalations...), step)
+	return nil
+}
@@ -0,0 +1,199 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"mainPackage/model"
+	"sort"
+	"time"
+)
+
+type memOrg struct {
+	db *memDB
+}
+
+func (r *memOrg) ListDepartments(ctx context.Context, orgId string, limit, offset int) ([]model.Department, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	return pageOf(s.depts, func(d model.Department) bool { return d.OrgID == orgId },
+		func(d model.Department) string { return d.ID }, limit, offset), nil
+}
+
+func (r *memOrg) GetDepartment(ctx context.Context, orgId, deptId string) (*model.Department, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := index(s.depts, func(d model.Department) bool { return d.OrgID == orgId && d.DeptID == deptId })
+	if i < 0 {
+		return nil, ErrNotFound
+	}
+	d := s.depts[i]
+	return &d, nil
+}
+
+func (r *memOrg) CreateDepartment(ctx context.Context, orgId, deptId, username string, in model.DepartmentInsert) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	if index(s.depts, func(d model.Department) bool { return d.OrgID == orgId && d.DeptID == deptId }) >= 0 {
+		return fmt.Errorf("department %s: %w", deptId, errDuplicate)
+	}
+	now := time.Now()
+	s.depts = append(s.depts, model.Department{ID: s.nextID(), DeptID: deptId, OrgID: orgId, En: in.En, Th: in.Th,
+		Active: in.Active, CreatedAt: now, UpdatedAt: now, CreatedBy: username, UpdatedBy: username})
+	return nil
+}
+
+func (r *memOrg) UpdateDepartment(ctx context.Context, orgId, id, username string, in model.DepartmentUpdate) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := index(s.depts, func(d model.Department) bool { return d.OrgID == orgId && d.ID == id })
+	if i < 0 {
+		return ErrNotFound
+	}
+	d := &s.depts[i]
+	d.En, d.Th, d.Active, d.UpdatedAt, d.UpdatedBy = in.En, in.Th, in.Active, time.Now(), username
+	return nil
+}
+
+func (r *memOrg) DeleteDepartment(ctx context.Context, orgId, id string) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := index(s.depts, func(d model.Department) bool { return d.OrgID == orgId && d.ID == id })
+	if i < 0 {
+		return ErrNotFound
+	}
+	s.depts = append(s.depts[:i:i], s.depts[i+1:]...)
+	return nil
+}
+
+func (r *memOrg) ListCommands(ctx context.Context, orgId string, limit, offset int) ([]model.Command, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	return pageOf(s.commands, func(cm model.Command) bool { return cm.OrgID == orgId },
+		func(cm model.Command) string { return cm.ID }, limit, offset), nil
+}
+
+func (r *memOrg) GetCommand(ctx context.Context, orgId, commId string) (*model.Command, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := index(s.commands, func(cm model.Command) bool { return cm.OrgID == orgId && cm.CommID == commId })
+	if i < 0 {
+		return nil, ErrNotFound
+	}
+	cm := s.commands[i]
+	return &cm, nil
+}
+
+func (r *memOrg) CreateCommand(ctx context.Context, orgId, commId, username string, in model.CommandInsert) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	if index(s.commands, func(cm model.Command) bool { return cm.OrgID == orgId && cm.CommID == commId }) >= 0 {
+		return fmt.Errorf("command %s: %w", commId, errDuplicate)
+	}
+	now := time.Now()
+	s.commands = append(s.commands, model.Command{ID: s.nextID(), DeptID: in.DeptID, OrgID: orgId, CommID: commId,
+		En: in.En, Th: in.Th, Active: in.Active, CreatedAt: now, UpdatedAt: now, CreatedBy: username, UpdatedBy: username})
+	return nil
+}
+
+func (r *memOrg) UpdateCommand(ctx context.Context, orgId, id, username string, in model.CommandUpdate) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := index(s.commands, func(cm model.Command) bool { return cm.OrgID == orgId && cm.ID == id })
+	if i < 0 {
+		return ErrNotFound
+	}
+	cm := &s.commands[i]
+	cm.DeptID, cm.En, cm.Th, cm.Active = in.DeptID, in.En, in.Th, in.Active
+	cm.UpdatedAt, cm.UpdatedBy = time.Now(), username
+	return nil
+}
+
+func (r *memOrg) DeleteCommand(ctx context.Context, orgId, id string) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := index(s.commands, func(cm model.Command) bool { return cm.OrgID == orgId && cm.ID == id })
+	if i < 0 {
+		return ErrNotFound
+	}
+	s.commands = append(s.commands[:i:i], s.commands[i+1:]...)
+	return nil
+}
+
+func (r *memOrg) ListStations(ctx context.Context, orgId string, limit, offset int) ([]model.Station, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	return pageOf(s.stations, func(st model.Station) bool { return st.OrgID == orgId },
+		func(st model.Station) string { return st.ID }, limit, offset), nil
+}
+
+func (r *memOrg) ListStationTree(ctx context.Context, orgId string) ([]model.StationWithCommandDept, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	var list []model.StationWithCommandDept
+	for _, st := range s.stations {
+		if st.OrgID != orgId {
+			continue
+		}
+		ci := index(s.commands, func(cm model.Command) bool { return cm.OrgID == orgId && cm.CommID == st.CommID })
+		di := index(s.depts, func(d model.Department) bool { return d.OrgID == orgId && d.DeptID == st.DeptID })
+		if ci < 0 || di < 0 {
+			continue
+		}
+		cm, d := s.commands[ci], s.depts[di]
+		list = append(list, model.StationWithCommandDept{ID: st.ID, OrgId: orgId, DeptId: st.DeptID,
+			CommId: st.CommID, StnId: st.StnID,
+			StationEn: st.En, StationTh: st.Th, StationActive: st.Active,
+			CommandEn: cm.En, CommandTh: cm.Th, CommandActive: cm.Active,
+			DeptEn: d.En, DeptTh: d.Th, DeptActive: d.Active})
+	}
+	sort.SliceStable(list, func(i, j int) bool { return list[i].StnId < list[j].StnId })
+	return list, nil
+}
+
+func (r *memOrg) GetStation(ctx context.Context, orgId, stnId string) (*model.Station, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := index(s.stations, func(st model.Station) bool { return st.OrgID == orgId && st.StnID == stnId })
+	if i < 0 {
+		return nil, ErrNotFound
+	}
+	st := s.stations[i]
+	return &st, nil
+}
+
+func (r *memOrg) CreateStation(ctx context.Context, orgId, stnId, username string, in model.StationInsert) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	if index(s.stations, func(st model.Station) bool { return st.OrgID == orgId && st.StnID == stnId }) >= 0 {
+		return fmt.Errorf("station %s: %w", stnId, errDuplicate)
+	}
+	now := time.Now()
+	s.stations = append(s.stations, model.Station{ID: s.nextID(), OrgID: orgId, DeptID: in.DeptID, CommID: in.CommID,
+		StnID: stnId, En: in.En, Th: in.Th, Active: in.Active, CreatedAt: now, UpdatedAt: now,
+		CreatedBy: username, UpdatedBy: username})
+	return nil
+}
+
+func (r *memOrg) UpdateStation(ctx context.Context, orgId, id, username string, in model.StationUpdate) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := index(s.stations, func(st model.Station) bool { return st.OrgID == orgId && st.ID == id })
+	if i < 0 {
+		return ErrNotFound
+	}
+	st := &s.stations[i]
+	st.DeptID, st.CommID, st.En, st.Th, st.Active = in.DeptID, in.CommID, in.En, in.Th, in.Active
+	st.UpdatedAt, st.UpdatedBy = time.Now(), username
+	return nil
+}
+
+func (r *memOrg) DeleteStation(ctx context.Context, orgId, id string) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := index(s.stations, func(st model.Station) bool { return st.OrgID == orgId && st.ID == id })
+	if i < 0 {
+		return ErrNotFound
+	}
+	s.stations = append(s.stations[:i:i], s.stations[i+1:]...)
+	return nil
+}
@@ -0,0 +1,135 @@
+package store
+
+import (
+	"context"
+	"mainPackage/model"
+	"sort"
+	"strconv"
+	"time"
+)
+
+type memOutbox struct {
+	db *memDB
+}
+
+func (r *memOutbox) Enqueue(ctx context.Context, orgId string, notificationId int64, channel string) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	id, _ := strconv.ParseInt(s.nextID(), 10, 64)
+	now := time.Now()
+	s.outbox = append(s.outbox, model.OutboxEntry{ID: id, OrgID: orgId, NotificationID: notificationId,
+		Channel: channel, Status: model.OutboxPending, NextAttemptAt: now, CreatedAt: now})
+	return nil
+}
+
+func findOutbox(s *memState, id int64) int {
+	for i, e := range s.outbox {
+		if e.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func (r *memOutbox) Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]model.OutboxEntry, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	var list []model.OutboxEntry
+	for i := range s.outbox {
+		e := &s.outbox[i]
+		if len(list) == limit {
+			break
+		}
+		if e.Status != model.OutboxPending || e.NextAttemptAt.After(now) {
+			continue
+		}
+		e.Attempts++
+		e.NextAttemptAt = now.Add(lease)
+		list = append(list, *e)
+	}
+	return list, nil
+}
+
+func (r *memOutbox) MarkDelivered(ctx context.Context, id int64, at time.Time) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	if i := findOutbox(s, id); i >= 0 {
+		s.outbox[i].Status, s.outbox[i].DeliveredAt, s.outbox[i].LastError = model.OutboxDelivered, &at, nil
+	}
+	return nil
+}
+
+func (r *memOutbox) MarkFailed(ctx context.Context, id int64, errText string, next time.Time, dead bool) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	if i := findOutbox(s, id); i >= 0 {
+		e := &s.outbox[i]
+		e.LastError, e.NextAttemptAt = &errText, next
+		if dead {
+			e.Status = model.OutboxDead
+		}
+	}
+	return nil
+}
+
+func (r *memOutbox) List(ctx context.Context, orgId string, filter model.OutboxFilter) ([]model.OutboxEntry, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	var list []model.OutboxEntry
+	for _, e := range s.outbox {
+		if e.OrgID == orgId && (filter.Status == "" || e.Status == filter.Status) {
+			list = append(list, e)
+		}
+	}
+	sort.SliceStable(list, func(i, j int) bool { return list[i].ID > list[j].ID })
+	start, end := page(len(list), filter.Limit, filter.Offset)
+	return list[start:end], nil
+}
+
+func (r *memOutbox) Requeue(ctx context.Context, orgId string, id int64, now time.Time) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := findOutbox(s, id)
+	if i < 0 || s.outbox[i].OrgID != orgId || s.outbox[i].Status != model.OutboxDead {
+		return ErrNotFound
+	}
+	s.outbox[i].Status, s.outbox[i].Attempts, s.outbox[i].NextAttemptAt = model.OutboxPending, 0, now
+	return nil
+}
+
+func (r *memOutbox) DeleteDelivered(ctx context.Context, before time.Time) (int64, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	kept := s.outbox[:0:0]
+	for _, e := range s.outbox {
+		if e.Status != model.OutboxDelivered || !e.DeliveredAt.Before(before) {
+			kept = append(kept, e)
+		}
+	}
+	n := int64(len(s.outbox) - len(kept))
+	s.outbox = kept
+	return n, nil
+}
+
+// pruneNotificationRows drops the outbox entries and states of deleted
+// notifications, as the foreign key cascades do.
+func pruneNotificationRows(s *memState) {
+	live := make(map[int64]bool, len(s.notis))
+	for _, n := range s.notis {
+		live[int64(n.ID)] = true
+	}
+	kept := s.outbox[:0:0]
+	for _, e := range s.outbox {
+		if live[e.NotificationID] {
+			kept = append(kept, e)
+		}
+	}
+	s.outbox = kept
+	states := s.notiStates[:0:0]
+	for _, st := range s.notiStates {
+		if live[st.NotificationID] {
+			states = append(states, st)
+		}
+	}
+	s.notiStates = states
+}
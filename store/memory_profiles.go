@@ -0,0 +1,127 @@
+package store
+
+import (
+	"context"
+	"mainPackage/model"
+	"strconv"
+	"time"
+)
+
+type memProfiles struct {
+	db *memDB
+}
+
+func contactID(ct model.UserContact) string { return strconv.FormatInt(ct.ID, 10) }
+
+func (r *memProfiles) ListContacts(ctx context.Context, orgId string, limit, offset int) ([]model.UserContact, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	return pageOf(s.contacts, func(ct model.UserContact) bool { return ct.OrgID == orgId }, contactID, limit, offset), nil
+}
+
+func (r *memProfiles) GetContact(ctx context.Context, orgId, id string) (*model.UserContact, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := index(s.contacts, func(ct model.UserContact) bool { return ct.OrgID == orgId && contactID(ct) == id })
+	if i < 0 {
+		return nil, ErrNotFound
+	}
+	ct := s.contacts[i]
+	return &ct, nil
+}
+
+func (r *memProfiles) CreateContact(ctx context.Context, orgId, username string, in model.UserContactInsert) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	now := time.Now()
+	id, _ := strconv.ParseInt(s.nextID(), 10, 64)
+	s.contacts = append(s.contacts, model.UserContact{ID: id, OrgID: orgId, Username: in.Username,
+		ContactName: in.ContactName, ContactPhone: in.ContactPhone, ContactAddr: in.ContactAddr,
+		CreatedAt: now, UpdatedAt: now, CreatedBy: username, UpdatedBy: username})
+	return nil
+}
+
+func (r *memProfiles) UpdateContact(ctx context.Context, orgId, id, username string, in model.UserContactInsertUpdate) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := index(s.contacts, func(ct model.UserContact) bool { return ct.OrgID == orgId && contactID(ct) == id })
+	if i < 0 {
+		return ErrNotFound
+	}
+	ct := &s.contacts[i]
+	ct.ContactName, ct.ContactPhone, ct.ContactAddr = in.ContactName, in.ContactPhone, in.ContactAddr
+	ct.UpdatedAt, ct.UpdatedBy = time.Now(), username
+	return nil
+}
+
+func (r *memProfiles) DeleteContact(ctx context.Context, orgId, id string) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := index(s.contacts, func(ct model.UserContact) bool { return ct.OrgID == orgId && contactID(ct) == id })
+	if i < 0 {
+		return ErrNotFound
+	}
+	s.contacts = append(s.contacts[:i:i], s.contacts[i+1:]...)
+	return nil
+}
+
+func socialID(so model.UserSocial) string { return strconv.FormatInt(so.ID, 10) }
+
+func (r *memProfiles) ListSocials(ctx context.Context, orgId string, limit, offset int) ([]model.UserSocial, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	return pageOf(s.socials, func(so model.UserSocial) bool { return so.OrgID == orgId }, socialID, limit, offset), nil
+}
+
+func (r *memProfiles) GetSocial(ctx context.Context, orgId, id string) (*model.UserSocial, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := index(s.socials, func(so model.UserSocial) bool { return so.OrgID == orgId && socialID(so) == id })
+	if i < 0 {
+		return nil, ErrNotFound
+	}
+	so := s.socials[i]
+	return &so, nil
+}
+
+func (r *memProfiles) CreateSocial(ctx context.Context, orgId, username string, in model.UserSocialInsert) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	now := time.Now()
+	id, _ := strconv.ParseInt(s.nextID(), 10, 64)
+	s.socials = append(s.socials, model.UserSocial{ID: id, OrgID: orgId, Username: in.Username,
+		SocialType: in.SocialType, SocialID: in.SocialID, SocialName: in.SocialName,
+		CreatedAt: now, UpdatedAt: now, CreatedBy: username, UpdatedBy: username})
+	return nil
+}
+
+func (r *memProfiles) UpdateSocial(ctx context.Context, orgId, id, username string, in model.UserSocialUpdate) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := index(s.socials, func(so model.UserSocial) bool { return so.OrgID == orgId && socialID(so) == id })
+	if i < 0 {
+		return ErrNotFound
+	}
+	so := &s.socials[i]
+	so.Username, so.SocialType, so.SocialID, so.SocialName = in.Username, in.SocialType, in.SocialID, in.SocialName
+	so.UpdatedAt, so.UpdatedBy = time.Now(), username
+	return nil
+}
+
+func (r *memProfiles) DeleteSocial(ctx context.Context, orgId, id string) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := index(s.socials, func(so model.UserSocial) bool { return so.OrgID == orgId && socialID(so) == id })
+	if i < 0 {
+		return ErrNotFound
+	}
+	s.socials = append(s.socials[:i:i], s.socials[i+1:]...)
+	return nil
+}
+
+func (r *memProfiles) ListGroups(ctx context.Context, orgId string, limit, offset int) ([]model.UmGroup, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	return pageOf(s.umGroups, func(g model.UmGroup) bool { return g.OrgID == orgId },
+		func(g model.UmGroup) string { return strconv.Itoa(g.ID) }, limit, offset), nil
+}
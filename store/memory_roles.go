@@ -0,0 +1,197 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"mainPackage/model"
+	"strconv"
+	"time"
+)
+
+type memRoles struct {
+	db *memDB
+}
+
+func (r *memRoles) List(ctx context.Context, orgId string, limit, offset int) ([]model.Role, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	return pageOf(s.roles, func(ro model.Role) bool { return ro.OrgID == orgId },
+		func(ro model.Role) string { return ro.ID }, limit, offset), nil
+}
+
+func (r *memRoles) Get(ctx context.Context, orgId, id string) (*model.Role, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := index(s.roles, func(ro model.Role) bool { return ro.OrgID == orgId && ro.ID == id })
+	if i < 0 {
+		return nil, ErrNotFound
+	}
+	ro := s.roles[i]
+	return &ro, nil
+}
+
+func (r *memRoles) Create(ctx context.Context, orgId, username string, in model.RoleInsert) (string, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	now := time.Now()
+	ro := model.Role{ID: s.nextID(), OrgID: orgId, RoleName: in.RoleName, Active: in.Active,
+		CreatedAt: now, UpdatedAt: now, CreatedBy: username, UpdatedBy: username}
+	s.roles = append(s.roles, ro)
+	return ro.ID, nil
+}
+
+func (r *memRoles) Update(ctx context.Context, orgId, id, username string, in model.RoleUpdate) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := index(s.roles, func(ro model.Role) bool { return ro.OrgID == orgId && ro.ID == id })
+	if i < 0 {
+		return ErrNotFound
+	}
+	ro := &s.roles[i]
+	ro.RoleName, ro.Active, ro.UpdatedAt, ro.UpdatedBy = in.RoleName, in.Active, time.Now(), username
+	return nil
+}
+
+// Delete mirrors the foreign keys: the role's grants go with it and its
+// users are left without a role.
+func (r *memRoles) Delete(ctx context.Context, orgId, id string) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := index(s.roles, func(ro model.Role) bool { return ro.OrgID == orgId && ro.ID == id })
+	if i < 0 {
+		return ErrNotFound
+	}
+	s.roles = append(s.roles[:i:i], s.roles[i+1:]...)
+	s.grants = dropGrants(s.grants, orgId, id)
+	for i := range s.users {
+		if s.users[i].RoleID == id {
+			s.users[i].RoleID = ""
+		}
+	}
+	return nil
+}
+
+func (r *memRoles) ListPermissions(ctx context.Context, limit, offset int) ([]model.Permission, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	return pageOf(s.perms, func(p model.Permission) bool { return true },
+		func(p model.Permission) string { return p.ID }, limit, offset), nil
+}
+
+func (r *memRoles) GetPermission(ctx context.Context, permId string) (*model.Permission, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := index(s.perms, func(p model.Permission) bool { return p.PermID == permId })
+	if i < 0 {
+		return nil, ErrNotFound
+	}
+	p := s.perms[i]
+	return &p, nil
+}
+
+func (r *memRoles) CreatePermission(ctx context.Context, permId, username string, in model.PermissionInsert) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	if index(s.perms, func(p model.Permission) bool { return p.PermID == permId }) >= 0 {
+		return fmt.Errorf("permission %s: %w", permId, errDuplicate)
+	}
+	now := time.Now()
+	s.perms = append(s.perms, model.Permission{ID: s.nextID(), GroupName: in.GroupName, PermID: permId,
+		PermName: in.PermName, Active: in.Active, CreatedAt: now, UpdatedAt: now, CreatedBy: username, UpdatedBy: username})
+	return nil
+}
+
+func (r *memRoles) UpdatePermission(ctx context.Context, permId, username string, in model.PermissionUpdate) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := index(s.perms, func(p model.Permission) bool { return p.PermID == permId })
+	if i < 0 {
+		return ErrNotFound
+	}
+	p := &s.perms[i]
+	p.GroupName, p.PermName, p.Active, p.UpdatedAt, p.UpdatedBy = in.GroupName, in.PermName, in.Active, time.Now(), username
+	return nil
+}
+
+func (r *memRoles) DeletePermission(ctx context.Context, permId string) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := index(s.perms, func(p model.Permission) bool { return p.PermID == permId })
+	if i < 0 {
+		return ErrNotFound
+	}
+	s.perms = append(s.perms[:i:i], s.perms[i+1:]...)
+	return nil
+}
+
+func grantID(g model.RolePermission) string { return strconv.FormatInt(g.ID, 10) }
+
+func (r *memRoles) ListGrants(ctx context.Context, orgId string, limit, offset int) ([]model.RolePermission, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	return pageOf(s.grants, func(g model.RolePermission) bool { return g.OrgID == orgId }, grantID, limit, offset), nil
+}
+
+func (r *memRoles) GetGrant(ctx context.Context, orgId, id string) (*model.RolePermission, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := index(s.grants, func(g model.RolePermission) bool { return g.OrgID == orgId && grantID(g) == id })
+	if i < 0 {
+		return nil, ErrNotFound
+	}
+	g := s.grants[i]
+	return &g, nil
+}
+
+func (r *memRoles) ListRoleGrants(ctx context.Context, orgId, roleId string) ([]model.RolePermission, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	return pageOf(s.grants, func(g model.RolePermission) bool { return g.OrgID == orgId && g.RoleID == roleId },
+		grantID, len(s.grants), 0), nil
+}
+
+func (r *memRoles) AddGrants(ctx context.Context, orgId, roleId, username string, perms []model.RolePermissionBody) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	s.addGrants(orgId, roleId, username, perms)
+	return nil
+}
+
+func (r *memRoles) SetGrants(ctx context.Context, orgId, roleId, username string, perms []model.RolePermissionBody) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	s.grants = dropGrants(s.grants, orgId, roleId)
+	s.addGrants(orgId, roleId, username, perms)
+	return nil
+}
+
+func (s *memState) addGrants(orgId, roleId, username string, perms []model.RolePermissionBody) {
+	now := time.Now()
+	for _, p := range perms {
+		id, _ := strconv.ParseInt(s.nextID(), 10, 64)
+		s.grants = append(s.grants, model.RolePermission{ID: id, OrgID: orgId, RoleID: roleId, PermID: p.PermID,
+			Active: p.Active, CreatedAt: now, UpdatedAt: now, CreatedBy: username, UpdatedBy: username})
+	}
+}
+
+// dropGrants returns grants without those of roleId.
+func dropGrants(grants []model.RolePermission, orgId, roleId string) []model.RolePermission {
+	kept := grants[:0:0]
+	for _, g := range grants {
+		if g.OrgID != orgId || g.RoleID != roleId {
+			kept = append(kept, g)
+		}
+	}
+	return kept
+}
+
+func (r *memRoles) DeleteGrant(ctx context.Context, orgId, id string) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := index(s.grants, func(g model.RolePermission) bool { return g.OrgID == orgId && grantID(g) == id })
+	if i < 0 {
+		return ErrNotFound
+	}
+	s.grants = append(s.grants[:i:i], s.grants[i+1:]...)
+	return nil
+}
@@ -0,0 +1,125 @@
+package store
+
+import (
+	"context"
+	"mainPackage/model"
+	"time"
+)
+
+type memSessions struct {
+	db *memDB
+}
+
+func (r *memSessions) GetProfile(ctx context.Context, orgId, username string) (*model.UserConnectionInfo, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	if i := index(s.presence, func(p model.UserConnectionInfo) bool {
+		return p.OrgID == orgId && p.Username == username
+	}); i >= 0 {
+		p := s.presence[i]
+		return &p, nil
+	}
+	i := index(s.users, func(u model.Um_User) bool { return u.OrgID == orgId && u.Username == username && u.Active })
+	if i < 0 {
+		return nil, ErrNotFound
+	}
+	u := s.users[i]
+	p := model.UserConnectionInfo{ID: u.EmpID, Username: u.Username, OrgID: u.OrgID, DeptID: u.DeptID,
+		CommID: u.CommID, StnID: u.StnID, RoleID: u.RoleID, GrpID: []string{}, DistIdLists: []string{}}
+	for _, g := range s.groups {
+		if g.OrgID == orgId && g.Username == username {
+			p.GrpID = append(p.GrpID, g.GrpID)
+		}
+	}
+	if j := index(s.areas, func(a memArea) bool { return a.OrgID == orgId && a.Username == username }); j >= 0 {
+		p.DistIdLists = append(p.DistIdLists, s.areas[j].DistIDs...)
+	}
+	return &p, nil
+}
+
+func (r *memSessions) Add(ctx context.Context, instanceId string, info model.UserConnectionInfo) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	s.sessions = append(s.sessions, memSession{SessionID: info.SessionID, EmpID: info.ID,
+		InstanceID: instanceId, SeenAt: time.Now()})
+	presence := info
+	presence.SessionID, presence.UserAgent, presence.ClientIP = "", "", ""
+	if i := index(s.presence, func(p model.UserConnectionInfo) bool { return p.ID == info.ID }); i >= 0 {
+		s.presence[i] = presence
+	} else {
+		s.presence = append(s.presence, presence)
+	}
+	return nil
+}
+
+func (r *memSessions) Remove(ctx context.Context, empId, sessionId string) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	if i := index(s.sessions, func(se memSession) bool { return se.SessionID == sessionId }); i >= 0 {
+		s.sessions = append(s.sessions[:i:i], s.sessions[i+1:]...)
+	}
+	s.dropPresence(empId)
+	return nil
+}
+
+// dropPresence removes empId's presence row when no session of theirs is
+// left.
+func (s *memState) dropPresence(empId string) {
+	if index(s.sessions, func(se memSession) bool { return se.EmpID == empId }) >= 0 {
+		return
+	}
+	if i := index(s.presence, func(p model.UserConnectionInfo) bool { return p.ID == empId }); i >= 0 {
+		s.presence = append(s.presence[:i:i], s.presence[i+1:]...)
+	}
+}
+
+func (r *memSessions) Beat(ctx context.Context, instanceId string) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	now := time.Now()
+	for i := range s.sessions {
+		if s.sessions[i].InstanceID == instanceId {
+			s.sessions[i].SeenAt = now
+		}
+	}
+	return nil
+}
+
+func (r *memSessions) ListStale(ctx context.Context, stale time.Duration) ([]string, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	cutoff := time.Now().Add(-stale)
+	seen := map[string]bool{}
+	var ids []string
+	for _, se := range s.sessions {
+		if se.SeenAt.Before(cutoff) && !seen[se.EmpID] {
+			seen[se.EmpID] = true
+			ids = append(ids, se.EmpID)
+		}
+	}
+	for _, p := range s.presence {
+		if !seen[p.ID] && index(s.sessions, func(se memSession) bool { return se.EmpID == p.ID }) < 0 {
+			seen[p.ID] = true
+			ids = append(ids, p.ID)
+		}
+	}
+	return ids, nil
+}
+
+func (r *memSessions) Sweep(ctx context.Context, empId string, stale time.Duration) (int64, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	cutoff := time.Now().Add(-stale)
+	var swept int64
+	kept := s.sessions[:0:0]
+	for _, se := range s.sessions {
+		if se.EmpID == empId && se.SeenAt.Before(cutoff) {
+			swept++
+			continue
+		}
+		kept = append(kept, se)
+	}
+	s.sessions = kept
+	s.dropPresence(empId)
+	return swept, nil
+}
@@ -0,0 +1,125 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"mainPackage/model"
+	"strconv"
+	"time"
+)
+
+type memSkills struct {
+	db *memDB
+}
+
+func (r *memSkills) List(ctx context.Context, orgId string, limit, offset int) ([]model.Skill, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	return pageOf(s.skills, func(sk model.Skill) bool { return sk.OrgID == orgId },
+		func(sk model.Skill) string { return sk.ID }, limit, offset), nil
+}
+
+func (r *memSkills) Get(ctx context.Context, orgId, skillId string) (*model.Skill, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := index(s.skills, func(sk model.Skill) bool { return sk.OrgID == orgId && sk.SkillID == skillId })
+	if i < 0 {
+		return nil, ErrNotFound
+	}
+	sk := s.skills[i]
+	return &sk, nil
+}
+
+func (r *memSkills) Create(ctx context.Context, orgId, skillId, username string, in model.SkillInsert) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	if index(s.skills, func(sk model.Skill) bool { return sk.OrgID == orgId && sk.SkillID == skillId }) >= 0 {
+		return fmt.Errorf("skill %s: %w", skillId, errDuplicate)
+	}
+	now := time.Now()
+	s.skills = append(s.skills, model.Skill{ID: s.nextID(), OrgID: orgId, SkillID: skillId, En: in.En, Th: in.Th,
+		Active: in.Active, CreatedAt: now, UpdatedAt: now, CreatedBy: username, UpdatedBy: username})
+	return nil
+}
+
+func (r *memSkills) Update(ctx context.Context, orgId, id, username string, in model.SkillUpdate) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := index(s.skills, func(sk model.Skill) bool { return sk.OrgID == orgId && sk.ID == id })
+	if i < 0 {
+		return ErrNotFound
+	}
+	sk := &s.skills[i]
+	sk.En, sk.Th, sk.Active, sk.UpdatedAt, sk.UpdatedBy = in.En, in.Th, in.Active, time.Now(), username
+	return nil
+}
+
+func (r *memSkills) Delete(ctx context.Context, orgId, id string) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := index(s.skills, func(sk model.Skill) bool { return sk.OrgID == orgId && sk.ID == id })
+	if i < 0 {
+		return ErrNotFound
+	}
+	s.skills = append(s.skills[:i:i], s.skills[i+1:]...)
+	return nil
+}
+
+func userSkillID(us model.UserSkill) string { return strconv.FormatInt(us.ID, 10) }
+
+func (r *memSkills) ListUserSkills(ctx context.Context, orgId string, limit, offset int) ([]model.UserSkill, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	return pageOf(s.userSkills, func(us model.UserSkill) bool { return us.OrgID == orgId }, userSkillID, limit, offset), nil
+}
+
+func (r *memSkills) GetUserSkill(ctx context.Context, orgId, id string) (*model.UserSkill, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := index(s.userSkills, func(us model.UserSkill) bool { return us.OrgID == orgId && userSkillID(us) == id })
+	if i < 0 {
+		return nil, ErrNotFound
+	}
+	us := s.userSkills[i]
+	return &us, nil
+}
+
+func (r *memSkills) ListSkillUsers(ctx context.Context, orgId, skillId string) ([]model.UserSkill, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	return pageOf(s.userSkills, func(us model.UserSkill) bool { return us.OrgID == orgId && us.SkillID == skillId },
+		userSkillID, len(s.userSkills), 0), nil
+}
+
+func (r *memSkills) CreateUserSkill(ctx context.Context, orgId, username string, in model.UserSkillInsert) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	now := time.Now()
+	id, _ := strconv.ParseInt(s.nextID(), 10, 64)
+	s.userSkills = append(s.userSkills, model.UserSkill{ID: id, OrgID: orgId, UserName: in.UserName, SkillID: in.SkillID,
+		Active: in.Active, CreatedAt: now, UpdatedAt: now, CreatedBy: username, UpdatedBy: username})
+	return nil
+}
+
+func (r *memSkills) UpdateUserSkill(ctx context.Context, orgId, id, username string, in model.UserSkillUpdate) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := index(s.userSkills, func(us model.UserSkill) bool { return us.OrgID == orgId && userSkillID(us) == id })
+	if i < 0 {
+		return ErrNotFound
+	}
+	us := &s.userSkills[i]
+	us.SkillID, us.Active, us.UpdatedAt, us.UpdatedBy = in.SkillID, in.Active, time.Now(), username
+	return nil
+}
+
+func (r *memSkills) DeleteUserSkill(ctx context.Context, orgId, id string) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := index(s.userSkills, func(us model.UserSkill) bool { return us.OrgID == orgId && userSkillID(us) == id })
+	if i < 0 {
+		return ErrNotFound
+	}
+	s.userSkills = append(s.userSkills[:i:i], s.userSkills[i+1:]...)
+	return nil
+}
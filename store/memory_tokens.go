@@ -0,0 +1,90 @@
+package store
+
+import (
+	"context"
+	"mainPackage/model"
+	"time"
+)
+
+type memTokens struct {
+	db *memDB
+}
+
+func (r *memTokens) Create(ctx context.Context, in model.RefreshTokenRecord) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	if findToken(s, in.JTI) >= 0 {
+		return errDuplicate
+	}
+	s.tokens = append(s.tokens, in)
+	return nil
+}
+
+func findToken(s *memState, jti string) int {
+	for i, t := range s.tokens {
+		if t.JTI == jti {
+			return i
+		}
+	}
+	return -1
+}
+
+func (r *memTokens) Get(ctx context.Context, jti string) (*model.RefreshTokenRecord, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := findToken(s, jti)
+	if i < 0 {
+		return nil, ErrNotFound
+	}
+	t := s.tokens[i]
+	return &t, nil
+}
+
+func (r *memTokens) Rotate(ctx context.Context, jti, next string, at time.Time) (bool, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := findToken(s, jti)
+	if i < 0 || s.tokens[i].UsedAt != nil || s.tokens[i].RevokedAt != nil {
+		return false, nil
+	}
+	s.tokens[i].UsedAt, s.tokens[i].ReplacedBy = &at, &next
+	return true, nil
+}
+
+func (r *memTokens) revoke(match func(t model.RefreshTokenRecord) bool, reason string, at time.Time) int64 {
+	s := r.db.lock()
+	defer r.db.unlock()
+	var n int64
+	for i := range s.tokens {
+		t := &s.tokens[i]
+		if t.RevokedAt == nil && match(*t) {
+			t.RevokedAt, t.RevokeReason = &at, &reason
+			n++
+		}
+	}
+	return n
+}
+
+func (r *memTokens) RevokeFamily(ctx context.Context, familyId, reason string, at time.Time) (int64, error) {
+	return r.revoke(func(t model.RefreshTokenRecord) bool { return t.FamilyID == familyId }, reason, at), nil
+}
+
+func (r *memTokens) RevokeUser(ctx context.Context, orgId, username, reason string, at time.Time) (int64, error) {
+	return r.revoke(func(t model.RefreshTokenRecord) bool {
+		return t.OrgID == orgId && t.Username == username
+	}, reason, at), nil
+}
+
+func (r *memTokens) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	kept := s.tokens[:0:0]
+	for _, t := range s.tokens {
+		if !t.ExpiresAt.Before(now) {
+			kept = append(kept, t)
+		}
+	}
+	n := int64(len(s.tokens) - len(kept))
+	s.tokens = kept
+	return n, nil
+}
@@ -0,0 +1,125 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"mainPackage/model"
+	"sort"
+	"time"
+)
+
+type memTransitions struct {
+	db *memDB
+}
+
+func findTransition(s *memState, orgId string, match func(model.CaseStatusTransition) bool) int {
+	for i, t := range s.transitions {
+		if t.OrgID == orgId && match(t) {
+			return i
+		}
+	}
+	return -1
+}
+
+// pairTaken mirrors the unique ("orgId", "fromStatusId", "toStatusId")
+// constraint and the one initial transition per organization.
+func pairTaken(s *memState, orgId string, in model.CaseStatusTransitionInput, exceptId string) bool {
+	return findTransition(s, orgId, func(t model.CaseStatusTransition) bool {
+		return t.FromStatusID == in.FromStatusID && (t.ToStatusID == in.ToStatusID || in.FromStatusID == "") &&
+			t.ID != exceptId
+	}) >= 0
+}
+
+func (r *memTransitions) List(ctx context.Context, orgId string) ([]model.CaseStatusTransition, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	var list []model.CaseStatusTransition
+	for _, t := range s.transitions {
+		if t.OrgID == orgId {
+			list = append(list, t)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].FromStatusID != list[j].FromStatusID {
+			return list[i].FromStatusID < list[j].FromStatusID
+		}
+		return list[i].ToStatusID < list[j].ToStatusID
+	})
+	return list, nil
+}
+
+func (r *memTransitions) Get(ctx context.Context, orgId, id string) (*model.CaseStatusTransition, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := findTransition(s, orgId, func(t model.CaseStatusTransition) bool { return t.ID == id })
+	if i < 0 {
+		return nil, ErrNotFound
+	}
+	t := s.transitions[i]
+	return &t, nil
+}
+
+func (r *memTransitions) Find(ctx context.Context, orgId, fromStatusId, toStatusId string) (*model.CaseStatusTransition, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := findTransition(s, orgId, func(t model.CaseStatusTransition) bool {
+		return t.FromStatusID == fromStatusId && t.ToStatusID == toStatusId
+	})
+	if i < 0 {
+		return nil, ErrNotFound
+	}
+	t := s.transitions[i]
+	return &t, nil
+}
+
+func (r *memTransitions) Initial(ctx context.Context, orgId string) (*model.CaseStatusTransition, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := findTransition(s, orgId, func(t model.CaseStatusTransition) bool { return t.FromStatusID == "" })
+	if i < 0 {
+		return nil, ErrNotFound
+	}
+	t := s.transitions[i]
+	return &t, nil
+}
+
+func (r *memTransitions) Create(ctx context.Context, orgId, username string, in model.CaseStatusTransitionInput) (string, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	if pairTaken(s, orgId, in, "") {
+		return "", fmt.Errorf("transition %s to %s: %w", in.FromStatusID, in.ToStatusID, errDuplicate)
+	}
+	now := time.Now()
+	t := model.CaseStatusTransition{ID: s.nextID(), OrgID: orgId, FromStatusID: in.FromStatusID,
+		ToStatusID: in.ToStatusID, Stamp: in.Stamp, Active: in.Active,
+		CreatedAt: now, UpdatedAt: now, CreatedBy: username, UpdatedBy: username}
+	s.transitions = append(s.transitions, t)
+	return t.ID, nil
+}
+
+func (r *memTransitions) Update(ctx context.Context, orgId, id, username string, in model.CaseStatusTransitionInput) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := findTransition(s, orgId, func(t model.CaseStatusTransition) bool { return t.ID == id })
+	if i < 0 {
+		return ErrNotFound
+	}
+	if pairTaken(s, orgId, in, id) {
+		return fmt.Errorf("transition %s to %s: %w", in.FromStatusID, in.ToStatusID, errDuplicate)
+	}
+	t := &s.transitions[i]
+	t.FromStatusID, t.ToStatusID, t.Stamp, t.Active = in.FromStatusID, in.ToStatusID, in.Stamp, in.Active
+	t.UpdatedAt, t.UpdatedBy = time.Now(), username
+	return nil
+}
+
+func (r *memTransitions) Delete(ctx context.Context, orgId, id string) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := findTransition(s, orgId, func(t model.CaseStatusTransition) bool { return t.ID == id })
+	if i < 0 {
+		return ErrNotFound
+	}
+	s.transitions = append(s.transitions[:i:i], s.transitions[i+1:]...)
+	return nil
+}
@@ -0,0 +1,252 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"mainPackage/model"
+	"strconv"
+	"time"
+)
+
+type memUnits struct {
+	db *memDB
+}
+
+func findUnit(s *memState, orgId, id string) int {
+	for i, u := range s.units {
+		if u.OrgID == orgId && u.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func (r *memUnits) List(ctx context.Context, orgId string, limit, offset int) ([]model.MmdUnit, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	var list []model.MmdUnit
+	for _, u := range s.units {
+		if u.OrgID == orgId {
+			list = append(list, u)
+		}
+	}
+	start, end := page(len(list), limit, offset)
+	return list[start:end], nil
+}
+
+func (r *memUnits) GetByID(ctx context.Context, orgId, id string) (*model.MmdUnit, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := findUnit(s, orgId, id)
+	if i < 0 {
+		return nil, ErrNotFound
+	}
+	u := s.units[i]
+	return &u, nil
+}
+
+func (r *memUnits) GetByUnitID(ctx context.Context, orgId, unitId string) (*model.MmdUnit, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	for _, u := range s.units {
+		if u.OrgID == orgId && u.UnitID == unitId {
+			return &u, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (r *memUnits) Create(ctx context.Context, orgId, username string, in model.MmdUnitInsert) (string, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	for _, u := range s.units {
+		if u.UnitID == in.UnitID {
+			return "", fmt.Errorf("unit %s: %w", in.UnitID, errDuplicate)
+		}
+	}
+	now := time.Now()
+	u := model.MmdUnit{
+		ID: s.nextID(), OrgID: orgId, UnitID: in.UnitID, UnitName: in.UnitName, UnitSourceID: in.UnitSourceID,
+		UnitTypeID: in.UnitTypeID, Priority: in.Priority, CompID: in.CompID, DeptID: in.DeptID, CommID: in.CommID,
+		StnID: in.StnID, PlateNo: in.PlateNo, ProvinceCode: in.ProvinceCode, Active: in.Active,
+		Username: in.Username, IsLogin: in.IsLogin, IsFreeze: in.IsFreeze, IsOutArea: in.IsOutArea,
+		LocLat: in.LocLat, LocLon: in.LocLon, LocAlt: in.LocAlt, LocBearing: in.LocBearing, LocSpeed: in.LocSpeed,
+		LocProvider: in.LocProvider, LocGpsTime: in.LocGpsTime, LocSatellites: in.LocSatellites,
+		LocAccuracy: in.LocAccuracy, LocLastUpdateTime: in.LocLastUpdateTime, BreakDuration: in.BreakDuration,
+		HealthChk: strconv.FormatBool(in.HealthChk), HealthChkTime: in.HealthChkTime, SttID: in.SttID,
+		CreatedAt: now, UpdatedAt: now, CreatedBy: username, UpdatedBy: username,
+	}
+	s.units = append(s.units, u)
+	return u.ID, nil
+}
+
+func (r *memUnits) Update(ctx context.Context, orgId, id, username string, in model.MmdUnitUpdate) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := findUnit(s, orgId, id)
+	if i < 0 {
+		return nil
+	}
+	u := &s.units[i]
+	u.UnitID, u.UnitName, u.UnitSourceID, u.UnitTypeID = in.UnitID, in.UnitName, in.UnitSourceID, in.UnitTypeID
+	u.Priority, u.CompID, u.DeptID, u.CommID, u.StnID = in.Priority, in.CompID, in.DeptID, in.CommID, in.StnID
+	u.PlateNo, u.ProvinceCode, u.Active, u.Username = in.PlateNo, in.ProvinceCode, in.Active, in.Username
+	u.IsLogin, u.IsFreeze, u.IsOutArea = in.IsLogin, in.IsFreeze, in.IsOutArea
+	u.LocLat, u.LocLon, u.LocAlt, u.LocBearing, u.LocSpeed = in.LocLat, in.LocLon, in.LocAlt, in.LocBearing, in.LocSpeed
+	u.LocProvider, u.LocGpsTime, u.LocSatellites, u.LocAccuracy = in.LocProvider, in.LocGpsTime, in.LocSatellites, in.LocAccuracy
+	u.LocLastUpdateTime, u.BreakDuration = in.LocLastUpdateTime, in.BreakDuration
+	u.HealthChk, u.HealthChkTime, u.SttID = in.HealthChk, in.HealthChkTime, in.SttID
+	u.UpdatedAt, u.UpdatedBy = time.Now(), username
+	return nil
+}
+
+func (r *memUnits) Delete(ctx context.Context, orgId, id string) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := findUnit(s, orgId, id)
+	if i < 0 {
+		return nil
+	}
+	unitId := s.units[i].UnitID
+	s.units = append(s.units[:i:i], s.units[i+1:]...)
+
+	// mdm_unit_with_properties cascades.
+	props := s.props[:0:0]
+	for _, p := range s.props {
+		if p.UnitID != unitId {
+			props = append(props, p)
+		}
+	}
+	s.props = props
+	return nil
+}
+
+func (r *memUnits) SetStatus(ctx context.Context, orgId, unitId, sttId, username string) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	for i := range s.units {
+		if u := &s.units[i]; u.OrgID == orgId && u.UnitID == unitId {
+			u.SttID, u.UpdatedAt, u.UpdatedBy = sttId, time.Now(), username
+			return nil
+		}
+	}
+	return ErrNotFound
+}
+
+func (r *memUnits) ListProperties(ctx context.Context, orgId, unitId string, limit, offset int) ([]model.MdmUnitProperty, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	var list []model.MdmUnitProperty
+	for _, p := range s.props {
+		if p.OrgID == orgId && p.UnitID == unitId {
+			list = append(list, p)
+		}
+	}
+	start, end := page(len(list), limit, offset)
+	return list[start:end], nil
+}
+
+// FindCandidates applies the same matching as the Postgres query: every
+// required property, an active crew member with one of the skills, and the
+// case district inside the crew member's response area.
+func (r *memUnits) FindCandidates(ctx context.Context, orgId, caseId string) ([]model.UnitUser, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+
+	var kase *model.Case
+	for i, c := range s.cases {
+		if c.OrgID == orgId && c.CaseID == caseId {
+			kase = &s.cases[i]
+		}
+	}
+	if kase == nil {
+		return nil, nil
+	}
+	var subType *model.CaseSubType
+	for i, st := range s.subTypes {
+		if st.STypeID == kase.CaseSTypeID && st.Active {
+			subType = &s.subTypes[i]
+		}
+	}
+	if subType == nil {
+		return nil, nil
+	}
+
+	var list []model.UnitUser
+	for _, u := range s.units {
+		if !u.Active || !s.unitHasProps(u.UnitID, subType.UnitPropLists) ||
+			!s.userActive(u.Username) || !s.userHasSkill(u.Username, subType.UserSkillList) ||
+			!s.userCovers(orgId, u.Username, kase.DistID) {
+			continue
+		}
+		list = append(list, model.UnitUser{
+			OrgID: u.OrgID, UnitID: u.UnitID, UnitName: u.UnitName, UnitSourceID: u.UnitSourceID,
+			UnitTypeID: u.UnitTypeID, Priority: u.Priority, CompID: u.CompID, DeptID: u.DeptID, CommID: u.CommID,
+			StnID: u.StnID, PlateNo: u.PlateNo, ProvinceCode: u.ProvinceCode, Active: u.Active,
+			Username: u.Username, IsLogin: u.IsLogin, IsFreeze: u.IsFreeze, IsOutArea: u.IsOutArea,
+			LocLat: u.LocLat, LocLon: u.LocLon, LocAlt: u.LocAlt, LocBearing: u.LocBearing, LocSpeed: u.LocSpeed,
+			LocProvider: u.LocProvider, LocGpsTime: u.LocGpsTime, LocSatellites: u.LocSatellites,
+			LocAccuracy: u.LocAccuracy, LocLastUpdateTime: u.LocLastUpdateTime, BreakDuration: u.BreakDuration,
+			HealthChk: u.HealthChk, HealthChkTime: u.HealthChkTime, SttID: u.SttID,
+			CreatedBy: u.CreatedBy, UpdatedBy: u.UpdatedBy,
+		})
+	}
+	return list, nil
+}
+
+// unitHasProps reports whether the unit has at least one active property
+// and every required one.
+func (s *memState) unitHasProps(unitId string, required []string) bool {
+	have := map[string]bool{}
+	for _, p := range s.props {
+		if p.UnitID == unitId && p.Active {
+			have[p.PropID] = true
+		}
+	}
+	if len(have) == 0 {
+		return false
+	}
+	for _, id := range required {
+		if !have[id] {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *memState) userActive(username string) bool {
+	for _, u := range s.users {
+		if u.Username == username {
+			return u.Active
+		}
+	}
+	return false
+}
+
+func (s *memState) userHasSkill(username string, skills []string) bool {
+	for _, sk := range s.userSkills {
+		if sk.UserName != username || !sk.Active {
+			continue
+		}
+		for _, id := range skills {
+			if sk.SkillID == id {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (s *memState) userCovers(orgId, username, distId string) bool {
+	for _, a := range s.areas {
+		if a.OrgID != orgId || a.Username != username {
+			continue
+		}
+		for _, d := range a.DistIDs {
+			if d == distId {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,213 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"mainPackage/model"
+	"strconv"
+	"time"
+)
+
+type memUsers struct {
+	db *memDB
+}
+
+func (r *memUsers) List(ctx context.Context, orgId string, limit, offset int) ([]model.Um_User, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	var list []model.Um_User
+	for _, u := range s.users {
+		if u.OrgID == orgId {
+			list = append(list, u)
+		}
+	}
+	start, end := page(len(list), limit, offset)
+	return list[start:end], nil
+}
+
+func findUser(s *memState, orgId string, match func(u model.Um_User) bool) int {
+	for i, u := range s.users {
+		if u.OrgID == orgId && match(u) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (r *memUsers) GetByID(ctx context.Context, orgId, id string) (*model.Um_User, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := findUser(s, orgId, func(u model.Um_User) bool { return u.ID == id })
+	if i < 0 {
+		return nil, ErrNotFound
+	}
+	u := s.users[i]
+	for _, us := range s.userSkills {
+		if us.OrgID != orgId || us.UserName != u.Username || !us.Active {
+			continue
+		}
+		if i := index(s.skills, func(sk model.Skill) bool { return sk.OrgID == orgId && sk.SkillID == us.SkillID }); i >= 0 {
+			sk := s.skills[i]
+			u.Skills = append(u.Skills, map[string]interface{}{"skillId": sk.SkillID, "en": sk.En, "th": sk.Th})
+		}
+	}
+	for _, a := range s.areas {
+		if a.OrgID != orgId || a.Username != u.Username {
+			continue
+		}
+		for _, d := range s.districts {
+			for _, distId := range a.DistIDs {
+				if d.OrgID == orgId && d.DistID == distId {
+					u.Areas = append(u.Areas, map[string]interface{}{"distId": d.DistID, "th": d.Th, "en": d.En})
+				}
+			}
+		}
+	}
+	return &u, nil
+}
+
+func (r *memUsers) GetByUsername(ctx context.Context, orgId, username string) (*model.Um_User, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := findUser(s, orgId, func(u model.Um_User) bool { return u.Username == username })
+	if i < 0 {
+		return nil, ErrNotFound
+	}
+	orgName, ok := s.orgs[orgId]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	u := s.users[i]
+	u.OrgName = orgName
+	for _, role := range s.roles {
+		if role.ID == u.RoleID {
+			u.RoleName = role.RoleName
+		}
+	}
+	return &u, nil
+}
+
+func (r *memUsers) GetActiveByUsername(ctx context.Context, username string) (*model.Um_User, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	for _, u := range s.users {
+		if u.Username == username && u.Active {
+			return &u, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (r *memUsers) GetOrgIDByName(ctx context.Context, name string) (string, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	for id, n := range s.orgs {
+		if n == name {
+			return id, nil
+		}
+	}
+	return "", ErrNotFound
+}
+
+func (r *memUsers) ListPermissionIDs(ctx context.Context, orgId, roleId string) ([]string, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	var ids []string
+	for _, g := range s.grants {
+		if g.OrgID == orgId && g.RoleID == roleId && g.Active {
+			ids = append(ids, g.PermID)
+		}
+	}
+	return ids, nil
+}
+
+func (r *memUsers) Create(ctx context.Context, in model.UserWrite, createdBy string) (string, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	for _, u := range s.users {
+		if u.Username == in.Username {
+			return "", fmt.Errorf("user %s: %w", in.Username, errDuplicate)
+		}
+	}
+	now := time.Now()
+	u := model.Um_User{ID: s.nextID(), CreatedAt: now, CreatedBy: createdBy}
+	applyUserWrite(&u, in, now, createdBy)
+	u.ActivationToken = in.ActivationToken
+	s.users = append(s.users, u)
+	return u.ID, nil
+}
+
+// applyUserWrite copies the columns written by both insert and update.
+func applyUserWrite(u *model.Um_User, in model.UserWrite, now time.Time, by string) {
+	text := func(v string) *string { return &v }
+	number := func(v *int64) string {
+		if v == nil {
+			return ""
+		}
+		return strconv.FormatInt(*v, 10)
+	}
+	u.OrgID, u.DisplayName, u.Title, u.FirstName = in.OrgID, in.DisplayName, in.Title, in.FirstName
+	u.MiddleName, u.LastName, u.CitizenID = text(in.MiddleName), in.LastName, in.CitizenID
+	u.Bod = timeOf(in.Bod)
+	u.Blood, u.Gender, u.MobileNo, u.Address = in.Blood, number(in.Gender), text(in.MobileNo), text(in.Address)
+	u.Photo, u.Username, u.Password, u.Email = in.Photo, in.Username, in.Password, text(in.Email)
+	u.RoleID, u.UserType, u.EmpID = in.RoleID, number(in.UserType), in.EmpID
+	u.DeptID, u.CommID, u.StnID, u.Active = in.DeptID, in.CommID, in.StnID, in.Active
+	u.LastActivationRequest, u.LostPasswordRequest, u.SignupStamp = in.LastActivationRequest, in.LostPasswordRequest, in.SignupStamp
+	u.IsLogin, u.LastLogin = in.IsLogin, in.LastLogin
+	u.UpdatedAt, u.UpdatedBy = now, by
+}
+
+func (r *memUsers) Update(ctx context.Context, orgId, id string, in model.UserWrite, updatedBy string) error {
+	return r.update(orgId, func(u model.Um_User) bool { return u.ID == id }, in, updatedBy)
+}
+
+func (r *memUsers) UpdateByUsername(ctx context.Context, orgId, username string, in model.UserWrite, updatedBy string) error {
+	return r.update(orgId, func(u model.Um_User) bool { return u.Username == username }, in, updatedBy)
+}
+
+func (r *memUsers) update(orgId string, match func(u model.Um_User) bool, in model.UserWrite, updatedBy string) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := findUser(s, orgId, match)
+	if i < 0 {
+		return nil
+	}
+	// The update statement leaves orgId alone.
+	in.OrgID = orgId
+	applyUserWrite(&s.users[i], in, time.Now(), updatedBy)
+	return nil
+}
+
+func (r *memUsers) Delete(ctx context.Context, orgId, id string) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	if i := findUser(s, orgId, func(u model.Um_User) bool { return u.ID == id }); i >= 0 {
+		s.users = append(s.users[:i:i], s.users[i+1:]...)
+	}
+	return nil
+}
+
+func (r *memUsers) SetPassword(ctx context.Context, id, hash string) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	for i := range s.users {
+		if s.users[i].ID == id {
+			s.users[i].Password = hash
+			return nil
+		}
+	}
+	return ErrNotFound
+}
+
+func (r *memUsers) ListCredentials(ctx context.Context) ([]model.UserCredential, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	var list []model.UserCredential
+	for _, u := range s.users {
+		list = append(list, model.UserCredential{
+			ID: u.ID, OrgID: u.OrgID, Username: u.Username, Active: u.Active, Password: u.Password,
+		})
+	}
+	return list, nil
+}
@@ -0,0 +1,336 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"mainPackage/model"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type memWorkflows struct {
+	db *memDB
+}
+
+func (r *memWorkflows) List(ctx context.Context, orgId string, limit, offset int) ([]model.WorkflowModel, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	var list []model.WorkflowModel
+	for _, w := range s.wfDefs {
+		if w.OrgID == orgId {
+			list = append(list, w)
+		}
+	}
+	start, end := page(len(list), limit, offset)
+	return list[start:end], nil
+}
+
+func findWorkflow(s *memState, orgId, wfId string) int {
+	for i, w := range s.wfDefs {
+		if w.OrgID == orgId && w.WfID == wfId {
+			return i
+		}
+	}
+	return -1
+}
+
+func (r *memWorkflows) GetDefinition(ctx context.Context, orgId, wfId string) (*model.WorkflowModel, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := findWorkflow(s, orgId, wfId)
+	if i < 0 {
+		return nil, ErrNotFound
+	}
+	w := s.wfDefs[i]
+	return &w, nil
+}
+
+func (r *memWorkflows) Get(ctx context.Context, orgId, wfId, versions string) (*model.WorkFlow, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := findWorkflow(s, orgId, wfId)
+	if i < 0 {
+		return nil, ErrNotFound
+	}
+	if versions == "" {
+		versions = Draft
+	}
+	def := s.wfDefs[i]
+	id, _ := strconv.Atoi(def.ID)
+	title, desc := def.Title, def.Desc
+	wf := model.WorkFlow{MetaData: model.WorkFlowMetadata{
+		Id: id, Title: &title, Desc: &desc, Status: &versions, CreatedAt: def.CreatedAt, UpdatedAt: def.UpdatedAt,
+	}}
+	for _, n := range s.wfNodes {
+		if n.OrgID != orgId || n.WfID != wfId || n.Versions != versions {
+			continue
+		}
+		switch n.Section {
+		case "nodes":
+			if node, ok := n.raw.(map[string]interface{}); ok {
+				wf.Nodes = append(wf.Nodes, node)
+			}
+		case "connections":
+			edges, _ := n.raw.([]interface{})
+			for _, e := range edges {
+				if edge, ok := e.(map[string]interface{}); ok {
+					wf.Connections = append(wf.Connections, edge)
+				}
+			}
+		}
+	}
+	return &wf, nil
+}
+
+func (r *memWorkflows) Create(ctx context.Context, orgId, wfId, username string, in model.WorkFlowInsert) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	if findWorkflow(s, orgId, wfId) >= 0 {
+		return fmt.Errorf("workflow %s: %w", wfId, errDuplicate)
+	}
+	now := time.Now()
+	s.wfDefs = append(s.wfDefs, model.WorkflowModel{
+		ID: s.nextID(), OrgID: orgId, WfID: wfId, Title: deref(in.MetaData.Title), Desc: deref(in.MetaData.Desc),
+		Active: true, Versions: Draft, CreatedAt: now, UpdatedAt: now, CreatedBy: username, UpdatedBy: username,
+	})
+	return memInsertNodes(s, orgId, wfId, Draft, username, in)
+}
+
+func (r *memWorkflows) Update(ctx context.Context, orgId, wfId, username string, in model.WorkFlowInsert) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	if i := findWorkflow(s, orgId, wfId); i >= 0 {
+		w := &s.wfDefs[i]
+		w.Title, w.Desc = deref(in.MetaData.Title), deref(in.MetaData.Desc)
+		w.UpdatedAt, w.UpdatedBy = time.Now(), username
+	}
+	s.deleteNodes(orgId, wfId, Draft)
+	return memInsertNodes(s, orgId, wfId, Draft, username, in)
+}
+
+// deleteNodes drops the wf_nodes rows of one version, or of every version
+// when versions is empty.
+func (s *memState) deleteNodes(orgId, wfId, versions string) {
+	nodes := s.wfNodes[:0:0]
+	for _, n := range s.wfNodes {
+		if n.OrgID != orgId || n.WfID != wfId || (versions != "" && n.Versions != versions) {
+			nodes = append(nodes, n)
+		}
+	}
+	s.wfNodes = nodes
+}
+
+// memInsertNodes mirrors insertNodes: one row per node plus one row holding
+// every connection.
+func memInsertNodes(s *memState, orgId, wfId, versions, username string, in model.WorkFlowInsert) error {
+	now := time.Now()
+	row := func(nodeId, typ, section string, data interface{}) (memWfNode, error) {
+		raw, err := roundTrip(data)
+		if err != nil {
+			return memWfNode{}, err
+		}
+		n := memWfNode{WfNode: model.WfNode{
+			ID: s.nextID(), OrgID: orgId, WfID: wfId, NodeID: nodeId, Versions: versions, Type: typ,
+			Section: section, CreatedAt: now, UpdatedAt: now, CreatedBy: username, UpdatedBy: username,
+		}, raw: raw}
+		n.Data, _ = raw.(map[string]interface{})
+		return n, nil
+	}
+	for _, item := range in.Nodes {
+		n, err := row(item.Id, item.Type, "nodes", item)
+		if err != nil {
+			return err
+		}
+		n.Pic, n.Group, n.FormID = nodeRefs(item)
+		s.wfNodes = append(s.wfNodes, n)
+	}
+	n, err := row("", "", "connections", in.Connections)
+	if err != nil {
+		return err
+	}
+	s.wfNodes = append(s.wfNodes, n)
+	return nil
+}
+
+func (r *memWorkflows) Delete(ctx context.Context, orgId, wfId string) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	if i := findWorkflow(s, orgId, wfId); i >= 0 {
+		s.wfDefs = append(s.wfDefs[:i:i], s.wfDefs[i+1:]...)
+	}
+	versions := s.wfVersions[:0:0]
+	for _, v := range s.wfVersions {
+		if v.OrgID != orgId || v.WfID != wfId {
+			versions = append(versions, v)
+		}
+	}
+	s.wfVersions = versions
+	s.deleteNodes(orgId, wfId, "")
+	return nil
+}
+
+func (r *memWorkflows) Publish(ctx context.Context, orgId, wfId, username string) (string, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	i := findWorkflow(s, orgId, wfId)
+	if i < 0 {
+		return "", ErrNotFound
+	}
+	empty := true
+	next := 1
+	for _, n := range s.wfNodes {
+		if n.OrgID == orgId && n.WfID == wfId && n.Versions == Draft && n.Section == "nodes" {
+			empty = false
+		}
+	}
+	if empty {
+		return "", ErrEmptyDraft
+	}
+	for _, v := range s.wfVersions {
+		if n, _ := strconv.Atoi(v.Versions); v.OrgID == orgId && v.WfID == wfId && n >= next {
+			next = n + 1
+		}
+	}
+	versions := strconv.Itoa(next)
+	now := time.Now()
+	def := &s.wfDefs[i]
+	s.wfVersions = append(s.wfVersions, model.WorkflowVersion{
+		ID: s.nextID(), OrgID: orgId, WfID: wfId, Versions: versions, Title: def.Title, Desc: def.Desc,
+		PublishedAt: now, PublishedBy: username,
+	})
+	s.copyNodes(orgId, wfId, Draft, versions, username)
+	def.Versions, def.Publish, def.UpdatedAt, def.UpdatedBy = versions, true, now, username
+	return versions, nil
+}
+
+// copyNodes mirrors copyNodes in the Postgres store.
+func (s *memState) copyNodes(orgId, wfId, from, to, username string) {
+	now := time.Now()
+	for _, n := range s.wfNodes {
+		if n.OrgID != orgId || n.WfID != wfId || n.Versions != from {
+			continue
+		}
+		n.ID, n.Versions = s.nextID(), to
+		n.CreatedAt, n.UpdatedAt, n.CreatedBy, n.UpdatedBy = now, now, username, username
+		s.wfNodes = append(s.wfNodes, n)
+	}
+}
+
+func (r *memWorkflows) ListVersions(ctx context.Context, orgId, wfId string) ([]model.WorkflowVersion, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	var list []model.WorkflowVersion
+	for i := len(s.wfVersions) - 1; i >= 0; i-- {
+		if v := s.wfVersions[i]; v.OrgID == orgId && v.WfID == wfId {
+			list = append(list, v)
+		}
+	}
+	return list, nil
+}
+
+func (r *memWorkflows) Rollback(ctx context.Context, orgId, wfId, versions, username string) error {
+	s := r.db.lock()
+	defer r.db.unlock()
+	for _, v := range s.wfVersions {
+		if v.OrgID != orgId || v.WfID != wfId || v.Versions != versions {
+			continue
+		}
+		s.deleteNodes(orgId, wfId, Draft)
+		s.copyNodes(orgId, wfId, versions, Draft, username)
+		if i := findWorkflow(s, orgId, wfId); i >= 0 {
+			w := &s.wfDefs[i]
+			w.Title, w.Desc, w.UpdatedAt, w.UpdatedBy = v.Title, v.Desc, time.Now(), username
+		}
+		return nil
+	}
+	return ErrNotFound
+}
+
+// currentNodes returns the "nodes" rows of the definition's current version.
+func (s *memState) currentNodes(orgId, wfId string) []model.WfNode {
+	i := findWorkflow(s, orgId, wfId)
+	if i < 0 {
+		return nil
+	}
+	var list []model.WfNode
+	for _, n := range s.wfNodes {
+		if n.OrgID == orgId && n.WfID == wfId && n.Versions == s.wfDefs[i].Versions && n.Section == "nodes" {
+			list = append(list, n.WfNode)
+		}
+	}
+	return list
+}
+
+func (r *memWorkflows) GetVersionNode(ctx context.Context, orgId, wfId, versions, nodeId string) (*model.WfNode, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	for _, n := range s.wfNodes {
+		if n.OrgID == orgId && n.WfID == wfId && n.Versions == versions && n.Section == "nodes" && n.NodeID == nodeId {
+			node := n.WfNode
+			return &node, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (r *memWorkflows) ListEdges(ctx context.Context, orgId, wfId, versions string) ([]model.WorkFlowConnection, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	for _, n := range s.wfNodes {
+		if n.OrgID == orgId && n.WfID == wfId && n.Versions == versions && n.Section == "connections" {
+			var edges []model.WorkFlowConnection
+			raw, err := json.Marshal(n.raw)
+			if err != nil {
+				return nil, err
+			}
+			if err := json.Unmarshal(raw, &edges); err != nil {
+				return nil, err
+			}
+			return edges, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (r *memWorkflows) ListNodes(ctx context.Context, orgId, wfId, versions string) ([]model.WorkflowNode, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	var nodes, connections []model.WorkflowNode
+	for _, n := range s.wfNodes {
+		if n.OrgID != orgId || n.WfID != wfId || n.Versions != versions {
+			continue
+		}
+		wn := model.WorkflowNode{NodeId: n.NodeID, Type: n.Type, Section: n.Section, Data: n.raw}
+		if n.Section == "nodes" {
+			nodes = append(nodes, wn)
+		} else {
+			connections = append(connections, wn)
+		}
+	}
+	return append(nodes, connections...), nil
+}
+
+func (r *memWorkflows) ListProcessNodes(ctx context.Context, orgId, wfId string) ([]model.WfNode, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	var list []model.WfNode
+	for _, n := range s.currentNodes(orgId, wfId) {
+		if strings.ToLower(n.Type) == "process" {
+			list = append(list, n)
+		}
+	}
+	return list, nil
+}
+
+func (r *memWorkflows) GetIDByCaseSubType(ctx context.Context, orgId, sTypeId string) (string, error) {
+	s := r.db.lock()
+	defer r.db.unlock()
+	for _, st := range s.subTypes {
+		if st.OrgID == orgId && st.STypeID == sTypeId {
+			return st.WFID, nil
+		}
+	}
+	return "", ErrNotFound
+}
@@ -0,0 +1,101 @@
+package store
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// conn is the query surface shared by *pgxpool.Pool and pgx.Tx. Begin on a
+// pgx.Tx opens a savepoint, so InTx nests cleanly.
+type conn interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+// NewPostgres returns a Store backed by the shared connection pool.
+func NewPostgres(pool *pgxpool.Pool) *Store {
+	return newPostgresStore(pool)
+}
+
+func newPostgresStore(db conn) *Store {
+	return &Store{
+		Cases:         &pgCases{db: db},
+		Workflows:     &pgWorkflows{db: db},
+		Forms:         &pgForms{db: db},
+		Notifications: &pgNotifications{db: db},
+		Units:         &pgUnits{db: db},
+		Users:         &pgUsers{db: db},
+		Tokens:        &pgTokens{db: db},
+		Outbox:        &pgOutbox{db: db},
+		Escalations:   &pgEscalations{db: db},
+		Audit:         &pgAudit{db: db},
+		Transitions:   &pgTransitions{db: db},
+		Dispatches:    &pgDispatches{db: db},
+		Org:           &pgOrg{db: db},
+		Roles:         &pgRoles{db: db},
+		Skills:        &pgSkills{db: db},
+		Profiles:      &pgProfiles{db: db},
+		CaseTypes:     &pgCaseTypes{db: db},
+		Customers:     &pgCustomers{db: db},
+		MasterData:    &pgMasterData{db: db},
+		Sessions:      &pgSessions{db: db},
+		Areas:         &pgAreas{db: db},
+		Devices:       &pgDevices{db: db},
+		inTx: func(ctx context.Context, fn func(tx *Store) error) error {
+			return pgx.BeginFunc(ctx, db, func(tx pgx.Tx) error {
+				return fn(newPostgresStore(tx))
+			})
+		},
+	}
+}
+
+// notFound maps pgx.ErrNoRows onto ErrNotFound.
+func notFound(err error) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrNotFound
+	}
+	return err
+}
+
+// nullable sends an empty string as NULL, for optional uuid and jsonb
+// parameters.
+func nullable(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// affected maps an Exec that touched no row onto ErrNotFound.
+func affected(tag pgconn.CommandTag, err error) error {
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// collect scans every row with scan.
+func collect[T any](rows pgx.Rows, err error, scan func(pgx.Row) (T, error)) ([]T, error) {
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var list []T
+	for rows.Next() {
+		v, err := scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, v)
+	}
+	return list, rows.Err()
+}
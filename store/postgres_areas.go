@@ -0,0 +1,36 @@
+package store
+
+import (
+	"context"
+	"mainPackage/model"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type pgAreas struct {
+	db conn
+}
+
+func (r *pgAreas) ListDistricts(ctx context.Context, orgId string) ([]model.AreaDistrictWithDetails, error) {
+	rows, err := r.db.Query(ctx, `SELECT t1.id::text, t1."orgId"::text, t1."countryId", t1."provId", t1."distId",
+		t1.en, t1.th, t1.active, t2.en, t2.th, t2.active, t3.en, t3.th, t3.active
+	FROM public.area_districts t1
+	FULL JOIN public.area_provinces t2 ON t1."provId" = t2."provId"
+	FULL JOIN public.area_countries t3 ON t2."countryId" = t3."countryId"
+	WHERE (t1."orgId"::text = $1 OR t1."orgId" IS NULL) ORDER BY t1."countryId" NULLS LAST`, orgId)
+	return collect(rows, err, func(row pgx.Row) (model.AreaDistrictWithDetails, error) {
+		var a model.AreaDistrictWithDetails
+		err := row.Scan(&a.ID, &a.OrgID, &a.CountryID, &a.ProvID, &a.DistID,
+			&a.DistrictEn, &a.DistrictTh, &a.DistrictActive,
+			&a.ProvinceEn, &a.ProvinceTh, &a.ProvinceActive,
+			&a.CountryEn, &a.CountryTh, &a.CountryActive)
+		return a, err
+	})
+}
+
+func (r *pgAreas) InProvince(ctx context.Context, orgId, provId string, distIds []string) (bool, error) {
+	var found bool
+	err := r.db.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM public.area_districts
+	WHERE "orgId"::text = $1 AND "provId" = $2 AND "distId" = ANY($3))`, orgId, provId, distIds).Scan(&found)
+	return found, err
+}
@@ -0,0 +1,175 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mainPackage/model"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type pgAudit struct {
+	db conn
+}
+
+const auditColumns = `id, COALESCE("orgId"::text, ''), COALESCE(username, ''), COALESCE("txId", ''),
+	COALESCE("uniqueId", ''), COALESCE("mainFunc", ''), COALESCE("subFunc", ''), COALESCE("nameFunc", ''),
+	COALESCE(action, ''), COALESCE(status, 0), COALESCE(duration, 0), COALESCE("newData", ''),
+	COALESCE("oldData", ''), COALESCE("resData", ''), COALESCE(message, ''), "createdAt",
+	COALESCE("entityId", ''), COALESCE("prevHash", ''), COALESCE(hash, '')`
+
+func scanAuditRows(rows pgx.Rows) ([]model.AuditLog, error) {
+	defer rows.Close()
+	var list []model.AuditLog
+	for rows.Next() {
+		var e model.AuditLog
+		if err := rows.Scan(&e.ID, &e.OrgID, &e.Username, &e.TxID, &e.UniqueId, &e.MainFunc, &e.SubFunc,
+			&e.NameFunc, &e.Action, &e.Status, &e.Duration, &e.NewData, &e.OldData, &e.ResData, &e.Message,
+			&e.CreatedAt, &e.EntityID, &e.PrevHash, &e.Hash); err != nil {
+			return nil, err
+		}
+		list = append(list, e)
+	}
+	return list, rows.Err()
+}
+
+func (r *pgAudit) Insert(ctx context.Context, entries []model.AuditLog) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	byOrg := make(map[string][]model.AuditLog)
+	var orgs []string
+	for _, e := range entries {
+		if _, ok := byOrg[e.OrgID]; !ok {
+			orgs = append(orgs, e.OrgID)
+		}
+		byOrg[e.OrgID] = append(byOrg[e.OrgID], e)
+	}
+	// a fixed lock order keeps two writers from deadlocking
+	sort.Strings(orgs)
+	return pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		for _, orgId := range orgs {
+			// serialises writers across instances so every row links to the
+			// one committed before it
+			if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext('audit_logs:' || $1))`, orgId); err != nil {
+				return err
+			}
+			var prev string
+			err := tx.QueryRow(ctx, `SELECT COALESCE(hash, '') FROM public.audit_logs
+			WHERE "orgId" IS NOT DISTINCT FROM $1 ORDER BY id DESC LIMIT 1`, nullable(orgId)).Scan(&prev)
+			if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+				return err
+			}
+			for _, e := range byOrg[orgId] {
+				e.CreatedAt = e.CreatedAt.Truncate(time.Microsecond)
+				e.PrevHash = prev
+				e.Hash = e.ChainHash()
+				_, err := tx.Exec(ctx, `INSERT INTO public.audit_logs
+				("orgId", username, "txId", "uniqueId", "mainFunc", "subFunc", "nameFunc", action, status, duration,
+				"newData", "oldData", "resData", message, "createdAt", "entityId", "prevHash", hash)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)`,
+					nullable(e.OrgID), e.Username, e.TxID, e.UniqueId, e.MainFunc, e.SubFunc, e.NameFunc,
+					e.Action, e.Status, e.Duration, e.NewData, e.OldData, e.ResData, e.Message, e.CreatedAt,
+					nullable(e.EntityID), e.PrevHash, e.Hash)
+				if err != nil {
+					return err
+				}
+				prev = e.Hash
+			}
+		}
+		return nil
+	})
+}
+
+func (r *pgAudit) ListChain(ctx context.Context, orgId string, afterID, limit int) ([]model.AuditLog, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+auditColumns+` FROM public.audit_logs
+	WHERE "orgId" IS NOT DISTINCT FROM $1 AND id > $2
+	ORDER BY id LIMIT $3`, nullable(orgId), afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	return scanAuditRows(rows)
+}
+
+func (r *pgAudit) ChainOrgs(ctx context.Context) ([]string, error) {
+	rows, err := r.db.Query(ctx, `SELECT DISTINCT COALESCE("orgId"::text, '') FROM public.audit_logs ORDER BY 1`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var orgs []string
+	for rows.Next() {
+		var orgId string
+		if err := rows.Scan(&orgId); err != nil {
+			return nil, err
+		}
+		orgs = append(orgs, orgId)
+	}
+	return orgs, rows.Err()
+}
+
+func (r *pgAudit) ChainStart(ctx context.Context) (int, error) {
+	var id int
+	err := r.db.QueryRow(ctx, `SELECT "lastUnchainedId" FROM public.audit_chain_start`).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, nil
+	}
+	return id, err
+}
+
+func (r *pgAudit) Search(ctx context.Context, orgId string, f model.AuditLogFilter) ([]model.AuditLog, error) {
+	query := `SELECT ` + auditColumns + ` FROM public.audit_logs WHERE "orgId" = $1`
+	params := []interface{}{orgId}
+	add := func(cond string, value interface{}) {
+		params = append(params, value)
+		query += " AND " + strings.ReplaceAll(cond, "$?", fmt.Sprintf("$%d", len(params)))
+	}
+	if f.From != nil {
+		add(`"createdAt" >= $?`, *f.From)
+	}
+	if f.To != nil {
+		add(`"createdAt" < $?`, *f.To)
+	}
+	if f.Username != "" {
+		add(`username = $?`, f.Username)
+	}
+	if f.MainFunc != "" {
+		add(`"mainFunc" = $?`, f.MainFunc)
+	}
+	if f.SubFunc != "" {
+		add(`"subFunc" = $?`, f.SubFunc)
+	}
+	if f.Action != "" {
+		add(`action = $?`, f.Action)
+	}
+	if f.EntityID != "" {
+		add(`"entityId" = $?`, f.EntityID)
+	}
+	if f.Status != nil {
+		add(`status = $?`, *f.Status)
+	}
+	if f.Query != "" {
+		add(`(message ILIKE $? OR "newData" ILIKE $? OR "oldData" ILIKE $? OR "resData" ILIKE $?)`,
+			"%"+escapeLike(f.Query)+"%")
+	}
+	if f.BeforeID > 0 {
+		add(`id < $?`, f.BeforeID)
+	}
+	query += fmt.Sprintf(` ORDER BY id DESC LIMIT $%d OFFSET $%d`, len(params)+1, len(params)+2)
+	params = append(params, f.Limit, f.Offset)
+
+	rows, err := r.db.Query(ctx, query, params...)
+	if err != nil {
+		return nil, err
+	}
+	return scanAuditRows(rows)
+}
+
+// escapeLike makes the LIKE wildcards in s match themselves.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
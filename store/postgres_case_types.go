@@ -0,0 +1,169 @@
+package store
+
+import (
+	"context"
+	"mainPackage/model"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type pgCaseTypes struct {
+	db conn
+}
+
+func (r *pgCaseTypes) List(ctx context.Context, orgId string, limit, offset int) ([]model.CaseType, error) {
+	rows, err := r.db.Query(ctx, `SELECT id::text, "typeId", "orgId"::text, en, th, active,
+		"createdAt", "updatedAt", "createdBy", "updatedBy"
+	FROM public.case_types WHERE "orgId" = $1 ORDER BY id LIMIT $2 OFFSET $3`, orgId, limit, offset)
+	return collect(rows, err, func(row pgx.Row) (model.CaseType, error) {
+		var t model.CaseType
+		err := row.Scan(&t.Id, &t.TypeId, &t.OrgId, &t.En, &t.Th, &t.Active,
+			&t.CreatedAt, &t.UpdatedAt, &t.CreatedBy, &t.UpdatedBy)
+		return t, err
+	})
+}
+
+func (r *pgCaseTypes) ListWithSubTypes(ctx context.Context, orgId string) ([]model.CaseTypeWithSubType, error) {
+	rows, err := r.db.Query(ctx, `SELECT t."typeId", t."orgId"::text, COALESCE(t.en, ''), COALESCE(t.th, ''), t.active,
+		s."sTypeId", s."sTypeCode", s.en, s.th, s."wfId", s."caseSla", s.priority,
+		s."userSkillList", s."unitPropLists", s.active
+	FROM public.case_types t
+	LEFT JOIN public.case_sub_types s ON s."orgId" = t."orgId" AND s."typeId" = t."typeId"
+	WHERE t."orgId" = $1 ORDER BY t.id, s.id`, orgId)
+	return collect(rows, err, func(row pgx.Row) (model.CaseTypeWithSubType, error) {
+		var t model.CaseTypeWithSubType
+		var priority *string
+		err := row.Scan(&t.TypeID, &t.OrgID, &t.TypeEN, &t.TypeTH, &t.TypeActive,
+			&t.SubTypeID, &t.SubTypeCode, &t.SubTypeEN, &t.SubTypeTH, &t.WfID, &t.CaseSla, &priority,
+			&t.UserSkillList, &t.UnitPropLists, &t.SubTypeActive)
+		t.Priority = atoiPtr(priority)
+		return t, err
+	})
+}
+
+// atoiPtr reads a text priority column into the int the join exposes; a
+// missing or non-numeric value stays nil.
+func atoiPtr(s *string) *int {
+	if s == nil {
+		return nil
+	}
+	n, err := strconv.Atoi(*s)
+	if err != nil {
+		return nil
+	}
+	return &n
+}
+
+func (r *pgCaseTypes) Create(ctx context.Context, orgId, typeId, username string, in model.CaseTypeInsert) error {
+	_, err := r.db.Exec(ctx, `INSERT INTO public.case_types
+	("typeId", "orgId", en, th, active, "createdAt", "updatedAt", "createdBy", "updatedBy")
+	VALUES ($1, $2, $3, $4, $5, $6, $6, $7, $7)`,
+		typeId, orgId, in.En, in.Th, in.Active, time.Now(), username)
+	return err
+}
+
+func (r *pgCaseTypes) Update(ctx context.Context, orgId, id, username string, in model.CaseTypeUpdate) error {
+	return affected(r.db.Exec(ctx, `UPDATE public.case_types
+	SET en = $3, th = $4, active = $5, "updatedAt" = $6, "updatedBy" = $7
+	WHERE "orgId" = $1 AND id::text = $2`,
+		orgId, id, in.En, in.Th, in.Active, time.Now(), username))
+}
+
+func (r *pgCaseTypes) Delete(ctx context.Context, orgId, id string) error {
+	return affected(r.db.Exec(ctx, `DELETE FROM public.case_types
+	WHERE "orgId" = $1 AND id::text = $2`, orgId, id))
+}
+
+func (r *pgCaseTypes) ListSubTypes(ctx context.Context, orgId string, limit, offset int) ([]model.CaseSubType, error) {
+	rows, err := r.db.Query(ctx, `SELECT id::text, "typeId", "sTypeId", COALESCE("sTypeCode", ''), "orgId"::text,
+		COALESCE(en, ''), COALESCE(th, ''), COALESCE("wfId", ''), COALESCE("caseSla", ''), COALESCE(priority, ''),
+		"userSkillList", "unitPropLists", active, "createdAt", "updatedAt",
+		COALESCE("createdBy", ''), COALESCE("updatedBy", '')
+	FROM public.case_sub_types WHERE "orgId" = $1 ORDER BY id LIMIT $2 OFFSET $3`, orgId, limit, offset)
+	return collect(rows, err, func(row pgx.Row) (model.CaseSubType, error) {
+		var st model.CaseSubType
+		err := row.Scan(&st.Id, &st.TypeID, &st.STypeID, &st.STypeCode, &st.OrgID, &st.EN, &st.TH, &st.WFID,
+			&st.CaseSLA, &st.Priority, &st.UserSkillList, &st.UnitPropLists, &st.Active,
+			&st.CreatedAt, &st.UpdatedAt, &st.CreatedBy, &st.UpdatedBy)
+		return st, err
+	})
+}
+
+// jsonList keeps a missing list from being stored as a JSON null in the
+// NOT NULL jsonb array columns.
+func jsonList(list []string) []string {
+	if list == nil {
+		return []string{}
+	}
+	return list
+}
+
+func (r *pgCaseTypes) CreateSubType(ctx context.Context, orgId, sTypeId, username string, in model.CaseSubTypeInsert) error {
+	_, err := r.db.Exec(ctx, `INSERT INTO public.case_sub_types
+	("typeId", "sTypeId", "sTypeCode", "orgId", en, th, "wfId", "caseSla", priority,
+		"userSkillList", "unitPropLists", active, "createdAt", "updatedAt", "createdBy", "updatedBy")
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10::jsonb, $11::jsonb, $12, $13, $13, $14, $14)`,
+		in.TypeID, sTypeId, in.STypeCode, orgId, in.EN, in.TH, in.WFID, in.CaseSLA, in.Priority,
+		jsonList(in.UserSkillList), jsonList(in.UnitPropLists), in.Active, time.Now(), username)
+	return err
+}
+
+func (r *pgCaseTypes) UpdateSubType(ctx context.Context, orgId, id, username string, in model.CaseSubTypeUpdate) error {
+	return affected(r.db.Exec(ctx, `UPDATE public.case_sub_types
+	SET "sTypeCode" = $3, en = $4, th = $5, "wfId" = $6, "caseSla" = $7, priority = $8,
+		"userSkillList" = $9::jsonb, "unitPropLists" = $10::jsonb, active = $11, "updatedAt" = $12, "updatedBy" = $13
+	WHERE "orgId" = $1 AND id::text = $2`,
+		orgId, id, in.STypeCode, in.EN, in.TH, in.WFID, in.CaseSLA, in.Priority,
+		jsonList(in.UserSkillList), jsonList(in.UnitPropLists), in.Active, time.Now(), username))
+}
+
+func (r *pgCaseTypes) DeleteSubType(ctx context.Context, orgId, id string) error {
+	return affected(r.db.Exec(ctx, `DELETE FROM public.case_sub_types
+	WHERE "orgId" = $1 AND id::text = $2`, orgId, id))
+}
+
+const caseStatusColumns = `id::text, "statusId", th, en, color, active,
+	"createdAt", "updatedAt", "createdBy", "updatedBy"`
+
+func scanCaseStatus(row pgx.Row) (model.CaseStatus, error) {
+	var cs model.CaseStatus
+	err := row.Scan(&cs.ID, &cs.StatusID, &cs.Th, &cs.En, &cs.Color, &cs.Active,
+		&cs.CreatedAt, &cs.UpdatedAt, &cs.CreatedBy, &cs.UpdatedBy)
+	return cs, err
+}
+
+func (r *pgCaseTypes) ListStatuses(ctx context.Context, limit, offset int) ([]model.CaseStatus, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+caseStatusColumns+`
+	FROM public.case_status ORDER BY id LIMIT $1 OFFSET $2`, limit, offset)
+	return collect(rows, err, scanCaseStatus)
+}
+
+func (r *pgCaseTypes) GetStatus(ctx context.Context, id string) (*model.CaseStatus, error) {
+	cs, err := scanCaseStatus(r.db.QueryRow(ctx, `SELECT `+caseStatusColumns+`
+	FROM public.case_status WHERE id::text = $1`, id))
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return &cs, nil
+}
+
+func (r *pgCaseTypes) CreateStatus(ctx context.Context, statusId, username string, in model.CaseStatusInsert) error {
+	_, err := r.db.Exec(ctx, `INSERT INTO public.case_status
+	("statusId", th, en, color, active, "createdAt", "updatedAt", "createdBy", "updatedBy")
+	VALUES ($1, $2, $3, $4, $5, $6, $6, $7, $7)`,
+		statusId, in.Th, in.En, in.Color, in.Active, time.Now(), username)
+	return err
+}
+
+func (r *pgCaseTypes) UpdateStatus(ctx context.Context, id, username string, in model.CaseStatusUpdate) error {
+	return affected(r.db.Exec(ctx, `UPDATE public.case_status
+	SET th = $2, en = $3, color = $4, active = $5, "updatedAt" = $6, "updatedBy" = $7
+	WHERE id::text = $1`,
+		id, in.Th, in.En, in.Color, in.Active, time.Now(), username))
+}
+
+func (r *pgCaseTypes) DeleteStatus(ctx context.Context, id string) error {
+	return affected(r.db.Exec(ctx, `DELETE FROM public.case_status WHERE id::text = $1`, id))
+}
@@ -0,0 +1,320 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"mainPackage/model"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type pgCases struct {
+	db conn
+}
+
+const caseColumns = `id, "orgId", "caseId", "caseVersion", "referCaseId", COALESCE("caseTypeId", ''),
+	COALESCE("caseSTypeId", ''), priority, "wfId", versions, COALESCE(source, ''), COALESCE("deviceId", ''),
+	COALESCE("phoneNo", ''), "phoneNoHide", "caseDetail", COALESCE("extReceive", ''), COALESCE("statusId", ''),
+	COALESCE("caseLat", ''), COALESCE("caseLon", ''), COALESCE("caselocAddr", ''), COALESCE("caselocAddrDecs", ''),
+	COALESCE("countryId", ''), COALESCE("provId", ''), COALESCE("distId", ''), "caseDuration", "createdDate",
+	"startedDate", "commandedDate", "receivedDate", "arrivedDate", "closedDate", COALESCE(usercreate, ''),
+	COALESCE(usercommand, ''), COALESCE(userreceive, ''), COALESCE(userarrive, ''), COALESCE(userclose, ''),
+	"resId", "resDetail", "scheduleFlag", "scheduleDate", "createdAt", "updatedAt", COALESCE("createdBy", ''),
+	COALESCE("updatedBy", '')`
+
+func scanCase(row pgx.Row) (model.Case, error) {
+	var c model.Case
+	err := row.Scan(
+		&c.ID, &c.OrgID, &c.CaseID, &c.CaseVersion, &c.ReferCaseID, &c.CaseTypeID,
+		&c.CaseSTypeID, &c.Priority, &c.WfID, &c.WfVersions, &c.Source, &c.DeviceID,
+		&c.PhoneNo, &c.PhoneNoHide, &c.CaseDetail, &c.ExtReceive, &c.StatusID,
+		&c.CaseLat, &c.CaseLon, &c.CaseLocAddr, &c.CaseLocAddrDecs,
+		&c.CountryID, &c.ProvID, &c.DistID, &c.CaseDuration, &c.CreatedDate,
+		&c.StartedDate, &c.CommandedDate, &c.ReceivedDate, &c.ArrivedDate, &c.ClosedDate, &c.UserCreate,
+		&c.UserCommand, &c.UserReceive, &c.UserArrive, &c.UserClose,
+		&c.ResID, &c.ResDetail, &c.ScheduleFlag, &c.ScheduleDate, &c.CreatedAt, &c.UpdatedAt, &c.CreatedBy,
+		&c.UpdatedBy,
+	)
+	return c, err
+}
+
+func (r *pgCases) List(ctx context.Context, orgId string, f model.CaseFilter) ([]model.Case, error) {
+	query := `SELECT ` + caseColumns + ` FROM public.tix_cases WHERE "orgId" = $1`
+	params := []interface{}{orgId}
+	add := func(cond string, value interface{}) {
+		params = append(params, value)
+		query += fmt.Sprintf(" AND "+cond, len(params))
+	}
+	if f.CaseTypeID != "" {
+		add(`"caseTypeId" = $%d`, f.CaseTypeID)
+	}
+	if f.CaseSTypeID != "" {
+		add(`"caseSTypeId" = $%d`, f.CaseSTypeID)
+	}
+	if f.Detail != "" {
+		add(`"caseDetail" ILIKE $%d`, "%"+f.Detail+"%")
+	}
+	if f.StartDate != "" {
+		add(`"createdAt" >= $%d`, f.StartDate)
+	}
+	if f.EndDate != "" {
+		add(`"createdAt" <= $%d`, f.EndDate)
+	}
+	if f.StatusID != "" {
+		add(`"statusId" = $%d`, f.StatusID)
+	}
+	if f.CountryID != "" {
+		add(`"countryId" = $%d`, f.CountryID)
+	}
+	if f.ProvID != "" {
+		add(`"provId" = $%d`, f.ProvID)
+	}
+	if f.DistID != "" {
+		add(`"distId" = $%d`, f.DistID)
+	}
+	query += fmt.Sprintf(` ORDER BY "createdAt" DESC LIMIT $%d OFFSET $%d`, len(params)+1, len(params)+2)
+	params = append(params, f.Length, f.Start)
+
+	rows, err := r.db.Query(ctx, query, params...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []model.Case
+	for rows.Next() {
+		c, err := scanCase(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, c)
+	}
+	return list, rows.Err()
+}
+
+func (r *pgCases) GetByID(ctx context.Context, orgId, id string) (*model.Case, error) {
+	c, err := scanCase(r.db.QueryRow(ctx, `SELECT `+caseColumns+`
+	FROM public.tix_cases WHERE "orgId"=$1 AND id=$2`, orgId, id))
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return &c, nil
+}
+
+func (r *pgCases) GetByCaseID(ctx context.Context, orgId, caseId string) (*model.Case, error) {
+	c, err := scanCase(r.db.QueryRow(ctx, `SELECT `+caseColumns+`
+	FROM public.tix_cases WHERE "orgId"=$1 AND "caseId"=$2`, orgId, caseId))
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return &c, nil
+}
+
+func (r *pgCases) Create(ctx context.Context, orgId, caseId, username string, in model.CaseInsert) (string, error) {
+	now := time.Now()
+	var id string
+	err := r.db.QueryRow(ctx, `
+	INSERT INTO public."tix_cases"(
+	"orgId", "caseId", "caseVersion", "referCaseId", "caseTypeId", "caseSTypeId", priority, "wfId", "versions",source, "deviceId",
+	"phoneNo", "phoneNoHide", "caseDetail", "extReceive", "statusId", "caseLat", "caseLon", "caselocAddr",
+	"caselocAddrDecs", "countryId", "provId", "distId", "createdDate", "startedDate", usercreate,
+	"resId", "resDetail", "scheduleFlag", "scheduleDate", "createdAt", "updatedAt", "createdBy", "updatedBy")
+	VALUES (
+		$1, $2, $3, $4, $5, $6, $7, $8, $9, $10,
+		$11, $12, $13, $14, $15, $16, $17, $18, $19, $20,
+		$21, $22, $23, $24, $25, $26, $27, $28, $29, $30,
+		$31, $32, $33, $34
+	) RETURNING id`,
+		orgId, caseId, in.CaseVersion, in.ReferCaseID, in.CaseTypeID, in.CaseSTypeID, in.Priority, in.WfID, in.WfVersions,
+		in.Source, in.DeviceID, in.PhoneNo, in.PhoneNoHide, in.CaseDetail, in.ExtReceive, in.StatusID,
+		in.CaseLat, in.CaseLon, in.CaseLocAddr, in.CaseLocAddrDecs, in.CountryID, in.ProvID, in.DistID,
+		in.CreatedDate, in.StartedDate, in.UserCreate, in.ResID,
+		in.ResDetail, in.ScheduleFlag, in.ScheduleDate, now, now, username, username).Scan(&id)
+	return id, err
+}
+
+func (r *pgCases) Update(ctx context.Context, orgId, id, username string, in model.CaseUpdate) error {
+	_, err := r.db.Exec(ctx, `UPDATE public."tix_cases"
+	SET "caseVersion"=$3, "referCaseId"=$4, "caseTypeId"=$5, "caseSTypeId"=$6,
+	 priority=$7, source=$8, "deviceId"=$9, "phoneNo"=$10, "phoneNoHide"=$11, "caseDetail"=$12, "extReceive"=$13,
+	  "caseLat"=$14, "caseLon"=$15, "caselocAddr"=$16, "caselocAddrDecs"=$17, "countryId"=$18,
+	   "provId"=$19, "distId"=$20, "createdDate"=$21, "startedDate"=$22, usercreate=$23,
+		 "resId"=$24, "resDetail"=$25, "scheduleFlag"=$26 , "scheduleDate"=$27, "updatedAt"=$28,"updatedBy"=$29
+	WHERE id = $1 AND "orgId"=$2`,
+		id, orgId, in.CaseVersion, in.ReferCaseID, in.CaseTypeID, in.CaseSTypeID, in.Priority,
+		in.Source, in.DeviceID, in.PhoneNo, in.PhoneNoHide, in.CaseDetail, in.ExtReceive,
+		in.CaseLat, in.CaseLon, in.CaseLocAddr, in.CaseLocAddrDecs, in.CountryID, in.ProvID, in.DistID,
+		in.CreatedDate, in.StartedDate, in.UserCreate, in.ResID,
+		in.ResDetail, in.ScheduleFlag, in.ScheduleDate, time.Now(), username)
+	return err
+}
+
+func (r *pgCases) SetStatus(ctx context.Context, orgId, fromStatusId, username string, c model.Case) error {
+	tag, err := r.db.Exec(ctx, `UPDATE public."tix_cases"
+	SET "statusId"=$4, "commandedDate"=$5, "receivedDate"=$6, "arrivedDate"=$7, "closedDate"=$8,
+	 usercommand=$9, userreceive=$10, userarrive=$11, userclose=$12, "caseDuration"=$13,
+	  "updatedAt"=$14, "updatedBy"=$15
+	WHERE id = $1 AND "orgId"=$2 AND COALESCE("statusId", '') = $3`,
+		c.ID, orgId, fromStatusId, c.StatusID, c.CommandedDate, c.ReceivedDate, c.ArrivedDate, c.ClosedDate,
+		c.UserCommand, c.UserReceive, c.UserArrive, c.UserClose, c.CaseDuration, time.Now(), username)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *pgCases) Delete(ctx context.Context, orgId, id string) error {
+	_, err := r.db.Exec(ctx, `DELETE FROM public."tix_cases" WHERE id = $1 AND "orgId"=$2`, id, orgId)
+	return err
+}
+
+func (r *pgCases) GetCurrentStage(ctx context.Context, orgId, caseId string) (*model.CurrentStage, error) {
+	var s model.CurrentStage
+	err := r.db.QueryRow(ctx, `
+	SELECT COALESCE("wfId", ''), "caseId", "nodeId", COALESCE(versions, ''), COALESCE(type, ''),
+	       COALESCE(section, ''), data, pic, "group", "formId"
+	FROM public.tix_case_current_stage
+	WHERE "orgId"=$1 AND "caseId"=$2
+	ORDER BY id DESC LIMIT 1`, orgId, caseId).
+		Scan(&s.WfId, &s.CaseId, &s.NodeId, &s.Versions, &s.Type, &s.Section, &s.Data, &s.Pic, &s.Group, &s.FormId)
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return &s, nil
+}
+
+func (r *pgCases) CreateCurrentStage(ctx context.Context, orgId, caseId, username string, node model.WfNode) error {
+	now := time.Now()
+	_, err := r.db.Exec(ctx, `
+	INSERT INTO public.tix_case_current_stage(
+		"orgId", "caseId", "wfId", "nodeId", "stageType", "unitId", "username", versions, type, section, data, pic, "group", "formId",
+		"createdAt", "updatedAt", "createdBy", "updatedBy"
+	) VALUES (
+		$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11,
+		$12, $13, $14, $15, $16, $17, $18
+	)`,
+		orgId, caseId, node.WfID, node.NodeID, "case", "", "", node.Versions,
+		node.Type, node.Section, node.Data, node.Pic,
+		node.Group, node.FormID, now, now, username, username)
+	return err
+}
+
+func (r *pgCases) UpdateCurrentStage(ctx context.Context, orgId, caseId, username, fromNodeId string, node model.WfNode) error {
+	tag, err := r.db.Exec(ctx, `
+	UPDATE public.tix_case_current_stage
+	SET "wfId"=$3, "nodeId"=$4, versions=$5, type=$6, section=$7, data=$8, pic=$9, "group"=$10, "formId"=$11,
+		"updatedAt"=$12, "updatedBy"=$13
+	WHERE id = (
+		SELECT id FROM public.tix_case_current_stage
+		WHERE "orgId"=$1 AND "caseId"=$2
+		ORDER BY id DESC LIMIT 1
+	) AND "nodeId"=$14`,
+		orgId, caseId, node.WfID, node.NodeID, node.Versions, node.Type, node.Section, node.Data,
+		node.Pic, node.Group, node.FormID, time.Now(), username, fromNodeId)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *pgCases) ListStageHistory(ctx context.Context, orgId, caseId string) ([]model.CaseStageHistory, error) {
+	rows, err := r.db.Query(ctx, `
+	SELECT id, "orgId", "caseId", "wfId", versions, "fromNodeId", "toNodeId", COALESCE(label, ''),
+	       "createdAt", COALESCE("createdBy", '')
+	FROM public.tix_case_stage_history
+	WHERE "orgId"=$1 AND "caseId"=$2
+	ORDER BY id`, orgId, caseId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []model.CaseStageHistory
+	for rows.Next() {
+		var h model.CaseStageHistory
+		if err := rows.Scan(&h.ID, &h.OrgID, &h.CaseID, &h.WfID, &h.Versions, &h.FromNodeID, &h.ToNodeID,
+			&h.Label, &h.CreatedAt, &h.CreatedBy); err != nil {
+			return nil, err
+		}
+		list = append(list, h)
+	}
+	return list, rows.Err()
+}
+
+func (r *pgCases) AddStageHistory(ctx context.Context, orgId, username string, in model.CaseStageHistoryInsert) (int, error) {
+	var id int
+	err := r.db.QueryRow(ctx, `
+	INSERT INTO public.tix_case_stage_history(
+		"orgId", "caseId", "wfId", versions, "fromNodeId", "toNodeId", label, "createdAt", "createdBy")
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	RETURNING id`,
+		orgId, in.CaseID, in.WfID, in.Versions, in.FromNodeID, in.ToNodeID, nullable(in.Label), time.Now(), username).
+		Scan(&id)
+	return id, err
+}
+
+const historyColumns = `id, "orgId", "caseId", COALESCE(username, ''), COALESCE(type, ''), COALESCE("fullMsg", ''),
+	"jsonData", "createdAt", COALESCE("createdBy", '')`
+
+func scanHistory(rows pgx.Rows) ([]model.CaseHistory, error) {
+	defer rows.Close()
+	var list []model.CaseHistory
+	for rows.Next() {
+		var h model.CaseHistory
+		if err := rows.Scan(&h.ID, &h.OrgID, &h.CaseID, &h.Username, &h.Type, &h.FullMsg,
+			&h.JSONData, &h.CreatedAt, &h.CreatedBy); err != nil {
+			return nil, err
+		}
+		list = append(list, h)
+	}
+	return list, rows.Err()
+}
+
+func (r *pgCases) ListHistory(ctx context.Context, orgId string, limit, offset int) ([]model.CaseHistory, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+historyColumns+`
+	FROM public.tix_case_history_events WHERE "orgId"=$1 ORDER BY id LIMIT $2 OFFSET $3`, orgId, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	return scanHistory(rows)
+}
+
+func (r *pgCases) ListHistoryByCaseID(ctx context.Context, orgId, caseId string) ([]model.CaseHistory, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+historyColumns+`
+	FROM public.tix_case_history_events WHERE "orgId"=$1 AND "caseId"=$2 ORDER BY "createdAt", id`, orgId, caseId)
+	if err != nil {
+		return nil, err
+	}
+	return scanHistory(rows)
+}
+
+func (r *pgCases) AddHistory(ctx context.Context, orgId, username string, in model.CaseHistoryInsert) (int, error) {
+	var id int
+	err := r.db.QueryRow(ctx, `
+	INSERT INTO public.tix_case_history_events(
+	"orgId", "caseId", username, type, "fullMsg", "jsonData", "createdAt", "createdBy")
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	RETURNING id`,
+		orgId, in.CaseID, username, in.Type, in.FullMsg, nullable(in.JSONData), time.Now(), username).Scan(&id)
+	return id, err
+}
+
+func (r *pgCases) UpdateHistory(ctx context.Context, orgId, id, username string, in model.CaseHistoryUpdate) error {
+	_, err := r.db.Exec(ctx, `UPDATE public."tix_case_history_events"
+	SET username=$3, type=$4, "fullMsg"=$5, "jsonData"=$6
+	WHERE id = $1 AND "orgId"=$2`,
+		id, orgId, username, in.Type, in.FullMsg, nullable(in.JSONData))
+	return err
+}
+
+func (r *pgCases) DeleteHistory(ctx context.Context, orgId, id string) error {
+	_, err := r.db.Exec(ctx, `DELETE FROM public.tix_case_history_events WHERE id = $1 AND "orgId"=$2`, id, orgId)
+	return err
+}
@@ -0,0 +1,187 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"mainPackage/model"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type pgCustomers struct {
+	db conn
+}
+
+const customerColumns = `id::text, "orgId"::text, COALESCE("displayName", ''), COALESCE(title, ''),
+	COALESCE("firstName", ''), COALESCE("middleName", ''), COALESCE("lastName", ''), COALESCE("citizenId", ''),
+	dob, COALESCE(blood, ''), COALESCE(gender, ''), COALESCE("mobileNo", ''), address, COALESCE(photo, ''),
+	COALESCE(email, ''), COALESCE(usertype, ''), active, "createdAt", "updatedAt",
+	COALESCE("createdBy", ''), COALESCE("updatedBy", '')`
+
+func scanCustomer(row pgx.Row) (model.Customer, error) {
+	var cu model.Customer
+	var dob *time.Time
+	err := row.Scan(&cu.ID, &cu.OrgID, &cu.DisplayName, &cu.Title, &cu.FirstName, &cu.MiddleName, &cu.LastName,
+		&cu.CitizenID, &dob, &cu.Blood, &cu.Gender, &cu.MobileNo, &cu.Address, &cu.Photo, &cu.Email, &cu.UserType,
+		&cu.Active, &cu.CreatedAt, &cu.UpdatedAt, &cu.CreatedBy, &cu.UpdatedBy)
+	if dob != nil {
+		cu.DOB = *dob
+	}
+	return cu, err
+}
+
+func (r *pgCustomers) List(ctx context.Context, orgId string, limit, offset int) ([]model.Customer, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+customerColumns+`
+	FROM public.cust_customers WHERE "orgId" = $1 ORDER BY id LIMIT $2 OFFSET $3`, orgId, limit, offset)
+	return collect(rows, err, scanCustomer)
+}
+
+func (r *pgCustomers) Get(ctx context.Context, orgId, id string) (*model.Customer, error) {
+	cu, err := scanCustomer(r.db.QueryRow(ctx, `SELECT `+customerColumns+`
+	FROM public.cust_customers WHERE "orgId" = $1 AND id::text = $2`, orgId, id))
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return &cu, nil
+}
+
+func (r *pgCustomers) Create(ctx context.Context, orgId, username string, in model.CustomerInsert) error {
+	_, err := r.db.Exec(ctx, `INSERT INTO public.cust_customers
+	("orgId", "displayName", title, "firstName", "middleName", "lastName", "citizenId", dob, blood, gender,
+		"mobileNo", address, photo, email, usertype, active, "createdAt", "updatedAt", "createdBy", "updatedBy")
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $17, $18, $18)`,
+		orgId, in.DisplayName, in.Title, in.FirstName, in.MiddleName, in.LastName, in.CitizenID, in.DOB, in.Blood,
+		in.Gender, in.MobileNo, in.Address, in.Photo, in.Email, in.UserType, in.Active, time.Now(), username)
+	return err
+}
+
+func (r *pgCustomers) Update(ctx context.Context, orgId, id, username string, in model.CustomerUpdate) error {
+	return affected(r.db.Exec(ctx, `UPDATE public.cust_customers
+	SET "displayName" = $3, title = $4, "firstName" = $5, "middleName" = $6, "lastName" = $7, "citizenId" = $8,
+		dob = $9, blood = $10, gender = $11, "mobileNo" = $12, address = $13, photo = $14, email = $15,
+		usertype = $16, active = $17, "updatedAt" = $18, "updatedBy" = $19
+	WHERE "orgId" = $1 AND id::text = $2`,
+		orgId, id, in.DisplayName, in.Title, in.FirstName, in.MiddleName, in.LastName, in.CitizenID, in.DOB,
+		in.Blood, in.Gender, in.MobileNo, in.Address, in.Photo, in.Email, in.UserType, in.Active, time.Now(), username))
+}
+
+func (r *pgCustomers) Delete(ctx context.Context, orgId, id string) error {
+	return affected(r.db.Exec(ctx, `DELETE FROM public.cust_customers
+	WHERE "orgId" = $1 AND id::text = $2`, orgId, id))
+}
+
+const customerSocialColumns = `id::text, "orgId"::text, "custId", COALESCE("socialType", ''), COALESCE("socialId", ''),
+	COALESCE("socialName", ''), COALESCE("imgUrl", ''), "createdAt", "updatedAt",
+	COALESCE("createdBy", ''), COALESCE("updatedBy", '')`
+
+func scanCustomerSocial(row pgx.Row) (model.CustomerSocial, error) {
+	var so model.CustomerSocial
+	err := row.Scan(&so.ID, &so.OrgID, &so.CustID, &so.SocialType, &so.SocialID, &so.SocialName, &so.ImgURL,
+		&so.CreatedAt, &so.UpdatedAt, &so.CreatedBy, &so.UpdatedBy)
+	return so, err
+}
+
+func (r *pgCustomers) ListSocials(ctx context.Context, orgId string, limit, offset int) ([]model.CustomerSocial, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+customerSocialColumns+`
+	FROM public.cust_customer_with_socials WHERE "orgId" = $1 ORDER BY id LIMIT $2 OFFSET $3`, orgId, limit, offset)
+	return collect(rows, err, scanCustomerSocial)
+}
+
+func (r *pgCustomers) GetSocial(ctx context.Context, orgId, id string) (*model.CustomerSocial, error) {
+	so, err := scanCustomerSocial(r.db.QueryRow(ctx, `SELECT `+customerSocialColumns+`
+	FROM public.cust_customer_with_socials WHERE "orgId" = $1 AND id::text = $2`, orgId, id))
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return &so, nil
+}
+
+func (r *pgCustomers) CreateSocial(ctx context.Context, orgId, username string, in model.CustomerSocialInsert) error {
+	_, err := r.db.Exec(ctx, `INSERT INTO public.cust_customer_with_socials
+	("orgId", "custId", "socialType", "socialId", "socialName", "imgUrl", "createdAt", "updatedAt", "createdBy", "updatedBy")
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $7, $8, $8)`,
+		orgId, in.CustID, in.SocialType, in.SocialID, in.SocialName, in.ImgURL, time.Now(), username)
+	return err
+}
+
+func (r *pgCustomers) UpdateSocial(ctx context.Context, orgId, id, username string, in model.CustomerSocialUpdate) error {
+	return affected(r.db.Exec(ctx, `UPDATE public.cust_customer_with_socials
+	SET "custId" = $3, "socialType" = $4, "socialId" = $5, "socialName" = $6, "imgUrl" = $7,
+		"updatedAt" = $8, "updatedBy" = $9
+	WHERE "orgId" = $1 AND id::text = $2`,
+		orgId, id, in.CustID, in.SocialType, in.SocialID, in.SocialName, in.ImgURL, time.Now(), username))
+}
+
+func (r *pgCustomers) DeleteSocial(ctx context.Context, orgId, id string) error {
+	return affected(r.db.Exec(ctx, `DELETE FROM public.cust_customer_with_socials
+	WHERE "orgId" = $1 AND id::text = $2`, orgId, id))
+}
+
+const customerContactColumns = `id::text, "orgId"::text, "custId", COALESCE("contactName", ''),
+	COALESCE("contactPhone", ''), COALESCE("contactAddr", ''), "createdAt", "updatedAt",
+	COALESCE("createdBy", ''), COALESCE("updatedBy", '')`
+
+// scanCustomerContact reads the text custId and contactAddr columns into the
+// number and JSON object the API exposes. An address that is not a JSON
+// object is left nil.
+func scanCustomerContact(row pgx.Row) (model.CustomerContact, error) {
+	var ct model.CustomerContact
+	var custId, addr string
+	err := row.Scan(&ct.ID, &ct.OrgID, &custId, &ct.ContactName, &ct.ContactPhone, &addr,
+		&ct.CreatedAt, &ct.UpdatedAt, &ct.CreatedBy, &ct.UpdatedBy)
+	ct.CustID, _ = strconv.Atoi(custId)
+	if addr != "" {
+		_ = json.Unmarshal([]byte(addr), &ct.ContactAddr)
+	}
+	return ct, err
+}
+
+// contactAddr stores a contact address object as the JSON text the column
+// holds.
+func contactAddr(addr map[string]interface{}) interface{} {
+	if addr == nil {
+		return nil
+	}
+	b, err := json.Marshal(addr)
+	if err != nil {
+		return nil
+	}
+	return string(b)
+}
+
+func (r *pgCustomers) ListContacts(ctx context.Context, orgId string, limit, offset int) ([]model.CustomerContact, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+customerContactColumns+`
+	FROM public.cust_contacts WHERE "orgId" = $1 ORDER BY id LIMIT $2 OFFSET $3`, orgId, limit, offset)
+	return collect(rows, err, scanCustomerContact)
+}
+
+func (r *pgCustomers) GetContact(ctx context.Context, orgId, id string) (*model.CustomerContact, error) {
+	ct, err := scanCustomerContact(r.db.QueryRow(ctx, `SELECT `+customerContactColumns+`
+	FROM public.cust_contacts WHERE "orgId" = $1 AND id::text = $2`, orgId, id))
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return &ct, nil
+}
+
+func (r *pgCustomers) CreateContact(ctx context.Context, orgId, username string, in model.CustomerContactInsert) error {
+	_, err := r.db.Exec(ctx, `INSERT INTO public.cust_contacts
+	("orgId", "custId", "contactName", "contactPhone", "contactAddr", "createdAt", "updatedAt", "createdBy", "updatedBy")
+	VALUES ($1, $2, $3, $4, $5, $6, $6, $7, $7)`,
+		orgId, strconv.Itoa(in.CustID), in.ContactName, in.ContactPhone, contactAddr(in.ContactAddr), time.Now(), username)
+	return err
+}
+
+func (r *pgCustomers) UpdateContact(ctx context.Context, orgId, id, username string, in model.CustomerContactUpdate) error {
+	return affected(r.db.Exec(ctx, `UPDATE public.cust_contacts
+	SET "custId" = $3, "contactName" = $4, "contactPhone" = $5, "contactAddr" = $6, "updatedAt" = $7, "updatedBy" = $8
+	WHERE "orgId" = $1 AND id::text = $2`,
+		orgId, id, strconv.Itoa(in.CustID), in.ContactName, in.ContactPhone, contactAddr(in.ContactAddr), time.Now(), username))
+}
+
+func (r *pgCustomers) DeleteContact(ctx context.Context, orgId, id string) error {
+	return affected(r.db.Exec(ctx, `DELETE FROM public.cust_contacts
+	WHERE "orgId" = $1 AND id::text = $2`, orgId, id))
+}
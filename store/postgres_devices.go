@@ -0,0 +1,38 @@
+package store
+
+import (
+	"context"
+	"mainPackage/model"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type pgDevices struct {
+	db conn
+}
+
+const deviceColumns = `"orgId"::text, "deviceId", COALESCE("deviceType", ''), COALESCE(model, ''),
+	COALESCE("firmwareVer", ''), COALESCE(latitude, ''), COALESCE(longitude, ''), COALESCE("ipAddress", ''),
+	COALESCE("macAddress", ''), "createdAt", "updatedAt", COALESCE("createdBy", ''), COALESCE("updatedBy", '')`
+
+func scanDevice(row pgx.Row) (model.DeviceIoT, error) {
+	var d model.DeviceIoT
+	err := row.Scan(&d.OrgID, &d.DeviceID, &d.DeviceType, &d.Model, &d.FirmwareVer, &d.Latitude, &d.Longitude,
+		&d.IPAddress, &d.MacAddress, &d.CreatedAt, &d.UpdatedAt, &d.CreatedBy, &d.UpdatedBy)
+	return d, err
+}
+
+func (r *pgDevices) List(ctx context.Context, orgId string, limit, offset int) ([]model.DeviceIoT, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+deviceColumns+`
+	FROM public.device_iot WHERE "orgId"::text = $1 ORDER BY id LIMIT $2 OFFSET $3`, orgId, limit, offset)
+	return collect(rows, err, scanDevice)
+}
+
+func (r *pgDevices) Get(ctx context.Context, orgId, deviceId string) (*model.DeviceIoT, error) {
+	d, err := scanDevice(r.db.QueryRow(ctx, `SELECT `+deviceColumns+`
+	FROM public.device_iot WHERE "orgId"::text = $1 AND "deviceId" = $2`, orgId, deviceId))
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return &d, nil
+}
@@ -0,0 +1,80 @@
+package store
+
+import (
+	"context"
+	"mainPackage/model"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type pgDispatches struct {
+	db conn
+}
+
+const dispatchColumns = `id::text, "orgId"::text, "caseId", "unitId", COALESCE(username, ''), status,
+	COALESCE("assignedBy", ''), "assignedAt", "acknowledgedAt", "enRouteAt", "onSceneAt", "completedAt",
+	"releasedAt", COALESCE("releasedBy", ''), "createdAt", "updatedAt", COALESCE("createdBy", ''),
+	COALESCE("updatedBy", '')`
+
+func scanDispatch(row pgx.Row) (model.CaseDispatch, error) {
+	var d model.CaseDispatch
+	err := row.Scan(&d.ID, &d.OrgID, &d.CaseID, &d.UnitID, &d.Username, &d.Status,
+		&d.AssignedBy, &d.AssignedAt, &d.AcknowledgedAt, &d.EnRouteAt, &d.OnSceneAt, &d.CompletedAt,
+		&d.ReleasedAt, &d.ReleasedBy, &d.CreatedAt, &d.UpdatedAt, &d.CreatedBy, &d.UpdatedBy)
+	return d, err
+}
+
+func (r *pgDispatches) ListByCase(ctx context.Context, orgId, caseId string) ([]model.CaseDispatch, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+dispatchColumns+`
+	FROM public.case_dispatches WHERE "orgId"=$1 AND "caseId"=$2 ORDER BY "assignedAt", id`, orgId, caseId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var list []model.CaseDispatch
+	for rows.Next() {
+		d, err := scanDispatch(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, d)
+	}
+	return list, rows.Err()
+}
+
+func (r *pgDispatches) GetOpen(ctx context.Context, orgId, unitId string) (*model.CaseDispatch, error) {
+	d, err := scanDispatch(r.db.QueryRow(ctx, `SELECT `+dispatchColumns+`
+	FROM public.case_dispatches WHERE "orgId"=$1 AND "unitId"=$2 AND status <> 'released'`, orgId, unitId))
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return &d, nil
+}
+
+func (r *pgDispatches) Create(ctx context.Context, orgId, username string, d model.CaseDispatch) (string, error) {
+	var id string
+	err := r.db.QueryRow(ctx, `INSERT INTO public.case_dispatches
+	("orgId", "caseId", "unitId", username, status, "assignedBy", "assignedAt",
+	 "createdAt", "updatedAt", "createdBy", "updatedBy")
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $8, $9, $9) RETURNING id::text`,
+		orgId, d.CaseID, d.UnitID, nullable(d.Username), d.Status, d.AssignedBy, d.AssignedAt,
+		time.Now(), username).Scan(&id)
+	return id, err
+}
+
+func (r *pgDispatches) Update(ctx context.Context, orgId, fromStatus, username string, d model.CaseDispatch) error {
+	tag, err := r.db.Exec(ctx, `UPDATE public.case_dispatches
+	SET status=$4, "acknowledgedAt"=$5, "enRouteAt"=$6, "onSceneAt"=$7, "completedAt"=$8,
+	 "releasedAt"=$9, "releasedBy"=$10, "updatedAt"=$11, "updatedBy"=$12
+	WHERE id::text=$1 AND "orgId"=$2 AND status=$3`,
+		d.ID, orgId, fromStatus, d.Status, d.AcknowledgedAt, d.EnRouteAt, d.OnSceneAt, d.CompletedAt,
+		d.ReleasedAt, nullable(d.ReleasedBy), time.Now(), username)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"mainPackage/model"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type pgEscalations struct {
+	db conn
+}
+
+const escalationColumns = `id::text, "orgId"::text, "eventType", steps, active, "createdAt", "updatedAt",
+	COALESCE("createdBy", ''), COALESCE("updatedBy", '')`
+
+func scanEscalation(row pgx.Row) (model.EscalationPolicy, error) {
+	var p model.EscalationPolicy
+	var steps []byte
+	err := row.Scan(&p.ID, &p.OrgID, &p.EventType, &steps, &p.Active, &p.CreatedAt, &p.UpdatedAt,
+		&p.CreatedBy, &p.UpdatedBy)
+	if err != nil {
+		return p, err
+	}
+	return p, json.Unmarshal(steps, &p.Steps)
+}
+
+func collectEscalations(rows pgx.Rows, err error) ([]model.EscalationPolicy, error) {
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var list []model.EscalationPolicy
+	for rows.Next() {
+		p, err := scanEscalation(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, p)
+	}
+	return list, rows.Err()
+}
+
+func (r *pgEscalations) List(ctx context.Context, orgId string) ([]model.EscalationPolicy, error) {
+	return collectEscalations(r.db.Query(ctx, `SELECT `+escalationColumns+`
+	FROM public.notification_escalation_policies WHERE "orgId"::text = $1 ORDER BY "eventType"`, orgId))
+}
+
+func (r *pgEscalations) ListActive(ctx context.Context) ([]model.EscalationPolicy, error) {
+	return collectEscalations(r.db.Query(ctx, `SELECT `+escalationColumns+`
+	FROM public.notification_escalation_policies WHERE active ORDER BY "orgId", "eventType"`))
+}
+
+func (r *pgEscalations) Get(ctx context.Context, orgId, id string) (*model.EscalationPolicy, error) {
+	p, err := scanEscalation(r.db.QueryRow(ctx, `SELECT `+escalationColumns+`
+	FROM public.notification_escalation_policies WHERE "orgId"::text = $1 AND id::text = $2`, orgId, id))
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return &p, nil
+}
+
+func (r *pgEscalations) Create(ctx context.Context, orgId, username string, in model.EscalationPolicyInput) (string, error) {
+	steps, err := json.Marshal(in.Steps)
+	if err != nil {
+		return "", err
+	}
+	now := time.Now()
+	var id string
+	err = r.db.QueryRow(ctx, `INSERT INTO public.notification_escalation_policies
+	("orgId", "eventType", steps, active, "createdAt", "updatedAt", "createdBy", "updatedBy")
+	VALUES ($1, $2, $3, $4, $5, $5, $6, $6) RETURNING id::text`,
+		orgId, in.EventType, string(steps), in.Active, now, username).Scan(&id)
+	return id, err
+}
+
+func (r *pgEscalations) Update(ctx context.Context, orgId, id, username string, in model.EscalationPolicyInput) error {
+	steps, err := json.Marshal(in.Steps)
+	if err != nil {
+		return err
+	}
+	tag, err := r.db.Exec(ctx, `UPDATE public.notification_escalation_policies
+	SET "eventType" = $3, steps = $4, active = $5, "updatedAt" = $6, "updatedBy" = $7
+	WHERE "orgId"::text = $1 AND id::text = $2`,
+		orgId, id, in.EventType, string(steps), in.Active, time.Now(), username)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *pgEscalations) Delete(ctx context.Context, orgId, id string) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM public.notification_escalation_policies
+	WHERE "orgId"::text = $1 AND id::text = $2`, orgId, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *pgEscalations) ResolveUnits(ctx context.Context, orgId string, stnIds, commIds []string) ([]model.OrgUnit, error) {
+	rows, err := r.db.Query(ctx, `
+	SELECT "deptId", "commId", "stnId" FROM public.sec_stations
+	WHERE "orgId"::text = $1 AND "stnId" = ANY($2)
+	UNION ALL
+	SELECT "deptId", "commId", '' FROM public.sec_commands
+	WHERE "orgId"::text = $1 AND "commId" = ANY($3)`, orgId, stnIds, commIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var list []model.OrgUnit
+	for rows.Next() {
+		var u model.OrgUnit
+		if err := rows.Scan(&u.DeptID, &u.CommID, &u.StnID); err != nil {
+			return nil, err
+		}
+		list = append(list, u)
+	}
+	return list, rows.Err()
+}
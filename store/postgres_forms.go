@@ -0,0 +1,336 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"mainPackage/model"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type pgForms struct {
+	db conn
+}
+
+func (r *pgForms) Get(ctx context.Context, orgId, formId, versions string) (*model.Form, error) {
+	var form model.Form
+	err := r.db.QueryRow(ctx, `SELECT "formId", "formName", "formColSpan"
+	FROM public.form_builder WHERE "formId" = $1 AND "orgId"=$2`, formId, orgId).
+		Scan(&form.FormId, &form.FormName, &form.FormColSpan)
+	if err != nil {
+		return nil, notFound(err)
+	}
+	form.FormFieldJson, err = r.elements(ctx, orgId, formId, versions)
+	if err != nil {
+		return nil, err
+	}
+	return &form, nil
+}
+
+func (r *pgForms) GetCurrent(ctx context.Context, orgId, formId string) (*model.Form, error) {
+	versions, err := r.CurrentVersion(ctx, orgId, formId)
+	if err != nil {
+		return nil, err
+	}
+	return r.Get(ctx, orgId, formId, versions)
+}
+
+func (r *pgForms) CurrentVersion(ctx context.Context, orgId, formId string) (string, error) {
+	var versions string
+	err := r.db.QueryRow(ctx, `SELECT versions FROM public.form_builder WHERE "formId" = $1 AND "orgId"=$2`,
+		formId, orgId).Scan(&versions)
+	if err != nil {
+		return "", notFound(err)
+	}
+	return versions, nil
+}
+
+func (r *pgForms) elements(ctx context.Context, orgId, formId, versions string) ([]map[string]interface{}, error) {
+	rows, err := r.db.Query(ctx, `SELECT "eleData" FROM public.form_elements
+	WHERE "orgId"=$1 AND "formId"=$2 AND versions=$3 ORDER BY "eleNumber"`, orgId, formId, versions)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var fields []map[string]interface{}
+	for rows.Next() {
+		var raw []byte
+		if err := rows.Scan(&raw); err != nil {
+			return nil, err
+		}
+		var field map[string]interface{}
+		if err := json.Unmarshal(raw, &field); err != nil {
+			return nil, err
+		}
+		fields = append(fields, field)
+	}
+	return fields, rows.Err()
+}
+
+func (r *pgForms) List(ctx context.Context, orgId string) ([]model.FormsManager, error) {
+	rows, err := r.db.Query(ctx, `SELECT t1."formId", t1.versions, t1.active, t1.publish, t1."formName", t1.locks,
+	t1."formColSpan", t2."eleData", t1."createdAt", t1."updatedAt", COALESCE(t1."createdBy", ''), COALESCE(t1."updatedBy", '')
+	FROM public.form_builder t1
+	INNER JOIN public.form_elements t2
+	ON t1."formId" = t2."formId" AND t1.versions = t2.versions AND t1."orgId" = t2."orgId"
+	WHERE t1."orgId" = $1
+	ORDER BY t1.id, t2."eleNumber"`, orgId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var forms []model.FormsManager
+	index := map[string]int{}
+	for rows.Next() {
+		var f model.FormsManager
+		var raw []byte
+		if err := rows.Scan(&f.FormId, &f.Versions, &f.Active, &f.Publish, &f.FormName, &f.Locks,
+			&f.FormColSpan, &raw, &f.CreatedAt, &f.UpdatedAt, &f.CreatedBy, &f.UpdatedBy); err != nil {
+			return nil, err
+		}
+		var field map[string]interface{}
+		if err := json.Unmarshal(raw, &field); err != nil {
+			return nil, err
+		}
+		if i, ok := index[*f.FormId]; ok {
+			forms[i].FormFieldJson = append(forms[i].FormFieldJson, field)
+			continue
+		}
+		f.FormFieldJson = []map[string]interface{}{field}
+		index[*f.FormId] = len(forms)
+		forms = append(forms, f)
+	}
+	return forms, rows.Err()
+}
+
+func (r *pgForms) NameExists(ctx context.Context, orgId, name string) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow(ctx, `SELECT EXISTS (
+		SELECT 1 FROM public."form_builder" WHERE "orgId" = $1 AND "formName" = $2
+	)`, orgId, name).Scan(&exists)
+	return exists, err
+}
+
+func (r *pgForms) Exists(ctx context.Context, orgId, formId string) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow(ctx, `SELECT EXISTS (
+		SELECT 1 FROM public."form_builder" WHERE "orgId" = $1 AND "formId" = $2
+	)`, orgId, formId).Scan(&exists)
+	return exists, err
+}
+
+func (r *pgForms) Create(ctx context.Context, orgId, formId, username string, in model.FormInsert) error {
+	return pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		now := time.Now()
+		_, err := tx.Exec(ctx, `
+	INSERT INTO public."form_builder"(
+	"orgId", "formId", "formName", "formColSpan", active, publish, versions, locks, "createdAt", "updatedAt", "createdBy", "updatedBy")
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`,
+			orgId, formId, in.FormName, in.FormColSpan, in.Active, false, Draft, in.Locks, now,
+			now, username, username)
+		if err != nil {
+			return err
+		}
+		return insertElements(ctx, tx, orgId, formId, Draft, username, in.FormFieldJson)
+	})
+}
+
+func (r *pgForms) Update(ctx context.Context, orgId, formId, username string, in model.FormUpdate) error {
+	return pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		var locks bool
+		err := tx.QueryRow(ctx, `SELECT locks FROM public.form_builder
+	WHERE "formId"=$1 AND "orgId"=$2 FOR UPDATE`, formId, orgId).Scan(&locks)
+		if err != nil {
+			return notFound(err)
+		}
+		if locks {
+			return ErrLocked
+		}
+		// versions and publish belong to FormPublish; the edit only reaches
+		// the draft, so cases keep using the published elements.
+		_, err = tx.Exec(ctx, `
+	UPDATE public.form_builder
+	SET "formName"=$2, "formColSpan"=$3, active=$4, locks=$5, "updatedAt"=$6,"updatedBy"=$7
+	WHERE "formId"=$1 AND "orgId"=$8`, formId,
+			in.FormName, in.FormColSpan, in.Active, in.Locks, time.Now(), username, orgId)
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(ctx, `DELETE FROM public.form_elements WHERE "formId"=$1 AND "orgId"=$2 AND versions=$3`,
+			formId, orgId, Draft)
+		if err != nil {
+			return err
+		}
+		return insertElements(ctx, tx, orgId, formId, Draft, username, in.FormFieldJson)
+	})
+}
+
+func (r *pgForms) Publish(ctx context.Context, orgId, formId, username string) (string, error) {
+	var versions string
+	err := pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		var name *string
+		var colSpan int
+		err := tx.QueryRow(ctx, `SELECT "formName", "formColSpan" FROM public.form_builder
+	WHERE "orgId"=$1 AND "formId"=$2 FOR UPDATE`, orgId, formId).Scan(&name, &colSpan)
+		if err != nil {
+			return notFound(err)
+		}
+		var elements int
+		err = tx.QueryRow(ctx, `SELECT COUNT(*) FROM public.form_elements
+	WHERE "orgId"=$1 AND "formId"=$2 AND versions=$3`, orgId, formId, Draft).Scan(&elements)
+		if err != nil {
+			return err
+		}
+		if elements == 0 {
+			return ErrEmptyDraft
+		}
+		err = tx.QueryRow(ctx, `SELECT (COALESCE(MAX(versions::int), 0) + 1)::text FROM public.form_versions
+	WHERE "orgId"=$1 AND "formId"=$2`, orgId, formId).Scan(&versions)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		_, err = tx.Exec(ctx, `INSERT INTO public.form_versions(
+	"orgId", "formId", versions, "formName", "formColSpan", "publishedAt", "publishedBy")
+	VALUES ($1, $2, $3, $4, $5, $6, $7)`, orgId, formId, versions, name, colSpan, now, username)
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(ctx, `
+	INSERT INTO public.form_elements(
+		"orgId", "formId", versions, "eleNumber", "eleData", "createdAt", "updatedAt", "createdBy", "updatedBy")
+	SELECT "orgId", "formId", $4, "eleNumber", "eleData", $5, $5, $6, $6
+	FROM public.form_elements
+	WHERE "orgId"=$1 AND "formId"=$2 AND versions=$3
+	ORDER BY "eleNumber"`, orgId, formId, Draft, versions, now, username)
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(ctx, `UPDATE public.form_builder
+	SET versions=$3, publish=true, "updatedAt"=$4, "updatedBy"=$5
+	WHERE "orgId"=$1 AND "formId"=$2`, orgId, formId, versions, now, username)
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+	return versions, nil
+}
+
+func (r *pgForms) ListVersions(ctx context.Context, orgId, formId string) ([]model.FormVersion, error) {
+	rows, err := r.db.Query(ctx, `SELECT id, "orgId", "formId", versions, COALESCE("formName", ''), "formColSpan",
+	"publishedAt", COALESCE("publishedBy", '')
+	FROM public.form_versions WHERE "orgId"=$1 AND "formId"=$2 ORDER BY versions::int DESC`, orgId, formId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []model.FormVersion
+	for rows.Next() {
+		var v model.FormVersion
+		if err := rows.Scan(&v.ID, &v.OrgID, &v.FormID, &v.Versions, &v.FormName, &v.FormColSpan,
+			&v.PublishedAt, &v.PublishedBy); err != nil {
+			return nil, err
+		}
+		list = append(list, v)
+	}
+	return list, rows.Err()
+}
+
+func insertElements(ctx context.Context, tx pgx.Tx, orgId, formId, versions, username string, fields []map[string]interface{}) error {
+	now := time.Now()
+	for i, item := range fields {
+		_, err := tx.Exec(ctx, `
+				INSERT INTO public.form_elements(
+				"orgId", "formId", versions, "eleNumber", "eleData", "createdAt", "updatedAt", "createdBy", "updatedBy")
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+			orgId, formId, versions, i+1, item, now, now, username, username)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *pgForms) SetActive(ctx context.Context, orgId, formId, username string, active bool) error {
+	return r.setFlag(ctx, "active", orgId, formId, username, active)
+}
+
+func (r *pgForms) SetPublish(ctx context.Context, orgId, formId, username string, publish bool) error {
+	return r.setFlag(ctx, "publish", orgId, formId, username, publish)
+}
+
+func (r *pgForms) SetLock(ctx context.Context, orgId, formId, username string, locks bool) error {
+	return r.setFlag(ctx, "locks", orgId, formId, username, locks)
+}
+
+// setFlag updates one of the fixed boolean columns; column is never user input.
+func (r *pgForms) setFlag(ctx context.Context, column, orgId, formId, username string, value bool) error {
+	_, err := r.db.Exec(ctx, `
+	UPDATE public.form_builder
+	SET `+column+`=$2, "updatedAt"=$3,"updatedBy"=$4
+	WHERE "formId"=$1 AND "orgId"=$5`, formId, value, time.Now(), username, orgId)
+	return err
+}
+
+func (r *pgForms) CreateSubmission(ctx context.Context, orgId, caseId, formId, username string, in model.FormSubmissionInsert) (*model.FormSubmission, error) {
+	data, err := json.Marshal(in.Data)
+	if err != nil {
+		return nil, err
+	}
+	sub := model.FormSubmission{
+		OrgID: orgId, CaseID: caseId, NodeID: in.NodeID, FormID: formId, FormVersions: in.FormVersions,
+		Data: in.Data, CreatedAt: time.Now(), CreatedBy: username,
+	}
+	err = r.db.QueryRow(ctx, `
+	INSERT INTO public.form_submissions(
+	"orgId", "caseId", "nodeId", "formId", "formVersions", revision, data, "createdAt", "createdBy")
+	SELECT $1, $2, $3, $4, $5, COALESCE(MAX(revision), 0) + 1, $6, $7, $8
+	FROM public.form_submissions
+	WHERE "orgId"=$1 AND "caseId"=$2 AND "nodeId"=$3 AND "formId"=$4
+	RETURNING id, revision`,
+		orgId, caseId, in.NodeID, formId, in.FormVersions, data, sub.CreatedAt, username).Scan(&sub.ID, &sub.Revision)
+	if err != nil {
+		return nil, err
+	}
+	return &sub, nil
+}
+
+func (r *pgForms) ListSubmissions(ctx context.Context, orgId, caseId string, f model.FormSubmissionFilter) ([]model.FormSubmission, error) {
+	query := `
+	SELECT id, "orgId", "caseId", "nodeId", "formId", "formVersions", revision, data, "createdAt",
+	 COALESCE("createdBy", '')
+	FROM public.form_submissions s
+	WHERE "orgId"=$1 AND "caseId"=$2
+	  AND ($3::text = '' OR "formId"=$3)
+	  AND ($4::text = '' OR "nodeId"=$4)`
+	if f.Latest {
+		query += `
+	  AND revision = (
+		SELECT MAX(revision) FROM public.form_submissions l
+		WHERE l."orgId"=s."orgId" AND l."caseId"=s."caseId" AND l."nodeId"=s."nodeId" AND l."formId"=s."formId"
+	  )`
+	}
+	rows, err := r.db.Query(ctx, query+`
+	ORDER BY id DESC`, orgId, caseId, f.FormID, f.NodeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []model.FormSubmission
+	for rows.Next() {
+		var sub model.FormSubmission
+		if err := rows.Scan(&sub.ID, &sub.OrgID, &sub.CaseID, &sub.NodeID, &sub.FormID, &sub.FormVersions, &sub.Revision,
+			&sub.Data, &sub.CreatedAt, &sub.CreatedBy); err != nil {
+			return nil, err
+		}
+		list = append(list, sub)
+	}
+	return list, rows.Err()
+}
@@ -0,0 +1,236 @@
+package store
+
+import (
+	"context"
+	"mainPackage/model"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type pgMasterData struct {
+	db conn
+}
+
+const catalogueColumns = `, "orgId"::text, COALESCE(en, ''), COALESCE(th, ''), active,
+	"createdAt", "updatedAt", COALESCE("createdBy", ''), COALESCE("updatedBy", '')`
+
+func scanProperty(row pgx.Row) (model.MmdProperty, error) {
+	var p model.MmdProperty
+	err := row.Scan(&p.ID, &p.PropID, &p.OrgID, &p.EN, &p.TH, &p.Active,
+		&p.CreatedAt, &p.UpdatedAt, &p.CreatedBy, &p.UpdatedBy)
+	return p, err
+}
+
+func (r *pgMasterData) ListProperties(ctx context.Context, orgId string, limit, offset int) ([]model.MmdProperty, error) {
+	rows, err := r.db.Query(ctx, `SELECT id::text, "propId"::text`+catalogueColumns+`
+	FROM public.mdm_properties WHERE "orgId" = $1 ORDER BY id LIMIT $2 OFFSET $3`, orgId, limit, offset)
+	return collect(rows, err, scanProperty)
+}
+
+func (r *pgMasterData) GetProperty(ctx context.Context, orgId, id string) (*model.MmdProperty, error) {
+	p, err := scanProperty(r.db.QueryRow(ctx, `SELECT id::text, "propId"::text`+catalogueColumns+`
+	FROM public.mdm_properties WHERE "orgId" = $1 AND id::text = $2`, orgId, id))
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return &p, nil
+}
+
+func (r *pgMasterData) CreateProperty(ctx context.Context, orgId, propId, username string, in model.MmdPropertyInsert) error {
+	_, err := r.db.Exec(ctx, `INSERT INTO public.mdm_properties
+	("propId", "orgId", en, th, active, "createdAt", "updatedAt", "createdBy", "updatedBy")
+	VALUES ($1, $2, $3, $4, $5, $6, $6, $7, $7)`,
+		propId, orgId, in.EN, in.TH, in.Active, time.Now(), username)
+	return err
+}
+
+func (r *pgMasterData) UpdateProperty(ctx context.Context, orgId, id, username string, in model.MmdPropertyUpdate) error {
+	return affected(r.db.Exec(ctx, `UPDATE public.mdm_properties
+	SET en = $3, th = $4, active = $5, "updatedAt" = $6, "updatedBy" = $7
+	WHERE "orgId" = $1 AND id::text = $2`,
+		orgId, id, in.EN, in.TH, in.Active, time.Now(), username))
+}
+
+func (r *pgMasterData) DeleteProperty(ctx context.Context, orgId, id string) error {
+	return affected(r.db.Exec(ctx, `DELETE FROM public.mdm_properties
+	WHERE "orgId" = $1 AND id::text = $2`, orgId, id))
+}
+
+func scanUnitSource(row pgx.Row) (model.MmdUnitSource, error) {
+	var us model.MmdUnitSource
+	err := row.Scan(&us.ID, &us.UnitSourceID, &us.OrgID, &us.EN, &us.TH, &us.Active,
+		&us.CreatedAt, &us.UpdatedAt, &us.CreatedBy, &us.UpdatedBy)
+	return us, err
+}
+
+func (r *pgMasterData) ListUnitSources(ctx context.Context, orgId string, limit, offset int) ([]model.MmdUnitSource, error) {
+	rows, err := r.db.Query(ctx, `SELECT id::text, "unitSourceId"`+catalogueColumns+`
+	FROM public.mdm_unit_sources WHERE "orgId" = $1 ORDER BY id LIMIT $2 OFFSET $3`, orgId, limit, offset)
+	return collect(rows, err, scanUnitSource)
+}
+
+func (r *pgMasterData) GetUnitSource(ctx context.Context, orgId, id string) (*model.MmdUnitSource, error) {
+	us, err := scanUnitSource(r.db.QueryRow(ctx, `SELECT id::text, "unitSourceId"`+catalogueColumns+`
+	FROM public.mdm_unit_sources WHERE "orgId" = $1 AND id::text = $2`, orgId, id))
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return &us, nil
+}
+
+func (r *pgMasterData) CreateUnitSource(ctx context.Context, orgId, unitSourceId, username string, in model.MmdUnitSourceInsert) error {
+	_, err := r.db.Exec(ctx, `INSERT INTO public.mdm_unit_sources
+	("unitSourceId", "orgId", en, th, active, "createdAt", "updatedAt", "createdBy", "updatedBy")
+	VALUES ($1, $2, $3, $4, $5, $6, $6, $7, $7)`,
+		unitSourceId, orgId, in.EN, in.TH, in.Active, time.Now(), username)
+	return err
+}
+
+func (r *pgMasterData) UpdateUnitSource(ctx context.Context, orgId, id, username string, in model.MmdUnitSourceUpdate) error {
+	return affected(r.db.Exec(ctx, `UPDATE public.mdm_unit_sources
+	SET en = $3, th = $4, active = $5, "updatedAt" = $6, "updatedBy" = $7
+	WHERE "orgId" = $1 AND id::text = $2`,
+		orgId, id, in.EN, in.TH, in.Active, time.Now(), username))
+}
+
+func (r *pgMasterData) DeleteUnitSource(ctx context.Context, orgId, id string) error {
+	return affected(r.db.Exec(ctx, `DELETE FROM public.mdm_unit_sources
+	WHERE "orgId" = $1 AND id::text = $2`, orgId, id))
+}
+
+func scanUnitType(row pgx.Row) (model.MmdUnitType, error) {
+	var ut model.MmdUnitType
+	err := row.Scan(&ut.ID, &ut.UnitTypeId, &ut.OrgID, &ut.EN, &ut.TH, &ut.Active,
+		&ut.CreatedAt, &ut.UpdatedAt, &ut.CreatedBy, &ut.UpdatedBy)
+	return ut, err
+}
+
+func (r *pgMasterData) ListUnitTypes(ctx context.Context, orgId string, limit, offset int) ([]model.MmdUnitType, error) {
+	rows, err := r.db.Query(ctx, `SELECT id::text, "unitTypeId"`+catalogueColumns+`
+	FROM public.mdm_unit_types WHERE "orgId" = $1 ORDER BY id LIMIT $2 OFFSET $3`, orgId, limit, offset)
+	return collect(rows, err, scanUnitType)
+}
+
+func (r *pgMasterData) GetUnitType(ctx context.Context, orgId, id string) (*model.MmdUnitType, error) {
+	ut, err := scanUnitType(r.db.QueryRow(ctx, `SELECT id::text, "unitTypeId"`+catalogueColumns+`
+	FROM public.mdm_unit_types WHERE "orgId" = $1 AND id::text = $2`, orgId, id))
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return &ut, nil
+}
+
+func (r *pgMasterData) CreateUnitType(ctx context.Context, orgId, unitTypeId, username string, in model.MmdUnitTypeInsert) error {
+	_, err := r.db.Exec(ctx, `INSERT INTO public.mdm_unit_types
+	("unitTypeId", "orgId", en, th, active, "createdAt", "updatedAt", "createdBy", "updatedBy")
+	VALUES ($1, $2, $3, $4, $5, $6, $6, $7, $7)`,
+		unitTypeId, orgId, in.EN, in.TH, in.Active, time.Now(), username)
+	return err
+}
+
+func (r *pgMasterData) UpdateUnitType(ctx context.Context, orgId, id, username string, in model.MmdUnitTypeUpdate) error {
+	return affected(r.db.Exec(ctx, `UPDATE public.mdm_unit_types
+	SET en = $3, th = $4, active = $5, "updatedAt" = $6, "updatedBy" = $7
+	WHERE "orgId" = $1 AND id::text = $2`,
+		orgId, id, in.EN, in.TH, in.Active, time.Now(), username))
+}
+
+func (r *pgMasterData) DeleteUnitType(ctx context.Context, orgId, id string) error {
+	return affected(r.db.Exec(ctx, `DELETE FROM public.mdm_unit_types
+	WHERE "orgId" = $1 AND id::text = $2`, orgId, id))
+}
+
+const companyColumns = `id::text, name, COALESCE("legalName", ''), COALESCE(domain, ''), COALESCE(email, ''),
+	COALESCE("phoneNumber", ''), address, COALESCE("logoUrl", ''), COALESCE("websiteUrl", ''),
+	COALESCE(description, ''), "createdAt", "updatedAt", COALESCE("createdBy", ''), COALESCE("updatedBy", '')`
+
+func scanCompany(row pgx.Row) (model.MmdCompanies, error) {
+	var co model.MmdCompanies
+	err := row.Scan(&co.ID, &co.Name, &co.LegalName, &co.Domain, &co.Email, &co.PhoneNumber, &co.Address,
+		&co.LogoURL, &co.WebsiteURL, &co.Description, &co.CreatedAt, &co.UpdatedAt, &co.CreatedBy, &co.UpdatedBy)
+	return co, err
+}
+
+func (r *pgMasterData) ListCompanies(ctx context.Context, limit, offset int) ([]model.MmdCompanies, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+companyColumns+`
+	FROM public.mdm_companies ORDER BY "createdAt", id LIMIT $1 OFFSET $2`, limit, offset)
+	return collect(rows, err, scanCompany)
+}
+
+func (r *pgMasterData) GetCompany(ctx context.Context, id string) (*model.MmdCompanies, error) {
+	co, err := scanCompany(r.db.QueryRow(ctx, `SELECT `+companyColumns+`
+	FROM public.mdm_companies WHERE id::text = $1`, id))
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return &co, nil
+}
+
+func (r *pgMasterData) CreateCompany(ctx context.Context, id, username string, in model.MmdCompaniesInsert) error {
+	_, err := r.db.Exec(ctx, `INSERT INTO public.mdm_companies
+	(id, name, "legalName", domain, email, "phoneNumber", address, "logoUrl", "websiteUrl", description,
+		"createdAt", "updatedAt", "createdBy", "updatedBy")
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $11, $12, $12)`,
+		id, in.Name, in.LegalName, in.Domain, in.Email, in.PhoneNumber, in.Address, in.LogoURL, in.WebsiteURL,
+		in.Description, time.Now(), username)
+	return err
+}
+
+func (r *pgMasterData) UpdateCompany(ctx context.Context, id, username string, in model.MmdCompaniesUpdate) error {
+	return affected(r.db.Exec(ctx, `UPDATE public.mdm_companies
+	SET name = $2, "legalName" = $3, domain = $4, email = $5, "phoneNumber" = $6, address = $7, "logoUrl" = $8,
+		"websiteUrl" = $9, description = $10, "updatedAt" = $11, "updatedBy" = $12
+	WHERE id::text = $1`,
+		id, in.Name, in.LegalName, in.Domain, in.Email, in.PhoneNumber, in.Address, in.LogoURL, in.WebsiteURL,
+		in.Description, time.Now(), username))
+}
+
+func (r *pgMasterData) DeleteCompany(ctx context.Context, id string) error {
+	return affected(r.db.Exec(ctx, `DELETE FROM public.mdm_companies WHERE id::text = $1`, id))
+}
+
+const unitStatusColumns = `id::text, "sttId", COALESCE("sttName", ''), "createdAt", "updatedAt",
+	COALESCE("createdBy", ''), COALESCE("updatedBy", '')`
+
+func scanUnitStatus(row pgx.Row) (model.MmdUnitStatus, error) {
+	var st model.MmdUnitStatus
+	err := row.Scan(&st.ID, &st.SttID, &st.SttName, &st.CreatedAt, &st.UpdatedAt, &st.CreatedBy, &st.UpdatedBy)
+	return st, err
+}
+
+func (r *pgMasterData) ListUnitStatuses(ctx context.Context, limit, offset int) ([]model.MmdUnitStatus, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+unitStatusColumns+`
+	FROM public.mdm_unit_statuses ORDER BY id LIMIT $1 OFFSET $2`, limit, offset)
+	return collect(rows, err, scanUnitStatus)
+}
+
+func (r *pgMasterData) GetUnitStatus(ctx context.Context, id string) (*model.MmdUnitStatus, error) {
+	st, err := scanUnitStatus(r.db.QueryRow(ctx, `SELECT `+unitStatusColumns+`
+	FROM public.mdm_unit_statuses WHERE id::text = $1`, id))
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return &st, nil
+}
+
+func (r *pgMasterData) CreateUnitStatus(ctx context.Context, username string, in model.MmdUnitStatusInsert) error {
+	_, err := r.db.Exec(ctx, `INSERT INTO public.mdm_unit_statuses
+	("sttId", "sttName", "createdAt", "updatedAt", "createdBy", "updatedBy")
+	VALUES ($1, $2, $3, $3, $4, $4)`,
+		in.SttID, in.SttName, time.Now(), username)
+	return err
+}
+
+// UpdateUnitStatus relies on the ON UPDATE CASCADE of mdm_units."sttId" to
+// carry a renamed sttId over to the units.
+func (r *pgMasterData) UpdateUnitStatus(ctx context.Context, id, username string, in model.MmdUnitStatusUpdate) error {
+	return affected(r.db.Exec(ctx, `UPDATE public.mdm_unit_statuses
+	SET "sttId" = $2, "sttName" = $3, "updatedAt" = $4, "updatedBy" = $5
+	WHERE id::text = $1`,
+		id, in.SttID, in.SttName, time.Now(), username))
+}
+
+func (r *pgMasterData) DeleteUnitStatus(ctx context.Context, id string) error {
+	return affected(r.db.Exec(ctx, `DELETE FROM public.mdm_unit_statuses WHERE id::text = $1`, id))
+}
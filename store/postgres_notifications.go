@@ -0,0 +1,462 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"mainPackage/model"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type pgNotifications struct {
+	db conn
+}
+
+const notificationColumns = `"id", "orgId", COALESCE("senderType", ''), COALESCE("sender", ''), COALESCE("senderPhoto", ''),
+	COALESCE("message", ''), COALESCE("eventType", ''), COALESCE("redirectUrl", ''), "createdAt",
+	COALESCE("createdBy", ''), "expiredAt", "recipients", "data", "escalations"`
+
+func scanNotification(row pgx.Row) (model.Notification, error) {
+	var n model.Notification
+	var recipients, data, escalations []byte
+	var expiredAt *time.Time
+	err := row.Scan(&n.ID, &n.OrgID, &n.SenderType, &n.Sender, &n.SenderPhoto,
+		&n.Message, &n.EventType, &n.RedirectUrl, &n.CreatedAt,
+		&n.CreatedBy, &expiredAt, &recipients, &data, &escalations)
+	if err != nil {
+		return n, err
+	}
+	if expiredAt != nil {
+		n.ExpiredAt = *expiredAt
+	}
+	if err := json.Unmarshal(recipients, &n.Recipients); err != nil {
+		return n, err
+	}
+	if err := json.Unmarshal(data, &n.Data); err != nil {
+		return n, err
+	}
+	if err := json.Unmarshal(escalations, &n.Escalations); err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+func (r *pgNotifications) Create(ctx context.Context, n *model.Notification) error {
+	recipients, err := json.Marshal(n.Recipients)
+	if err != nil {
+		return fmt.Errorf("failed to process recipients: %w", err)
+	}
+	data, err := json.Marshal(n.Data)
+	if err != nil {
+		return fmt.Errorf("failed to process custom data: %w", err)
+	}
+	err = r.db.QueryRow(ctx, `
+		INSERT INTO notifications
+		("orgId", "senderType", "sender", "senderPhoto", "message", "eventType", "redirectUrl", "createdAt", "createdBy", "expiredAt", "recipients", "data")
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) RETURNING "id"`,
+		n.OrgID, n.SenderType, n.Sender, n.SenderPhoto, n.Message,
+		n.EventType, n.RedirectUrl, n.CreatedAt, n.CreatedBy, n.ExpiredAt, string(recipients), string(data)).Scan(&n.ID)
+	if err != nil {
+		return err
+	}
+	return r.addRecipients(ctx, n.OrgID, int64(n.ID), n.Recipients)
+}
+
+func (r *pgNotifications) Get(ctx context.Context, id int64) (*model.Notification, error) {
+	n, err := scanNotification(r.db.QueryRow(ctx, `SELECT `+notificationColumns+`
+		FROM notifications WHERE "id" = $1`, id))
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return &n, nil
+}
+
+func (r *pgNotifications) Update(ctx context.Context, id int64, in model.Notification) error {
+	recipients, err := json.Marshal(in.Recipients)
+	if err != nil {
+		return fmt.Errorf("failed to process recipients: %w", err)
+	}
+	data, err := json.Marshal(in.Data)
+	if err != nil {
+		return fmt.Errorf("failed to process custom data: %w", err)
+	}
+	var orgId string
+	err = r.db.QueryRow(ctx, `
+        UPDATE notifications
+        SET "message" = $1, "eventType" = $2, "redirectUrl" = $3, "recipients" = $4, "data" = $5, "expiredAt" = $6
+        WHERE "id" = $7 RETURNING "orgId"::text`,
+		in.Message, in.EventType, in.RedirectUrl, string(recipients), string(data), in.ExpiredAt, id).Scan(&orgId)
+	if err != nil {
+		return notFound(err)
+	}
+	if _, err := r.db.Exec(ctx, `DELETE FROM notification_recipients WHERE "notificationId" = $1`, id); err != nil {
+		return err
+	}
+	return r.addRecipients(ctx, orgId, id, in.Recipients)
+}
+
+func (r *pgNotifications) Delete(ctx context.Context, id int64) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM notifications WHERE "id" = $1`, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// recipientKeys returns the (type, value) recipient pairs that address the
+// user: the profile fields, the group and the districts and provinces the
+// user responds to. Empty values never match.
+func (r *pgNotifications) recipientKeys(ctx context.Context, orgId, username string) ([]string, []string, error) {
+	// 1) ดึงโปรไฟล์ผู้ใช้
+	var p model.UserProfile
+	err := r.db.QueryRow(ctx, `
+		SELECT
+			COALESCE(u."empId", ''),
+			u."orgId"::text,
+			COALESCE(u."roleId"::text, ''),
+			COALESCE(u."deptId", ''),
+			COALESCE(u."stnId", ''),
+			COALESCE(u."commId", ''),
+			COALESCE(ug."grpId"::text, '') AS "grpId"
+		FROM um_users u
+		LEFT JOIN um_user_with_groups ug ON u."username" = ug."username"
+		WHERE u."username" = $1
+		  AND u."orgId" = $2::uuid
+		  AND u."active" = true
+		LIMIT 1`, username, orgId).Scan(&p.EmpID, &p.OrgID, &p.RoleID, &p.DeptID, &p.StnID, &p.CommID, &p.GrpID)
+	if err != nil {
+		return nil, nil, notFound(err)
+	}
+
+	var types, values []string
+	add := func(recipientType, value string) {
+		if strings.TrimSpace(value) != "" {
+			types = append(types, recipientType)
+			values = append(values, value)
+		}
+	}
+	add("empId", p.EmpID)
+	add("roleId", p.RoleID)
+	add("deptId", p.DeptID)
+	add("stnId", p.StnID)
+	add("commId", p.CommID)
+	add("orgId", p.OrgID)
+	add("username", username)
+	add("grpId", p.GrpID)
+
+	// 2) provId / distId จาก distIdLists ของผู้ใช้
+	rows, err := r.db.Query(ctx, `
+		SELECT d.distId, COALESCE(ad."provId", '')
+		FROM um_user_with_area_response uar
+		JOIN LATERAL jsonb_array_elements_text(uar."distIdLists"::jsonb) AS d(distId) ON TRUE
+		LEFT JOIN area_districts ad ON ad."distId" = d.distId
+		WHERE uar."username" = $1`, username)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var distId, provId string
+		if err := rows.Scan(&distId, &provId); err != nil {
+			return nil, nil, err
+		}
+		add("distId", distId)
+		add("provId", provId)
+	}
+	return types, values, rows.Err()
+}
+
+// inboxIDs is the CTE "mine" of the notification ids addressed to the user,
+// found through notification_recipients_match_idx. $1 is the orgId and $2
+// and $3 the recipientKeys.
+const inboxIDs = `WITH mine AS (
+			SELECT DISTINCT nr."notificationId"
+			FROM unnest($2::text[], $3::text[]) AS me(type, value)
+			JOIN notification_recipients nr ON nr."orgId" = $1::uuid AND nr.type = me.type AND nr.value = me.value
+		)`
+
+func (r *pgNotifications) ListForUser(ctx context.Context, orgId, username string) ([]model.Notification, error) {
+	return r.ListInbox(ctx, orgId, username, model.NotificationInboxFilter{})
+}
+
+func (r *pgNotifications) ListInbox(ctx context.Context, orgId, username string, f model.NotificationInboxFilter) ([]model.Notification, error) {
+	types, values, err := r.recipientKeys(ctx, orgId, username)
+	if err != nil {
+		return nil, err
+	}
+
+	// The user's recipient keys drive the query through
+	// notification_recipients_match_idx; only the notifications found there
+	// are read.
+	conditions := []string{`n."orgId" = $1::uuid`}
+	args := []interface{}{orgId, types, values}
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+	if f.EventType != "" {
+		conditions = append(conditions, `n."eventType" = `+arg(f.EventType))
+	}
+	if f.From != nil {
+		conditions = append(conditions, `n."createdAt" >= `+arg(*f.From))
+	}
+	if f.To != nil {
+		conditions = append(conditions, `n."createdAt" < `+arg(*f.To))
+	}
+	if f.State != "" {
+		stateCond := map[string]string{
+			model.NotificationUnread:       `NOT EXISTS (SELECT 1 FROM notification_states st WHERE st."notificationId" = n.id AND st.username = %s AND (st."readAt" IS NOT NULL OR st."dismissedAt" IS NOT NULL))`,
+			model.NotificationRead:         `EXISTS (SELECT 1 FROM notification_states st WHERE st."notificationId" = n.id AND st.username = %s AND st."readAt" IS NOT NULL)`,
+			model.NotificationAcknowledged: `EXISTS (SELECT 1 FROM notification_states st WHERE st."notificationId" = n.id AND st.username = %s AND st."acknowledgedAt" IS NOT NULL)`,
+			model.NotificationDismissed:    `EXISTS (SELECT 1 FROM notification_states st WHERE st."notificationId" = n.id AND st.username = %s AND st."dismissedAt" IS NOT NULL)`,
+		}[f.State]
+		if stateCond == "" {
+			return nil, fmt.Errorf("unknown notification state %q", f.State)
+		}
+		conditions = append(conditions, fmt.Sprintf(stateCond, arg(username)))
+	}
+	if f.Before != nil {
+		conditions = append(conditions, fmt.Sprintf(`(n."createdAt", n.id) < (%s, %s)`, arg(f.Before.CreatedAt), arg(f.Before.ID)))
+	}
+	query := inboxIDs + `
+		SELECT ` + notificationColumns + `
+		FROM mine JOIN notifications n ON n.id = mine."notificationId"
+		WHERE ` + strings.Join(conditions, " AND ") + `
+		ORDER BY n."createdAt" DESC, n.id DESC`
+	if f.Limit > 0 {
+		query += ` LIMIT ` + arg(f.Limit)
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []model.Notification
+	for rows.Next() {
+		n, err := scanNotification(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, n)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return list, r.fillStates(ctx, orgId, username, list)
+}
+
+func (r *pgNotifications) GetForUser(ctx context.Context, orgId, username string, id int64) (*model.Notification, error) {
+	types, values, err := r.recipientKeys(ctx, orgId, username)
+	if err != nil {
+		return nil, err
+	}
+	n, err := scanNotification(r.db.QueryRow(ctx, `SELECT `+notificationColumns+`
+		FROM notifications n
+		WHERE n.id = $4 AND n."orgId" = $1::uuid AND EXISTS (
+			SELECT 1 FROM unnest($2::text[], $3::text[]) AS me(type, value)
+			JOIN notification_recipients nr ON nr."notificationId" = n.id AND nr.type = me.type AND nr.value = me.value
+		)`, orgId, types, values, id))
+	if err != nil {
+		return nil, notFound(err)
+	}
+	list := []model.Notification{n}
+	if err := r.fillStates(ctx, orgId, username, list); err != nil {
+		return nil, err
+	}
+	return &list[0], nil
+}
+
+// unreadMine selects from inboxIDs the ids without a read or dismissed stamp
+// for username $4.
+const unreadMine = `
+		FROM mine
+		LEFT JOIN notification_states st ON st."notificationId" = mine."notificationId" AND st.username = $4
+		WHERE st."readAt" IS NULL AND st."dismissedAt" IS NULL`
+
+func (r *pgNotifications) CountUnread(ctx context.Context, orgId, username string) (int, error) {
+	types, values, err := r.recipientKeys(ctx, orgId, username)
+	if err != nil {
+		return 0, err
+	}
+	var n int
+	err = r.db.QueryRow(ctx, inboxIDs+` SELECT count(*)`+unreadMine, orgId, types, values, username).Scan(&n)
+	return n, err
+}
+
+func (r *pgNotifications) UnreadIDs(ctx context.Context, orgId, username string, among []int64) ([]int64, error) {
+	types, values, err := r.recipientKeys(ctx, orgId, username)
+	if err != nil {
+		return nil, err
+	}
+	query := inboxIDs + ` SELECT mine."notificationId"` + unreadMine
+	args := []interface{}{orgId, types, values, username}
+	if among != nil {
+		query += ` AND mine."notificationId" = ANY($5)`
+		args = append(args, among)
+	}
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// recipientRows splits recipients into the (type, value) rows of
+// notification_recipients; a comma-joined value gives one row per value.
+func recipientRows(recipients []model.Recipient) ([]string, []string) {
+	var types, values []string
+	for _, rc := range recipients {
+		for _, v := range strings.Split(rc.Value, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				types = append(types, rc.Type)
+				values = append(values, v)
+			}
+		}
+	}
+	return types, values
+}
+
+// addRecipients writes the notification_recipients rows of recipients.
+func (r *pgNotifications) addRecipients(ctx context.Context, orgId string, id int64, recipients []model.Recipient) error {
+	types, values := recipientRows(recipients)
+	if len(types) == 0 {
+		return nil
+	}
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO notification_recipients ("notificationId", "orgId", type, value)
+		SELECT $1, $2, t, v FROM unnest($3::text[], $4::text[]) AS x(t, v)
+		ON CONFLICT DO NOTHING`, id, orgId, types, values)
+	return err
+}
+
+// fillStates attaches the user's state rows to list.
+func (r *pgNotifications) fillStates(ctx context.Context, orgId, username string, list []model.Notification) error {
+	if len(list) == 0 {
+		return nil
+	}
+	byID := make(map[int64]*model.Notification, len(list))
+	ids := make([]int64, len(list))
+	for i := range list {
+		ids[i] = int64(list[i].ID)
+		byID[ids[i]] = &list[i]
+	}
+	rows, err := r.db.Query(ctx, `SELECT "notificationId", "deliveredAt", "readAt", "acknowledgedAt", "dismissedAt"
+		FROM notification_states
+		WHERE "orgId" = $1::uuid AND username = $2 AND "notificationId" = ANY($3)`, orgId, username, ids)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id int64
+		var st model.NotificationState
+		if err := rows.Scan(&id, &st.DeliveredAt, &st.ReadAt, &st.AcknowledgedAt, &st.DismissedAt); err != nil {
+			return err
+		}
+		if n := byID[id]; n != nil {
+			n.State = &st
+		}
+	}
+	return rows.Err()
+}
+
+// stateColumns maps a notification state onto its timestamp column.
+var stateColumns = map[string]string{
+	model.NotificationDelivered:    `"deliveredAt"`,
+	model.NotificationRead:         `"readAt"`,
+	model.NotificationAcknowledged: `"acknowledgedAt"`,
+	model.NotificationDismissed:    `"dismissedAt"`,
+}
+
+func (r *pgNotifications) SetState(ctx context.Context, orgId, username string, ids []int64, state string, at time.Time) (int64, error) {
+	column, ok := stateColumns[state]
+	if !ok {
+		return 0, fmt.Errorf("unknown notification state %q", state)
+	}
+	tag, err := r.db.Exec(ctx, `
+		INSERT INTO notification_states ("notificationId", "orgId", username, `+column+`)
+		SELECT id, $1, $2, $4 FROM unnest($3::int[]) AS id
+		ON CONFLICT ("notificationId", username) DO UPDATE SET `+column+` = EXCLUDED.`+column+`
+		WHERE notification_states.`+column+` IS NULL`, orgId, username, ids, at)
+	return tag.RowsAffected(), err
+}
+
+func (r *pgNotifications) MarkDelivered(ctx context.Context, orgId string, id int64, usernames []string, at time.Time) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO notification_states ("notificationId", "orgId", username, "deliveredAt")
+		SELECT $2, $1, u, $4 FROM unnest($3::text[]) AS u
+		ON CONFLICT ("notificationId", username) DO UPDATE SET "deliveredAt" = EXCLUDED."deliveredAt"
+		WHERE notification_states."deliveredAt" IS NULL`, orgId, id, usernames, at)
+	return err
+}
+
+func (r *pgNotifications) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	tag, err := r.db.Exec(ctx, `DELETE FROM notifications WHERE "expiredAt" IS NOT NULL AND "expiredAt" < $1`, now)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+func (r *pgNotifications) ListEscalationDue(ctx context.Context, orgId, eventType string, level int, sentBefore, now time.Time) ([]model.Notification, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+notificationColumns+`
+		FROM notifications n
+		WHERE "orgId" = $1::uuid AND "eventType" = $2 AND jsonb_array_length(escalations) = $3
+		  AND "createdAt" <= $4 AND ("expiredAt" IS NULL OR "expiredAt" > $5)
+		  AND NOT EXISTS (
+				SELECT 1 FROM notification_states st
+				WHERE st."notificationId" = n.id AND st."acknowledgedAt" IS NOT NULL
+		  )
+		ORDER BY "createdAt"`, orgId, eventType, level, sentBefore, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var list []model.Notification
+	for rows.Next() {
+		n, err := scanNotification(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, n)
+	}
+	return list, rows.Err()
+}
+
+func (r *pgNotifications) Escalate(ctx context.Context, id int64, step model.NotificationEscalation) error {
+	added, err := json.Marshal(step.Added)
+	if err != nil {
+		return fmt.Errorf("failed to process recipients: %w", err)
+	}
+	record, err := json.Marshal(step)
+	if err != nil {
+		return err
+	}
+	var orgId string
+	err = r.db.QueryRow(ctx, `
+		UPDATE notifications
+		SET recipients = recipients || $2::jsonb, escalations = escalations || jsonb_build_array($3::jsonb)
+		WHERE id = $1 AND jsonb_array_length(escalations) = $4 RETURNING "orgId"::text`,
+		id, string(added), string(record), step.Level-1).Scan(&orgId)
+	if err != nil {
+		return notFound(err)
+	}
+	return r.addRecipients(ctx, orgId, id, step.Added)
+}
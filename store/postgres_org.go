@@ -0,0 +1,168 @@
+package store
+
+import (
+	"context"
+	"mainPackage/model"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type pgOrg struct {
+	db conn
+}
+
+const departmentColumns = `id::text, "deptId", "orgId"::text, COALESCE(en, ''), COALESCE(th, ''), active,
+	"createdAt", "updatedAt", COALESCE("createdBy", ''), COALESCE("updatedBy", '')`
+
+func scanDepartment(row pgx.Row) (model.Department, error) {
+	var d model.Department
+	err := row.Scan(&d.ID, &d.DeptID, &d.OrgID, &d.En, &d.Th, &d.Active,
+		&d.CreatedAt, &d.UpdatedAt, &d.CreatedBy, &d.UpdatedBy)
+	return d, err
+}
+
+func (r *pgOrg) ListDepartments(ctx context.Context, orgId string, limit, offset int) ([]model.Department, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+departmentColumns+`
+	FROM public.sec_departments WHERE "orgId" = $1 ORDER BY id LIMIT $2 OFFSET $3`, orgId, limit, offset)
+	return collect(rows, err, scanDepartment)
+}
+
+func (r *pgOrg) GetDepartment(ctx context.Context, orgId, deptId string) (*model.Department, error) {
+	d, err := scanDepartment(r.db.QueryRow(ctx, `SELECT `+departmentColumns+`
+	FROM public.sec_departments WHERE "orgId" = $1 AND "deptId" = $2`, orgId, deptId))
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return &d, nil
+}
+
+func (r *pgOrg) CreateDepartment(ctx context.Context, orgId, deptId, username string, in model.DepartmentInsert) error {
+	_, err := r.db.Exec(ctx, `INSERT INTO public.sec_departments
+	("deptId", "orgId", en, th, active, "createdAt", "updatedAt", "createdBy", "updatedBy")
+	VALUES ($1, $2, $3, $4, $5, $6, $6, $7, $7)`,
+		deptId, orgId, in.En, in.Th, in.Active, time.Now(), username)
+	return err
+}
+
+func (r *pgOrg) UpdateDepartment(ctx context.Context, orgId, id, username string, in model.DepartmentUpdate) error {
+	return affected(r.db.Exec(ctx, `UPDATE public.sec_departments
+	SET en = $3, th = $4, active = $5, "updatedAt" = $6, "updatedBy" = $7
+	WHERE "orgId" = $1 AND id::text = $2`,
+		orgId, id, in.En, in.Th, in.Active, time.Now(), username))
+}
+
+func (r *pgOrg) DeleteDepartment(ctx context.Context, orgId, id string) error {
+	return affected(r.db.Exec(ctx, `DELETE FROM public.sec_departments
+	WHERE "orgId" = $1 AND id::text = $2`, orgId, id))
+}
+
+const commandColumns = `id::text, "deptId", "orgId"::text, "commId", COALESCE(en, ''), COALESCE(th, ''), active,
+	"createdAt", "updatedAt", COALESCE("createdBy", ''), COALESCE("updatedBy", '')`
+
+func scanCommand(row pgx.Row) (model.Command, error) {
+	var cm model.Command
+	err := row.Scan(&cm.ID, &cm.DeptID, &cm.OrgID, &cm.CommID, &cm.En, &cm.Th, &cm.Active,
+		&cm.CreatedAt, &cm.UpdatedAt, &cm.CreatedBy, &cm.UpdatedBy)
+	return cm, err
+}
+
+func (r *pgOrg) ListCommands(ctx context.Context, orgId string, limit, offset int) ([]model.Command, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+commandColumns+`
+	FROM public.sec_commands WHERE "orgId" = $1 ORDER BY id LIMIT $2 OFFSET $3`, orgId, limit, offset)
+	return collect(rows, err, scanCommand)
+}
+
+func (r *pgOrg) GetCommand(ctx context.Context, orgId, commId string) (*model.Command, error) {
+	cm, err := scanCommand(r.db.QueryRow(ctx, `SELECT `+commandColumns+`
+	FROM public.sec_commands WHERE "orgId" = $1 AND "commId" = $2`, orgId, commId))
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return &cm, nil
+}
+
+func (r *pgOrg) CreateCommand(ctx context.Context, orgId, commId, username string, in model.CommandInsert) error {
+	_, err := r.db.Exec(ctx, `INSERT INTO public.sec_commands
+	("deptId", "orgId", "commId", en, th, active, "createdAt", "updatedAt", "createdBy", "updatedBy")
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $7, $8, $8)`,
+		in.DeptID, orgId, commId, in.En, in.Th, in.Active, time.Now(), username)
+	return err
+}
+
+func (r *pgOrg) UpdateCommand(ctx context.Context, orgId, id, username string, in model.CommandUpdate) error {
+	return affected(r.db.Exec(ctx, `UPDATE public.sec_commands
+	SET "deptId" = $3, en = $4, th = $5, active = $6, "updatedAt" = $7, "updatedBy" = $8
+	WHERE "orgId" = $1 AND id::text = $2`,
+		orgId, id, in.DeptID, in.En, in.Th, in.Active, time.Now(), username))
+}
+
+func (r *pgOrg) DeleteCommand(ctx context.Context, orgId, id string) error {
+	return affected(r.db.Exec(ctx, `DELETE FROM public.sec_commands
+	WHERE "orgId" = $1 AND id::text = $2`, orgId, id))
+}
+
+const stationColumns = `id::text, "orgId"::text, "deptId", "commId", "stnId", COALESCE(en, ''), COALESCE(th, ''), active,
+	"createdAt", "updatedAt", COALESCE("createdBy", ''), COALESCE("updatedBy", '')`
+
+func scanStation(row pgx.Row) (model.Station, error) {
+	var st model.Station
+	err := row.Scan(&st.ID, &st.OrgID, &st.DeptID, &st.CommID, &st.StnID, &st.En, &st.Th, &st.Active,
+		&st.CreatedAt, &st.UpdatedAt, &st.CreatedBy, &st.UpdatedBy)
+	return st, err
+}
+
+func (r *pgOrg) ListStations(ctx context.Context, orgId string, limit, offset int) ([]model.Station, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+stationColumns+`
+	FROM public.sec_stations WHERE "orgId" = $1 ORDER BY id LIMIT $2 OFFSET $3`, orgId, limit, offset)
+	return collect(rows, err, scanStation)
+}
+
+func (r *pgOrg) ListStationTree(ctx context.Context, orgId string) ([]model.StationWithCommandDept, error) {
+	rows, err := r.db.Query(ctx, `
+	SELECT s.id::text, s."orgId"::text, s."deptId", s."commId", s."stnId",
+		COALESCE(s.en, ''), COALESCE(s.th, ''), s.active,
+		COALESCE(c.en, ''), COALESCE(c.th, ''), c.active,
+		COALESCE(d.en, ''), COALESCE(d.th, ''), d.active
+	FROM public.sec_stations s
+	JOIN public.sec_commands c ON c."orgId" = s."orgId" AND c."commId" = s."commId"
+	JOIN public.sec_departments d ON d."orgId" = s."orgId" AND d."deptId" = s."deptId"
+	WHERE s."orgId" = $1 ORDER BY s."stnId"`, orgId)
+	return collect(rows, err, func(row pgx.Row) (model.StationWithCommandDept, error) {
+		var st model.StationWithCommandDept
+		err := row.Scan(&st.ID, &st.OrgId, &st.DeptId, &st.CommId, &st.StnId,
+			&st.StationEn, &st.StationTh, &st.StationActive,
+			&st.CommandEn, &st.CommandTh, &st.CommandActive,
+			&st.DeptEn, &st.DeptTh, &st.DeptActive)
+		return st, err
+	})
+}
+
+func (r *pgOrg) GetStation(ctx context.Context, orgId, stnId string) (*model.Station, error) {
+	st, err := scanStation(r.db.QueryRow(ctx, `SELECT `+stationColumns+`
+	FROM public.sec_stations WHERE "orgId" = $1 AND "stnId" = $2`, orgId, stnId))
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return &st, nil
+}
+
+func (r *pgOrg) CreateStation(ctx context.Context, orgId, stnId, username string, in model.StationInsert) error {
+	_, err := r.db.Exec(ctx, `INSERT INTO public.sec_stations
+	("orgId", "deptId", "commId", "stnId", en, th, active, "createdAt", "updatedAt", "createdBy", "updatedBy")
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $8, $9, $9)`,
+		orgId, in.DeptID, in.CommID, stnId, in.En, in.Th, in.Active, time.Now(), username)
+	return err
+}
+
+func (r *pgOrg) UpdateStation(ctx context.Context, orgId, id, username string, in model.StationUpdate) error {
+	return affected(r.db.Exec(ctx, `UPDATE public.sec_stations
+	SET "deptId" = $3, "commId" = $4, en = $5, th = $6, active = $7, "updatedAt" = $8, "updatedBy" = $9
+	WHERE "orgId" = $1 AND id::text = $2`,
+		orgId, id, in.DeptID, in.CommID, in.En, in.Th, in.Active, time.Now(), username))
+}
+
+func (r *pgOrg) DeleteStation(ctx context.Context, orgId, id string) error {
+	return affected(r.db.Exec(ctx, `DELETE FROM public.sec_stations
+	WHERE "orgId" = $1 AND id::text = $2`, orgId, id))
+}
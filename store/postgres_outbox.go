@@ -0,0 +1,98 @@
+package store
+
+import (
+	"context"
+	"mainPackage/model"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type pgOutbox struct {
+	db conn
+}
+
+const outboxColumns = `id, "orgId"::text, "notificationId", channel, status, attempts, "nextAttemptAt",
+	"lastError", "createdAt", "deliveredAt"`
+
+func scanOutbox(row pgx.Row) (model.OutboxEntry, error) {
+	var e model.OutboxEntry
+	err := row.Scan(&e.ID, &e.OrgID, &e.NotificationID, &e.Channel, &e.Status, &e.Attempts, &e.NextAttemptAt,
+		&e.LastError, &e.CreatedAt, &e.DeliveredAt)
+	return e, err
+}
+
+func collectOutbox(rows pgx.Rows, err error) ([]model.OutboxEntry, error) {
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var list []model.OutboxEntry
+	for rows.Next() {
+		e, err := scanOutbox(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, e)
+	}
+	return list, rows.Err()
+}
+
+func (r *pgOutbox) Enqueue(ctx context.Context, orgId string, notificationId int64, channel string) error {
+	_, err := r.db.Exec(ctx, `INSERT INTO public.notification_outbox ("orgId", "notificationId", channel)
+	VALUES ($1, $2, $3)`, orgId, notificationId, channel)
+	return err
+}
+
+func (r *pgOutbox) Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]model.OutboxEntry, error) {
+	return collectOutbox(r.db.Query(ctx, `
+	UPDATE public.notification_outbox SET attempts = attempts + 1, "nextAttemptAt" = $2
+	WHERE id IN (
+		SELECT id FROM public.notification_outbox
+		WHERE status = 'pending' AND "nextAttemptAt" <= $1
+		ORDER BY id LIMIT $3 FOR UPDATE SKIP LOCKED
+	)
+	RETURNING `+outboxColumns, now, now.Add(lease), limit))
+}
+
+func (r *pgOutbox) MarkDelivered(ctx context.Context, id int64, at time.Time) error {
+	_, err := r.db.Exec(ctx, `UPDATE public.notification_outbox
+	SET status = 'delivered', "deliveredAt" = $2, "lastError" = NULL WHERE id = $1`, id, at)
+	return err
+}
+
+func (r *pgOutbox) MarkFailed(ctx context.Context, id int64, errText string, next time.Time, dead bool) error {
+	status := model.OutboxPending
+	if dead {
+		status = model.OutboxDead
+	}
+	_, err := r.db.Exec(ctx, `UPDATE public.notification_outbox
+	SET status = $2, "lastError" = $3, "nextAttemptAt" = $4 WHERE id = $1`, id, status, errText, next)
+	return err
+}
+
+func (r *pgOutbox) List(ctx context.Context, orgId string, filter model.OutboxFilter) ([]model.OutboxEntry, error) {
+	return collectOutbox(r.db.Query(ctx, `SELECT `+outboxColumns+`
+	FROM public.notification_outbox
+	WHERE "orgId" = $1 AND ($2 = '' OR status = $2)
+	ORDER BY id DESC LIMIT $3 OFFSET $4`, orgId, filter.Status, filter.Limit, filter.Offset))
+}
+
+func (r *pgOutbox) Requeue(ctx context.Context, orgId string, id int64, now time.Time) error {
+	tag, err := r.db.Exec(ctx, `UPDATE public.notification_outbox
+	SET status = 'pending', attempts = 0, "nextAttemptAt" = $3
+	WHERE id = $1 AND "orgId" = $2 AND status = 'dead'`, id, orgId, now)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *pgOutbox) DeleteDelivered(ctx context.Context, before time.Time) (int64, error) {
+	tag, err := r.db.Exec(ctx, `DELETE FROM public.notification_outbox
+	WHERE status = 'delivered' AND "deliveredAt" < $1`, before)
+	return tag.RowsAffected(), err
+}
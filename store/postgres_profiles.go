@@ -0,0 +1,117 @@
+package store
+
+import (
+	"context"
+	"mainPackage/model"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type pgProfiles struct {
+	db conn
+}
+
+const contactColumns = `id, "orgId"::text, username, COALESCE("contactName", ''), COALESCE("contactPhone", ''),
+	COALESCE("contactAddr", ''), "createdAt", "updatedAt", COALESCE("createdBy", ''), COALESCE("updatedBy", '')`
+
+func scanContact(row pgx.Row) (model.UserContact, error) {
+	var ct model.UserContact
+	var addr string
+	err := row.Scan(&ct.ID, &ct.OrgID, &ct.Username, &ct.ContactName, &ct.ContactPhone,
+		&addr, &ct.CreatedAt, &ct.UpdatedAt, &ct.CreatedBy, &ct.UpdatedBy)
+	ct.ContactAddr = addr
+	return ct, err
+}
+
+func (r *pgProfiles) ListContacts(ctx context.Context, orgId string, limit, offset int) ([]model.UserContact, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+contactColumns+`
+	FROM public.um_user_contacts WHERE "orgId" = $1 ORDER BY id LIMIT $2 OFFSET $3`, orgId, limit, offset)
+	return collect(rows, err, scanContact)
+}
+
+func (r *pgProfiles) GetContact(ctx context.Context, orgId, id string) (*model.UserContact, error) {
+	ct, err := scanContact(r.db.QueryRow(ctx, `SELECT `+contactColumns+`
+	FROM public.um_user_contacts WHERE "orgId" = $1 AND id::text = $2`, orgId, id))
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return &ct, nil
+}
+
+func (r *pgProfiles) CreateContact(ctx context.Context, orgId, username string, in model.UserContactInsert) error {
+	_, err := r.db.Exec(ctx, `INSERT INTO public.um_user_contacts
+	("orgId", username, "contactName", "contactPhone", "contactAddr", "createdAt", "updatedAt", "createdBy", "updatedBy")
+	VALUES ($1, $2, $3, $4, $5, $6, $6, $7, $7)`,
+		orgId, in.Username, in.ContactName, in.ContactPhone, in.ContactAddr, time.Now(), username)
+	return err
+}
+
+func (r *pgProfiles) UpdateContact(ctx context.Context, orgId, id, username string, in model.UserContactInsertUpdate) error {
+	return affected(r.db.Exec(ctx, `UPDATE public.um_user_contacts
+	SET "contactName" = $3, "contactPhone" = $4, "contactAddr" = $5, "updatedAt" = $6, "updatedBy" = $7
+	WHERE "orgId" = $1 AND id::text = $2`,
+		orgId, id, in.ContactName, in.ContactPhone, in.ContactAddr, time.Now(), username))
+}
+
+func (r *pgProfiles) DeleteContact(ctx context.Context, orgId, id string) error {
+	return affected(r.db.Exec(ctx, `DELETE FROM public.um_user_contacts
+	WHERE "orgId" = $1 AND id::text = $2`, orgId, id))
+}
+
+const socialColumns = `id, "orgId"::text, username, COALESCE("socialType", ''), COALESCE("socialId", ''),
+	COALESCE("socialName", ''), "createdAt", "updatedAt", COALESCE("createdBy", ''), COALESCE("updatedBy", '')`
+
+func scanSocial(row pgx.Row) (model.UserSocial, error) {
+	var so model.UserSocial
+	err := row.Scan(&so.ID, &so.OrgID, &so.Username, &so.SocialType, &so.SocialID,
+		&so.SocialName, &so.CreatedAt, &so.UpdatedAt, &so.CreatedBy, &so.UpdatedBy)
+	return so, err
+}
+
+func (r *pgProfiles) ListSocials(ctx context.Context, orgId string, limit, offset int) ([]model.UserSocial, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+socialColumns+`
+	FROM public.um_user_with_socials WHERE "orgId" = $1 ORDER BY id LIMIT $2 OFFSET $3`, orgId, limit, offset)
+	return collect(rows, err, scanSocial)
+}
+
+func (r *pgProfiles) GetSocial(ctx context.Context, orgId, id string) (*model.UserSocial, error) {
+	so, err := scanSocial(r.db.QueryRow(ctx, `SELECT `+socialColumns+`
+	FROM public.um_user_with_socials WHERE "orgId" = $1 AND id::text = $2`, orgId, id))
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return &so, nil
+}
+
+func (r *pgProfiles) CreateSocial(ctx context.Context, orgId, username string, in model.UserSocialInsert) error {
+	_, err := r.db.Exec(ctx, `INSERT INTO public.um_user_with_socials
+	("orgId", username, "socialType", "socialId", "socialName", "createdAt", "updatedAt", "createdBy", "updatedBy")
+	VALUES ($1, $2, $3, $4, $5, $6, $6, $7, $7)`,
+		orgId, in.Username, in.SocialType, in.SocialID, in.SocialName, time.Now(), username)
+	return err
+}
+
+func (r *pgProfiles) UpdateSocial(ctx context.Context, orgId, id, username string, in model.UserSocialUpdate) error {
+	return affected(r.db.Exec(ctx, `UPDATE public.um_user_with_socials
+	SET username = $3, "socialType" = $4, "socialId" = $5, "socialName" = $6, "updatedAt" = $7, "updatedBy" = $8
+	WHERE "orgId" = $1 AND id::text = $2`,
+		orgId, id, in.Username, in.SocialType, in.SocialID, in.SocialName, time.Now(), username))
+}
+
+func (r *pgProfiles) DeleteSocial(ctx context.Context, orgId, id string) error {
+	return affected(r.db.Exec(ctx, `DELETE FROM public.um_user_with_socials
+	WHERE "orgId" = $1 AND id::text = $2`, orgId, id))
+}
+
+func (r *pgProfiles) ListGroups(ctx context.Context, orgId string, limit, offset int) ([]model.UmGroup, error) {
+	rows, err := r.db.Query(ctx, `SELECT id, "orgId"::text, "grpId", COALESCE(en, ''), COALESCE(th, ''), active,
+		"createdAt", "updatedAt", COALESCE("createdBy", ''), COALESCE("updatedBy", '')
+	FROM public.um_groups WHERE "orgId" = $1 ORDER BY id LIMIT $2 OFFSET $3`, orgId, limit, offset)
+	return collect(rows, err, func(row pgx.Row) (model.UmGroup, error) {
+		var g model.UmGroup
+		err := row.Scan(&g.ID, &g.OrgID, &g.GrpID, &g.En, &g.Th, &g.Active,
+			&g.CreatedAt, &g.UpdatedAt, &g.CreatedBy, &g.UpdatedBy)
+		return g, err
+	})
+}
@@ -0,0 +1,169 @@
+package store
+
+import (
+	"context"
+	"mainPackage/model"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type pgRoles struct {
+	db conn
+}
+
+const roleColumns = `id::text, "orgId"::text, "roleName", active,
+	"createdAt", "updatedAt", COALESCE("createdBy", ''), COALESCE("updatedBy", '')`
+
+func scanRole(row pgx.Row) (model.Role, error) {
+	var ro model.Role
+	err := row.Scan(&ro.ID, &ro.OrgID, &ro.RoleName, &ro.Active,
+		&ro.CreatedAt, &ro.UpdatedAt, &ro.CreatedBy, &ro.UpdatedBy)
+	return ro, err
+}
+
+func (r *pgRoles) List(ctx context.Context, orgId string, limit, offset int) ([]model.Role, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+roleColumns+`
+	FROM public.um_roles WHERE "orgId" = $1 ORDER BY "createdAt", id LIMIT $2 OFFSET $3`, orgId, limit, offset)
+	return collect(rows, err, scanRole)
+}
+
+func (r *pgRoles) Get(ctx context.Context, orgId, id string) (*model.Role, error) {
+	ro, err := scanRole(r.db.QueryRow(ctx, `SELECT `+roleColumns+`
+	FROM public.um_roles WHERE "orgId" = $1 AND id::text = $2`, orgId, id))
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return &ro, nil
+}
+
+func (r *pgRoles) Create(ctx context.Context, orgId, username string, in model.RoleInsert) (string, error) {
+	var id string
+	err := r.db.QueryRow(ctx, `INSERT INTO public.um_roles
+	("orgId", "roleName", active, "createdAt", "updatedAt", "createdBy", "updatedBy")
+	VALUES ($1, $2, $3, $4, $4, $5, $5)
+	RETURNING id::text`,
+		orgId, in.RoleName, in.Active, time.Now(), username).Scan(&id)
+	return id, err
+}
+
+func (r *pgRoles) Update(ctx context.Context, orgId, id, username string, in model.RoleUpdate) error {
+	return affected(r.db.Exec(ctx, `UPDATE public.um_roles
+	SET "roleName" = $3, active = $4, "updatedAt" = $5, "updatedBy" = $6
+	WHERE "orgId" = $1 AND id::text = $2`,
+		orgId, id, in.RoleName, in.Active, time.Now(), username))
+}
+
+func (r *pgRoles) Delete(ctx context.Context, orgId, id string) error {
+	return affected(r.db.Exec(ctx, `DELETE FROM public.um_roles
+	WHERE "orgId" = $1 AND id::text = $2`, orgId, id))
+}
+
+const permissionColumns = `id::text, COALESCE("groupName", ''), "permId", COALESCE("permName", ''), active,
+	"createdAt", "updatedAt", COALESCE("createdBy", ''), COALESCE("updatedBy", '')`
+
+func scanPermission(row pgx.Row) (model.Permission, error) {
+	var p model.Permission
+	err := row.Scan(&p.ID, &p.GroupName, &p.PermID, &p.PermName, &p.Active,
+		&p.CreatedAt, &p.UpdatedAt, &p.CreatedBy, &p.UpdatedBy)
+	return p, err
+}
+
+func (r *pgRoles) ListPermissions(ctx context.Context, limit, offset int) ([]model.Permission, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+permissionColumns+`
+	FROM public.um_permissions ORDER BY id LIMIT $1 OFFSET $2`, limit, offset)
+	return collect(rows, err, scanPermission)
+}
+
+func (r *pgRoles) GetPermission(ctx context.Context, permId string) (*model.Permission, error) {
+	p, err := scanPermission(r.db.QueryRow(ctx, `SELECT `+permissionColumns+`
+	FROM public.um_permissions WHERE "permId" = $1`, permId))
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return &p, nil
+}
+
+func (r *pgRoles) CreatePermission(ctx context.Context, permId, username string, in model.PermissionInsert) error {
+	_, err := r.db.Exec(ctx, `INSERT INTO public.um_permissions
+	("groupName", "permId", "permName", active, "createdAt", "updatedAt", "createdBy", "updatedBy")
+	VALUES ($1, $2, $3, $4, $5, $5, $6, $6)`,
+		in.GroupName, permId, in.PermName, in.Active, time.Now(), username)
+	return err
+}
+
+func (r *pgRoles) UpdatePermission(ctx context.Context, permId, username string, in model.PermissionUpdate) error {
+	return affected(r.db.Exec(ctx, `UPDATE public.um_permissions
+	SET "groupName" = $2, "permName" = $3, active = $4, "updatedAt" = $5, "updatedBy" = $6
+	WHERE "permId" = $1`,
+		permId, in.GroupName, in.PermName, in.Active, time.Now(), username))
+}
+
+func (r *pgRoles) DeletePermission(ctx context.Context, permId string) error {
+	return affected(r.db.Exec(ctx, `DELETE FROM public.um_permissions WHERE "permId" = $1`, permId))
+}
+
+const grantColumns = `id, "orgId"::text, "roleId"::text, "permId", active,
+	"createdAt", "updatedAt", COALESCE("createdBy", ''), COALESCE("updatedBy", '')`
+
+func scanGrant(row pgx.Row) (model.RolePermission, error) {
+	var g model.RolePermission
+	err := row.Scan(&g.ID, &g.OrgID, &g.RoleID, &g.PermID, &g.Active,
+		&g.CreatedAt, &g.UpdatedAt, &g.CreatedBy, &g.UpdatedBy)
+	return g, err
+}
+
+func (r *pgRoles) ListGrants(ctx context.Context, orgId string, limit, offset int) ([]model.RolePermission, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+grantColumns+`
+	FROM public.um_role_with_permissions WHERE "orgId" = $1 ORDER BY id LIMIT $2 OFFSET $3`, orgId, limit, offset)
+	return collect(rows, err, scanGrant)
+}
+
+func (r *pgRoles) GetGrant(ctx context.Context, orgId, id string) (*model.RolePermission, error) {
+	g, err := scanGrant(r.db.QueryRow(ctx, `SELECT `+grantColumns+`
+	FROM public.um_role_with_permissions WHERE "orgId" = $1 AND id::text = $2`, orgId, id))
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return &g, nil
+}
+
+func (r *pgRoles) ListRoleGrants(ctx context.Context, orgId, roleId string) ([]model.RolePermission, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+grantColumns+`
+	FROM public.um_role_with_permissions WHERE "orgId" = $1 AND "roleId"::text = $2 ORDER BY id`, orgId, roleId)
+	return collect(rows, err, scanGrant)
+}
+
+func (r *pgRoles) AddGrants(ctx context.Context, orgId, roleId, username string, perms []model.RolePermissionBody) error {
+	return pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		return insertGrants(ctx, tx, orgId, roleId, username, perms)
+	})
+}
+
+func (r *pgRoles) SetGrants(ctx context.Context, orgId, roleId, username string, perms []model.RolePermissionBody) error {
+	return pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, `DELETE FROM public.um_role_with_permissions
+		WHERE "orgId" = $1 AND "roleId" = $2`, orgId, roleId); err != nil {
+			return err
+		}
+		return insertGrants(ctx, tx, orgId, roleId, username, perms)
+	})
+}
+
+func insertGrants(ctx context.Context, tx pgx.Tx, orgId, roleId, username string, perms []model.RolePermissionBody) error {
+	now := time.Now()
+	for _, p := range perms {
+		if _, err := tx.Exec(ctx, `INSERT INTO public.um_role_with_permissions
+		("orgId", "roleId", "permId", active, "createdAt", "updatedAt", "createdBy", "updatedBy")
+		VALUES ($1, $2, $3, $4, $5, $5, $6, $6)`,
+			orgId, roleId, p.PermID, p.Active, now, username); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *pgRoles) DeleteGrant(ctx context.Context, orgId, id string) error {
+	return affected(r.db.Exec(ctx, `DELETE FROM public.um_role_with_permissions
+	WHERE "orgId" = $1 AND id::text = $2`, orgId, id))
+}
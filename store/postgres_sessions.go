@@ -0,0 +1,145 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"mainPackage/model"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type pgSessions struct {
+	db conn
+}
+
+func (r *pgSessions) GetProfile(ctx context.Context, orgId, username string) (*model.UserConnectionInfo, error) {
+	var p model.UserConnectionInfo
+	err := r.db.QueryRow(ctx, `SELECT "empId", username, "orgId", COALESCE("deptId", ''), COALESCE("commId", ''),
+		COALESCE("stnId", ''), COALESCE("roleId", ''), COALESCE("grpId", '{}'), COALESCE("distIdLists", '{}')
+	FROM public.user_connections WHERE "orgId" = $1 AND username = $2 LIMIT 1`, orgId, username).Scan(
+		&p.ID, &p.Username, &p.OrgID, &p.DeptID, &p.CommID, &p.StnID, &p.RoleID, &p.GrpID, &p.DistIdLists)
+	if err == nil {
+		return &p, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+
+	var distIds []byte
+	err = r.db.QueryRow(ctx, `SELECT COALESCE(u."empId"::text, ''), u.username, COALESCE(u."orgId"::text, ''),
+		COALESCE(u."deptId"::text, ''), COALESCE(u."commId"::text, ''), COALESCE(u."stnId"::text, ''),
+		COALESCE(u."roleId"::text, ''),
+		COALESCE(array_agg(DISTINCT ug."grpId"::text) FILTER (WHERE ug."grpId" IS NOT NULL), '{}'),
+		COALESCE(uar."distIdLists", '[]'::jsonb)
+	FROM public.um_users u
+	LEFT JOIN public.um_user_with_groups ug ON ug.username = u.username
+	LEFT JOIN public.um_user_with_area_response uar ON uar.username = u.username
+	WHERE u."orgId"::text = $1 AND u.username = $2 AND u.active = true
+	GROUP BY u."empId", u.username, u."orgId", u."deptId", u."commId", u."stnId", u."roleId", uar."distIdLists"
+	LIMIT 1`, orgId, username).Scan(
+		&p.ID, &p.Username, &p.OrgID, &p.DeptID, &p.CommID, &p.StnID, &p.RoleID, &p.GrpID, &distIds)
+	if err != nil {
+		return nil, notFound(err)
+	}
+	if err := json.Unmarshal(distIds, &p.DistIdLists); err != nil {
+		p.DistIdLists = []string{}
+	}
+	return &p, nil
+}
+
+// lockPresence serializes session changes of one employee across instances,
+// so a closing tab cannot remove the user_connections row another tab has
+// just claimed.
+func lockPresence(ctx context.Context, tx pgx.Tx, empId string) error {
+	_, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext('user_connections:' || $1))`, empId)
+	return err
+}
+
+// orNull sends an empty list as NULL rather than an empty array.
+func orNull(list []string) interface{} {
+	if len(list) == 0 {
+		return nil
+	}
+	return list
+}
+
+func (r *pgSessions) Add(ctx context.Context, instanceId string, info model.UserConnectionInfo) error {
+	return pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		if err := lockPresence(ctx, tx, info.ID); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, `INSERT INTO public.user_sessions
+		("sessionId", "empId", username, "orgId", "userAgent", "clientIp", "connectedAt", "instanceId", "seenAt")
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, now())`,
+			info.SessionID, info.ID, info.Username, info.OrgID, info.UserAgent, info.ClientIP,
+			info.ConnectedAt, instanceId); err != nil {
+			return err
+		}
+		_, err := tx.Exec(ctx, `INSERT INTO public.user_connections
+		("empId", username, "orgId", "deptId", "commId", "stnId", "roleId", "grpId", "distIdLists", "connectedAt")
+		VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''), NULLIF($6, ''), NULLIF($7, ''), $8, $9, $10)
+		ON CONFLICT ("empId") DO UPDATE SET
+			username = EXCLUDED.username, "orgId" = EXCLUDED."orgId", "deptId" = EXCLUDED."deptId",
+			"commId" = EXCLUDED."commId", "stnId" = EXCLUDED."stnId", "roleId" = EXCLUDED."roleId",
+			"grpId" = EXCLUDED."grpId", "distIdLists" = EXCLUDED."distIdLists", "connectedAt" = EXCLUDED."connectedAt"`,
+			info.ID, info.Username, info.OrgID, info.DeptID, info.CommID, info.StnID, info.RoleID,
+			orNull(info.GrpID), orNull(info.DistIdLists), info.ConnectedAt)
+		return err
+	})
+}
+
+func (r *pgSessions) Remove(ctx context.Context, empId, sessionId string) error {
+	return pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		if err := lockPresence(ctx, tx, empId); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, `DELETE FROM public.user_sessions WHERE "sessionId" = $1`, sessionId); err != nil {
+			return err
+		}
+		return dropPresence(ctx, tx, empId)
+	})
+}
+
+// dropPresence removes empId's presence row when no session of theirs is
+// left.
+func dropPresence(ctx context.Context, tx pgx.Tx, empId string) error {
+	_, err := tx.Exec(ctx, `DELETE FROM public.user_connections WHERE "empId" = $1
+	AND NOT EXISTS (SELECT 1 FROM public.user_sessions WHERE "empId" = $1)`, empId)
+	return err
+}
+
+func (r *pgSessions) Beat(ctx context.Context, instanceId string) error {
+	_, err := r.db.Exec(ctx, `UPDATE public.user_sessions SET "seenAt" = now() WHERE "instanceId" = $1`, instanceId)
+	return err
+}
+
+func (r *pgSessions) ListStale(ctx context.Context, stale time.Duration) ([]string, error) {
+	rows, err := r.db.Query(ctx, `SELECT DISTINCT "empId" FROM public.user_sessions
+	WHERE "seenAt" < now() - make_interval(secs => $1)
+	UNION
+	SELECT "empId" FROM public.user_connections c
+	WHERE NOT EXISTS (SELECT 1 FROM public.user_sessions s WHERE s."empId" = c."empId")`, stale.Seconds())
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowTo[string])
+}
+
+func (r *pgSessions) Sweep(ctx context.Context, empId string, stale time.Duration) (int64, error) {
+	var swept int64
+	err := pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		if err := lockPresence(ctx, tx, empId); err != nil {
+			return err
+		}
+		tag, err := tx.Exec(ctx, `DELETE FROM public.user_sessions
+		WHERE "empId" = $1 AND "seenAt" < now() - make_interval(secs => $2)`, empId, stale.Seconds())
+		if err != nil {
+			return err
+		}
+		swept = tag.RowsAffected()
+		return dropPresence(ctx, tx, empId)
+	})
+	return swept, err
+}
@@ -0,0 +1,109 @@
+package store
+
+import (
+	"context"
+	"mainPackage/model"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type pgSkills struct {
+	db conn
+}
+
+const skillColumns = `id::text, "orgId"::text, "skillId"::text, COALESCE(en, ''), COALESCE(th, ''), active,
+	"createdAt", "updatedAt", COALESCE("createdBy", ''), COALESCE("updatedBy", '')`
+
+func scanSkill(row pgx.Row) (model.Skill, error) {
+	var sk model.Skill
+	err := row.Scan(&sk.ID, &sk.OrgID, &sk.SkillID, &sk.En, &sk.Th, &sk.Active,
+		&sk.CreatedAt, &sk.UpdatedAt, &sk.CreatedBy, &sk.UpdatedBy)
+	return sk, err
+}
+
+func (r *pgSkills) List(ctx context.Context, orgId string, limit, offset int) ([]model.Skill, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+skillColumns+`
+	FROM public.um_skills WHERE "orgId" = $1 ORDER BY id LIMIT $2 OFFSET $3`, orgId, limit, offset)
+	return collect(rows, err, scanSkill)
+}
+
+func (r *pgSkills) Get(ctx context.Context, orgId, skillId string) (*model.Skill, error) {
+	sk, err := scanSkill(r.db.QueryRow(ctx, `SELECT `+skillColumns+`
+	FROM public.um_skills WHERE "orgId" = $1 AND "skillId"::text = $2`, orgId, skillId))
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return &sk, nil
+}
+
+func (r *pgSkills) Create(ctx context.Context, orgId, skillId, username string, in model.SkillInsert) error {
+	_, err := r.db.Exec(ctx, `INSERT INTO public.um_skills
+	("orgId", "skillId", en, th, active, "createdAt", "updatedAt", "createdBy", "updatedBy")
+	VALUES ($1, $2, $3, $4, $5, $6, $6, $7, $7)`,
+		orgId, skillId, in.En, in.Th, in.Active, time.Now(), username)
+	return err
+}
+
+func (r *pgSkills) Update(ctx context.Context, orgId, id, username string, in model.SkillUpdate) error {
+	return affected(r.db.Exec(ctx, `UPDATE public.um_skills
+	SET en = $3, th = $4, active = $5, "updatedAt" = $6, "updatedBy" = $7
+	WHERE "orgId" = $1 AND id::text = $2`,
+		orgId, id, in.En, in.Th, in.Active, time.Now(), username))
+}
+
+func (r *pgSkills) Delete(ctx context.Context, orgId, id string) error {
+	return affected(r.db.Exec(ctx, `DELETE FROM public.um_skills
+	WHERE "orgId" = $1 AND id::text = $2`, orgId, id))
+}
+
+const userSkillColumns = `id, "orgId"::text, "userName", "skillId"::text, active,
+	"createdAt", "updatedAt", COALESCE("createdBy", ''), COALESCE("updatedBy", '')`
+
+func scanUserSkill(row pgx.Row) (model.UserSkill, error) {
+	var us model.UserSkill
+	err := row.Scan(&us.ID, &us.OrgID, &us.UserName, &us.SkillID, &us.Active,
+		&us.CreatedAt, &us.UpdatedAt, &us.CreatedBy, &us.UpdatedBy)
+	return us, err
+}
+
+func (r *pgSkills) ListUserSkills(ctx context.Context, orgId string, limit, offset int) ([]model.UserSkill, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+userSkillColumns+`
+	FROM public.um_user_with_skills WHERE "orgId" = $1 ORDER BY id LIMIT $2 OFFSET $3`, orgId, limit, offset)
+	return collect(rows, err, scanUserSkill)
+}
+
+func (r *pgSkills) GetUserSkill(ctx context.Context, orgId, id string) (*model.UserSkill, error) {
+	us, err := scanUserSkill(r.db.QueryRow(ctx, `SELECT `+userSkillColumns+`
+	FROM public.um_user_with_skills WHERE "orgId" = $1 AND id::text = $2`, orgId, id))
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return &us, nil
+}
+
+func (r *pgSkills) ListSkillUsers(ctx context.Context, orgId, skillId string) ([]model.UserSkill, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+userSkillColumns+`
+	FROM public.um_user_with_skills WHERE "orgId" = $1 AND "skillId"::text = $2 ORDER BY id`, orgId, skillId)
+	return collect(rows, err, scanUserSkill)
+}
+
+func (r *pgSkills) CreateUserSkill(ctx context.Context, orgId, username string, in model.UserSkillInsert) error {
+	_, err := r.db.Exec(ctx, `INSERT INTO public.um_user_with_skills
+	("orgId", "userName", "skillId", active, "createdAt", "updatedAt", "createdBy", "updatedBy")
+	VALUES ($1, $2, $3, $4, $5, $5, $6, $6)`,
+		orgId, in.UserName, in.SkillID, in.Active, time.Now(), username)
+	return err
+}
+
+func (r *pgSkills) UpdateUserSkill(ctx context.Context, orgId, id, username string, in model.UserSkillUpdate) error {
+	return affected(r.db.Exec(ctx, `UPDATE public.um_user_with_skills
+	SET "skillId" = $3, active = $4, "updatedAt" = $5, "updatedBy" = $6
+	WHERE "orgId" = $1 AND id::text = $2`,
+		orgId, id, in.SkillID, in.Active, time.Now(), username))
+}
+
+func (r *pgSkills) DeleteUserSkill(ctx context.Context, orgId, id string) error {
+	return affected(r.db.Exec(ctx, `DELETE FROM public.um_user_with_skills
+	WHERE "orgId" = $1 AND id::text = $2`, orgId, id))
+}
@@ -0,0 +1,58 @@
+package store
+
+import (
+	"context"
+	"mainPackage/model"
+	"time"
+)
+
+type pgTokens struct {
+	db conn
+}
+
+func (r *pgTokens) Create(ctx context.Context, in model.RefreshTokenRecord) error {
+	_, err := r.db.Exec(ctx, `INSERT INTO public.auth_refresh_tokens
+	(jti, "familyId", "orgId", username, "userAgent", "clientIp", "issuedAt", "expiresAt")
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		in.JTI, in.FamilyID, in.OrgID, in.Username, in.UserAgent, in.ClientIP, in.IssuedAt, in.ExpiresAt)
+	return err
+}
+
+func (r *pgTokens) Get(ctx context.Context, jti string) (*model.RefreshTokenRecord, error) {
+	var t model.RefreshTokenRecord
+	err := r.db.QueryRow(ctx, `SELECT jti::text, "familyId"::text, "orgId"::text, username,
+	COALESCE("userAgent", ''), COALESCE("clientIp", ''), "issuedAt", "expiresAt", "usedAt",
+	"replacedBy"::text, "revokedAt", "revokeReason"
+	FROM public.auth_refresh_tokens WHERE jti = $1`, jti).Scan(&t.JTI, &t.FamilyID, &t.OrgID, &t.Username,
+		&t.UserAgent, &t.ClientIP, &t.IssuedAt, &t.ExpiresAt, &t.UsedAt, &t.ReplacedBy, &t.RevokedAt, &t.RevokeReason)
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return &t, nil
+}
+
+func (r *pgTokens) Rotate(ctx context.Context, jti, next string, at time.Time) (bool, error) {
+	tag, err := r.db.Exec(ctx, `UPDATE public.auth_refresh_tokens SET "usedAt"=$3, "replacedBy"=$2
+	WHERE jti=$1 AND "usedAt" IS NULL AND "revokedAt" IS NULL`, jti, next, at)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+func (r *pgTokens) RevokeFamily(ctx context.Context, familyId, reason string, at time.Time) (int64, error) {
+	tag, err := r.db.Exec(ctx, `UPDATE public.auth_refresh_tokens SET "revokedAt"=$3, "revokeReason"=$2
+	WHERE "familyId"=$1 AND "revokedAt" IS NULL`, familyId, reason, at)
+	return tag.RowsAffected(), err
+}
+
+func (r *pgTokens) RevokeUser(ctx context.Context, orgId, username, reason string, at time.Time) (int64, error) {
+	tag, err := r.db.Exec(ctx, `UPDATE public.auth_refresh_tokens SET "revokedAt"=$4, "revokeReason"=$3
+	WHERE "orgId"=$1 AND username=$2 AND "revokedAt" IS NULL`, orgId, username, reason, at)
+	return tag.RowsAffected(), err
+}
+
+func (r *pgTokens) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	tag, err := r.db.Exec(ctx, `DELETE FROM public.auth_refresh_tokens WHERE "expiresAt" < $1`, now)
+	return tag.RowsAffected(), err
+}
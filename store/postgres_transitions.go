@@ -0,0 +1,105 @@
+package store
+
+import (
+	"context"
+	"mainPackage/model"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type pgTransitions struct {
+	db conn
+}
+
+const transitionColumns = `id::text, "orgId"::text, COALESCE("fromStatusId", ''), "toStatusId", stamp, active, "createdAt",
+	"updatedAt", COALESCE("createdBy", ''), COALESCE("updatedBy", '')`
+
+func scanTransition(row pgx.Row) (model.CaseStatusTransition, error) {
+	var t model.CaseStatusTransition
+	err := row.Scan(&t.ID, &t.OrgID, &t.FromStatusID, &t.ToStatusID, &t.Stamp, &t.Active, &t.CreatedAt,
+		&t.UpdatedAt, &t.CreatedBy, &t.UpdatedBy)
+	return t, err
+}
+
+func (r *pgTransitions) List(ctx context.Context, orgId string) ([]model.CaseStatusTransition, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+transitionColumns+`
+	FROM public.case_status_transitions WHERE "orgId"::text = $1 ORDER BY "fromStatusId", "toStatusId"`, orgId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var list []model.CaseStatusTransition
+	for rows.Next() {
+		t, err := scanTransition(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, t)
+	}
+	return list, rows.Err()
+}
+
+func (r *pgTransitions) Get(ctx context.Context, orgId, id string) (*model.CaseStatusTransition, error) {
+	t, err := scanTransition(r.db.QueryRow(ctx, `SELECT `+transitionColumns+`
+	FROM public.case_status_transitions WHERE "orgId"::text = $1 AND id::text = $2`, orgId, id))
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return &t, nil
+}
+
+func (r *pgTransitions) Find(ctx context.Context, orgId, fromStatusId, toStatusId string) (*model.CaseStatusTransition, error) {
+	t, err := scanTransition(r.db.QueryRow(ctx, `SELECT `+transitionColumns+`
+	FROM public.case_status_transitions
+	WHERE "orgId"::text = $1 AND COALESCE("fromStatusId", '') = $2 AND "toStatusId" = $3`, orgId, fromStatusId, toStatusId))
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return &t, nil
+}
+
+func (r *pgTransitions) Initial(ctx context.Context, orgId string) (*model.CaseStatusTransition, error) {
+	t, err := scanTransition(r.db.QueryRow(ctx, `SELECT `+transitionColumns+`
+	FROM public.case_status_transitions
+	WHERE "orgId"::text = $1 AND "fromStatusId" IS NULL`, orgId))
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return &t, nil
+}
+
+func (r *pgTransitions) Create(ctx context.Context, orgId, username string, in model.CaseStatusTransitionInput) (string, error) {
+	var id string
+	err := r.db.QueryRow(ctx, `INSERT INTO public.case_status_transitions
+	("orgId", "fromStatusId", "toStatusId", stamp, active, "createdAt", "updatedAt", "createdBy", "updatedBy")
+	VALUES ($1, $2, $3, $4, $5, $6, $6, $7, $7) RETURNING id::text`,
+		orgId, nullable(in.FromStatusID), in.ToStatusID, in.Stamp, in.Active, time.Now(), username).Scan(&id)
+	return id, err
+}
+
+func (r *pgTransitions) Update(ctx context.Context, orgId, id, username string, in model.CaseStatusTransitionInput) error {
+	tag, err := r.db.Exec(ctx, `UPDATE public.case_status_transitions
+	SET "fromStatusId" = $3, "toStatusId" = $4, stamp = $5, active = $6, "updatedAt" = $7, "updatedBy" = $8
+	WHERE "orgId"::text = $1 AND id::text = $2`,
+		orgId, id, nullable(in.FromStatusID), in.ToStatusID, in.Stamp, in.Active, time.Now(), username)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *pgTransitions) Delete(ctx context.Context, orgId, id string) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM public.case_status_transitions
+	WHERE "orgId"::text = $1 AND id::text = $2`, orgId, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,261 @@
+package store
+
+import (
+	"context"
+	"mainPackage/model"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type pgUnits struct {
+	db conn
+}
+
+// unitFields are the mdm_units columns shared by MmdUnit and UnitUser, with
+// nullable columns folded to their zero values.
+const unitFields = `u."orgId", u."unitId", COALESCE(u."unitName", ''), COALESCE(u."unitSourceId", ''),
+	COALESCE(u."unitTypeId", ''), u.priority, COALESCE(u."compId", ''), COALESCE(u."deptId", ''),
+	COALESCE(u."commId", ''), COALESCE(u."stnId", ''), COALESCE(u."plateNo", ''), COALESCE(u."provinceCode", ''),
+	u.active, COALESCE(u.username, ''), u."isLogin", u."isFreeze", u."isOutArea",
+	COALESCE(u."locLat", 0), COALESCE(u."locLon", 0), COALESCE(u."locAlt", 0), COALESCE(u."locBearing", 0),
+	COALESCE(u."locSpeed", 0), COALESCE(u."locProvider", ''), u."locGpsTime", COALESCE(u."locSatellites", 0),
+	COALESCE(u."locAccuracy", 0), u."locLastUpdateTime", COALESCE(u."breakDuration", 0),
+	COALESCE(u."healthChk", ''), u."healthChkTime", COALESCE(u."sttId", '')`
+
+const unitColumns = `u.id, ` + unitFields + `, u."createdAt", u."updatedAt",
+	COALESCE(u."createdBy", ''), COALESCE(u."updatedBy", '')`
+
+func scanUnit(row pgx.Row) (model.MmdUnit, error) {
+	var u model.MmdUnit
+	var gpsTime, lastUpdate, healthTime *time.Time
+	err := row.Scan(&u.ID, &u.OrgID, &u.UnitID, &u.UnitName, &u.UnitSourceID, &u.UnitTypeID, &u.Priority,
+		&u.CompID, &u.DeptID, &u.CommID, &u.StnID, &u.PlateNo, &u.ProvinceCode,
+		&u.Active, &u.Username, &u.IsLogin, &u.IsFreeze, &u.IsOutArea,
+		&u.LocLat, &u.LocLon, &u.LocAlt, &u.LocBearing, &u.LocSpeed, &u.LocProvider,
+		&gpsTime, &u.LocSatellites, &u.LocAccuracy, &lastUpdate,
+		&u.BreakDuration, &u.HealthChk, &healthTime, &u.SttID,
+		&u.CreatedAt, &u.UpdatedAt, &u.CreatedBy, &u.UpdatedBy)
+	u.LocGpsTime, u.LocLastUpdateTime, u.HealthChkTime = timeOf(gpsTime), timeOf(lastUpdate), timeOf(healthTime)
+	return u, err
+}
+
+// timeOf returns the zero time for a NULL timestamp.
+func timeOf(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return *t
+}
+
+func (r *pgUnits) List(ctx context.Context, orgId string, limit, offset int) ([]model.MmdUnit, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+unitColumns+`
+	FROM public.mdm_units u WHERE u."orgId"=$1 ORDER BY u.id LIMIT $2 OFFSET $3`, orgId, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []model.MmdUnit
+	for rows.Next() {
+		u, err := scanUnit(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, u)
+	}
+	return list, rows.Err()
+}
+
+func (r *pgUnits) GetByID(ctx context.Context, orgId, id string) (*model.MmdUnit, error) {
+	u, err := scanUnit(r.db.QueryRow(ctx, `SELECT `+unitColumns+`
+	FROM public.mdm_units u WHERE u.id=$1 AND u."orgId"=$2`, id, orgId))
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return &u, nil
+}
+
+func (r *pgUnits) GetByUnitID(ctx context.Context, orgId, unitId string) (*model.MmdUnit, error) {
+	u, err := scanUnit(r.db.QueryRow(ctx, `SELECT `+unitColumns+`
+	FROM public.mdm_units u WHERE u."unitId"=$1 AND u."orgId"=$2`, unitId, orgId))
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return &u, nil
+}
+
+func (r *pgUnits) Create(ctx context.Context, orgId, username string, in model.MmdUnitInsert) (string, error) {
+	now := time.Now()
+	var id string
+	err := r.db.QueryRow(ctx, `INSERT INTO public.mdm_units(
+	"orgId", "unitId", "unitName", "unitSourceId", "unitTypeId", priority, "compId", "deptId", "commId",
+	 "stnId", "plateNo", "provinceCode", active, username, "isLogin", "isFreeze", "isOutArea", "locLat",
+	  "locLon", "locAlt", "locBearing", "locSpeed", "locProvider", "locGpsTime", "locSatellites", "locAccuracy",
+	   "locLastUpdateTime", "breakDuration", "healthChk", "healthChkTime", "sttId", "createdAt", "updatedAt",
+	    "createdBy", "updatedBy")
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14,
+	 $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28,
+	  $29, $30, $31, $32, $33, $34, $35)
+	RETURNING id`,
+		orgId, in.UnitID, in.UnitName, in.UnitSourceID, in.UnitTypeID, in.Priority, in.CompID,
+		in.DeptID, in.CommID, in.StnID, in.PlateNo, in.ProvinceCode, in.Active, in.Username,
+		in.IsLogin, in.IsFreeze, in.IsOutArea, in.LocLat, in.LocLon, in.LocAlt, in.LocBearing,
+		in.LocSpeed, in.LocProvider, in.LocGpsTime, in.LocSatellites, in.LocAccuracy,
+		in.LocLastUpdateTime, in.BreakDuration, strconv.FormatBool(in.HealthChk), in.HealthChkTime,
+		in.SttID, now, now, username, username).Scan(&id)
+	return id, err
+}
+
+func (r *pgUnits) Update(ctx context.Context, orgId, id, username string, in model.MmdUnitUpdate) error {
+	_, err := r.db.Exec(ctx, `UPDATE public."mdm_units"
+	SET "unitId"=$2, "unitName"=$3, "unitSourceId"=$4, "unitTypeId"=$5, priority=$6, "compId"=$7,
+	 "deptId"=$8, "commId"=$9, "stnId"=$10, "plateNo"=$11, "provinceCode"=$12, active=$13, username=$14,
+	  "isLogin"=$15, "isFreeze"=$16, "isOutArea"=$17, "locLat"=$18, "locLon"=$19, "locAlt"=$20, "locBearing"=$21,
+	   "locSpeed"=$22, "locProvider"=$23, "locGpsTime"=$24, "locSatellites"=$25, "locAccuracy"=$26,
+	    "locLastUpdateTime"=$27, "breakDuration"=$28, "healthChk"=$29, "healthChkTime"=$30, "sttId"=$31,
+		 "updatedAt"=$32, "updatedBy"=$33
+	WHERE id = $1 AND "orgId"=$34`,
+		id, in.UnitID, in.UnitName, in.UnitSourceID, in.UnitTypeID, in.Priority, in.CompID,
+		in.DeptID, in.CommID, in.StnID, in.PlateNo, in.ProvinceCode, in.Active, in.Username,
+		in.IsLogin, in.IsFreeze, in.IsOutArea, in.LocLat, in.LocLon, in.LocAlt, in.LocBearing,
+		in.LocSpeed, in.LocProvider, in.LocGpsTime, in.LocSatellites, in.LocAccuracy,
+		in.LocLastUpdateTime, in.BreakDuration, in.HealthChk, in.HealthChkTime, in.SttID, time.Now(), username, orgId)
+	return err
+}
+
+func (r *pgUnits) Delete(ctx context.Context, orgId, id string) error {
+	_, err := r.db.Exec(ctx, `DELETE FROM public."mdm_units" WHERE id = $1 AND "orgId"=$2`, id, orgId)
+	return err
+}
+
+func (r *pgUnits) SetStatus(ctx context.Context, orgId, unitId, sttId, username string) error {
+	tag, err := r.db.Exec(ctx, `UPDATE public."mdm_units" SET "sttId"=$3, "updatedAt"=$4, "updatedBy"=$5
+	WHERE "unitId"=$1 AND "orgId"=$2`, unitId, orgId, sttId, time.Now(), username)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *pgUnits) ListProperties(ctx context.Context, orgId, unitId string, limit, offset int) ([]model.MdmUnitProperty, error) {
+	rows, err := r.db.Query(ctx, `SELECT id, "orgId", "unitId", "propId", active, "createdAt", "updatedAt",
+	COALESCE("createdBy", ''), COALESCE("updatedBy", '')
+	FROM public.mdm_unit_with_properties WHERE "orgId"=$1 AND "unitId"=$2 ORDER BY id LIMIT $3 OFFSET $4`,
+		orgId, unitId, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []model.MdmUnitProperty
+	for rows.Next() {
+		var p model.MdmUnitProperty
+		if err := rows.Scan(&p.ID, &p.OrgID, &p.UnitID, &p.PropID, &p.Active,
+			&p.CreatedAt, &p.UpdatedAt, &p.CreatedBy, &p.UpdatedBy); err != nil {
+			return nil, err
+		}
+		list = append(list, p)
+	}
+	return list, rows.Err()
+}
+
+// FindCandidates matches active units that carry every property the case
+// sub-type asks for, operated by an active user who has one of the required
+// skills and covers the case district.
+func (r *pgUnits) FindCandidates(ctx context.Context, orgId, caseId string) ([]model.UnitUser, error) {
+	rows, err := r.db.Query(ctx, `
+  WITH case_info AS (
+  SELECT
+    c."caseSTypeId",
+    c."countryId",
+    c."provId",
+    c."distId",
+    s."unitPropLists",
+    s."userSkillList"
+  FROM "tix_cases" c
+  JOIN "case_sub_types" s ON c."caseSTypeId" = s."sTypeId"
+  WHERE c."caseId" = $1
+    AND c."orgId" = $2
+    AND s."active" = TRUE
+),
+unit_with_props AS (
+  SELECT
+    "unitId",
+    array_agg("propId") AS props
+  FROM "mdm_unit_with_properties"
+  WHERE "active" = TRUE
+  GROUP BY "unitId"
+),
+units_matched AS (
+  SELECT u."unitId", u."unitName", p.props
+  FROM "mdm_units" u
+  JOIN unit_with_props p ON u."unitId" = p."unitId"
+  CROSS JOIN case_info c
+  WHERE u."active" = TRUE
+    AND (
+      SELECT COUNT(DISTINCT prop_uuid)
+      FROM (
+        SELECT (jsonb_array_elements_text(c."unitPropLists"::jsonb))::uuid AS prop_uuid
+      ) AS required_props
+      WHERE prop_uuid = ANY(p.props)
+    ) = (SELECT jsonb_array_length(c."unitPropLists"::jsonb))
+),
+users_on_units AS (
+  SELECT u."unitId", mdm."username"
+  FROM units_matched u
+  JOIN "mdm_units" mdm ON mdm."unitId" = u."unitId" AND mdm."active" = TRUE
+  JOIN "um_users" um ON um."username" = mdm."username" AND um."active" = TRUE
+),
+users_with_skill AS (
+  SELECT DISTINCT "userName"
+  FROM "um_user_with_skills"
+  WHERE "skillId" IN (
+    SELECT (jsonb_array_elements_text(ci."userSkillList"::jsonb))::uuid
+    FROM case_info ci
+  )
+  AND "active" = TRUE
+),
+users_in_area AS (
+  SELECT "username"
+  FROM "um_user_with_area_response" ua
+  CROSS JOIN case_info c
+  WHERE ua."orgId" = $2
+    AND EXISTS (
+      SELECT 1
+      FROM jsonb_array_elements_text(ua."distIdLists") AS distId
+      WHERE distId.value = c."distId"
+    )
+)
+SELECT `+unitFields+`, COALESCE(u."createdBy", ''), COALESCE(u."updatedBy", '')
+FROM users_on_units uu
+JOIN users_with_skill us ON uu."username" = us."userName"
+JOIN users_in_area ua ON uu."username" = ua."username"
+JOIN "mdm_units" u ON u."unitId" = uu."unitId"`, caseId, orgId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []model.UnitUser
+	for rows.Next() {
+		var u model.UnitUser
+		var gpsTime, lastUpdate, healthTime *time.Time
+		if err := rows.Scan(&u.OrgID, &u.UnitID, &u.UnitName, &u.UnitSourceID, &u.UnitTypeID, &u.Priority,
+			&u.CompID, &u.DeptID, &u.CommID, &u.StnID, &u.PlateNo, &u.ProvinceCode,
+			&u.Active, &u.Username, &u.IsLogin, &u.IsFreeze, &u.IsOutArea,
+			&u.LocLat, &u.LocLon, &u.LocAlt, &u.LocBearing, &u.LocSpeed, &u.LocProvider,
+			&gpsTime, &u.LocSatellites, &u.LocAccuracy, &lastUpdate,
+			&u.BreakDuration, &u.HealthChk, &healthTime, &u.SttID,
+			&u.CreatedBy, &u.UpdatedBy); err != nil {
+			return nil, err
+		}
+		u.LocGpsTime, u.LocLastUpdateTime, u.HealthChkTime = timeOf(gpsTime), timeOf(lastUpdate), timeOf(healthTime)
+		list = append(list, u)
+	}
+	return list, rows.Err()
+}
@@ -0,0 +1,267 @@
+package store
+
+import (
+	"context"
+	"mainPackage/model"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type pgUsers struct {
+	db conn
+}
+
+const userColumns = `t1.id, t1."orgId", COALESCE(t1."displayName", ''), COALESCE(t1.title, ''),
+	COALESCE(t1."firstName", ''), t1."middleName", COALESCE(t1."lastName", ''), COALESCE(t1."citizenId", ''),
+	t1.bod, COALESCE(t1.blood, ''), COALESCE(t1.gender::text, ''), t1."mobileNo", t1.address, t1.photo,
+	t1.username, t1.password, t1.email, COALESCE(t1."roleId"::text, ''), COALESCE(t1."userType"::text, ''),
+	COALESCE(t1."empId", ''), COALESCE(t1."deptId", ''), COALESCE(t1."commId", ''), COALESCE(t1."stnId", ''),
+	t1.active, t1."activationToken", t1."lastActivationRequest", t1."lostPasswordRequest", t1."signupStamp",
+	t1.islogin, t1."lastLogin", t1."createdAt", t1."updatedAt", COALESCE(t1."createdBy", ''), COALESCE(t1."updatedBy", '')`
+
+func userFields(u *model.Um_User, bod **time.Time) []any {
+	return []any{&u.ID, &u.OrgID, &u.DisplayName, &u.Title, &u.FirstName, &u.MiddleName, &u.LastName, &u.CitizenID,
+		bod, &u.Blood, &u.Gender, &u.MobileNo, &u.Address, &u.Photo, &u.Username, &u.Password, &u.Email,
+		&u.RoleID, &u.UserType, &u.EmpID, &u.DeptID, &u.CommID, &u.StnID, &u.Active, &u.ActivationToken,
+		&u.LastActivationRequest, &u.LostPasswordRequest, &u.SignupStamp, &u.IsLogin, &u.LastLogin,
+		&u.CreatedAt, &u.UpdatedAt, &u.CreatedBy, &u.UpdatedBy}
+}
+
+func scanUser(row pgx.Row, extra ...any) (model.Um_User, error) {
+	var u model.Um_User
+	var bod *time.Time
+	err := row.Scan(append(userFields(&u, &bod), extra...)...)
+	u.Bod = timeOf(bod)
+	return u, err
+}
+
+func (r *pgUsers) List(ctx context.Context, orgId string, limit, offset int) ([]model.Um_User, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+userColumns+`
+	FROM public.um_users t1
+	WHERE t1."orgId"=$1 ORDER BY t1.id LIMIT $2 OFFSET $3`, orgId, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []model.Um_User
+	for rows.Next() {
+		u, err := scanUser(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, u)
+	}
+	return list, rows.Err()
+}
+
+func (r *pgUsers) GetByID(ctx context.Context, orgId, id string) (*model.Um_User, error) {
+	u, err := scanUser(r.db.QueryRow(ctx, `SELECT `+userColumns+`
+	FROM public.um_users t1 WHERE t1.id=$1 AND t1."orgId"=$2`, id, orgId))
+	if err != nil {
+		return nil, notFound(err)
+	}
+	if u.Skills, err = r.skills(ctx, orgId, u.Username); err != nil {
+		return nil, err
+	}
+	if u.Areas, err = r.areas(ctx, orgId, u.Username); err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
+
+func (r *pgUsers) skills(ctx context.Context, orgId, username string) ([]map[string]interface{}, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT s."skillId"::text, COALESCE(s."en", ''), COALESCE(s."th", '')
+		FROM public.um_user_with_skills us
+		JOIN public.um_skills s ON us."skillId" = s."skillId"
+		WHERE us."orgId" = $1 AND us."userName" = $2 AND us.active = TRUE`, orgId, username)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var skills []map[string]interface{}
+	for rows.Next() {
+		var skillId, en, th string
+		if err := rows.Scan(&skillId, &en, &th); err != nil {
+			return nil, err
+		}
+		skills = append(skills, map[string]interface{}{
+			"skillId": skillId,
+			"en":      en,
+			"th":      th,
+		})
+	}
+	return skills, rows.Err()
+}
+
+func (r *pgUsers) areas(ctx context.Context, orgId, username string) ([]map[string]interface{}, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT ad."distId", COALESCE(ad."th", ''), COALESCE(ad."en", '')
+		FROM "um_user_with_area_response" ua
+		JOIN "area_districts" ad ON ad."orgId" = ua."orgId"
+		AND ad."distId" IN (
+			SELECT jsonb_array_elements_text(ua."distIdLists")
+		)
+		WHERE ua."orgId" = $1 AND ua."username" = $2`, orgId, username)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var areas []map[string]interface{}
+	for rows.Next() {
+		var distId, th, en string
+		if err := rows.Scan(&distId, &th, &en); err != nil {
+			return nil, err
+		}
+		areas = append(areas, map[string]interface{}{
+			"distId": distId,
+			"th":     th,
+			"en":     en,
+		})
+	}
+	return areas, rows.Err()
+}
+
+func (r *pgUsers) GetByUsername(ctx context.Context, orgId, username string) (*model.Um_User, error) {
+	var orgName, roleName string
+	u, err := scanUser(r.db.QueryRow(ctx, `SELECT `+userColumns+`, t2.name, COALESCE(t3."roleName", '')
+		FROM public.um_users t1
+		JOIN public.organizations t2 ON t1."orgId" = t2.id
+		LEFT JOIN public.um_roles t3 ON t1."roleId" = t3.id
+		WHERE t1.username=$1 AND t1."orgId"=$2`, username, orgId), &orgName, &roleName)
+	if err != nil {
+		return nil, notFound(err)
+	}
+	u.OrgName, u.RoleName = orgName, roleName
+	return &u, nil
+}
+
+func (r *pgUsers) GetActiveByUsername(ctx context.Context, username string) (*model.Um_User, error) {
+	u, err := scanUser(r.db.QueryRow(ctx, `SELECT `+userColumns+`
+	FROM public.um_users t1 WHERE t1.username = $1 AND t1.active = true`, username))
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return &u, nil
+}
+
+func (r *pgUsers) GetOrgIDByName(ctx context.Context, name string) (string, error) {
+	var id string
+	err := r.db.QueryRow(ctx, `SELECT id FROM public.organizations WHERE name = $1`, name).Scan(&id)
+	return id, notFound(err)
+}
+
+func (r *pgUsers) ListPermissionIDs(ctx context.Context, orgId, roleId string) ([]string, error) {
+	rows, err := r.db.Query(ctx, `SELECT "permId" FROM public.um_role_with_permissions
+	WHERE "orgId"=$1 AND "roleId"=$2 AND active = true`, orgId, roleId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var permId string
+		if err := rows.Scan(&permId); err != nil {
+			return nil, err
+		}
+		ids = append(ids, permId)
+	}
+	return ids, rows.Err()
+}
+
+func (r *pgUsers) Create(ctx context.Context, in model.UserWrite, createdBy string) (string, error) {
+	now := time.Now()
+	var id string
+	err := r.db.QueryRow(ctx, `
+		INSERT INTO public.um_users(
+		"orgId", "displayName", title, "firstName", "middleName", "lastName", "citizenId", bod, blood, gender,
+		"mobileNo", address, photo, username, password, email, "roleId", "userType", "empId", "deptId",
+		"commId", "stnId", active, "activationToken", "lastActivationRequest", "lostPasswordRequest",
+		"signupStamp", islogin, "lastLogin", "createdAt", "updatedAt", "createdBy", "updatedBy"
+			)
+	VALUES (
+		$1, $2, $3, $4, $5, $6, $7,
+		$8, $9, $10, $11,
+		$12, $13, $14, $15, $16,
+		$17, $18, $19, $20, $21,
+		$22, $23, $24, $25, $26,
+		$27, $28, $29, $30, $31,
+		$32, $33
+	)
+	RETURNING id`,
+		in.OrgID, in.DisplayName, in.Title, in.FirstName, in.MiddleName,
+		in.LastName, in.CitizenID, in.Bod, in.Blood,
+		in.Gender, in.MobileNo, in.Address, in.Photo, in.Username,
+		in.Password, in.Email, nullable(in.RoleID), in.UserType, in.EmpID, in.DeptID, in.CommID, in.StnID,
+		in.Active, in.ActivationToken, in.LastActivationRequest, in.LostPasswordRequest, in.SignupStamp,
+		in.IsLogin, in.LastLogin, now, now, createdBy, createdBy,
+	).Scan(&id)
+	return id, err
+}
+
+func (r *pgUsers) Update(ctx context.Context, orgId, id string, in model.UserWrite, updatedBy string) error {
+	return r.update(ctx, "id", orgId, id, in, updatedBy)
+}
+
+func (r *pgUsers) UpdateByUsername(ctx context.Context, orgId, username string, in model.UserWrite, updatedBy string) error {
+	return r.update(ctx, "username", orgId, username, in, updatedBy)
+}
+
+// update rewrites a user matched on key, which is either id or username.
+func (r *pgUsers) update(ctx context.Context, key, orgId, value string, in model.UserWrite, updatedBy string) error {
+	_, err := r.db.Exec(ctx, `
+	UPDATE public.um_users
+	SET "displayName"=$1, title=$2, "firstName"=$3, "middleName"=$4, "lastName"=$5, "citizenId"=$6,
+	bod=$7, blood=$8, gender=$9, "mobileNo"=$10, address=$11, photo=$12, username=$13, password=$14, email=$15, "roleId"=$16,
+	"userType"=$17, "empId"=$18, "deptId"=$19, "commId"=$20, "stnId"=$21, active=$22,
+	"lastActivationRequest"=$23, "lostPasswordRequest"=$24, "signupStamp"=$25, islogin=$26, "lastLogin"=$27,
+	"updatedAt"=$28,"updatedBy"=$29 WHERE `+key+` = $30 AND "orgId"=$31`,
+		in.DisplayName, in.Title, in.FirstName, in.MiddleName,
+		in.LastName, in.CitizenID, in.Bod, in.Blood,
+		in.Gender, in.MobileNo, in.Address, in.Photo, in.Username,
+		in.Password, in.Email, nullable(in.RoleID), in.UserType, in.EmpID, in.DeptID, in.CommID, in.StnID,
+		in.Active, in.LastActivationRequest, in.LostPasswordRequest, in.SignupStamp,
+		in.IsLogin, in.LastLogin, time.Now(), updatedBy, value, orgId,
+	)
+	return err
+}
+
+func (r *pgUsers) Delete(ctx context.Context, orgId, id string) error {
+	_, err := r.db.Exec(ctx, `DELETE FROM public."um_users" WHERE id = $1 AND "orgId"=$2`, id, orgId)
+	return err
+}
+
+func (r *pgUsers) SetPassword(ctx context.Context, id, hash string) error {
+	tag, err := r.db.Exec(ctx, `UPDATE public.um_users SET password=$2 WHERE id=$1`, id, hash)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *pgUsers) ListCredentials(ctx context.Context) ([]model.UserCredential, error) {
+	rows, err := r.db.Query(ctx, `SELECT id::text, "orgId"::text, username, active, password
+	FROM public.um_users ORDER BY "orgId", username`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []model.UserCredential
+	for rows.Next() {
+		var u model.UserCredential
+		if err := rows.Scan(&u.ID, &u.OrgID, &u.Username, &u.Active, &u.Password); err != nil {
+			return nil, err
+		}
+		list = append(list, u)
+	}
+	return list, rows.Err()
+}
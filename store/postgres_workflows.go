@@ -0,0 +1,389 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"mainPackage/model"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type pgWorkflows struct {
+	db conn
+}
+
+func (r *pgWorkflows) List(ctx context.Context, orgId string, limit, offset int) ([]model.WorkflowModel, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+wfDefinitionColumns+`
+	FROM public.wf_definitions WHERE "orgId"=$1 ORDER BY id LIMIT $2 OFFSET $3`, orgId, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []model.WorkflowModel
+	for rows.Next() {
+		var w model.WorkflowModel
+		if err := rows.Scan(&w.ID, &w.OrgID, &w.WfID, &w.Title, &w.Desc, &w.Active, &w.Publish, &w.Locks,
+			&w.Versions, &w.CreatedAt, &w.UpdatedAt, &w.CreatedBy, &w.UpdatedBy); err != nil {
+			return nil, err
+		}
+		list = append(list, w)
+	}
+	return list, rows.Err()
+}
+
+const wfDefinitionColumns = `id, "orgId", "wfId", COALESCE(title, ''), COALESCE("desc", ''), active, publish,
+	locks, versions, "createdAt", "updatedAt", COALESCE("createdBy", ''), COALESCE("updatedBy", '')`
+
+func (r *pgWorkflows) GetDefinition(ctx context.Context, orgId, wfId string) (*model.WorkflowModel, error) {
+	var w model.WorkflowModel
+	err := r.db.QueryRow(ctx, `SELECT `+wfDefinitionColumns+`
+	FROM public.wf_definitions WHERE "orgId"=$1 AND "wfId"=$2`, orgId, wfId).
+		Scan(&w.ID, &w.OrgID, &w.WfID, &w.Title, &w.Desc, &w.Active, &w.Publish, &w.Locks,
+			&w.Versions, &w.CreatedAt, &w.UpdatedAt, &w.CreatedBy, &w.UpdatedBy)
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return &w, nil
+}
+
+func (r *pgWorkflows) Get(ctx context.Context, orgId, wfId, versions string) (*model.WorkFlow, error) {
+	if versions == "" {
+		versions = Draft
+	}
+	var meta model.WorkFlowMetadata
+	err := r.db.QueryRow(ctx, `SELECT id, title, "desc", "createdAt", "updatedAt"
+	FROM public.wf_definitions WHERE "orgId"=$1 AND "wfId"=$2`, orgId, wfId).
+		Scan(&meta.Id, &meta.Title, &meta.Desc, &meta.CreatedAt, &meta.UpdatedAt)
+	if err != nil {
+		return nil, notFound(err)
+	}
+	meta.Status = &versions
+
+	rows, err := r.db.Query(ctx, `SELECT section, data FROM public.wf_nodes
+	WHERE "orgId"=$1 AND "wfId"=$2 AND versions=$3 ORDER BY id`, orgId, wfId, versions)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	wf := model.WorkFlow{MetaData: meta}
+	for rows.Next() {
+		var section string
+		var raw []byte
+		if err := rows.Scan(&section, &raw); err != nil {
+			return nil, err
+		}
+		if err := appendSection(&wf, section, raw); err != nil {
+			return nil, err
+		}
+	}
+	return &wf, rows.Err()
+}
+
+// appendSection decodes one wf_nodes data column into the workflow: a
+// "nodes" row holds one node, the "connections" row holds all edges.
+func appendSection(wf *model.WorkFlow, section string, raw []byte) error {
+	switch section {
+	case "nodes":
+		var node map[string]interface{}
+		if err := json.Unmarshal(raw, &node); err != nil {
+			return err
+		}
+		wf.Nodes = append(wf.Nodes, node)
+	case "connections":
+		var edges []map[string]interface{}
+		if err := json.Unmarshal(raw, &edges); err != nil {
+			return err
+		}
+		wf.Connections = append(wf.Connections, edges...)
+	}
+	return nil
+}
+
+func (r *pgWorkflows) Create(ctx context.Context, orgId, wfId, username string, in model.WorkFlowInsert) error {
+	return pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		now := time.Now()
+		_, err := tx.Exec(ctx, `INSERT INTO public.wf_definitions(
+	"orgId", "wfId", title, "desc", active, publish, locks, versions, "createdAt", "updatedAt", "createdBy", "updatedBy")
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`,
+			orgId, wfId, in.MetaData.Title, in.MetaData.Desc, true, false, false, Draft, now, now, username, username)
+		if err != nil {
+			return err
+		}
+		return insertNodes(ctx, tx, orgId, wfId, Draft, username, in)
+	})
+}
+
+func (r *pgWorkflows) Update(ctx context.Context, orgId, wfId, username string, in model.WorkFlowInsert) error {
+	return pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, `UPDATE public.wf_definitions
+	SET title=$3, "desc"=$4, "updatedAt"=$5,"updatedBy"=$6
+		WHERE "wfId"=$1 AND "orgId"=$2`,
+			wfId, orgId, in.MetaData.Title, in.MetaData.Desc, time.Now(), username)
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(ctx, `DELETE FROM public.wf_nodes WHERE "wfId"=$1 AND "orgId"=$2 AND versions=$3`,
+			wfId, orgId, Draft)
+		if err != nil {
+			return err
+		}
+		return insertNodes(ctx, tx, orgId, wfId, Draft, username, in)
+	})
+}
+
+// insertNodes writes one row per node plus a single row holding every
+// connection of the workflow version.
+func insertNodes(ctx context.Context, tx pgx.Tx, orgId, wfId, versions, username string, in model.WorkFlowInsert) error {
+	now := time.Now()
+	for _, item := range in.Nodes {
+		pic, group, formId := nodeRefs(item)
+		_, err := tx.Exec(ctx, `
+		INSERT INTO public.wf_nodes(
+	"orgId", "wfId", "nodeId", versions, type, section, data,pic,"group","formId", "createdAt", "updatedAt", "createdBy", "updatedBy")
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)`,
+			orgId, wfId, item.Id, versions, item.Type, "nodes", item, pic, group, formId, now, now, username, username)
+		if err != nil {
+			return err
+		}
+	}
+	_, err := tx.Exec(ctx, `
+		INSERT INTO public.wf_nodes(
+	"orgId", "wfId", "nodeId", versions, type, section, data, "createdAt", "updatedAt", "createdBy", "updatedBy")
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`,
+		orgId, wfId, "", versions, "", "connections", in.Connections, now, now, username, username)
+	return err
+}
+
+// nodeRefs lifts pic, group and formId out of a node's config so they can be
+// stored in their own columns.
+func nodeRefs(item model.WorkFlowNode) (pic, group, formId *string) {
+	if item.Data == nil || item.Data.Config == nil {
+		return nil, nil, nil
+	}
+	config := *item.Data.Config
+	str := func(key string) *string {
+		if val, ok := config[key].(string); ok {
+			return &val
+		}
+		return nil
+	}
+	return str("pic"), str("group"), str("formId")
+}
+
+func (r *pgWorkflows) Delete(ctx context.Context, orgId, wfId string) error {
+	return pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, `DELETE FROM public."wf_definitions" WHERE "wfId" = $1 AND "orgId"=$2`, wfId, orgId)
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(ctx, `DELETE FROM public."wf_versions" WHERE "wfId" = $1 AND "orgId"=$2`, wfId, orgId)
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(ctx, `DELETE FROM public."wf_nodes" WHERE "wfId" = $1 AND "orgId"=$2`, wfId, orgId)
+		return err
+	})
+}
+
+func (r *pgWorkflows) Publish(ctx context.Context, orgId, wfId, username string) (string, error) {
+	var versions string
+	err := pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		var title, desc *string
+		err := tx.QueryRow(ctx, `SELECT title, "desc" FROM public.wf_definitions
+	WHERE "orgId"=$1 AND "wfId"=$2 FOR UPDATE`, orgId, wfId).Scan(&title, &desc)
+		if err != nil {
+			return notFound(err)
+		}
+		var nodes int
+		err = tx.QueryRow(ctx, `SELECT COUNT(*) FROM public.wf_nodes
+	WHERE "orgId"=$1 AND "wfId"=$2 AND versions=$3 AND section='nodes'`, orgId, wfId, Draft).Scan(&nodes)
+		if err != nil {
+			return err
+		}
+		if nodes == 0 {
+			return ErrEmptyDraft
+		}
+		err = tx.QueryRow(ctx, `SELECT (COALESCE(MAX(versions::int), 0) + 1)::text FROM public.wf_versions
+	WHERE "orgId"=$1 AND "wfId"=$2`, orgId, wfId).Scan(&versions)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		_, err = tx.Exec(ctx, `INSERT INTO public.wf_versions(
+	"orgId", "wfId", versions, title, "desc", "publishedAt", "publishedBy")
+	VALUES ($1, $2, $3, $4, $5, $6, $7)`, orgId, wfId, versions, title, desc, now, username)
+		if err != nil {
+			return err
+		}
+		if err := copyNodes(ctx, tx, orgId, wfId, Draft, versions, username); err != nil {
+			return err
+		}
+		_, err = tx.Exec(ctx, `UPDATE public.wf_definitions
+	SET versions=$3, publish=true, "updatedAt"=$4, "updatedBy"=$5
+	WHERE "orgId"=$1 AND "wfId"=$2`, orgId, wfId, versions, now, username)
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+	return versions, nil
+}
+
+// copyNodes duplicates every wf_nodes row of one version under another.
+func copyNodes(ctx context.Context, tx pgx.Tx, orgId, wfId, from, to, username string) error {
+	now := time.Now()
+	_, err := tx.Exec(ctx, `
+	INSERT INTO public.wf_nodes(
+		"orgId", "wfId", "nodeId", versions, type, section, data, pic, "group", "formId",
+		"createdAt", "updatedAt", "createdBy", "updatedBy")
+	SELECT "orgId", "wfId", "nodeId", $4, type, section, data, pic, "group", "formId", $5, $5, $6, $6
+	FROM public.wf_nodes
+	WHERE "orgId"=$1 AND "wfId"=$2 AND versions=$3
+	ORDER BY id`, orgId, wfId, from, to, now, username)
+	return err
+}
+
+func (r *pgWorkflows) ListVersions(ctx context.Context, orgId, wfId string) ([]model.WorkflowVersion, error) {
+	rows, err := r.db.Query(ctx, `SELECT id, "orgId", "wfId", versions, COALESCE(title, ''), COALESCE("desc", ''),
+	"publishedAt", COALESCE("publishedBy", '')
+	FROM public.wf_versions WHERE "orgId"=$1 AND "wfId"=$2 ORDER BY versions::int DESC`, orgId, wfId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []model.WorkflowVersion
+	for rows.Next() {
+		var v model.WorkflowVersion
+		if err := rows.Scan(&v.ID, &v.OrgID, &v.WfID, &v.Versions, &v.Title, &v.Desc,
+			&v.PublishedAt, &v.PublishedBy); err != nil {
+			return nil, err
+		}
+		list = append(list, v)
+	}
+	return list, rows.Err()
+}
+
+func (r *pgWorkflows) Rollback(ctx context.Context, orgId, wfId, versions, username string) error {
+	return pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		var title, desc *string
+		err := tx.QueryRow(ctx, `SELECT title, "desc" FROM public.wf_versions
+	WHERE "orgId"=$1 AND "wfId"=$2 AND versions=$3`, orgId, wfId, versions).Scan(&title, &desc)
+		if err != nil {
+			return notFound(err)
+		}
+		_, err = tx.Exec(ctx, `DELETE FROM public.wf_nodes WHERE "wfId"=$1 AND "orgId"=$2 AND versions=$3`,
+			wfId, orgId, Draft)
+		if err != nil {
+			return err
+		}
+		if err := copyNodes(ctx, tx, orgId, wfId, versions, Draft, username); err != nil {
+			return err
+		}
+		_, err = tx.Exec(ctx, `UPDATE public.wf_definitions
+	SET title=$3, "desc"=$4, "updatedAt"=$5, "updatedBy"=$6
+	WHERE "orgId"=$1 AND "wfId"=$2`, orgId, wfId, title, desc, time.Now(), username)
+		return err
+	})
+}
+
+const wfNodeColumns = `t1.id, t1."orgId", t1."wfId", t1."nodeId", t1.versions, COALESCE(t1.type, ''), t1.section, t1.data,
+	t1.pic, t1."group", t1."formId", t1."createdAt", t1."updatedAt", COALESCE(t1."createdBy", ''), COALESCE(t1."updatedBy", '')`
+
+func scanWfNode(row pgx.Row) (model.WfNode, error) {
+	var n model.WfNode
+	err := row.Scan(&n.ID, &n.OrgID, &n.WfID, &n.NodeID, &n.Versions, &n.Type, &n.Section, &n.Data,
+		&n.Pic, &n.Group, &n.FormID, &n.CreatedAt, &n.UpdatedAt, &n.CreatedBy, &n.UpdatedBy)
+	return n, err
+}
+
+func (r *pgWorkflows) GetVersionNode(ctx context.Context, orgId, wfId, versions, nodeId string) (*model.WfNode, error) {
+	n, err := scanWfNode(r.db.QueryRow(ctx, `
+	SELECT `+wfNodeColumns+`
+	FROM public.wf_nodes t1
+	WHERE t1."orgId" = $1 AND t1."wfId" = $2 AND t1.versions = $3 AND t1."nodeId" = $4 AND t1.section = 'nodes'`,
+		orgId, wfId, versions, nodeId))
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return &n, nil
+}
+
+func (r *pgWorkflows) ListEdges(ctx context.Context, orgId, wfId, versions string) ([]model.WorkFlowConnection, error) {
+	var raw []byte
+	err := r.db.QueryRow(ctx, `SELECT data FROM public.wf_nodes
+	WHERE "orgId"=$1 AND "wfId"=$2 AND versions=$3 AND section = 'connections'`, orgId, wfId, versions).Scan(&raw)
+	if err != nil {
+		return nil, notFound(err)
+	}
+	var edges []model.WorkFlowConnection
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &edges); err != nil {
+			return nil, err
+		}
+	}
+	return edges, nil
+}
+
+func (r *pgWorkflows) ListNodes(ctx context.Context, orgId, wfId, versions string) ([]model.WorkflowNode, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT "nodeId", COALESCE("type", ''), "section", "data"
+		FROM public.wf_nodes
+		WHERE "orgId"=$1 AND "wfId"=$2 AND "versions"=$3
+		ORDER BY
+			CASE
+				WHEN "section" = 'nodes' THEN 1
+				WHEN "section" = 'connections' THEN 2
+				ELSE 3
+			END, id`, orgId, wfId, versions)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []model.WorkflowNode
+	for rows.Next() {
+		var n model.WorkflowNode
+		if err := rows.Scan(&n.NodeId, &n.Type, &n.Section, &n.Data); err != nil {
+			return nil, err
+		}
+		list = append(list, n)
+	}
+	return list, rows.Err()
+}
+
+func (r *pgWorkflows) ListProcessNodes(ctx context.Context, orgId, wfId string) ([]model.WfNode, error) {
+	rows, err := r.db.Query(ctx, `
+	SELECT `+wfNodeColumns+`
+	FROM public.wf_nodes t1
+	JOIN public.wf_definitions t2
+	  ON t1."wfId" = t2."wfId" AND t1."versions" = t2."versions" AND t1."orgId" = t2."orgId"
+	WHERE t2."wfId" = $1 AND t2."orgId" = $2
+	  AND t1.section = 'nodes' AND LOWER(t1."type") = 'process'
+	ORDER BY t1.id`, wfId, orgId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []model.WfNode
+	for rows.Next() {
+		n, err := scanWfNode(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, n)
+	}
+	return list, rows.Err()
+}
+
+func (r *pgWorkflows) GetIDByCaseSubType(ctx context.Context, orgId, sTypeId string) (string, error) {
+	var wfId string
+	err := r.db.QueryRow(ctx, `SELECT COALESCE("wfId", '') FROM public.case_sub_types
+	WHERE "orgId"=$1 AND "sTypeId"=$2`, orgId, sTypeId).Scan(&wfId)
+	return wfId, notFound(err)
+}
@@ -0,0 +1,546 @@
+// Package store holds the persistence layer behind the HTTP handlers. Each
+// aggregate is reached through a small repository interface so handlers never
+// see SQL, and the same handlers can run against Postgres or the in-memory
+// implementation.
+package store
+
+import (
+	"context"
+	"errors"
+	"mainPackage/model"
+	"time"
+)
+
+// Draft is the versions value of the editable copy of a workflow or form;
+// published copies are numbered from "1".
+const Draft = "draft"
+
+// ErrNotFound is returned when a lookup matches no row.
+var ErrNotFound = errors.New("not found")
+
+// ErrEmptyDraft is returned when publishing a workflow whose draft has no
+// nodes or a form whose draft has no elements.
+var ErrEmptyDraft = errors.New("draft is empty")
+
+// ErrLocked is returned when editing a form that is locked.
+var ErrLocked = errors.New("form is locked")
+
+// Store groups the repositories. Obtain one from NewPostgres or NewMemory.
+type Store struct {
+	Cases         CaseRepository
+	Workflows     WorkflowRepository
+	Forms         FormRepository
+	Notifications NotificationRepository
+	Units         UnitRepository
+	Users         UserRepository
+	Tokens        TokenRepository
+	Outbox        OutboxRepository
+	Escalations   EscalationRepository
+	Audit         AuditRepository
+	Transitions   CaseTransitionRepository
+	Dispatches    DispatchRepository
+	Org           OrgRepository
+	Roles         RoleRepository
+	Skills        SkillRepository
+	Profiles      ProfileRepository
+	CaseTypes     CaseTypeRepository
+	Customers     CustomerRepository
+	MasterData    MasterDataRepository
+	Sessions      SessionRepository
+	Areas         AreaRepository
+	Devices       DeviceRepository
+
+	inTx func(ctx context.Context, fn func(tx *Store) error) error
+}
+
+// InTx runs fn with a Store whose repositories share one transaction. The
+// transaction commits when fn returns nil and rolls back otherwise.
+func (s *Store) InTx(ctx context.Context, fn func(tx *Store) error) error {
+	return s.inTx(ctx, fn)
+}
+
+// CaseRepository covers tix_cases together with the current stage and
+// history rows that hang off a case.
+type CaseRepository interface {
+	List(ctx context.Context, orgId string, filter model.CaseFilter) ([]model.Case, error)
+	GetByID(ctx context.Context, orgId, id string) (*model.Case, error)
+	GetByCaseID(ctx context.Context, orgId, caseId string) (*model.Case, error)
+	Create(ctx context.Context, orgId, caseId, username string, in model.CaseInsert) (string, error)
+	Update(ctx context.Context, orgId, id, username string, in model.CaseUpdate) error
+	// SetStatus writes c's statusId, lifecycle dates and users and
+	// caseDuration, provided the case is still in fromStatusId; otherwise
+	// it returns ErrNotFound.
+	SetStatus(ctx context.Context, orgId, fromStatusId, username string, c model.Case) error
+	Delete(ctx context.Context, orgId, id string) error
+
+	GetCurrentStage(ctx context.Context, orgId, caseId string) (*model.CurrentStage, error)
+	CreateCurrentStage(ctx context.Context, orgId, caseId, username string, node model.WfNode) error
+	// UpdateCurrentStage moves the case's latest stage row from node fromNodeId
+	// onto node. ErrNotFound means the case has no stage or is no longer on
+	// fromNodeId.
+	UpdateCurrentStage(ctx context.Context, orgId, caseId, username, fromNodeId string, node model.WfNode) error
+	// ListStageHistory returns the transitions of a case, oldest first.
+	ListStageHistory(ctx context.Context, orgId, caseId string) ([]model.CaseStageHistory, error)
+	AddStageHistory(ctx context.Context, orgId, username string, in model.CaseStageHistoryInsert) (int, error)
+
+	ListHistory(ctx context.Context, orgId string, limit, offset int) ([]model.CaseHistory, error)
+	ListHistoryByCaseID(ctx context.Context, orgId, caseId string) ([]model.CaseHistory, error)
+	AddHistory(ctx context.Context, orgId, username string, in model.CaseHistoryInsert) (int, error)
+	UpdateHistory(ctx context.Context, orgId, id, username string, in model.CaseHistoryUpdate) error
+	DeleteHistory(ctx context.Context, orgId, id string) error
+}
+
+// WorkflowRepository covers wf_definitions, their wf_nodes rows and the
+// published wf_versions. Editing only ever touches the "draft" rows; a
+// definition's versions column names the latest published version, which is
+// what new cases start on.
+type WorkflowRepository interface {
+	List(ctx context.Context, orgId string, limit, offset int) ([]model.WorkflowModel, error)
+	GetDefinition(ctx context.Context, orgId, wfId string) (*model.WorkflowModel, error)
+	// Get returns the nodes and connections of one version, or of the draft
+	// when versions is empty.
+	Get(ctx context.Context, orgId, wfId, versions string) (*model.WorkFlow, error)
+	Create(ctx context.Context, orgId, wfId, username string, in model.WorkFlowInsert) error
+	// Update replaces the draft and leaves published versions alone.
+	Update(ctx context.Context, orgId, wfId, username string, in model.WorkFlowInsert) error
+	Delete(ctx context.Context, orgId, wfId string) error
+
+	// Publish freezes the draft into the next numbered version and makes it
+	// current. It returns the new version.
+	Publish(ctx context.Context, orgId, wfId, username string) (string, error)
+	ListVersions(ctx context.Context, orgId, wfId string) ([]model.WorkflowVersion, error)
+	// Rollback replaces the draft with a copy of a published version.
+	Rollback(ctx context.Context, orgId, wfId, versions, username string) error
+
+	// GetVersionNode returns a node of the given version.
+	GetVersionNode(ctx context.Context, orgId, wfId, versions, nodeId string) (*model.WfNode, error)
+	// ListEdges returns the connections of one version.
+	ListEdges(ctx context.Context, orgId, wfId, versions string) ([]model.WorkFlowConnection, error)
+	// ListNodes returns nodes then connections of one version.
+	ListNodes(ctx context.Context, orgId, wfId, versions string) ([]model.WorkflowNode, error)
+	// ListProcessNodes returns the "process" nodes of the current version.
+	ListProcessNodes(ctx context.Context, orgId, wfId string) ([]model.WfNode, error)
+	// GetIDByCaseSubType resolves the workflow bound to a case sub type.
+	GetIDByCaseSubType(ctx context.Context, orgId, sTypeId string) (string, error)
+}
+
+// FormRepository covers form_builder, form_elements, form_versions and the
+// form_submissions made against cases.
+type FormRepository interface {
+	Get(ctx context.Context, orgId, formId, versions string) (*model.Form, error)
+	// GetCurrent returns the form with the elements of its current version.
+	GetCurrent(ctx context.Context, orgId, formId string) (*model.Form, error)
+	// CurrentVersion returns the version GetCurrent reads: the latest
+	// published one, or the draft when the form was never published.
+	CurrentVersion(ctx context.Context, orgId, formId string) (string, error)
+	List(ctx context.Context, orgId string) ([]model.FormsManager, error)
+	NameExists(ctx context.Context, orgId, name string) (bool, error)
+	Exists(ctx context.Context, orgId, formId string) (bool, error)
+	Create(ctx context.Context, orgId, formId, username string, in model.FormInsert) error
+	// Update replaces the draft; published versions are left alone. It
+	// returns ErrLocked when the form is locked.
+	Update(ctx context.Context, orgId, formId, username string, in model.FormUpdate) error
+	// Publish copies the draft elements into the next numbered version and
+	// makes it current.
+	Publish(ctx context.Context, orgId, formId, username string) (string, error)
+	// ListVersions returns the published versions, newest first.
+	ListVersions(ctx context.Context, orgId, formId string) ([]model.FormVersion, error)
+	SetActive(ctx context.Context, orgId, formId, username string, active bool) error
+	SetPublish(ctx context.Context, orgId, formId, username string, publish bool) error
+	SetLock(ctx context.Context, orgId, formId, username string, locks bool) error
+
+	// CreateSubmission stores the next revision of a form for a case and
+	// node.
+	CreateSubmission(ctx context.Context, orgId, caseId, formId, username string, in model.FormSubmissionInsert) (*model.FormSubmission, error)
+	// ListSubmissions returns a case's submissions, newest first.
+	ListSubmissions(ctx context.Context, orgId, caseId string, filter model.FormSubmissionFilter) ([]model.FormSubmission, error)
+}
+
+// NotificationRepository covers the notifications table.
+type NotificationRepository interface {
+	// Create inserts n and fills in its ID.
+	Create(ctx context.Context, n *model.Notification) error
+	Get(ctx context.Context, id int64) (*model.Notification, error)
+	// Update rewrites the notification and its recipient rows in several
+	// statements; run it inside Store.InTx.
+	Update(ctx context.Context, id int64, in model.Notification) error
+	Delete(ctx context.Context, id int64) error
+	// ListForUser returns the notifications whose recipients match the
+	// user's profile, newest first, each with the user's State. ErrNotFound
+	// means no active user.
+	ListForUser(ctx context.Context, orgId, username string) ([]model.Notification, error)
+	// ListInbox is ListForUser narrowed by f, newest first with ties broken
+	// by id.
+	ListInbox(ctx context.Context, orgId, username string, f model.NotificationInboxFilter) ([]model.Notification, error)
+	// GetForUser returns notification id with the user's State. ErrNotFound
+	// means it is not addressed to the user, or no active user.
+	GetForUser(ctx context.Context, orgId, username string, id int64) (*model.Notification, error)
+	// CountUnread counts the notifications addressed to the user that are
+	// neither read nor dismissed. ErrNotFound means no active user.
+	CountUnread(ctx context.Context, orgId, username string) (int, error)
+	// UnreadIDs returns the ids of the unread notifications addressed to the
+	// user, limited to among unless among is nil.
+	UnreadIDs(ctx context.Context, orgId, username string, among []int64) ([]int64, error)
+	// SetState stamps one state (read, acknowledged, ...) for a user on the
+	// given notifications, creating state rows as needed. A stamp already
+	// set is kept; the count is of rows that gained it.
+	SetState(ctx context.Context, orgId, username string, ids []int64, state string, at time.Time) (int64, error)
+	// MarkDelivered stamps the delivered state of one notification for each
+	// of usernames.
+	MarkDelivered(ctx context.Context, orgId string, id int64, usernames []string, at time.Time) error
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+	// ListEscalationDue returns the unexpired notifications of eventType
+	// sent before sentBefore that have taken exactly level escalation steps
+	// and that no recipient has acknowledged.
+	ListEscalationDue(ctx context.Context, orgId, eventType string, level int, sentBefore, now time.Time) ([]model.Notification, error)
+	// Escalate adds step.Added to the recipients and appends step to the
+	// escalations of notification id. ErrNotFound means the notification is
+	// gone or no longer at step.Level-1.
+	Escalate(ctx context.Context, id int64, step model.NotificationEscalation) error
+}
+
+// EscalationRepository covers notification_escalation_policies and the
+// station and command lookups escalation widens recipients with.
+type EscalationRepository interface {
+	List(ctx context.Context, orgId string) ([]model.EscalationPolicy, error)
+	// ListActive returns the active policies of every organization.
+	ListActive(ctx context.Context) ([]model.EscalationPolicy, error)
+	Get(ctx context.Context, orgId, id string) (*model.EscalationPolicy, error)
+	Create(ctx context.Context, orgId, username string, in model.EscalationPolicyInput) (string, error)
+	Update(ctx context.Context, orgId, id, username string, in model.EscalationPolicyInput) error
+	Delete(ctx context.Context, orgId, id string) error
+	// ResolveUnits returns the stations among stnIds and the commands among
+	// commIds together with the units above them.
+	ResolveUnits(ctx context.Context, orgId string, stnIds, commIds []string) ([]model.OrgUnit, error)
+}
+
+// CaseTransitionRepository covers case_status_transitions, the status changes
+// an organization allows on its cases.
+type CaseTransitionRepository interface {
+	List(ctx context.Context, orgId string) ([]model.CaseStatusTransition, error)
+	Get(ctx context.Context, orgId, id string) (*model.CaseStatusTransition, error)
+	// Find returns the transition between two statuses, active or not. An
+	// empty fromStatusId matches the initial transition.
+	Find(ctx context.Context, orgId, fromStatusId, toStatusId string) (*model.CaseStatusTransition, error)
+	// Initial returns the transition without fromStatusId, active or not,
+	// whose toStatusId new cases start in.
+	Initial(ctx context.Context, orgId string) (*model.CaseStatusTransition, error)
+	Create(ctx context.Context, orgId, username string, in model.CaseStatusTransitionInput) (string, error)
+	Update(ctx context.Context, orgId, id, username string, in model.CaseStatusTransitionInput) error
+	Delete(ctx context.Context, orgId, id string) error
+}
+
+// DispatchRepository covers case_dispatches, the units sent to each case.
+type DispatchRepository interface {
+	// ListByCase returns the case's dispatches, oldest first.
+	ListByCase(ctx context.Context, orgId, caseId string) ([]model.CaseDispatch, error)
+	// GetOpen returns the unit's unreleased dispatch, whichever case it is
+	// on. A unit has at most one.
+	GetOpen(ctx context.Context, orgId, unitId string) (*model.CaseDispatch, error)
+	Create(ctx context.Context, orgId, username string, d model.CaseDispatch) (string, error)
+	// Update writes d's status, step times and releasedBy. It returns
+	// ErrNotFound when the row is no longer at fromStatus.
+	Update(ctx context.Context, orgId, fromStatus, username string, d model.CaseDispatch) error
+}
+
+// AuditRepository covers audit_logs. Each org's rows form a hash chain in
+// id order; rows without an org share a chain of their own.
+type AuditRepository interface {
+	// Insert chains each entry onto its org's last row and writes them all
+	// in one transaction. The audit writer passes one org's entries at a
+	// time so a failure holds back only that org.
+	Insert(ctx context.Context, entries []model.AuditLog) error
+	// ListChain returns up to limit rows of the org's chain after afterID,
+	// in id order. An empty orgId lists the rows without an org.
+	ListChain(ctx context.Context, orgId string, afterID, limit int) ([]model.AuditLog, error)
+	// ChainOrgs lists the orgs that have audit rows.
+	ChainOrgs(ctx context.Context) ([]string, error)
+	// ChainStart returns the id of the last row written before the chain
+	// existed; every later row must carry a hash.
+	ChainStart(ctx context.Context) (int, error)
+	// Search lists the org's rows matching f, newest first.
+	Search(ctx context.Context, orgId string, f model.AuditLogFilter) ([]model.AuditLog, error)
+}
+
+// OutboxRepository covers notification_outbox, the deliveries owed for
+// committed notifications.
+type OutboxRepository interface {
+	Enqueue(ctx context.Context, orgId string, notificationId int64, channel string) error
+	// Claim leases up to limit due pending entries: each has its attempt
+	// counted and its next attempt pushed lease into the future, so an entry
+	// whose worker dies is picked up again once the lease runs out.
+	Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]model.OutboxEntry, error)
+	MarkDelivered(ctx context.Context, id int64, at time.Time) error
+	// MarkFailed records a failed attempt. The entry is retried at next, or
+	// dead-lettered when dead is set.
+	MarkFailed(ctx context.Context, id int64, errText string, next time.Time, dead bool) error
+	// List returns an organization's entries, newest first.
+	List(ctx context.Context, orgId string, filter model.OutboxFilter) ([]model.OutboxEntry, error)
+	// Requeue moves a dead entry back to pending with a fresh attempt count.
+	Requeue(ctx context.Context, orgId string, id int64, now time.Time) error
+	// DeleteDelivered drops entries delivered before the given time.
+	DeleteDelivered(ctx context.Context, before time.Time) (int64, error)
+}
+
+// UnitRepository covers mdm_units and their properties.
+type UnitRepository interface {
+	List(ctx context.Context, orgId string, limit, offset int) ([]model.MmdUnit, error)
+	GetByID(ctx context.Context, orgId, id string) (*model.MmdUnit, error)
+	// GetByUnitID looks a unit up by its unitId rather than its row id.
+	GetByUnitID(ctx context.Context, orgId, unitId string) (*model.MmdUnit, error)
+	Create(ctx context.Context, orgId, username string, in model.MmdUnitInsert) (string, error)
+	Update(ctx context.Context, orgId, id, username string, in model.MmdUnitUpdate) error
+	Delete(ctx context.Context, orgId, id string) error
+	// SetStatus writes the sttId of the unit with unitId and nothing else.
+	SetStatus(ctx context.Context, orgId, unitId, sttId, username string) error
+	ListProperties(ctx context.Context, orgId, unitId string, limit, offset int) ([]model.MdmUnitProperty, error)
+	// FindCandidates returns active units whose properties, crew skills and
+	// response area satisfy the case's sub type.
+	FindCandidates(ctx context.Context, orgId, caseId string) ([]model.UnitUser, error)
+}
+
+// OrgRepository covers the organization tree: sec_departments,
+// sec_commands and sec_stations. Rows are read by their deptId, commId or
+// stnId and written by their row id.
+type OrgRepository interface {
+	ListDepartments(ctx context.Context, orgId string, limit, offset int) ([]model.Department, error)
+	GetDepartment(ctx context.Context, orgId, deptId string) (*model.Department, error)
+	CreateDepartment(ctx context.Context, orgId, deptId, username string, in model.DepartmentInsert) error
+	UpdateDepartment(ctx context.Context, orgId, id, username string, in model.DepartmentUpdate) error
+	DeleteDepartment(ctx context.Context, orgId, id string) error
+
+	ListCommands(ctx context.Context, orgId string, limit, offset int) ([]model.Command, error)
+	GetCommand(ctx context.Context, orgId, commId string) (*model.Command, error)
+	CreateCommand(ctx context.Context, orgId, commId, username string, in model.CommandInsert) error
+	UpdateCommand(ctx context.Context, orgId, id, username string, in model.CommandUpdate) error
+	DeleteCommand(ctx context.Context, orgId, id string) error
+
+	ListStations(ctx context.Context, orgId string, limit, offset int) ([]model.Station, error)
+	// ListStationTree returns every station with its command and
+	// department, ordered by stnId.
+	ListStationTree(ctx context.Context, orgId string) ([]model.StationWithCommandDept, error)
+	GetStation(ctx context.Context, orgId, stnId string) (*model.Station, error)
+	CreateStation(ctx context.Context, orgId, stnId, username string, in model.StationInsert) error
+	UpdateStation(ctx context.Context, orgId, id, username string, in model.StationUpdate) error
+	DeleteStation(ctx context.Context, orgId, id string) error
+}
+
+// RoleRepository covers um_roles, the um_permissions catalogue and the
+// grants between them in um_role_with_permissions. Permissions are shared by
+// every organization and keyed by permId; grants are keyed by their row id.
+type RoleRepository interface {
+	List(ctx context.Context, orgId string, limit, offset int) ([]model.Role, error)
+	Get(ctx context.Context, orgId, id string) (*model.Role, error)
+	Create(ctx context.Context, orgId, username string, in model.RoleInsert) (string, error)
+	Update(ctx context.Context, orgId, id, username string, in model.RoleUpdate) error
+	Delete(ctx context.Context, orgId, id string) error
+
+	ListPermissions(ctx context.Context, limit, offset int) ([]model.Permission, error)
+	GetPermission(ctx context.Context, permId string) (*model.Permission, error)
+	CreatePermission(ctx context.Context, permId, username string, in model.PermissionInsert) error
+	UpdatePermission(ctx context.Context, permId, username string, in model.PermissionUpdate) error
+	DeletePermission(ctx context.Context, permId string) error
+
+	ListGrants(ctx context.Context, orgId string, limit, offset int) ([]model.RolePermission, error)
+	GetGrant(ctx context.Context, orgId, id string) (*model.RolePermission, error)
+	ListRoleGrants(ctx context.Context, orgId, roleId string) ([]model.RolePermission, error)
+	AddGrants(ctx context.Context, orgId, roleId, username string, perms []model.RolePermissionBody) error
+	// SetGrants replaces every grant of roleId with perms.
+	SetGrants(ctx context.Context, orgId, roleId, username string, perms []model.RolePermissionBody) error
+	DeleteGrant(ctx context.Context, orgId, id string) error
+}
+
+// SkillRepository covers the um_skills catalogue, read by skillId and
+// written by row id, and the skills held by users in um_user_with_skills.
+type SkillRepository interface {
+	List(ctx context.Context, orgId string, limit, offset int) ([]model.Skill, error)
+	Get(ctx context.Context, orgId, skillId string) (*model.Skill, error)
+	Create(ctx context.Context, orgId, skillId, username string, in model.SkillInsert) error
+	Update(ctx context.Context, orgId, id, username string, in model.SkillUpdate) error
+	Delete(ctx context.Context, orgId, id string) error
+
+	ListUserSkills(ctx context.Context, orgId string, limit, offset int) ([]model.UserSkill, error)
+	GetUserSkill(ctx context.Context, orgId, id string) (*model.UserSkill, error)
+	// ListSkillUsers returns the users holding skillId.
+	ListSkillUsers(ctx context.Context, orgId, skillId string) ([]model.UserSkill, error)
+	CreateUserSkill(ctx context.Context, orgId, username string, in model.UserSkillInsert) error
+	UpdateUserSkill(ctx context.Context, orgId, id, username string, in model.UserSkillUpdate) error
+	DeleteUserSkill(ctx context.Context, orgId, id string) error
+}
+
+// ProfileRepository covers the contacts and social accounts kept for users,
+// um_user_contacts and um_user_with_socials, and the um_groups catalogue.
+type ProfileRepository interface {
+	ListContacts(ctx context.Context, orgId string, limit, offset int) ([]model.UserContact, error)
+	GetContact(ctx context.Context, orgId, id string) (*model.UserContact, error)
+	CreateContact(ctx context.Context, orgId, username string, in model.UserContactInsert) error
+	UpdateContact(ctx context.Context, orgId, id, username string, in model.UserContactInsertUpdate) error
+	DeleteContact(ctx context.Context, orgId, id string) error
+
+	ListSocials(ctx context.Context, orgId string, limit, offset int) ([]model.UserSocial, error)
+	GetSocial(ctx context.Context, orgId, id string) (*model.UserSocial, error)
+	CreateSocial(ctx context.Context, orgId, username string, in model.UserSocialInsert) error
+	UpdateSocial(ctx context.Context, orgId, id, username string, in model.UserSocialUpdate) error
+	DeleteSocial(ctx context.Context, orgId, id string) error
+
+	ListGroups(ctx context.Context, orgId string, limit, offset int) ([]model.UmGroup, error)
+}
+
+// CaseTypeRepository covers the case catalogues: case_types and
+// case_sub_types, written by row id, and the case_status list shared by
+// every organization.
+type CaseTypeRepository interface {
+	List(ctx context.Context, orgId string, limit, offset int) ([]model.CaseType, error)
+	// ListWithSubTypes returns each case type joined with its sub types; a
+	// type without any appears once with the sub type fields nil.
+	ListWithSubTypes(ctx context.Context, orgId string) ([]model.CaseTypeWithSubType, error)
+	Create(ctx context.Context, orgId, typeId, username string, in model.CaseTypeInsert) error
+	Update(ctx context.Context, orgId, id, username string, in model.CaseTypeUpdate) error
+	Delete(ctx context.Context, orgId, id string) error
+
+	ListSubTypes(ctx context.Context, orgId string, limit, offset int) ([]model.CaseSubType, error)
+	CreateSubType(ctx context.Context, orgId, sTypeId, username string, in model.CaseSubTypeInsert) error
+	UpdateSubType(ctx context.Context, orgId, id, username string, in model.CaseSubTypeUpdate) error
+	DeleteSubType(ctx context.Context, orgId, id string) error
+
+	ListStatuses(ctx context.Context, limit, offset int) ([]model.CaseStatus, error)
+	GetStatus(ctx context.Context, id string) (*model.CaseStatus, error)
+	CreateStatus(ctx context.Context, statusId, username string, in model.CaseStatusInsert) error
+	UpdateStatus(ctx context.Context, id, username string, in model.CaseStatusUpdate) error
+	DeleteStatus(ctx context.Context, id string) error
+}
+
+// CustomerRepository covers cust_customers together with the social
+// accounts and contacts kept per customer, all written by row id.
+type CustomerRepository interface {
+	List(ctx context.Context, orgId string, limit, offset int) ([]model.Customer, error)
+	Get(ctx context.Context, orgId, id string) (*model.Customer, error)
+	Create(ctx context.Context, orgId, username string, in model.CustomerInsert) error
+	Update(ctx context.Context, orgId, id, username string, in model.CustomerUpdate) error
+	Delete(ctx context.Context, orgId, id string) error
+
+	ListSocials(ctx context.Context, orgId string, limit, offset int) ([]model.CustomerSocial, error)
+	GetSocial(ctx context.Context, orgId, id string) (*model.CustomerSocial, error)
+	CreateSocial(ctx context.Context, orgId, username string, in model.CustomerSocialInsert) error
+	UpdateSocial(ctx context.Context, orgId, id, username string, in model.CustomerSocialUpdate) error
+	DeleteSocial(ctx context.Context, orgId, id string) error
+
+	ListContacts(ctx context.Context, orgId string, limit, offset int) ([]model.CustomerContact, error)
+	GetContact(ctx context.Context, orgId, id string) (*model.CustomerContact, error)
+	CreateContact(ctx context.Context, orgId, username string, in model.CustomerContactInsert) error
+	UpdateContact(ctx context.Context, orgId, id, username string, in model.CustomerContactUpdate) error
+	DeleteContact(ctx context.Context, orgId, id string) error
+}
+
+// MasterDataRepository covers the unit catalogues: mdm_properties,
+// mdm_unit_sources and mdm_unit_types per organization, and mdm_companies and
+// mdm_unit_statuses shared by every organization. Rows are read and written
+// by their row id.
+type MasterDataRepository interface {
+	ListProperties(ctx context.Context, orgId string, limit, offset int) ([]model.MmdProperty, error)
+	GetProperty(ctx context.Context, orgId, id string) (*model.MmdProperty, error)
+	CreateProperty(ctx context.Context, orgId, propId, username string, in model.MmdPropertyInsert) error
+	UpdateProperty(ctx context.Context, orgId, id, username string, in model.MmdPropertyUpdate) error
+	DeleteProperty(ctx context.Context, orgId, id string) error
+
+	ListUnitSources(ctx context.Context, orgId string, limit, offset int) ([]model.MmdUnitSource, error)
+	GetUnitSource(ctx context.Context, orgId, id string) (*model.MmdUnitSource, error)
+	CreateUnitSource(ctx context.Context, orgId, unitSourceId, username string, in model.MmdUnitSourceInsert) error
+	UpdateUnitSource(ctx context.Context, orgId, id, username string, in model.MmdUnitSourceUpdate) error
+	DeleteUnitSource(ctx context.Context, orgId, id string) error
+
+	ListUnitTypes(ctx context.Context, orgId string, limit, offset int) ([]model.MmdUnitType, error)
+	GetUnitType(ctx context.Context, orgId, id string) (*model.MmdUnitType, error)
+	CreateUnitType(ctx context.Context, orgId, unitTypeId, username string, in model.MmdUnitTypeInsert) error
+	UpdateUnitType(ctx context.Context, orgId, id, username string, in model.MmdUnitTypeUpdate) error
+	DeleteUnitType(ctx context.Context, orgId, id string) error
+
+	ListCompanies(ctx context.Context, limit, offset int) ([]model.MmdCompanies, error)
+	GetCompany(ctx context.Context, id string) (*model.MmdCompanies, error)
+	CreateCompany(ctx context.Context, id, username string, in model.MmdCompaniesInsert) error
+	UpdateCompany(ctx context.Context, id, username string, in model.MmdCompaniesUpdate) error
+	DeleteCompany(ctx context.Context, id string) error
+
+	ListUnitStatuses(ctx context.Context, limit, offset int) ([]model.MmdUnitStatus, error)
+	GetUnitStatus(ctx context.Context, id string) (*model.MmdUnitStatus, error)
+	CreateUnitStatus(ctx context.Context, username string, in model.MmdUnitStatusInsert) error
+	// UpdateUnitStatus renames the status on the units in it as well.
+	UpdateUnitStatus(ctx context.Context, id, username string, in model.MmdUnitStatusUpdate) error
+	DeleteUnitStatus(ctx context.Context, id string) error
+}
+
+// SessionRepository covers the notification sockets: a user_sessions row per
+// open socket, stamped with the instance holding it, and a user_connections
+// presence row for every employee with at least one session. Changes for one
+// employee are serialized across instances.
+type SessionRepository interface {
+	// GetProfile returns the user's presence row or, when there is none,
+	// builds it from their active um_users row, groups and response area.
+	GetProfile(ctx context.Context, orgId, username string) (*model.UserConnectionInfo, error)
+	// Add records the session held by instanceId and upserts the presence
+	// row.
+	Add(ctx context.Context, instanceId string, info model.UserConnectionInfo) error
+	// Remove drops a session, and the presence row once the employee has no
+	// session left.
+	Remove(ctx context.Context, empId, sessionId string) error
+	// Beat refreshes the sessions instanceId holds.
+	Beat(ctx context.Context, instanceId string) error
+	// ListStale returns the employees with a session not refreshed within
+	// stale, or with a presence row but no session.
+	ListStale(ctx context.Context, stale time.Duration) ([]string, error)
+	// Sweep removes empId's sessions not refreshed within stale, and the
+	// presence row when none is left, and returns how many it removed.
+	Sweep(ctx context.Context, empId string, stale time.Duration) (int64, error)
+}
+
+// AreaRepository covers the area_districts of an organization together with
+// the area_provinces and area_countries they belong to.
+type AreaRepository interface {
+	// ListDistricts returns every district of orgId with its province and
+	// country, followed by the provinces and countries without one.
+	ListDistricts(ctx context.Context, orgId string) ([]model.AreaDistrictWithDetails, error)
+	// InProvince reports whether any of distIds is a district of provId.
+	InProvince(ctx context.Context, orgId, provId string, distIds []string) (bool, error)
+}
+
+// DeviceRepository covers device_iot, read by deviceId.
+type DeviceRepository interface {
+	List(ctx context.Context, orgId string, limit, offset int) ([]model.DeviceIoT, error)
+	Get(ctx context.Context, orgId, deviceId string) (*model.DeviceIoT, error)
+}
+
+// UserRepository covers um_users and the lookups made around login.
+type UserRepository interface {
+	List(ctx context.Context, orgId string, limit, offset int) ([]model.Um_User, error)
+	// GetByID also loads the user's skills and response areas.
+	GetByID(ctx context.Context, orgId, id string) (*model.Um_User, error)
+	// GetByUsername also loads the organization and role names.
+	GetByUsername(ctx context.Context, orgId, username string) (*model.Um_User, error)
+	GetActiveByUsername(ctx context.Context, username string) (*model.Um_User, error)
+	GetOrgIDByName(ctx context.Context, name string) (string, error)
+	ListPermissionIDs(ctx context.Context, orgId, roleId string) ([]string, error)
+	Create(ctx context.Context, in model.UserWrite, createdBy string) (string, error)
+	Update(ctx context.Context, orgId, id string, in model.UserWrite, updatedBy string) error
+	UpdateByUsername(ctx context.Context, orgId, username string, in model.UserWrite, updatedBy string) error
+	Delete(ctx context.Context, orgId, id string) error
+	// SetPassword replaces the stored password hash of the user with id.
+	SetPassword(ctx context.Context, id, hash string) error
+	// ListCredentials returns the stored password of every account.
+	ListCredentials(ctx context.Context) ([]model.UserCredential, error)
+}
+
+// TokenRepository covers auth_refresh_tokens, the server-side record of every
+// refresh token handed out.
+type TokenRepository interface {
+	Create(ctx context.Context, in model.RefreshTokenRecord) error
+	Get(ctx context.Context, jti string) (*model.RefreshTokenRecord, error)
+	// Rotate marks jti used and replaced by next. It reports false when the
+	// token was already used or revoked, which the caller treats as reuse.
+	Rotate(ctx context.Context, jti, next string, at time.Time) (bool, error)
+	// RevokeFamily revokes every live token of a family and returns how many
+	// it touched.
+	RevokeFamily(ctx context.Context, familyId, reason string, at time.Time) (int64, error)
+	// RevokeUser revokes every live token of a user.
+	RevokeUser(ctx context.Context, orgId, username, reason string, at time.Time) (int64, error)
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
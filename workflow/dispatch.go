@@ -0,0 +1,175 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mainPackage/model"
+	"mainPackage/store"
+	"slices"
+	"time"
+)
+
+var (
+	// ErrDispatchStep means a dispatch cannot move from its status to the
+	// one reported.
+	ErrDispatchStep = errors.New("dispatch step not allowed")
+	// ErrDispatchChanged means the dispatch moved while the step was being
+	// recorded.
+	ErrDispatchChanged = errors.New("dispatch changed, reload and retry")
+	// ErrUnitBusy means the unit already has an open dispatch.
+	ErrUnitBusy = errors.New("unit is already dispatched")
+	// ErrUnitInactive means the unit is switched off in mdm_units.
+	ErrUnitInactive = errors.New("unit is not active")
+)
+
+// dispatchSteps is the order a dispatch moves in. Released is not part of
+// it: a dispatch may be released from any step.
+var dispatchSteps = []string{
+	model.DispatchAssigned, model.DispatchAcknowledged, model.DispatchEnRoute,
+	model.DispatchOnScene, model.DispatchCompleted,
+}
+
+// ValidDispatchStep reports whether a dispatch at from may move to to. Steps
+// may be skipped but not repeated or undone; settleDispatch backfills the
+// case stamps of the skipped ones.
+func ValidDispatchStep(from, to string) bool {
+	if from == model.DispatchReleased {
+		return false
+	}
+	if to == model.DispatchReleased {
+		return true
+	}
+	i, j := slices.Index(dispatchSteps, from), slices.Index(dispatchSteps, to)
+	return i >= 0 && j > i
+}
+
+// UnitStatus is the mdm_units sttId of a unit whose dispatch is at step.
+func UnitStatus(step string) string {
+	if step == model.DispatchReleased {
+		return model.UnitAvailable
+	}
+	return step
+}
+
+// dispatchStamp is the case lifecycle stamp a dispatch step rolls up into.
+func dispatchStamp(step string) string {
+	switch step {
+	case model.DispatchAssigned:
+		return model.CaseStampCommanded
+	case model.DispatchAcknowledged:
+		return model.CaseStampReceived
+	case model.DispatchOnScene:
+		return model.CaseStampArrived
+	}
+	return ""
+}
+
+// dispatchStamps is every case stamp a dispatch at step implies, in order:
+// those of the steps before it, which may have been skipped, then its own.
+// A released dispatch implies none.
+func dispatchStamps(step string) []string {
+	var stamps []string
+	for _, s := range dispatchSteps[:slices.Index(dispatchSteps, step)+1] {
+		if stamp := dispatchStamp(s); stamp != "" {
+			stamps = append(stamps, stamp)
+		}
+	}
+	return stamps
+}
+
+// Assign opens a dispatch of unit on c, marks the unit assigned and stamps
+// commandedDate on c if no unit has yet. c is updated in place. Run it
+// inside Store.InTx.
+func Assign(ctx context.Context, repo *store.Store, orgId string, c *model.Case, unit *model.MmdUnit, username string, now time.Time) (*model.CaseDispatch, error) {
+	if !unit.Active {
+		return nil, fmt.Errorf("%w: %s", ErrUnitInactive, unit.UnitID)
+	}
+	open, err := repo.Dispatches.GetOpen(ctx, orgId, unit.UnitID)
+	if err == nil {
+		return nil, fmt.Errorf("%w: %s is on case %s", ErrUnitBusy, unit.UnitID, open.CaseID)
+	}
+	if !errors.Is(err, store.ErrNotFound) {
+		return nil, err
+	}
+	d := model.CaseDispatch{
+		OrgID:      orgId,
+		CaseID:     c.CaseID,
+		UnitID:     unit.UnitID,
+		Username:   unit.Username,
+		Status:     model.DispatchAssigned,
+		AssignedBy: username,
+		AssignedAt: now,
+	}
+	d.ID, err = repo.Dispatches.Create(ctx, orgId, username, d)
+	if err != nil {
+		return nil, err
+	}
+	if err := settleDispatch(ctx, repo, orgId, c, &d, username, now); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// Report moves d to step, records the step's time, updates the unit's
+// status and rolls the step up into c. Releasing is how a dispatch is
+// unassigned as well as how a unit stands down. d and c are updated in
+// place. Run it inside Store.InTx.
+func Report(ctx context.Context, repo *store.Store, orgId string, c *model.Case, d *model.CaseDispatch, step, username string, now time.Time) error {
+	if !ValidDispatchStep(d.Status, step) {
+		return fmt.Errorf("%w: %q to %q", ErrDispatchStep, d.Status, step)
+	}
+	from := d.Status
+	at := now
+	switch step {
+	case model.DispatchAcknowledged:
+		d.AcknowledgedAt = &at
+	case model.DispatchEnRoute:
+		d.EnRouteAt = &at
+	case model.DispatchOnScene:
+		d.OnSceneAt = &at
+	case model.DispatchCompleted:
+		d.CompletedAt = &at
+	case model.DispatchReleased:
+		d.ReleasedAt, d.ReleasedBy = &at, username
+	}
+	d.Status = step
+	if err := repo.Dispatches.Update(ctx, orgId, from, username, *d); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return ErrDispatchChanged
+		}
+		return err
+	}
+	return settleDispatch(ctx, repo, orgId, c, d, username, now)
+}
+
+// settleDispatch writes the unit's status for d and stamps the case fields
+// d's step and the steps before it map to, the first time any unit on the
+// case reaches them. A unit reporting on-scene straight from assigned thus
+// stamps receivedDate as well as arrivedDate.
+func settleDispatch(ctx context.Context, repo *store.Store, orgId string, c *model.Case, d *model.CaseDispatch, username string, now time.Time) error {
+	if err := repo.Units.SetStatus(ctx, orgId, d.UnitID, UnitStatus(d.Status), username); err != nil {
+		return err
+	}
+	stamped := false
+	for _, stamp := range dispatchStamps(d.Status) {
+		switch {
+		case stamp == model.CaseStampCommanded && c.CommandedDate != nil,
+			stamp == model.CaseStampReceived && c.ReceivedDate != nil,
+			stamp == model.CaseStampArrived && c.ArrivedDate != nil:
+			continue
+		}
+		ApplyStamp(c, stamp, username, now)
+		stamped = true
+	}
+	if !stamped {
+		return nil
+	}
+	if err := repo.Cases.SetStatus(ctx, orgId, c.StatusID, username, *c); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return ErrStatusChanged
+		}
+		return err
+	}
+	return nil
+}
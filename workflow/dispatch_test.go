@@ -0,0 +1,140 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"mainPackage/model"
+	"mainPackage/store"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestValidDispatchStep(t *testing.T) {
+	tests := []struct {
+		from, to string
+		want     bool
+	}{
+		{model.DispatchAssigned, model.DispatchAcknowledged, true},
+		{model.DispatchAcknowledged, model.DispatchEnRoute, true},
+		{model.DispatchEnRoute, model.DispatchOnScene, true},
+		{model.DispatchOnScene, model.DispatchCompleted, true},
+		{model.DispatchAssigned, model.DispatchOnScene, true},
+		{model.DispatchAssigned, model.DispatchReleased, true},
+		{model.DispatchCompleted, model.DispatchReleased, true},
+		{model.DispatchAssigned, model.DispatchAssigned, false},
+		{model.DispatchOnScene, model.DispatchAcknowledged, false},
+		{model.DispatchReleased, model.DispatchAssigned, false},
+		{model.DispatchReleased, model.DispatchReleased, false},
+		{model.DispatchAssigned, "parked", false},
+		{"parked", model.DispatchCompleted, false},
+	}
+	for _, tt := range tests {
+		if got := ValidDispatchStep(tt.from, tt.to); got != tt.want {
+			t.Errorf("ValidDispatchStep(%q, %q) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestDispatchStamp(t *testing.T) {
+	tests := map[string]string{
+		model.DispatchAssigned:     model.CaseStampCommanded,
+		model.DispatchAcknowledged: model.CaseStampReceived,
+		model.DispatchEnRoute:      "",
+		model.DispatchOnScene:      model.CaseStampArrived,
+		model.DispatchCompleted:    "",
+		model.DispatchReleased:     "",
+	}
+	for step, want := range tests {
+		if got := dispatchStamp(step); got != want {
+			t.Errorf("dispatchStamp(%q) = %q, want %q", step, got, want)
+		}
+	}
+}
+
+func TestDispatchStamps(t *testing.T) {
+	tests := map[string][]string{
+		model.DispatchAssigned:  {model.CaseStampCommanded},
+		model.DispatchOnScene:   {model.CaseStampCommanded, model.CaseStampReceived, model.CaseStampArrived},
+		model.DispatchCompleted: {model.CaseStampCommanded, model.CaseStampReceived, model.CaseStampArrived},
+		model.DispatchReleased:  nil,
+	}
+	for step, want := range tests {
+		if got := dispatchStamps(step); !slices.Equal(got, want) {
+			t.Errorf("dispatchStamps(%q) = %v, want %v", step, got, want)
+		}
+	}
+}
+
+func addUnit(t *testing.T, m *store.Memory, unitId string) *model.MmdUnit {
+	t.Helper()
+	ctx := context.Background()
+	if _, err := m.Units.Create(ctx, testOrg, "u", model.MmdUnitInsert{UnitID: unitId, Active: true}); err != nil {
+		t.Fatal(err)
+	}
+	unit, err := m.Units.GetByUnitID(ctx, testOrg, unitId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return unit
+}
+
+func TestReportSkippedStepBackfillsStamps(t *testing.T) {
+	ctx := context.Background()
+	m := newMemory(t)
+	c := caseWithStatus(t, m, "C1", "new")
+	unit := addUnit(t, m, "U1")
+	now := time.Now()
+
+	d, err := Assign(ctx, m.Store, testOrg, c, unit, "disp", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.CommandedDate == nil || c.ReceivedDate != nil {
+		t.Fatalf("after assign: commanded %v received %v", c.CommandedDate, c.ReceivedDate)
+	}
+	if err := Report(ctx, m.Store, testOrg, c, d, model.DispatchOnScene, "crew", now.Add(time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+
+	stored, err := m.Cases.GetByCaseID(ctx, testOrg, "C1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.ReceivedDate == nil || stored.ArrivedDate == nil {
+		t.Fatalf("on-scene straight from assigned: received %v arrived %v, want both", stored.ReceivedDate, stored.ArrivedDate)
+	}
+	if !stored.CommandedDate.Equal(now) {
+		t.Errorf("commandedDate moved to %v", stored.CommandedDate)
+	}
+	if stored.UserReceive != "crew" || stored.UserArrive != "crew" {
+		t.Errorf("stamp users = %q, %q, want crew", stored.UserReceive, stored.UserArrive)
+	}
+
+	unit, err = m.Units.GetByUnitID(ctx, testOrg, "U1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if unit.SttID != model.DispatchOnScene {
+		t.Errorf("unit status = %q, want on-scene", unit.SttID)
+	}
+
+	if err := Report(ctx, m.Store, testOrg, c, d, model.DispatchAcknowledged, "crew", now); !errors.Is(err, ErrDispatchStep) {
+		t.Errorf("going back a step: %v, want ErrDispatchStep", err)
+	}
+}
+
+func TestAssignBusyUnit(t *testing.T) {
+	ctx := context.Background()
+	m := newMemory(t)
+	unit := addUnit(t, m, "U1")
+	now := time.Now()
+
+	if _, err := Assign(ctx, m.Store, testOrg, caseWithStatus(t, m, "C1", "new"), unit, "disp", now); err != nil {
+		t.Fatal(err)
+	}
+	_, err := Assign(ctx, m.Store, testOrg, caseWithStatus(t, m, "C2", "new"), unit, "disp", now)
+	if !errors.Is(err, ErrUnitBusy) {
+		t.Errorf("assigning a dispatched unit: %v, want ErrUnitBusy", err)
+	}
+}
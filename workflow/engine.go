@@ -0,0 +1,153 @@
+// Package workflow moves cases through the nodes of a published workflow
+// version. The graph is read from the wf_nodes rows of that version: one row
+// per node and a single row holding every connection. Status changes follow
+// the organization's case_status_transitions instead, see ChangeStatus, and
+// units dispatched to a case follow a fixed lifecycle, see Assign and Report.
+package workflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mainPackage/model"
+	"mainPackage/store"
+	"strings"
+)
+
+var (
+	// ErrNoCurrentStage means the case was never placed on a workflow node.
+	ErrNoCurrentStage = errors.New("case has no current stage")
+	// ErrNoTransition means the current node has no outgoing edge.
+	ErrNoTransition = errors.New("no transition leaves the current node")
+	// ErrLabelRequired means several edges leave the current node and the
+	// caller did not say which one to take.
+	ErrLabelRequired = errors.New("several transitions leave the current node, a label is required")
+	// ErrUnknownLabel means no outgoing edge carries the requested label.
+	ErrUnknownLabel = errors.New("no transition with that label leaves the current node")
+	// ErrFormRequired means the current node carries a form that was not
+	// submitted for the case yet.
+	ErrFormRequired = errors.New("the current node's form must be submitted first")
+	// ErrStageChanged means the case left its node while the move was being
+	// made.
+	ErrStageChanged = errors.New("case stage changed, reload and retry")
+	// ErrNotPublished means the workflow only has a draft, which cases must
+	// not run on since it can still be edited.
+	ErrNotPublished = errors.New("workflow has no published version")
+	// ErrNoStartNode means the workflow version has no start node to open
+	// cases on.
+	ErrNoStartNode = errors.New("workflow version has no start node")
+)
+
+// Transition is one move made by Advance.
+type Transition struct {
+	From model.CurrentStage
+	Edge model.WorkFlowConnection
+	// To is the node the case now sits on, read from the same version.
+	To model.WfNode
+}
+
+// Next picks the edge leaving from. A single outgoing edge is taken when
+// label is empty; otherwise label must match one edge, ignoring case.
+func Next(edges []model.WorkFlowConnection, from, label string) (model.WorkFlowConnection, error) {
+	var out []model.WorkFlowConnection
+	for _, e := range edges {
+		if e.Source == from {
+			out = append(out, e)
+		}
+	}
+	if len(out) == 0 {
+		return model.WorkFlowConnection{}, ErrNoTransition
+	}
+	label = strings.TrimSpace(label)
+	if label == "" {
+		if len(out) > 1 {
+			return model.WorkFlowConnection{}, ErrLabelRequired
+		}
+		return out[0], nil
+	}
+	for _, e := range out {
+		if strings.EqualFold(strings.TrimSpace(e.Label), label) {
+			return e, nil
+		}
+	}
+	return model.WorkFlowConnection{}, fmt.Errorf("%w: %q", ErrUnknownLabel, label)
+}
+
+// Start returns the start node of a workflow version, the node a case
+// pinned to that version opens on.
+func Start(ctx context.Context, repo *store.Store, orgId, wfId, versions string) (*model.WfNode, error) {
+	nodes, err := repo.Workflows.ListNodes(ctx, orgId, wfId, versions)
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		return nil, err
+	}
+	for _, n := range nodes {
+		if n.Section == "nodes" && strings.ToLower(n.Type) == typeStart {
+			return repo.Workflows.GetVersionNode(ctx, orgId, wfId, versions, n.NodeId)
+		}
+	}
+	return nil, fmt.Errorf("%w: %s version %s", ErrNoStartNode, wfId, versions)
+}
+
+// Advance moves the case one edge forward within the workflow version it is
+// running on, then records the move in tix_case_stage_history. A node that
+// carries a form is left only once the form was submitted on it. Run it
+// inside Store.InTx so the stage and its history change together.
+func Advance(ctx context.Context, repo *store.Store, orgId, caseId, username, label string) (*Transition, error) {
+	current, err := repo.Cases.GetCurrentStage(ctx, orgId, caseId)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, ErrNoCurrentStage
+		}
+		return nil, err
+	}
+
+	if current.FormId != nil && *current.FormId != "" {
+		subs, err := repo.Forms.ListSubmissions(ctx, orgId, caseId, model.FormSubmissionFilter{
+			FormID: *current.FormId,
+			NodeID: current.NodeId,
+			Latest: true,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(subs) == 0 {
+			return nil, fmt.Errorf("%w: form %s", ErrFormRequired, *current.FormId)
+		}
+	}
+
+	edges, err := repo.Workflows.ListEdges(ctx, orgId, current.WfId, current.Versions)
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		return nil, err
+	}
+	edge, err := Next(edges, current.NodeId, label)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := repo.Workflows.GetVersionNode(ctx, orgId, current.WfId, current.Versions, edge.Target)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, fmt.Errorf("transition %s points at missing node %s", edge.Id, edge.Target)
+		}
+		return nil, err
+	}
+
+	if err := repo.Cases.UpdateCurrentStage(ctx, orgId, caseId, username, current.NodeId, *to); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, ErrStageChanged
+		}
+		return nil, err
+	}
+	_, err = repo.Cases.AddStageHistory(ctx, orgId, username, model.CaseStageHistoryInsert{
+		CaseID:     caseId,
+		WfID:       current.WfId,
+		Versions:   current.Versions,
+		FromNodeID: current.NodeId,
+		ToNodeID:   to.NodeID,
+		Label:      edge.Label,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Transition{From: *current, Edge: edge, To: *to}, nil
+}
@@ -0,0 +1,210 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"mainPackage/model"
+	"mainPackage/store"
+	"testing"
+)
+
+const testOrg = "o1"
+
+func newMemory(t *testing.T) *store.Memory {
+	t.Helper()
+	m := store.NewMemory()
+	m.AddOrganization(testOrg, "Org")
+	return m
+}
+
+// node builds a designer node; formId, when given, goes into its config.
+func node(id, typ, formId string) model.WorkFlowNode {
+	n := model.WorkFlowNode{Id: id, Type: typ}
+	if formId != "" {
+		config := map[string]interface{}{"formId": formId}
+		n.Data = &model.NodeConfig{Config: &config}
+	}
+	return n
+}
+
+func edge(id, source, target, label string) model.WorkFlowConnection {
+	return model.WorkFlowConnection{Id: id, Source: source, Target: target, Label: label}
+}
+
+// startCase publishes in as workflow wf and opens case caseId on its node
+// start.
+func startCase(t *testing.T, m *store.Memory, in model.WorkFlowInsert, caseId, start string) {
+	t.Helper()
+	ctx := context.Background()
+	if err := m.Workflows.Create(ctx, testOrg, "wf", "u", in); err != nil {
+		t.Fatal(err)
+	}
+	versions, err := m.Workflows.Publish(ctx, testOrg, "wf", "u")
+	if err != nil {
+		t.Fatal(err)
+	}
+	wfId := "wf"
+	if _, err := m.Cases.Create(ctx, testOrg, caseId, "u", model.CaseInsert{WfID: &wfId, WfVersions: &versions}); err != nil {
+		t.Fatal(err)
+	}
+	n, err := m.Workflows.GetVersionNode(ctx, testOrg, "wf", versions, start)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Cases.CreateCurrentStage(ctx, testOrg, caseId, "u", *n); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNext(t *testing.T) {
+	edges := []model.WorkFlowConnection{
+		edge("e1", "a", "b", ""),
+		edge("e2", "b", "c", "Approve"),
+		edge("e3", "b", "d", " Reject "),
+	}
+	tests := []struct {
+		name, from, label, want string
+		err                     error
+	}{
+		{name: "single edge without label", from: "a", want: "e1"},
+		{name: "single edge with another label", from: "a", label: "anything", err: ErrUnknownLabel},
+		{name: "label required", from: "b", err: ErrLabelRequired},
+		{name: "label matches ignoring case", from: "b", label: "approve", want: "e2"},
+		{name: "label matches ignoring spaces", from: "b", label: "reject", want: "e3"},
+		{name: "unknown label", from: "b", label: "escalate", err: ErrUnknownLabel},
+		{name: "no outgoing edge", from: "c", err: ErrNoTransition},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Next(edges, tt.from, tt.label)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Next error = %v, want %v", err, tt.err)
+			}
+			if got.Id != tt.want {
+				t.Errorf("Next = %q, want %q", got.Id, tt.want)
+			}
+		})
+	}
+}
+
+func TestStart(t *testing.T) {
+	ctx := context.Background()
+	m := newMemory(t)
+	publish := func(wfId string, in model.WorkFlowInsert) string {
+		t.Helper()
+		if err := m.Workflows.Create(ctx, testOrg, wfId, "u", in); err != nil {
+			t.Fatal(err)
+		}
+		versions, err := m.Workflows.Publish(ctx, testOrg, wfId, "u")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return versions
+	}
+
+	versions := publish("wf", model.WorkFlowInsert{
+		Nodes:       []model.WorkFlowNode{node("b", "process", ""), node("a", "Start", ""), node("c", "end", "")},
+		Connections: []model.WorkFlowConnection{edge("e1", "a", "b", ""), edge("e2", "b", "c", "")},
+	})
+	start, err := Start(ctx, m.Store, testOrg, "wf", versions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if start.NodeID != "a" || start.Versions != versions {
+		t.Errorf("Start = node %s of version %s, want a of %s", start.NodeID, start.Versions, versions)
+	}
+
+	versions = publish("headless", model.WorkFlowInsert{Nodes: []model.WorkFlowNode{node("b", "process", "")}})
+	if _, err := Start(ctx, m.Store, testOrg, "headless", versions); !errors.Is(err, ErrNoStartNode) {
+		t.Errorf("Start without a start node: %v, want ErrNoStartNode", err)
+	}
+}
+
+func TestAdvance(t *testing.T) {
+	ctx := context.Background()
+	m := newMemory(t)
+	startCase(t, m, model.WorkFlowInsert{
+		Nodes:       []model.WorkFlowNode{node("a", "start", ""), node("b", "process", ""), node("c", "end", "")},
+		Connections: []model.WorkFlowConnection{edge("e1", "a", "b", ""), edge("e2", "b", "c", "done")},
+	}, "C1", "a")
+
+	step, err := Advance(ctx, m.Store, testOrg, "C1", "u", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if step.From.NodeId != "a" || step.To.NodeID != "b" || step.Edge.Id != "e1" {
+		t.Errorf("Advance moved %s to %s over %s, want a to b over e1", step.From.NodeId, step.To.NodeID, step.Edge.Id)
+	}
+	if _, err := Advance(ctx, m.Store, testOrg, "C1", "u", "done"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Advance(ctx, m.Store, testOrg, "C1", "u", ""); !errors.Is(err, ErrNoTransition) {
+		t.Errorf("Advance from the end node: %v, want ErrNoTransition", err)
+	}
+
+	history, err := m.Cases.ListStageHistory(ctx, testOrg, "C1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 2 || history[0].ToNodeID != "b" || history[1].ToNodeID != "c" {
+		t.Errorf("stage history = %+v, want moves to b then c", history)
+	}
+
+	if _, err := Advance(ctx, m.Store, testOrg, "C2", "u", ""); !errors.Is(err, ErrNoCurrentStage) {
+		t.Errorf("Advance of a case without a stage: %v, want ErrNoCurrentStage", err)
+	}
+}
+
+func TestAdvanceRequiresForm(t *testing.T) {
+	ctx := context.Background()
+	m := newMemory(t)
+	startCase(t, m, model.WorkFlowInsert{
+		Nodes:       []model.WorkFlowNode{node("a", "start", "f1"), node("b", "end", "")},
+		Connections: []model.WorkFlowConnection{edge("e1", "a", "b", "")},
+	}, "C1", "a")
+
+	if _, err := Advance(ctx, m.Store, testOrg, "C1", "u", ""); !errors.Is(err, ErrFormRequired) {
+		t.Fatalf("Advance before the form was submitted: %v, want ErrFormRequired", err)
+	}
+	// A submission on another node does not count.
+	_, err := m.Forms.CreateSubmission(ctx, testOrg, "C1", "f1", "u", model.FormSubmissionInsert{NodeID: "x", Data: map[string]interface{}{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Advance(ctx, m.Store, testOrg, "C1", "u", ""); !errors.Is(err, ErrFormRequired) {
+		t.Fatalf("Advance with the form submitted elsewhere: %v, want ErrFormRequired", err)
+	}
+	_, err = m.Forms.CreateSubmission(ctx, testOrg, "C1", "f1", "u", model.FormSubmissionInsert{NodeID: "a", Data: map[string]interface{}{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Advance(ctx, m.Store, testOrg, "C1", "u", ""); err != nil {
+		t.Fatalf("Advance after the form was submitted: %v", err)
+	}
+}
+
+func TestUpdateCurrentStageFromNode(t *testing.T) {
+	ctx := context.Background()
+	m := newMemory(t)
+	startCase(t, m, model.WorkFlowInsert{
+		Nodes:       []model.WorkFlowNode{node("a", "start", ""), node("b", "process", ""), node("c", "end", "")},
+		Connections: []model.WorkFlowConnection{edge("e1", "a", "b", ""), edge("e2", "b", "c", "")},
+	}, "C1", "a")
+
+	// A concurrent move already took the case from a to b; a second move
+	// planned from a must not apply.
+	stage, err := m.Cases.GetCurrentStage(ctx, testOrg, "C1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := m.Workflows.GetVersionNode(ctx, testOrg, "wf", stage.Versions, "b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Cases.UpdateCurrentStage(ctx, testOrg, "C1", "u", "a", *b); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Cases.UpdateCurrentStage(ctx, testOrg, "C1", "u", "a", *b); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("UpdateCurrentStage from a stale node: %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,114 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mainPackage/model"
+	"mainPackage/store"
+	"time"
+)
+
+var (
+	// ErrStatusTransition means the organization does not allow the case
+	// to move from its status to the one asked for.
+	ErrStatusTransition = errors.New("status transition not allowed")
+	// ErrStatusChanged means the case's status changed while the move was
+	// being made.
+	ErrStatusChanged = errors.New("case status changed, reload and retry")
+	// ErrNoInitialStatus means the organization has deactivated its
+	// transition without fromStatusId, so new cases have no status to start
+	// in.
+	ErrNoInitialStatus = errors.New("organization has no initial case status")
+)
+
+// InitialStatus returns the status the organization's new cases start in:
+// the toStatusId of its transition without fromStatusId, or
+// model.CaseStatusNew when it has none. Migration 0026 seeds the transition
+// only for the organizations that existed then.
+func InitialStatus(ctx context.Context, repo *store.Store, orgId string) (string, error) {
+	t, err := repo.Transitions.Initial(ctx, orgId)
+	if errors.Is(err, store.ErrNotFound) {
+		return model.CaseStatusNew, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if !t.Active {
+		return "", ErrNoInitialStatus
+	}
+	return t.ToStatusID, nil
+}
+
+// ValidStamp reports whether stamp is one a transition may carry.
+func ValidStamp(stamp string) bool {
+	switch stamp {
+	case "", model.CaseStampCommanded, model.CaseStampReceived, model.CaseStampArrived,
+		model.CaseStampClosed, model.CaseStampReopened:
+		return true
+	}
+	return false
+}
+
+// ApplyStamp sets the lifecycle date and user stamp names on c, then
+// recomputes caseDuration.
+func ApplyStamp(c *model.Case, stamp, username string, now time.Time) {
+	at := now
+	switch stamp {
+	case model.CaseStampCommanded:
+		c.CommandedDate, c.UserCommand = &at, username
+	case model.CaseStampReceived:
+		c.ReceivedDate, c.UserReceive = &at, username
+	case model.CaseStampArrived:
+		c.ArrivedDate, c.UserArrive = &at, username
+	case model.CaseStampClosed:
+		c.ClosedDate, c.UserClose = &at, username
+	case model.CaseStampReopened:
+		c.ClosedDate, c.UserClose = nil, ""
+	}
+	c.CaseDuration = Duration(c, now)
+}
+
+// Duration is caseDuration in seconds: from createdDate, or createdAt when
+// that is unset, to closedDate, or to now while the case is open.
+func Duration(c *model.Case, now time.Time) int {
+	start := c.CreatedDate
+	if start == nil {
+		start = c.CreatedAt
+	}
+	if start == nil {
+		return 0
+	}
+	end := now
+	if c.ClosedDate != nil {
+		end = *c.ClosedDate
+	}
+	if end.Before(*start) {
+		return 0
+	}
+	return int(end.Sub(*start) / time.Second)
+}
+
+// ChangeStatus moves c to toStatusId through the organization's active
+// transition from its current status and stamps the lifecycle fields the
+// transition names. c is updated in place. Run it inside Store.InTx so the
+// history and notification of the move commit with it.
+func ChangeStatus(ctx context.Context, repo *store.Store, orgId string, c *model.Case, toStatusId, username string, now time.Time) (*model.CaseStatusTransition, error) {
+	t, err := repo.Transitions.Find(ctx, orgId, c.StatusID, toStatusId)
+	if errors.Is(err, store.ErrNotFound) || (err == nil && !t.Active) {
+		return nil, fmt.Errorf("%w: %q to %q", ErrStatusTransition, c.StatusID, toStatusId)
+	}
+	if err != nil {
+		return nil, err
+	}
+	from := c.StatusID
+	c.StatusID = toStatusId
+	ApplyStamp(c, t.Stamp, username, now)
+	if err := repo.Cases.SetStatus(ctx, orgId, from, username, *c); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, ErrStatusChanged
+		}
+		return nil, err
+	}
+	return t, nil
+}
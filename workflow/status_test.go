@@ -0,0 +1,153 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"mainPackage/model"
+	"mainPackage/store"
+	"testing"
+	"time"
+)
+
+func TestValidStamp(t *testing.T) {
+	for _, stamp := range []string{"", "commanded", "received", "arrived", "closed", "reopened"} {
+		if !ValidStamp(stamp) {
+			t.Errorf("ValidStamp(%q) = false", stamp)
+		}
+	}
+	for _, stamp := range []string{"open", "Closed", "created"} {
+		if ValidStamp(stamp) {
+			t.Errorf("ValidStamp(%q) = true", stamp)
+		}
+	}
+}
+
+func TestApplyStamp(t *testing.T) {
+	created := time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC)
+	c := model.Case{CreatedDate: &created}
+
+	ApplyStamp(&c, model.CaseStampCommanded, "cmd", created.Add(time.Minute))
+	ApplyStamp(&c, model.CaseStampReceived, "rcv", created.Add(2*time.Minute))
+	ApplyStamp(&c, model.CaseStampArrived, "arr", created.Add(10*time.Minute))
+	if c.CommandedDate == nil || c.UserCommand != "cmd" || c.ReceivedDate == nil || c.UserReceive != "rcv" ||
+		c.ArrivedDate == nil || c.UserArrive != "arr" {
+		t.Fatalf("stamps not set: %+v", c)
+	}
+	if c.CaseDuration != 600 {
+		t.Errorf("open case duration = %d, want 600", c.CaseDuration)
+	}
+
+	ApplyStamp(&c, model.CaseStampClosed, "cls", created.Add(time.Hour))
+	if c.ClosedDate == nil || !c.ClosedDate.Equal(created.Add(time.Hour)) || c.UserClose != "cls" {
+		t.Fatalf("closed stamp = %v %q", c.ClosedDate, c.UserClose)
+	}
+	if c.CaseDuration != 3600 {
+		t.Errorf("closed case duration = %d, want 3600", c.CaseDuration)
+	}
+
+	ApplyStamp(&c, model.CaseStampReopened, "rop", created.Add(2*time.Hour))
+	if c.ClosedDate != nil || c.UserClose != "" {
+		t.Errorf("reopen kept closedDate %v and userclose %q", c.ClosedDate, c.UserClose)
+	}
+	if c.CaseDuration != 7200 {
+		t.Errorf("reopened case duration = %d, want 7200", c.CaseDuration)
+	}
+}
+
+func TestDuration(t *testing.T) {
+	start := time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC)
+	later := start.Add(90 * time.Second)
+	if d := Duration(&model.Case{}, later); d != 0 {
+		t.Errorf("Duration without a start = %d, want 0", d)
+	}
+	if d := Duration(&model.Case{CreatedAt: &start}, later); d != 90 {
+		t.Errorf("Duration from createdAt = %d, want 90", d)
+	}
+	if d := Duration(&model.Case{CreatedDate: &later}, start); d != 0 {
+		t.Errorf("Duration ending before the start = %d, want 0", d)
+	}
+}
+
+// caseWithStatus creates case caseId in statusId and returns it as stored.
+func caseWithStatus(t *testing.T, m *store.Memory, caseId, statusId string) *model.Case {
+	t.Helper()
+	ctx := context.Background()
+	if _, err := m.Cases.Create(ctx, testOrg, caseId, "u", model.CaseInsert{StatusID: statusId}); err != nil {
+		t.Fatal(err)
+	}
+	c, err := m.Cases.GetByCaseID(ctx, testOrg, caseId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func addTransition(t *testing.T, m *store.Memory, from, to, stamp string, active bool) {
+	t.Helper()
+	in := model.CaseStatusTransitionInput{FromStatusID: from, ToStatusID: to, Stamp: stamp, Active: active}
+	if _, err := m.Transitions.Create(context.Background(), testOrg, "u", in); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestChangeStatus(t *testing.T) {
+	ctx := context.Background()
+	m := newMemory(t)
+	addTransition(t, m, "new", "closed", model.CaseStampClosed, true)
+	addTransition(t, m, "new", "hold", "", false)
+	now := time.Now()
+
+	c := caseWithStatus(t, m, "C1", "new")
+	if _, err := ChangeStatus(ctx, m.Store, testOrg, c, "hold", "u", now); !errors.Is(err, ErrStatusTransition) {
+		t.Errorf("inactive transition: %v, want ErrStatusTransition", err)
+	}
+	if _, err := ChangeStatus(ctx, m.Store, testOrg, c, "assigned", "u", now); !errors.Is(err, ErrStatusTransition) {
+		t.Errorf("missing transition: %v, want ErrStatusTransition", err)
+	}
+
+	stale := *c
+	tr, err := ChangeStatus(ctx, m.Store, testOrg, c, "closed", "u", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tr.Stamp != model.CaseStampClosed {
+		t.Errorf("transition stamp = %q", tr.Stamp)
+	}
+	stored, err := m.Cases.GetByCaseID(ctx, testOrg, "C1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.StatusID != "closed" || stored.ClosedDate == nil || stored.UserClose != "u" {
+		t.Errorf("stored case = status %q closed %v by %q", stored.StatusID, stored.ClosedDate, stored.UserClose)
+	}
+
+	// The copy read before the change still says "new".
+	if _, err := ChangeStatus(ctx, m.Store, testOrg, &stale, "closed", "u", now); !errors.Is(err, ErrStatusChanged) {
+		t.Errorf("change from a stale status: %v, want ErrStatusChanged", err)
+	}
+}
+
+func TestInitialStatus(t *testing.T) {
+	ctx := context.Background()
+	m := newMemory(t)
+	if status, err := InitialStatus(ctx, m.Store, testOrg); err != nil || status != model.CaseStatusNew {
+		t.Errorf("InitialStatus without a transition = %q, %v, want %s", status, err, model.CaseStatusNew)
+	}
+	addTransition(t, m, "", "new", "", false)
+	if _, err := InitialStatus(ctx, m.Store, testOrg); !errors.Is(err, ErrNoInitialStatus) {
+		t.Errorf("InitialStatus with an inactive transition: %v, want ErrNoInitialStatus", err)
+	}
+
+	t0, err := m.Transitions.Initial(ctx, testOrg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	in := model.CaseStatusTransitionInput{ToStatusID: "new", Active: true}
+	if err := m.Transitions.Update(ctx, testOrg, t0.ID, "u", in); err != nil {
+		t.Fatal(err)
+	}
+	status, err := InitialStatus(ctx, m.Store, testOrg)
+	if err != nil || status != "new" {
+		t.Errorf("InitialStatus = %q, %v, want new", status, err)
+	}
+}
@@ -0,0 +1,310 @@
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mainPackage/model"
+	"mainPackage/store"
+	"sort"
+	"strings"
+)
+
+// ErrInvalid is returned by Publish when the draft has validation errors.
+var ErrInvalid = errors.New("workflow has validation errors")
+
+// Node types the designer emits that the validator gives meaning to.
+const (
+	typeStart = "start"
+	typeEnd   = "end"
+)
+
+// Actions GetFormByCaseSubType looks for on process nodes: S001 opens the
+// case and S002 carries the form the caller fills in.
+const (
+	actionOpen = "S001"
+	actionForm = "S002"
+)
+
+// Validate checks a workflow graph before it is published. formExists
+// reports whether a formId is present in form_builder; its error aborts the
+// run.
+func Validate(in model.WorkFlowInsert, formExists func(formId string) (bool, error)) (model.WorkflowValidation, error) {
+	v := &validation{}
+
+	nodes := map[string]model.WorkFlowNode{}
+	var starts []string
+	hasEnd := false
+	for _, n := range in.Nodes {
+		if n.Id == "" {
+			v.fail("node_id_missing", "", "", "node has no id")
+			continue
+		}
+		if _, dup := nodes[n.Id]; dup {
+			v.fail("node_id_duplicate", n.Id, "", "node id %s is used more than once", n.Id)
+			continue
+		}
+		nodes[n.Id] = n
+		switch strings.ToLower(n.Type) {
+		case typeStart:
+			starts = append(starts, n.Id)
+		case typeEnd:
+			hasEnd = true
+		}
+	}
+	switch {
+	case len(starts) == 0:
+		v.fail("start_missing", "", "", "workflow has no start node")
+	case len(starts) > 1:
+		for _, id := range starts[1:] {
+			v.fail("start_duplicate", id, "", "workflow has more than one start node")
+		}
+	}
+	if !hasEnd {
+		v.fail("end_missing", "", "", "workflow has no end node")
+	}
+
+	ids := sortedIds(nodes)
+	out := map[string][]model.WorkFlowConnection{}
+	inDegree := map[string]int{}
+	edgeIds := map[string]bool{}
+	for _, e := range in.Connections {
+		if e.Id != "" {
+			if edgeIds[e.Id] {
+				v.warn("edge_id_duplicate", "", e.Id, "edge id %s is used more than once", e.Id)
+			}
+			edgeIds[e.Id] = true
+		}
+		_, okSource := nodes[e.Source]
+		_, okTarget := nodes[e.Target]
+		if !okSource {
+			v.fail("edge_source_unknown", "", e.Id, "edge source %q is not a node", e.Source)
+		}
+		if !okTarget {
+			v.fail("edge_target_unknown", "", e.Id, "edge target %q is not a node", e.Target)
+		}
+		if !okSource || !okTarget {
+			continue
+		}
+		if e.Source == e.Target {
+			v.fail("edge_self_loop", e.Source, e.Id, "edge leads from node %s back to itself", e.Source)
+			continue
+		}
+		out[e.Source] = append(out[e.Source], e)
+		inDegree[e.Target]++
+	}
+
+	for _, id := range ids {
+		n := nodes[id]
+		edges := out[id]
+		switch strings.ToLower(n.Type) {
+		case typeStart:
+			if inDegree[id] > 0 {
+				v.warn("start_has_incoming", id, "", "start node %s has incoming edges", id)
+			}
+		case typeEnd:
+			if len(edges) > 0 {
+				v.fail("end_has_outgoing", id, "", "end node %s has outgoing edges", id)
+			}
+			continue
+		}
+		if len(edges) == 0 {
+			v.fail("dead_end", id, "", "node %s has no outgoing edge and is not an end node", id)
+		}
+		if len(edges) > 1 {
+			labels := map[string]bool{}
+			for _, e := range edges {
+				label := strings.ToLower(strings.TrimSpace(e.Label))
+				if label == "" {
+					v.fail("edge_label_missing", id, e.Id, "node %s has several outgoing edges, each needs a label", id)
+				} else if labels[label] {
+					v.fail("edge_label_duplicate", id, e.Id, "node %s has two outgoing edges labelled %q", id, e.Label)
+				}
+				labels[label] = true
+			}
+		}
+	}
+
+	if len(starts) == 1 {
+		reached := walk(starts[0], func(id string) []string {
+			var next []string
+			for _, e := range out[id] {
+				next = append(next, e.Target)
+			}
+			return next
+		})
+		for _, id := range ids {
+			if !reached[id] {
+				v.fail("unreachable", id, "", "node %s cannot be reached from the start node", id)
+			}
+		}
+		// Loops are allowed as long as some node on them leads on to an end
+		// node; a case that enters a loop or branch without one never
+		// finishes.
+		incoming := map[string][]string{}
+		for src, edges := range out {
+			for _, e := range edges {
+				incoming[e.Target] = append(incoming[e.Target], src)
+			}
+		}
+		finishes := map[string]bool{}
+		for _, id := range ids {
+			if strings.ToLower(nodes[id].Type) == typeEnd {
+				for k := range walk(id, func(id string) []string { return incoming[id] }) {
+					finishes[k] = true
+				}
+			}
+		}
+		for _, id := range ids {
+			if reached[id] && !finishes[id] && len(out[id]) > 0 {
+				v.fail("no_path_to_end", id, "", "node %s never leads to an end node", id)
+			}
+		}
+	}
+
+	var opens, forms int
+	for _, id := range ids {
+		config := nodeConfig(nodes[id])
+		formId, _ := config["formId"].(string)
+		if formId != "" {
+			ok, err := formExists(formId)
+			if err != nil {
+				return model.WorkflowValidation{}, err
+			}
+			if !ok {
+				v.fail("form_unknown", id, "", "node %s uses form %s which does not exist", id, formId)
+			}
+		}
+		action, _ := config["action"].(string)
+		switch action {
+		case actionOpen:
+			opens++
+		case actionForm:
+			forms++
+			if formId == "" {
+				v.fail("form_missing", id, "", "node %s has action %s but no formId", id, actionForm)
+			}
+		}
+		if (action == actionOpen || action == actionForm) && !strings.EqualFold(nodes[id].Type, "process") {
+			v.warn("action_not_process", id, "", "node %s has action %s but is not a process node, so it will not be found", id, action)
+		}
+	}
+	if opens == 0 {
+		v.warn("action_missing", "", "", "no process node has action %s", actionOpen)
+	}
+	if forms == 0 {
+		v.warn("action_missing", "", "", "no process node has action %s, the case sub type will have no form", actionForm)
+	}
+
+	return v.report(), nil
+}
+
+type validation struct {
+	errors, warnings []model.WorkflowIssue
+}
+
+func (v *validation) fail(code, nodeId, edgeId, format string, args ...interface{}) {
+	v.errors = append(v.errors, model.WorkflowIssue{
+		Code: code, Message: fmt.Sprintf(format, args...), NodeID: nodeId, EdgeID: edgeId,
+	})
+}
+
+func (v *validation) warn(code, nodeId, edgeId, format string, args ...interface{}) {
+	v.warnings = append(v.warnings, model.WorkflowIssue{
+		Code: code, Message: fmt.Sprintf(format, args...), NodeID: nodeId, EdgeID: edgeId,
+	})
+}
+
+func (v *validation) report() model.WorkflowValidation {
+	r := model.WorkflowValidation{
+		Valid:    len(v.errors) == 0,
+		Errors:   v.errors,
+		Warnings: v.warnings,
+	}
+	if r.Errors == nil {
+		r.Errors = []model.WorkflowIssue{}
+	}
+	if r.Warnings == nil {
+		r.Warnings = []model.WorkflowIssue{}
+	}
+	return r
+}
+
+// walk returns every node reachable from start through next, start included.
+func walk(start string, next func(id string) []string) map[string]bool {
+	seen := map[string]bool{start: true}
+	queue := []string{start}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, n := range next(id) {
+			if !seen[n] {
+				seen[n] = true
+				queue = append(queue, n)
+			}
+		}
+	}
+	return seen
+}
+
+func sortedIds(nodes map[string]model.WorkFlowNode) []string {
+	ids := make([]string, 0, len(nodes))
+	for id := range nodes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func nodeConfig(n model.WorkFlowNode) map[string]interface{} {
+	if n.Data == nil || n.Data.Config == nil {
+		return nil
+	}
+	return *n.Data.Config
+}
+
+// Check validates in against the organization's forms.
+func Check(ctx context.Context, repo *store.Store, orgId string, in model.WorkFlowInsert) (model.WorkflowValidation, error) {
+	known := map[string]bool{}
+	return Validate(in, func(formId string) (bool, error) {
+		if ok, seen := known[formId]; seen {
+			return ok, nil
+		}
+		ok, err := repo.Forms.Exists(ctx, orgId, formId)
+		if err != nil {
+			return false, err
+		}
+		known[formId] = ok
+		return ok, nil
+	})
+}
+
+// Publish validates the draft and, when it has no errors, freezes it into
+// the next numbered version. The report is returned either way; ErrInvalid
+// means publishing was refused because of it.
+func Publish(ctx context.Context, repo *store.Store, orgId, wfId, username string) (string, model.WorkflowValidation, error) {
+	current, err := repo.Workflows.Get(ctx, orgId, wfId, "")
+	if err != nil {
+		return "", model.WorkflowValidation{}, err
+	}
+	// The stored draft comes back as loose JSON; decode it into the shape
+	// the designer saves.
+	raw, err := json.Marshal(current)
+	if err != nil {
+		return "", model.WorkflowValidation{}, err
+	}
+	var in model.WorkFlowInsert
+	if err := json.Unmarshal(raw, &in); err != nil {
+		return "", model.WorkflowValidation{}, err
+	}
+	report, err := Check(ctx, repo, orgId, in)
+	if err != nil {
+		return "", report, err
+	}
+	if !report.Valid {
+		return "", report, ErrInvalid
+	}
+	versions, err := repo.Workflows.Publish(ctx, orgId, wfId, username)
+	return versions, report, err
+}
@@ -0,0 +1,134 @@
+package workflow
+
+import (
+	"errors"
+	"mainPackage/model"
+	"slices"
+	"testing"
+)
+
+// process builds a process node carrying action and, when given, formId.
+func process(id, action, formId string) model.WorkFlowNode {
+	config := map[string]interface{}{"action": action}
+	if formId != "" {
+		config["formId"] = formId
+	}
+	return model.WorkFlowNode{Id: id, Type: "process", Data: &model.NodeConfig{Config: &config}}
+}
+
+func knownForms(ids ...string) func(string) (bool, error) {
+	return func(formId string) (bool, error) { return slices.Contains(ids, formId), nil }
+}
+
+func codes(issues []model.WorkflowIssue) []string {
+	var list []string
+	for _, i := range issues {
+		list = append(list, i.Code)
+	}
+	return list
+}
+
+// validFlow is start -> open -> form -> end.
+func validFlow() model.WorkFlowInsert {
+	return model.WorkFlowInsert{
+		Nodes: []model.WorkFlowNode{
+			node("start", "start", ""), process("open", actionOpen, ""), process("form", actionForm, "f1"),
+			node("end", "end", ""),
+		},
+		Connections: []model.WorkFlowConnection{
+			edge("e1", "start", "open", ""), edge("e2", "open", "form", ""), edge("e3", "form", "end", ""),
+		},
+	}
+}
+
+func TestValidateValid(t *testing.T) {
+	r, err := Validate(validFlow(), knownForms("f1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !r.Valid || len(r.Errors) != 0 || len(r.Warnings) != 0 {
+		t.Errorf("Validate = %+v, want valid without issues", r)
+	}
+}
+
+func TestValidateErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		edit func(in *model.WorkFlowInsert)
+		want string
+	}{
+		{"no start", func(in *model.WorkFlowInsert) { in.Nodes[0].Type = "process" }, "start_missing"},
+		{"two starts", func(in *model.WorkFlowInsert) { in.Nodes[1].Type = "start" }, "start_duplicate"},
+		{"no end", func(in *model.WorkFlowInsert) { in.Nodes[3].Type = "process" }, "end_missing"},
+		{"duplicate node", func(in *model.WorkFlowInsert) { in.Nodes = append(in.Nodes, node("end", "end", "")) }, "node_id_duplicate"},
+		{"unknown target", func(in *model.WorkFlowInsert) { in.Connections[2].Target = "nowhere" }, "edge_target_unknown"},
+		{"self loop", func(in *model.WorkFlowInsert) {
+			in.Connections = append(in.Connections, edge("e4", "open", "open", "again"))
+		}, "edge_self_loop"},
+		{"dead end", func(in *model.WorkFlowInsert) { in.Connections = in.Connections[:2] }, "dead_end"},
+		{"unlabelled branch", func(in *model.WorkFlowInsert) {
+			in.Connections = append(in.Connections, edge("e4", "open", "end", ""))
+		}, "edge_label_missing"},
+		{"unreachable node", func(in *model.WorkFlowInsert) {
+			in.Nodes = append(in.Nodes, process("orphan", "", ""))
+			in.Connections = append(in.Connections, edge("e4", "orphan", "end", ""))
+		}, "unreachable"},
+		{"loop without exit", func(in *model.WorkFlowInsert) {
+			in.Nodes = append(in.Nodes, process("x", "", ""), process("y", "", ""))
+			in.Connections[1].Label = "on"
+			in.Connections = append(in.Connections, edge("e4", "open", "x", "loop"), edge("e5", "x", "y", ""),
+				edge("e6", "y", "x", ""))
+		}, "no_path_to_end"},
+		{"unknown form", func(in *model.WorkFlowInsert) { (*in.Nodes[2].Data.Config)["formId"] = "f9" }, "form_unknown"},
+		{"form action without form", func(in *model.WorkFlowInsert) { delete(*in.Nodes[2].Data.Config, "formId") }, "form_missing"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := validFlow()
+			tt.edit(&in)
+			r, err := Validate(in, knownForms("f1"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if r.Valid || !slices.Contains(codes(r.Errors), tt.want) {
+				t.Errorf("Validate errors = %v, want %s", codes(r.Errors), tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateWarnings(t *testing.T) {
+	in := validFlow()
+	in.Nodes[1] = node("open", "process", "")
+	r, err := Validate(in, knownForms("f1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !r.Valid {
+		t.Errorf("Validate errors = %v, want none", codes(r.Errors))
+	}
+	if !slices.Contains(codes(r.Warnings), "action_missing") {
+		t.Errorf("Validate warnings = %v, want action_missing", codes(r.Warnings))
+	}
+}
+
+func TestValidateLoopWithExit(t *testing.T) {
+	in := validFlow()
+	in.Connections[2].Label = "done"
+	in.Connections = append(in.Connections, edge("e4", "form", "open", "redo"))
+	r, err := Validate(in, knownForms("f1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !r.Valid {
+		t.Errorf("Validate errors = %v, want a loop that can reach the end to pass", codes(r.Errors))
+	}
+}
+
+func TestValidateFormLookupError(t *testing.T) {
+	boom := errors.New("boom")
+	_, err := Validate(validFlow(), func(string) (bool, error) { return false, boom })
+	if !errors.Is(err, boom) {
+		t.Errorf("Validate error = %v, want the lookup error", err)
+	}
+}